@@ -42,9 +42,28 @@ type Alarm struct {
 	AcknowledgedBy string    `json:"acknowledged_by,omitempty"`
 	ResolvedAt     time.Time `json:"resolved_at,omitempty"`
 	ResolvedBy     string    `json:"resolved_by,omitempty"`
+	Occurrences    uint64    `json:"occurrences"`
+	LastOccurredAt time.Time `json:"last_occurred_at,omitempty"`
+	EscalatedAt    time.Time `json:"escalated_at,omitempty"`
 	Metadata       Metadata  `json:"metadata,omitempty"`
 }
 
+// Lifecycle configures how active alarms are resolved and escalated.
+type Lifecycle struct {
+	// ResolveOnClear clears the active alarm once the rule reports the condition cleared.
+	// Otherwise, cleared conditions are ignored and the alarm stays active.
+	ResolveOnClear bool
+	// QuietPeriod clears the active alarms that did not occur for the given period.
+	// Zero disables resolving quiet alarms.
+	QuietPeriod time.Duration
+	// EscalateAfter raises the severity of the active alarms that stay unacknowledged
+	// for the given period since they were created or last escalated.
+	// Zero disables escalation.
+	EscalateAfter time.Duration
+	// EscalationStep is the severity added on every escalation, up to SeverityMax.
+	EscalationStep uint8
+}
+
 type AlarmsPage struct {
 	Offset uint64  `json:"offset"`
 	Limit  uint64  `json:"limit"`
@@ -111,13 +130,57 @@ type Service interface {
 	ViewAlarm(ctx context.Context, session authn.Session, id string) (Alarm, error)
 	ListAlarms(ctx context.Context, session authn.Session, pm PageMetadata) (AlarmsPage, error)
 	DeleteAlarm(ctx context.Context, session authn.Session, id string) error
+	// AlarmStats returns the alarm counts and mean times to acknowledge and
+	// resolve, grouped as requested by the query.
+	AlarmStats(ctx context.Context, session authn.Session, q StatsQuery) (Stats, error)
+
+	AddNotificationPolicy(ctx context.Context, session authn.Session, policy NotificationPolicy) (NotificationPolicy, error)
+	ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (NotificationPolicy, error)
+	UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy NotificationPolicy) (NotificationPolicy, error)
+	ListNotificationPolicies(ctx context.Context, session authn.Session, pm NotificationPoliciesPageMeta) (NotificationPoliciesPage, error)
+	RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error
+
+	// StartScheduler periodically clears quiet alarms and escalates
+	// unacknowledged alarms, as configured by the service Lifecycle,
+	// and notifies the escalation chains of the notification policies.
+	StartScheduler(ctx context.Context) error
 }
 
 type Repository interface {
+	// CreateAlarm saves the active alarm. If an active alarm with the same rule, channel,
+	// client, subtopic and measurement exists, its occurrences are incremented and its
+	// value is updated instead.
 	CreateAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
+	// ClearAlarm clears the active alarm with the same rule, channel, client, subtopic
+	// and measurement, unless the alarm occurred after the alarm is cleared.
+	ClearAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
+	// UpdateAlarmValue updates the value of the active alarm with the same rule, channel,
+	// client, subtopic and measurement, unless the alarm occurred after the given alarm.
+	UpdateAlarmValue(ctx context.Context, alarm Alarm) (Alarm, error)
+	// ClearQuietAlarms clears the active alarms that last occurred before the given time.
+	ClearQuietAlarms(ctx context.Context, before, clearedAt time.Time) ([]Alarm, error)
+	// EscalateAlarms raises the severity of the active, unacknowledged alarms
+	// created or last escalated before the given time by step.
+	EscalateAlarms(ctx context.Context, before, escalatedAt time.Time, step uint8) ([]Alarm, error)
 	UpdateAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
 	ViewAlarm(ctx context.Context, alarmID, domainID string) (Alarm, error)
 	ListAllAlarms(ctx context.Context, pm PageMetadata) (AlarmsPage, error)
 	ListUserAlarms(ctx context.Context, userID string, pm PageMetadata) (AlarmsPage, error)
 	DeleteAlarm(ctx context.Context, id string) error
+	AlarmStats(ctx context.Context, q StatsQuery) (Stats, error)
+	UserAlarmStats(ctx context.Context, userID string, q StatsQuery) (Stats, error)
+
+	AddNotificationPolicy(ctx context.Context, policy NotificationPolicy) (NotificationPolicy, error)
+	ViewNotificationPolicy(ctx context.Context, domainID, id string) (NotificationPolicy, error)
+	UpdateNotificationPolicy(ctx context.Context, policy NotificationPolicy) (NotificationPolicy, error)
+	ListNotificationPolicies(ctx context.Context, pm NotificationPoliciesPageMeta) (NotificationPoliciesPage, error)
+	RemoveNotificationPolicy(ctx context.Context, domainID, id string) error
+	// MatchNotificationPolicies returns the policies of the domain for the alarm severity.
+	MatchNotificationPolicies(ctx context.Context, domainID string, severity uint8) ([]NotificationPolicy, error)
+	// ListDueEscalations returns the escalation levels of the active, unacknowledged
+	// alarms that are due at the given time and were not notified yet.
+	ListDueEscalations(ctx context.Context, due time.Time) ([]Escalation, error)
+	// AddEscalation records that the escalation level was notified. It fails with
+	// conflict if the level was already notified.
+	AddEscalation(ctx context.Context, escalation Escalation, notifiedAt time.Time) error
 }
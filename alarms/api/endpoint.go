@@ -83,6 +83,29 @@ func listAlarmsEndpoint(svc alarms.Service) endpoint.Endpoint {
 	}
 }
 
+func alarmStatsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(alarmStatsReq)
+		if err := req.validate(); err != nil {
+			return alarmStatsRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return alarmStatsRes{}, svcerr.ErrAuthorization
+		}
+
+		stats, err := svc.AlarmStats(ctx, session, req.StatsQuery)
+		if err != nil {
+			return alarmStatsRes{}, err
+		}
+
+		return alarmStatsRes{
+			Stats: stats,
+		}, nil
+	}
+}
+
 func deleteAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(alarmReq)
@@ -102,3 +125,116 @@ func deleteAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
 		return alarmRes{deleted: true}, nil
 	}
 }
+
+func addNotificationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(notificationPolicyReq)
+		if err := req.validate(); err != nil {
+			return notificationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return notificationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.AddNotificationPolicy(ctx, session, req.NotificationPolicy)
+		if err != nil {
+			return notificationPolicyRes{}, err
+		}
+
+		return notificationPolicyRes{
+			NotificationPolicy: policy,
+			created:            true,
+		}, nil
+	}
+}
+
+func viewNotificationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(notificationPolicyIDReq)
+		if err := req.validate(); err != nil {
+			return notificationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return notificationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.ViewNotificationPolicy(ctx, session, req.id)
+		if err != nil {
+			return notificationPolicyRes{}, err
+		}
+
+		return notificationPolicyRes{
+			NotificationPolicy: policy,
+		}, nil
+	}
+}
+
+func updateNotificationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(notificationPolicyReq)
+		if err := req.validate(); err != nil {
+			return notificationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return notificationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.UpdateNotificationPolicy(ctx, session, req.NotificationPolicy)
+		if err != nil {
+			return notificationPolicyRes{}, err
+		}
+
+		return notificationPolicyRes{
+			NotificationPolicy: policy,
+		}, nil
+	}
+}
+
+func listNotificationPoliciesEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listNotificationPoliciesReq)
+		if err := req.validate(); err != nil {
+			return notificationPoliciesPageRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return notificationPoliciesPageRes{}, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListNotificationPolicies(ctx, session, req.NotificationPoliciesPageMeta)
+		if err != nil {
+			return notificationPoliciesPageRes{}, err
+		}
+
+		return notificationPoliciesPageRes{
+			NotificationPoliciesPage: page,
+		}, nil
+	}
+}
+
+func removeNotificationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(notificationPolicyIDReq)
+		if err := req.validate(); err != nil {
+			return notificationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return notificationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveNotificationPolicy(ctx, session, req.id); err != nil {
+			return notificationPolicyRes{}, err
+		}
+
+		return notificationPolicyRes{deleted: true}, nil
+	}
+}
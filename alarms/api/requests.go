@@ -57,3 +57,47 @@ func (req listAlarmsReq) validate() error {
 
 	return nil
 }
+
+type alarmStatsReq struct {
+	alarms.StatsQuery
+}
+
+func (req alarmStatsReq) validate() error {
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() && req.CreatedTo.Before(req.CreatedFrom) {
+		return errors.New("created_to must not be before created_from")
+	}
+
+	return req.StatsQuery.Validate()
+}
+
+type notificationPolicyReq struct {
+	alarms.NotificationPolicy `json:",inline"`
+}
+
+func (req notificationPolicyReq) validate() error {
+	return req.NotificationPolicy.Validate()
+}
+
+type notificationPolicyIDReq struct {
+	id string
+}
+
+func (req notificationPolicyIDReq) validate() error {
+	if req.id == "" {
+		return errors.New("missing notification policy id")
+	}
+
+	return nil
+}
+
+type listNotificationPoliciesReq struct {
+	alarms.NotificationPoliciesPageMeta
+}
+
+func (req listNotificationPoliciesReq) validate() error {
+	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -14,6 +14,9 @@ import (
 var (
 	_ supermq.Response = (*alarmRes)(nil)
 	_ supermq.Response = (*alarmsPageRes)(nil)
+	_ supermq.Response = (*alarmStatsRes)(nil)
+	_ supermq.Response = (*notificationPolicyRes)(nil)
+	_ supermq.Response = (*notificationPoliciesPageRes)(nil)
 )
 
 type alarmRes struct {
@@ -68,3 +71,67 @@ func (res alarmsPageRes) Code() int {
 func (res alarmsPageRes) Empty() bool {
 	return false
 }
+
+type alarmStatsRes struct {
+	alarms.Stats `json:",inline"`
+}
+
+func (res alarmStatsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmStatsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmStatsRes) Empty() bool {
+	return false
+}
+
+type notificationPolicyRes struct {
+	alarms.NotificationPolicy `json:",inline"`
+	created                   bool
+	deleted                   bool
+}
+
+func (res notificationPolicyRes) Headers() map[string]string {
+	switch {
+	case res.created:
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/alarms/notification-policies/%s", res.DomainID, res.ID),
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+func (res notificationPolicyRes) Code() int {
+	switch {
+	case res.created:
+		return http.StatusCreated
+	case res.deleted:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+func (res notificationPolicyRes) Empty() bool {
+	return res.deleted
+}
+
+type notificationPoliciesPageRes struct {
+	alarms.NotificationPoliciesPage `json:",inline"`
+}
+
+func (res notificationPoliciesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res notificationPoliciesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res notificationPoliciesPageRes) Empty() bool {
+	return false
+}
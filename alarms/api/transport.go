@@ -42,6 +42,46 @@ func MakeHandler(svc alarms.Service, logger *slog.Logger, idp supermq.IDProvider
 				api.EncodeResponse,
 				opts...,
 			), "list_alarms").ServeHTTP)
+			r.Get("/stats", otelhttp.NewHandler(kithttp.NewServer(
+				alarmStatsEndpoint(svc),
+				decodeAlarmStatsReq,
+				api.EncodeResponse,
+				opts...,
+			), "alarm_stats").ServeHTTP)
+			r.Route("/notification-policies", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					addNotificationPolicyEndpoint(svc),
+					decodeNotificationPolicyReq,
+					api.EncodeResponse,
+					opts...,
+				), "add_notification_policy").ServeHTTP)
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listNotificationPoliciesEndpoint(svc),
+					decodeListNotificationPoliciesReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_notification_policies").ServeHTTP)
+				r.Route("/{policyID}", func(r chi.Router) {
+					r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+						viewNotificationPolicyEndpoint(svc),
+						decodeNotificationPolicyIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "view_notification_policy").ServeHTTP)
+					r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+						updateNotificationPolicyEndpoint(svc),
+						decodeNotificationPolicyReq,
+						api.EncodeResponse,
+						opts...,
+					), "update_notification_policy").ServeHTTP)
+					r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+						removeNotificationPolicyEndpoint(svc),
+						decodeNotificationPolicyIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "remove_notification_policy").ServeHTTP)
+				})
+			})
 			r.Route("/{alarmID}", func(r chi.Router) {
 				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 					viewAlarmEndpoint(svc),
@@ -185,6 +225,29 @@ func decodeListAlarmsReq(_ context.Context, r *http.Request) (any, error) {
 	}, nil
 }
 
+func decodeAlarmStatsReq(ctx context.Context, r *http.Request) (any, error) {
+	req, err := decodeListAlarmsReq(ctx, r)
+	if err != nil {
+		return alarmStatsReq{}, err
+	}
+	groupBy, err := apiutil.ReadStringQuery(r, "group_by", alarms.GroupByStatus)
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	interval, err := apiutil.ReadStringQuery(r, "interval", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return alarmStatsReq{
+		StatsQuery: alarms.StatsQuery{
+			PageMetadata: req.(listAlarmsReq).PageMetadata,
+			GroupBy:      groupBy,
+			Interval:     interval,
+		},
+	}, nil
+}
+
 func decodeAlarmReq(_ context.Context, r *http.Request) (any, error) {
 	return alarmReq{
 		Alarm: alarms.Alarm{
@@ -207,3 +270,42 @@ func decodeUpdateAlarmReq(_ context.Context, r *http.Request) (any, error) {
 
 	return req, nil
 }
+
+func decodeNotificationPolicyReq(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return notificationPolicyReq{}, apiutil.ErrUnsupportedContentType
+	}
+
+	req := notificationPolicyReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req.NotificationPolicy); err != nil {
+		return notificationPolicyReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	req.NotificationPolicy.ID = chi.URLParam(r, "policyID")
+
+	return req, nil
+}
+
+func decodeNotificationPolicyIDReq(_ context.Context, r *http.Request) (any, error) {
+	return notificationPolicyIDReq{
+		id: chi.URLParam(r, "policyID"),
+	}, nil
+}
+
+func decodeListNotificationPoliciesReq(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return listNotificationPoliciesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return listNotificationPoliciesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listNotificationPoliciesReq{
+		NotificationPoliciesPageMeta: alarms.NotificationPoliciesPageMeta{
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
@@ -21,6 +21,10 @@ var (
 	errDomainUpdateAlarms = errors.New("not authorized to update alarms in domain")
 	errDomainDeleteAlarms = errors.New("not authorized to delete alarms in domain")
 	errDomainViewAlarms   = errors.New("not authorized to view alarms in domain")
+
+	errDomainUpdateNotificationPolicies = errors.New("not authorized to update alarm notification policies in domain")
+	errDomainDeleteNotificationPolicies = errors.New("not authorized to delete alarm notification policies in domain")
+	errDomainViewNotificationPolicies   = errors.New("not authorized to view alarm notification policies in domain")
 )
 
 type authorizationMiddleware struct {
@@ -47,6 +51,10 @@ func (am *authorizationMiddleware) CreateAlarm(ctx context.Context, alarm alarms
 	return am.svc.CreateAlarm(ctx, alarm)
 }
 
+func (am *authorizationMiddleware) StartScheduler(ctx context.Context) error {
+	return am.svc.StartScheduler(ctx)
+}
+
 func (am *authorizationMiddleware) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
 	if len(alarm.Metadata) > 0 {
 		if err := am.authorize(ctx, operations.OpUpdateAlarm, session, policies.DomainType, session.DomainID); err != nil {
@@ -111,6 +119,22 @@ func (am *authorizationMiddleware) ListAlarms(ctx context.Context, session authn
 	return am.svc.ListAlarms(ctx, session, pm)
 }
 
+func (am *authorizationMiddleware) AlarmStats(ctx context.Context, session authn.Session, q alarms.StatsQuery) (alarms.Stats, error) {
+	if q.DomainID == "" {
+		q.DomainID = session.DomainID
+	}
+
+	switch err := am.checkSuperAdmin(ctx, session); {
+	case err == nil:
+		session.SuperAdmin = true
+	case errors.Contains(err, svcerr.ErrSuperAdminAction):
+	default:
+		return alarms.Stats{}, err
+	}
+
+	return am.svc.AlarmStats(ctx, session, q)
+}
+
 func (am *authorizationMiddleware) ViewAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
 	if err := am.authorize(ctx, operations.OpViewAlarm, session, policies.DomainType, session.DomainID); err != nil {
 		return alarms.Alarm{}, errors.Wrap(errDomainViewAlarms, err)
@@ -119,6 +143,46 @@ func (am *authorizationMiddleware) ViewAlarm(ctx context.Context, session authn.
 	return am.svc.ViewAlarm(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) AddNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	if err := am.authorize(ctx, operations.OpAddNotificationPolicy, session, policies.DomainType, session.DomainID); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(errDomainUpdateNotificationPolicies, err)
+	}
+
+	return am.svc.AddNotificationPolicy(ctx, session, policy)
+}
+
+func (am *authorizationMiddleware) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (alarms.NotificationPolicy, error) {
+	if err := am.authorize(ctx, operations.OpViewNotificationPolicy, session, policies.DomainType, session.DomainID); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(errDomainViewNotificationPolicies, err)
+	}
+
+	return am.svc.ViewNotificationPolicy(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	if err := am.authorize(ctx, operations.OpUpdateNotificationPolicy, session, policies.DomainType, session.DomainID); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(errDomainUpdateNotificationPolicies, err)
+	}
+
+	return am.svc.UpdateNotificationPolicy(ctx, session, policy)
+}
+
+func (am *authorizationMiddleware) ListNotificationPolicies(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	if err := am.authorize(ctx, operations.OpListNotificationPolicies, session, policies.DomainType, session.DomainID); err != nil {
+		return alarms.NotificationPoliciesPage{}, errors.Wrap(errDomainViewNotificationPolicies, err)
+	}
+
+	return am.svc.ListNotificationPolicies(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error {
+	if err := am.authorize(ctx, operations.OpRemoveNotificationPolicy, session, policies.DomainType, session.DomainID); err != nil {
+		return errors.Wrap(errDomainDeleteNotificationPolicies, err)
+	}
+
+	return am.svc.RemoveNotificationPolicy(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) authorize(ctx context.Context, op permissions.Operation, session authn.Session, objType, obj string) error {
 	perm, err := am.entitiesOps.GetPermission(operations.EntityType, op)
 	if err != nil {
@@ -136,6 +136,30 @@ func (lm *loggingMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return lm.service.ListAlarms(ctx, session, pm)
 }
 
+func (lm *loggingMiddleware) AlarmStats(ctx context.Context, session authn.Session, q alarms.StatsQuery) (stats alarms.Stats, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("group_by", q.GroupBy),
+			slog.String("interval", q.Interval),
+			slog.String("rule_id", q.RuleID),
+			slog.String("domain_id", q.DomainID),
+			slog.String("channel_id", q.ChannelID),
+			slog.String("client_id", q.ClientID),
+			slog.String("status", q.Status.String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Alarm stats failed", args...)
+			return
+		}
+		lm.logger.Info("Alarm stats completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.AlarmStats(ctx, session, q)
+}
+
 func (lm *loggingMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -153,3 +177,125 @@ func (lm *loggingMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return lm.service.DeleteAlarm(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) StartScheduler(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Start scheduler failed", args...)
+			return
+		}
+		lm.logger.Info("Start scheduler completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.StartScheduler(ctx)
+}
+
+func (lm *loggingMiddleware) AddNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (np alarms.NotificationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("notification_policy",
+				slog.String("id", np.ID),
+				slog.String("name", policy.Name),
+				slog.Uint64("min_severity", uint64(policy.MinSeverity)),
+				slog.Uint64("max_severity", uint64(policy.MaxSeverity)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add notification policy failed", args...)
+			return
+		}
+		lm.logger.Info("Add notification policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.AddNotificationPolicy(ctx, session, policy)
+}
+
+func (lm *loggingMiddleware) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (np alarms.NotificationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View notification policy failed", args...)
+			return
+		}
+		lm.logger.Info("View notification policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ViewNotificationPolicy(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (np alarms.NotificationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("notification_policy",
+				slog.String("id", policy.ID),
+				slog.String("name", policy.Name),
+				slog.Uint64("min_severity", uint64(policy.MinSeverity)),
+				slog.Uint64("max_severity", uint64(policy.MaxSeverity)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update notification policy failed", args...)
+			return
+		}
+		lm.logger.Info("Update notification policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.UpdateNotificationPolicy(ctx, session, policy)
+}
+
+func (lm *loggingMiddleware) ListNotificationPolicies(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (page alarms.NotificationPoliciesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Int("offset", int(pm.Offset)),
+			slog.Int("limit", int(pm.Limit)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List notification policies failed", args...)
+			return
+		}
+		lm.logger.Info("List notification policies completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ListNotificationPolicies(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove notification policy failed", args...)
+			return
+		}
+		lm.logger.Info("Remove notification policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.RemoveNotificationPolicy(ctx, session, id)
+}
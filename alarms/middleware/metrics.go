@@ -64,6 +64,15 @@ func (mm *metricsMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return mm.service.ListAlarms(ctx, session, pm)
 }
 
+func (mm *metricsMiddleware) AlarmStats(ctx context.Context, session authn.Session, q alarms.StatsQuery) (alarms.Stats, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "alarm_stats").Add(1)
+		mm.latency.With("method", "alarm_stats").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.AlarmStats(ctx, session, q)
+}
+
 func (mm *metricsMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "delete_alarm").Add(1)
@@ -72,3 +81,57 @@ func (mm *metricsMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return mm.service.DeleteAlarm(ctx, session, id)
 }
+
+func (mm *metricsMiddleware) StartScheduler(ctx context.Context) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_scheduler").Add(1)
+		mm.latency.With("method", "start_scheduler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.StartScheduler(ctx)
+}
+
+func (mm *metricsMiddleware) AddNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_notification_policy").Add(1)
+		mm.latency.With("method", "add_notification_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.AddNotificationPolicy(ctx, session, policy)
+}
+
+func (mm *metricsMiddleware) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (alarms.NotificationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_notification_policy").Add(1)
+		mm.latency.With("method", "view_notification_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ViewNotificationPolicy(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_notification_policy").Add(1)
+		mm.latency.With("method", "update_notification_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.UpdateNotificationPolicy(ctx, session, policy)
+}
+
+func (mm *metricsMiddleware) ListNotificationPolicies(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_notification_policies").Add(1)
+		mm.latency.With("method", "list_notification_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListNotificationPolicies(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_notification_policy").Add(1)
+		mm.latency.With("method", "remove_notification_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.RemoveNotificationPolicy(ctx, session, id)
+}
@@ -74,6 +74,16 @@ func (tm *tracingMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return tm.svc.ListAlarms(ctx, session, pm)
 }
 
+func (tm *tracingMiddleware) AlarmStats(ctx context.Context, session authn.Session, q alarms.StatsQuery) (alarms.Stats, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "alarm_stats", trace.WithAttributes(
+		attribute.String("group_by", q.GroupBy),
+		attribute.String("interval", q.Interval),
+	))
+	defer span.End()
+
+	return tm.svc.AlarmStats(ctx, session, q)
+}
+
 func (tm *tracingMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
 	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "delete_alarm", trace.WithAttributes(
 		attribute.String("id", id),
@@ -82,3 +92,58 @@ func (tm *tracingMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return tm.svc.DeleteAlarm(ctx, session, id)
 }
+
+func (tm *tracingMiddleware) StartScheduler(ctx context.Context) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "start_scheduler")
+	defer span.End()
+
+	return tm.svc.StartScheduler(ctx)
+}
+
+func (tm *tracingMiddleware) AddNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "add_notification_policy", trace.WithAttributes(
+		attribute.String("id", policy.ID),
+		attribute.String("name", policy.Name),
+	))
+	defer span.End()
+
+	return tm.svc.AddNotificationPolicy(ctx, session, policy)
+}
+
+func (tm *tracingMiddleware) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (alarms.NotificationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_notification_policy", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewNotificationPolicy(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "update_notification_policy", trace.WithAttributes(
+		attribute.String("id", policy.ID),
+		attribute.String("name", policy.Name),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateNotificationPolicy(ctx, session, policy)
+}
+
+func (tm *tracingMiddleware) ListNotificationPolicies(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_notification_policies", trace.WithAttributes(
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListNotificationPolicies(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "remove_notification_policy", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveNotificationPolicy(ctx, session, id)
+}
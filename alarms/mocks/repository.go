@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/alarms"
 	mock "github.com/stretchr/testify/mock"
@@ -42,6 +43,341 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// AddEscalation provides a mock function for the type Repository
+func (_mock *Repository) AddEscalation(ctx context.Context, escalation alarms.Escalation, notifiedAt time.Time) error {
+	ret := _mock.Called(ctx, escalation, notifiedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEscalation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Escalation, time.Time) error); ok {
+		r0 = returnFunc(ctx, escalation, notifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddEscalation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddEscalation'
+type Repository_AddEscalation_Call struct {
+	*mock.Call
+}
+
+// AddEscalation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - escalation alarms.Escalation
+//   - notifiedAt time.Time
+func (_e *Repository_Expecter) AddEscalation(ctx interface{}, escalation interface{}, notifiedAt interface{}) *Repository_AddEscalation_Call {
+	return &Repository_AddEscalation_Call{Call: _e.mock.On("AddEscalation", ctx, escalation, notifiedAt)}
+}
+
+func (_c *Repository_AddEscalation_Call) Run(run func(ctx context.Context, escalation alarms.Escalation, notifiedAt time.Time)) *Repository_AddEscalation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Escalation
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Escalation)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddEscalation_Call) Return(err error) *Repository_AddEscalation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddEscalation_Call) RunAndReturn(run func(ctx context.Context, escalation alarms.Escalation, notifiedAt time.Time) error) *Repository_AddEscalation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddNotificationPolicy provides a mock function for the type Repository
+func (_mock *Repository) AddNotificationPolicy(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPolicy) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPolicy) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.NotificationPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_AddNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddNotificationPolicy'
+type Repository_AddNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// AddNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy alarms.NotificationPolicy
+func (_e *Repository_Expecter) AddNotificationPolicy(ctx interface{}, policy interface{}) *Repository_AddNotificationPolicy_Call {
+	return &Repository_AddNotificationPolicy_Call{Call: _e.mock.On("AddNotificationPolicy", ctx, policy)}
+}
+
+func (_c *Repository_AddNotificationPolicy_Call) Run(run func(ctx context.Context, policy alarms.NotificationPolicy)) *Repository_AddNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.NotificationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(alarms.NotificationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Repository_AddNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
+	return _c
+}
+
+func (_c *Repository_AddNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error)) *Repository_AddNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmStats provides a mock function for the type Repository
+func (_mock *Repository) AlarmStats(ctx context.Context, q alarms.StatsQuery) (alarms.Stats, error) {
+	ret := _mock.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmStats")
+	}
+
+	var r0 alarms.Stats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.StatsQuery) (alarms.Stats, error)); ok {
+		return returnFunc(ctx, q)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.StatsQuery) alarms.Stats); ok {
+		r0 = returnFunc(ctx, q)
+	} else {
+		r0 = ret.Get(0).(alarms.Stats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.StatsQuery) error); ok {
+		r1 = returnFunc(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_AlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmStats'
+type Repository_AlarmStats_Call struct {
+	*mock.Call
+}
+
+// AlarmStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q alarms.StatsQuery
+func (_e *Repository_Expecter) AlarmStats(ctx interface{}, q interface{}) *Repository_AlarmStats_Call {
+	return &Repository_AlarmStats_Call{Call: _e.mock.On("AlarmStats", ctx, q)}
+}
+
+func (_c *Repository_AlarmStats_Call) Run(run func(ctx context.Context, q alarms.StatsQuery)) *Repository_AlarmStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.StatsQuery
+		if args[1] != nil {
+			arg1 = args[1].(alarms.StatsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AlarmStats_Call) Return(stats alarms.Stats, err error) *Repository_AlarmStats_Call {
+	_c.Call.Return(stats, err)
+	return _c
+}
+
+func (_c *Repository_AlarmStats_Call) RunAndReturn(run func(ctx context.Context, q alarms.StatsQuery) (alarms.Stats, error)) *Repository_AlarmStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearAlarm provides a mock function for the type Repository
+func (_mock *Repository) ClearAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClearAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearAlarm'
+type Repository_ClearAlarm_Call struct {
+	*mock.Call
+}
+
+// ClearAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) ClearAlarm(ctx interface{}, alarm interface{}) *Repository_ClearAlarm_Call {
+	return &Repository_ClearAlarm_Call{Call: _e.mock.On("ClearAlarm", ctx, alarm)}
+}
+
+func (_c *Repository_ClearAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_ClearAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClearAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_ClearAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_ClearAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_ClearAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearQuietAlarms provides a mock function for the type Repository
+func (_mock *Repository) ClearQuietAlarms(ctx context.Context, before time.Time, clearedAt time.Time) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, before, clearedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearQuietAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, before, clearedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, before, clearedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, before, clearedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClearQuietAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearQuietAlarms'
+type Repository_ClearQuietAlarms_Call struct {
+	*mock.Call
+}
+
+// ClearQuietAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - clearedAt time.Time
+func (_e *Repository_Expecter) ClearQuietAlarms(ctx interface{}, before interface{}, clearedAt interface{}) *Repository_ClearQuietAlarms_Call {
+	return &Repository_ClearQuietAlarms_Call{Call: _e.mock.On("ClearQuietAlarms", ctx, before, clearedAt)}
+}
+
+func (_c *Repository_ClearQuietAlarms_Call) Run(run func(ctx context.Context, before time.Time, clearedAt time.Time)) *Repository_ClearQuietAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClearQuietAlarms_Call) Return(alarms []alarms.Alarm, err error) *Repository_ClearQuietAlarms_Call {
+	_c.Call.Return(alarms, err)
+	return _c
+}
+
+func (_c *Repository_ClearQuietAlarms_Call) RunAndReturn(run func(ctx context.Context, before time.Time, clearedAt time.Time) ([]alarms.Alarm, error)) *Repository_ClearQuietAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAlarm provides a mock function for the type Repository
 func (_mock *Repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
 	ret := _mock.Called(ctx, alarm)
@@ -58,86 +394,570 @@ func (_mock *Repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (a
 	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
 		r0 = returnFunc(ctx, alarm)
 	} else {
-		r0 = ret.Get(0).(alarms.Alarm)
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
+type Repository_CreateAlarm_Call struct {
+	*mock.Call
+}
+
+// CreateAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) CreateAlarm(ctx interface{}, alarm interface{}) *Repository_CreateAlarm_Call {
+	return &Repository_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm)}
+}
+
+func (_c *Repository_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_CreateAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_CreateAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_CreateAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlarm provides a mock function for the type Repository
+func (_mock *Repository) DeleteAlarm(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlarm")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
+type Repository_DeleteAlarm_Call struct {
+	*mock.Call
+}
+
+// DeleteAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) DeleteAlarm(ctx interface{}, id interface{}) *Repository_DeleteAlarm_Call {
+	return &Repository_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, id)}
+}
+
+func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id string)) *Repository_DeleteAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteAlarm_Call) Return(err error) *Repository_DeleteAlarm_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_DeleteAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EscalateAlarms provides a mock function for the type Repository
+func (_mock *Repository) EscalateAlarms(ctx context.Context, before time.Time, escalatedAt time.Time, step uint8) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, before, escalatedAt, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EscalateAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint8) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, before, escalatedAt, step)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint8) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, before, escalatedAt, step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint8) error); ok {
+		r1 = returnFunc(ctx, before, escalatedAt, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_EscalateAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EscalateAlarms'
+type Repository_EscalateAlarms_Call struct {
+	*mock.Call
+}
+
+// EscalateAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - escalatedAt time.Time
+//   - step uint8
+func (_e *Repository_Expecter) EscalateAlarms(ctx interface{}, before interface{}, escalatedAt interface{}, step interface{}) *Repository_EscalateAlarms_Call {
+	return &Repository_EscalateAlarms_Call{Call: _e.mock.On("EscalateAlarms", ctx, before, escalatedAt, step)}
+}
+
+func (_c *Repository_EscalateAlarms_Call) Run(run func(ctx context.Context, before time.Time, escalatedAt time.Time, step uint8)) *Repository_EscalateAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 uint8
+		if args[3] != nil {
+			arg3 = args[3].(uint8)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_EscalateAlarms_Call) Return(alarms []alarms.Alarm, err error) *Repository_EscalateAlarms_Call {
+	_c.Call.Return(alarms, err)
+	return _c
+}
+
+func (_c *Repository_EscalateAlarms_Call) RunAndReturn(run func(ctx context.Context, before time.Time, escalatedAt time.Time, step uint8) ([]alarms.Alarm, error)) *Repository_EscalateAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllAlarms provides a mock function for the type Repository
+func (_mock *Repository) ListAllAlarms(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllAlarms")
+	}
+
+	var r0 alarms.AlarmsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) alarms.AlarmsPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.AlarmsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListAllAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllAlarms'
+type Repository_ListAllAlarms_Call struct {
+	*mock.Call
+}
+
+// ListAllAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.PageMetadata
+func (_e *Repository_Expecter) ListAllAlarms(ctx interface{}, pm interface{}) *Repository_ListAllAlarms_Call {
+	return &Repository_ListAllAlarms_Call{Call: _e.mock.On("ListAllAlarms", ctx, pm)}
+}
+
+func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata)) *Repository_ListAllAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(alarms.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListAllAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListAllAlarms_Call {
+	_c.Call.Return(alarmsPage, err)
+	return _c
+}
+
+func (_c *Repository_ListAllAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListAllAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDueEscalations provides a mock function for the type Repository
+func (_mock *Repository) ListDueEscalations(ctx context.Context, due time.Time) ([]alarms.Escalation, error) {
+	ret := _mock.Called(ctx, due)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueEscalations")
+	}
+
+	var r0 []alarms.Escalation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]alarms.Escalation, error)); ok {
+		return returnFunc(ctx, due)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []alarms.Escalation); ok {
+		r0 = returnFunc(ctx, due)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Escalation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, due)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListDueEscalations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueEscalations'
+type Repository_ListDueEscalations_Call struct {
+	*mock.Call
+}
+
+// ListDueEscalations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - due time.Time
+func (_e *Repository_Expecter) ListDueEscalations(ctx interface{}, due interface{}) *Repository_ListDueEscalations_Call {
+	return &Repository_ListDueEscalations_Call{Call: _e.mock.On("ListDueEscalations", ctx, due)}
+}
+
+func (_c *Repository_ListDueEscalations_Call) Run(run func(ctx context.Context, due time.Time)) *Repository_ListDueEscalations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListDueEscalations_Call) Return(escalations []alarms.Escalation, err error) *Repository_ListDueEscalations_Call {
+	_c.Call.Return(escalations, err)
+	return _c
+}
+
+func (_c *Repository_ListDueEscalations_Call) RunAndReturn(run func(ctx context.Context, due time.Time) ([]alarms.Escalation, error)) *Repository_ListDueEscalations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotificationPolicies provides a mock function for the type Repository
+func (_mock *Repository) ListNotificationPolicies(ctx context.Context, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotificationPolicies")
+	}
+
+	var r0 alarms.NotificationPoliciesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPoliciesPageMeta) alarms.NotificationPoliciesPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPoliciesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.NotificationPoliciesPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListNotificationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotificationPolicies'
+type Repository_ListNotificationPolicies_Call struct {
+	*mock.Call
+}
+
+// ListNotificationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.NotificationPoliciesPageMeta
+func (_e *Repository_Expecter) ListNotificationPolicies(ctx interface{}, pm interface{}) *Repository_ListNotificationPolicies_Call {
+	return &Repository_ListNotificationPolicies_Call{Call: _e.mock.On("ListNotificationPolicies", ctx, pm)}
+}
+
+func (_c *Repository_ListNotificationPolicies_Call) Run(run func(ctx context.Context, pm alarms.NotificationPoliciesPageMeta)) *Repository_ListNotificationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.NotificationPoliciesPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.NotificationPoliciesPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListNotificationPolicies_Call) Return(notificationPoliciesPage alarms.NotificationPoliciesPage, err error) *Repository_ListNotificationPolicies_Call {
+	_c.Call.Return(notificationPoliciesPage, err)
+	return _c
+}
+
+func (_c *Repository_ListNotificationPolicies_Call) RunAndReturn(run func(ctx context.Context, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error)) *Repository_ListNotificationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserAlarms provides a mock function for the type Repository
+func (_mock *Repository) ListUserAlarms(ctx context.Context, userID string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	ret := _mock.Called(ctx, userID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserAlarms")
+	}
+
+	var r0 alarms.AlarmsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
+		return returnFunc(ctx, userID, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.PageMetadata) alarms.AlarmsPage); ok {
+		r0 = returnFunc(ctx, userID, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.AlarmsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, alarms.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, userID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListUserAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserAlarms'
+type Repository_ListUserAlarms_Call struct {
+	*mock.Call
+}
+
+// ListUserAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - pm alarms.PageMetadata
+func (_e *Repository_Expecter) ListUserAlarms(ctx interface{}, userID interface{}, pm interface{}) *Repository_ListUserAlarms_Call {
+	return &Repository_ListUserAlarms_Call{Call: _e.mock.On("ListUserAlarms", ctx, userID, pm)}
+}
+
+func (_c *Repository_ListUserAlarms_Call) Run(run func(ctx context.Context, userID string, pm alarms.PageMetadata)) *Repository_ListUserAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 alarms.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(alarms.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListUserAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListUserAlarms_Call {
+	_c.Call.Return(alarmsPage, err)
+	return _c
+}
+
+func (_c *Repository_ListUserAlarms_Call) RunAndReturn(run func(ctx context.Context, userID string, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListUserAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MatchNotificationPolicies provides a mock function for the type Repository
+func (_mock *Repository) MatchNotificationPolicies(ctx context.Context, domainID string, severity uint8) ([]alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, domainID, severity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchNotificationPolicies")
+	}
+
+	var r0 []alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint8) ([]alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, domainID, severity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint8) []alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, domainID, severity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.NotificationPolicy)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint8) error); ok {
+		r1 = returnFunc(ctx, domainID, severity)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
-type Repository_CreateAlarm_Call struct {
+// Repository_MatchNotificationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MatchNotificationPolicies'
+type Repository_MatchNotificationPolicies_Call struct {
 	*mock.Call
 }
 
-// CreateAlarm is a helper method to define mock.On call
+// MatchNotificationPolicies is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alarm alarms.Alarm
-func (_e *Repository_Expecter) CreateAlarm(ctx interface{}, alarm interface{}) *Repository_CreateAlarm_Call {
-	return &Repository_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm)}
+//   - domainID string
+//   - severity uint8
+func (_e *Repository_Expecter) MatchNotificationPolicies(ctx interface{}, domainID interface{}, severity interface{}) *Repository_MatchNotificationPolicies_Call {
+	return &Repository_MatchNotificationPolicies_Call{Call: _e.mock.On("MatchNotificationPolicies", ctx, domainID, severity)}
 }
 
-func (_c *Repository_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_CreateAlarm_Call {
+func (_c *Repository_MatchNotificationPolicies_Call) Run(run func(ctx context.Context, domainID string, severity uint8)) *Repository_MatchNotificationPolicies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.Alarm
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(alarms.Alarm)
+			arg1 = args[1].(string)
+		}
+		var arg2 uint8
+		if args[2] != nil {
+			arg2 = args[2].(uint8)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_CreateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Repository_MatchNotificationPolicies_Call) Return(notificationPolicys []alarms.NotificationPolicy, err error) *Repository_MatchNotificationPolicies_Call {
+	_c.Call.Return(notificationPolicys, err)
 	return _c
 }
 
-func (_c *Repository_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_CreateAlarm_Call {
+func (_c *Repository_MatchNotificationPolicies_Call) RunAndReturn(run func(ctx context.Context, domainID string, severity uint8) ([]alarms.NotificationPolicy, error)) *Repository_MatchNotificationPolicies_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAlarm provides a mock function for the type Repository
-func (_mock *Repository) DeleteAlarm(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// RemoveNotificationPolicy provides a mock function for the type Repository
+func (_mock *Repository) RemoveNotificationPolicy(ctx context.Context, domainID string, id string) error {
+	ret := _mock.Called(ctx, domainID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAlarm")
+		panic("no return value specified for RemoveNotificationPolicy")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, domainID, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Repository_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
-type Repository_DeleteAlarm_Call struct {
+// Repository_RemoveNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveNotificationPolicy'
+type Repository_RemoveNotificationPolicy_Call struct {
 	*mock.Call
 }
 
-// DeleteAlarm is a helper method to define mock.On call
+// RemoveNotificationPolicy is a helper method to define mock.On call
 //   - ctx context.Context
+//   - domainID string
 //   - id string
-func (_e *Repository_Expecter) DeleteAlarm(ctx interface{}, id interface{}) *Repository_DeleteAlarm_Call {
-	return &Repository_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, id)}
+func (_e *Repository_Expecter) RemoveNotificationPolicy(ctx interface{}, domainID interface{}, id interface{}) *Repository_RemoveNotificationPolicy_Call {
+	return &Repository_RemoveNotificationPolicy_Call{Call: _e.mock.On("RemoveNotificationPolicy", ctx, domainID, id)}
 }
 
-func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id string)) *Repository_DeleteAlarm_Call {
+func (_c *Repository_RemoveNotificationPolicy_Call) Run(run func(ctx context.Context, domainID string, id string)) *Repository_RemoveNotificationPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -147,71 +967,76 @@ func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_DeleteAlarm_Call) Return(err error) *Repository_DeleteAlarm_Call {
+func (_c *Repository_RemoveNotificationPolicy_Call) Return(err error) *Repository_RemoveNotificationPolicy_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_DeleteAlarm_Call {
+func (_c *Repository_RemoveNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) error) *Repository_RemoveNotificationPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAllAlarms provides a mock function for the type Repository
-func (_mock *Repository) ListAllAlarms(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
-	ret := _mock.Called(ctx, pm)
+// UpdateAlarm provides a mock function for the type Repository
+func (_mock *Repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAllAlarms")
+		panic("no return value specified for UpdateAlarm")
 	}
 
-	var r0 alarms.AlarmsPage
+	var r0 alarms.Alarm
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
-		return returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) alarms.AlarmsPage); ok {
-		r0 = returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm)
 	} else {
-		r0 = ret.Get(0).(alarms.AlarmsPage)
+		r0 = ret.Get(0).(alarms.Alarm)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata) error); ok {
-		r1 = returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_ListAllAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllAlarms'
-type Repository_ListAllAlarms_Call struct {
+// Repository_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
+type Repository_UpdateAlarm_Call struct {
 	*mock.Call
 }
 
-// ListAllAlarms is a helper method to define mock.On call
+// UpdateAlarm is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pm alarms.PageMetadata
-func (_e *Repository_Expecter) ListAllAlarms(ctx interface{}, pm interface{}) *Repository_ListAllAlarms_Call {
-	return &Repository_ListAllAlarms_Call{Call: _e.mock.On("ListAllAlarms", ctx, pm)}
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) UpdateAlarm(ctx interface{}, alarm interface{}) *Repository_UpdateAlarm_Call {
+	return &Repository_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, alarm)}
 }
 
-func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata)) *Repository_ListAllAlarms_Call {
+func (_c *Repository_UpdateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_UpdateAlarm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.PageMetadata
+		var arg1 alarms.Alarm
 		if args[1] != nil {
-			arg1 = args[1].(alarms.PageMetadata)
+			arg1 = args[1].(alarms.Alarm)
 		}
 		run(
 			arg0,
@@ -221,150 +1046,216 @@ func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm al
 	return _c
 }
 
-func (_c *Repository_ListAllAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListAllAlarms_Call {
-	_c.Call.Return(alarmsPage, err)
+func (_c *Repository_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_UpdateAlarm_Call {
+	_c.Call.Return(alarm1, err)
 	return _c
 }
 
-func (_c *Repository_ListAllAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListAllAlarms_Call {
+func (_c *Repository_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_UpdateAlarm_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListUserAlarms provides a mock function for the type Repository
-func (_mock *Repository) ListUserAlarms(ctx context.Context, userID string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
-	ret := _mock.Called(ctx, userID, pm)
+// UpdateAlarmValue provides a mock function for the type Repository
+func (_mock *Repository) UpdateAlarmValue(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUserAlarms")
+		panic("no return value specified for UpdateAlarmValue")
 	}
 
-	var r0 alarms.AlarmsPage
+	var r0 alarms.Alarm
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
-		return returnFunc(ctx, userID, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.PageMetadata) alarms.AlarmsPage); ok {
-		r0 = returnFunc(ctx, userID, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm)
 	} else {
-		r0 = ret.Get(0).(alarms.AlarmsPage)
+		r0 = ret.Get(0).(alarms.Alarm)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, alarms.PageMetadata) error); ok {
-		r1 = returnFunc(ctx, userID, pm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_ListUserAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserAlarms'
-type Repository_ListUserAlarms_Call struct {
+// Repository_UpdateAlarmValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarmValue'
+type Repository_UpdateAlarmValue_Call struct {
 	*mock.Call
 }
 
-// ListUserAlarms is a helper method to define mock.On call
+// UpdateAlarmValue is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - pm alarms.PageMetadata
-func (_e *Repository_Expecter) ListUserAlarms(ctx interface{}, userID interface{}, pm interface{}) *Repository_ListUserAlarms_Call {
-	return &Repository_ListUserAlarms_Call{Call: _e.mock.On("ListUserAlarms", ctx, userID, pm)}
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) UpdateAlarmValue(ctx interface{}, alarm interface{}) *Repository_UpdateAlarmValue_Call {
+	return &Repository_UpdateAlarmValue_Call{Call: _e.mock.On("UpdateAlarmValue", ctx, alarm)}
 }
 
-func (_c *Repository_ListUserAlarms_Call) Run(run func(ctx context.Context, userID string, pm alarms.PageMetadata)) *Repository_ListUserAlarms_Call {
+func (_c *Repository_UpdateAlarmValue_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_UpdateAlarmValue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 alarms.Alarm
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(alarms.Alarm)
 		}
-		var arg2 alarms.PageMetadata
-		if args[2] != nil {
-			arg2 = args[2].(alarms.PageMetadata)
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateAlarmValue_Call) Return(alarm1 alarms.Alarm, err error) *Repository_UpdateAlarmValue_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_UpdateAlarmValue_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_UpdateAlarmValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationPolicy provides a mock function for the type Repository
+func (_mock *Repository) UpdateNotificationPolicy(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPolicy) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.NotificationPolicy) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.NotificationPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_UpdateNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationPolicy'
+type Repository_UpdateNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy alarms.NotificationPolicy
+func (_e *Repository_Expecter) UpdateNotificationPolicy(ctx interface{}, policy interface{}) *Repository_UpdateNotificationPolicy_Call {
+	return &Repository_UpdateNotificationPolicy_Call{Call: _e.mock.On("UpdateNotificationPolicy", ctx, policy)}
+}
+
+func (_c *Repository_UpdateNotificationPolicy_Call) Run(run func(ctx context.Context, policy alarms.NotificationPolicy)) *Repository_UpdateNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.NotificationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(alarms.NotificationPolicy)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_ListUserAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListUserAlarms_Call {
-	_c.Call.Return(alarmsPage, err)
+func (_c *Repository_UpdateNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Repository_UpdateNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
 	return _c
 }
 
-func (_c *Repository_ListUserAlarms_Call) RunAndReturn(run func(ctx context.Context, userID string, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListUserAlarms_Call {
+func (_c *Repository_UpdateNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error)) *Repository_UpdateNotificationPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAlarm provides a mock function for the type Repository
-func (_mock *Repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
-	ret := _mock.Called(ctx, alarm)
+// UserAlarmStats provides a mock function for the type Repository
+func (_mock *Repository) UserAlarmStats(ctx context.Context, userID string, q alarms.StatsQuery) (alarms.Stats, error) {
+	ret := _mock.Called(ctx, userID, q)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAlarm")
+		panic("no return value specified for UserAlarmStats")
 	}
 
-	var r0 alarms.Alarm
+	var r0 alarms.Stats
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
-		return returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.StatsQuery) (alarms.Stats, error)); ok {
+		return returnFunc(ctx, userID, q)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
-		r0 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, alarms.StatsQuery) alarms.Stats); ok {
+		r0 = returnFunc(ctx, userID, q)
 	} else {
-		r0 = ret.Get(0).(alarms.Alarm)
+		r0 = ret.Get(0).(alarms.Stats)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, alarms.StatsQuery) error); ok {
+		r1 = returnFunc(ctx, userID, q)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
-type Repository_UpdateAlarm_Call struct {
+// Repository_UserAlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserAlarmStats'
+type Repository_UserAlarmStats_Call struct {
 	*mock.Call
 }
 
-// UpdateAlarm is a helper method to define mock.On call
+// UserAlarmStats is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alarm alarms.Alarm
-func (_e *Repository_Expecter) UpdateAlarm(ctx interface{}, alarm interface{}) *Repository_UpdateAlarm_Call {
-	return &Repository_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, alarm)}
+//   - userID string
+//   - q alarms.StatsQuery
+func (_e *Repository_Expecter) UserAlarmStats(ctx interface{}, userID interface{}, q interface{}) *Repository_UserAlarmStats_Call {
+	return &Repository_UserAlarmStats_Call{Call: _e.mock.On("UserAlarmStats", ctx, userID, q)}
 }
 
-func (_c *Repository_UpdateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_UpdateAlarm_Call {
+func (_c *Repository_UserAlarmStats_Call) Run(run func(ctx context.Context, userID string, q alarms.StatsQuery)) *Repository_UserAlarmStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.Alarm
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(alarms.Alarm)
+			arg1 = args[1].(string)
+		}
+		var arg2 alarms.StatsQuery
+		if args[2] != nil {
+			arg2 = args[2].(alarms.StatsQuery)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_UpdateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Repository_UserAlarmStats_Call) Return(stats alarms.Stats, err error) *Repository_UserAlarmStats_Call {
+	_c.Call.Return(stats, err)
 	return _c
 }
 
-func (_c *Repository_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_UpdateAlarm_Call {
+func (_c *Repository_UserAlarmStats_Call) RunAndReturn(run func(ctx context.Context, userID string, q alarms.StatsQuery) (alarms.Stats, error)) *Repository_UserAlarmStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -440,3 +1331,75 @@ func (_c *Repository_ViewAlarm_Call) RunAndReturn(run func(ctx context.Context,
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewNotificationPolicy provides a mock function for the type Repository
+func (_mock *Repository) ViewNotificationPolicy(ctx context.Context, domainID string, id string) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, domainID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewNotificationPolicy'
+type Repository_ViewNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - id string
+func (_e *Repository_Expecter) ViewNotificationPolicy(ctx interface{}, domainID interface{}, id interface{}) *Repository_ViewNotificationPolicy_Call {
+	return &Repository_ViewNotificationPolicy_Call{Call: _e.mock.On("ViewNotificationPolicy", ctx, domainID, id)}
+}
+
+func (_c *Repository_ViewNotificationPolicy_Call) Run(run func(ctx context.Context, domainID string, id string)) *Repository_ViewNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Repository_ViewNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
+	return _c
+}
+
+func (_c *Repository_ViewNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) (alarms.NotificationPolicy, error)) *Repository_ViewNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
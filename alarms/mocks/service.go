@@ -43,6 +43,150 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// AddNotificationPolicy provides a mock function for the type Service
+func (_mock *Service) AddNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, session, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPolicy) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, session, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPolicy) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, session, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.NotificationPolicy) error); ok {
+		r1 = returnFunc(ctx, session, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AddNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddNotificationPolicy'
+type Service_AddNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// AddNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - policy alarms.NotificationPolicy
+func (_e *Service_Expecter) AddNotificationPolicy(ctx interface{}, session interface{}, policy interface{}) *Service_AddNotificationPolicy_Call {
+	return &Service_AddNotificationPolicy_Call{Call: _e.mock.On("AddNotificationPolicy", ctx, session, policy)}
+}
+
+func (_c *Service_AddNotificationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy)) *Service_AddNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.NotificationPolicy
+		if args[2] != nil {
+			arg2 = args[2].(alarms.NotificationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AddNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Service_AddNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
+	return _c
+}
+
+func (_c *Service_AddNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error)) *Service_AddNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmStats provides a mock function for the type Service
+func (_mock *Service) AlarmStats(ctx context.Context, session authn.Session, q alarms.StatsQuery) (alarms.Stats, error) {
+	ret := _mock.Called(ctx, session, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmStats")
+	}
+
+	var r0 alarms.Stats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StatsQuery) (alarms.Stats, error)); ok {
+		return returnFunc(ctx, session, q)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StatsQuery) alarms.Stats); ok {
+		r0 = returnFunc(ctx, session, q)
+	} else {
+		r0 = ret.Get(0).(alarms.Stats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.StatsQuery) error); ok {
+		r1 = returnFunc(ctx, session, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmStats'
+type Service_AlarmStats_Call struct {
+	*mock.Call
+}
+
+// AlarmStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - q alarms.StatsQuery
+func (_e *Service_Expecter) AlarmStats(ctx interface{}, session interface{}, q interface{}) *Service_AlarmStats_Call {
+	return &Service_AlarmStats_Call{Call: _e.mock.On("AlarmStats", ctx, session, q)}
+}
+
+func (_c *Service_AlarmStats_Call) Run(run func(ctx context.Context, session authn.Session, q alarms.StatsQuery)) *Service_AlarmStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.StatsQuery
+		if args[2] != nil {
+			arg2 = args[2].(alarms.StatsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AlarmStats_Call) Return(stats alarms.Stats, err error) *Service_AlarmStats_Call {
+	_c.Call.Return(stats, err)
+	return _c
+}
+
+func (_c *Service_AlarmStats_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, q alarms.StatsQuery) (alarms.Stats, error)) *Service_AlarmStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAlarm provides a mock function for the type Service
 func (_mock *Service) CreateAlarm(ctx context.Context, alarm alarms.Alarm) error {
 	ret := _mock.Called(ctx, alarm)
@@ -235,6 +379,192 @@ func (_c *Service_ListAlarms_Call) RunAndReturn(run func(ctx context.Context, se
 	return _c
 }
 
+// ListNotificationPolicies provides a mock function for the type Service
+func (_mock *Service) ListNotificationPolicies(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotificationPolicies")
+	}
+
+	var r0 alarms.NotificationPoliciesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPoliciesPageMeta) alarms.NotificationPoliciesPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPoliciesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.NotificationPoliciesPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListNotificationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotificationPolicies'
+type Service_ListNotificationPolicies_Call struct {
+	*mock.Call
+}
+
+// ListNotificationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm alarms.NotificationPoliciesPageMeta
+func (_e *Service_Expecter) ListNotificationPolicies(ctx interface{}, session interface{}, pm interface{}) *Service_ListNotificationPolicies_Call {
+	return &Service_ListNotificationPolicies_Call{Call: _e.mock.On("ListNotificationPolicies", ctx, session, pm)}
+}
+
+func (_c *Service_ListNotificationPolicies_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta)) *Service_ListNotificationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.NotificationPoliciesPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(alarms.NotificationPoliciesPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListNotificationPolicies_Call) Return(notificationPoliciesPage alarms.NotificationPoliciesPage, err error) *Service_ListNotificationPolicies_Call {
+	_c.Call.Return(notificationPoliciesPage, err)
+	return _c
+}
+
+func (_c *Service_ListNotificationPolicies_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error)) *Service_ListNotificationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveNotificationPolicy provides a mock function for the type Service
+func (_mock *Service) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveNotificationPolicy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_RemoveNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveNotificationPolicy'
+type Service_RemoveNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// RemoveNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) RemoveNotificationPolicy(ctx interface{}, session interface{}, id interface{}) *Service_RemoveNotificationPolicy_Call {
+	return &Service_RemoveNotificationPolicy_Call{Call: _e.mock.On("RemoveNotificationPolicy", ctx, session, id)}
+}
+
+func (_c *Service_RemoveNotificationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_RemoveNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RemoveNotificationPolicy_Call) Return(err error) *Service_RemoveNotificationPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_RemoveNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_RemoveNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartScheduler provides a mock function for the type Service
+func (_mock *Service) StartScheduler(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartScheduler")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StartScheduler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartScheduler'
+type Service_StartScheduler_Call struct {
+	*mock.Call
+}
+
+// StartScheduler is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Service_Expecter) StartScheduler(ctx interface{}) *Service_StartScheduler_Call {
+	return &Service_StartScheduler_Call{Call: _e.mock.On("StartScheduler", ctx)}
+}
+
+func (_c *Service_StartScheduler_Call) Run(run func(ctx context.Context)) *Service_StartScheduler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StartScheduler_Call) Return(err error) *Service_StartScheduler_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StartScheduler_Call) RunAndReturn(run func(ctx context.Context) error) *Service_StartScheduler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateAlarm provides a mock function for the type Service
 func (_mock *Service) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
 	ret := _mock.Called(ctx, session, alarm)
@@ -307,6 +637,78 @@ func (_c *Service_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, s
 	return _c
 }
 
+// UpdateNotificationPolicy provides a mock function for the type Service
+func (_mock *Service) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, session, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPolicy) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, session, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.NotificationPolicy) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, session, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.NotificationPolicy) error); ok {
+		r1 = returnFunc(ctx, session, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_UpdateNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationPolicy'
+type Service_UpdateNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - policy alarms.NotificationPolicy
+func (_e *Service_Expecter) UpdateNotificationPolicy(ctx interface{}, session interface{}, policy interface{}) *Service_UpdateNotificationPolicy_Call {
+	return &Service_UpdateNotificationPolicy_Call{Call: _e.mock.On("UpdateNotificationPolicy", ctx, session, policy)}
+}
+
+func (_c *Service_UpdateNotificationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy)) *Service_UpdateNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.NotificationPolicy
+		if args[2] != nil {
+			arg2 = args[2].(alarms.NotificationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_UpdateNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Service_UpdateNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
+	return _c
+}
+
+func (_c *Service_UpdateNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error)) *Service_UpdateNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewAlarm provides a mock function for the type Service
 func (_mock *Service) ViewAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -378,3 +780,75 @@ func (_c *Service_ViewAlarm_Call) RunAndReturn(run func(ctx context.Context, ses
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewNotificationPolicy provides a mock function for the type Service
+func (_mock *Service) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (alarms.NotificationPolicy, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewNotificationPolicy")
+	}
+
+	var r0 alarms.NotificationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (alarms.NotificationPolicy, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) alarms.NotificationPolicy); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(alarms.NotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewNotificationPolicy'
+type Service_ViewNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ViewNotificationPolicy(ctx interface{}, session interface{}, id interface{}) *Service_ViewNotificationPolicy_Call {
+	return &Service_ViewNotificationPolicy_Call{Call: _e.mock.On("ViewNotificationPolicy", ctx, session, id)}
+}
+
+func (_c *Service_ViewNotificationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ViewNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewNotificationPolicy_Call) Return(notificationPolicy alarms.NotificationPolicy, err error) *Service_ViewNotificationPolicy_Call {
+	_c.Call.Return(notificationPolicy, err)
+	return _c
+}
+
+func (_c *Service_ViewNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (alarms.NotificationPolicy, error)) *Service_ViewNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
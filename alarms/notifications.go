@@ -0,0 +1,177 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"net/mail"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Alarm state changes that trigger notifications.
+const (
+	CreatedEvent      = "created"
+	AssignedEvent     = "assigned"
+	AcknowledgedEvent = "acknowledged"
+	ResolvedEvent     = "resolved"
+	EscalatedEvent    = "escalated"
+	// UnacknowledgedEvent is sent to the escalation chain of the policy
+	// when the alarm is not acknowledged in time.
+	UnacknowledgedEvent = "unacknowledged"
+)
+
+var events = []string{CreatedEvent, AssignedEvent, AcknowledgedEvent, ResolvedEvent, EscalatedEvent}
+
+var (
+	ErrMissingPolicyName  = errors.New("missing notification policy name")
+	ErrInvalidSeverities  = errors.New("invalid severity range")
+	ErrInvalidEvent       = errors.New("invalid notification event")
+	ErrMissingRecipients  = errors.New("missing notification recipients")
+	ErrInvalidEmail       = errors.New("invalid notification email")
+	ErrInvalidWebhook     = errors.New("invalid notification webhook URL")
+	ErrInvalidEscalations = errors.New("escalation levels must be ordered by increasing after_minutes")
+)
+
+// NotificationPolicy defines who is notified about the alarms of the domain
+// with severity in the given range, and whom to escalate the alarm to if the
+// alarm is not acknowledged in time.
+type NotificationPolicy struct {
+	ID          string `json:"id"`
+	DomainID    string `json:"domain_id"`
+	Name        string `json:"name"`
+	MinSeverity uint8  `json:"min_severity"`
+	MaxSeverity uint8  `json:"max_severity"`
+	// Events are the alarm state changes the recipients are notified about.
+	// If empty, the recipients are notified about all the state changes.
+	Events     []string          `json:"events,omitempty"`
+	Recipients Recipients        `json:"recipients"`
+	Escalation []EscalationLevel `json:"escalation,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	CreatedBy  string            `json:"created_by"`
+	UpdatedAt  time.Time         `json:"updated_at,omitempty"`
+	UpdatedBy  string            `json:"updated_by,omitempty"`
+}
+
+// Recipients are notified by email and by webhook.
+type Recipients struct {
+	Emails   []string  `json:"emails,omitempty"`
+	Webhooks []Webhook `json:"webhooks,omitempty"`
+}
+
+// Webhook receives the notification as JSON in the body of a POST request.
+// If the secret is set, the request is signed using HMAC-SHA256.
+type Webhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// EscalationLevel notifies the recipients if the alarm is still not
+// acknowledged the given number of minutes after it was created.
+type EscalationLevel struct {
+	AfterMinutes uint32     `json:"after_minutes"`
+	Recipients   Recipients `json:"recipients"`
+}
+
+type NotificationPoliciesPage struct {
+	Offset   uint64               `json:"offset"`
+	Limit    uint64               `json:"limit"`
+	Total    uint64               `json:"total"`
+	Policies []NotificationPolicy `json:"policies"`
+}
+
+type NotificationPoliciesPageMeta struct {
+	Offset   uint64 `json:"offset"    db:"offset"`
+	Limit    uint64 `json:"limit"     db:"limit"`
+	DomainID string `json:"domain_id" db:"domain_id"`
+}
+
+// Notification is sent to the recipients on the alarm state change.
+type Notification struct {
+	Event    string `json:"event"`
+	PolicyID string `json:"policy_id"`
+	// EscalationLevel is the level of the escalation chain, starting from 1,
+	// for the unacknowledged event.
+	EscalationLevel uint      `json:"escalation_level,omitempty"`
+	Alarm           Alarm     `json:"alarm"`
+	SentAt          time.Time `json:"sent_at"`
+}
+
+// Escalation is a due escalation level of the policy for the alarm.
+type Escalation struct {
+	Alarm    Alarm
+	PolicyID string
+	Level    uint
+}
+
+// Notifier sends the notifications to the recipients.
+type Notifier interface {
+	Notify(ctx context.Context, recipients Recipients, notification Notification) error
+}
+
+func (p NotificationPolicy) Validate() error {
+	if p.Name == "" {
+		return ErrMissingPolicyName
+	}
+	if p.MinSeverity > p.MaxSeverity || p.MaxSeverity > SeverityMax {
+		return ErrInvalidSeverities
+	}
+	for _, e := range p.Events {
+		if !slices.Contains(events, e) {
+			return errors.Wrap(ErrInvalidEvent, errors.New(e))
+		}
+	}
+	if p.Recipients.empty() && len(p.Escalation) == 0 {
+		return ErrMissingRecipients
+	}
+	if err := p.Recipients.validate(); err != nil {
+		return err
+	}
+	var after uint32
+	for i, l := range p.Escalation {
+		if l.AfterMinutes == 0 || (i > 0 && l.AfterMinutes <= after) {
+			return ErrInvalidEscalations
+		}
+		after = l.AfterMinutes
+		if l.Recipients.empty() {
+			return ErrMissingRecipients
+		}
+		if err := l.Recipients.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Notifies reports whether the recipients of the policy are notified about the event.
+func (p NotificationPolicy) Notifies(event string) bool {
+	return len(p.Events) == 0 || slices.Contains(p.Events, event)
+}
+
+func (r Recipients) empty() bool {
+	return len(r.Emails) == 0 && len(r.Webhooks) == 0
+}
+
+func (r Recipients) validate() error {
+	for _, e := range r.Emails {
+		if _, err := mail.ParseAddress(e); err != nil {
+			return errors.Wrap(ErrInvalidEmail, err)
+		}
+	}
+	for _, w := range r.Webhooks {
+		u, err := url.ParseRequestURI(w.URL)
+		if err != nil {
+			return errors.Wrap(ErrInvalidWebhook, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Wrap(ErrInvalidWebhook, errors.New(w.URL))
+		}
+	}
+
+	return nil
+}
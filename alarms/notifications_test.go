@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNotificationPolicy(t *testing.T) {
+	recipients := alarms.Recipients{
+		Emails:   []string{"admin@example.com"},
+		Webhooks: []alarms.Webhook{{URL: "https://example.com/alarms", Secret: "secret"}},
+	}
+
+	cases := []struct {
+		desc   string
+		policy alarms.NotificationPolicy
+		err    error
+	}{
+		{
+			desc: "valid policy",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MinSeverity: 50,
+				MaxSeverity: alarms.SeverityMax,
+				Events:      []string{alarms.CreatedEvent, alarms.EscalatedEvent},
+				Recipients:  recipients,
+				Escalation: []alarms.EscalationLevel{
+					{AfterMinutes: 15, Recipients: recipients},
+					{AfterMinutes: 60, Recipients: recipients},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid policy with escalation only",
+			policy: alarms.NotificationPolicy{
+				Name:        "escalation",
+				MaxSeverity: alarms.SeverityMax,
+				Escalation:  []alarms.EscalationLevel{{AfterMinutes: 15, Recipients: recipients}},
+			},
+			err: nil,
+		},
+		{
+			desc: "missing name",
+			policy: alarms.NotificationPolicy{
+				MaxSeverity: alarms.SeverityMax,
+				Recipients:  recipients,
+			},
+			err: alarms.ErrMissingPolicyName,
+		},
+		{
+			desc: "min severity higher than max severity",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MinSeverity: 80,
+				MaxSeverity: 50,
+				Recipients:  recipients,
+			},
+			err: alarms.ErrInvalidSeverities,
+		},
+		{
+			desc: "max severity out of range",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax + 1,
+				Recipients:  recipients,
+			},
+			err: alarms.ErrInvalidSeverities,
+		},
+		{
+			desc: "invalid event",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+				Events:      []string{"deleted"},
+				Recipients:  recipients,
+			},
+			err: alarms.ErrInvalidEvent,
+		},
+		{
+			desc: "missing recipients",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+			},
+			err: alarms.ErrMissingRecipients,
+		},
+		{
+			desc: "invalid email",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+				Recipients:  alarms.Recipients{Emails: []string{"admin"}},
+			},
+			err: alarms.ErrInvalidEmail,
+		},
+		{
+			desc: "invalid webhook",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+				Recipients:  alarms.Recipients{Webhooks: []alarms.Webhook{{URL: "ftp://example.com"}}},
+			},
+			err: alarms.ErrInvalidWebhook,
+		},
+		{
+			desc: "escalation levels out of order",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+				Escalation: []alarms.EscalationLevel{
+					{AfterMinutes: 60, Recipients: recipients},
+					{AfterMinutes: 15, Recipients: recipients},
+				},
+			},
+			err: alarms.ErrInvalidEscalations,
+		},
+		{
+			desc: "escalation level without recipients",
+			policy: alarms.NotificationPolicy{
+				Name:        "critical",
+				MaxSeverity: alarms.SeverityMax,
+				Escalation:  []alarms.EscalationLevel{{AfterMinutes: 15}},
+			},
+			err: alarms.ErrMissingRecipients,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		})
+	}
+}
+
+func TestNotificationPolicyNotifies(t *testing.T) {
+	all := alarms.NotificationPolicy{}
+	assert.True(t, all.Notifies(alarms.AssignedEvent), "policy without events should notify all events")
+
+	created := alarms.NotificationPolicy{Events: []string{alarms.CreatedEvent}}
+	assert.True(t, created.Notifies(alarms.CreatedEvent), "policy should notify configured event")
+	assert.False(t, created.Notifies(alarms.ResolvedEvent), "policy should not notify other events")
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notifier sends the alarm notifications by email and to webhooks.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/emailer"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/webhook"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+	emailHeader    = "Alarm notification"
+	emailFooter    = "You receive this email because of the alarm notification policy of the domain."
+)
+
+var (
+	errEmail         = errors.New("failed to send notification email")
+	errWebhookStatus = errors.New("webhook request failed")
+)
+
+var _ alarms.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	emailer emailer.Emailer
+	client  *http.Client
+}
+
+// New returns the notifier which sends the emails using the emailer and
+// posts the notifications as JSON to the webhooks.
+func New(e emailer.Emailer) alarms.Notifier {
+	return &notifier{
+		emailer: e,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (n *notifier) Notify(ctx context.Context, recipients alarms.Recipients, notification alarms.Notification) error {
+	var failed []string
+	var err error
+	if len(recipients.Emails) > 0 {
+		if eerr := n.sendEmail(recipients.Emails, notification); eerr != nil {
+			failed = append(failed, strings.Join(recipients.Emails, ", "))
+			err = eerr
+		}
+	}
+
+	body, merr := json.Marshal(notification)
+	if merr != nil {
+		return merr
+	}
+	for _, w := range recipients.Webhooks {
+		if werr := n.post(ctx, w, body); werr != nil {
+			failed = append(failed, w.URL)
+			err = werr
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Wrap(fmt.Errorf("recipients %s", strings.Join(failed, ", ")), err)
+	}
+
+	return nil
+}
+
+func (n *notifier) sendEmail(to []string, notification alarms.Notification) error {
+	if n.emailer == nil {
+		return errEmail
+	}
+	a := notification.Alarm
+	subject := fmt.Sprintf("Alarm %s: %s (severity %d)", notification.Event, a.Measurement, a.Severity)
+	if notification.Event == alarms.UnacknowledgedEvent {
+		subject = fmt.Sprintf("Alarm not acknowledged, escalation level %d: %s (severity %d)", notification.EscalationLevel, a.Measurement, a.Severity)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Alarm: %s", a.ID),
+		fmt.Sprintf("Status: %s", a.Status),
+		fmt.Sprintf("Severity: %d", a.Severity),
+		fmt.Sprintf("Measurement: %s", a.Measurement),
+		fmt.Sprintf("Value: %s %s", a.Value, a.Unit),
+	}
+	if a.Threshold != "" {
+		lines = append(lines, fmt.Sprintf("Threshold: %s", a.Threshold))
+	}
+	if a.Cause != "" {
+		lines = append(lines, fmt.Sprintf("Cause: %s", a.Cause))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Rule: %s", a.RuleID),
+		fmt.Sprintf("Channel: %s", a.ChannelID),
+		fmt.Sprintf("Client: %s", a.ClientID),
+		fmt.Sprintf("Created at: %s", a.CreatedAt.Format(time.RFC3339)),
+	)
+	// The email is sent as HTML, so the lines are escaped and
+	// separated with the line breaks.
+	for i, l := range lines {
+		lines[i] = html.EscapeString(l)
+	}
+	content := strings.Join(lines, "<br>\n")
+
+	if err := n.emailer.SendEmailNotification(to, "", subject, emailHeader, "", content, emailFooter, make(map[string][]byte)); err != nil {
+		return errors.Wrap(errEmail, err)
+	}
+
+	return nil
+}
+
+func (n *notifier) post(ctx context.Context, w alarms.Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		webhook.SignRequest(req, w.Secret, body)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrap(errWebhookStatus, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/alarms/notifier"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/emailer/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errEmail = errors.New("email error")
+
+func TestNotify(t *testing.T) {
+	notification := alarms.Notification{
+		Event:    alarms.CreatedEvent,
+		PolicyID: testsutil.GenerateUUID(t),
+		Alarm: alarms.Alarm{
+			ID:          testsutil.GenerateUUID(t),
+			Measurement: "temperature",
+			Value:       "31.5",
+			Cause:       "value > 30 & rising",
+			Severity:    80,
+		},
+		SentAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	cases := []struct {
+		desc     string
+		emails   []string
+		secret   string
+		status   int
+		emailErr error
+		err      bool
+	}{
+		{
+			desc:   "notify emails and webhook successfully",
+			emails: []string{"admin@example.com"},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "notify signed webhook successfully",
+			secret: "secret",
+			status: http.StatusNoContent,
+		},
+		{
+			desc:     "notify with failed email",
+			emails:   []string{"admin@example.com"},
+			status:   http.StatusOK,
+			emailErr: errEmail,
+			err:      true,
+		},
+		{
+			desc:   "notify with failed webhook",
+			status: http.StatusInternalServerError,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var body []byte
+			var headers http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				headers = r.Header.Clone()
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			emailer := new(mocks.Emailer)
+			recipients := alarms.Recipients{
+				Emails:   tc.emails,
+				Webhooks: []alarms.Webhook{{URL: srv.URL, Secret: tc.secret}},
+			}
+			call := emailer.On("SendEmailNotification", tc.emails, "", mock.Anything, mock.Anything, "", mock.Anything, mock.Anything, mock.Anything).Return(tc.emailErr)
+
+			err := notifier.New(emailer).Notify(context.Background(), recipients, notification)
+			assert.Equal(t, tc.err, err != nil, "unexpected error: %v", err)
+
+			var got alarms.Notification
+			require.Nil(t, json.Unmarshal(body, &got))
+			assert.Equal(t, notification.Alarm.ID, got.Alarm.ID)
+			assert.Equal(t, notification.Event, got.Event)
+			assert.Equal(t, "application/json", headers.Get("Content-Type"))
+			if tc.secret != "" {
+				ts := headers.Get(webhook.SignatureTimestampHeader)
+				assert.Equal(t, "sha256="+webhook.Sign(tc.secret, ts, body), headers.Get(webhook.SignatureHeader))
+			} else {
+				assert.Empty(t, headers.Get(webhook.SignatureHeader))
+			}
+			if len(tc.emails) > 0 {
+				content := mock.MatchedBy(func(content string) bool {
+					return strings.Contains(content, "Measurement: temperature<br>") && strings.Contains(content, "Cause: value &gt; 30 &amp; rising<br>")
+				})
+				emailer.AssertCalled(t, "SendEmailNotification", tc.emails, "", mock.Anything, mock.Anything, "", content, mock.Anything, mock.Anything)
+			} else {
+				emailer.AssertNotCalled(t, "SendEmailNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			call.Unset()
+		})
+	}
+}
@@ -16,6 +16,11 @@ const (
 	OpAcknowledgeAlarm
 	OpResolveAlarm
 	OpUpdateAlarm
+	OpAddNotificationPolicy
+	OpViewNotificationPolicy
+	OpUpdateNotificationPolicy
+	OpListNotificationPolicies
+	OpRemoveNotificationPolicy
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -48,5 +53,25 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "update",
 			PermissionRequired: true,
 		},
+		OpAddNotificationPolicy: {
+			Name:               "add_notification_policy",
+			PermissionRequired: true,
+		},
+		OpViewNotificationPolicy: {
+			Name:               "view_notification_policy",
+			PermissionRequired: true,
+		},
+		OpUpdateNotificationPolicy: {
+			Name:               "update_notification_policy",
+			PermissionRequired: true,
+		},
+		OpListNotificationPolicies: {
+			Name:               "list_notification_policies",
+			PermissionRequired: true,
+		},
+		OpRemoveNotificationPolicy: {
+			Name:               "remove_notification_policy",
+			PermissionRequired: true,
+		},
 	}
 }
@@ -22,7 +22,32 @@ import (
 
 const alarmColumns = `alarms.id, alarms.rule_id, alarms.domain_id, alarms.channel_id, alarms.client_id, alarms.subtopic, alarms.measurement, alarms.value, alarms.unit,
 alarms.threshold, alarms.cause, alarms.status, alarms.severity, alarms.assignee_id, alarms.created_at, alarms.updated_at, alarms.updated_by, alarms.assigned_at,
-alarms.assigned_by, alarms.acknowledged_at, alarms.acknowledged_by, alarms.resolved_at, alarms.resolved_by, alarms.metadata`
+alarms.assigned_by, alarms.acknowledged_at, alarms.acknowledged_by, alarms.resolved_at, alarms.resolved_by, alarms.occurrences, alarms.last_occurred_at,
+alarms.escalated_at, alarms.metadata`
+
+const returnedColumns = `id, rule_id, domain_id, channel_id, client_id, subtopic, measurement, value, unit, threshold,
+	cause, status, severity, assignee_id, assigned_at, assigned_by, acknowledged_at, acknowledged_by,
+	resolved_by, resolved_at, occurrences, last_occurred_at, escalated_at, metadata, created_at, updated_by, updated_at`
+
+// userAlarmsCondition matches the alarms of the rules the user is a member of,
+// and the alarms of the domains where the user has an alarm action.
+const userAlarmsCondition = `(
+	EXISTS (
+		SELECT 1
+		FROM rules_roles rr
+		JOIN rules_role_members rrm ON rrm.role_id = rr.id
+		WHERE rr.entity_id = alarms.rule_id AND rrm.member_id = :user_id
+	)
+	OR EXISTS (
+		SELECT 1
+		FROM domains_roles dr
+		JOIN domains_role_members drm ON drm.role_id = dr.id
+		JOIN domains_role_actions dra ON dra.role_id = dr.id
+		WHERE dr.entity_id = alarms.domain_id
+			AND drm.member_id = :user_id
+			AND dra.action LIKE 'alarm%'
+	)
+)`
 
 type repository struct {
 	db *sqlx.DB
@@ -36,45 +61,32 @@ func NewAlarmsRepo(db *sqlx.DB) alarms.Repository {
 
 func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
 	query := `
-	WITH existing AS (
-		SELECT status, severity
-		FROM alarms
-		WHERE domain_id = :domain_id
-			AND rule_id = :rule_id
-			AND channel_id = :channel_id
-			AND client_id = :client_id
-			AND subtopic = :subtopic
-			AND measurement = :measurement
-			AND created_at <= :created_at
-		ORDER BY created_at DESC
-		LIMIT 1
-	)
 	INSERT INTO alarms (
 		id, rule_id, domain_id, channel_id, client_id, subtopic, measurement,
 		value, unit, threshold, cause, status, severity, assignee_id,
 		created_at, updated_at, updated_by, assigned_at, assigned_by,
-		acknowledged_at, acknowledged_by, resolved_at, resolved_by, metadata
+		acknowledged_at, acknowledged_by, resolved_at, resolved_by,
+		occurrences, last_occurred_at, escalated_at, metadata
 	)
-	SELECT
+	VALUES (
 		:id, :rule_id, :domain_id, :channel_id, :client_id, :subtopic, :measurement,
 		:value, :unit, :threshold, :cause, :status, :severity, :assignee_id,
 		:created_at, :updated_at, :updated_by, :assigned_at, :assigned_by,
-		:acknowledged_at, :acknowledged_by, :resolved_at, :resolved_by, :metadata
-	WHERE (
-		EXISTS (
-			SELECT 1 FROM existing
-			WHERE existing.status IS DISTINCT FROM :status
-			OR (:status = 0 AND existing.status = 0 AND existing.severity IS DISTINCT FROM :severity)
-		)
-		OR (
-			NOT EXISTS (SELECT 1 FROM existing) AND :status = 0
-		)
+		:acknowledged_at, :acknowledged_by, :resolved_at, :resolved_by,
+		:occurrences, :last_occurred_at, :escalated_at, :metadata
 	)
+	ON CONFLICT (domain_id, rule_id, channel_id, client_id, subtopic, measurement) WHERE status = 0
+	DO UPDATE SET
+		occurrences = alarms.occurrences + 1,
+		value = CASE WHEN EXCLUDED.last_occurred_at >= alarms.last_occurred_at THEN EXCLUDED.value ELSE alarms.value END,
+		severity = GREATEST(alarms.severity, EXCLUDED.severity),
+		last_occurred_at = GREATEST(alarms.last_occurred_at, EXCLUDED.last_occurred_at)
 	RETURNING
 		id, rule_id, domain_id, channel_id, client_id, subtopic, measurement,
 		value, unit, threshold, cause, status, severity, created_at,
 		assignee_id, updated_at, updated_by, assigned_at, assigned_by,
-		acknowledged_at, acknowledged_by, resolved_at, resolved_by, metadata
+		acknowledged_at, acknowledged_by, resolved_at, resolved_by,
+		occurrences, last_occurred_at, escalated_at, metadata
 	;
 	`
 	dba, err := toDBAlarm(alarm)
@@ -99,6 +111,128 @@ func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 	return toAlarm(dba)
 }
 
+func (r *repository) ClearAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	query := fmt.Sprintf(`
+	UPDATE alarms SET status = :status, value = :value, resolved_at = :created_at, updated_at = :created_at
+	WHERE domain_id = :domain_id
+		AND rule_id = :rule_id
+		AND channel_id = :channel_id
+		AND client_id = :client_id
+		AND subtopic = :subtopic
+		AND measurement = :measurement
+		AND status = 0
+		AND last_occurred_at <= :created_at
+	RETURNING %s;`, returnedColumns)
+
+	alarm.Status = alarms.ClearedStatus
+	dba, err := toDBAlarm(alarm)
+	if err != nil {
+		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	row, err := r.db.NamedQueryContext(ctx, query, dba)
+	if err != nil {
+		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return alarms.Alarm{}, repoerr.ErrNotFound
+	}
+
+	dba = dbAlarm{}
+	if err := row.StructScan(&dba); err != nil {
+		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return toAlarm(dba)
+}
+
+func (r *repository) UpdateAlarmValue(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	query := fmt.Sprintf(`
+	UPDATE alarms SET value = :value, updated_at = :created_at
+	WHERE domain_id = :domain_id
+		AND rule_id = :rule_id
+		AND channel_id = :channel_id
+		AND client_id = :client_id
+		AND subtopic = :subtopic
+		AND measurement = :measurement
+		AND status = 0
+		AND last_occurred_at <= :created_at
+	RETURNING %s;`, returnedColumns)
+
+	dba, err := toDBAlarm(alarm)
+	if err != nil {
+		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	row, err := r.db.NamedQueryContext(ctx, query, dba)
+	if err != nil {
+		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	if !row.Next() {
+		return alarms.Alarm{}, repoerr.ErrNotFound
+	}
+
+	dba = dbAlarm{}
+	if err := row.StructScan(&dba); err != nil {
+		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return toAlarm(dba)
+}
+
+func (r *repository) ClearQuietAlarms(ctx context.Context, before, clearedAt time.Time) ([]alarms.Alarm, error) {
+	query := fmt.Sprintf(`UPDATE alarms SET status = :status, resolved_at = :cleared_at, updated_at = :cleared_at
+		WHERE status = 0 AND last_occurred_at < :before
+		RETURNING %s;`, returnedColumns)
+
+	return r.updateAlarms(ctx, query, map[string]any{
+		"status":     alarms.ClearedStatus,
+		"cleared_at": clearedAt,
+		"before":     before,
+	})
+}
+
+func (r *repository) EscalateAlarms(ctx context.Context, before, escalatedAt time.Time, step uint8) ([]alarms.Alarm, error) {
+	query := fmt.Sprintf(`UPDATE alarms SET severity = LEAST(severity + :step, :max), escalated_at = :escalated_at
+		WHERE status = 0
+			AND acknowledged_at IS NULL
+			AND severity < :max
+			AND COALESCE(escalated_at, created_at) < :before
+		RETURNING %s;`, returnedColumns)
+
+	return r.updateAlarms(ctx, query, map[string]any{
+		"step":         step,
+		"max":          alarms.SeverityMax,
+		"escalated_at": escalatedAt,
+		"before":       before,
+	})
+}
+
+func (r *repository) updateAlarms(ctx context.Context, query string, params map[string]any) ([]alarms.Alarm, error) {
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var items []alarms.Alarm
+	for rows.Next() {
+		dba := dbAlarm{}
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		a, err := toAlarm(dba)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		items = append(items, a)
+	}
+
+	return items, nil
+}
+
 func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
 	var query []string
 	var upq string
@@ -134,9 +268,7 @@ func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 	}
 
 	q := fmt.Sprintf(`UPDATE alarms SET %s updated_by = :updated_by, updated_at = :updated_at WHERE id = :id
-		RETURNING id, rule_id, domain_id, channel_id, client_id, subtopic, measurement, value, unit, threshold,
-		cause, status, severity, assignee_id, assigned_at, assigned_by, acknowledged_at, acknowledged_by,
-		resolved_by, resolved_at, metadata, created_at, updated_by, updated_at;`, upq)
+		RETURNING %s;`, upq, returnedColumns)
 
 	dba, err := toDBAlarm(alarm)
 	if err != nil {
@@ -199,25 +331,7 @@ func (r *repository) ListAllAlarms(ctx context.Context, pm alarms.PageMetadata)
 }
 
 func (r *repository) ListUserAlarms(ctx context.Context, userID string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
-	clauses := []string{
-		`(
-			EXISTS (
-				SELECT 1
-				FROM rules_roles rr
-				JOIN rules_role_members rrm ON rrm.role_id = rr.id
-				WHERE rr.entity_id = alarms.rule_id AND rrm.member_id = :user_id
-			)
-			OR EXISTS (
-				SELECT 1
-				FROM domains_roles dr
-				JOIN domains_role_members drm ON drm.role_id = dr.id
-				JOIN domains_role_actions dra ON dra.role_id = dr.id
-				WHERE dr.entity_id = alarms.domain_id
-					AND drm.member_id = :user_id
-					AND dra.action LIKE 'alarm%'
-			)
-		)`,
-	}
+	clauses := []string{userAlarmsCondition}
 
 	clauses = append(clauses, pageQueryConditions(pm)...)
 	query := fmt.Sprintf("WHERE %s", strings.Join(clauses, " AND "))
@@ -321,6 +435,9 @@ type dbAlarm struct {
 	AcknowledgedBy *string       `db:"acknowledged_by,omitempty"`
 	ResolvedAt     sql.NullTime  `db:"resolved_at,omitempty"`
 	ResolvedBy     *string       `db:"resolved_by,omitempty"`
+	Occurrences    uint64        `db:"occurrences"`
+	LastOccurredAt time.Time     `db:"last_occurred_at"`
+	EscalatedAt    sql.NullTime  `db:"escalated_at,omitempty"`
 	Metadata       []byte        `db:"metadata,omitempty"`
 }
 
@@ -328,6 +445,16 @@ func toDBAlarm(a alarms.Alarm) (dbAlarm, error) {
 	if a.CreatedAt.IsZero() {
 		a.CreatedAt = time.Now()
 	}
+	if a.LastOccurredAt.IsZero() {
+		a.LastOccurredAt = a.CreatedAt
+	}
+	if a.Occurrences == 0 {
+		a.Occurrences = 1
+	}
+	var escalatedAt sql.NullTime
+	if !a.EscalatedAt.IsZero() {
+		escalatedAt = sql.NullTime{Time: a.EscalatedAt, Valid: true}
+	}
 	var updatedBy *string
 	if a.UpdatedBy != "" {
 		updatedBy = &a.UpdatedBy
@@ -397,6 +524,9 @@ func toDBAlarm(a alarms.Alarm) (dbAlarm, error) {
 		AcknowledgedBy: acknowledgedBy,
 		ResolvedAt:     resolvedAt,
 		ResolvedBy:     resolvedBy,
+		Occurrences:    a.Occurrences,
+		LastOccurredAt: a.LastOccurredAt,
+		EscalatedAt:    escalatedAt,
 		Metadata:       metadata,
 	}, nil
 }
@@ -438,6 +568,11 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		resolvedAt = dbr.ResolvedAt.Time
 	}
 
+	var escalatedAt time.Time
+	if dbr.EscalatedAt.Valid {
+		escalatedAt = dbr.EscalatedAt.Time
+	}
+
 	var metadata map[string]any
 	if len(dbr.Metadata) > 0 {
 		err := json.Unmarshal(dbr.Metadata, &metadata)
@@ -470,6 +605,9 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		AcknowledgedBy: acknowledgedBy,
 		ResolvedAt:     resolvedAt,
 		ResolvedBy:     resolvedBy,
+		Occurrences:    dbr.Occurrences,
+		LastOccurredAt: dbr.LastOccurredAt,
+		EscalatedAt:    escalatedAt,
 		Metadata:       metadata,
 	}, nil
 }
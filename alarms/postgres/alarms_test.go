@@ -53,20 +53,48 @@ func TestCreateAlarm(t *testing.T) {
 		},
 	}
 
+	repeated := alarm
+	repeated.ID = generateUUID(t)
+	repeated.Value = namegen.Generate()
+	repeated.CreatedAt = alarm.CreatedAt.Add(time.Minute)
+	repeated.LastOccurredAt = repeated.CreatedAt
+	delayed := alarm
+	delayed.ID = generateUUID(t)
+	delayed.Value = namegen.Generate()
+	delayed.CreatedAt = alarm.CreatedAt.Add(time.Second)
+	delayed.LastOccurredAt = delayed.CreatedAt
+
 	cases := []struct {
-		desc  string
-		alarm alarms.Alarm
-		err   error
+		desc        string
+		alarm       alarms.Alarm
+		id          string
+		value       string
+		occurrences uint64
+		err         error
 	}{
 		{
-			desc:  "valid alarm",
-			alarm: alarm,
-			err:   nil,
+			desc:        "valid alarm",
+			alarm:       alarm,
+			id:          alarm.ID,
+			value:       alarm.Value,
+			occurrences: 1,
+			err:         nil,
 		},
 		{
-			desc:  "duplicate alarm",
-			alarm: alarm,
-			err:   repoerr.ErrNotFound,
+			desc:        "repeated alarm",
+			alarm:       repeated,
+			id:          alarm.ID,
+			value:       repeated.Value,
+			occurrences: 2,
+			err:         nil,
+		},
+		{
+			desc:        "delayed repeated alarm",
+			alarm:       delayed,
+			id:          alarm.ID,
+			value:       repeated.Value,
+			occurrences: 3,
+			err:         nil,
 		},
 		{
 			desc: "missing rule id",
@@ -130,10 +158,11 @@ func TestCreateAlarm(t *testing.T) {
 				return
 			}
 			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-			assert.NotEmpty(t, alarm.ID)
+			assert.Equal(t, tc.id, alarm.ID)
+			assert.Equal(t, tc.occurrences, alarm.Occurrences)
 			assert.Equal(t, tc.alarm.RuleID, alarm.RuleID)
 			assert.Equal(t, tc.alarm.Measurement, alarm.Measurement)
-			assert.Equal(t, tc.alarm.Value, alarm.Value)
+			assert.Equal(t, tc.value, alarm.Value)
 			assert.Equal(t, tc.alarm.Unit, alarm.Unit)
 			assert.Equal(t, tc.alarm.Cause, alarm.Cause)
 			assert.Equal(t, tc.alarm.Status, alarm.Status)
@@ -250,6 +279,334 @@ func TestUpdateAlarm(t *testing.T) {
 	}
 }
 
+func TestClearAlarm(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	alarm := alarms.Alarm{
+		ID:          generateUUID(t),
+		RuleID:      generateUUID(t),
+		DomainID:    generateUUID(t),
+		ChannelID:   generateUUID(t),
+		ClientID:    generateUUID(t),
+		Measurement: namegen.Generate(),
+		Value:       namegen.Generate(),
+		Unit:        namegen.Generate(),
+		Threshold:   namegen.Generate(),
+		Cause:       namegen.Generate(),
+		CreatedAt:   now,
+	}
+	alarm, err := repo.CreateAlarm(context.Background(), alarm)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stale := alarm
+	stale.Value = namegen.Generate()
+	stale.CreatedAt = now.Add(-time.Minute)
+	cleared := alarm
+	cleared.Value = namegen.Generate()
+	cleared.CreatedAt = now.Add(time.Minute)
+	unknown := alarm
+	unknown.Measurement = namegen.Generate()
+	unknown.CreatedAt = now.Add(time.Minute)
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		err   error
+	}{
+		{
+			desc:  "clear alarm before it last occurred",
+			alarm: stale,
+			err:   repoerr.ErrNotFound,
+		},
+		{
+			desc:  "clear alarm without active alarm",
+			alarm: unknown,
+			err:   repoerr.ErrNotFound,
+		},
+		{
+			desc:  "clear alarm",
+			alarm: cleared,
+			err:   nil,
+		},
+		{
+			desc:  "clear already cleared alarm",
+			alarm: cleared,
+			err:   repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.ClearAlarm(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, alarm.ID, got.ID)
+				assert.Equal(t, alarms.ClearedStatus, got.Status)
+				assert.Equal(t, tc.alarm.Value, got.Value)
+				assert.True(t, tc.alarm.CreatedAt.Equal(got.ResolvedAt), fmt.Sprintf("%s: expected resolved at %s got %s", tc.desc, tc.alarm.CreatedAt, got.ResolvedAt))
+			}
+		})
+	}
+
+	// A new trigger after the alarm is cleared raises a new alarm.
+	repeated := alarm
+	repeated.ID = generateUUID(t)
+	repeated.CreatedAt = now.Add(time.Hour)
+	repeated.LastOccurredAt = time.Time{}
+	repeated.Occurrences = 0
+	got, err := repo.CreateAlarm(context.Background(), repeated)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, repeated.ID, got.ID)
+	assert.Equal(t, uint64(1), got.Occurrences)
+}
+
+func TestUpdateAlarmValue(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	alarm := alarms.Alarm{
+		ID:             generateUUID(t),
+		RuleID:         generateUUID(t),
+		DomainID:       generateUUID(t),
+		ChannelID:      generateUUID(t),
+		ClientID:       generateUUID(t),
+		Measurement:    namegen.Generate(),
+		Value:          namegen.Generate(),
+		Unit:           namegen.Generate(),
+		Threshold:      namegen.Generate(),
+		Cause:          namegen.Generate(),
+		CreatedAt:      now,
+		Occurrences:    1,
+		LastOccurredAt: now,
+	}
+	alarm, err := repo.CreateAlarm(context.Background(), alarm)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stale := alarm
+	stale.Status = alarms.ClearedStatus
+	stale.Value = namegen.Generate()
+	stale.CreatedAt = now.Add(-time.Minute)
+	cleared := alarm
+	cleared.Status = alarms.ClearedStatus
+	cleared.Value = namegen.Generate()
+	cleared.CreatedAt = now.Add(time.Minute)
+	unknown := cleared
+	unknown.Measurement = namegen.Generate()
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		err   error
+	}{
+		{
+			desc:  "update value before alarm last occurred",
+			alarm: stale,
+			err:   repoerr.ErrNotFound,
+		},
+		{
+			desc:  "update value without active alarm",
+			alarm: unknown,
+			err:   repoerr.ErrNotFound,
+		},
+		{
+			desc:  "update value",
+			alarm: cleared,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdateAlarmValue(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, alarm.ID, got.ID)
+				assert.Equal(t, alarms.ActiveStatus, got.Status)
+				assert.Equal(t, tc.alarm.Value, got.Value)
+				assert.Equal(t, alarm.Occurrences, got.Occurrences)
+				assert.True(t, alarm.LastOccurredAt.Equal(got.LastOccurredAt), fmt.Sprintf("%s: expected last occurred at %s got %s", tc.desc, alarm.LastOccurredAt, got.LastOccurredAt))
+			}
+		})
+	}
+}
+
+func TestClearQuietAlarms(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	var items []alarms.Alarm
+	for i := range 10 {
+		alarm := alarms.Alarm{
+			ID:          generateUUID(t),
+			RuleID:      generateUUID(t),
+			DomainID:    generateUUID(t),
+			ChannelID:   generateUUID(t),
+			ClientID:    generateUUID(t),
+			Measurement: namegen.Generate(),
+			Value:       namegen.Generate(),
+			Unit:        namegen.Generate(),
+			Threshold:   namegen.Generate(),
+			Cause:       namegen.Generate(),
+			CreatedAt:   now.Add(-time.Duration(i) * time.Hour),
+		}
+		alarm, err := repo.CreateAlarm(context.Background(), alarm)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		items = append(items, alarm)
+	}
+
+	cases := []struct {
+		desc    string
+		before  time.Time
+		cleared uint64
+	}{
+		{
+			desc:    "clear alarms quiet for more than 5 hours",
+			before:  now.Add(-5*time.Hour + time.Minute),
+			cleared: 5,
+		},
+		{
+			desc:    "clear already cleared alarms",
+			before:  now.Add(-5*time.Hour + time.Minute),
+			cleared: 0,
+		},
+		{
+			desc:    "clear all alarms",
+			before:  now.Add(time.Minute),
+			cleared: 5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cleared, err := repo.ClearQuietAlarms(context.Background(), tc.before, now)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.cleared, uint64(len(cleared)))
+		})
+	}
+
+	alarm, err := repo.ViewAlarm(context.Background(), items[9].ID, items[9].DomainID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, alarms.ClearedStatus, alarm.Status)
+	assert.True(t, now.Equal(alarm.ResolvedAt), fmt.Sprintf("expected resolved at %s got %s", now, alarm.ResolvedAt))
+}
+
+func TestEscalateAlarms(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	newAlarm := func(severity uint8, createdAt time.Time) alarms.Alarm {
+		alarm, err := repo.CreateAlarm(context.Background(), alarms.Alarm{
+			ID:          generateUUID(t),
+			RuleID:      generateUUID(t),
+			DomainID:    generateUUID(t),
+			ChannelID:   generateUUID(t),
+			ClientID:    generateUUID(t),
+			Measurement: namegen.Generate(),
+			Value:       namegen.Generate(),
+			Unit:        namegen.Generate(),
+			Threshold:   namegen.Generate(),
+			Cause:       namegen.Generate(),
+			Severity:    severity,
+			CreatedAt:   createdAt,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		return alarm
+	}
+
+	old := newAlarm(10, now.Add(-time.Hour))
+	critical := newAlarm(95, now.Add(-time.Hour))
+	maxed := newAlarm(alarms.SeverityMax, now.Add(-time.Hour))
+	recent := newAlarm(10, now)
+	acknowledged := newAlarm(10, now.Add(-time.Hour))
+	_, err := repo.UpdateAlarm(context.Background(), alarms.Alarm{
+		ID:             acknowledged.ID,
+		AcknowledgedBy: generateUUID(t),
+		AcknowledgedAt: now,
+		UpdatedBy:      generateUUID(t),
+		UpdatedAt:      now,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		before    time.Time
+		at        time.Time
+		escalated uint64
+		severity  map[string]uint8
+	}{
+		{
+			desc:      "escalate unacknowledged alarms",
+			before:    now.Add(-time.Minute),
+			at:        now,
+			escalated: 2,
+			severity: map[string]uint8{
+				old.ID:          20,
+				critical.ID:     alarms.SeverityMax,
+				maxed.ID:        alarms.SeverityMax,
+				recent.ID:       10,
+				acknowledged.ID: 10,
+			},
+		},
+		{
+			desc:      "escalate alarms escalated recently",
+			before:    now.Add(-time.Minute),
+			at:        now.Add(time.Minute),
+			escalated: 0,
+			severity: map[string]uint8{
+				old.ID:    20,
+				recent.ID: 10,
+			},
+		},
+		{
+			desc:      "escalate alarms again",
+			before:    now.Add(2 * time.Minute),
+			at:        now.Add(2 * time.Minute),
+			escalated: 2,
+			severity: map[string]uint8{
+				old.ID:          30,
+				critical.ID:     alarms.SeverityMax,
+				recent.ID:       20,
+				acknowledged.ID: 10,
+			},
+		},
+	}
+
+	items := map[string]alarms.Alarm{old.ID: old, critical.ID: critical, maxed.ID: maxed, recent.ID: recent, acknowledged.ID: acknowledged}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			escalated, err := repo.EscalateAlarms(context.Background(), tc.before, tc.at, 10)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.escalated, uint64(len(escalated)))
+			for id, severity := range tc.severity {
+				alarm, err := repo.ViewAlarm(context.Background(), id, items[id].DomainID)
+				require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+				assert.Equal(t, severity, alarm.Severity, fmt.Sprintf("%s: unexpected severity of alarm %s", tc.desc, id))
+			}
+		})
+	}
+}
+
 func TestViewAlarm(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM alarms")
@@ -51,6 +51,73 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS alarms`,
 				},
 			},
+			{
+				Id: "alarms_02",
+				Up: []string{
+					`ALTER TABLE alarms
+						ADD COLUMN IF NOT EXISTS occurrences      BIGINT NOT NULL DEFAULT 1 CHECK (occurrences > 0),
+						ADD COLUMN IF NOT EXISTS last_occurred_at TIMESTAMPTZ NULL,
+						ADD COLUMN IF NOT EXISTS escalated_at     TIMESTAMPTZ NULL;`,
+					`UPDATE alarms SET last_occurred_at = created_at;`,
+					// Only the latest active alarm of the same source stays active.
+					`UPDATE alarms SET status = 1, resolved_at = CURRENT_TIMESTAMP
+					WHERE status = 0 AND EXISTS (
+						SELECT 1 FROM alarms latest
+						WHERE latest.status = 0
+							AND latest.domain_id = alarms.domain_id
+							AND latest.rule_id = alarms.rule_id
+							AND latest.channel_id = alarms.channel_id
+							AND latest.client_id = alarms.client_id
+							AND latest.subtopic = alarms.subtopic
+							AND latest.measurement = alarms.measurement
+							AND (latest.created_at, latest.id) > (alarms.created_at, alarms.id)
+					);`,
+					`ALTER TABLE alarms ALTER COLUMN last_occurred_at SET NOT NULL;`,
+					"CREATE UNIQUE INDEX IF NOT EXISTS idx_alarms_active ON alarms (domain_id, rule_id, channel_id, client_id, subtopic, measurement) WHERE status = 0;",
+					"CREATE INDEX IF NOT EXISTS idx_alarms_last_occurred_at ON alarms (last_occurred_at) WHERE status = 0;",
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_alarms_last_occurred_at;`,
+					`DROP INDEX IF EXISTS idx_alarms_active;`,
+					`ALTER TABLE alarms
+						DROP COLUMN IF EXISTS occurrences,
+						DROP COLUMN IF EXISTS last_occurred_at,
+						DROP COLUMN IF EXISTS escalated_at;`,
+				},
+			},
+			{
+				Id: "alarms_03",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS alarm_notification_policies (
+						id           VARCHAR(36) PRIMARY KEY,
+						domain_id    VARCHAR(36) NOT NULL,
+						name         VARCHAR(1024) NOT NULL,
+						min_severity SMALLINT NOT NULL DEFAULT 0 CHECK (min_severity >= 0),
+						max_severity SMALLINT NOT NULL DEFAULT 100 CHECK (max_severity >= min_severity),
+						events       TEXT[] NOT NULL DEFAULT '{}',
+						recipients   JSONB NOT NULL DEFAULT '{}',
+						escalation   JSONB NOT NULL DEFAULT '[]',
+						created_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+						created_by   VARCHAR(36) NULL,
+						updated_at   TIMESTAMPTZ NULL,
+						updated_by   VARCHAR(36) NULL,
+						UNIQUE (domain_id, name)
+					);`,
+					`CREATE TABLE IF NOT EXISTS alarm_escalations (
+						alarm_id    VARCHAR(36) NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+						policy_id   VARCHAR(36) NOT NULL REFERENCES alarm_notification_policies (id) ON DELETE CASCADE,
+						level       SMALLINT NOT NULL CHECK (level > 0),
+						notified_at TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (alarm_id, policy_id, level)
+					);`,
+					"CREATE INDEX IF NOT EXISTS idx_alarms_unacknowledged ON alarms (domain_id, created_at) WHERE status = 0 AND acknowledged_at IS NULL;",
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_alarms_unacknowledged;`,
+					`DROP TABLE IF EXISTS alarm_escalations;`,
+					`DROP TABLE IF EXISTS alarm_notification_policies;`,
+				},
+			},
 		},
 	}
 
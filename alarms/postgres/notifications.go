@@ -0,0 +1,312 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/lib/pq"
+)
+
+const policyColumns = `id, domain_id, name, min_severity, max_severity, events, recipients, escalation,
+	created_at, created_by, updated_at, updated_by`
+
+func (r *repository) AddNotificationPolicy(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	query := fmt.Sprintf(`INSERT INTO alarm_notification_policies (%s)
+		VALUES (:id, :domain_id, :name, :min_severity, :max_severity, :events, :recipients, :escalation,
+			:created_at, :created_by, :updated_at, :updated_by)
+		RETURNING %s;`, policyColumns, policyColumns)
+
+	return r.savePolicy(ctx, query, policy, repoerr.ErrCreateEntity)
+}
+
+func (r *repository) ViewNotificationPolicy(ctx context.Context, domainID, id string) (alarms.NotificationPolicy, error) {
+	query := fmt.Sprintf(`SELECT %s FROM alarm_notification_policies WHERE id = :id AND domain_id = :domain_id;`, policyColumns)
+	rows, err := r.db.NamedQueryContext(ctx, query, map[string]any{"id": id, "domain_id": domainID})
+	if err != nil {
+		return alarms.NotificationPolicy{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return alarms.NotificationPolicy{}, repoerr.ErrNotFound
+	}
+
+	var dbp dbPolicy
+	if err := rows.StructScan(&dbp); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toPolicy(dbp)
+}
+
+func (r *repository) UpdateNotificationPolicy(ctx context.Context, policy alarms.NotificationPolicy) (alarms.NotificationPolicy, error) {
+	query := fmt.Sprintf(`UPDATE alarm_notification_policies SET name = :name, min_severity = :min_severity,
+			max_severity = :max_severity, events = :events, recipients = :recipients, escalation = :escalation,
+			updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id AND domain_id = :domain_id
+		RETURNING %s;`, policyColumns)
+
+	return r.savePolicy(ctx, query, policy, repoerr.ErrUpdateEntity)
+}
+
+func (r *repository) ListNotificationPolicies(ctx context.Context, pm alarms.NotificationPoliciesPageMeta) (alarms.NotificationPoliciesPage, error) {
+	query := fmt.Sprintf(`SELECT %s FROM alarm_notification_policies WHERE domain_id = :domain_id
+		ORDER BY created_at, id LIMIT :limit OFFSET :offset;`, policyColumns)
+	policies, err := r.listPolicies(ctx, query, pm)
+	if err != nil {
+		return alarms.NotificationPoliciesPage{}, err
+	}
+
+	cq := `SELECT COUNT(*) AS total_count FROM alarm_notification_policies WHERE domain_id = :domain_id;`
+	total, err := postgres.Total(ctx, r.db, cq, pm)
+	if err != nil {
+		return alarms.NotificationPoliciesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.NotificationPoliciesPage{
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Total:    total,
+		Policies: policies,
+	}, nil
+}
+
+func (r *repository) RemoveNotificationPolicy(ctx context.Context, domainID, id string) error {
+	query := `DELETE FROM alarm_notification_policies WHERE id = :id AND domain_id = :domain_id;`
+	result, err := r.db.NamedExecContext(ctx, query, map[string]any{"id": id, "domain_id": domainID})
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) MatchNotificationPolicies(ctx context.Context, domainID string, severity uint8) ([]alarms.NotificationPolicy, error) {
+	query := fmt.Sprintf(`SELECT %s FROM alarm_notification_policies
+		WHERE domain_id = :domain_id AND :severity BETWEEN min_severity AND max_severity
+		ORDER BY created_at, id;`, policyColumns)
+
+	return r.listPolicies(ctx, query, map[string]any{"domain_id": domainID, "severity": severity})
+}
+
+func (r *repository) ListDueEscalations(ctx context.Context, due time.Time) ([]alarms.Escalation, error) {
+	query := fmt.Sprintf(`SELECT %s, p.id AS policy_id, l.level
+		FROM alarms
+		JOIN alarm_notification_policies p ON p.domain_id = alarms.domain_id
+			AND alarms.severity BETWEEN p.min_severity AND p.max_severity
+		CROSS JOIN LATERAL jsonb_array_elements(p.escalation) WITH ORDINALITY AS l(value, level)
+		WHERE alarms.status = 0
+			AND alarms.acknowledged_at IS NULL
+			AND alarms.created_at + make_interval(mins => (l.value->>'after_minutes')::INT) <= :due
+			AND NOT EXISTS (
+				SELECT 1 FROM alarm_escalations e
+				WHERE e.alarm_id = alarms.id AND e.policy_id = p.id AND e.level = l.level
+			)
+		ORDER BY alarms.created_at, alarms.id, p.id, l.level;`, alarmColumns)
+
+	rows, err := r.db.NamedQueryContext(ctx, query, map[string]any{"due": due})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var escalations []alarms.Escalation
+	for rows.Next() {
+		var dbe dbEscalation
+		if err := rows.StructScan(&dbe); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		a, err := toAlarm(dbe.dbAlarm)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		escalations = append(escalations, alarms.Escalation{
+			Alarm:    a,
+			PolicyID: dbe.PolicyID,
+			Level:    dbe.Level,
+		})
+	}
+
+	return escalations, nil
+}
+
+func (r *repository) AddEscalation(ctx context.Context, escalation alarms.Escalation, notifiedAt time.Time) error {
+	query := `INSERT INTO alarm_escalations (alarm_id, policy_id, level, notified_at)
+		VALUES (:alarm_id, :policy_id, :level, :notified_at);`
+	if _, err := r.db.NamedExecContext(ctx, query, map[string]any{
+		"alarm_id":    escalation.Alarm.ID,
+		"policy_id":   escalation.PolicyID,
+		"level":       escalation.Level,
+		"notified_at": notifiedAt,
+	}); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (r *repository) savePolicy(ctx context.Context, query string, policy alarms.NotificationPolicy, wrapErr error) (alarms.NotificationPolicy, error) {
+	dbp, err := toDBPolicy(policy)
+	if err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(wrapErr, err)
+	}
+	rows, err := r.db.NamedQueryContext(ctx, query, dbp)
+	if err != nil {
+		return alarms.NotificationPolicy{}, postgres.HandleError(wrapErr, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return alarms.NotificationPolicy{}, repoerr.ErrNotFound
+	}
+
+	dbp = dbPolicy{}
+	if err := rows.StructScan(&dbp); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(wrapErr, err)
+	}
+
+	return toPolicy(dbp)
+}
+
+func (r *repository) listPolicies(ctx context.Context, query string, params any) ([]alarms.NotificationPolicy, error) {
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	policies := []alarms.NotificationPolicy{}
+	for rows.Next() {
+		var dbp dbPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		p, err := toPolicy(dbp)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+type dbEscalation struct {
+	dbAlarm
+	PolicyID string `db:"policy_id"`
+	Level    uint   `db:"level"`
+}
+
+type dbPolicy struct {
+	ID          string         `db:"id"`
+	DomainID    string         `db:"domain_id"`
+	Name        string         `db:"name"`
+	MinSeverity uint8          `db:"min_severity"`
+	MaxSeverity uint8          `db:"max_severity"`
+	Events      pq.StringArray `db:"events"`
+	Recipients  []byte         `db:"recipients"`
+	Escalation  []byte         `db:"escalation"`
+	CreatedAt   time.Time      `db:"created_at"`
+	CreatedBy   *string        `db:"created_by,omitempty"`
+	UpdatedAt   sql.NullTime   `db:"updated_at,omitempty"`
+	UpdatedBy   *string        `db:"updated_by,omitempty"`
+}
+
+func toDBPolicy(p alarms.NotificationPolicy) (dbPolicy, error) {
+	recipients, err := json.Marshal(p.Recipients)
+	if err != nil {
+		return dbPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	escalation := []byte("[]")
+	if len(p.Escalation) > 0 {
+		if escalation, err = json.Marshal(p.Escalation); err != nil {
+			return dbPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+	}
+	var createdBy *string
+	if p.CreatedBy != "" {
+		createdBy = &p.CreatedBy
+	}
+	var updatedBy *string
+	if p.UpdatedBy != "" {
+		updatedBy = &p.UpdatedBy
+	}
+	var updatedAt sql.NullTime
+	if !p.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: p.UpdatedAt, Valid: true}
+	}
+	events := pq.StringArray{}
+	if len(p.Events) > 0 {
+		events = pq.StringArray(p.Events)
+	}
+
+	return dbPolicy{
+		ID:          p.ID,
+		DomainID:    p.DomainID,
+		Name:        p.Name,
+		MinSeverity: p.MinSeverity,
+		MaxSeverity: p.MaxSeverity,
+		Events:      events,
+		Recipients:  recipients,
+		Escalation:  escalation,
+		CreatedAt:   p.CreatedAt,
+		CreatedBy:   createdBy,
+		UpdatedAt:   updatedAt,
+		UpdatedBy:   updatedBy,
+	}, nil
+}
+
+func toPolicy(dbp dbPolicy) (alarms.NotificationPolicy, error) {
+	var recipients alarms.Recipients
+	if err := json.Unmarshal(dbp.Recipients, &recipients); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	var escalation []alarms.EscalationLevel
+	if err := json.Unmarshal(dbp.Escalation, &escalation); err != nil {
+		return alarms.NotificationPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	var createdBy string
+	if dbp.CreatedBy != nil {
+		createdBy = *dbp.CreatedBy
+	}
+	var updatedBy string
+	if dbp.UpdatedBy != nil {
+		updatedBy = *dbp.UpdatedBy
+	}
+	var updatedAt time.Time
+	if dbp.UpdatedAt.Valid {
+		updatedAt = dbp.UpdatedAt.Time
+	}
+	var events []string
+	if len(dbp.Events) > 0 {
+		events = dbp.Events
+	}
+
+	return alarms.NotificationPolicy{
+		ID:          dbp.ID,
+		DomainID:    dbp.DomainID,
+		Name:        dbp.Name,
+		MinSeverity: dbp.MinSeverity,
+		MaxSeverity: dbp.MaxSeverity,
+		Events:      events,
+		Recipients:  recipients,
+		Escalation:  escalation,
+		CreatedAt:   dbp.CreatedAt,
+		CreatedBy:   createdBy,
+		UpdatedAt:   updatedAt,
+		UpdatedBy:   updatedBy,
+	}, nil
+}
@@ -0,0 +1,392 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/alarms/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cleanPolicies(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarm_notification_policies")
+		require.Nil(t, err, fmt.Sprintf("clean notification policies unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+}
+
+func newPolicy(t *testing.T, domainID string) alarms.NotificationPolicy {
+	return alarms.NotificationPolicy{
+		ID:          generateUUID(t),
+		DomainID:    domainID,
+		Name:        namegen.Generate(),
+		MinSeverity: 50,
+		MaxSeverity: alarms.SeverityMax,
+		Events:      []string{alarms.CreatedEvent, alarms.ResolvedEvent},
+		Recipients: alarms.Recipients{
+			Emails:   []string{"admin@example.com"},
+			Webhooks: []alarms.Webhook{{URL: "https://example.com/alarms", Secret: "secret"}},
+		},
+		Escalation: []alarms.EscalationLevel{
+			{AfterMinutes: 15, Recipients: alarms.Recipients{Emails: []string{"operator@example.com"}}},
+			{AfterMinutes: 60, Recipients: alarms.Recipients{Emails: []string{"manager@example.com"}}},
+		},
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+	}
+}
+
+func TestAddNotificationPolicy(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	policy := newPolicy(t, generateUUID(t))
+	duplicate := newPolicy(t, policy.DomainID)
+	duplicate.Name = policy.Name
+	otherDomain := newPolicy(t, generateUUID(t))
+	otherDomain.Name = policy.Name
+
+	cases := []struct {
+		desc   string
+		policy alarms.NotificationPolicy
+		err    error
+	}{
+		{
+			desc:   "add new policy",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "add policy with existing name",
+			policy: duplicate,
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc:   "add policy with existing name in other domain",
+			policy: otherDomain,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.AddNotificationPolicy(context.Background(), tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.policy, saved, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, saved))
+			}
+		})
+	}
+}
+
+func TestViewNotificationPolicy(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	policy, err := repo.AddNotificationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "view existing policy",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+			err:      nil,
+		},
+		{
+			desc:     "view policy from other domain",
+			id:       policy.ID,
+			domainID: generateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "view non existing policy",
+			id:       generateUUID(t),
+			domainID: policy.DomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.ViewNotificationPolicy(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, policy, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, policy, got))
+			}
+		})
+	}
+}
+
+func TestUpdateNotificationPolicy(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	policy, err := repo.AddNotificationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := policy
+	updated.Name = namegen.Generate()
+	updated.MinSeverity = 0
+	updated.Events = nil
+	updated.Escalation = nil
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	updated.UpdatedBy = generateUUID(t)
+	missing := updated
+	missing.ID = generateUUID(t)
+
+	cases := []struct {
+		desc   string
+		policy alarms.NotificationPolicy
+		err    error
+	}{
+		{
+			desc:   "update existing policy",
+			policy: updated,
+			err:    nil,
+		},
+		{
+			desc:   "update non existing policy",
+			policy: missing,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := repo.UpdateNotificationPolicy(context.Background(), tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.policy, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, got))
+			}
+		})
+	}
+}
+
+func TestListNotificationPolicies(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	domainID := generateUUID(t)
+	var items []alarms.NotificationPolicy
+	for i := range 10 {
+		p := newPolicy(t, domainID)
+		p.CreatedAt = p.CreatedAt.Add(time.Duration(i) * time.Second)
+		p, err := repo.AddNotificationPolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		items = append(items, p)
+	}
+	_, err := repo.AddNotificationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		pm   alarms.NotificationPoliciesPageMeta
+		page alarms.NotificationPoliciesPage
+	}{
+		{
+			desc: "list all policies of the domain",
+			pm:   alarms.NotificationPoliciesPageMeta{Offset: 0, Limit: 10, DomainID: domainID},
+			page: alarms.NotificationPoliciesPage{Offset: 0, Limit: 10, Total: 10, Policies: items},
+		},
+		{
+			desc: "list policies with offset and limit",
+			pm:   alarms.NotificationPoliciesPageMeta{Offset: 5, Limit: 2, DomainID: domainID},
+			page: alarms.NotificationPoliciesPage{Offset: 5, Limit: 2, Total: 10, Policies: items[5:7]},
+		},
+		{
+			desc: "list policies of domain without policies",
+			pm:   alarms.NotificationPoliciesPageMeta{Offset: 0, Limit: 10, DomainID: generateUUID(t)},
+			page: alarms.NotificationPoliciesPage{Offset: 0, Limit: 10, Total: 0, Policies: []alarms.NotificationPolicy{}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListNotificationPolicies(context.Background(), tc.pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+		})
+	}
+}
+
+func TestRemoveNotificationPolicy(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	policy, err := repo.AddNotificationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "remove policy from other domain",
+			id:       policy.ID,
+			domainID: generateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "remove existing policy",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+			err:      nil,
+		},
+		{
+			desc:     "remove removed policy",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.RemoveNotificationPolicy(context.Background(), tc.domainID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestMatchNotificationPolicies(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	domainID := generateUUID(t)
+	critical := newPolicy(t, domainID)
+	critical, err := repo.AddNotificationPolicy(context.Background(), critical)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	low := newPolicy(t, domainID)
+	low.MinSeverity = 0
+	low.MaxSeverity = 49
+	low.CreatedAt = low.CreatedAt.Add(time.Second)
+	low, err = repo.AddNotificationPolicy(context.Background(), low)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	all := newPolicy(t, domainID)
+	all.MinSeverity = 0
+	all.CreatedAt = all.CreatedAt.Add(2 * time.Second)
+	all, err = repo.AddNotificationPolicy(context.Background(), all)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		severity uint8
+		policies []alarms.NotificationPolicy
+	}{
+		{
+			desc:     "match high severity",
+			domainID: domainID,
+			severity: 80,
+			policies: []alarms.NotificationPolicy{critical, all},
+		},
+		{
+			desc:     "match low severity",
+			domainID: domainID,
+			severity: 10,
+			policies: []alarms.NotificationPolicy{low, all},
+		},
+		{
+			desc:     "match severity at range boundary",
+			domainID: domainID,
+			severity: 50,
+			policies: []alarms.NotificationPolicy{critical, all},
+		},
+		{
+			desc:     "match other domain",
+			domainID: generateUUID(t),
+			severity: 80,
+			policies: []alarms.NotificationPolicy{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			policies, err := repo.MatchNotificationPolicies(context.Background(), tc.domainID, tc.severity)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.policies, policies, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policies, policies))
+		})
+	}
+}
+
+func TestListDueEscalations(t *testing.T) {
+	cleanPolicies(t)
+	repo := postgres.NewAlarmsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	domainID := generateUUID(t)
+	policy, err := repo.AddNotificationPolicy(context.Background(), newPolicy(t, domainID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	newAlarm := func(severity uint8, createdAt time.Time) alarms.Alarm {
+		a, err := repo.CreateAlarm(context.Background(), alarms.Alarm{
+			ID:          generateUUID(t),
+			RuleID:      generateUUID(t),
+			DomainID:    domainID,
+			ChannelID:   generateUUID(t),
+			ClientID:    generateUUID(t),
+			Subtopic:    namegen.Generate(),
+			Measurement: namegen.Generate(),
+			Value:       namegen.Generate(),
+			Cause:       namegen.Generate(),
+			Severity:    severity,
+			CreatedAt:   createdAt,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		return a
+	}
+	// Due for the first level only.
+	recent := newAlarm(80, now.Add(-30*time.Minute))
+	// Due for both levels.
+	old := newAlarm(80, now.Add(-2*time.Hour))
+	// Severity out of the policy range.
+	newAlarm(10, now.Add(-2*time.Hour))
+	// Not due yet.
+	newAlarm(80, now.Add(-time.Minute))
+	acknowledged := newAlarm(80, now.Add(-2*time.Hour))
+	acknowledged.AcknowledgedBy = generateUUID(t)
+	acknowledged.AcknowledgedAt = now
+	_, err = repo.UpdateAlarm(context.Background(), acknowledged)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	due, err := repo.ListDueEscalations(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, due, 3)
+	assert.Equal(t, old.ID, due[0].Alarm.ID)
+	assert.Equal(t, uint(1), due[0].Level)
+	assert.Equal(t, old.ID, due[1].Alarm.ID)
+	assert.Equal(t, uint(2), due[1].Level)
+	assert.Equal(t, recent.ID, due[2].Alarm.ID)
+	assert.Equal(t, uint(1), due[2].Level)
+	for _, e := range due {
+		assert.Equal(t, policy.ID, e.PolicyID)
+	}
+
+	err = repo.AddEscalation(context.Background(), due[0], now)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.AddEscalation(context.Background(), due[0], now)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("expected %s got %s\n", repoerr.ErrConflict, err))
+
+	due, err = repo.ListDueEscalations(context.Background(), now)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, due, 2, "notified escalation level should not be due again")
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var groupByColumns = map[string]string{
+	alarms.GroupByStatus:   "CAST(alarms.status AS TEXT)",
+	alarms.GroupBySeverity: "CAST(alarms.severity AS TEXT)",
+	alarms.GroupByRule:     "alarms.rule_id",
+	alarms.GroupByChannel:  "alarms.channel_id",
+	alarms.GroupByClient:   "alarms.client_id",
+}
+
+func (r *repository) AlarmStats(ctx context.Context, q alarms.StatsQuery) (alarms.Stats, error) {
+	return r.stats(ctx, q, pageQueryConditions(q.PageMetadata))
+}
+
+func (r *repository) UserAlarmStats(ctx context.Context, userID string, q alarms.StatsQuery) (alarms.Stats, error) {
+	q.UserID = userID
+	conditions := append([]string{userAlarmsCondition}, pageQueryConditions(q.PageMetadata)...)
+
+	return r.stats(ctx, q, conditions)
+}
+
+func (r *repository) stats(ctx context.Context, q alarms.StatsQuery, conditions []string) (alarms.Stats, error) {
+	key, ok := groupByColumns[q.GroupBy]
+	if !ok {
+		return alarms.Stats{}, errors.Wrap(repoerr.ErrViewEntity, alarms.ErrInvalidGroupBy)
+	}
+	bucket := "CAST(NULL AS TIMESTAMPTZ)"
+	if q.Interval != "" {
+		bucket = "date_trunc(CAST(:interval AS TEXT), alarms.created_at)"
+	}
+	var where string
+	if len(conditions) > 0 {
+		where = fmt.Sprintf("WHERE %s", strings.Join(conditions, " AND "))
+	}
+
+	query := fmt.Sprintf(`SELECT %s AS key, %s AS bucket, COUNT(*) AS count,
+			COUNT(alarms.acknowledged_at) AS acknowledged, COUNT(alarms.resolved_at) AS resolved,
+			CAST(COALESCE(AVG(EXTRACT(EPOCH FROM (alarms.acknowledged_at - alarms.created_at))), 0) AS DOUBLE PRECISION) AS mtta,
+			CAST(COALESCE(AVG(EXTRACT(EPOCH FROM (alarms.resolved_at - alarms.created_at))), 0) AS DOUBLE PRECISION) AS mttr
+		FROM alarms %s
+		GROUP BY 1, 2
+		ORDER BY 2, 1;`, key, bucket, where)
+
+	rows, err := r.db.NamedQueryContext(ctx, query, q)
+	if err != nil {
+		return alarms.Stats{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var counts []alarms.StatsCount
+	for rows.Next() {
+		var dbc dbStatsCount
+		if err := rows.StructScan(&dbc); err != nil {
+			return alarms.Stats{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		counts = append(counts, toStatsCount(q.GroupBy, dbc))
+	}
+	if err := rows.Err(); err != nil {
+		return alarms.Stats{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.NewStats(q, counts), nil
+}
+
+type dbStatsCount struct {
+	Key          string       `db:"key"`
+	Bucket       sql.NullTime `db:"bucket"`
+	Count        uint64       `db:"count"`
+	Acknowledged uint64       `db:"acknowledged"`
+	Resolved     uint64       `db:"resolved"`
+	MTTA         float64      `db:"mtta"`
+	MTTR         float64      `db:"mttr"`
+}
+
+func toStatsCount(groupBy string, dbc dbStatsCount) alarms.StatsCount {
+	key := dbc.Key
+	if groupBy == alarms.GroupByStatus {
+		if status, err := strconv.ParseUint(dbc.Key, 10, 8); err == nil {
+			key = alarms.Status(status).String()
+		}
+	}
+	c := alarms.StatsCount{
+		Key:                   key,
+		Count:                 dbc.Count,
+		Acknowledged:          dbc.Acknowledged,
+		Resolved:              dbc.Resolved,
+		MeanTimeToAcknowledge: dbc.MTTA,
+		MeanTimeToResolve:     dbc.MTTR,
+	}
+	if dbc.Bucket.Valid {
+		c.Bucket = dbc.Bucket.Time.UTC()
+	}
+
+	return c
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/alarms/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlarmStats(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+	repo := postgres.NewAlarmsRepo(db)
+
+	domainID := generateUUID(t)
+	ruleID := generateUUID(t)
+	otherRuleID := generateUUID(t)
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// Three alarms of the rule on the first day, one of them acknowledged after
+	// 60 seconds and resolved after 120 seconds, and one alarm of the other
+	// rule on the next day, acknowledged after 180 seconds.
+	for i, rid := range []string{ruleID, ruleID, ruleID, otherRuleID} {
+		createdAt := day.Add(time.Duration(i) * time.Minute)
+		if rid == otherRuleID {
+			createdAt = day.Add(24 * time.Hour)
+		}
+		alarm, err := repo.CreateAlarm(context.Background(), alarms.Alarm{
+			ID:          generateUUID(t),
+			RuleID:      rid,
+			DomainID:    domainID,
+			ChannelID:   generateUUID(t),
+			ClientID:    generateUUID(t),
+			Measurement: "temperature",
+			Value:       "30",
+			Cause:       "threshold exceeded",
+			Severity:    uint8(50 + i*10),
+			CreatedAt:   createdAt,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		switch i {
+		case 0:
+			_, err = db.Exec(`UPDATE alarms SET acknowledged_at = created_at + INTERVAL '60 seconds',
+				resolved_at = created_at + INTERVAL '120 seconds', status = 1 WHERE id = $1`, alarm.ID)
+		case 3:
+			_, err = db.Exec(`UPDATE alarms SET acknowledged_at = created_at + INTERVAL '180 seconds' WHERE id = $1`, alarm.ID)
+		}
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	filter := alarms.PageMetadata{
+		DomainID: domainID,
+		Status:   alarms.AllStatus,
+		Severity: math.MaxUint8,
+	}
+
+	cases := []struct {
+		desc   string
+		query  alarms.StatsQuery
+		counts []alarms.StatsCount
+		mtta   float64
+		mttr   float64
+		err    error
+	}{
+		{
+			desc:  "group by status",
+			query: alarms.StatsQuery{PageMetadata: filter, GroupBy: alarms.GroupByStatus},
+			counts: []alarms.StatsCount{
+				{Key: alarms.Active, Count: 3, Acknowledged: 1, MeanTimeToAcknowledge: 180},
+				{Key: alarms.Cleared, Count: 1, Acknowledged: 1, Resolved: 1, MeanTimeToAcknowledge: 60, MeanTimeToResolve: 120},
+			},
+			mtta: 120,
+			mttr: 120,
+		},
+		{
+			desc:  "group by rule per day",
+			query: alarms.StatsQuery{PageMetadata: filter, GroupBy: alarms.GroupByRule, Interval: alarms.DayInterval},
+			counts: []alarms.StatsCount{
+				{Key: ruleID, Bucket: day.Truncate(24 * time.Hour), Count: 3, Acknowledged: 1, Resolved: 1, MeanTimeToAcknowledge: 60, MeanTimeToResolve: 120},
+				{Key: otherRuleID, Bucket: day.Truncate(24 * time.Hour).Add(24 * time.Hour), Count: 1, Acknowledged: 1, MeanTimeToAcknowledge: 180},
+			},
+			mtta: 120,
+			mttr: 120,
+		},
+		{
+			desc: "filter by rule",
+			query: alarms.StatsQuery{
+				PageMetadata: alarms.PageMetadata{DomainID: domainID, RuleID: otherRuleID, Status: alarms.AllStatus, Severity: math.MaxUint8},
+				GroupBy:      alarms.GroupBySeverity,
+			},
+			counts: []alarms.StatsCount{
+				{Key: "80", Count: 1, Acknowledged: 1, MeanTimeToAcknowledge: 180},
+			},
+			mtta: 180,
+		},
+		{
+			desc: "no matching alarms",
+			query: alarms.StatsQuery{
+				PageMetadata: alarms.PageMetadata{DomainID: generateUUID(t), Status: alarms.AllStatus, Severity: math.MaxUint8},
+				GroupBy:      alarms.GroupByClient,
+			},
+			counts: []alarms.StatsCount{},
+		},
+		{
+			desc:  "invalid group by",
+			query: alarms.StatsQuery{PageMetadata: filter, GroupBy: "measurement"},
+			err:   repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			stats, err := repo.AlarmStats(context.Background(), tc.query)
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Equal(t, tc.counts, stats.Counts)
+			assert.InDelta(t, tc.mtta, stats.MeanTimeToAcknowledge, 0.001)
+			assert.InDelta(t, tc.mttr, stats.MeanTimeToResolve, 0.001)
+		})
+	}
+}
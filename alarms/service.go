@@ -5,24 +5,39 @@ package alarms
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	pkglog "github.com/absmach/supermq/pkg/logger"
+	"github.com/absmach/supermq/pkg/ticker"
 )
 
 type service struct {
-	idp  supermq.IDProvider
-	repo Repository
+	idp       supermq.IDProvider
+	repo      Repository
+	notifier  Notifier
+	runInfo   chan pkglog.RunInfo
+	ticker    ticker.Ticker
+	lifecycle Lifecycle
 }
 
 var _ Service = (*service)(nil)
 
-func NewService(idp supermq.IDProvider, repo Repository) Service {
+func NewService(idp supermq.IDProvider, repo Repository, notifier Notifier, runInfo chan pkglog.RunInfo, tck ticker.Ticker, lifecycle Lifecycle) Service {
 	return &service{
-		idp:  idp,
-		repo: repo,
+		idp:       idp,
+		repo:      repo,
+		notifier:  notifier,
+		runInfo:   runInfo,
+		ticker:    tck,
+		lifecycle: lifecycle,
 	}
 }
 
@@ -40,9 +55,36 @@ func (s *service) CreateAlarm(ctx context.Context, alarm Alarm) error {
 		return err
 	}
 
-	if _, err = s.repo.CreateAlarm(ctx, alarm); err != nil && err != repoerr.ErrNotFound {
+	if alarm.Status == ClearedStatus {
+		if !s.lifecycle.ResolveOnClear {
+			// The active alarm stays active until it is resolved,
+			// so the cleared condition only updates its value.
+			if _, err := s.repo.UpdateAlarmValue(ctx, alarm); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+				return err
+			}
+			return nil
+		}
+		cleared, err := s.repo.ClearAlarm(ctx, alarm)
+		switch {
+		case errors.Contains(err, repoerr.ErrNotFound):
+			return nil
+		case err != nil:
+			return err
+		}
+		s.notify(ctx, ResolvedEvent, cleared)
+		return nil
+	}
+
+	alarm.Occurrences = 1
+	alarm.LastOccurredAt = alarm.CreatedAt
+	saved, err := s.repo.CreateAlarm(ctx, alarm)
+	if err != nil {
 		return err
 	}
+	// Repeated triggers only update the active alarm.
+	if saved.Occurrences == 1 {
+		s.notify(ctx, CreatedEvent, saved)
+	}
 
 	return nil
 }
@@ -58,13 +100,262 @@ func (s *service) ListAlarms(ctx context.Context, session authn.Session, pm Page
 	return s.repo.ListUserAlarms(ctx, session.UserID, pm)
 }
 
+func (s *service) AlarmStats(ctx context.Context, session authn.Session, q StatsQuery) (Stats, error) {
+	if session.SuperAdmin {
+		return s.repo.AlarmStats(ctx, q)
+	}
+	return s.repo.UserAlarmStats(ctx, session.UserID, q)
+}
+
 func (s *service) DeleteAlarm(ctx context.Context, session authn.Session, alarmID string) error {
 	return s.repo.DeleteAlarm(ctx, alarmID)
 }
 
 func (s *service) UpdateAlarm(ctx context.Context, session authn.Session, alarm Alarm) (Alarm, error) {
+	stored, err := s.repo.ViewAlarm(ctx, alarm.ID, session.DomainID)
+	if err != nil {
+		return Alarm{}, err
+	}
 	alarm.UpdatedAt = time.Now()
 	alarm.UpdatedBy = session.UserID
 
-	return s.repo.UpdateAlarm(ctx, alarm)
+	updated, err := s.repo.UpdateAlarm(ctx, alarm)
+	if err != nil {
+		return Alarm{}, err
+	}
+	// Only the changes are notified, so repeating the same
+	// update does not send the notifications again.
+	if alarm.AssigneeID != "" && alarm.AssigneeID != stored.AssigneeID {
+		s.notify(ctx, AssignedEvent, updated)
+	}
+	if alarm.AcknowledgedBy != "" && alarm.AcknowledgedBy != stored.AcknowledgedBy {
+		s.notify(ctx, AcknowledgedEvent, updated)
+	}
+	if (alarm.ResolvedBy != "" && alarm.ResolvedBy != stored.ResolvedBy) || (alarm.Status == ClearedStatus && stored.Status != ClearedStatus) {
+		s.notify(ctx, ResolvedEvent, updated)
+	}
+
+	return updated, nil
+}
+
+func (s *service) AddNotificationPolicy(ctx context.Context, session authn.Session, policy NotificationPolicy) (NotificationPolicy, error) {
+	id, err := s.idp.ID()
+	if err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	policy.ID = id
+	policy.DomainID = session.DomainID
+	policy.CreatedAt = time.Now().UTC()
+	policy.CreatedBy = session.UserID
+	policy.UpdatedAt = time.Time{}
+	policy.UpdatedBy = ""
+	if err := policy.Validate(); err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	saved, err := s.repo.AddNotificationPolicy(ctx, policy)
+	if err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return saved, nil
+}
+
+func (s *service) ViewNotificationPolicy(ctx context.Context, session authn.Session, id string) (NotificationPolicy, error) {
+	policy, err := s.repo.ViewNotificationPolicy(ctx, session.DomainID, id)
+	if err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return policy, nil
+}
+
+func (s *service) UpdateNotificationPolicy(ctx context.Context, session authn.Session, policy NotificationPolicy) (NotificationPolicy, error) {
+	policy.DomainID = session.DomainID
+	policy.UpdatedAt = time.Now().UTC()
+	policy.UpdatedBy = session.UserID
+	if err := policy.Validate(); err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	updated, err := s.repo.UpdateNotificationPolicy(ctx, policy)
+	if err != nil {
+		return NotificationPolicy{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (s *service) ListNotificationPolicies(ctx context.Context, session authn.Session, pm NotificationPoliciesPageMeta) (NotificationPoliciesPage, error) {
+	pm.DomainID = session.DomainID
+	page, err := s.repo.ListNotificationPolicies(ctx, pm)
+	if err != nil {
+		return NotificationPoliciesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (s *service) RemoveNotificationPolicy(ctx context.Context, session authn.Session, id string) error {
+	if err := s.repo.RemoveNotificationPolicy(ctx, session.DomainID, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (s *service) StartScheduler(ctx context.Context) error {
+	defer s.ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.ticker.Tick():
+			now := time.Now().UTC()
+			if s.lifecycle.QuietPeriod > 0 {
+				cleared, err := s.repo.ClearQuietAlarms(ctx, now.Add(-s.lifecycle.QuietPeriod), now)
+				s.runInfo <- runInfo("clear quiet alarms", uint64(len(cleared)), err, now)
+				for _, a := range cleared {
+					s.notify(ctx, ResolvedEvent, a)
+				}
+			}
+			if s.lifecycle.EscalateAfter > 0 && s.lifecycle.EscalationStep > 0 {
+				escalated, err := s.repo.EscalateAlarms(ctx, now.Add(-s.lifecycle.EscalateAfter), now, s.lifecycle.EscalationStep)
+				s.runInfo <- runInfo("escalate alarms", uint64(len(escalated)), err, now)
+				for _, a := range escalated {
+					s.notify(ctx, EscalatedEvent, a)
+				}
+			}
+			notified, err := s.notifyEscalations(ctx, now)
+			s.runInfo <- runInfo("notify escalation chains", notified, err, now)
+		}
+	}
+}
+
+// notify sends the notification about the alarm state change to the recipients
+// of the matching notification policies. Notifications are sent in the background,
+// so slow recipients do not hold up the alarms processing.
+func (s *service) notify(ctx context.Context, event string, alarm Alarm) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		policies, err := s.repo.MatchNotificationPolicies(ctx, alarm.DomainID, alarm.Severity)
+		if err != nil {
+			s.runInfo <- notificationInfo(event, alarm, 0, err)
+			return
+		}
+
+		var notified uint64
+		var failed []string
+		var ferr error
+		for _, p := range policies {
+			if !p.Notifies(event) || p.Recipients.empty() {
+				continue
+			}
+			n := Notification{
+				Event:    event,
+				PolicyID: p.ID,
+				Alarm:    alarm,
+				SentAt:   time.Now().UTC(),
+			}
+			if err := s.notifier.Notify(ctx, p.Recipients, n); err != nil {
+				failed, ferr = append(failed, p.ID), err
+				continue
+			}
+			notified++
+		}
+		if len(failed) > 0 {
+			err = fmt.Errorf("policies %s: %w", strings.Join(failed, ", "), ferr)
+		}
+		s.runInfo <- notificationInfo(event, alarm, notified, err)
+	}()
+}
+
+// notifyEscalations notifies the due escalation levels of the notification policies.
+// Each level is recorded before it is notified, so it is notified at most once even
+// if multiple service instances run the scheduler.
+func (s *service) notifyEscalations(ctx context.Context, now time.Time) (uint64, error) {
+	due, err := s.repo.ListDueEscalations(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	policies := make(map[string]NotificationPolicy)
+	var notified uint64
+	var failed []string
+	var ferr error
+	for _, e := range due {
+		p, ok := policies[e.PolicyID]
+		if !ok {
+			if p, err = s.repo.ViewNotificationPolicy(ctx, e.Alarm.DomainID, e.PolicyID); err != nil {
+				failed, ferr = append(failed, e.Alarm.ID), err
+				continue
+			}
+			policies[e.PolicyID] = p
+		}
+		if e.Level == 0 || int(e.Level) > len(p.Escalation) {
+			continue
+		}
+		switch err := s.repo.AddEscalation(ctx, e, now); {
+		case errors.Contains(err, repoerr.ErrConflict):
+			continue
+		case err != nil:
+			failed, ferr = append(failed, e.Alarm.ID), err
+			continue
+		}
+		n := Notification{
+			Event:           UnacknowledgedEvent,
+			PolicyID:        p.ID,
+			EscalationLevel: e.Level,
+			Alarm:           e.Alarm,
+			SentAt:          time.Now().UTC(),
+		}
+		if err := s.notifier.Notify(ctx, p.Escalation[e.Level-1].Recipients, n); err != nil {
+			failed, ferr = append(failed, e.Alarm.ID), err
+			continue
+		}
+		notified++
+	}
+	if len(failed) > 0 {
+		return notified, fmt.Errorf("alarms %s: %w", strings.Join(failed, ", "), ferr)
+	}
+
+	return notified, nil
+}
+
+func notificationInfo(event string, alarm Alarm, notified uint64, err error) pkglog.RunInfo {
+	details := []slog.Attr{
+		slog.String("event", event),
+		slog.String("alarm_id", alarm.ID),
+		slog.String("domain_id", alarm.DomainID),
+		slog.Uint64("policies", notified),
+	}
+	if err != nil {
+		return pkglog.RunInfo{
+			Level:   slog.LevelError,
+			Message: fmt.Sprintf("failed to notify alarm state change: %s", err),
+			Details: details,
+		}
+	}
+
+	return pkglog.RunInfo{
+		Level:   slog.LevelDebug,
+		Message: "notify alarm state change",
+		Details: details,
+	}
+}
+
+func runInfo(op string, n uint64, err error, t time.Time) pkglog.RunInfo {
+	if err != nil {
+		return pkglog.RunInfo{
+			Level:   slog.LevelError,
+			Message: fmt.Sprintf("failed to %s: %s", op, err),
+			Details: []slog.Attr{slog.Time("time", t)},
+		}
+	}
+
+	return pkglog.RunInfo{
+		Level:   slog.LevelDebug,
+		Message: op,
+		Details: []slog.Attr{slog.Uint64("alarms", n), slog.Time("time", t)},
+	}
 }
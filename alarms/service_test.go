@@ -6,75 +6,187 @@ package alarms_test
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/absmach/supermq/alarms"
 	"github.com/absmach/supermq/alarms/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	pkglog "github.com/absmach/supermq/pkg/logger"
+	tmocks "github.com/absmach/supermq/pkg/ticker/mocks"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var idp = uuid.New()
 
+var lifecycle = alarms.Lifecycle{
+	ResolveOnClear: true,
+	QuietPeriod:    time.Hour,
+	EscalateAfter:  time.Minute,
+	EscalationStep: 10,
+}
+
 func newService(t *testing.T, repo *mocks.Repository) alarms.Service {
-	return alarms.NewService(idp, repo)
+	return alarms.NewService(idp, repo, new(mocks.Notifier), make(chan pkglog.RunInfo, 10), new(tmocks.Ticker), lifecycle)
 }
 
 func TestCreateAlarm(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := newService(t, repo)
 	ts := time.Now()
+	alarm := alarms.Alarm{
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		ClientID:    "client-id",
+		Subtopic:    "subtopic",
+		Measurement: "measurement",
+		Value:       "value",
+		Unit:        "unit",
+		Cause:       "cause",
+		Severity:    100,
+		CreatedAt:   ts,
+	}
+	cleared := alarm
+	cleared.Status = alarms.ClearedStatus
+	invalid := alarm
+	invalid.RuleID = ""
+
 	cases := []struct {
-		desc  string
-		alarm alarms.Alarm
-		err   error
+		desc      string
+		alarm     alarms.Alarm
+		lifecycle alarms.Lifecycle
+		createErr error
+		clearErr  error
+		updateErr error
+		created   bool
+		cleared   bool
+		updated   bool
+		err       error
 	}{
 		{
-			desc: "valid alarm",
-			alarm: alarms.Alarm{
-				RuleID:      "rule-id",
-				DomainID:    "domain-id",
-				ChannelID:   "channel-id",
-				ClientID:    "client-id",
-				Subtopic:    "subtopic",
-				Measurement: "measurement",
-				Value:       "value",
-				Unit:        "unit",
-				Cause:       "cause",
-				Severity:    100,
-				CreatedAt:   ts,
-			},
-			err: nil,
+			desc:      "valid alarm",
+			alarm:     alarm,
+			lifecycle: lifecycle,
+			created:   true,
+			err:       nil,
 		},
 		{
-			desc: "missing rule_id",
-			alarm: alarms.Alarm{
-				DomainID:    "domain-id",
-				ChannelID:   "channel-id",
-				ClientID:    "client-id",
-				Subtopic:    "subtopic",
-				Measurement: "measurement",
-				Value:       "value",
-				Unit:        "unit",
-				Cause:       "cause",
-				Severity:    100,
-				CreatedAt:   ts,
-			},
-			err: errors.New("rule_id is required"),
+			desc:      "valid alarm with failed repository",
+			alarm:     alarm,
+			lifecycle: lifecycle,
+			createErr: repoerr.ErrCreateEntity,
+			created:   true,
+			err:       repoerr.ErrCreateEntity,
+		},
+		{
+			desc:      "missing rule_id",
+			alarm:     invalid,
+			lifecycle: lifecycle,
+			err:       errors.New("rule_id is required"),
+		},
+		{
+			desc:      "cleared alarm",
+			alarm:     cleared,
+			lifecycle: lifecycle,
+			cleared:   true,
+			err:       nil,
+		},
+		{
+			desc:      "cleared alarm without active alarm",
+			alarm:     cleared,
+			lifecycle: lifecycle,
+			clearErr:  repoerr.ErrNotFound,
+			cleared:   true,
+			err:       nil,
+		},
+		{
+			desc:      "cleared alarm with failed repository",
+			alarm:     cleared,
+			lifecycle: lifecycle,
+			clearErr:  repoerr.ErrUpdateEntity,
+			cleared:   true,
+			err:       repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "cleared alarm with wrapped not found error",
+			alarm:     cleared,
+			lifecycle: lifecycle,
+			clearErr:  errors.Wrap(repoerr.ErrNotFound, errors.New("no rows")),
+			cleared:   true,
+			err:       nil,
+		},
+		{
+			desc:      "cleared alarm without resolve on clear",
+			alarm:     cleared,
+			lifecycle: alarms.Lifecycle{},
+			updated:   true,
+			err:       nil,
+		},
+		{
+			desc:      "cleared alarm without resolve on clear and active alarm",
+			alarm:     cleared,
+			lifecycle: alarms.Lifecycle{},
+			updateErr: repoerr.ErrNotFound,
+			updated:   true,
+			err:       nil,
+		},
+		{
+			desc:      "cleared alarm without resolve on clear with failed repository",
+			alarm:     cleared,
+			lifecycle: alarms.Lifecycle{},
+			updateErr: repoerr.ErrUpdateEntity,
+			updated:   true,
+			err:       repoerr.ErrUpdateEntity,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := repo.On("CreateAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.err)
+			runInfo := make(chan pkglog.RunInfo, 10)
+			svc := alarms.NewService(idp, repo, new(mocks.Notifier), runInfo, new(tmocks.Ticker), tc.lifecycle)
+			saved := tc.alarm
+			saved.Occurrences = 1
+			repoCall := repo.On("CreateAlarm", context.Background(), mock.Anything).Return(saved, tc.createErr)
+			repoCall1 := repo.On("ClearAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.clearErr)
+			repoCall2 := repo.On("MatchNotificationPolicies", mock.Anything, tc.alarm.DomainID, tc.alarm.Severity).Return([]alarms.NotificationPolicy{}, nil)
+			repoCall3 := repo.On("UpdateAlarmValue", context.Background(), mock.Anything).Return(tc.alarm, tc.updateErr)
 			err := svc.CreateAlarm(context.Background(), tc.alarm)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil && (tc.created || tc.cleared) && tc.clearErr == nil {
+				info := <-runInfo
+				assert.Equal(t, slog.LevelDebug, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			}
+			if tc.created {
+				ok := repoCall.Parent.AssertCalled(t, "CreateAlarm", context.Background(), mock.MatchedBy(func(a alarms.Alarm) bool {
+					return a.ID != "" && a.Occurrences == 1 && a.LastOccurredAt.Equal(ts)
+				}))
+				assert.True(t, ok, fmt.Sprintf("%s: CreateAlarm was not called with the new occurrence", tc.desc))
+			}
+			if tc.cleared {
+				ok := repoCall1.Parent.AssertCalled(t, "ClearAlarm", context.Background(), mock.Anything)
+				assert.True(t, ok, fmt.Sprintf("%s: ClearAlarm was not called", tc.desc))
+			} else {
+				repoCall1.Parent.AssertNotCalled(t, "ClearAlarm", context.Background(), mock.Anything)
+			}
+			if tc.updated {
+				ok := repoCall3.Parent.AssertCalled(t, "UpdateAlarmValue", context.Background(), mock.Anything)
+				assert.True(t, ok, fmt.Sprintf("%s: UpdateAlarmValue was not called", tc.desc))
+			} else {
+				repoCall3.Parent.AssertNotCalled(t, "UpdateAlarmValue", context.Background(), mock.Anything)
+			}
 			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repo.Calls = nil
 		})
 	}
 }
@@ -164,7 +276,8 @@ func TestUpdateAlarm(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			s := authn.Session{DomainID: tc.alarm.DomainID}
-			repoCall := repo.On("UpdateAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.err)
+			repoCall := repo.On("ViewAlarm", context.Background(), tc.alarm.ID, tc.alarm.DomainID).Return(tc.alarm, tc.err)
+			repoCall1 := repo.On("UpdateAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.err)
 			_, err := svc.UpdateAlarm(context.Background(), s, tc.alarm)
 			if tc.err != nil {
 				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
@@ -172,6 +285,79 @@ func TestUpdateAlarm(t *testing.T) {
 				return
 			}
 			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestUpdateAlarmNotifications(t *testing.T) {
+	stored := alarms.Alarm{
+		ID:         "alarm-id",
+		DomainID:   "domain-id",
+		Severity:   80,
+		Status:     alarms.ActiveStatus,
+		AssigneeID: "assignee-id",
+	}
+	policy := alarms.NotificationPolicy{
+		ID:          "policy-id",
+		DomainID:    "domain-id",
+		MaxSeverity: alarms.SeverityMax,
+		Recipients:  alarms.Recipients{Emails: []string{"admin@example.com"}},
+	}
+
+	cases := []struct {
+		desc   string
+		update alarms.Alarm
+		events []string
+	}{
+		{
+			desc:   "update alarm with new assignee",
+			update: alarms.Alarm{AssigneeID: "other-id"},
+			events: []string{alarms.AssignedEvent},
+		},
+		{
+			desc:   "update alarm with the same assignee",
+			update: alarms.Alarm{AssigneeID: stored.AssigneeID},
+		},
+		{
+			desc:   "acknowledge alarm",
+			update: alarms.Alarm{AssigneeID: stored.AssigneeID, AcknowledgedBy: "user-id"},
+			events: []string{alarms.AcknowledgedEvent},
+		},
+		{
+			desc:   "resolve alarm",
+			update: alarms.Alarm{ResolvedBy: "user-id", Status: alarms.ClearedStatus},
+			events: []string{alarms.ResolvedEvent},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			notifier := new(mocks.Notifier)
+			runInfo := make(chan pkglog.RunInfo, 10)
+			svc := alarms.NewService(idp, repo, notifier, runInfo, new(tmocks.Ticker), lifecycle)
+
+			tc.update.ID = stored.ID
+			updated := stored
+			updated.AssigneeID = tc.update.AssigneeID
+			updated.AcknowledgedBy = tc.update.AcknowledgedBy
+			updated.ResolvedBy = tc.update.ResolvedBy
+			repo.On("ViewAlarm", context.Background(), stored.ID, stored.DomainID).Return(stored, nil)
+			repo.On("UpdateAlarm", context.Background(), mock.Anything).Return(updated, nil)
+			repo.On("MatchNotificationPolicies", mock.Anything, stored.DomainID, stored.Severity).Return([]alarms.NotificationPolicy{policy}, nil)
+			var events []string
+			notifier.On("Notify", mock.Anything, policy.Recipients, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				events = append(events, args.Get(2).(alarms.Notification).Event)
+			})
+
+			_, err := svc.UpdateAlarm(context.Background(), authn.Session{DomainID: stored.DomainID}, tc.update)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			for range tc.events {
+				<-runInfo
+			}
+			assert.Equal(t, tc.events, events, fmt.Sprintf("%s: unexpected notifications", tc.desc))
+			assert.Empty(t, runInfo, fmt.Sprintf("%s: unexpected notifications", tc.desc))
 		})
 	}
 }
@@ -217,6 +403,66 @@ func TestListAlarms(t *testing.T) {
 	}
 }
 
+func TestAlarmStats(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+
+	query := alarms.StatsQuery{
+		PageMetadata: alarms.PageMetadata{DomainID: testsutil.GenerateUUID(t)},
+		GroupBy:      alarms.GroupByRule,
+		Interval:     alarms.DayInterval,
+	}
+	stats := alarms.Stats{
+		GroupBy:  alarms.GroupByRule,
+		Interval: alarms.DayInterval,
+		Total:    1,
+		Counts:   []alarms.StatsCount{{Key: testsutil.GenerateUUID(t), Count: 1}},
+	}
+
+	cases := []struct {
+		desc    string
+		session authn.Session
+		method  string
+		stats   alarms.Stats
+		err     error
+	}{
+		{
+			desc:    "stats of user alarms",
+			session: authn.Session{UserID: testsutil.GenerateUUID(t), DomainID: query.DomainID},
+			method:  "UserAlarmStats",
+			stats:   stats,
+		},
+		{
+			desc:    "stats of all alarms as super admin",
+			session: authn.Session{SuperAdmin: true, DomainID: query.DomainID},
+			method:  "AlarmStats",
+			stats:   stats,
+		},
+		{
+			desc:    "stats with repository error",
+			session: authn.Session{UserID: testsutil.GenerateUUID(t), DomainID: query.DomainID},
+			method:  "UserAlarmStats",
+			err:     repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var repoCall *mock.Call
+			switch tc.method {
+			case "AlarmStats":
+				repoCall = repo.On("AlarmStats", context.Background(), query).Return(tc.stats, tc.err)
+			default:
+				repoCall = repo.On("UserAlarmStats", context.Background(), tc.session.UserID, query).Return(tc.stats, tc.err)
+			}
+			got, err := svc.AlarmStats(context.Background(), tc.session, query)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.stats, got)
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestDeleteAlarm(t *testing.T) {
 	repo := new(mocks.Repository)
 	svc := newService(t, repo)
@@ -252,3 +498,506 @@ func TestDeleteAlarm(t *testing.T) {
 		})
 	}
 }
+
+func TestStartScheduler(t *testing.T) {
+	cases := []struct {
+		desc         string
+		lifecycle    alarms.Lifecycle
+		clearErr     error
+		escalateErr  error
+		dueErr       error
+		cleared      bool
+		escalated    bool
+		runInfoLevel []slog.Level
+	}{
+		{
+			desc:         "clear quiet alarms and escalate alarms",
+			lifecycle:    lifecycle,
+			cleared:      true,
+			escalated:    true,
+			runInfoLevel: []slog.Level{slog.LevelDebug, slog.LevelDebug, slog.LevelDebug},
+		},
+		{
+			desc:         "clear quiet alarms with failed repository",
+			lifecycle:    lifecycle,
+			clearErr:     repoerr.ErrUpdateEntity,
+			cleared:      true,
+			escalated:    true,
+			runInfoLevel: []slog.Level{slog.LevelError, slog.LevelDebug, slog.LevelDebug},
+		},
+		{
+			desc:         "escalate alarms with failed repository",
+			lifecycle:    lifecycle,
+			escalateErr:  repoerr.ErrUpdateEntity,
+			cleared:      true,
+			escalated:    true,
+			runInfoLevel: []slog.Level{slog.LevelDebug, slog.LevelError, slog.LevelDebug},
+		},
+		{
+			desc:         "escalate alarms without quiet period",
+			lifecycle:    alarms.Lifecycle{EscalateAfter: time.Minute, EscalationStep: 10},
+			escalated:    true,
+			runInfoLevel: []slog.Level{slog.LevelDebug, slog.LevelDebug},
+		},
+		{
+			desc:         "clear quiet alarms without escalation step",
+			lifecycle:    alarms.Lifecycle{QuietPeriod: time.Hour, EscalateAfter: time.Minute},
+			cleared:      true,
+			runInfoLevel: []slog.Level{slog.LevelDebug, slog.LevelDebug},
+		},
+		{
+			desc:         "notify escalation chains with failed repository",
+			lifecycle:    alarms.Lifecycle{},
+			dueErr:       repoerr.ErrViewEntity,
+			runInfoLevel: []slog.Level{slog.LevelError},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			ticker := new(tmocks.Ticker)
+			runInfo := make(chan pkglog.RunInfo)
+			svc := alarms.NewService(idp, repo, new(mocks.Notifier), runInfo, ticker, tc.lifecycle)
+
+			tickChan := make(chan time.Time)
+			ticker.On("Tick").Return((<-chan time.Time)(tickChan))
+			ticker.On("Stop").Return()
+			repo.On("ClearQuietAlarms", mock.Anything, mock.Anything, mock.Anything).Return([]alarms.Alarm{}, tc.clearErr)
+			repo.On("EscalateAlarms", mock.Anything, mock.Anything, mock.Anything, tc.lifecycle.EscalationStep).Return([]alarms.Alarm{}, tc.escalateErr)
+			repo.On("ListDueEscalations", mock.Anything, mock.Anything).Return([]alarms.Escalation{}, tc.dueErr)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- svc.StartScheduler(ctx)
+			}()
+
+			start := time.Now().UTC()
+			tickChan <- start
+			for _, level := range tc.runInfoLevel {
+				info := <-runInfo
+				assert.Equal(t, level, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			}
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+
+			if tc.cleared {
+				repo.AssertCalled(t, "ClearQuietAlarms", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+					return !before.After(start.Add(-tc.lifecycle.QuietPeriod).Add(time.Second))
+				}), mock.Anything)
+			} else {
+				repo.AssertNotCalled(t, "ClearQuietAlarms", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.escalated {
+				repo.AssertCalled(t, "EscalateAlarms", mock.Anything, mock.Anything, mock.Anything, tc.lifecycle.EscalationStep)
+			} else {
+				repo.AssertNotCalled(t, "EscalateAlarms", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			ticker.AssertCalled(t, "Stop")
+		})
+	}
+}
+
+func TestNotifyAlarmStateChange(t *testing.T) {
+	alarm := alarms.Alarm{
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		ClientID:    "client-id",
+		Measurement: "measurement",
+		Value:       "value",
+		Cause:       "cause",
+		Severity:    80,
+		CreatedAt:   time.Now(),
+	}
+	recipients := alarms.Recipients{Emails: []string{"admin@example.com"}}
+	policy := alarms.NotificationPolicy{
+		ID:          "policy-id",
+		DomainID:    "domain-id",
+		Name:        "critical",
+		MinSeverity: 50,
+		MaxSeverity: alarms.SeverityMax,
+		Recipients:  recipients,
+	}
+	resolvedOnly := policy
+	resolvedOnly.Events = []string{alarms.ResolvedEvent}
+	escalationOnly := policy
+	escalationOnly.Recipients = alarms.Recipients{}
+
+	cases := []struct {
+		desc         string
+		policies     []alarms.NotificationPolicy
+		matchErr     error
+		notifyErr    error
+		notified     bool
+		runInfoLevel slog.Level
+	}{
+		{
+			desc:         "notify matching policy",
+			policies:     []alarms.NotificationPolicy{policy},
+			notified:     true,
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "skip policy without created event",
+			policies:     []alarms.NotificationPolicy{resolvedOnly},
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "skip policy with escalation recipients only",
+			policies:     []alarms.NotificationPolicy{escalationOnly},
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "notify with failed notifier",
+			policies:     []alarms.NotificationPolicy{policy},
+			notifyErr:    errors.New("failed to send"),
+			notified:     true,
+			runInfoLevel: slog.LevelError,
+		},
+		{
+			desc:         "notify with failed repository",
+			matchErr:     repoerr.ErrViewEntity,
+			runInfoLevel: slog.LevelError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			notifier := new(mocks.Notifier)
+			runInfo := make(chan pkglog.RunInfo, 10)
+			svc := alarms.NewService(idp, repo, notifier, runInfo, new(tmocks.Ticker), lifecycle)
+
+			saved := alarm
+			saved.Occurrences = 1
+			repo.On("CreateAlarm", context.Background(), mock.Anything).Return(saved, nil)
+			repo.On("MatchNotificationPolicies", mock.Anything, alarm.DomainID, alarm.Severity).Return(tc.policies, tc.matchErr)
+			notifier.On("Notify", mock.Anything, recipients, mock.MatchedBy(func(n alarms.Notification) bool {
+				return n.Event == alarms.CreatedEvent && n.PolicyID == policy.ID
+			})).Return(tc.notifyErr)
+
+			err := svc.CreateAlarm(context.Background(), alarm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			info := <-runInfo
+			assert.Equal(t, tc.runInfoLevel, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			if tc.notified {
+				notifier.AssertNumberOfCalls(t, "Notify", 1)
+			} else {
+				notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestNotifyEscalations(t *testing.T) {
+	first := alarms.Recipients{Emails: []string{"operator@example.com"}}
+	second := alarms.Recipients{Webhooks: []alarms.Webhook{{URL: "https://example.com/alarms"}}}
+	policy := alarms.NotificationPolicy{
+		ID:          "policy-id",
+		DomainID:    "domain-id",
+		Name:        "critical",
+		MaxSeverity: alarms.SeverityMax,
+		Escalation: []alarms.EscalationLevel{
+			{AfterMinutes: 15, Recipients: first},
+			{AfterMinutes: 60, Recipients: second},
+		},
+	}
+	alarm := alarms.Alarm{ID: "alarm-id", DomainID: "domain-id", Severity: 80}
+
+	cases := []struct {
+		desc         string
+		escalation   alarms.Escalation
+		addErr       error
+		notifyErr    error
+		recipients   alarms.Recipients
+		notified     bool
+		runInfoLevel slog.Level
+	}{
+		{
+			desc:         "notify first escalation level",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 1},
+			recipients:   first,
+			notified:     true,
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "notify second escalation level",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 2},
+			recipients:   second,
+			notified:     true,
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "skip escalation level notified by another instance",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 1},
+			addErr:       repoerr.ErrConflict,
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "skip removed escalation level",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 3},
+			runInfoLevel: slog.LevelDebug,
+		},
+		{
+			desc:         "notify escalation level with failed repository",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 1},
+			addErr:       repoerr.ErrCreateEntity,
+			runInfoLevel: slog.LevelError,
+		},
+		{
+			desc:         "notify escalation level with failed notifier",
+			escalation:   alarms.Escalation{Alarm: alarm, PolicyID: policy.ID, Level: 1},
+			notifyErr:    errors.New("failed to send"),
+			recipients:   first,
+			notified:     true,
+			runInfoLevel: slog.LevelError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			notifier := new(mocks.Notifier)
+			ticker := new(tmocks.Ticker)
+			runInfo := make(chan pkglog.RunInfo)
+			svc := alarms.NewService(idp, repo, notifier, runInfo, ticker, alarms.Lifecycle{})
+
+			tickChan := make(chan time.Time)
+			ticker.On("Tick").Return((<-chan time.Time)(tickChan))
+			ticker.On("Stop").Return()
+			repo.On("ListDueEscalations", mock.Anything, mock.Anything).Return([]alarms.Escalation{tc.escalation}, nil)
+			repo.On("ViewNotificationPolicy", mock.Anything, alarm.DomainID, policy.ID).Return(policy, nil)
+			repo.On("AddEscalation", mock.Anything, tc.escalation, mock.Anything).Return(tc.addErr)
+			notifier.On("Notify", mock.Anything, tc.recipients, mock.MatchedBy(func(n alarms.Notification) bool {
+				return n.Event == alarms.UnacknowledgedEvent && n.EscalationLevel == tc.escalation.Level
+			})).Return(tc.notifyErr)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- svc.StartScheduler(ctx)
+			}()
+
+			tickChan <- time.Now().UTC()
+			info := <-runInfo
+			assert.Equal(t, tc.runInfoLevel, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			cancel()
+			<-errc
+
+			if tc.notified {
+				notifier.AssertNumberOfCalls(t, "Notify", 1)
+			} else {
+				notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAddNotificationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	policy := alarms.NotificationPolicy{
+		Name:        "critical",
+		MaxSeverity: alarms.SeverityMax,
+		Recipients:  alarms.Recipients{Emails: []string{"admin@example.com"}},
+	}
+
+	cases := []struct {
+		desc    string
+		policy  alarms.NotificationPolicy
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "add valid policy",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "add invalid policy",
+			policy: alarms.NotificationPolicy{Name: "critical"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "add policy with existing name",
+			policy:  policy,
+			repoErr: repoerr.ErrConflict,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+			repoCall := repo.On("AddNotificationPolicy", context.Background(), mock.Anything).Return(tc.policy, tc.repoErr)
+			_, err := svc.AddNotificationPolicy(context.Background(), s, tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				repo.AssertCalled(t, "AddNotificationPolicy", context.Background(), mock.MatchedBy(func(p alarms.NotificationPolicy) bool {
+					return p.ID != "" && p.DomainID == s.DomainID && p.CreatedBy == s.UserID
+				}))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewNotificationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+
+	cases := []struct {
+		desc    string
+		id      string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "view existing policy",
+			id:   "policy-id",
+			err:  nil,
+		},
+		{
+			desc:    "view non existing policy",
+			id:      "policy-id",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := authn.Session{DomainID: "domain-id"}
+			repoCall := repo.On("ViewNotificationPolicy", context.Background(), s.DomainID, tc.id).Return(alarms.NotificationPolicy{}, tc.repoErr)
+			_, err := svc.ViewNotificationPolicy(context.Background(), s, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateNotificationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	policy := alarms.NotificationPolicy{
+		ID:          "policy-id",
+		Name:        "critical",
+		MaxSeverity: alarms.SeverityMax,
+		Recipients:  alarms.Recipients{Emails: []string{"admin@example.com"}},
+	}
+
+	cases := []struct {
+		desc    string
+		policy  alarms.NotificationPolicy
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "update valid policy",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "update invalid policy",
+			policy: alarms.NotificationPolicy{ID: "policy-id"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "update non existing policy",
+			policy:  policy,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+			repoCall := repo.On("UpdateNotificationPolicy", context.Background(), mock.Anything).Return(tc.policy, tc.repoErr)
+			_, err := svc.UpdateNotificationPolicy(context.Background(), s, tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				repo.AssertCalled(t, "UpdateNotificationPolicy", context.Background(), mock.MatchedBy(func(p alarms.NotificationPolicy) bool {
+					return p.DomainID == s.DomainID && p.UpdatedBy == s.UserID
+				}))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListNotificationPolicies(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+
+	cases := []struct {
+		desc    string
+		pm      alarms.NotificationPoliciesPageMeta
+		page    alarms.NotificationPoliciesPage
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "list policies",
+			pm:   alarms.NotificationPoliciesPageMeta{Offset: 0, Limit: 10},
+			page: alarms.NotificationPoliciesPage{Offset: 0, Limit: 10, Total: 1, Policies: []alarms.NotificationPolicy{{ID: "policy-id"}}},
+			err:  nil,
+		},
+		{
+			desc:    "list policies with failed repository",
+			pm:      alarms.NotificationPoliciesPageMeta{Offset: 0, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := authn.Session{DomainID: "domain-id"}
+			pm := tc.pm
+			pm.DomainID = s.DomainID
+			repoCall := repo.On("ListNotificationPolicies", context.Background(), pm).Return(tc.page, tc.repoErr)
+			page, err := svc.ListNotificationPolicies(context.Background(), s, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveNotificationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+
+	cases := []struct {
+		desc    string
+		id      string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "remove existing policy",
+			id:   "policy-id",
+			err:  nil,
+		},
+		{
+			desc:    "remove non existing policy",
+			id:      "policy-id",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := authn.Session{DomainID: "domain-id"}
+			repoCall := repo.On("RemoveNotificationPolicy", context.Background(), s.DomainID, tc.id).Return(tc.repoErr)
+			err := svc.RemoveNotificationPolicy(context.Background(), s, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
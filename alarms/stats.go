@@ -0,0 +1,113 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"slices"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Dimensions the alarm counts are grouped by.
+const (
+	GroupByStatus   = "status"
+	GroupBySeverity = "severity"
+	GroupByRule     = "rule"
+	GroupByChannel  = "channel"
+	GroupByClient   = "client"
+)
+
+// Time buckets the alarm counts are grouped by, using the alarm creation time.
+const (
+	HourInterval  = "hour"
+	DayInterval   = "day"
+	WeekInterval  = "week"
+	MonthInterval = "month"
+)
+
+var (
+	groupBys  = []string{GroupByStatus, GroupBySeverity, GroupByRule, GroupByChannel, GroupByClient}
+	intervals = []string{HourInterval, DayInterval, WeekInterval, MonthInterval}
+)
+
+var (
+	ErrInvalidGroupBy  = errors.New("invalid group_by, must be one of status, severity, rule, channel or client")
+	ErrInvalidInterval = errors.New("invalid interval, must be one of hour, day, week or month")
+)
+
+// StatsQuery filters the alarms the same way as listing does, and groups them
+// by the dimension and, optionally, by time bucket. Offset, limit, order and
+// direction of the page metadata are ignored.
+type StatsQuery struct {
+	PageMetadata
+	GroupBy  string `json:"group_by" db:"group_by"`
+	Interval string `json:"interval" db:"interval"`
+}
+
+func (q StatsQuery) Validate() error {
+	if !slices.Contains(groupBys, q.GroupBy) {
+		return ErrInvalidGroupBy
+	}
+	if q.Interval != "" && !slices.Contains(intervals, q.Interval) {
+		return ErrInvalidInterval
+	}
+
+	return nil
+}
+
+// StatsCount holds the number of alarms for the value of the grouping dimension
+// in the time bucket. Mean times are in seconds and are computed from the
+// alarms that were acknowledged or resolved.
+type StatsCount struct {
+	Key                   string    `json:"key"`
+	Bucket                time.Time `json:"bucket,omitempty"`
+	Count                 uint64    `json:"count"`
+	Acknowledged          uint64    `json:"acknowledged"`
+	Resolved              uint64    `json:"resolved"`
+	MeanTimeToAcknowledge float64   `json:"mean_time_to_acknowledge"`
+	MeanTimeToResolve     float64   `json:"mean_time_to_resolve"`
+}
+
+// Stats aggregates the alarms matching the StatsQuery. Mean times are in seconds.
+type Stats struct {
+	GroupBy               string       `json:"group_by"`
+	Interval              string       `json:"interval,omitempty"`
+	Total                 uint64       `json:"total"`
+	Acknowledged          uint64       `json:"acknowledged"`
+	Resolved              uint64       `json:"resolved"`
+	MeanTimeToAcknowledge float64      `json:"mean_time_to_acknowledge"`
+	MeanTimeToResolve     float64      `json:"mean_time_to_resolve"`
+	Counts                []StatsCount `json:"counts"`
+}
+
+// NewStats sums up the counts and computes the overall mean times weighted
+// by the number of the acknowledged and resolved alarms.
+func NewStats(q StatsQuery, counts []StatsCount) Stats {
+	stats := Stats{
+		GroupBy:  q.GroupBy,
+		Interval: q.Interval,
+		Counts:   counts,
+	}
+	if stats.Counts == nil {
+		stats.Counts = []StatsCount{}
+	}
+
+	var ackSum, resSum float64
+	for _, c := range counts {
+		stats.Total += c.Count
+		stats.Acknowledged += c.Acknowledged
+		stats.Resolved += c.Resolved
+		ackSum += c.MeanTimeToAcknowledge * float64(c.Acknowledged)
+		resSum += c.MeanTimeToResolve * float64(c.Resolved)
+	}
+	if stats.Acknowledged > 0 {
+		stats.MeanTimeToAcknowledge = ackSum / float64(stats.Acknowledged)
+	}
+	if stats.Resolved > 0 {
+		stats.MeanTimeToResolve = resSum / float64(stats.Resolved)
+	}
+
+	return stats
+}
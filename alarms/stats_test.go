@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStatsQuery(t *testing.T) {
+	cases := []struct {
+		desc  string
+		query alarms.StatsQuery
+		err   error
+	}{
+		{
+			desc:  "group by status",
+			query: alarms.StatsQuery{GroupBy: alarms.GroupByStatus},
+			err:   nil,
+		},
+		{
+			desc:  "group by client per week",
+			query: alarms.StatsQuery{GroupBy: alarms.GroupByClient, Interval: alarms.WeekInterval},
+			err:   nil,
+		},
+		{
+			desc:  "missing group by",
+			query: alarms.StatsQuery{},
+			err:   alarms.ErrInvalidGroupBy,
+		},
+		{
+			desc:  "invalid group by",
+			query: alarms.StatsQuery{GroupBy: "measurement"},
+			err:   alarms.ErrInvalidGroupBy,
+		},
+		{
+			desc:  "invalid interval",
+			query: alarms.StatsQuery{GroupBy: alarms.GroupByRule, Interval: "minute"},
+			err:   alarms.ErrInvalidInterval,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.query.Validate()
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		})
+	}
+}
+
+func TestNewStats(t *testing.T) {
+	query := alarms.StatsQuery{GroupBy: alarms.GroupByRule, Interval: alarms.DayInterval}
+
+	empty := alarms.NewStats(query, nil)
+	assert.Equal(t, alarms.Stats{GroupBy: alarms.GroupByRule, Interval: alarms.DayInterval, Counts: []alarms.StatsCount{}}, empty)
+
+	counts := []alarms.StatsCount{
+		{Key: "rule-1", Count: 5, Acknowledged: 3, Resolved: 1, MeanTimeToAcknowledge: 60, MeanTimeToResolve: 600},
+		{Key: "rule-2", Count: 2, Acknowledged: 1, Resolved: 3, MeanTimeToAcknowledge: 180, MeanTimeToResolve: 200},
+		{Key: "rule-3", Count: 1},
+	}
+	stats := alarms.NewStats(query, counts)
+	assert.Equal(t, uint64(8), stats.Total)
+	assert.Equal(t, uint64(4), stats.Acknowledged)
+	assert.Equal(t, uint64(4), stats.Resolved)
+	assert.InDelta(t, 90, stats.MeanTimeToAcknowledge, 0.001, "mean time to acknowledge should be weighted by acknowledged alarms")
+	assert.InDelta(t, 300, stats.MeanTimeToResolve, 0.001, "mean time to resolve should be weighted by resolved alarms")
+	assert.Equal(t, counts, stats.Counts)
+}
@@ -34,17 +34,31 @@ const (
 	Aggregation_AGGREGATION_SUM         Aggregation = 3
 	Aggregation_AGGREGATION_COUNT       Aggregation = 4
 	Aggregation_AGGREGATION_AVG         Aggregation = 5
+	Aggregation_AGGREGATION_P50         Aggregation = 6
+	Aggregation_AGGREGATION_P95         Aggregation = 7
+	Aggregation_AGGREGATION_P99         Aggregation = 8
+	Aggregation_AGGREGATION_FIRST       Aggregation = 9
+	Aggregation_AGGREGATION_LAST        Aggregation = 10
+	Aggregation_AGGREGATION_STDDEV      Aggregation = 11
+	Aggregation_AGGREGATION_RATE        Aggregation = 12
 )
 
 // Enum value maps for Aggregation.
 var (
 	Aggregation_name = map[int32]string{
-		0: "AGGREGATION_UNSPECIFIED",
-		1: "AGGREGATION_MAX",
-		2: "AGGREGATION_MIN",
-		3: "AGGREGATION_SUM",
-		4: "AGGREGATION_COUNT",
-		5: "AGGREGATION_AVG",
+		0:  "AGGREGATION_UNSPECIFIED",
+		1:  "AGGREGATION_MAX",
+		2:  "AGGREGATION_MIN",
+		3:  "AGGREGATION_SUM",
+		4:  "AGGREGATION_COUNT",
+		5:  "AGGREGATION_AVG",
+		6:  "AGGREGATION_P50",
+		7:  "AGGREGATION_P95",
+		8:  "AGGREGATION_P99",
+		9:  "AGGREGATION_FIRST",
+		10: "AGGREGATION_LAST",
+		11: "AGGREGATION_STDDEV",
+		12: "AGGREGATION_RATE",
 	}
 	Aggregation_value = map[string]int32{
 		"AGGREGATION_UNSPECIFIED": 0,
@@ -53,6 +67,13 @@ var (
 		"AGGREGATION_SUM":         3,
 		"AGGREGATION_COUNT":       4,
 		"AGGREGATION_AVG":         5,
+		"AGGREGATION_P50":         6,
+		"AGGREGATION_P95":         7,
+		"AGGREGATION_P99":         8,
+		"AGGREGATION_FIRST":       9,
+		"AGGREGATION_LAST":        10,
+		"AGGREGATION_STDDEV":      11,
+		"AGGREGATION_RATE":        12,
 	}
 )
 
@@ -104,6 +125,7 @@ type PageMetadata struct {
 	Format        string                 `protobuf:"bytes,17,opt,name=format,proto3" json:"format,omitempty"`
 	Order         string                 `protobuf:"bytes,18,opt,name=order,proto3" json:"order,omitempty"`
 	Dir           string                 `protobuf:"bytes,19,opt,name=dir,proto3" json:"dir,omitempty"`
+	Cursor        string                 `protobuf:"bytes,20,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -271,11 +293,19 @@ func (x *PageMetadata) GetDir() string {
 	return ""
 }
 
+func (x *PageMetadata) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type ReadMessagesRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         uint64                 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	PageMetadata  *PageMetadata          `protobuf:"bytes,2,opt,name=page_metadata,json=pageMetadata,proto3" json:"page_metadata,omitempty"`
 	Messages      []*Message             `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -331,6 +361,13 @@ func (x *ReadMessagesRes) GetMessages() []*Message {
 	return nil
 }
 
+func (x *ReadMessagesRes) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type Message struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Payload:
@@ -722,7 +759,7 @@ var File_readers_v1_readers_proto protoreflect.FileDescriptor
 const file_readers_v1_readers_proto_rawDesc = "" +
 	"\n" +
 	"\x18readers/v1/readers.proto\x12\n" +
-	"readers.v1\"\x8c\x04\n" +
+	"readers.v1\"\xa4\x04\n" +
 	"\fPageMetadata\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x04R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x04R\x06offset\x12\x1a\n" +
@@ -747,11 +784,14 @@ const file_readers_v1_readers_proto_rawDesc = "" +
 	"comparator\x12\x16\n" +
 	"\x06format\x18\x11 \x01(\tR\x06format\x12\x14\n" +
 	"\x05order\x18\x12 \x01(\tR\x05order\x12\x10\n" +
-	"\x03dir\x18\x13 \x01(\tR\x03dir\"\x97\x01\n" +
+	"\x03dir\x18\x13 \x01(\tR\x03dir\x12\x16\n" +
+	"\x06cursor\x18\x14 \x01(\tR\x06cursor\"\xb8\x01\n" +
 	"\x0fReadMessagesRes\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x04R\x05total\x12=\n" +
 	"\rpage_metadata\x18\x02 \x01(\v2\x18.readers.v1.PageMetadataR\fpageMetadata\x12/\n" +
-	"\bmessages\x18\x03 \x03(\v2\x13.readers.v1.MessageR\bmessages\"u\n" +
+	"\bmessages\x18\x03 \x03(\v2\x13.readers.v1.MessageR\bmessages\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"u\n" +
 	"\aMessage\x120\n" +
 	"\x05senml\x18\x01 \x01(\v2\x18.readers.v1.SenMLMessageH\x00R\x05senml\x12-\n" +
 	"\x04json\x18\x02 \x01(\v2\x17.readers.v1.JsonMessageH\x00R\x04jsonB\t\n" +
@@ -789,16 +829,25 @@ const file_readers_v1_readers_proto_rawDesc = "" +
 	"\n" +
 	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x1b\n" +
 	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12=\n" +
-	"\rpage_metadata\x18\x03 \x01(\v2\x18.readers.v1.PageMetadataR\fpageMetadata*\x95\x01\n" +
+	"\rpage_metadata\x18\x03 \x01(\v2\x18.readers.v1.PageMetadataR\fpageMetadata*\xaf\x02\n" +
 	"\vAggregation\x12\x1b\n" +
 	"\x17AGGREGATION_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fAGGREGATION_MAX\x10\x01\x12\x13\n" +
 	"\x0fAGGREGATION_MIN\x10\x02\x12\x13\n" +
 	"\x0fAGGREGATION_SUM\x10\x03\x12\x15\n" +
 	"\x11AGGREGATION_COUNT\x10\x04\x12\x13\n" +
-	"\x0fAGGREGATION_AVG\x10\x052\\\n" +
+	"\x0fAGGREGATION_AVG\x10\x05\x12\x13\n" +
+	"\x0fAGGREGATION_P50\x10\x06\x12\x13\n" +
+	"\x0fAGGREGATION_P95\x10\a\x12\x13\n" +
+	"\x0fAGGREGATION_P99\x10\b\x12\x15\n" +
+	"\x11AGGREGATION_FIRST\x10\t\x12\x14\n" +
+	"\x10AGGREGATION_LAST\x10\n" +
+	"\x12\x16\n" +
+	"\x12AGGREGATION_STDDEV\x10\v\x12\x14\n" +
+	"\x10AGGREGATION_RATE\x10\f2\xa4\x01\n" +
 	"\x0eReadersService\x12J\n" +
-	"\fReadMessages\x12\x1b.readers.v1.ReadMessagesReq\x1a\x1b.readers.v1.ReadMessagesRes\"\x00B0Z.github.com/absmach/supermq/api/grpc/readers/v1b\x06proto3"
+	"\fReadMessages\x12\x1b.readers.v1.ReadMessagesReq\x1a\x1b.readers.v1.ReadMessagesRes\"\x00\x12F\n" +
+	"\x0eStreamMessages\x12\x1b.readers.v1.ReadMessagesReq\x1a\x13.readers.v1.Message\"\x000\x01B0Z.github.com/absmach/supermq/api/grpc/readers/v1b\x06proto3"
 
 var (
 	file_readers_v1_readers_proto_rawDescOnce sync.Once
@@ -825,21 +874,23 @@ var file_readers_v1_readers_proto_goTypes = []any{
 	(*ReadMessagesReq)(nil), // 7: readers.v1.ReadMessagesReq
 }
 var file_readers_v1_readers_proto_depIdxs = []int32{
-	0, // 0: readers.v1.PageMetadata.aggregation:type_name -> readers.v1.Aggregation
-	1, // 1: readers.v1.ReadMessagesRes.page_metadata:type_name -> readers.v1.PageMetadata
-	3, // 2: readers.v1.ReadMessagesRes.messages:type_name -> readers.v1.Message
-	5, // 3: readers.v1.Message.senml:type_name -> readers.v1.SenMLMessage
-	6, // 4: readers.v1.Message.json:type_name -> readers.v1.JsonMessage
-	4, // 5: readers.v1.SenMLMessage.base:type_name -> readers.v1.BaseMessage
-	4, // 6: readers.v1.JsonMessage.base:type_name -> readers.v1.BaseMessage
-	1, // 7: readers.v1.ReadMessagesReq.page_metadata:type_name -> readers.v1.PageMetadata
-	7, // 8: readers.v1.ReadersService.ReadMessages:input_type -> readers.v1.ReadMessagesReq
-	2, // 9: readers.v1.ReadersService.ReadMessages:output_type -> readers.v1.ReadMessagesRes
-	9, // [9:10] is the sub-list for method output_type
-	8, // [8:9] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	0,  // 0: readers.v1.PageMetadata.aggregation:type_name -> readers.v1.Aggregation
+	1,  // 1: readers.v1.ReadMessagesRes.page_metadata:type_name -> readers.v1.PageMetadata
+	3,  // 2: readers.v1.ReadMessagesRes.messages:type_name -> readers.v1.Message
+	5,  // 3: readers.v1.Message.senml:type_name -> readers.v1.SenMLMessage
+	6,  // 4: readers.v1.Message.json:type_name -> readers.v1.JsonMessage
+	4,  // 5: readers.v1.SenMLMessage.base:type_name -> readers.v1.BaseMessage
+	4,  // 6: readers.v1.JsonMessage.base:type_name -> readers.v1.BaseMessage
+	1,  // 7: readers.v1.ReadMessagesReq.page_metadata:type_name -> readers.v1.PageMetadata
+	7,  // 8: readers.v1.ReadersService.ReadMessages:input_type -> readers.v1.ReadMessagesReq
+	7,  // 9: readers.v1.ReadersService.StreamMessages:input_type -> readers.v1.ReadMessagesReq
+	2,  // 10: readers.v1.ReadersService.ReadMessages:output_type -> readers.v1.ReadMessagesRes
+	3,  // 11: readers.v1.ReadersService.StreamMessages:output_type -> readers.v1.Message
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_readers_v1_readers_proto_init() }
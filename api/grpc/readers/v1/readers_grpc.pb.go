@@ -22,7 +22,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReadersService_ReadMessages_FullMethodName = "/readers.v1.ReadersService/ReadMessages"
+	ReadersService_ReadMessages_FullMethodName   = "/readers.v1.ReadersService/ReadMessages"
+	ReadersService_StreamMessages_FullMethodName = "/readers.v1.ReadersService/StreamMessages"
 )
 
 // ReadersServiceClient is the client API for ReadersService service.
@@ -33,6 +34,7 @@ const (
 // readers functionalities for SuperMQ services.
 type ReadersServiceClient interface {
 	ReadMessages(ctx context.Context, in *ReadMessagesReq, opts ...grpc.CallOption) (*ReadMessagesRes, error)
+	StreamMessages(ctx context.Context, in *ReadMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
 }
 
 type readersServiceClient struct {
@@ -53,6 +55,25 @@ func (c *readersServiceClient) ReadMessages(ctx context.Context, in *ReadMessage
 	return out, nil
 }
 
+func (c *readersServiceClient) StreamMessages(ctx context.Context, in *ReadMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReadersService_ServiceDesc.Streams[0], ReadersService_StreamMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReadMessagesReq, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReadersService_StreamMessagesClient = grpc.ServerStreamingClient[Message]
+
 // ReadersServiceServer is the server API for ReadersService service.
 // All implementations must embed UnimplementedReadersServiceServer
 // for forward compatibility.
@@ -61,6 +82,7 @@ func (c *readersServiceClient) ReadMessages(ctx context.Context, in *ReadMessage
 // readers functionalities for SuperMQ services.
 type ReadersServiceServer interface {
 	ReadMessages(context.Context, *ReadMessagesReq) (*ReadMessagesRes, error)
+	StreamMessages(*ReadMessagesReq, grpc.ServerStreamingServer[Message]) error
 	mustEmbedUnimplementedReadersServiceServer()
 }
 
@@ -74,6 +96,9 @@ type UnimplementedReadersServiceServer struct{}
 func (UnimplementedReadersServiceServer) ReadMessages(context.Context, *ReadMessagesReq) (*ReadMessagesRes, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReadMessages not implemented")
 }
+func (UnimplementedReadersServiceServer) StreamMessages(*ReadMessagesReq, grpc.ServerStreamingServer[Message]) error {
+	return status.Error(codes.Unimplemented, "method StreamMessages not implemented")
+}
 func (UnimplementedReadersServiceServer) mustEmbedUnimplementedReadersServiceServer() {}
 func (UnimplementedReadersServiceServer) testEmbeddedByValue()                        {}
 
@@ -113,6 +138,17 @@ func _ReadersService_ReadMessages_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReadersService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadMessagesReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReadersServiceServer).StreamMessages(m, &grpc.GenericServerStream[ReadMessagesReq, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReadersService_StreamMessagesServer = grpc.ServerStreamingServer[Message]
+
 // ReadersService_ServiceDesc is the grpc.ServiceDesc for ReadersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -125,6 +161,12 @@ var ReadersService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ReadersService_ReadMessages_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMessages",
+			Handler:       _ReadersService_StreamMessages_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "readers/v1/readers.proto",
 }
@@ -204,6 +204,18 @@ var (
 	// ErrInvalidInterval indicates invalid interval value.
 	ErrInvalidInterval = errors.NewRequestError("invalid interval value")
 
+	// ErrInvalidCursor indicates invalid cursor value.
+	ErrInvalidCursor = errors.NewRequestError("invalid cursor value")
+
+	// ErrInvalidExportFormat indicates invalid export format value.
+	ErrInvalidExportFormat = errors.NewRequestError("invalid export format value")
+
+	// ErrBucketsSize indicates that the time range holds too many buckets of the interval.
+	ErrBucketsSize = errors.NewRequestError("invalid number of time buckets")
+
+	// ErrSeriesSize indicates invalid number of channels or measurements of the series.
+	ErrSeriesSize = errors.NewRequestError("invalid number of series")
+
 	// ErrMissingFrom indicates missing from value.
 	ErrMissingFrom = errors.NewRequestError("missing from time value")
 
@@ -41,6 +41,9 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			ClientKey:   req.ClientKey,
 			CACert:      req.CACert,
 			Content:     req.Content,
+			Tags:        req.Tags,
+			TemplateID:  req.TemplateID,
+			Variables:   req.Variables,
 		}
 
 		saved, err := svc.Add(ctx, session, req.token, config)
@@ -57,6 +60,91 @@ func addEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
+func bulkAddEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(bulkAddReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		res := bulkRes{
+			Total:   len(req.Configs),
+			Results: make([]bulkRowRes, len(req.Configs)),
+		}
+
+		// Invalid rows are reported without being sent to the service.
+		var cfgs []bootstrap.Config
+		var rows []int
+		for i, c := range req.Configs {
+			res.Results[i] = bulkRowRes{Index: i, ExternalID: c.ExternalID}
+			if err := c.validate(); err != nil {
+				res.Results[i].Error = rowError(err)
+				continue
+			}
+
+			channels := []bootstrap.Channel{}
+			for _, ch := range c.Channels {
+				channels = append(channels, bootstrap.Channel{ID: ch})
+			}
+			cfgs = append(cfgs, bootstrap.Config{
+				ClientID:    c.ClientID,
+				ExternalID:  c.ExternalID,
+				ExternalKey: c.ExternalKey,
+				Name:        c.Name,
+				Content:     c.Content,
+				Channels:    channels,
+				Tags:        c.Tags,
+				TemplateID:  c.TemplateID,
+				Variables:   c.Variables,
+			})
+			rows = append(rows, i)
+		}
+
+		if len(cfgs) > 0 {
+			results, err := svc.BulkAdd(ctx, session, req.token, cfgs)
+			if err != nil {
+				return nil, err
+			}
+			for i, r := range results {
+				row := &res.Results[rows[i]]
+				if r.Err != nil {
+					row.Error = rowError(r.Err)
+					continue
+				}
+				row.ClientID = r.Config.ClientID
+			}
+		}
+
+		for _, row := range res.Results {
+			if row.Error != "" {
+				res.Failed++
+				continue
+			}
+			res.Created++
+		}
+
+		return res, nil
+	}
+}
+
+// rowError returns the message of the error the bulk row failed with.
+// Request errors are caused by the row itself, so their cause is reported too.
+func rowError(err error) string {
+	switch e := err.(type) {
+	case *errors.RequestError:
+		return e.Error()
+	case errors.Error:
+		return e.Msg()
+	default:
+		return err.Error()
+	}
+}
+
 func updateCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(updateCertReq)
@@ -85,6 +173,28 @@ func updateCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	}
 }
 
+func enrollCertEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(enrollCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		cfg, err := svc.EnrollCert(ctx, req.id, req.ClientCert, req.CACert)
+		if err != nil {
+			return nil, err
+		}
+
+		res := updateConfigRes{
+			ClientID:   cfg.ClientID,
+			ClientCert: cfg.ClientCert,
+			CACert:     cfg.CACert,
+		}
+
+		return res, nil
+	}
+}
+
 func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(entityReq)
@@ -112,14 +222,20 @@ func viewEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		}
 
 		res := viewRes{
-			ClientID:     config.ClientID,
-			CLientSecret: config.ClientSecret,
-			Channels:     channels,
-			ExternalID:   config.ExternalID,
-			ExternalKey:  config.ExternalKey,
-			Name:         config.Name,
-			Content:      config.Content,
-			State:        config.State,
+			ClientID:        config.ClientID,
+			CLientSecret:    config.ClientSecret,
+			Channels:        channels,
+			ExternalID:      config.ExternalID,
+			ExternalKey:     config.ExternalKey,
+			Name:            config.Name,
+			Content:         config.Content,
+			State:           config.State,
+			Tags:            config.Tags,
+			Revision:        config.Revision,
+			AppliedRevision: config.AppliedRevision,
+			LastAck:         config.LastAck,
+			TemplateID:      config.TemplateID,
+			Variables:       config.Variables,
 		}
 
 		return res, nil
@@ -142,6 +258,7 @@ func updateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			ClientID: req.id,
 			Name:     req.Name,
 			Content:  req.Content,
+			Tags:     req.Tags,
 		}
 
 		if err := svc.Update(ctx, session, config); err != nil {
@@ -216,14 +333,20 @@ func listEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 			}
 
 			view := viewRes{
-				ClientID:     cfg.ClientID,
-				CLientSecret: cfg.ClientSecret,
-				Channels:     channels,
-				ExternalID:   cfg.ExternalID,
-				ExternalKey:  cfg.ExternalKey,
-				Name:         cfg.Name,
-				Content:      cfg.Content,
-				State:        cfg.State,
+				ClientID:        cfg.ClientID,
+				CLientSecret:    cfg.ClientSecret,
+				Channels:        channels,
+				ExternalID:      cfg.ExternalID,
+				ExternalKey:     cfg.ExternalKey,
+				Name:            cfg.Name,
+				Content:         cfg.Content,
+				State:           cfg.State,
+				Tags:            cfg.Tags,
+				Revision:        cfg.Revision,
+				AppliedRevision: cfg.AppliedRevision,
+				LastAck:         cfg.LastAck,
+				TemplateID:      cfg.TemplateID,
+				Variables:       cfg.Variables,
 			}
 			res.Configs = append(res.Configs, view)
 		}
@@ -287,3 +410,260 @@ func stateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
 		return stateRes{}, nil
 	}
 }
+
+func listRevisionsEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listRevisionsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListRevisions(ctx, session, req.id, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := revisionsRes{
+			Total:     page.Total,
+			Offset:    page.Offset,
+			Limit:     page.Limit,
+			Revisions: page.Revisions,
+		}
+
+		return res, nil
+	}
+}
+
+func diffRevisionsEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(diffRevisionsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		diff, err := svc.DiffRevisions(ctx, session, req.id, req.from, req.to)
+		if err != nil {
+			return nil, err
+		}
+
+		return diffRes{diff}, nil
+	}
+}
+
+func createRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(createRolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		ro := bootstrap.Rollout{
+			Content:          req.Content,
+			Tags:             req.Tags,
+			Percentage:       req.Percentage,
+			FailureThreshold: req.FailureThreshold,
+		}
+
+		saved, err := svc.CreateRollout(ctx, session, ro)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: saved, created: true}, nil
+	}
+}
+
+func viewRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(rolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		ro, err := svc.ViewRollout(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: ro}, nil
+	}
+}
+
+func rollbackRolloutEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(rolloutReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		ro, err := svc.RollbackRollout(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: ro}, nil
+	}
+}
+
+func ackEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(ackReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ack := bootstrap.Ack{
+			Revision: req.Revision,
+			Status:   req.Status,
+			Message:  req.Message,
+		}
+
+		if err := svc.Acknowledge(ctx, req.key, req.id, ack); err != nil {
+			return nil, err
+		}
+
+		return ackRes{}, nil
+	}
+}
+
+func createTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(createTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		t := bootstrap.Template{
+			Name:     req.Name,
+			Content:  req.Content,
+			Channels: req.Channels,
+		}
+
+		saved, err := svc.CreateTemplate(ctx, session, t)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: saved, created: true}, nil
+	}
+}
+
+func viewTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(templateEntityReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		t, err := svc.ViewTemplate(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: t}, nil
+	}
+}
+
+func listTemplatesEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListTemplates(ctx, session, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return templatesRes{TemplatesPage: page}, nil
+	}
+}
+
+func updateTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(updateTemplateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		t := bootstrap.Template{
+			ID:       req.id,
+			Name:     req.Name,
+			Content:  req.Content,
+			Channels: req.Channels,
+		}
+
+		saved, err := svc.UpdateTemplate(ctx, session, t)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: saved}, nil
+	}
+}
+
+func removeTemplateEndpoint(svc bootstrap.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(templateEntityReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		if err := svc.RemoveTemplate(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
@@ -42,10 +42,11 @@ const (
 	contentType  = "application/json"
 	wrongID      = "wrong_id"
 
-	addName    = "name"
-	addContent = "config"
-	instanceID = "5de9b29a-feb9-11ed-be56-0242ac120002"
-	validID    = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	addName     = "name"
+	addContent  = "config"
+	instanceID  = "5de9b29a-feb9-11ed-be56-0242ac120002"
+	validID     = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	certsSecret = "certsSecret"
 )
 
 var (
@@ -181,7 +182,7 @@ func newBootstrapServer() (*httptest.Server, *mocks.Service, *authnmocks.Authent
 	svc := new(mocks.Service)
 	authn := new(authnmocks.Authentication)
 	am := smqauthn.NewAuthNMiddleware(authn, smqauthn.WithAllowUnverifiedUser(true))
-	mux := bsapi.MakeHandler(svc, am, bootstrap.NewConfigReader(encKey), logger, instanceID)
+	mux := bsapi.MakeHandler(svc, am, bootstrap.NewConfigReader(encKey), logger, instanceID, certsSecret)
 	return httptest.NewServer(mux), svc, authn
 }
 
@@ -1394,6 +1395,1245 @@ func TestChangeState(t *testing.T) {
 	}
 }
 
+func TestListRevisions(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+	c := newConfig()
+
+	page := bootstrap.RevisionsPage{
+		Total:  2,
+		Offset: 0,
+		Limit:  10,
+		Revisions: []bootstrap.Revision{
+			{ClientID: c.ClientID, DomainID: domainID, Revision: 2, Content: "config update"},
+			{ClientID: c.ClientID, DomainID: domainID, Revision: 1, Content: c.Content},
+		},
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		id              string
+		query           string
+		status          int
+		page            bootstrap.RevisionsPage
+		authenticateErr error
+		err             error
+	}{
+		{
+			desc:   "list revisions of an existing config",
+			token:  validToken,
+			id:     c.ClientID,
+			status: http.StatusOK,
+			page:   page,
+			err:    nil,
+		},
+		{
+			desc:            "list revisions with invalid token",
+			token:           invalidToken,
+			id:              c.ClientID,
+			status:          http.StatusUnauthorized,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list revisions with too big limit",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?limit=101",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list revisions with invalid offset",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list revisions of a non-existing config",
+			token:  validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("ListRevisions", mock.Anything, tc.session, tc.id, mock.Anything, mock.Anything).Return(tc.page, tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/configs/%s/revisions%s", bs.URL, domainID, tc.id, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body bootstrap.RevisionsPage
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.page, body, fmt.Sprintf("%s: expected response %v got %v", tc.desc, tc.page, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+	c := newConfig()
+
+	diff := bootstrap.RevisionDiff{
+		ClientID: c.ClientID,
+		From:     1,
+		To:       2,
+		Diff:     "--- revision 1\n+++ revision 2\n@@ -1 +1 @@\n-config\n+config update\n",
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		query  string
+		status int
+		diff   bootstrap.RevisionDiff
+		err    error
+	}{
+		{
+			desc:   "diff revisions of an existing config",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?from=1&to=2",
+			status: http.StatusOK,
+			diff:   diff,
+			err:    nil,
+		},
+		{
+			desc:   "diff revisions with an empty token",
+			token:  "",
+			id:     c.ClientID,
+			query:  "?from=1&to=2",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "diff revisions without from revision",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?to=2",
+			status: http.StatusBadRequest,
+			err:    bootstrap.ErrInvalidRevision,
+		},
+		{
+			desc:   "diff revisions with invalid to revision",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?from=1&to=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "diff non-existing revisions",
+			token:  validToken,
+			id:     c.ClientID,
+			query:  "?from=1&to=5",
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("DiffRevisions", mock.Anything, session, tc.id, mock.Anything, mock.Anything).Return(tc.diff, tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/configs/%s/revisions/diff%s", bs.URL, domainID, tc.id, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body bootstrap.RevisionDiff
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.diff, body, fmt.Sprintf("%s: expected response %v got %v", tc.desc, tc.diff, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestCreateRollout(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	ro := bootstrap.Rollout{
+		ID:               validID,
+		DomainID:         domainID,
+		Content:          "config update",
+		Tags:             []string{"edge"},
+		Percentage:       20,
+		FailureThreshold: 10,
+		Status:           bootstrap.RolloutInProgress,
+		Targets:          4,
+	}
+	data := toJSON(map[string]any{
+		"content":           ro.Content,
+		"tags":              ro.Tags,
+		"percentage":        ro.Percentage,
+		"failure_threshold": ro.FailureThreshold,
+	})
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "create a rollout",
+			token:       validToken,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			location:    "/clients/rollouts/" + ro.ID,
+			err:         nil,
+		},
+		{
+			desc:        "create a rollout with an empty token",
+			token:       "",
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "create a rollout with invalid content type",
+			token:       validToken,
+			data:        data,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "create a rollout with invalid percentage",
+			token:       validToken,
+			data:        `{"content": "config", "percentage": 0}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrRolloutPercentage,
+		},
+		{
+			desc:        "create a rollout with malformed data",
+			token:       validToken,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+		{
+			desc:        "create a rollout without targets",
+			token:       validToken,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrEmptyRollout,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("CreateRollout", mock.Anything, session, mock.Anything).Return(ro, tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/rollouts", bs.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			location := res.Header.Get("Location")
+			assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location '%s' got '%s'", tc.desc, tc.location, location))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewRollout(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	ro := bootstrap.Rollout{
+		ID:         validID,
+		DomainID:   domainID,
+		Content:    "config update",
+		Percentage: 100,
+		Status:     bootstrap.RolloutInProgress,
+		Targets:    4,
+		Applied:    2,
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		id      string
+		status  int
+		rollout bootstrap.Rollout
+		err     error
+	}{
+		{
+			desc:    "view an existing rollout",
+			token:   validToken,
+			id:      ro.ID,
+			status:  http.StatusOK,
+			rollout: ro,
+			err:     nil,
+		},
+		{
+			desc:   "view a rollout with an empty token",
+			token:  "",
+			id:     ro.ID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "view a non-existing rollout",
+			token:  validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:   "view a rollout without authorization",
+			token:  validToken,
+			id:     ro.ID,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("ViewRollout", mock.Anything, session, tc.id).Return(tc.rollout, tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/rollouts/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body bootstrap.Rollout
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.rollout, body, fmt.Sprintf("%s: expected response %v got %v", tc.desc, tc.rollout, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRollbackRollout(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	ro := bootstrap.Rollout{
+		ID:         validID,
+		DomainID:   domainID,
+		Content:    "config update",
+		Percentage: 100,
+		Status:     bootstrap.RolloutRolledBack,
+		Targets:    4,
+		Failed:     2,
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+		err    error
+	}{
+		{
+			desc:   "roll back an existing rollout",
+			token:  validToken,
+			id:     ro.ID,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "roll back a rollout with an empty token",
+			token:  "",
+			id:     ro.ID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "roll back a non-existing rollout",
+			token:  validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("RollbackRollout", mock.Anything, session, tc.id).Return(ro, tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/clients/rollouts/%s/rollback", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestAcknowledge(t *testing.T) {
+	bs, svc, _ := newBootstrapServer()
+	defer bs.Close()
+	c := newConfig()
+
+	applied := toJSON(map[string]any{"revision": 2, "status": bootstrap.AckApplied})
+
+	cases := []struct {
+		desc        string
+		externalKey string
+		externalID  string
+		data        string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "acknowledge applied revision",
+			externalKey: c.ExternalKey,
+			externalID:  c.ExternalID,
+			data:        applied,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "acknowledge failed revision",
+			externalKey: c.ExternalKey,
+			externalID:  c.ExternalID,
+			data:        toJSON(map[string]any{"revision": 2, "status": bootstrap.AckFailed, "message": "invalid config"}),
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "acknowledge with an empty key",
+			externalKey: "",
+			externalID:  c.ExternalID,
+			data:        applied,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerKey,
+		},
+		{
+			desc:        "acknowledge with invalid key",
+			externalKey: unknown,
+			externalID:  c.ExternalID,
+			data:        applied,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			err:         bootstrap.ErrExternalKey,
+		},
+		{
+			desc:        "acknowledge with invalid content type",
+			externalKey: c.ExternalKey,
+			externalID:  c.ExternalID,
+			data:        applied,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "acknowledge with invalid status",
+			externalKey: c.ExternalKey,
+			externalID:  c.ExternalID,
+			data:        toJSON(map[string]any{"revision": 2, "status": unknown}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrInvalidAckStatus,
+		},
+		{
+			desc:        "acknowledge non-existing revision",
+			externalKey: c.ExternalKey,
+			externalID:  c.ExternalID,
+			data:        toJSON(map[string]any{"revision": 5, "status": bootstrap.AckApplied}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrInvalidRevision,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Acknowledge", mock.Anything, tc.externalKey, tc.externalID, mock.Anything).Return(tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/clients/bootstrap/%s/ack", bs.URL, tc.externalID),
+				contentType: tc.contentType,
+				key:         tc.externalKey,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestEnrollCert(t *testing.T) {
+	bs, svc, _ := newBootstrapServer()
+	defer bs.Close()
+	c := newConfig()
+
+	data := toJSON(map[string]string{"client_cert": "newCert", "ca_cert": "newCA"})
+
+	cases := []struct {
+		desc        string
+		token       string
+		externalID  string
+		data        string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "enroll cert",
+			token:       certsSecret,
+			externalID:  c.ExternalID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "enroll cert with an empty token",
+			token:       "",
+			externalID:  c.ExternalID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "enroll cert with invalid token",
+			token:       unknown,
+			externalID:  c.ExternalID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "enroll cert for a non-existing config",
+			token:       certsSecret,
+			externalID:  unknown,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "enroll cert with invalid content type",
+			token:       certsSecret,
+			externalID:  c.ExternalID,
+			data:        data,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "enroll cert without client cert",
+			token:       certsSecret,
+			externalID:  c.ExternalID,
+			data:        toJSON(map[string]string{"ca_cert": "newCA"}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingCertData,
+		},
+		{
+			desc:        "enroll cert with malformed data",
+			token:       certsSecret,
+			externalID:  c.ExternalID,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := bootstrap.Config{ClientID: c.ClientID, ClientCert: "newCert", CACert: "newCA"}
+			svcCall := svc.On("EnrollCert", mock.Anything, tc.externalID, "newCert", "newCA").Return(cfg, tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPatch,
+				url:         fmt.Sprintf("%s/clients/bootstrap/certs/%s", bs.URL, tc.externalID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestBulkAdd(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	tmplID := testsutil.GenerateUUID(t)
+	jsonData := toJSON(map[string]any{
+		"configs": []map[string]any{
+			{"external_id": "ext-1", "external_key": "key-1", "channels": []string{"1"}, "content": "config"},
+			{"external_id": "ext-2", "external_key": "key-2", "template_id": tmplID, "variables": map[string]string{"serial": "SN-2"}},
+		},
+	})
+	csvData := "external_id,external_key,template_id,channels,serial\n" +
+		"ext-1,key-1," + tmplID + ",1;2,SN-1\n" +
+		"ext-2,key-2," + tmplID + ",,SN-2\n"
+	invalidRow := toJSON(map[string]any{
+		"configs": []map[string]any{
+			{"external_id": "ext-1", "external_key": "key-1", "channels": []string{"1"}},
+			{"external_id": "ext-2", "channels": []string{"1"}},
+		},
+	})
+	tooMany := make([]map[string]any, 1001)
+	for i := range tooMany {
+		tooMany[i] = map[string]any{"external_id": fmt.Sprintf("ext-%d", i), "external_key": "key", "channels": []string{"1"}}
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		configs     []bootstrap.Config
+		results     []bootstrap.BulkResult
+		status      int
+		created     int
+		failed      int
+		err         error
+	}{
+		{
+			desc:        "bulk add configs from JSON",
+			token:       validToken,
+			data:        jsonData,
+			contentType: contentType,
+			configs: []bootstrap.Config{
+				{ExternalID: "ext-1", ExternalKey: "key-1", Channels: []bootstrap.Channel{{ID: "1"}}, Content: "config"},
+				{ExternalID: "ext-2", ExternalKey: "key-2", Channels: []bootstrap.Channel{}, TemplateID: tmplID, Variables: map[string]string{"serial": "SN-2"}},
+			},
+			results: []bootstrap.BulkResult{
+				{ExternalID: "ext-1", Config: bootstrap.Config{ClientID: addClientID}},
+				{ExternalID: "ext-2", Err: bootstrap.ErrRenderTemplate},
+			},
+			status:  http.StatusOK,
+			created: 1,
+			failed:  1,
+			err:     nil,
+		},
+		{
+			desc:        "bulk add configs from CSV",
+			token:       validToken,
+			data:        csvData,
+			contentType: "text/csv",
+			configs: []bootstrap.Config{
+				{ExternalID: "ext-1", ExternalKey: "key-1", Channels: []bootstrap.Channel{{ID: "1"}, {ID: "2"}}, TemplateID: tmplID, Variables: map[string]string{"serial": "SN-1"}},
+				{ExternalID: "ext-2", ExternalKey: "key-2", Channels: []bootstrap.Channel{}, TemplateID: tmplID, Variables: map[string]string{"serial": "SN-2"}},
+			},
+			results: []bootstrap.BulkResult{
+				{ExternalID: "ext-1", Config: bootstrap.Config{ClientID: addClientID}},
+				{ExternalID: "ext-2", Config: bootstrap.Config{ClientID: validID}},
+			},
+			status:  http.StatusOK,
+			created: 2,
+			err:     nil,
+		},
+		{
+			desc:        "bulk add configs with an invalid row",
+			token:       validToken,
+			data:        invalidRow,
+			contentType: contentType,
+			configs: []bootstrap.Config{
+				{ExternalID: "ext-1", ExternalKey: "key-1", Channels: []bootstrap.Channel{{ID: "1"}}},
+			},
+			results: []bootstrap.BulkResult{
+				{ExternalID: "ext-1", Config: bootstrap.Config{ClientID: addClientID}},
+			},
+			status:  http.StatusOK,
+			created: 1,
+			failed:  1,
+			err:     nil,
+		},
+		{
+			desc:        "bulk add configs with an empty token",
+			token:       "",
+			data:        jsonData,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "bulk add configs with invalid content type",
+			token:       validToken,
+			data:        jsonData,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "bulk add configs with malformed CSV",
+			token:       validToken,
+			data:        "external_id,external_key\next-1\n",
+			contentType: "text/csv",
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+		{
+			desc:        "bulk add empty list of configs",
+			token:       validToken,
+			data:        `{"configs": []}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrEmptyList,
+		},
+		{
+			desc:        "bulk add too many configs",
+			token:       validToken,
+			data:        toJSON(map[string]any{"configs": tooMany}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrBulkSize,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("BulkAdd", mock.Anything, session, tc.token, tc.configs).Return(tc.results, nil)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/configs/bulk", bs.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Created int `json:"created"`
+					Failed  int `json:"failed"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.created, body.Created, fmt.Sprintf("%s: expected %d created got %d", tc.desc, tc.created, body.Created))
+				assert.Equal(t, tc.failed, body.Failed, fmt.Sprintf("%s: expected %d failed got %d", tc.desc, tc.failed, body.Failed))
+				svcCall.Parent.AssertCalled(t, "BulkAdd", mock.Anything, session, tc.token, tc.configs)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	tmpl := bootstrap.Template{
+		ID:       validID,
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  `{"serial": "{{.serial}}"}`,
+		Channels: []string{"1"},
+	}
+	data := toJSON(map[string]any{
+		"name":     tmpl.Name,
+		"content":  tmpl.Content,
+		"channels": tmpl.Channels,
+	})
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "create a template",
+			token:       validToken,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			location:    "/clients/templates/" + tmpl.ID,
+			err:         nil,
+		},
+		{
+			desc:        "create a template with an empty token",
+			token:       "",
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "create a template with invalid content type",
+			token:       validToken,
+			data:        data,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "create a template without name",
+			token:       validToken,
+			data:        `{"content": "config"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingName,
+		},
+		{
+			desc:        "create a template with invalid content",
+			token:       validToken,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrInvalidTemplate,
+		},
+		{
+			desc:        "create a template with malformed data",
+			token:       validToken,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("CreateTemplate", mock.Anything, session, mock.Anything).Return(tmpl, tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/templates", bs.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			location := res.Header.Get("Location")
+			assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location '%s' got '%s'", tc.desc, tc.location, location))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewTemplate(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	tmpl := bootstrap.Template{
+		ID:       validID,
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  "config",
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		status   int
+		template bootstrap.Template
+		err      error
+	}{
+		{
+			desc:     "view an existing template",
+			token:    validToken,
+			id:       tmpl.ID,
+			status:   http.StatusOK,
+			template: tmpl,
+			err:      nil,
+		},
+		{
+			desc:   "view a template with an empty token",
+			token:  "",
+			id:     tmpl.ID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "view a non-existing template",
+			token:  validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("ViewTemplate", mock.Anything, session, tc.id).Return(tc.template, tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/templates/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body bootstrap.Template
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.template, body, fmt.Sprintf("%s: expected response %v got %v", tc.desc, tc.template, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	page := bootstrap.TemplatesPage{
+		Total: 1,
+		Limit: 10,
+		Templates: []bootstrap.Template{
+			{ID: validID, DomainID: domainID, Name: "sensor", Content: "config"},
+		},
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		offset uint64
+		limit  uint64
+		status int
+		err    error
+	}{
+		{
+			desc:   "list templates",
+			token:  validToken,
+			offset: 0,
+			limit:  10,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list templates with limit",
+			token:  validToken,
+			query:  "?offset=5&limit=20",
+			offset: 5,
+			limit:  20,
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:   "list templates with an empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "list templates with too large limit",
+			token:  validToken,
+			query:  "?limit=1000",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list templates with invalid offset",
+			token:  validToken,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("ListTemplates", mock.Anything, session, tc.offset, tc.limit).Return(page, nil)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/clients/templates%s", bs.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body bootstrap.TemplatesPage
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, page, body, fmt.Sprintf("%s: expected response %v got %v", tc.desc, page, body))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	tmpl := bootstrap.Template{
+		ID:       validID,
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  `{"serial": "{{.serial}}"}`,
+	}
+	data := toJSON(map[string]any{
+		"name":    tmpl.Name,
+		"content": tmpl.Content,
+	})
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		data        string
+		contentType string
+		status      int
+		err         error
+	}{
+		{
+			desc:        "update a template",
+			token:       validToken,
+			id:          tmpl.ID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "update a template with an empty token",
+			token:       "",
+			id:          tmpl.ID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "update a template with invalid content type",
+			token:       validToken,
+			id:          tmpl.ID,
+			data:        data,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "update a template without name",
+			token:       validToken,
+			id:          tmpl.ID,
+			data:        `{"content": "config"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingName,
+		},
+		{
+			desc:        "update a template with variable missing in a config",
+			token:       validToken,
+			id:          tmpl.ID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         bootstrap.ErrRenderTemplate,
+		},
+		{
+			desc:        "update a non-existing template",
+			token:       validToken,
+			id:          wrongID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("UpdateTemplate", mock.Anything, session, mock.Anything).Return(tmpl, tc.err)
+			req := testRequest{
+				client:      bs.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/clients/templates/%s", bs.URL, domainID, tc.id),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	bs, svc, auth := newBootstrapServer()
+	defer bs.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+		err    error
+	}{
+		{
+			desc:   "remove a template",
+			token:  validToken,
+			id:     validID,
+			status: http.StatusNoContent,
+			err:    nil,
+		},
+		{
+			desc:   "remove a template with an empty token",
+			token:  "",
+			id:     validID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "remove a non-existing template",
+			token:  validToken,
+			id:     wrongID,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var session smqauthn.Session
+			if tc.token == validToken {
+				session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(session, nil)
+			svcCall := svc.On("RemoveTemplate", mock.Anything, session, tc.id).Return(tc.err)
+			req := testRequest{
+				client: bs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/clients/templates/%s", bs.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type channel struct {
 	ID       string `json:"id"`
 	Name     string `json:"name,omitempty"`
@@ -8,7 +8,10 @@ import (
 	"github.com/absmach/supermq/bootstrap"
 )
 
-const maxLimitSize = 100
+const (
+	maxLimitSize = 100
+	maxBulkSize  = 1000
+)
 
 type addReq struct {
 	token       string
@@ -21,6 +24,10 @@ type addReq struct {
 	ClientCert  string   `json:"client_cert"`
 	ClientKey   string   `json:"client_key"`
 	CACert      string   `json:"ca_cert"`
+	Tags        []string `json:"tags"`
+
+	TemplateID string            `json:"template_id"`
+	Variables  map[string]string `json:"variables"`
 }
 
 func (req addReq) validate() error {
@@ -28,19 +35,25 @@ func (req addReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if req.ExternalID == "" {
+	return validateConfig(req.ExternalID, req.ExternalKey, req.TemplateID, req.Channels)
+}
+
+// validateConfig validates the Config to be added. Configs instantiating
+// the Template may omit Channels, to be connected to the Template Channels.
+func validateConfig(externalID, externalKey, templateID string, channels []string) error {
+	if externalID == "" {
 		return apiutil.ErrMissingID
 	}
 
-	if req.ExternalKey == "" {
+	if externalKey == "" {
 		return apiutil.ErrBearerKey
 	}
 
-	if len(req.Channels) == 0 {
+	if len(channels) == 0 && templateID == "" {
 		return apiutil.ErrEmptyList
 	}
 
-	for _, channel := range req.Channels {
+	for _, channel := range channels {
 		if channel == "" {
 			return apiutil.ErrMissingID
 		}
@@ -49,6 +62,45 @@ func (req addReq) validate() error {
 	return nil
 }
 
+type bulkConfigReq struct {
+	ClientID    string            `json:"client_id"`
+	ExternalID  string            `json:"external_id"`
+	ExternalKey string            `json:"external_key"`
+	Name        string            `json:"name"`
+	Content     string            `json:"content"`
+	Channels    []string          `json:"channels"`
+	Tags        []string          `json:"tags"`
+	TemplateID  string            `json:"template_id"`
+	Variables   map[string]string `json:"variables"`
+}
+
+func (req bulkConfigReq) validate() error {
+	return validateConfig(req.ExternalID, req.ExternalKey, req.TemplateID, req.Channels)
+}
+
+// bulkAddReq is validated as a whole, while each of the Configs is
+// validated separately, so an invalid Config fails only its own row.
+type bulkAddReq struct {
+	token   string
+	Configs []bulkConfigReq `json:"configs"`
+}
+
+func (req bulkAddReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.Configs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	if len(req.Configs) > maxBulkSize {
+		return bootstrap.ErrBulkSize
+	}
+
+	return nil
+}
+
 type entityReq struct {
 	id string
 }
@@ -63,8 +115,9 @@ func (req entityReq) validate() error {
 
 type updateReq struct {
 	id      string
-	Name    string `json:"name"`
-	Content string `json:"content"`
+	Name    string   `json:"name"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
 }
 
 func (req updateReq) validate() error {
@@ -90,6 +143,24 @@ func (req updateCertReq) validate() error {
 	return nil
 }
 
+type enrollCertReq struct {
+	id         string
+	ClientCert string `json:"client_cert"`
+	CACert     string `json:"ca_cert"`
+}
+
+func (req enrollCertReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.ClientCert == "" {
+		return apiutil.ErrMissingCertData
+	}
+
+	return nil
+}
+
 type updateConnReq struct {
 	token    string
 	id       string
@@ -161,3 +232,163 @@ func (req changeStateReq) validate() error {
 
 	return nil
 }
+
+type listRevisionsReq struct {
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listRevisionsReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type diffRevisionsReq struct {
+	id   string
+	from uint64
+	to   uint64
+}
+
+func (req diffRevisionsReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.from == 0 || req.to == 0 {
+		return bootstrap.ErrInvalidRevision
+	}
+
+	return nil
+}
+
+type createRolloutReq struct {
+	Content          string   `json:"content"`
+	Tags             []string `json:"tags"`
+	Percentage       uint8    `json:"percentage"`
+	FailureThreshold uint8    `json:"failure_threshold"`
+}
+
+func (req createRolloutReq) validate() error {
+	if req.Percentage == 0 || req.Percentage > 100 {
+		return bootstrap.ErrRolloutPercentage
+	}
+
+	if req.FailureThreshold > 100 {
+		return bootstrap.ErrFailureThreshold
+	}
+
+	return nil
+}
+
+type rolloutReq struct {
+	id string
+}
+
+func (req rolloutReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type ackReq struct {
+	key      string
+	id       string
+	Revision uint64              `json:"revision"`
+	Status   bootstrap.AckStatus `json:"status"`
+	Message  string              `json:"message"`
+}
+
+func (req ackReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Revision == 0 {
+		return bootstrap.ErrInvalidRevision
+	}
+
+	if req.Status != bootstrap.AckApplied &&
+		req.Status != bootstrap.AckFailed {
+		return bootstrap.ErrInvalidAckStatus
+	}
+
+	return nil
+}
+
+type createTemplateReq struct {
+	Name     string   `json:"name"`
+	Content  string   `json:"content"`
+	Channels []string `json:"channels"`
+}
+
+func (req createTemplateReq) validate() error {
+	return validateTemplate(req.Name, req.Channels)
+}
+
+type updateTemplateReq struct {
+	id       string
+	Name     string   `json:"name"`
+	Content  string   `json:"content"`
+	Channels []string `json:"channels"`
+}
+
+func (req updateTemplateReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return validateTemplate(req.Name, req.Channels)
+}
+
+func validateTemplate(name string, channels []string) error {
+	if name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	for _, channel := range channels {
+		if channel == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+type templateEntityReq struct {
+	id string
+}
+
+func (req templateEntityReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listTemplatesReq struct {
+	offset uint64
+	limit  uint64
+}
+
+func (req listTemplatesReq) validate() error {
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -311,3 +311,433 @@ func TestChangeStateReqValidation(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestListRevisionsReqValidation(t *testing.T) {
+	cases := []struct {
+		desc  string
+		id    string
+		limit uint64
+		err   error
+	}{
+		{
+			desc:  "valid request",
+			id:    "id",
+			limit: 10,
+			err:   nil,
+		},
+		{
+			desc:  "empty id",
+			id:    "",
+			limit: 10,
+			err:   apiutil.ErrMissingID,
+		},
+		{
+			desc:  "too big limit",
+			id:    "id",
+			limit: 101,
+			err:   apiutil.ErrLimitSize,
+		},
+	}
+
+	for _, tc := range cases {
+		req := listRevisionsReq{
+			id:    tc.id,
+			limit: tc.limit,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDiffRevisionsReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		id   string
+		from uint64
+		to   uint64
+		err  error
+	}{
+		{
+			desc: "valid request",
+			id:   "id",
+			from: 1,
+			to:   2,
+			err:  nil,
+		},
+		{
+			desc: "empty id",
+			id:   "",
+			from: 1,
+			to:   2,
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc: "missing from revision",
+			id:   "id",
+			from: 0,
+			to:   2,
+			err:  bootstrap.ErrInvalidRevision,
+		},
+		{
+			desc: "missing to revision",
+			id:   "id",
+			from: 1,
+			to:   0,
+			err:  bootstrap.ErrInvalidRevision,
+		},
+	}
+
+	for _, tc := range cases {
+		req := diffRevisionsReq{
+			id:   tc.id,
+			from: tc.from,
+			to:   tc.to,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCreateRolloutReqValidation(t *testing.T) {
+	cases := []struct {
+		desc             string
+		percentage       uint8
+		failureThreshold uint8
+		err              error
+	}{
+		{
+			desc:             "valid request",
+			percentage:       25,
+			failureThreshold: 10,
+			err:              nil,
+		},
+		{
+			desc:       "zero percentage",
+			percentage: 0,
+			err:        bootstrap.ErrRolloutPercentage,
+		},
+		{
+			desc:       "too big percentage",
+			percentage: 101,
+			err:        bootstrap.ErrRolloutPercentage,
+		},
+		{
+			desc:             "too big failure threshold",
+			percentage:       100,
+			failureThreshold: 101,
+			err:              bootstrap.ErrFailureThreshold,
+		},
+	}
+
+	for _, tc := range cases {
+		req := createRolloutReq{
+			Content:          "config",
+			Percentage:       tc.percentage,
+			FailureThreshold: tc.failureThreshold,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRolloutReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "valid request",
+			id:   "id",
+			err:  nil,
+		},
+		{
+			desc: "empty id",
+			id:   "",
+			err:  apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		req := rolloutReq{
+			id: tc.id,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAckReqValidation(t *testing.T) {
+	cases := []struct {
+		desc     string
+		key      string
+		id       string
+		revision uint64
+		status   bootstrap.AckStatus
+		err      error
+	}{
+		{
+			desc:     "valid request",
+			key:      "key",
+			id:       "id",
+			revision: 1,
+			status:   bootstrap.AckApplied,
+			err:      nil,
+		},
+		{
+			desc:     "empty key",
+			key:      "",
+			id:       "id",
+			revision: 1,
+			status:   bootstrap.AckApplied,
+			err:      apiutil.ErrBearerKey,
+		},
+		{
+			desc:     "empty id",
+			key:      "key",
+			id:       "",
+			revision: 1,
+			status:   bootstrap.AckApplied,
+			err:      apiutil.ErrMissingID,
+		},
+		{
+			desc:     "missing revision",
+			key:      "key",
+			id:       "id",
+			revision: 0,
+			status:   bootstrap.AckFailed,
+			err:      bootstrap.ErrInvalidRevision,
+		},
+		{
+			desc:     "invalid status",
+			key:      "key",
+			id:       "id",
+			revision: 1,
+			status:   "unknown",
+			err:      bootstrap.ErrInvalidAckStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		req := ackReq{
+			key:      tc.key,
+			id:       tc.id,
+			Revision: tc.revision,
+			Status:   tc.status,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestBulkAddReqValidation(t *testing.T) {
+	cfg := bulkConfigReq{
+		ExternalID:  "external-id",
+		ExternalKey: "external-key",
+		Channels:    []string{channel1},
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		configs []bulkConfigReq
+		err     error
+	}{
+		{
+			desc:    "valid request",
+			token:   "token",
+			configs: []bulkConfigReq{cfg},
+			err:     nil,
+		},
+		{
+			desc:    "valid request with an invalid config",
+			token:   "token",
+			configs: []bulkConfigReq{cfg, {ExternalID: "external-id"}},
+			err:     nil,
+		},
+		{
+			desc:    "empty token",
+			token:   "",
+			configs: []bulkConfigReq{cfg},
+			err:     apiutil.ErrBearerToken,
+		},
+		{
+			desc:    "empty configs",
+			token:   "token",
+			configs: []bulkConfigReq{},
+			err:     apiutil.ErrEmptyList,
+		},
+		{
+			desc:    "too many configs",
+			token:   "token",
+			configs: make([]bulkConfigReq, maxBulkSize+1),
+			err:     bootstrap.ErrBulkSize,
+		},
+	}
+
+	for _, tc := range cases {
+		req := bulkAddReq{
+			token:   tc.token,
+			Configs: tc.configs,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestBulkConfigReqValidation(t *testing.T) {
+	cases := []struct {
+		desc       string
+		templateID string
+		channels   []string
+		err        error
+	}{
+		{
+			desc:     "valid request",
+			channels: []string{channel1},
+			err:      nil,
+		},
+		{
+			desc:       "valid request with template and without channels",
+			templateID: "template-id",
+			err:        nil,
+		},
+		{
+			desc: "empty template and channels",
+			err:  apiutil.ErrEmptyList,
+		},
+	}
+
+	for _, tc := range cases {
+		req := bulkConfigReq{
+			ExternalID:  "external-id",
+			ExternalKey: "external-key",
+			TemplateID:  tc.templateID,
+			Channels:    tc.channels,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateTemplateReqValidation(t *testing.T) {
+	cases := []struct {
+		desc     string
+		id       string
+		name     string
+		channels []string
+		err      error
+	}{
+		{
+			desc:     "valid request",
+			id:       "id",
+			name:     "name",
+			channels: []string{channel1},
+			err:      nil,
+		},
+		{
+			desc: "empty id",
+			id:   "",
+			name: "name",
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty name",
+			id:   "id",
+			name: "",
+			err:  apiutil.ErrMissingName,
+		},
+		{
+			desc:     "empty channel value",
+			id:       "id",
+			name:     "name",
+			channels: []string{channel1, ""},
+			err:      apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		req := updateTemplateReq{
+			id:       tc.id,
+			Name:     tc.name,
+			Content:  "config",
+			Channels: tc.channels,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListTemplatesReqValidation(t *testing.T) {
+	cases := []struct {
+		desc  string
+		limit uint64
+		err   error
+	}{
+		{
+			desc:  "valid request",
+			limit: 10,
+			err:   nil,
+		},
+		{
+			desc:  "too big limit",
+			limit: maxLimitSize + 1,
+			err:   apiutil.ErrLimitSize,
+		},
+	}
+
+	for _, tc := range cases {
+		req := listTemplatesReq{
+			limit: tc.limit,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestEnrollCertReqValidation(t *testing.T) {
+	cases := []struct {
+		desc       string
+		id         string
+		clientCert string
+		err        error
+	}{
+		{
+			desc:       "valid request",
+			id:         "id",
+			clientCert: "cert",
+			err:        nil,
+		},
+		{
+			desc:       "empty id",
+			id:         "",
+			clientCert: "cert",
+			err:        apiutil.ErrMissingID,
+		},
+		{
+			desc:       "empty client cert",
+			id:         "id",
+			clientCert: "",
+			err:        apiutil.ErrMissingCertData,
+		},
+	}
+
+	for _, tc := range cases {
+		req := enrollCertReq{
+			id:         tc.id,
+			ClientCert: tc.clientCert,
+		}
+
+		err := req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
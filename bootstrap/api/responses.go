@@ -17,6 +17,13 @@ var (
 	_ supermq.Response = (*stateRes)(nil)
 	_ supermq.Response = (*viewRes)(nil)
 	_ supermq.Response = (*listRes)(nil)
+	_ supermq.Response = (*revisionsRes)(nil)
+	_ supermq.Response = (*diffRes)(nil)
+	_ supermq.Response = (*rolloutRes)(nil)
+	_ supermq.Response = (*ackRes)(nil)
+	_ supermq.Response = (*bulkRes)(nil)
+	_ supermq.Response = (*templateRes)(nil)
+	_ supermq.Response = (*templatesRes)(nil)
 )
 
 type removeRes struct{}
@@ -77,6 +84,14 @@ type viewRes struct {
 	State        bootstrap.State `json:"state"`
 	ClientCert   string          `json:"client_cert,omitempty"`
 	CACert       string          `json:"ca_cert,omitempty"`
+	Tags         []string        `json:"tags,omitempty"`
+
+	Revision        uint64        `json:"revision"`
+	AppliedRevision uint64        `json:"applied_revision"`
+	LastAck         bootstrap.Ack `json:"last_ack,omitzero"`
+
+	TemplateID string            `json:"template_id,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
 }
 
 func (res viewRes) Code() int {
@@ -142,3 +157,148 @@ func (res updateConfigRes) Headers() map[string]string {
 func (res updateConfigRes) Empty() bool {
 	return false
 }
+
+type revisionsRes struct {
+	Total     uint64               `json:"total"`
+	Offset    uint64               `json:"offset"`
+	Limit     uint64               `json:"limit"`
+	Revisions []bootstrap.Revision `json:"revisions"`
+}
+
+func (res revisionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res revisionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revisionsRes) Empty() bool {
+	return false
+}
+
+type diffRes struct {
+	bootstrap.RevisionDiff
+}
+
+func (res diffRes) Code() int {
+	return http.StatusOK
+}
+
+func (res diffRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res diffRes) Empty() bool {
+	return false
+}
+
+type rolloutRes struct {
+	bootstrap.Rollout
+	created bool
+}
+
+func (res rolloutRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res rolloutRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/clients/rollouts/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res rolloutRes) Empty() bool {
+	return false
+}
+
+type ackRes struct{}
+
+func (res ackRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ackRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ackRes) Empty() bool {
+	return true
+}
+
+type bulkRowRes struct {
+	Index      int    `json:"index"`
+	ExternalID string `json:"external_id"`
+	ClientID   string `json:"client_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type bulkRes struct {
+	Total   int          `json:"total"`
+	Created int          `json:"created"`
+	Failed  int          `json:"failed"`
+	Results []bulkRowRes `json:"results"`
+}
+
+func (res bulkRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bulkRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bulkRes) Empty() bool {
+	return false
+}
+
+type templateRes struct {
+	bootstrap.Template
+	created bool
+}
+
+func (res templateRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res templateRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/clients/templates/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res templateRes) Empty() bool {
+	return false
+}
+
+type templatesRes struct {
+	bootstrap.TemplatesPage
+}
+
+func (res templatesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res templatesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res templatesRes) Empty() bool {
+	return false
+}
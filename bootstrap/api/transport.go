@@ -5,7 +5,10 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/csv"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -17,6 +20,7 @@ import (
 	"github.com/absmach/supermq/bootstrap"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -25,9 +29,13 @@ import (
 
 const (
 	contentType     = "application/json"
+	csvContentType  = "text/csv"
 	byteContentType = "application/octet-stream"
+	csvListSep      = ";"
 	offsetKey       = "offset"
 	limitKey        = "limit"
+	fromKey         = "from"
+	toKey           = "to"
 	defOffset       = 0
 	defLimit        = 10
 )
@@ -40,7 +48,8 @@ var (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader bootstrap.ConfigReader, logger *slog.Logger, instanceID string) http.Handler {
+// The certs secret authenticates the Certs service linking the enrolled certificates.
+func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader bootstrap.ConfigReader, logger *slog.Logger, instanceID, certsSecret string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
@@ -57,6 +66,12 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader b
 					api.EncodeResponse,
 					opts...), "add").ServeHTTP)
 
+				r.Post("/bulk", otelhttp.NewHandler(kithttp.NewServer(
+					bulkAddEndpoint(svc),
+					decodeBulkAddRequest,
+					api.EncodeResponse,
+					opts...), "bulk_add").ServeHTTP)
+
 				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 					listEndpoint(svc),
 					decodeListRequest,
@@ -92,6 +107,70 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader b
 					decodeUpdateConnRequest,
 					api.EncodeResponse,
 					opts...), "update_connections").ServeHTTP)
+
+				r.Get("/{configID}/revisions", otelhttp.NewHandler(kithttp.NewServer(
+					listRevisionsEndpoint(svc),
+					decodeListRevisionsRequest,
+					api.EncodeResponse,
+					opts...), "list_revisions").ServeHTTP)
+
+				r.Get("/{configID}/revisions/diff", otelhttp.NewHandler(kithttp.NewServer(
+					diffRevisionsEndpoint(svc),
+					decodeDiffRevisionsRequest,
+					api.EncodeResponse,
+					opts...), "diff_revisions").ServeHTTP)
+			})
+
+			r.Route("/rollouts", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createRolloutEndpoint(svc),
+					decodeCreateRolloutRequest,
+					api.EncodeResponse,
+					opts...), "create_rollout").ServeHTTP)
+
+				r.Get("/{rolloutID}", otelhttp.NewHandler(kithttp.NewServer(
+					viewRolloutEndpoint(svc),
+					decodeRolloutRequest,
+					api.EncodeResponse,
+					opts...), "view_rollout").ServeHTTP)
+
+				r.Post("/{rolloutID}/rollback", otelhttp.NewHandler(kithttp.NewServer(
+					rollbackRolloutEndpoint(svc),
+					decodeRolloutRequest,
+					api.EncodeResponse,
+					opts...), "rollback_rollout").ServeHTTP)
+			})
+
+			r.Route("/templates", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createTemplateEndpoint(svc),
+					decodeCreateTemplateRequest,
+					api.EncodeResponse,
+					opts...), "create_template").ServeHTTP)
+
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listTemplatesEndpoint(svc),
+					decodeListTemplatesRequest,
+					api.EncodeResponse,
+					opts...), "list_templates").ServeHTTP)
+
+				r.Get("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					viewTemplateEndpoint(svc),
+					decodeTemplateEntityRequest,
+					api.EncodeResponse,
+					opts...), "view_template").ServeHTTP)
+
+				r.Put("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					updateTemplateEndpoint(svc),
+					decodeUpdateTemplateRequest,
+					api.EncodeResponse,
+					opts...), "update_template").ServeHTTP)
+
+				r.Delete("/{templateID}", otelhttp.NewHandler(kithttp.NewServer(
+					removeTemplateEndpoint(svc),
+					decodeTemplateEntityRequest,
+					api.EncodeResponse,
+					opts...), "remove_template").ServeHTTP)
 			})
 		})
 
@@ -113,6 +192,16 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader b
 			decodeBootstrapRequest,
 			api.EncodeResponse,
 			opts...), "bootstrap").ServeHTTP)
+		r.Post("/{externalID}/ack", otelhttp.NewHandler(kithttp.NewServer(
+			ackEndpoint(svc),
+			decodeAckRequest,
+			api.EncodeResponse,
+			opts...), "acknowledge").ServeHTTP)
+		r.With(certsAuthMiddleware(certsSecret)).Patch("/certs/{externalID}", otelhttp.NewHandler(kithttp.NewServer(
+			enrollCertEndpoint(svc),
+			decodeEnrollCertRequest,
+			api.EncodeResponse,
+			opts...), "enroll_cert").ServeHTTP)
 		r.Get("/secure/{externalID}", otelhttp.NewHandler(kithttp.NewServer(
 			bootstrapEndpoint(svc, reader, true),
 			decodeBootstrapRequest,
@@ -126,6 +215,26 @@ func MakeHandler(svc bootstrap.Service, authn smqauthn.AuthNMiddleware, reader b
 	return r
 }
 
+// certsAuthMiddleware authenticates the Certs service with the secret shared with it.
+// Requests are refused if the secret is not configured.
+func certsAuthMiddleware(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := apiutil.ExtractBearerToken(r)
+			if token == "" {
+				api.EncodeError(r.Context(), apiutil.ErrBearerToken, w)
+				return
+			}
+			if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+				api.EncodeError(r.Context(), svcerr.ErrAuthentication, w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func decodeAddRequest(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -141,6 +250,29 @@ func decodeAddRequest(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
+func decodeBulkAddRequest(_ context.Context, r *http.Request) (any, error) {
+	req := bulkAddReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	switch ct := r.Header.Get("Content-Type"); {
+	case strings.Contains(ct, contentType):
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+		}
+	case strings.Contains(ct, csvContentType):
+		cfgs, err := readCSVConfigs(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+		}
+		req.Configs = cfgs
+	default:
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	return req, nil
+}
+
 func decodeUpdateRequest(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -171,6 +303,21 @@ func decodeUpdateCertRequest(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
+func decodeEnrollCertRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := enrollCertReq{
+		id: chi.URLParam(r, "externalID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
 func decodeUpdateConnRequest(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -245,6 +392,204 @@ func decodeEntityRequest(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
+func decodeListRevisionsRequest(_ context.Context, r *http.Request) (any, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	l, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listRevisionsReq{
+		id:     chi.URLParam(r, "configID"),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeDiffRevisionsRequest(_ context.Context, r *http.Request) (any, error) {
+	from, err := apiutil.ReadNumQuery[uint64](r, fromKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	to, err := apiutil.ReadNumQuery[uint64](r, toKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := diffRevisionsReq{
+		id:   chi.URLParam(r, "configID"),
+		from: from,
+		to:   to,
+	}
+
+	return req, nil
+}
+
+func decodeCreateRolloutRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req createRolloutReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeRolloutRequest(_ context.Context, r *http.Request) (any, error) {
+	req := rolloutReq{
+		id: chi.URLParam(r, "rolloutID"),
+	}
+
+	return req, nil
+}
+
+func decodeAckRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := ackReq{
+		id:  chi.URLParam(r, "externalID"),
+		key: apiutil.ExtractClientSecret(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeCreateTemplateRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req createTemplateReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTemplateRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateTemplateReq{
+		id: chi.URLParam(r, "templateID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeTemplateEntityRequest(_ context.Context, r *http.Request) (any, error) {
+	req := templateEntityReq{
+		id: chi.URLParam(r, "templateID"),
+	}
+
+	return req, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, r *http.Request) (any, error) {
+	o, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	l, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listTemplatesReq{
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+// readCSVConfigs reads the Configs from CSV with the header row. Columns
+// client_id, external_id, external_key, name, content, channels, tags and
+// template_id set the corresponding Config fields, where channels and tags
+// are separated by semicolons. All the other columns are Template variables.
+// Reading stops past the bulk size limit, so the request is rejected without
+// reading all of it.
+func readCSVConfigs(r io.Reader) ([]bulkConfigReq, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfgs []bulkConfigReq
+	for len(cfgs) <= maxBulkSize {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var cfg bulkConfigReq
+		for i, col := range header {
+			val := record[i]
+			switch col {
+			case "client_id":
+				cfg.ClientID = val
+			case "external_id":
+				cfg.ExternalID = val
+			case "external_key":
+				cfg.ExternalKey = val
+			case "name":
+				cfg.Name = val
+			case "content":
+				cfg.Content = val
+			case "channels":
+				cfg.Channels = splitList(val)
+			case "tags":
+				cfg.Tags = splitList(val)
+			case "template_id":
+				cfg.TemplateID = val
+			default:
+				if cfg.Variables == nil {
+					cfg.Variables = map[string]string{}
+				}
+				cfg.Variables[col] = val
+			}
+		}
+		cfgs = append(cfgs, cfg)
+	}
+
+	return cfgs, nil
+}
+
+func splitList(val string) []string {
+	if val == "" {
+		return nil
+	}
+
+	return strings.Split(val, csvListSep)
+}
+
 func encodeSecureRes(_ context.Context, w http.ResponseWriter, response any) error {
 	w.Header().Set("Content-Type", byteContentType)
 	w.WriteHeader(http.StatusOK)
@@ -15,6 +15,9 @@ import (
 // MGClient represents corresponding SuperMQ Client ID.
 // MGKey is key of corresponding SuperMQ Client.
 // MGChannels is a list of SuperMQ Channels corresponding SuperMQ Client connects to.
+// Revision is the current revision of the Content, and AppliedRevision is the
+// last revision the device reported as applied. Content of the Config with
+// TemplateID is rendered from the Template using Variables.
 type Config struct {
 	ClientID     string    `json:"client_id"`
 	ClientSecret string    `json:"client_secret"`
@@ -28,6 +31,14 @@ type Config struct {
 	ExternalKey  string    `json:"external_key"`
 	Content      string    `json:"content,omitempty"`
 	State        State     `json:"state"`
+	Tags         []string  `json:"tags,omitempty"`
+
+	TemplateID string            `json:"template_id,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+
+	Revision        uint64 `json:"revision"`
+	AppliedRevision uint64 `json:"applied_revision"`
+	LastAck         Ack    `json:"last_ack,omitzero"`
 }
 
 // Channel represents SuperMQ channel corresponding SuperMQ Client is connected to.
@@ -76,8 +87,9 @@ type ConfigRepository interface {
 	// RetrieveByExternalID returns Config for given external ID.
 	RetrieveByExternalID(ctx context.Context, externalID string) (Config, error)
 
-	// Update updates an existing Config. A non-nil error is returned
-	// to indicate operation failure.
+	// Update updates an existing Config. If the content is changed, a new
+	// revision is created. A non-nil error is returned to indicate operation
+	// failure.
 	Update(ctx context.Context, cfg Config) error
 
 	// UpdateCerts updates and returns an existing Config certificate and domainID.
@@ -115,4 +127,51 @@ type ConfigRepository interface {
 
 	// DisconnectClient changes state of the Config when the corresponding Client is disconnected from the Channel.
 	DisconnectClient(ctx context.Context, channelID, clientID string) error
+
+	// RetrieveRevisions retrieves a subset of the Config revisions, the latest first.
+	RetrieveRevisions(ctx context.Context, domainID, clientID string, offset, limit uint64) (RevisionsPage, error)
+
+	// RetrieveRevision retrieves the Config revision.
+	RetrieveRevision(ctx context.Context, domainID, clientID string, revision uint64) (Revision, error)
+
+	// RetrieveTargets retrieves IDs of the domain Configs having all the given tags.
+	RetrieveTargets(ctx context.Context, domainID string, tags []string) ([]string, error)
+
+	// SaveRollout persists the Rollout and creates the Rollout revision of
+	// each of the targeted Configs.
+	SaveRollout(ctx context.Context, ro Rollout, clientIDs []string) (Rollout, error)
+
+	// RetrieveRollout retrieves the Rollout with the acknowledgment counts.
+	RetrieveRollout(ctx context.Context, domainID, id string) (Rollout, error)
+
+	// UpdateRolloutStatus changes the status of the Rollout.
+	UpdateRolloutStatus(ctx context.Context, id string, status RolloutStatus, at time.Time) error
+
+	// RollbackRollout restores the previous content of the targeted Configs
+	// which are not changed after the Rollout, and marks it rolled back.
+	RollbackRollout(ctx context.Context, id string, at time.Time) error
+
+	// SaveAck persists the device acknowledgment and returns the ID of the
+	// Rollout the acknowledged revision belongs to, if any.
+	SaveAck(ctx context.Context, ack Ack) (string, error)
+
+	// SaveTemplate persists the Template.
+	SaveTemplate(ctx context.Context, t Template) (Template, error)
+
+	// RetrieveTemplate retrieves the Template with the given ID.
+	RetrieveTemplate(ctx context.Context, domainID, id string) (Template, error)
+
+	// RetrieveAllTemplates retrieves a subset of the domain Templates.
+	RetrieveAllTemplates(ctx context.Context, domainID string, offset, limit uint64) (TemplatesPage, error)
+
+	// RetrieveByTemplate retrieves the Configs instantiating the Template.
+	RetrieveByTemplate(ctx context.Context, domainID, templateID string) ([]Config, error)
+
+	// UpdateTemplate updates the Template and the content of the given
+	// Configs instantiating it, creating a new revision of each changed Config.
+	UpdateTemplate(ctx context.Context, t Template, cfgs []Config) error
+
+	// RemoveTemplate removes the Template. Configs instantiating it keep
+	// their current content.
+	RemoveTemplate(ctx context.Context, domainID, id string) error
 }
@@ -23,11 +23,21 @@ const (
 	clientUpdateConnections = clientPrefix + "update_connections"
 	clientConnect           = clientPrefix + "connect"
 	clientDisconnect        = clientPrefix + "disconnect"
+	clientAcknowledge       = clientPrefix + "acknowledge"
 
 	channelPrefix        = "bootstrap.channel."
 	channelHandlerRemove = channelPrefix + "remove_handler"
 	channelUpdateHandler = channelPrefix + "update_handler"
 
+	templatePrefix = "bootstrap.template."
+	templateCreate = templatePrefix + "create"
+	templateUpdate = templatePrefix + "update"
+	templateRemove = templatePrefix + "remove"
+
+	rolloutPrefix   = "bootstrap.rollout."
+	rolloutCreate   = rolloutPrefix + "create"
+	rolloutRollback = rolloutPrefix + "rollback"
+
 	certUpdate = "bootstrap.cert.update"
 )
 
@@ -40,6 +50,10 @@ var (
 	_ events.Event = (*updateCertEvent)(nil)
 	_ events.Event = (*listConfigsEvent)(nil)
 	_ events.Event = (*removeHandlerEvent)(nil)
+	_ events.Event = (*rolloutEvent)(nil)
+	_ events.Event = (*ackEvent)(nil)
+	_ events.Event = (*templateEvent)(nil)
+	_ events.Event = (*removeTemplateEvent)(nil)
 )
 
 type configEvent struct {
@@ -86,6 +100,9 @@ func (ce configEvent) Encode() (map[string]any, error) {
 	if ce.Content != "" {
 		val["content"] = ce.Content
 	}
+	if ce.TemplateID != "" {
+		val["template_id"] = ce.TemplateID
+	}
 
 	return val, nil
 }
@@ -275,3 +292,83 @@ func (dte disconnectClientEvent) Encode() (map[string]any, error) {
 		"operation":  clientDisconnect,
 	}, nil
 }
+
+type rolloutEvent struct {
+	bootstrap.Rollout
+	operation string
+}
+
+func (re rolloutEvent) Encode() (map[string]any, error) {
+	val := map[string]any{
+		"id":                re.ID,
+		"domain_id":         re.DomainID,
+		"percentage":        re.Percentage,
+		"failure_threshold": re.FailureThreshold,
+		"status":            string(re.Status),
+		"targets":           re.Targets,
+		"operation":         re.operation,
+	}
+	if len(re.Tags) > 0 {
+		val["tags"] = re.Tags
+	}
+	if re.CreatedBy != "" {
+		val["created_by"] = re.CreatedBy
+	}
+
+	return val, nil
+}
+
+type ackEvent struct {
+	bootstrap.Ack
+	externalID string
+}
+
+func (ae ackEvent) Encode() (map[string]any, error) {
+	val := map[string]any{
+		"external_id": ae.externalID,
+		"revision":    ae.Revision,
+		"status":      string(ae.Status),
+		"operation":   clientAcknowledge,
+	}
+	if ae.Message != "" {
+		val["message"] = ae.Message
+	}
+
+	return val, nil
+}
+
+type templateEvent struct {
+	bootstrap.Template
+	operation string
+}
+
+func (te templateEvent) Encode() (map[string]any, error) {
+	val := map[string]any{
+		"id":        te.ID,
+		"domain_id": te.DomainID,
+		"name":      te.Name,
+		"operation": te.operation,
+	}
+	if te.Content != "" {
+		val["content"] = te.Content
+	}
+	if len(te.Channels) > 0 {
+		val["channels"] = te.Channels
+	}
+	if te.CreatedBy != "" {
+		val["created_by"] = te.CreatedBy
+	}
+
+	return val, nil
+}
+
+type removeTemplateEvent struct {
+	id string
+}
+
+func (rte removeTemplateEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"id":        rte.id,
+		"operation": templateRemove,
+	}, nil
+}
@@ -29,6 +29,12 @@ const (
 	disconnectStream           = magistralaPrefix + clientDisconnect
 	updateHandlerStream        = magistralaPrefix + channelUpdateHandler
 	removeChannelHandlerStream = magistralaPrefix + channelHandlerRemove
+	createRolloutStream        = magistralaPrefix + rolloutCreate
+	rollbackRolloutStream      = magistralaPrefix + rolloutRollback
+	acknowledgeStream          = magistralaPrefix + clientAcknowledge
+	createTemplateStream       = magistralaPrefix + templateCreate
+	updateTemplateStream       = magistralaPrefix + templateUpdate
+	removeTemplateStream       = magistralaPrefix + templateRemove
 )
 
 type eventStore struct {
@@ -62,6 +68,27 @@ func (es *eventStore) Add(ctx context.Context, session smqauthn.Session, token s
 	return saved, err
 }
 
+func (es *eventStore) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []bootstrap.Config) ([]bootstrap.BulkResult, error) {
+	results, err := es.svc.BulkAdd(ctx, session, token, cfgs)
+	if err != nil {
+		return results, err
+	}
+
+	for _, res := range results {
+		if res.Err != nil {
+			continue
+		}
+		ev := configEvent{
+			res.Config, configCreate,
+		}
+		if err := es.Publish(ctx, createStream, ev); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
 func (es *eventStore) View(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Config, error) {
 	cfg, err := es.svc.View(ctx, session, id)
 	if err != nil {
@@ -110,6 +137,25 @@ func (es eventStore) UpdateCert(ctx context.Context, session smqauthn.Session, c
 	return cfg, nil
 }
 
+func (es eventStore) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (bootstrap.Config, error) {
+	cfg, err := es.svc.EnrollCert(ctx, externalID, clientCert, caCert)
+	if err != nil {
+		return cfg, err
+	}
+
+	ev := updateCertEvent{
+		clientID:   cfg.ClientID,
+		clientCert: clientCert,
+		caCert:     caCert,
+	}
+
+	if err := es.Publish(ctx, updateCertStream, ev); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
 func (es *eventStore) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	if err := es.svc.UpdateConnections(ctx, session, token, id, connections); err != nil {
 		return err
@@ -188,6 +234,118 @@ func (es *eventStore) ChangeState(ctx context.Context, session smqauthn.Session,
 	return es.Publish(ctx, stateChangeStream, ev)
 }
 
+func (es *eventStore) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (bootstrap.RevisionsPage, error) {
+	return es.svc.ListRevisions(ctx, session, id, offset, limit)
+}
+
+func (es *eventStore) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (bootstrap.RevisionDiff, error) {
+	return es.svc.DiffRevisions(ctx, session, id, from, to)
+}
+
+func (es *eventStore) CreateRollout(ctx context.Context, session smqauthn.Session, ro bootstrap.Rollout) (bootstrap.Rollout, error) {
+	saved, err := es.svc.CreateRollout(ctx, session, ro)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := rolloutEvent{
+		saved, rolloutCreate,
+	}
+
+	if err := es.Publish(ctx, createRolloutStream, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	return es.svc.ViewRollout(ctx, session, id)
+}
+
+func (es *eventStore) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ro, err := es.svc.RollbackRollout(ctx, session, id)
+	if err != nil {
+		return ro, err
+	}
+
+	ev := rolloutEvent{
+		ro, rolloutRollback,
+	}
+
+	if err := es.Publish(ctx, rollbackRolloutStream, ev); err != nil {
+		return ro, err
+	}
+
+	return ro, nil
+}
+
+func (es *eventStore) Acknowledge(ctx context.Context, externalKey, externalID string, ack bootstrap.Ack) error {
+	if err := es.svc.Acknowledge(ctx, externalKey, externalID, ack); err != nil {
+		return err
+	}
+
+	ev := ackEvent{
+		ack, externalID,
+	}
+
+	return es.Publish(ctx, acknowledgeStream, ev)
+}
+
+func (es *eventStore) CreateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	saved, err := es.svc.CreateTemplate(ctx, session, t)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := templateEvent{
+		saved, templateCreate,
+	}
+
+	if err := es.Publish(ctx, createTemplateStream, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Template, error) {
+	return es.svc.ViewTemplate(ctx, session, id)
+}
+
+func (es *eventStore) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	return es.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+func (es *eventStore) UpdateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	saved, err := es.svc.UpdateTemplate(ctx, session, t)
+	if err != nil {
+		return saved, err
+	}
+
+	ev := templateEvent{
+		saved, templateUpdate,
+	}
+
+	if err := es.Publish(ctx, updateTemplateStream, ev); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := es.svc.RemoveTemplate(ctx, session, id); err != nil {
+		return err
+	}
+
+	ev := removeTemplateEvent{
+		id: id,
+	}
+
+	return es.Publish(ctx, removeTemplateStream, ev)
+}
+
 func (es *eventStore) RemoveConfigHandler(ctx context.Context, id string) error {
 	if err := es.svc.RemoveConfigHandler(ctx, id); err != nil {
 		return err
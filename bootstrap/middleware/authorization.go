@@ -41,6 +41,14 @@ func (am *authorizationMiddleware) Add(ctx context.Context, session smqauthn.Ses
 	return am.svc.Add(ctx, session, token, cfg)
 }
 
+func (am *authorizationMiddleware) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []bootstrap.Config) ([]bootstrap.BulkResult, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return nil, err
+	}
+
+	return am.svc.BulkAdd(ctx, session, token, cfgs)
+}
+
 func (am *authorizationMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Config, error) {
 	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, readPermission, policies.ClientType, id); err != nil {
 		return bootstrap.Config{}, err
@@ -65,6 +73,10 @@ func (am *authorizationMiddleware) UpdateCert(ctx context.Context, session smqau
 	return am.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+func (am *authorizationMiddleware) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (bootstrap.Config, error) {
+	return am.svc.EnrollCert(ctx, externalID, clientCert, caCert)
+}
+
 func (am *authorizationMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, updatePermission, policies.ClientType, id); err != nil {
 		return err
@@ -100,6 +112,90 @@ func (am *authorizationMiddleware) ChangeState(ctx context.Context, session smqa
 	return am.svc.ChangeState(ctx, session, token, id, state)
 }
 
+func (am *authorizationMiddleware) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (bootstrap.RevisionsPage, error) {
+	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, readPermission, policies.ClientType, id); err != nil {
+		return bootstrap.RevisionsPage{}, err
+	}
+
+	return am.svc.ListRevisions(ctx, session, id, offset, limit)
+}
+
+func (am *authorizationMiddleware) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (bootstrap.RevisionDiff, error) {
+	if err := am.authorize(ctx, session.DomainID, policies.UserType, policies.UsersKind, session.DomainUserID, readPermission, policies.ClientType, id); err != nil {
+		return bootstrap.RevisionDiff{}, err
+	}
+
+	return am.svc.DiffRevisions(ctx, session, id, from, to)
+}
+
+func (am *authorizationMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, ro bootstrap.Rollout) (bootstrap.Rollout, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Rollout{}, err
+	}
+
+	return am.svc.CreateRollout(ctx, session, ro)
+}
+
+func (am *authorizationMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Rollout{}, err
+	}
+
+	return am.svc.ViewRollout(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Rollout{}, err
+	}
+
+	return am.svc.RollbackRollout(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) Acknowledge(ctx context.Context, externalKey, externalID string, ack bootstrap.Ack) error {
+	return am.svc.Acknowledge(ctx, externalKey, externalID, ack)
+}
+
+func (am *authorizationMiddleware) CreateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.CreateTemplate(ctx, session, t)
+}
+
+func (am *authorizationMiddleware) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.ViewTemplate(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.MembershipPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.TemplatesPage{}, err
+	}
+
+	return am.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+func (am *authorizationMiddleware) UpdateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return bootstrap.Template{}, err
+	}
+
+	return am.svc.UpdateTemplate(ctx, session, t)
+}
+
+func (am *authorizationMiddleware) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorize(ctx, "", policies.UserType, policies.UsersKind, session.DomainUserID, policies.AdminPermission, policies.DomainType, session.DomainID); err != nil {
+		return err
+	}
+
+	return am.svc.RemoveTemplate(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) error {
 	return am.svc.UpdateChannelHandler(ctx, channel)
 }
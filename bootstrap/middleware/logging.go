@@ -45,6 +45,33 @@ func (lm *loggingMiddleware) Add(ctx context.Context, session smqauthn.Session,
 	return lm.svc.Add(ctx, session, token, cfg)
 }
 
+// BulkAdd logs the bulk add request. It logs the number of the added and
+// failed configs and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []bootstrap.Config) (results []bootstrap.BulkResult, err error) {
+	defer func(begin time.Time) {
+		var failed int
+		for _, res := range results {
+			if res.Err != nil {
+				failed++
+			}
+		}
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("total", len(cfgs)),
+			slog.Int("failed", failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bulk add bootstrap configs failed", args...)
+			return
+		}
+		lm.logger.Info("Bulk add bootstrap configs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.BulkAdd(ctx, session, token, cfgs)
+}
+
 // View logs the view request. It logs the client ID and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (saved bootstrap.Config, err error) {
@@ -105,6 +132,26 @@ func (lm *loggingMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return lm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// EnrollCert logs the enroll_cert request. It logs external ID and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("external_id", externalID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll bootstrap config certificate failed", args...)
+			return
+		}
+		args = append(args, slog.String("client_id", cfg.ClientID))
+		lm.logger.Info("Enroll bootstrap config certificate completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.EnrollCert(ctx, externalID, clientCert, caCert)
+}
+
 // UpdateConnections logs the update_connections request. It logs bootstrap ID and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) (err error) {
@@ -203,6 +250,217 @@ func (lm *loggingMiddleware) ChangeState(ctx context.Context, session smqauthn.S
 	return lm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+func (lm *loggingMiddleware) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (page bootstrap.RevisionsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", id),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List config revisions failed", args...)
+			return
+		}
+		lm.logger.Info("List config revisions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListRevisions(ctx, session, id, offset, limit)
+}
+
+func (lm *loggingMiddleware) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (diff bootstrap.RevisionDiff, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("client_id", id),
+			slog.Uint64("from", from),
+			slog.Uint64("to", to),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Diff config revisions failed", args...)
+			return
+		}
+		lm.logger.Info("Diff config revisions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DiffRevisions(ctx, session, id, from, to)
+}
+
+func (lm *loggingMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, ro bootstrap.Rollout) (saved bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("rollout",
+				slog.String("id", saved.ID),
+				slog.Any("tags", ro.Tags),
+				slog.Uint64("percentage", uint64(ro.Percentage)),
+				slog.Uint64("targets", saved.Targets),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create rollout failed", args...)
+			return
+		}
+		lm.logger.Info("Create rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateRollout(ctx, session, ro)
+}
+
+func (lm *loggingMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (ro bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("rollout_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rollout failed", args...)
+			return
+		}
+		lm.logger.Info("View rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRollout(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (ro bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("rollout_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Roll back rollout failed", args...)
+			return
+		}
+		lm.logger.Info("Roll back rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RollbackRollout(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) Acknowledge(ctx context.Context, externalKey, externalID string, ack bootstrap.Ack) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("external_id", externalID),
+			slog.Uint64("revision", ack.Revision),
+			slog.Any("status", ack.Status),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Acknowledge bootstrap config failed", args...)
+			return
+		}
+		lm.logger.Info("Acknowledge bootstrap config completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Acknowledge(ctx, externalKey, externalID, ack)
+}
+
+func (lm *loggingMiddleware) CreateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("template",
+				slog.String("id", saved.ID),
+				slog.String("name", t.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create template failed", args...)
+			return
+		}
+		lm.logger.Info("Create template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateTemplate(ctx, session, t)
+}
+
+func (lm *loggingMiddleware) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (t bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("template_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View template failed", args...)
+			return
+		}
+		lm.logger.Info("View template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTemplate(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (page bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List templates failed", args...)
+			return
+		}
+		lm.logger.Info("List templates completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+func (lm *loggingMiddleware) UpdateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("template",
+				slog.String("id", t.ID),
+				slog.String("name", t.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update template failed", args...)
+			return
+		}
+		lm.logger.Info("Update template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateTemplate(ctx, session, t)
+}
+
+func (lm *loggingMiddleware) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("template_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove template failed", args...)
+			return
+		}
+		lm.logger.Info("Remove template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveTemplate(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -41,6 +41,16 @@ func (mm *metricsMiddleware) Add(ctx context.Context, session smqauthn.Session,
 	return mm.svc.Add(ctx, session, token, cfg)
 }
 
+// BulkAdd instruments BulkAdd method with metrics.
+func (mm *metricsMiddleware) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []bootstrap.Config) (results []bootstrap.BulkResult, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "bulk_add").Add(1)
+		mm.latency.With("method", "bulk_add").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.BulkAdd(ctx, session, token, cfgs)
+}
+
 // View instruments View method with metrics.
 func (mm *metricsMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (saved bootstrap.Config, err error) {
 	defer func(begin time.Time) {
@@ -71,6 +81,16 @@ func (mm *metricsMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return mm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// EnrollCert instruments EnrollCert method with metrics.
+func (mm *metricsMiddleware) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (cfg bootstrap.Config, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enroll_cert").Add(1)
+		mm.latency.With("method", "enroll_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.EnrollCert(ctx, externalID, clientCert, caCert)
+}
+
 // UpdateConnections instruments UpdateConnections method with metrics.
 func (mm *metricsMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) (err error) {
 	defer func(begin time.Time) {
@@ -121,6 +141,116 @@ func (mm *metricsMiddleware) ChangeState(ctx context.Context, session smqauthn.S
 	return mm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+// ListRevisions instruments ListRevisions method with metrics.
+func (mm *metricsMiddleware) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (page bootstrap.RevisionsPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_revisions").Add(1)
+		mm.latency.With("method", "list_revisions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListRevisions(ctx, session, id, offset, limit)
+}
+
+// DiffRevisions instruments DiffRevisions method with metrics.
+func (mm *metricsMiddleware) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (diff bootstrap.RevisionDiff, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "diff_revisions").Add(1)
+		mm.latency.With("method", "diff_revisions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DiffRevisions(ctx, session, id, from, to)
+}
+
+// CreateRollout instruments CreateRollout method with metrics.
+func (mm *metricsMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, ro bootstrap.Rollout) (saved bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_rollout").Add(1)
+		mm.latency.With("method", "create_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateRollout(ctx, session, ro)
+}
+
+// ViewRollout instruments ViewRollout method with metrics.
+func (mm *metricsMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (ro bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rollout").Add(1)
+		mm.latency.With("method", "view_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRollout(ctx, session, id)
+}
+
+// RollbackRollout instruments RollbackRollout method with metrics.
+func (mm *metricsMiddleware) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (ro bootstrap.Rollout, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rollback_rollout").Add(1)
+		mm.latency.With("method", "rollback_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RollbackRollout(ctx, session, id)
+}
+
+// Acknowledge instruments Acknowledge method with metrics.
+func (mm *metricsMiddleware) Acknowledge(ctx context.Context, externalKey, externalID string, ack bootstrap.Ack) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "acknowledge").Add(1)
+		mm.latency.With("method", "acknowledge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Acknowledge(ctx, externalKey, externalID, ack)
+}
+
+// CreateTemplate instruments CreateTemplate method with metrics.
+func (mm *metricsMiddleware) CreateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_template").Add(1)
+		mm.latency.With("method", "create_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateTemplate(ctx, session, t)
+}
+
+// ViewTemplate instruments ViewTemplate method with metrics.
+func (mm *metricsMiddleware) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (t bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_template").Add(1)
+		mm.latency.With("method", "view_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTemplate(ctx, session, id)
+}
+
+// ListTemplates instruments ListTemplates method with metrics.
+func (mm *metricsMiddleware) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (page bootstrap.TemplatesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_templates").Add(1)
+		mm.latency.With("method", "list_templates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+// UpdateTemplate instruments UpdateTemplate method with metrics.
+func (mm *metricsMiddleware) UpdateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (saved bootstrap.Template, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_template").Add(1)
+		mm.latency.With("method", "update_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateTemplate(ctx, session, t)
+}
+
+// RemoveTemplate instruments RemoveTemplate method with metrics.
+func (mm *metricsMiddleware) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_template").Add(1)
+		mm.latency.With("method", "remove_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTemplate(ctx, session, id)
+}
+
 // UpdateChannelHandler instruments UpdateChannelHandler method with metrics.
 func (mm *metricsMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) (err error) {
 	defer func(begin time.Time) {
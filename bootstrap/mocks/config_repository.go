@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/bootstrap"
 	mock "github.com/stretchr/testify/mock"
@@ -488,134 +489,1002 @@ func (_c *ConfigRepository_RemoveClient_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// RemoveTemplate provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RemoveTemplate(ctx context.Context, domainID string, id string) error {
+	ret := _mock.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, domainID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ConfigRepository_RemoveTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTemplate'
+type ConfigRepository_RemoveTemplate_Call struct {
+	*mock.Call
+}
+
+// RemoveTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - id string
+func (_e *ConfigRepository_Expecter) RemoveTemplate(ctx interface{}, domainID interface{}, id interface{}) *ConfigRepository_RemoveTemplate_Call {
+	return &ConfigRepository_RemoveTemplate_Call{Call: _e.mock.On("RemoveTemplate", ctx, domainID, id)}
+}
+
+func (_c *ConfigRepository_RemoveTemplate_Call) Run(run func(ctx context.Context, domainID string, id string)) *ConfigRepository_RemoveTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RemoveTemplate_Call) Return(err error) *ConfigRepository_RemoveTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ConfigRepository_RemoveTemplate_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) error) *ConfigRepository_RemoveTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveAll provides a mock function for the type ConfigRepository
 func (_mock *ConfigRepository) RetrieveAll(ctx context.Context, domainID string, clientIDs []string, filter bootstrap.Filter, offset uint64, limit uint64) bootstrap.ConfigsPage {
 	ret := _mock.Called(ctx, domainID, clientIDs, filter, offset, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveAll")
+		panic("no return value specified for RetrieveAll")
+	}
+
+	var r0 bootstrap.ConfigsPage
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, bootstrap.Filter, uint64, uint64) bootstrap.ConfigsPage); ok {
+		r0 = returnFunc(ctx, domainID, clientIDs, filter, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.ConfigsPage)
+	}
+	return r0
+}
+
+// ConfigRepository_RetrieveAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveAll'
+type ConfigRepository_RetrieveAll_Call struct {
+	*mock.Call
+}
+
+// RetrieveAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - clientIDs []string
+//   - filter bootstrap.Filter
+//   - offset uint64
+//   - limit uint64
+func (_e *ConfigRepository_Expecter) RetrieveAll(ctx interface{}, domainID interface{}, clientIDs interface{}, filter interface{}, offset interface{}, limit interface{}) *ConfigRepository_RetrieveAll_Call {
+	return &ConfigRepository_RetrieveAll_Call{Call: _e.mock.On("RetrieveAll", ctx, domainID, clientIDs, filter, offset, limit)}
+}
+
+func (_c *ConfigRepository_RetrieveAll_Call) Run(run func(ctx context.Context, domainID string, clientIDs []string, filter bootstrap.Filter, offset uint64, limit uint64)) *ConfigRepository_RetrieveAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 bootstrap.Filter
+		if args[3] != nil {
+			arg3 = args[3].(bootstrap.Filter)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		var arg5 uint64
+		if args[5] != nil {
+			arg5 = args[5].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveAll_Call) Return(configsPage bootstrap.ConfigsPage) *ConfigRepository_RetrieveAll_Call {
+	_c.Call.Return(configsPage)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveAll_Call) RunAndReturn(run func(ctx context.Context, domainID string, clientIDs []string, filter bootstrap.Filter, offset uint64, limit uint64) bootstrap.ConfigsPage) *ConfigRepository_RetrieveAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveAllTemplates provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveAllTemplates(ctx context.Context, domainID string, offset uint64, limit uint64) (bootstrap.TemplatesPage, error) {
+	ret := _mock.Called(ctx, domainID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllTemplates")
+	}
+
+	var r0 bootstrap.TemplatesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) (bootstrap.TemplatesPage, error)); ok {
+		return returnFunc(ctx, domainID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) bootstrap.TemplatesPage); ok {
+		r0 = returnFunc(ctx, domainID, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.TemplatesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, domainID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveAllTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveAllTemplates'
+type ConfigRepository_RetrieveAllTemplates_Call struct {
+	*mock.Call
+}
+
+// RetrieveAllTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - offset uint64
+//   - limit uint64
+func (_e *ConfigRepository_Expecter) RetrieveAllTemplates(ctx interface{}, domainID interface{}, offset interface{}, limit interface{}) *ConfigRepository_RetrieveAllTemplates_Call {
+	return &ConfigRepository_RetrieveAllTemplates_Call{Call: _e.mock.On("RetrieveAllTemplates", ctx, domainID, offset, limit)}
+}
+
+func (_c *ConfigRepository_RetrieveAllTemplates_Call) Run(run func(ctx context.Context, domainID string, offset uint64, limit uint64)) *ConfigRepository_RetrieveAllTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveAllTemplates_Call) Return(templatesPage bootstrap.TemplatesPage, err error) *ConfigRepository_RetrieveAllTemplates_Call {
+	_c.Call.Return(templatesPage, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveAllTemplates_Call) RunAndReturn(run func(ctx context.Context, domainID string, offset uint64, limit uint64) (bootstrap.TemplatesPage, error)) *ConfigRepository_RetrieveAllTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveByExternalID provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveByExternalID(ctx context.Context, externalID string) (bootstrap.Config, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByExternalID")
+	}
+
+	var r0 bootstrap.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bootstrap.Config, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bootstrap.Config); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Config)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveByExternalID'
+type ConfigRepository_RetrieveByExternalID_Call struct {
+	*mock.Call
+}
+
+// RetrieveByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *ConfigRepository_Expecter) RetrieveByExternalID(ctx interface{}, externalID interface{}) *ConfigRepository_RetrieveByExternalID_Call {
+	return &ConfigRepository_RetrieveByExternalID_Call{Call: _e.mock.On("RetrieveByExternalID", ctx, externalID)}
+}
+
+func (_c *ConfigRepository_RetrieveByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *ConfigRepository_RetrieveByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByExternalID_Call) Return(config bootstrap.Config, err error) *ConfigRepository_RetrieveByExternalID_Call {
+	_c.Call.Return(config, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (bootstrap.Config, error)) *ConfigRepository_RetrieveByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveByID provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveByID(ctx context.Context, domainID string, id string) (bootstrap.Config, error) {
+	ret := _mock.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByID")
+	}
+
+	var r0 bootstrap.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bootstrap.Config, error)); ok {
+		return returnFunc(ctx, domainID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bootstrap.Config); ok {
+		r0 = returnFunc(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Config)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveByID'
+type ConfigRepository_RetrieveByID_Call struct {
+	*mock.Call
+}
+
+// RetrieveByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - id string
+func (_e *ConfigRepository_Expecter) RetrieveByID(ctx interface{}, domainID interface{}, id interface{}) *ConfigRepository_RetrieveByID_Call {
+	return &ConfigRepository_RetrieveByID_Call{Call: _e.mock.On("RetrieveByID", ctx, domainID, id)}
+}
+
+func (_c *ConfigRepository_RetrieveByID_Call) Run(run func(ctx context.Context, domainID string, id string)) *ConfigRepository_RetrieveByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByID_Call) Return(config bootstrap.Config, err error) *ConfigRepository_RetrieveByID_Call {
+	_c.Call.Return(config, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByID_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) (bootstrap.Config, error)) *ConfigRepository_RetrieveByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveByTemplate provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveByTemplate(ctx context.Context, domainID string, templateID string) ([]bootstrap.Config, error) {
+	ret := _mock.Called(ctx, domainID, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveByTemplate")
+	}
+
+	var r0 []bootstrap.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]bootstrap.Config, error)); ok {
+		return returnFunc(ctx, domainID, templateID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []bootstrap.Config); ok {
+		r0 = returnFunc(ctx, domainID, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bootstrap.Config)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainID, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveByTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveByTemplate'
+type ConfigRepository_RetrieveByTemplate_Call struct {
+	*mock.Call
+}
+
+// RetrieveByTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - templateID string
+func (_e *ConfigRepository_Expecter) RetrieveByTemplate(ctx interface{}, domainID interface{}, templateID interface{}) *ConfigRepository_RetrieveByTemplate_Call {
+	return &ConfigRepository_RetrieveByTemplate_Call{Call: _e.mock.On("RetrieveByTemplate", ctx, domainID, templateID)}
+}
+
+func (_c *ConfigRepository_RetrieveByTemplate_Call) Run(run func(ctx context.Context, domainID string, templateID string)) *ConfigRepository_RetrieveByTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByTemplate_Call) Return(configs []bootstrap.Config, err error) *ConfigRepository_RetrieveByTemplate_Call {
+	_c.Call.Return(configs, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveByTemplate_Call) RunAndReturn(run func(ctx context.Context, domainID string, templateID string) ([]bootstrap.Config, error)) *ConfigRepository_RetrieveByTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveRevision provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveRevision(ctx context.Context, domainID string, clientID string, revision uint64) (bootstrap.Revision, error) {
+	ret := _mock.Called(ctx, domainID, clientID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRevision")
+	}
+
+	var r0 bootstrap.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, uint64) (bootstrap.Revision, error)); ok {
+		return returnFunc(ctx, domainID, clientID, revision)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, uint64) bootstrap.Revision); ok {
+		r0 = returnFunc(ctx, domainID, clientID, revision)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Revision)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
+		r1 = returnFunc(ctx, domainID, clientID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveRevision'
+type ConfigRepository_RetrieveRevision_Call struct {
+	*mock.Call
+}
+
+// RetrieveRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - clientID string
+//   - revision uint64
+func (_e *ConfigRepository_Expecter) RetrieveRevision(ctx interface{}, domainID interface{}, clientID interface{}, revision interface{}) *ConfigRepository_RetrieveRevision_Call {
+	return &ConfigRepository_RetrieveRevision_Call{Call: _e.mock.On("RetrieveRevision", ctx, domainID, clientID, revision)}
+}
+
+func (_c *ConfigRepository_RetrieveRevision_Call) Run(run func(ctx context.Context, domainID string, clientID string, revision uint64)) *ConfigRepository_RetrieveRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRevision_Call) Return(revision1 bootstrap.Revision, err error) *ConfigRepository_RetrieveRevision_Call {
+	_c.Call.Return(revision1, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRevision_Call) RunAndReturn(run func(ctx context.Context, domainID string, clientID string, revision uint64) (bootstrap.Revision, error)) *ConfigRepository_RetrieveRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveRevisions provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveRevisions(ctx context.Context, domainID string, clientID string, offset uint64, limit uint64) (bootstrap.RevisionsPage, error) {
+	ret := _mock.Called(ctx, domainID, clientID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRevisions")
+	}
+
+	var r0 bootstrap.RevisionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, uint64, uint64) (bootstrap.RevisionsPage, error)); ok {
+		return returnFunc(ctx, domainID, clientID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, uint64, uint64) bootstrap.RevisionsPage); ok {
+		r0 = returnFunc(ctx, domainID, clientID, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.RevisionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, domainID, clientID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveRevisions'
+type ConfigRepository_RetrieveRevisions_Call struct {
+	*mock.Call
+}
+
+// RetrieveRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - clientID string
+//   - offset uint64
+//   - limit uint64
+func (_e *ConfigRepository_Expecter) RetrieveRevisions(ctx interface{}, domainID interface{}, clientID interface{}, offset interface{}, limit interface{}) *ConfigRepository_RetrieveRevisions_Call {
+	return &ConfigRepository_RetrieveRevisions_Call{Call: _e.mock.On("RetrieveRevisions", ctx, domainID, clientID, offset, limit)}
+}
+
+func (_c *ConfigRepository_RetrieveRevisions_Call) Run(run func(ctx context.Context, domainID string, clientID string, offset uint64, limit uint64)) *ConfigRepository_RetrieveRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRevisions_Call) Return(revisionsPage bootstrap.RevisionsPage, err error) *ConfigRepository_RetrieveRevisions_Call {
+	_c.Call.Return(revisionsPage, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRevisions_Call) RunAndReturn(run func(ctx context.Context, domainID string, clientID string, offset uint64, limit uint64) (bootstrap.RevisionsPage, error)) *ConfigRepository_RetrieveRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveRollout provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveRollout(ctx context.Context, domainID string, id string) (bootstrap.Rollout, error) {
+	ret := _mock.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bootstrap.Rollout, error)); ok {
+		return returnFunc(ctx, domainID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bootstrap.Rollout); ok {
+		r0 = returnFunc(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveRollout'
+type ConfigRepository_RetrieveRollout_Call struct {
+	*mock.Call
+}
+
+// RetrieveRollout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - id string
+func (_e *ConfigRepository_Expecter) RetrieveRollout(ctx interface{}, domainID interface{}, id interface{}) *ConfigRepository_RetrieveRollout_Call {
+	return &ConfigRepository_RetrieveRollout_Call{Call: _e.mock.On("RetrieveRollout", ctx, domainID, id)}
+}
+
+func (_c *ConfigRepository_RetrieveRollout_Call) Run(run func(ctx context.Context, domainID string, id string)) *ConfigRepository_RetrieveRollout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRollout_Call) Return(rollout bootstrap.Rollout, err error) *ConfigRepository_RetrieveRollout_Call {
+	_c.Call.Return(rollout, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveRollout_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) (bootstrap.Rollout, error)) *ConfigRepository_RetrieveRollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveTargets provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveTargets(ctx context.Context, domainID string, tags []string) ([]string, error) {
+	ret := _mock.Called(ctx, domainID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveTargets")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return returnFunc(ctx, domainID, tags)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = returnFunc(ctx, domainID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, domainID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveTargets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveTargets'
+type ConfigRepository_RetrieveTargets_Call struct {
+	*mock.Call
+}
+
+// RetrieveTargets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - tags []string
+func (_e *ConfigRepository_Expecter) RetrieveTargets(ctx interface{}, domainID interface{}, tags interface{}) *ConfigRepository_RetrieveTargets_Call {
+	return &ConfigRepository_RetrieveTargets_Call{Call: _e.mock.On("RetrieveTargets", ctx, domainID, tags)}
+}
+
+func (_c *ConfigRepository_RetrieveTargets_Call) Run(run func(ctx context.Context, domainID string, tags []string)) *ConfigRepository_RetrieveTargets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveTargets_Call) Return(strings []string, err error) *ConfigRepository_RetrieveTargets_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveTargets_Call) RunAndReturn(run func(ctx context.Context, domainID string, tags []string) ([]string, error)) *ConfigRepository_RetrieveTargets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveTemplate provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RetrieveTemplate(ctx context.Context, domainID string, id string) (bootstrap.Template, error) {
+	ret := _mock.Called(ctx, domainID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bootstrap.Template, error)); ok {
+		return returnFunc(ctx, domainID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bootstrap.Template); ok {
+		r0 = returnFunc(ctx, domainID, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigRepository_RetrieveTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveTemplate'
+type ConfigRepository_RetrieveTemplate_Call struct {
+	*mock.Call
+}
+
+// RetrieveTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - id string
+func (_e *ConfigRepository_Expecter) RetrieveTemplate(ctx interface{}, domainID interface{}, id interface{}) *ConfigRepository_RetrieveTemplate_Call {
+	return &ConfigRepository_RetrieveTemplate_Call{Call: _e.mock.On("RetrieveTemplate", ctx, domainID, id)}
+}
+
+func (_c *ConfigRepository_RetrieveTemplate_Call) Run(run func(ctx context.Context, domainID string, id string)) *ConfigRepository_RetrieveTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveTemplate_Call) Return(template bootstrap.Template, err error) *ConfigRepository_RetrieveTemplate_Call {
+	_c.Call.Return(template, err)
+	return _c
+}
+
+func (_c *ConfigRepository_RetrieveTemplate_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) (bootstrap.Template, error)) *ConfigRepository_RetrieveTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackRollout provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) RollbackRollout(ctx context.Context, id string, at time.Time) error {
+	ret := _mock.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackRollout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ConfigRepository_RollbackRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackRollout'
+type ConfigRepository_RollbackRollout_Call struct {
+	*mock.Call
+}
+
+// RollbackRollout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - at time.Time
+func (_e *ConfigRepository_Expecter) RollbackRollout(ctx interface{}, id interface{}, at interface{}) *ConfigRepository_RollbackRollout_Call {
+	return &ConfigRepository_RollbackRollout_Call{Call: _e.mock.On("RollbackRollout", ctx, id, at)}
+}
+
+func (_c *ConfigRepository_RollbackRollout_Call) Run(run func(ctx context.Context, id string, at time.Time)) *ConfigRepository_RollbackRollout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_RollbackRollout_Call) Return(err error) *ConfigRepository_RollbackRollout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ConfigRepository_RollbackRollout_Call) RunAndReturn(run func(ctx context.Context, id string, at time.Time) error) *ConfigRepository_RollbackRollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (string, error) {
+	ret := _mock.Called(ctx, cfg, chsConnIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
 	}
 
-	var r0 bootstrap.ConfigsPage
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, bootstrap.Filter, uint64, uint64) bootstrap.ConfigsPage); ok {
-		r0 = returnFunc(ctx, domainID, clientIDs, filter, offset, limit)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) (string, error)); ok {
+		return returnFunc(ctx, cfg, chsConnIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) string); ok {
+		r0 = returnFunc(ctx, cfg, chsConnIDs)
 	} else {
-		r0 = ret.Get(0).(bootstrap.ConfigsPage)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bootstrap.Config, []string) error); ok {
+		r1 = returnFunc(ctx, cfg, chsConnIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// ConfigRepository_RetrieveAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveAll'
-type ConfigRepository_RetrieveAll_Call struct {
+// ConfigRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type ConfigRepository_Save_Call struct {
 	*mock.Call
 }
 
-// RetrieveAll is a helper method to define mock.On call
+// Save is a helper method to define mock.On call
 //   - ctx context.Context
-//   - domainID string
-//   - clientIDs []string
-//   - filter bootstrap.Filter
-//   - offset uint64
-//   - limit uint64
-func (_e *ConfigRepository_Expecter) RetrieveAll(ctx interface{}, domainID interface{}, clientIDs interface{}, filter interface{}, offset interface{}, limit interface{}) *ConfigRepository_RetrieveAll_Call {
-	return &ConfigRepository_RetrieveAll_Call{Call: _e.mock.On("RetrieveAll", ctx, domainID, clientIDs, filter, offset, limit)}
+//   - cfg bootstrap.Config
+//   - chsConnIDs []string
+func (_e *ConfigRepository_Expecter) Save(ctx interface{}, cfg interface{}, chsConnIDs interface{}) *ConfigRepository_Save_Call {
+	return &ConfigRepository_Save_Call{Call: _e.mock.On("Save", ctx, cfg, chsConnIDs)}
 }
 
-func (_c *ConfigRepository_RetrieveAll_Call) Run(run func(ctx context.Context, domainID string, clientIDs []string, filter bootstrap.Filter, offset uint64, limit uint64)) *ConfigRepository_RetrieveAll_Call {
+func (_c *ConfigRepository_Save_Call) Run(run func(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string)) *ConfigRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 bootstrap.Config
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(bootstrap.Config)
 		}
 		var arg2 []string
 		if args[2] != nil {
 			arg2 = args[2].([]string)
 		}
-		var arg3 bootstrap.Filter
-		if args[3] != nil {
-			arg3 = args[3].(bootstrap.Filter)
-		}
-		var arg4 uint64
-		if args[4] != nil {
-			arg4 = args[4].(uint64)
-		}
-		var arg5 uint64
-		if args[5] != nil {
-			arg5 = args[5].(uint64)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
-			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveAll_Call) Return(configsPage bootstrap.ConfigsPage) *ConfigRepository_RetrieveAll_Call {
-	_c.Call.Return(configsPage)
+func (_c *ConfigRepository_Save_Call) Return(s string, err error) *ConfigRepository_Save_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveAll_Call) RunAndReturn(run func(ctx context.Context, domainID string, clientIDs []string, filter bootstrap.Filter, offset uint64, limit uint64) bootstrap.ConfigsPage) *ConfigRepository_RetrieveAll_Call {
+func (_c *ConfigRepository_Save_Call) RunAndReturn(run func(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (string, error)) *ConfigRepository_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RetrieveByExternalID provides a mock function for the type ConfigRepository
-func (_mock *ConfigRepository) RetrieveByExternalID(ctx context.Context, externalID string) (bootstrap.Config, error) {
-	ret := _mock.Called(ctx, externalID)
+// SaveAck provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) SaveAck(ctx context.Context, ack bootstrap.Ack) (string, error) {
+	ret := _mock.Called(ctx, ack)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveByExternalID")
+		panic("no return value specified for SaveAck")
 	}
 
-	var r0 bootstrap.Config
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bootstrap.Config, error)); ok {
-		return returnFunc(ctx, externalID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Ack) (string, error)); ok {
+		return returnFunc(ctx, ack)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bootstrap.Config); ok {
-		r0 = returnFunc(ctx, externalID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Ack) string); ok {
+		r0 = returnFunc(ctx, ack)
 	} else {
-		r0 = ret.Get(0).(bootstrap.Config)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, externalID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bootstrap.Ack) error); ok {
+		r1 = returnFunc(ctx, ack)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ConfigRepository_RetrieveByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveByExternalID'
-type ConfigRepository_RetrieveByExternalID_Call struct {
+// ConfigRepository_SaveAck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAck'
+type ConfigRepository_SaveAck_Call struct {
 	*mock.Call
 }
 
-// RetrieveByExternalID is a helper method to define mock.On call
+// SaveAck is a helper method to define mock.On call
 //   - ctx context.Context
-//   - externalID string
-func (_e *ConfigRepository_Expecter) RetrieveByExternalID(ctx interface{}, externalID interface{}) *ConfigRepository_RetrieveByExternalID_Call {
-	return &ConfigRepository_RetrieveByExternalID_Call{Call: _e.mock.On("RetrieveByExternalID", ctx, externalID)}
+//   - ack bootstrap.Ack
+func (_e *ConfigRepository_Expecter) SaveAck(ctx interface{}, ack interface{}) *ConfigRepository_SaveAck_Call {
+	return &ConfigRepository_SaveAck_Call{Call: _e.mock.On("SaveAck", ctx, ack)}
 }
 
-func (_c *ConfigRepository_RetrieveByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *ConfigRepository_RetrieveByExternalID_Call {
+func (_c *ConfigRepository_SaveAck_Call) Run(run func(ctx context.Context, ack bootstrap.Ack)) *ConfigRepository_SaveAck_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 bootstrap.Ack
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(bootstrap.Ack)
 		}
 		run(
 			arg0,
@@ -625,68 +1494,68 @@ func (_c *ConfigRepository_RetrieveByExternalID_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveByExternalID_Call) Return(config bootstrap.Config, err error) *ConfigRepository_RetrieveByExternalID_Call {
-	_c.Call.Return(config, err)
+func (_c *ConfigRepository_SaveAck_Call) Return(s string, err error) *ConfigRepository_SaveAck_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (bootstrap.Config, error)) *ConfigRepository_RetrieveByExternalID_Call {
+func (_c *ConfigRepository_SaveAck_Call) RunAndReturn(run func(ctx context.Context, ack bootstrap.Ack) (string, error)) *ConfigRepository_SaveAck_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RetrieveByID provides a mock function for the type ConfigRepository
-func (_mock *ConfigRepository) RetrieveByID(ctx context.Context, domainID string, id string) (bootstrap.Config, error) {
-	ret := _mock.Called(ctx, domainID, id)
+// SaveRollout provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) SaveRollout(ctx context.Context, ro bootstrap.Rollout, clientIDs []string) (bootstrap.Rollout, error) {
+	ret := _mock.Called(ctx, ro, clientIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveByID")
+		panic("no return value specified for SaveRollout")
 	}
 
-	var r0 bootstrap.Config
+	var r0 bootstrap.Rollout
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bootstrap.Config, error)); ok {
-		return returnFunc(ctx, domainID, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Rollout, []string) (bootstrap.Rollout, error)); ok {
+		return returnFunc(ctx, ro, clientIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bootstrap.Config); ok {
-		r0 = returnFunc(ctx, domainID, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Rollout, []string) bootstrap.Rollout); ok {
+		r0 = returnFunc(ctx, ro, clientIDs)
 	} else {
-		r0 = ret.Get(0).(bootstrap.Config)
+		r0 = ret.Get(0).(bootstrap.Rollout)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, domainID, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bootstrap.Rollout, []string) error); ok {
+		r1 = returnFunc(ctx, ro, clientIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ConfigRepository_RetrieveByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveByID'
-type ConfigRepository_RetrieveByID_Call struct {
+// ConfigRepository_SaveRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRollout'
+type ConfigRepository_SaveRollout_Call struct {
 	*mock.Call
 }
 
-// RetrieveByID is a helper method to define mock.On call
+// SaveRollout is a helper method to define mock.On call
 //   - ctx context.Context
-//   - domainID string
-//   - id string
-func (_e *ConfigRepository_Expecter) RetrieveByID(ctx interface{}, domainID interface{}, id interface{}) *ConfigRepository_RetrieveByID_Call {
-	return &ConfigRepository_RetrieveByID_Call{Call: _e.mock.On("RetrieveByID", ctx, domainID, id)}
+//   - ro bootstrap.Rollout
+//   - clientIDs []string
+func (_e *ConfigRepository_Expecter) SaveRollout(ctx interface{}, ro interface{}, clientIDs interface{}) *ConfigRepository_SaveRollout_Call {
+	return &ConfigRepository_SaveRollout_Call{Call: _e.mock.On("SaveRollout", ctx, ro, clientIDs)}
 }
 
-func (_c *ConfigRepository_RetrieveByID_Call) Run(run func(ctx context.Context, domainID string, id string)) *ConfigRepository_RetrieveByID_Call {
+func (_c *ConfigRepository_SaveRollout_Call) Run(run func(ctx context.Context, ro bootstrap.Rollout, clientIDs []string)) *ConfigRepository_SaveRollout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 bootstrap.Rollout
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(bootstrap.Rollout)
 		}
-		var arg2 string
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].([]string)
 		}
 		run(
 			arg0,
@@ -697,84 +1566,78 @@ func (_c *ConfigRepository_RetrieveByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveByID_Call) Return(config bootstrap.Config, err error) *ConfigRepository_RetrieveByID_Call {
-	_c.Call.Return(config, err)
+func (_c *ConfigRepository_SaveRollout_Call) Return(rollout bootstrap.Rollout, err error) *ConfigRepository_SaveRollout_Call {
+	_c.Call.Return(rollout, err)
 	return _c
 }
 
-func (_c *ConfigRepository_RetrieveByID_Call) RunAndReturn(run func(ctx context.Context, domainID string, id string) (bootstrap.Config, error)) *ConfigRepository_RetrieveByID_Call {
+func (_c *ConfigRepository_SaveRollout_Call) RunAndReturn(run func(ctx context.Context, ro bootstrap.Rollout, clientIDs []string) (bootstrap.Rollout, error)) *ConfigRepository_SaveRollout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function for the type ConfigRepository
-func (_mock *ConfigRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (string, error) {
-	ret := _mock.Called(ctx, cfg, chsConnIDs)
+// SaveTemplate provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) SaveTemplate(ctx context.Context, t bootstrap.Template) (bootstrap.Template, error) {
+	ret := _mock.Called(ctx, t)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for SaveTemplate")
 	}
 
-	var r0 string
+	var r0 bootstrap.Template
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) (string, error)); ok {
-		return returnFunc(ctx, cfg, chsConnIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Template) (bootstrap.Template, error)); ok {
+		return returnFunc(ctx, t)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Config, []string) string); ok {
-		r0 = returnFunc(ctx, cfg, chsConnIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Template) bootstrap.Template); ok {
+		r0 = returnFunc(ctx, t)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bootstrap.Template)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, bootstrap.Config, []string) error); ok {
-		r1 = returnFunc(ctx, cfg, chsConnIDs)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bootstrap.Template) error); ok {
+		r1 = returnFunc(ctx, t)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ConfigRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
-type ConfigRepository_Save_Call struct {
+// ConfigRepository_SaveTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTemplate'
+type ConfigRepository_SaveTemplate_Call struct {
 	*mock.Call
 }
 
-// Save is a helper method to define mock.On call
+// SaveTemplate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - cfg bootstrap.Config
-//   - chsConnIDs []string
-func (_e *ConfigRepository_Expecter) Save(ctx interface{}, cfg interface{}, chsConnIDs interface{}) *ConfigRepository_Save_Call {
-	return &ConfigRepository_Save_Call{Call: _e.mock.On("Save", ctx, cfg, chsConnIDs)}
+//   - t bootstrap.Template
+func (_e *ConfigRepository_Expecter) SaveTemplate(ctx interface{}, t interface{}) *ConfigRepository_SaveTemplate_Call {
+	return &ConfigRepository_SaveTemplate_Call{Call: _e.mock.On("SaveTemplate", ctx, t)}
 }
 
-func (_c *ConfigRepository_Save_Call) Run(run func(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string)) *ConfigRepository_Save_Call {
+func (_c *ConfigRepository_SaveTemplate_Call) Run(run func(ctx context.Context, t bootstrap.Template)) *ConfigRepository_SaveTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 bootstrap.Config
+		var arg1 bootstrap.Template
 		if args[1] != nil {
-			arg1 = args[1].(bootstrap.Config)
-		}
-		var arg2 []string
-		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg1 = args[1].(bootstrap.Template)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *ConfigRepository_Save_Call) Return(s string, err error) *ConfigRepository_Save_Call {
-	_c.Call.Return(s, err)
+func (_c *ConfigRepository_SaveTemplate_Call) Return(template bootstrap.Template, err error) *ConfigRepository_SaveTemplate_Call {
+	_c.Call.Return(template, err)
 	return _c
 }
 
-func (_c *ConfigRepository_Save_Call) RunAndReturn(run func(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (string, error)) *ConfigRepository_Save_Call {
+func (_c *ConfigRepository_SaveTemplate_Call) RunAndReturn(run func(ctx context.Context, t bootstrap.Template) (bootstrap.Template, error)) *ConfigRepository_SaveTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1057,3 +1920,135 @@ func (_c *ConfigRepository_UpdateConnections_Call) RunAndReturn(run func(ctx con
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateRolloutStatus provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) UpdateRolloutStatus(ctx context.Context, id string, status bootstrap.RolloutStatus, at time.Time) error {
+	ret := _mock.Called(ctx, id, status, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRolloutStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bootstrap.RolloutStatus, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, status, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ConfigRepository_UpdateRolloutStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRolloutStatus'
+type ConfigRepository_UpdateRolloutStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateRolloutStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status bootstrap.RolloutStatus
+//   - at time.Time
+func (_e *ConfigRepository_Expecter) UpdateRolloutStatus(ctx interface{}, id interface{}, status interface{}, at interface{}) *ConfigRepository_UpdateRolloutStatus_Call {
+	return &ConfigRepository_UpdateRolloutStatus_Call{Call: _e.mock.On("UpdateRolloutStatus", ctx, id, status, at)}
+}
+
+func (_c *ConfigRepository_UpdateRolloutStatus_Call) Run(run func(ctx context.Context, id string, status bootstrap.RolloutStatus, at time.Time)) *ConfigRepository_UpdateRolloutStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bootstrap.RolloutStatus
+		if args[2] != nil {
+			arg2 = args[2].(bootstrap.RolloutStatus)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_UpdateRolloutStatus_Call) Return(err error) *ConfigRepository_UpdateRolloutStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ConfigRepository_UpdateRolloutStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status bootstrap.RolloutStatus, at time.Time) error) *ConfigRepository_UpdateRolloutStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTemplate provides a mock function for the type ConfigRepository
+func (_mock *ConfigRepository) UpdateTemplate(ctx context.Context, t bootstrap.Template, cfgs []bootstrap.Config) error {
+	ret := _mock.Called(ctx, t, cfgs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bootstrap.Template, []bootstrap.Config) error); ok {
+		r0 = returnFunc(ctx, t, cfgs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ConfigRepository_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
+type ConfigRepository_UpdateTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t bootstrap.Template
+//   - cfgs []bootstrap.Config
+func (_e *ConfigRepository_Expecter) UpdateTemplate(ctx interface{}, t interface{}, cfgs interface{}) *ConfigRepository_UpdateTemplate_Call {
+	return &ConfigRepository_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", ctx, t, cfgs)}
+}
+
+func (_c *ConfigRepository_UpdateTemplate_Call) Run(run func(ctx context.Context, t bootstrap.Template, cfgs []bootstrap.Config)) *ConfigRepository_UpdateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bootstrap.Template
+		if args[1] != nil {
+			arg1 = args[1].(bootstrap.Template)
+		}
+		var arg2 []bootstrap.Config
+		if args[2] != nil {
+			arg2 = args[2].([]bootstrap.Config)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ConfigRepository_UpdateTemplate_Call) Return(err error) *ConfigRepository_UpdateTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ConfigRepository_UpdateTemplate_Call) RunAndReturn(run func(ctx context.Context, t bootstrap.Template, cfgs []bootstrap.Config) error) *ConfigRepository_UpdateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
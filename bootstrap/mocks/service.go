@@ -43,6 +43,75 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// Acknowledge provides a mock function for the type Service
+func (_mock *Service) Acknowledge(ctx context.Context, externalKey string, externalID string, ack bootstrap.Ack) error {
+	ret := _mock.Called(ctx, externalKey, externalID, ack)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acknowledge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bootstrap.Ack) error); ok {
+		r0 = returnFunc(ctx, externalKey, externalID, ack)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Acknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acknowledge'
+type Service_Acknowledge_Call struct {
+	*mock.Call
+}
+
+// Acknowledge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalKey string
+//   - externalID string
+//   - ack bootstrap.Ack
+func (_e *Service_Expecter) Acknowledge(ctx interface{}, externalKey interface{}, externalID interface{}, ack interface{}) *Service_Acknowledge_Call {
+	return &Service_Acknowledge_Call{Call: _e.mock.On("Acknowledge", ctx, externalKey, externalID, ack)}
+}
+
+func (_c *Service_Acknowledge_Call) Run(run func(ctx context.Context, externalKey string, externalID string, ack bootstrap.Ack)) *Service_Acknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bootstrap.Ack
+		if args[3] != nil {
+			arg3 = args[3].(bootstrap.Ack)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Acknowledge_Call) Return(err error) *Service_Acknowledge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Acknowledge_Call) RunAndReturn(run func(ctx context.Context, externalKey string, externalID string, ack bootstrap.Ack) error) *Service_Acknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Add provides a mock function for the type Service
 func (_mock *Service) Add(ctx context.Context, session authn.Session, token string, cfg bootstrap.Config) (bootstrap.Config, error) {
 	ret := _mock.Called(ctx, session, token, cfg)
@@ -199,6 +268,86 @@ func (_c *Service_Bootstrap_Call) RunAndReturn(run func(ctx context.Context, ext
 	return _c
 }
 
+// BulkAdd provides a mock function for the type Service
+func (_mock *Service) BulkAdd(ctx context.Context, session authn.Session, token string, cfgs []bootstrap.Config) ([]bootstrap.BulkResult, error) {
+	ret := _mock.Called(ctx, session, token, cfgs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkAdd")
+	}
+
+	var r0 []bootstrap.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, []bootstrap.Config) ([]bootstrap.BulkResult, error)); ok {
+		return returnFunc(ctx, session, token, cfgs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, []bootstrap.Config) []bootstrap.BulkResult); ok {
+		r0 = returnFunc(ctx, session, token, cfgs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bootstrap.BulkResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, []bootstrap.Config) error); ok {
+		r1 = returnFunc(ctx, session, token, cfgs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_BulkAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkAdd'
+type Service_BulkAdd_Call struct {
+	*mock.Call
+}
+
+// BulkAdd is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - token string
+//   - cfgs []bootstrap.Config
+func (_e *Service_Expecter) BulkAdd(ctx interface{}, session interface{}, token interface{}, cfgs interface{}) *Service_BulkAdd_Call {
+	return &Service_BulkAdd_Call{Call: _e.mock.On("BulkAdd", ctx, session, token, cfgs)}
+}
+
+func (_c *Service_BulkAdd_Call) Run(run func(ctx context.Context, session authn.Session, token string, cfgs []bootstrap.Config)) *Service_BulkAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []bootstrap.Config
+		if args[3] != nil {
+			arg3 = args[3].([]bootstrap.Config)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_BulkAdd_Call) Return(bulkResults []bootstrap.BulkResult, err error) *Service_BulkAdd_Call {
+	_c.Call.Return(bulkResults, err)
+	return _c
+}
+
+func (_c *Service_BulkAdd_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, token string, cfgs []bootstrap.Config) ([]bootstrap.BulkResult, error)) *Service_BulkAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ChangeState provides a mock function for the type Service
 func (_mock *Service) ChangeState(ctx context.Context, session authn.Session, token string, id string, state bootstrap.State) error {
 	ret := _mock.Called(ctx, session, token, id, state)
@@ -337,49 +486,58 @@ func (_c *Service_ConnectClientHandler_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// DisconnectClientHandler provides a mock function for the type Service
-func (_mock *Service) DisconnectClientHandler(ctx context.Context, channelID string, clientID string) error {
-	ret := _mock.Called(ctx, channelID, clientID)
+// CreateRollout provides a mock function for the type Service
+func (_mock *Service) CreateRollout(ctx context.Context, session authn.Session, ro bootstrap.Rollout) (bootstrap.Rollout, error) {
+	ret := _mock.Called(ctx, session, ro)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisconnectClientHandler")
+		panic("no return value specified for CreateRollout")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, channelID, clientID)
+	var r0 bootstrap.Rollout
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Rollout) (bootstrap.Rollout, error)); ok {
+		return returnFunc(ctx, session, ro)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Rollout) bootstrap.Rollout); ok {
+		r0 = returnFunc(ctx, session, ro)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bootstrap.Rollout)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Rollout) error); ok {
+		r1 = returnFunc(ctx, session, ro)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Service_DisconnectClientHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisconnectClientHandler'
-type Service_DisconnectClientHandler_Call struct {
+// Service_CreateRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRollout'
+type Service_CreateRollout_Call struct {
 	*mock.Call
 }
 
-// DisconnectClientHandler is a helper method to define mock.On call
+// CreateRollout is a helper method to define mock.On call
 //   - ctx context.Context
-//   - channelID string
-//   - clientID string
-func (_e *Service_Expecter) DisconnectClientHandler(ctx interface{}, channelID interface{}, clientID interface{}) *Service_DisconnectClientHandler_Call {
-	return &Service_DisconnectClientHandler_Call{Call: _e.mock.On("DisconnectClientHandler", ctx, channelID, clientID)}
+//   - session authn.Session
+//   - ro bootstrap.Rollout
+func (_e *Service_Expecter) CreateRollout(ctx interface{}, session interface{}, ro interface{}) *Service_CreateRollout_Call {
+	return &Service_CreateRollout_Call{Call: _e.mock.On("CreateRollout", ctx, session, ro)}
 }
 
-func (_c *Service_DisconnectClientHandler_Call) Run(run func(ctx context.Context, channelID string, clientID string)) *Service_DisconnectClientHandler_Call {
+func (_c *Service_CreateRollout_Call) Run(run func(ctx context.Context, session authn.Session, ro bootstrap.Rollout)) *Service_CreateRollout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 authn.Session
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(authn.Session)
 		}
-		var arg2 string
+		var arg2 bootstrap.Rollout
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(bootstrap.Rollout)
 		}
 		run(
 			arg0,
@@ -390,58 +548,56 @@ func (_c *Service_DisconnectClientHandler_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *Service_DisconnectClientHandler_Call) Return(err error) *Service_DisconnectClientHandler_Call {
-	_c.Call.Return(err)
+func (_c *Service_CreateRollout_Call) Return(rollout bootstrap.Rollout, err error) *Service_CreateRollout_Call {
+	_c.Call.Return(rollout, err)
 	return _c
 }
 
-func (_c *Service_DisconnectClientHandler_Call) RunAndReturn(run func(ctx context.Context, channelID string, clientID string) error) *Service_DisconnectClientHandler_Call {
+func (_c *Service_CreateRollout_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ro bootstrap.Rollout) (bootstrap.Rollout, error)) *Service_CreateRollout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function for the type Service
-func (_mock *Service) List(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error) {
-	ret := _mock.Called(ctx, session, filter, offset, limit)
+// CreateTemplate provides a mock function for the type Service
+func (_mock *Service) CreateTemplate(ctx context.Context, session authn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	ret := _mock.Called(ctx, session, t)
 
 	if len(ret) == 0 {
-		panic("no return value specified for List")
+		panic("no return value specified for CreateTemplate")
 	}
 
-	var r0 bootstrap.ConfigsPage
+	var r0 bootstrap.Template
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) (bootstrap.ConfigsPage, error)); ok {
-		return returnFunc(ctx, session, filter, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Template) (bootstrap.Template, error)); ok {
+		return returnFunc(ctx, session, t)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) bootstrap.ConfigsPage); ok {
-		r0 = returnFunc(ctx, session, filter, offset, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Template) bootstrap.Template); ok {
+		r0 = returnFunc(ctx, session, t)
 	} else {
-		r0 = ret.Get(0).(bootstrap.ConfigsPage)
+		r0 = ret.Get(0).(bootstrap.Template)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) error); ok {
-		r1 = returnFunc(ctx, session, filter, offset, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Template) error); ok {
+		r1 = returnFunc(ctx, session, t)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Service_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type Service_List_Call struct {
+// Service_CreateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTemplate'
+type Service_CreateTemplate_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// CreateTemplate is a helper method to define mock.On call
 //   - ctx context.Context
 //   - session authn.Session
-//   - filter bootstrap.Filter
-//   - offset uint64
-//   - limit uint64
-func (_e *Service_Expecter) List(ctx interface{}, session interface{}, filter interface{}, offset interface{}, limit interface{}) *Service_List_Call {
-	return &Service_List_Call{Call: _e.mock.On("List", ctx, session, filter, offset, limit)}
+//   - t bootstrap.Template
+func (_e *Service_Expecter) CreateTemplate(ctx interface{}, session interface{}, t interface{}) *Service_CreateTemplate_Call {
+	return &Service_CreateTemplate_Call{Call: _e.mock.On("CreateTemplate", ctx, session, t)}
 }
 
-func (_c *Service_List_Call) Run(run func(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64)) *Service_List_Call {
+func (_c *Service_CreateTemplate_Call) Run(run func(ctx context.Context, session authn.Session, t bootstrap.Template)) *Service_CreateTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -451,70 +607,71 @@ func (_c *Service_List_Call) Run(run func(ctx context.Context, session authn.Ses
 		if args[1] != nil {
 			arg1 = args[1].(authn.Session)
 		}
-		var arg2 bootstrap.Filter
+		var arg2 bootstrap.Template
 		if args[2] != nil {
-			arg2 = args[2].(bootstrap.Filter)
-		}
-		var arg3 uint64
-		if args[3] != nil {
-			arg3 = args[3].(uint64)
-		}
-		var arg4 uint64
-		if args[4] != nil {
-			arg4 = args[4].(uint64)
+			arg2 = args[2].(bootstrap.Template)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *Service_List_Call) Return(configsPage bootstrap.ConfigsPage, err error) *Service_List_Call {
-	_c.Call.Return(configsPage, err)
+func (_c *Service_CreateTemplate_Call) Return(template bootstrap.Template, err error) *Service_CreateTemplate_Call {
+	_c.Call.Return(template, err)
 	return _c
 }
 
-func (_c *Service_List_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error)) *Service_List_Call {
+func (_c *Service_CreateTemplate_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, t bootstrap.Template) (bootstrap.Template, error)) *Service_CreateTemplate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Remove provides a mock function for the type Service
-func (_mock *Service) Remove(ctx context.Context, session authn.Session, id string) error {
-	ret := _mock.Called(ctx, session, id)
+// DiffRevisions provides a mock function for the type Service
+func (_mock *Service) DiffRevisions(ctx context.Context, session authn.Session, id string, from uint64, to uint64) (bootstrap.RevisionDiff, error) {
+	ret := _mock.Called(ctx, session, id, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Remove")
+		panic("no return value specified for DiffRevisions")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
-		r0 = returnFunc(ctx, session, id)
+	var r0 bootstrap.RevisionDiff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) (bootstrap.RevisionDiff, error)); ok {
+		return returnFunc(ctx, session, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) bootstrap.RevisionDiff); ok {
+		r0 = returnFunc(ctx, session, id, from, to)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bootstrap.RevisionDiff)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, session, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Service_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
-type Service_Remove_Call struct {
+// Service_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type Service_DiffRevisions_Call struct {
 	*mock.Call
 }
 
-// Remove is a helper method to define mock.On call
+// DiffRevisions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - session authn.Session
 //   - id string
-func (_e *Service_Expecter) Remove(ctx interface{}, session interface{}, id interface{}) *Service_Remove_Call {
-	return &Service_Remove_Call{Call: _e.mock.On("Remove", ctx, session, id)}
+//   - from uint64
+//   - to uint64
+func (_e *Service_Expecter) DiffRevisions(ctx interface{}, session interface{}, id interface{}, from interface{}, to interface{}) *Service_DiffRevisions_Call {
+	return &Service_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", ctx, session, id, from, to)}
 }
 
-func (_c *Service_Remove_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_Remove_Call {
+func (_c *Service_DiffRevisions_Call) Run(run func(ctx context.Context, session authn.Session, id string, from uint64, to uint64)) *Service_DiffRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -528,55 +685,66 @@ func (_c *Service_Remove_Call) Run(run func(ctx context.Context, session authn.S
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *Service_Remove_Call) Return(err error) *Service_Remove_Call {
-	_c.Call.Return(err)
+func (_c *Service_DiffRevisions_Call) Return(revisionDiff bootstrap.RevisionDiff, err error) *Service_DiffRevisions_Call {
+	_c.Call.Return(revisionDiff, err)
 	return _c
 }
 
-func (_c *Service_Remove_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_Remove_Call {
+func (_c *Service_DiffRevisions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string, from uint64, to uint64) (bootstrap.RevisionDiff, error)) *Service_DiffRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveChannelHandler provides a mock function for the type Service
-func (_mock *Service) RemoveChannelHandler(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// DisconnectClientHandler provides a mock function for the type Service
+func (_mock *Service) DisconnectClientHandler(ctx context.Context, channelID string, clientID string) error {
+	ret := _mock.Called(ctx, channelID, clientID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveChannelHandler")
+		panic("no return value specified for DisconnectClientHandler")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, channelID, clientID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Service_RemoveChannelHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveChannelHandler'
-type Service_RemoveChannelHandler_Call struct {
+// Service_DisconnectClientHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisconnectClientHandler'
+type Service_DisconnectClientHandler_Call struct {
 	*mock.Call
 }
 
-// RemoveChannelHandler is a helper method to define mock.On call
+// DisconnectClientHandler is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *Service_Expecter) RemoveChannelHandler(ctx interface{}, id interface{}) *Service_RemoveChannelHandler_Call {
-	return &Service_RemoveChannelHandler_Call{Call: _e.mock.On("RemoveChannelHandler", ctx, id)}
+//   - channelID string
+//   - clientID string
+func (_e *Service_Expecter) DisconnectClientHandler(ctx interface{}, channelID interface{}, clientID interface{}) *Service_DisconnectClientHandler_Call {
+	return &Service_DisconnectClientHandler_Call{Call: _e.mock.On("DisconnectClientHandler", ctx, channelID, clientID)}
 }
 
-func (_c *Service_RemoveChannelHandler_Call) Run(run func(ctx context.Context, id string)) *Service_RemoveChannelHandler_Call {
+func (_c *Service_DisconnectClientHandler_Call) Run(run func(ctx context.Context, channelID string, clientID string)) *Service_DisconnectClientHandler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -586,77 +754,661 @@ func (_c *Service_RemoveChannelHandler_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Service_RemoveChannelHandler_Call) Return(err error) *Service_RemoveChannelHandler_Call {
+func (_c *Service_DisconnectClientHandler_Call) Return(err error) *Service_DisconnectClientHandler_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Service_RemoveChannelHandler_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Service_RemoveChannelHandler_Call {
+func (_c *Service_DisconnectClientHandler_Call) RunAndReturn(run func(ctx context.Context, channelID string, clientID string) error) *Service_DisconnectClientHandler_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveConfigHandler provides a mock function for the type Service
-func (_mock *Service) RemoveConfigHandler(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// EnrollCert provides a mock function for the type Service
+func (_mock *Service) EnrollCert(ctx context.Context, externalID string, clientCert string, caCert string) (bootstrap.Config, error) {
+	ret := _mock.Called(ctx, externalID, clientCert, caCert)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveConfigHandler")
+		panic("no return value specified for EnrollCert")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 bootstrap.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (bootstrap.Config, error)); ok {
+		return returnFunc(ctx, externalID, clientCert, caCert)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) bootstrap.Config); ok {
+		r0 = returnFunc(ctx, externalID, clientCert, caCert)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Config)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, externalID, clientCert, caCert)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_EnrollCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollCert'
+type Service_EnrollCert_Call struct {
+	*mock.Call
+}
+
+// EnrollCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+//   - clientCert string
+//   - caCert string
+func (_e *Service_Expecter) EnrollCert(ctx interface{}, externalID interface{}, clientCert interface{}, caCert interface{}) *Service_EnrollCert_Call {
+	return &Service_EnrollCert_Call{Call: _e.mock.On("EnrollCert", ctx, externalID, clientCert, caCert)}
+}
+
+func (_c *Service_EnrollCert_Call) Run(run func(ctx context.Context, externalID string, clientCert string, caCert string)) *Service_EnrollCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_EnrollCert_Call) Return(config bootstrap.Config, err error) *Service_EnrollCert_Call {
+	_c.Call.Return(config, err)
+	return _c
+}
+
+func (_c *Service_EnrollCert_Call) RunAndReturn(run func(ctx context.Context, externalID string, clientCert string, caCert string) (bootstrap.Config, error)) *Service_EnrollCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type Service
+func (_mock *Service) List(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error) {
+	ret := _mock.Called(ctx, session, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 bootstrap.ConfigsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) (bootstrap.ConfigsPage, error)); ok {
+		return returnFunc(ctx, session, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) bootstrap.ConfigsPage); ok {
+		r0 = returnFunc(ctx, session, filter, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.ConfigsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Filter, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, session, filter, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type Service_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - filter bootstrap.Filter
+//   - offset uint64
+//   - limit uint64
+func (_e *Service_Expecter) List(ctx interface{}, session interface{}, filter interface{}, offset interface{}, limit interface{}) *Service_List_Call {
+	return &Service_List_Call{Call: _e.mock.On("List", ctx, session, filter, offset, limit)}
+}
+
+func (_c *Service_List_Call) Run(run func(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64)) *Service_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 bootstrap.Filter
+		if args[2] != nil {
+			arg2 = args[2].(bootstrap.Filter)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_List_Call) Return(configsPage bootstrap.ConfigsPage, err error) *Service_List_Call {
+	_c.Call.Return(configsPage, err)
+	return _c
+}
+
+func (_c *Service_List_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, filter bootstrap.Filter, offset uint64, limit uint64) (bootstrap.ConfigsPage, error)) *Service_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function for the type Service
+func (_mock *Service) ListRevisions(ctx context.Context, session authn.Session, id string, offset uint64, limit uint64) (bootstrap.RevisionsPage, error) {
+	ret := _mock.Called(ctx, session, id, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 bootstrap.RevisionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) (bootstrap.RevisionsPage, error)); ok {
+		return returnFunc(ctx, session, id, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) bootstrap.RevisionsPage); ok {
+		r0 = returnFunc(ctx, session, id, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.RevisionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, session, id, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type Service_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+//   - offset uint64
+//   - limit uint64
+func (_e *Service_Expecter) ListRevisions(ctx interface{}, session interface{}, id interface{}, offset interface{}, limit interface{}) *Service_ListRevisions_Call {
+	return &Service_ListRevisions_Call{Call: _e.mock.On("ListRevisions", ctx, session, id, offset, limit)}
+}
+
+func (_c *Service_ListRevisions_Call) Run(run func(ctx context.Context, session authn.Session, id string, offset uint64, limit uint64)) *Service_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListRevisions_Call) Return(revisionsPage bootstrap.RevisionsPage, err error) *Service_ListRevisions_Call {
+	_c.Call.Return(revisionsPage, err)
+	return _c
+}
+
+func (_c *Service_ListRevisions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string, offset uint64, limit uint64) (bootstrap.RevisionsPage, error)) *Service_ListRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplates provides a mock function for the type Service
+func (_mock *Service) ListTemplates(ctx context.Context, session authn.Session, offset uint64, limit uint64) (bootstrap.TemplatesPage, error) {
+	ret := _mock.Called(ctx, session, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 bootstrap.TemplatesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) (bootstrap.TemplatesPage, error)); ok {
+		return returnFunc(ctx, session, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, uint64, uint64) bootstrap.TemplatesPage); ok {
+		r0 = returnFunc(ctx, session, offset, limit)
+	} else {
+		r0 = ret.Get(0).(bootstrap.TemplatesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, session, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
+type Service_ListTemplates_Call struct {
+	*mock.Call
+}
+
+// ListTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - offset uint64
+//   - limit uint64
+func (_e *Service_Expecter) ListTemplates(ctx interface{}, session interface{}, offset interface{}, limit interface{}) *Service_ListTemplates_Call {
+	return &Service_ListTemplates_Call{Call: _e.mock.On("ListTemplates", ctx, session, offset, limit)}
+}
+
+func (_c *Service_ListTemplates_Call) Run(run func(ctx context.Context, session authn.Session, offset uint64, limit uint64)) *Service_ListTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListTemplates_Call) Return(templatesPage bootstrap.TemplatesPage, err error) *Service_ListTemplates_Call {
+	_c.Call.Return(templatesPage, err)
+	return _c
+}
+
+func (_c *Service_ListTemplates_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, offset uint64, limit uint64) (bootstrap.TemplatesPage, error)) *Service_ListTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function for the type Service
+func (_mock *Service) Remove(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type Service_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) Remove(ctx interface{}, session interface{}, id interface{}) *Service_Remove_Call {
+	return &Service_Remove_Call{Call: _e.mock.On("Remove", ctx, session, id)}
+}
+
+func (_c *Service_Remove_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Remove_Call) Return(err error) *Service_Remove_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Remove_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveChannelHandler provides a mock function for the type Service
+func (_mock *Service) RemoveChannelHandler(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveChannelHandler")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_RemoveChannelHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveChannelHandler'
+type Service_RemoveChannelHandler_Call struct {
+	*mock.Call
+}
+
+// RemoveChannelHandler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Service_Expecter) RemoveChannelHandler(ctx interface{}, id interface{}) *Service_RemoveChannelHandler_Call {
+	return &Service_RemoveChannelHandler_Call{Call: _e.mock.On("RemoveChannelHandler", ctx, id)}
+}
+
+func (_c *Service_RemoveChannelHandler_Call) Run(run func(ctx context.Context, id string)) *Service_RemoveChannelHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RemoveChannelHandler_Call) Return(err error) *Service_RemoveChannelHandler_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_RemoveChannelHandler_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Service_RemoveChannelHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveConfigHandler provides a mock function for the type Service
+func (_mock *Service) RemoveConfigHandler(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveConfigHandler")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_RemoveConfigHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveConfigHandler'
+type Service_RemoveConfigHandler_Call struct {
+	*mock.Call
+}
+
+// RemoveConfigHandler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Service_Expecter) RemoveConfigHandler(ctx interface{}, id interface{}) *Service_RemoveConfigHandler_Call {
+	return &Service_RemoveConfigHandler_Call{Call: _e.mock.On("RemoveConfigHandler", ctx, id)}
+}
+
+func (_c *Service_RemoveConfigHandler_Call) Run(run func(ctx context.Context, id string)) *Service_RemoveConfigHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RemoveConfigHandler_Call) Return(err error) *Service_RemoveConfigHandler_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_RemoveConfigHandler_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Service_RemoveConfigHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTemplate provides a mock function for the type Service
+func (_mock *Service) RemoveTemplate(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Service_RemoveConfigHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveConfigHandler'
-type Service_RemoveConfigHandler_Call struct {
+// Service_RemoveTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTemplate'
+type Service_RemoveTemplate_Call struct {
 	*mock.Call
 }
 
-// RemoveConfigHandler is a helper method to define mock.On call
+// RemoveTemplate is a helper method to define mock.On call
 //   - ctx context.Context
+//   - session authn.Session
 //   - id string
-func (_e *Service_Expecter) RemoveConfigHandler(ctx interface{}, id interface{}) *Service_RemoveConfigHandler_Call {
-	return &Service_RemoveConfigHandler_Call{Call: _e.mock.On("RemoveConfigHandler", ctx, id)}
+func (_e *Service_Expecter) RemoveTemplate(ctx interface{}, session interface{}, id interface{}) *Service_RemoveTemplate_Call {
+	return &Service_RemoveTemplate_Call{Call: _e.mock.On("RemoveTemplate", ctx, session, id)}
 }
 
-func (_c *Service_RemoveConfigHandler_Call) Run(run func(ctx context.Context, id string)) *Service_RemoveConfigHandler_Call {
+func (_c *Service_RemoveTemplate_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_RemoveTemplate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 authn.Session
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Service_RemoveConfigHandler_Call) Return(err error) *Service_RemoveConfigHandler_Call {
+func (_c *Service_RemoveTemplate_Call) Return(err error) *Service_RemoveTemplate_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Service_RemoveConfigHandler_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Service_RemoveConfigHandler_Call {
+func (_c *Service_RemoveTemplate_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_RemoveTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackRollout provides a mock function for the type Service
+func (_mock *Service) RollbackRollout(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Rollout, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Rollout); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_RollbackRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackRollout'
+type Service_RollbackRollout_Call struct {
+	*mock.Call
+}
+
+// RollbackRollout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) RollbackRollout(ctx interface{}, session interface{}, id interface{}) *Service_RollbackRollout_Call {
+	return &Service_RollbackRollout_Call{Call: _e.mock.On("RollbackRollout", ctx, session, id)}
+}
+
+func (_c *Service_RollbackRollout_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_RollbackRollout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RollbackRollout_Call) Return(rollout bootstrap.Rollout, err error) *Service_RollbackRollout_Call {
+	_c.Call.Return(rollout, err)
+	return _c
+}
+
+func (_c *Service_RollbackRollout_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error)) *Service_RollbackRollout_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -946,6 +1698,78 @@ func (_c *Service_UpdateConnections_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// UpdateTemplate provides a mock function for the type Service
+func (_mock *Service) UpdateTemplate(ctx context.Context, session authn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	ret := _mock.Called(ctx, session, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Template) (bootstrap.Template, error)); ok {
+		return returnFunc(ctx, session, t)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, bootstrap.Template) bootstrap.Template); ok {
+		r0 = returnFunc(ctx, session, t)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, bootstrap.Template) error); ok {
+		r1 = returnFunc(ctx, session, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_UpdateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplate'
+type Service_UpdateTemplate_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - t bootstrap.Template
+func (_e *Service_Expecter) UpdateTemplate(ctx interface{}, session interface{}, t interface{}) *Service_UpdateTemplate_Call {
+	return &Service_UpdateTemplate_Call{Call: _e.mock.On("UpdateTemplate", ctx, session, t)}
+}
+
+func (_c *Service_UpdateTemplate_Call) Run(run func(ctx context.Context, session authn.Session, t bootstrap.Template)) *Service_UpdateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 bootstrap.Template
+		if args[2] != nil {
+			arg2 = args[2].(bootstrap.Template)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_UpdateTemplate_Call) Return(template bootstrap.Template, err error) *Service_UpdateTemplate_Call {
+	_c.Call.Return(template, err)
+	return _c
+}
+
+func (_c *Service_UpdateTemplate_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, t bootstrap.Template) (bootstrap.Template, error)) *Service_UpdateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // View provides a mock function for the type Service
 func (_mock *Service) View(ctx context.Context, session authn.Session, id string) (bootstrap.Config, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -1017,3 +1841,147 @@ func (_c *Service_View_Call) RunAndReturn(run func(ctx context.Context, session
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewRollout provides a mock function for the type Service
+func (_mock *Service) ViewRollout(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRollout")
+	}
+
+	var r0 bootstrap.Rollout
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Rollout, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Rollout); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Rollout)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewRollout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewRollout'
+type Service_ViewRollout_Call struct {
+	*mock.Call
+}
+
+// ViewRollout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ViewRollout(ctx interface{}, session interface{}, id interface{}) *Service_ViewRollout_Call {
+	return &Service_ViewRollout_Call{Call: _e.mock.On("ViewRollout", ctx, session, id)}
+}
+
+func (_c *Service_ViewRollout_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ViewRollout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewRollout_Call) Return(rollout bootstrap.Rollout, err error) *Service_ViewRollout_Call {
+	_c.Call.Return(rollout, err)
+	return _c
+}
+
+func (_c *Service_ViewRollout_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (bootstrap.Rollout, error)) *Service_ViewRollout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewTemplate provides a mock function for the type Service
+func (_mock *Service) ViewTemplate(ctx context.Context, session authn.Session, id string) (bootstrap.Template, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTemplate")
+	}
+
+	var r0 bootstrap.Template
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (bootstrap.Template, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) bootstrap.Template); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(bootstrap.Template)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewTemplate'
+type Service_ViewTemplate_Call struct {
+	*mock.Call
+}
+
+// ViewTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ViewTemplate(ctx interface{}, session interface{}, id interface{}) *Service_ViewTemplate_Call {
+	return &Service_ViewTemplate_Call{Call: _e.mock.On("ViewTemplate", ctx, session, id)}
+}
+
+func (_c *Service_ViewTemplate_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ViewTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewTemplate_Call) Return(template bootstrap.Template, err error) *Service_ViewTemplate_Call {
+	_c.Call.Return(template, err)
+	return _c
+}
+
+func (_c *Service_ViewTemplate_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (bootstrap.Template, error)) *Service_ViewTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -30,6 +30,7 @@ var (
 	errRemoveChannels   = errors.New("failed to remove channels from bootstrap configuration in database")
 	errConnectClient    = errors.New("failed to connect client in bootstrap configuration in database")
 	errDisconnectClient = errors.New("failed to disconnect client in bootstrap configuration in database")
+	errSaveRevision     = errors.New("failed to insert bootstrap configuration revision to database")
 )
 
 const cleanupQuery = `DELETE FROM channels ch WHERE NOT EXISTS (
@@ -49,8 +50,8 @@ func NewConfigRepository(db postgres.Database, log *slog.Logger) bootstrap.Confi
 }
 
 func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsConnIDs []string) (clientID string, err error) {
-	q := `INSERT INTO configs (magistrala_client, domain_id, name, client_cert, client_key, ca_cert, magistrala_secret, external_id, external_key, content, state)
-	VALUES (:magistrala_client, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_secret, :external_id, :external_key, :content, :state)`
+	q := `INSERT INTO configs (magistrala_client, domain_id, name, client_cert, client_key, ca_cert, magistrala_secret, external_id, external_key, content, state, tags, revision, template_id, variables)
+	VALUES (:magistrala_client, :domain_id, :name, :client_cert, :client_key, :ca_cert, :magistrala_secret, :external_id, :external_key, :content, :state, :tags, 1, :template_id, :variables)`
 
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -74,6 +75,12 @@ func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsCo
 		return "", err
 	}
 
+	q = `INSERT INTO config_revisions (client_id, domain_id, revision, content, created_at)
+	VALUES ($1, $2, 1, $3, $4)`
+	if _, err = tx.ExecContext(ctx, q, cfg.ClientID, cfg.DomainID, dbcfg.Content, time.Now().UTC()); err != nil {
+		return "", errors.Wrap(errSaveRevision, err)
+	}
+
 	if err := insertChannels(cfg.DomainID, cfg.Channels, tx); err != nil {
 		return "", errors.Wrap(errSaveChannels, err)
 	}
@@ -90,7 +97,8 @@ func (cr configRepository) Save(ctx context.Context, cfg bootstrap.Config, chsCo
 }
 
 func (cr configRepository) RetrieveByID(ctx context.Context, domainID, id string) (bootstrap.Config, error) {
-	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state, client_cert, ca_cert
+	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state, client_cert, ca_cert,
+		  tags, revision, applied_revision, ack_revision, ack_status, ack_message, acked_at, template_id, variables
 		  FROM configs
 		  WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id`
 
@@ -149,30 +157,28 @@ func (cr configRepository) RetrieveAll(ctx context.Context, domainID string, cli
 	search, params := buildRetrieveQueryParams(domainID, clientIDs, filter)
 	n := len(params)
 
-	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state
+	q := `SELECT magistrala_client, magistrala_secret, external_id, external_key, name, content, state,
+		  tags, revision, applied_revision, ack_revision, ack_status, ack_message, acked_at, template_id, variables
 		  FROM configs %s ORDER BY magistrala_client LIMIT $%d OFFSET $%d`
 	q = fmt.Sprintf(q, search, n+1, n+2)
 
-	rows, err := cr.db.QueryContext(ctx, q, append(params, limit, offset)...)
+	rows, err := cr.db.QueryxContext(ctx, q, append(params, limit, offset)...)
 	if err != nil {
 		cr.log.Error(fmt.Sprintf("Failed to retrieve configs due to %s", err))
 		return bootstrap.ConfigsPage{}
 	}
 	defer rows.Close()
 
-	var name, content sql.NullString
 	configs := []bootstrap.Config{}
 
 	for rows.Next() {
-		c := bootstrap.Config{DomainID: domainID}
-		if err := rows.Scan(&c.ClientID, &c.ClientSecret, &c.ExternalID, &c.ExternalKey, &name, &content, &c.State); err != nil {
+		dbcfg := dbConfig{DomainID: domainID}
+		if err := rows.StructScan(&dbcfg); err != nil {
 			cr.log.Error(fmt.Sprintf("Failed to read retrieved config due to %s", err))
 			return bootstrap.ConfigsPage{}
 		}
 
-		c.Name = name.String
-		c.Content = content.String
-		configs = append(configs, c)
+		configs = append(configs, toConfig(dbcfg))
 	}
 
 	q = fmt.Sprintf(`SELECT COUNT(*) FROM configs %s`, search)
@@ -192,7 +198,8 @@ func (cr configRepository) RetrieveAll(ctx context.Context, domainID string, cli
 }
 
 func (cr configRepository) RetrieveByExternalID(ctx context.Context, externalID string) (bootstrap.Config, error) {
-	q := `SELECT magistrala_client, magistrala_secret, external_key, domain_id, name, client_cert, client_key, ca_cert, content, state
+	q := `SELECT magistrala_client, magistrala_secret, external_key, domain_id, name, client_cert, client_key, ca_cert, content, state,
+		  tags, revision, applied_revision, ack_revision, ack_status, ack_message, acked_at, template_id, variables
 		  FROM configs
 		  WHERE external_id = :external_id`
 	dbcfg := dbConfig{
@@ -247,28 +254,49 @@ func (cr configRepository) RetrieveByExternalID(ctx context.Context, externalID
 	return cfg, nil
 }
 
-func (cr configRepository) Update(ctx context.Context, cfg bootstrap.Config) error {
-	q := `UPDATE configs SET name = :name, content = :content WHERE magistrala_client = :magistrala_client AND domain_id = :domain_id `
-
-	dbcfg := dbConfig{
-		Name:     nullString(cfg.Name),
-		Content:  nullString(cfg.Content),
-		ClientID: cfg.ClientID,
-		DomainID: cfg.DomainID,
+func (cr configRepository) Update(ctx context.Context, cfg bootstrap.Config) (err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
 
-	res, err := cr.db.NamedExecContext(ctx, q, dbcfg)
-	if err != nil {
+	defer func() {
+		if err != nil {
+			err = cr.rollback("Update method", err, tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = errors.Wrap(repoerr.ErrUpdateEntity, commitErr)
+		}
+	}()
+
+	q := `SELECT content FROM configs WHERE magistrala_client = $1 AND domain_id = $2 FOR UPDATE`
+
+	var content sql.NullString
+	if err = tx.QueryRowxContext(ctx, q, cfg.ClientID, cfg.DomainID).Scan(&content); err != nil {
+		if err == sql.ErrNoRows {
+			return repoerr.ErrNotFound
+		}
 		return errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
 
-	cnt, err := res.RowsAffected()
-	if err != nil {
+	q = `UPDATE configs SET name = $1, tags = $2 WHERE magistrala_client = $3 AND domain_id = $4`
+	if _, err = tx.ExecContext(ctx, q, nullString(cfg.Name), toTextArray(cfg.Tags), cfg.ClientID, cfg.DomainID); err != nil {
 		return errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
 
-	if cnt == 0 {
-		return repoerr.ErrNotFound
+	if content.String == cfg.Content {
+		return nil
+	}
+
+	rev := bootstrap.Revision{
+		ClientID:  cfg.ClientID,
+		DomainID:  cfg.DomainID,
+		Content:   cfg.Content,
+		CreatedAt: time.Now().UTC(),
+	}
+	if _, err = insertRevision(ctx, rev, tx); err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
 
 	return nil
@@ -648,6 +676,17 @@ type dbConfig struct {
 	ExternalKey  string          `db:"external_key"`
 	Content      sql.NullString  `db:"content"`
 	State        bootstrap.State `db:"state"`
+
+	Tags            pgtype.TextArray `db:"tags"`
+	Revision        uint64           `db:"revision"`
+	AppliedRevision uint64           `db:"applied_revision"`
+	AckRevision     uint64           `db:"ack_revision"`
+	AckStatus       string           `db:"ack_status"`
+	AckMessage      string           `db:"ack_message"`
+	AckedAt         sql.NullTime     `db:"acked_at"`
+
+	TemplateID sql.NullString `db:"template_id"`
+	Variables  string         `db:"variables"`
 }
 
 func toDBConfig(cfg bootstrap.Config) dbConfig {
@@ -663,17 +702,22 @@ func toDBConfig(cfg bootstrap.Config) dbConfig {
 		ExternalKey:  cfg.ExternalKey,
 		Content:      nullString(cfg.Content),
 		State:        cfg.State,
+		Tags:         toTextArray(cfg.Tags),
+		TemplateID:   nullString(cfg.TemplateID),
+		Variables:    toJSONVariables(cfg.Variables),
 	}
 }
 
 func toConfig(dbcfg dbConfig) bootstrap.Config {
 	cfg := bootstrap.Config{
-		ClientID:     dbcfg.ClientID,
-		ClientSecret: dbcfg.ClientSecret,
-		DomainID:     dbcfg.DomainID,
-		ExternalID:   dbcfg.ExternalID,
-		ExternalKey:  dbcfg.ExternalKey,
-		State:        dbcfg.State,
+		ClientID:        dbcfg.ClientID,
+		ClientSecret:    dbcfg.ClientSecret,
+		DomainID:        dbcfg.DomainID,
+		ExternalID:      dbcfg.ExternalID,
+		ExternalKey:     dbcfg.ExternalKey,
+		State:           dbcfg.State,
+		Revision:        dbcfg.Revision,
+		AppliedRevision: dbcfg.AppliedRevision,
 	}
 
 	if dbcfg.Name.Valid {
@@ -695,9 +739,69 @@ func toConfig(dbcfg dbConfig) bootstrap.Config {
 	if dbcfg.CaCert.Valid {
 		cfg.CACert = dbcfg.CaCert.String
 	}
+
+	cfg.Tags = fromTextArray(dbcfg.Tags)
+
+	if dbcfg.TemplateID.Valid {
+		cfg.TemplateID = dbcfg.TemplateID.String
+	}
+
+	cfg.Variables = fromJSONVariables(dbcfg.Variables)
+
+	if dbcfg.AckedAt.Valid {
+		cfg.LastAck = bootstrap.Ack{
+			ClientID:   dbcfg.ClientID,
+			Revision:   dbcfg.AckRevision,
+			Status:     bootstrap.AckStatus(dbcfg.AckStatus),
+			Message:    dbcfg.AckMessage,
+			ReceivedAt: dbcfg.AckedAt.Time,
+		}
+	}
 	return cfg
 }
 
+func toTextArray(s []string) pgtype.TextArray {
+	arr := pgtype.TextArray{}
+	if s == nil {
+		s = []string{}
+	}
+	// Setting a string slice never fails.
+	_ = arr.Set(s)
+
+	return arr
+}
+
+func toJSONVariables(vars map[string]string) string {
+	if len(vars) == 0 {
+		return "{}"
+	}
+	// Marshaling a string map never fails.
+	b, _ := json.Marshal(vars)
+
+	return string(b)
+}
+
+func fromJSONVariables(s string) map[string]string {
+	var vars map[string]string
+	if err := json.Unmarshal([]byte(s), &vars); err != nil || len(vars) == 0 {
+		return nil
+	}
+
+	return vars
+}
+
+func fromTextArray(arr pgtype.TextArray) []string {
+	var s []string
+	if arr.Status != pgtype.Present {
+		return s
+	}
+	for _, e := range arr.Elements {
+		s = append(s, e.String)
+	}
+
+	return s
+}
+
 type dbChannel struct {
 	ID          string         `db:"magistrala_channel"`
 	Name        sql.NullString `db:"name"`
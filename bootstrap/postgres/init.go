@@ -103,6 +103,93 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE IF EXISTS connections ADD FOREIGN KEY (config_id, domain_id) REFERENCES configs (magistrala_client, domain_id) ON DELETE CASCADE ON UPDATE CASCADE`,
 				},
 			},
+			{
+				Id: "configs_7",
+				Up: []string{
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}'`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS applied_revision BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS ack_revision BIGINT NOT NULL DEFAULT 0`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS ack_status VARCHAR(16) NOT NULL DEFAULT ''`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS ack_message TEXT NOT NULL DEFAULT ''`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS acked_at TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS idx_configs_tags ON configs USING GIN (tags)`,
+					`CREATE TABLE IF NOT EXISTS config_revisions (
+						client_id  TEXT NOT NULL,
+						domain_id  VARCHAR(256) NOT NULL,
+						revision   BIGINT NOT NULL,
+						content    TEXT,
+						rollout_id VARCHAR(36),
+						created_at TIMESTAMP NOT NULL,
+						FOREIGN KEY (client_id, domain_id) REFERENCES configs (magistrala_client, domain_id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (client_id, revision)
+					)`,
+					`INSERT INTO config_revisions (client_id, domain_id, revision, content, created_at)
+						SELECT magistrala_client, domain_id, 1, content, NOW() FROM configs`,
+					`UPDATE configs SET revision = 1`,
+					`CREATE TABLE IF NOT EXISTS rollouts (
+						id                VARCHAR(36) PRIMARY KEY,
+						domain_id         VARCHAR(256) NOT NULL,
+						content           TEXT,
+						tags              TEXT[] NOT NULL DEFAULT '{}',
+						percentage        SMALLINT NOT NULL CHECK (percentage > 0 AND percentage <= 100),
+						failure_threshold SMALLINT NOT NULL DEFAULT 0 CHECK (failure_threshold >= 0 AND failure_threshold <= 100),
+						status            VARCHAR(16) NOT NULL,
+						created_at        TIMESTAMP NOT NULL,
+						created_by        VARCHAR(254),
+						updated_at        TIMESTAMP
+					)`,
+					`CREATE TABLE IF NOT EXISTS rollout_targets (
+						rollout_id    VARCHAR(36) NOT NULL REFERENCES rollouts (id) ON DELETE CASCADE,
+						client_id     TEXT NOT NULL,
+						domain_id     VARCHAR(256) NOT NULL,
+						revision      BIGINT NOT NULL,
+						prev_revision BIGINT NOT NULL,
+						status        VARCHAR(16) NOT NULL DEFAULT '',
+						FOREIGN KEY (client_id, domain_id) REFERENCES configs (magistrala_client, domain_id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (rollout_id, client_id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rollout_targets_revision ON rollout_targets (client_id, revision)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rollout_targets`,
+					`DROP TABLE IF EXISTS rollouts`,
+					`DROP TABLE IF EXISTS config_revisions`,
+					`DROP INDEX IF EXISTS idx_configs_tags`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS acked_at`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS ack_message`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS ack_status`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS ack_revision`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS applied_revision`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS revision`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS tags`,
+				},
+			},
+			{
+				Id: "configs_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS config_templates (
+						id         VARCHAR(36) PRIMARY KEY,
+						domain_id  VARCHAR(256) NOT NULL,
+						name       TEXT NOT NULL,
+						content    TEXT,
+						channels   TEXT[] NOT NULL DEFAULT '{}',
+						created_at TIMESTAMP NOT NULL,
+						created_by VARCHAR(254),
+						updated_at TIMESTAMP,
+						UNIQUE (domain_id, name)
+					)`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS template_id VARCHAR(36) REFERENCES config_templates (id) ON DELETE SET NULL`,
+					`ALTER TABLE IF EXISTS configs ADD COLUMN IF NOT EXISTS variables JSONB NOT NULL DEFAULT '{}'`,
+					`CREATE INDEX IF NOT EXISTS idx_configs_template_id ON configs (template_id)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_configs_template_id`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS variables`,
+					`ALTER TABLE IF EXISTS configs DROP COLUMN IF EXISTS template_id`,
+					`DROP TABLE IF EXISTS config_templates`,
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,380 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errSaveRollout     = errors.New("failed to insert rollout to database")
+	errRollbackRollout = errors.New("failed to roll back rollout in database")
+	errSaveAck         = errors.New("failed to save acknowledgment to database")
+)
+
+func (cr configRepository) RetrieveRevisions(ctx context.Context, domainID, clientID string, offset, limit uint64) (bootstrap.RevisionsPage, error) {
+	q := `SELECT client_id, domain_id, revision, content, rollout_id, created_at FROM config_revisions
+		  WHERE client_id = $1 AND domain_id = $2 ORDER BY revision DESC LIMIT $3 OFFSET $4`
+
+	rows, err := cr.db.QueryxContext(ctx, q, clientID, domainID, limit, offset)
+	if err != nil {
+		return bootstrap.RevisionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	revs := []bootstrap.Revision{}
+	for rows.Next() {
+		var dbrev dbRevision
+		if err := rows.StructScan(&dbrev); err != nil {
+			return bootstrap.RevisionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		revs = append(revs, toRevision(dbrev))
+	}
+
+	q = `SELECT COUNT(*) FROM config_revisions WHERE client_id = $1 AND domain_id = $2`
+
+	var total uint64
+	if err := cr.db.QueryRowxContext(ctx, q, clientID, domainID).Scan(&total); err != nil {
+		return bootstrap.RevisionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	// Every Config has at least the initial revision.
+	if total == 0 {
+		return bootstrap.RevisionsPage{}, repoerr.ErrNotFound
+	}
+
+	return bootstrap.RevisionsPage{
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Revisions: revs,
+	}, nil
+}
+
+func (cr configRepository) RetrieveRevision(ctx context.Context, domainID, clientID string, revision uint64) (bootstrap.Revision, error) {
+	q := `SELECT client_id, domain_id, revision, content, rollout_id, created_at FROM config_revisions
+		  WHERE client_id = $1 AND domain_id = $2 AND revision = $3`
+
+	var dbrev dbRevision
+	if err := cr.db.QueryRowxContext(ctx, q, clientID, domainID, revision).StructScan(&dbrev); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.Revision{}, repoerr.ErrNotFound
+		}
+		return bootstrap.Revision{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toRevision(dbrev), nil
+}
+
+func (cr configRepository) RetrieveTargets(ctx context.Context, domainID string, tags []string) ([]string, error) {
+	q := `SELECT magistrala_client FROM configs WHERE domain_id = $1 AND tags @> $2 ORDER BY magistrala_client`
+
+	rows, err := cr.db.QueryxContext(ctx, q, domainID, toTextArray(tags))
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (cr configRepository) SaveRollout(ctx context.Context, ro bootstrap.Rollout, clientIDs []string) (saved bootstrap.Rollout, err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = cr.rollback("SaveRollout method", err, tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = errors.Wrap(repoerr.ErrCreateEntity, commitErr)
+		}
+	}()
+
+	q := `INSERT INTO rollouts (id, domain_id, content, tags, percentage, failure_threshold, status, created_at, created_by)
+		  VALUES (:id, :domain_id, :content, :tags, :percentage, :failure_threshold, :status, :created_at, :created_by)`
+	if _, err = tx.NamedExecContext(ctx, q, toDBRollout(ro)); err != nil {
+		return bootstrap.Rollout{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	q = `INSERT INTO rollout_targets (rollout_id, client_id, domain_id, revision, prev_revision)
+		 VALUES ($1, $2, $3, $4, $5)`
+	for _, id := range clientIDs {
+		rev := bootstrap.Revision{
+			ClientID:  id,
+			DomainID:  ro.DomainID,
+			Content:   ro.Content,
+			RolloutID: ro.ID,
+			CreatedAt: ro.CreatedAt,
+		}
+		var revision uint64
+		if revision, err = insertRevision(ctx, rev, tx); err != nil {
+			return bootstrap.Rollout{}, errors.Wrap(errSaveRollout, err)
+		}
+		if _, err = tx.ExecContext(ctx, q, ro.ID, id, ro.DomainID, revision, revision-1); err != nil {
+			return bootstrap.Rollout{}, errors.Wrap(errSaveRollout, err)
+		}
+	}
+
+	ro.Targets = uint64(len(clientIDs))
+
+	return ro, nil
+}
+
+func (cr configRepository) RetrieveRollout(ctx context.Context, domainID, id string) (bootstrap.Rollout, error) {
+	q := `SELECT r.id, r.domain_id, r.content, r.tags, r.percentage, r.failure_threshold, r.status, r.created_at, r.created_by, r.updated_at,
+		  COUNT(t.client_id) AS targets,
+		  COUNT(t.client_id) FILTER (WHERE t.status = $3) AS applied,
+		  COUNT(t.client_id) FILTER (WHERE t.status = $4) AS failed
+		  FROM rollouts r LEFT JOIN rollout_targets t ON t.rollout_id = r.id
+		  WHERE r.id = $1 AND r.domain_id = $2
+		  GROUP BY r.id`
+
+	var dbro dbRollout
+	if err := cr.db.QueryRowxContext(ctx, q, id, domainID, bootstrap.AckApplied, bootstrap.AckFailed).StructScan(&dbro); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.Rollout{}, repoerr.ErrNotFound
+		}
+		return bootstrap.Rollout{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toRollout(dbro), nil
+}
+
+func (cr configRepository) UpdateRolloutStatus(ctx context.Context, id string, status bootstrap.RolloutStatus, at time.Time) error {
+	q := `UPDATE rollouts SET status = $1, updated_at = $2 WHERE id = $3`
+
+	res, err := cr.db.ExecContext(ctx, q, status, at, id)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr configRepository) RollbackRollout(ctx context.Context, id string, at time.Time) (err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = cr.rollback("RollbackRollout method", err, tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = errors.Wrap(repoerr.ErrUpdateEntity, commitErr)
+		}
+	}()
+
+	// Lock the rollout so concurrent acknowledgments roll it back only once.
+	var status bootstrap.RolloutStatus
+	q := `SELECT status FROM rollouts WHERE id = $1 FOR UPDATE`
+	if err = tx.QueryRowxContext(ctx, q, id).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return repoerr.ErrNotFound
+		}
+		return errors.Wrap(errRollbackRollout, err)
+	}
+	if status == bootstrap.RolloutRolledBack {
+		return nil
+	}
+
+	// Only the Configs whose content is not changed after the rollout are reverted.
+	q = `SELECT t.client_id, t.domain_id, r.content FROM rollout_targets t
+		 INNER JOIN configs c ON c.magistrala_client = t.client_id AND c.revision = t.revision
+		 INNER JOIN config_revisions r ON r.client_id = t.client_id AND r.revision = t.prev_revision
+		 WHERE t.rollout_id = $1`
+	var revs []dbRevision
+	if err = tx.SelectContext(ctx, &revs, q, id); err != nil {
+		return errors.Wrap(errRollbackRollout, err)
+	}
+
+	for _, dbrev := range revs {
+		rev := toRevision(dbrev)
+		rev.RolloutID = id
+		rev.CreatedAt = at
+		if _, err = insertRevision(ctx, rev, tx); err != nil {
+			return errors.Wrap(errRollbackRollout, err)
+		}
+	}
+
+	q = `UPDATE rollouts SET status = $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.ExecContext(ctx, q, bootstrap.RolloutRolledBack, at, id); err != nil {
+		return errors.Wrap(errRollbackRollout, err)
+	}
+
+	return nil
+}
+
+func (cr configRepository) SaveAck(ctx context.Context, ack bootstrap.Ack) (rolloutID string, err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = cr.rollback("SaveAck method", err, tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = errors.Wrap(repoerr.ErrUpdateEntity, commitErr)
+		}
+	}()
+
+	q := `UPDATE configs SET ack_revision = $1, ack_status = $2, ack_message = $3, acked_at = $4,
+		  applied_revision = CASE WHEN $2 = $5 THEN $1 ELSE applied_revision END
+		  WHERE magistrala_client = $6`
+
+	res, err := tx.ExecContext(ctx, q, ack.Revision, ack.Status, ack.Message, ack.ReceivedAt, bootstrap.AckApplied, ack.ClientID)
+	if err != nil {
+		return "", errors.Wrap(errSaveAck, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return "", errors.Wrap(errSaveAck, err)
+	}
+	if cnt == 0 {
+		return "", repoerr.ErrNotFound
+	}
+
+	// Only the first acknowledgment of the rollout revision is counted.
+	q = `UPDATE rollout_targets SET status = $1 WHERE client_id = $2 AND revision = $3 AND status = ''
+		 RETURNING rollout_id`
+	if err = tx.QueryRowxContext(ctx, q, ack.Status, ack.ClientID, ack.Revision).Scan(&rolloutID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", errors.Wrap(errSaveAck, err)
+	}
+
+	return rolloutID, nil
+}
+
+// insertRevision sets the Config content and creates its next revision.
+func insertRevision(ctx context.Context, rev bootstrap.Revision, tx *sqlx.Tx) (uint64, error) {
+	q := `UPDATE configs SET content = $1, revision = revision + 1 WHERE magistrala_client = $2 AND domain_id = $3
+		  RETURNING revision`
+
+	var revision uint64
+	if err := tx.QueryRowxContext(ctx, q, nullString(rev.Content), rev.ClientID, rev.DomainID).Scan(&revision); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, repoerr.ErrNotFound
+		}
+		return 0, errors.Wrap(errSaveRevision, err)
+	}
+
+	q = `INSERT INTO config_revisions (client_id, domain_id, revision, content, rollout_id, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, q, rev.ClientID, rev.DomainID, revision, nullString(rev.Content), nullString(rev.RolloutID), rev.CreatedAt); err != nil {
+		return 0, errors.Wrap(errSaveRevision, err)
+	}
+
+	return revision, nil
+}
+
+type dbRevision struct {
+	ClientID  string         `db:"client_id"`
+	DomainID  string         `db:"domain_id"`
+	Revision  uint64         `db:"revision"`
+	Content   sql.NullString `db:"content"`
+	RolloutID sql.NullString `db:"rollout_id"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func toRevision(dbrev dbRevision) bootstrap.Revision {
+	return bootstrap.Revision{
+		ClientID:  dbrev.ClientID,
+		DomainID:  dbrev.DomainID,
+		Revision:  dbrev.Revision,
+		Content:   dbrev.Content.String,
+		RolloutID: dbrev.RolloutID.String,
+		CreatedAt: dbrev.CreatedAt,
+	}
+}
+
+type dbRollout struct {
+	ID               string                  `db:"id"`
+	DomainID         string                  `db:"domain_id"`
+	Content          sql.NullString          `db:"content"`
+	Tags             pgtype.TextArray        `db:"tags"`
+	Percentage       uint8                   `db:"percentage"`
+	FailureThreshold uint8                   `db:"failure_threshold"`
+	Status           bootstrap.RolloutStatus `db:"status"`
+	CreatedAt        time.Time               `db:"created_at"`
+	CreatedBy        sql.NullString          `db:"created_by"`
+	UpdatedAt        sql.NullTime            `db:"updated_at"`
+	Targets          uint64                  `db:"targets"`
+	Applied          uint64                  `db:"applied"`
+	Failed           uint64                  `db:"failed"`
+}
+
+func toDBRollout(ro bootstrap.Rollout) dbRollout {
+	return dbRollout{
+		ID:               ro.ID,
+		DomainID:         ro.DomainID,
+		Content:          nullString(ro.Content),
+		Tags:             toTextArray(ro.Tags),
+		Percentage:       ro.Percentage,
+		FailureThreshold: ro.FailureThreshold,
+		Status:           ro.Status,
+		CreatedAt:        ro.CreatedAt,
+		CreatedBy:        nullString(ro.CreatedBy),
+		UpdatedAt:        nullTime(ro.UpdatedAt),
+	}
+}
+
+func toRollout(dbro dbRollout) bootstrap.Rollout {
+	ro := bootstrap.Rollout{
+		ID:               dbro.ID,
+		DomainID:         dbro.DomainID,
+		Content:          dbro.Content.String,
+		Tags:             fromTextArray(dbro.Tags),
+		Percentage:       dbro.Percentage,
+		FailureThreshold: dbro.FailureThreshold,
+		Status:           dbro.Status,
+		CreatedAt:        dbro.CreatedAt,
+		CreatedBy:        dbro.CreatedBy.String,
+		Targets:          dbro.Targets,
+		Applied:          dbro.Applied,
+		Failed:           dbro.Failed,
+	}
+	if dbro.UpdatedAt.Valid {
+		ro.UpdatedAt = dbro.UpdatedAt.Time
+	}
+
+	return ro
+}
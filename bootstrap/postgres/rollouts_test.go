@@ -0,0 +1,452 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/bootstrap/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveConfig(t *testing.T, repo bootstrap.ConfigRepository, domainID string, tags []string) bootstrap.Config {
+	c := config
+	id := testsutil.GenerateUUID(t)
+	c.ClientID = id
+	c.ClientSecret = id
+	c.ExternalID = id
+	c.ExternalKey = id
+	c.DomainID = domainID
+	c.Channels = []bootstrap.Channel{}
+	c.Tags = tags
+	_, err := repo.Save(context.Background(), c, nil)
+	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
+
+	return c
+}
+
+func TestRetrieveRevisions(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := saveConfig(t, repo, config.DomainID, nil)
+	for _, content := range []string{"content 2", "content 3"} {
+		c.Content = content
+		err := repo.Update(context.Background(), c)
+		require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+	}
+	// Updating without content change does not create a revision.
+	c.Name = "updated name"
+	err = repo.Update(context.Background(), c)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc      string
+		domainID  string
+		clientID  string
+		offset    uint64
+		limit     uint64
+		total     uint64
+		revisions []uint64
+		err       error
+	}{
+		{
+			desc:      "retrieve all revisions",
+			domainID:  c.DomainID,
+			clientID:  c.ClientID,
+			offset:    0,
+			limit:     10,
+			total:     3,
+			revisions: []uint64{3, 2, 1},
+			err:       nil,
+		},
+		{
+			desc:      "retrieve a page of revisions",
+			domainID:  c.DomainID,
+			clientID:  c.ClientID,
+			offset:    1,
+			limit:     1,
+			total:     3,
+			revisions: []uint64{2},
+			err:       nil,
+		},
+		{
+			desc:     "retrieve revisions of a non-existing config",
+			domainID: c.DomainID,
+			clientID: testsutil.GenerateUUID(t),
+			offset:   0,
+			limit:    10,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve revisions from another domain",
+			domainID: testsutil.GenerateUUID(t),
+			clientID: c.ClientID,
+			offset:   0,
+			limit:    10,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		page, err := repo.RetrieveRevisions(context.Background(), tc.domainID, tc.clientID, tc.offset, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		var revisions []uint64
+		for _, rev := range page.Revisions {
+			revisions = append(revisions, rev.Revision)
+		}
+		assert.Equal(t, tc.revisions, revisions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.revisions, revisions))
+	}
+
+	cfg, err := repo.RetrieveByID(context.Background(), c.DomainID, c.ClientID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, uint64(3), cfg.Revision, fmt.Sprintf("expected revision 3 got %d\n", cfg.Revision))
+	assert.Equal(t, "content 3", cfg.Content, fmt.Sprintf("expected content 'content 3' got '%s'\n", cfg.Content))
+}
+
+func TestRetrieveRevision(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	c := saveConfig(t, repo, config.DomainID, nil)
+	initial := c.Content
+	c.Content = "updated content"
+	err = repo.Update(context.Background(), c)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc     string
+		clientID string
+		revision uint64
+		content  string
+		err      error
+	}{
+		{
+			desc:     "retrieve initial revision",
+			clientID: c.ClientID,
+			revision: 1,
+			content:  initial,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve latest revision",
+			clientID: c.ClientID,
+			revision: 2,
+			content:  c.Content,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve non-existing revision",
+			clientID: c.ClientID,
+			revision: 3,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		rev, err := repo.RetrieveRevision(context.Background(), c.DomainID, tc.clientID, tc.revision)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.content, rev.Content, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.content, rev.Content))
+	}
+}
+
+func TestRetrieveTargets(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	edge := saveConfig(t, repo, domainID, []string{"edge", "eu"})
+	gateway := saveConfig(t, repo, domainID, []string{"gateway", "eu"})
+	untagged := saveConfig(t, repo, domainID, nil)
+
+	cases := []struct {
+		desc string
+		tags []string
+		ids  []string
+	}{
+		{
+			desc: "retrieve all configs",
+			tags: nil,
+			ids:  []string{edge.ClientID, gateway.ClientID, untagged.ClientID},
+		},
+		{
+			desc: "retrieve configs with a tag",
+			tags: []string{"eu"},
+			ids:  []string{edge.ClientID, gateway.ClientID},
+		},
+		{
+			desc: "retrieve configs with all tags",
+			tags: []string{"eu", "edge"},
+			ids:  []string{edge.ClientID},
+		},
+		{
+			desc: "retrieve configs with unknown tag",
+			tags: []string{"unknown"},
+			ids:  []string{},
+		},
+	}
+	for _, tc := range cases {
+		ids, err := repo.RetrieveTargets(context.Background(), domainID, tc.tags)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.ElementsMatch(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+	}
+}
+
+func TestSaveRollout(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	c1 := saveConfig(t, repo, domainID, []string{"edge"})
+	c2 := saveConfig(t, repo, domainID, []string{"edge"})
+
+	ro := bootstrap.Rollout{
+		ID:               testsutil.GenerateUUID(t),
+		DomainID:         domainID,
+		Content:          "rollout content",
+		Tags:             []string{"edge"},
+		Percentage:       100,
+		FailureThreshold: 50,
+		Status:           bootstrap.RolloutInProgress,
+		CreatedAt:        time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy:        testsutil.GenerateUUID(t),
+	}
+
+	cases := []struct {
+		desc    string
+		rollout bootstrap.Rollout
+		targets []string
+		err     error
+	}{
+		{
+			desc:    "save a rollout",
+			rollout: ro,
+			targets: []string{c1.ClientID, c2.ClientID},
+			err:     nil,
+		},
+		{
+			desc:    "save a rollout with the same ID",
+			rollout: ro,
+			targets: []string{c1.ClientID},
+			err:     repoerr.ErrConflict,
+		},
+		{
+			desc: "save a rollout with non-existing target",
+			rollout: func() bootstrap.Rollout {
+				r := ro
+				r.ID = testsutil.GenerateUUID(t)
+				return r
+			}(),
+			targets: []string{testsutil.GenerateUUID(t)},
+			err:     repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		saved, err := repo.SaveRollout(context.Background(), tc.rollout, tc.targets)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, uint64(len(tc.targets)), saved.Targets, fmt.Sprintf("%s: expected %d targets got %d\n", tc.desc, len(tc.targets), saved.Targets))
+		}
+	}
+
+	retrieved, err := repo.RetrieveRollout(context.Background(), domainID, ro.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	ro.Targets = 2
+	assert.Equal(t, ro, retrieved, fmt.Sprintf("expected %v got %v\n", ro, retrieved))
+
+	for _, c := range []bootstrap.Config{c1, c2} {
+		cfg, err := repo.RetrieveByID(context.Background(), domainID, c.ClientID)
+		require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+		assert.Equal(t, ro.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", ro.Content, cfg.Content))
+		assert.Equal(t, uint64(2), cfg.Revision, fmt.Sprintf("expected revision 2 got %d\n", cfg.Revision))
+	}
+
+	_, err = repo.RetrieveRollout(context.Background(), testsutil.GenerateUUID(t), ro.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve rollout from another domain: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestRollbackRollout(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	unchanged := saveConfig(t, repo, domainID, nil)
+	changed := saveConfig(t, repo, domainID, nil)
+
+	ro := bootstrap.Rollout{
+		ID:         testsutil.GenerateUUID(t),
+		DomainID:   domainID,
+		Content:    "rollout content",
+		Percentage: 100,
+		Status:     bootstrap.RolloutInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	_, err = repo.SaveRollout(context.Background(), ro, []string{unchanged.ClientID, changed.ClientID})
+	require.Nil(t, err, fmt.Sprintf("Saving rollout expected to succeed: %s.\n", err))
+
+	// The config changed after the rollout keeps its content.
+	changed.Content = "manual content"
+	err = repo.Update(context.Background(), changed)
+	require.Nil(t, err, fmt.Sprintf("Updating config expected to succeed: %s.\n", err))
+
+	err = repo.RollbackRollout(context.Background(), ro.ID, time.Now().UTC())
+	assert.Nil(t, err, fmt.Sprintf("rolling back rollout: unexpected error %s\n", err))
+
+	cfg, err := repo.RetrieveByID(context.Background(), domainID, unchanged.ClientID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, unchanged.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", unchanged.Content, cfg.Content))
+	assert.Equal(t, uint64(3), cfg.Revision, fmt.Sprintf("expected revision 3 got %d\n", cfg.Revision))
+
+	cfg, err = repo.RetrieveByID(context.Background(), domainID, changed.ClientID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, changed.Content, cfg.Content, fmt.Sprintf("expected content %s got %s\n", changed.Content, cfg.Content))
+
+	retrieved, err := repo.RetrieveRollout(context.Background(), domainID, ro.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.RolloutRolledBack, retrieved.Status, fmt.Sprintf("expected status %s got %s\n", bootstrap.RolloutRolledBack, retrieved.Status))
+
+	// Rolling back again does not create new revisions.
+	err = repo.RollbackRollout(context.Background(), ro.ID, time.Now().UTC())
+	assert.Nil(t, err, fmt.Sprintf("rolling back rollout again: unexpected error %s\n", err))
+	cfg, err = repo.RetrieveByID(context.Background(), domainID, unchanged.ClientID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+	assert.Equal(t, uint64(3), cfg.Revision, fmt.Sprintf("expected revision 3 got %d\n", cfg.Revision))
+
+	err = repo.RollbackRollout(context.Background(), testsutil.GenerateUUID(t), time.Now().UTC())
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("rolling back non-existing rollout: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestSaveAck(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	c := saveConfig(t, repo, domainID, nil)
+
+	ro := bootstrap.Rollout{
+		ID:               testsutil.GenerateUUID(t),
+		DomainID:         domainID,
+		Content:          "rollout content",
+		Percentage:       100,
+		FailureThreshold: 100,
+		Status:           bootstrap.RolloutInProgress,
+		CreatedAt:        time.Now().UTC(),
+	}
+	_, err = repo.SaveRollout(context.Background(), ro, []string{c.ClientID})
+	require.Nil(t, err, fmt.Sprintf("Saving rollout expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc      string
+		ack       bootstrap.Ack
+		rolloutID string
+		applied   uint64
+		err       error
+	}{
+		{
+			desc:      "acknowledge initial revision",
+			ack:       bootstrap.Ack{ClientID: c.ClientID, Revision: 1, Status: bootstrap.AckApplied},
+			rolloutID: "",
+			applied:   1,
+			err:       nil,
+		},
+		{
+			desc:      "acknowledge failed rollout revision",
+			ack:       bootstrap.Ack{ClientID: c.ClientID, Revision: 2, Status: bootstrap.AckFailed, Message: "invalid content"},
+			rolloutID: ro.ID,
+			applied:   1,
+			err:       nil,
+		},
+		{
+			desc:      "acknowledge applied rollout revision after failure",
+			ack:       bootstrap.Ack{ClientID: c.ClientID, Revision: 2, Status: bootstrap.AckApplied},
+			rolloutID: "",
+			applied:   2,
+			err:       nil,
+		},
+		{
+			desc: "acknowledge revision of non-existing config",
+			ack:  bootstrap.Ack{ClientID: testsutil.GenerateUUID(t), Revision: 1, Status: bootstrap.AckApplied},
+			err:  repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		tc.ack.ReceivedAt = time.Now().UTC().Truncate(time.Microsecond)
+		rolloutID, err := repo.SaveAck(context.Background(), tc.ack)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rolloutID, rolloutID, fmt.Sprintf("%s: expected rollout %s got %s\n", tc.desc, tc.rolloutID, rolloutID))
+		if err != nil {
+			continue
+		}
+		cfg, err := repo.RetrieveByID(context.Background(), domainID, c.ClientID)
+		require.Nil(t, err, fmt.Sprintf("Retrieving config expected to succeed: %s.\n", err))
+		assert.Equal(t, tc.applied, cfg.AppliedRevision, fmt.Sprintf("%s: expected applied revision %d got %d\n", tc.desc, tc.applied, cfg.AppliedRevision))
+		assert.Equal(t, tc.ack, cfg.LastAck, fmt.Sprintf("%s: expected last ack %v got %v\n", tc.desc, tc.ack, cfg.LastAck))
+	}
+
+	retrieved, err := repo.RetrieveRollout(context.Background(), domainID, ro.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, uint64(1), retrieved.Failed, fmt.Sprintf("expected 1 failed target got %d\n", retrieved.Failed))
+	assert.Equal(t, uint64(0), retrieved.Applied, fmt.Sprintf("expected 0 applied targets got %d\n", retrieved.Applied))
+}
+
+func TestUpdateRolloutStatus(t *testing.T) {
+	repo := postgres.NewConfigRepository(db, testLog)
+	err := deleteChannels(context.Background(), repo)
+	require.Nil(t, err, "Channels cleanup expected to succeed.")
+
+	domainID := testsutil.GenerateUUID(t)
+	c := saveConfig(t, repo, domainID, nil)
+	ro := bootstrap.Rollout{
+		ID:         testsutil.GenerateUUID(t),
+		DomainID:   domainID,
+		Content:    "rollout content",
+		Percentage: 100,
+		Status:     bootstrap.RolloutInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	_, err = repo.SaveRollout(context.Background(), ro, []string{c.ClientID})
+	require.Nil(t, err, fmt.Sprintf("Saving rollout expected to succeed: %s.\n", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "complete an existing rollout",
+			id:   ro.ID,
+			err:  nil,
+		},
+		{
+			desc: "complete a non-existing rollout",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		err := repo.UpdateRolloutStatus(context.Background(), tc.id, bootstrap.RolloutCompleted, time.Now().UTC())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	retrieved, err := repo.RetrieveRollout(context.Background(), domainID, ro.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving rollout expected to succeed: %s.\n", err))
+	assert.Equal(t, bootstrap.RolloutCompleted, retrieved.Status, fmt.Sprintf("expected status %s got %s\n", bootstrap.RolloutCompleted, retrieved.Status))
+	assert.False(t, retrieved.UpdatedAt.IsZero(), "expected updated at to be set")
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/bootstrap"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jackc/pgtype"
+)
+
+var errUpdateTemplate = errors.New("failed to update bootstrap configuration template in database")
+
+func (cr configRepository) SaveTemplate(ctx context.Context, t bootstrap.Template) (bootstrap.Template, error) {
+	q := `INSERT INTO config_templates (id, domain_id, name, content, channels, created_at, created_by)
+		  VALUES (:id, :domain_id, :name, :content, :channels, :created_at, :created_by)`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBTemplate(t)); err != nil {
+		return bootstrap.Template{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return t, nil
+}
+
+func (cr configRepository) RetrieveTemplate(ctx context.Context, domainID, id string) (bootstrap.Template, error) {
+	q := `SELECT id, domain_id, name, content, channels, created_at, created_by, updated_at
+		  FROM config_templates WHERE id = $1 AND domain_id = $2`
+
+	var dbt dbTemplate
+	if err := cr.db.QueryRowxContext(ctx, q, id, domainID).StructScan(&dbt); err != nil {
+		if err == sql.ErrNoRows {
+			return bootstrap.Template{}, repoerr.ErrNotFound
+		}
+		return bootstrap.Template{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toTemplate(dbt), nil
+}
+
+func (cr configRepository) RetrieveAllTemplates(ctx context.Context, domainID string, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	q := `SELECT id, domain_id, name, content, channels, created_at, created_by, updated_at
+		  FROM config_templates WHERE domain_id = $1 ORDER BY name LIMIT $2 OFFSET $3`
+
+	rows, err := cr.db.QueryxContext(ctx, q, domainID, limit, offset)
+	if err != nil {
+		return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	templates := []bootstrap.Template{}
+	for rows.Next() {
+		var dbt dbTemplate
+		if err := rows.StructScan(&dbt); err != nil {
+			return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		templates = append(templates, toTemplate(dbt))
+	}
+
+	q = `SELECT COUNT(*) FROM config_templates WHERE domain_id = $1`
+
+	var total uint64
+	if err := cr.db.QueryRowxContext(ctx, q, domainID).Scan(&total); err != nil {
+		return bootstrap.TemplatesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return bootstrap.TemplatesPage{
+		Total:     total,
+		Offset:    offset,
+		Limit:     limit,
+		Templates: templates,
+	}, nil
+}
+
+func (cr configRepository) RetrieveByTemplate(ctx context.Context, domainID, templateID string) ([]bootstrap.Config, error) {
+	q := `SELECT magistrala_client, domain_id, content, template_id, variables
+		  FROM configs WHERE domain_id = $1 AND template_id = $2 ORDER BY magistrala_client`
+
+	rows, err := cr.db.QueryxContext(ctx, q, domainID, templateID)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	cfgs := []bootstrap.Config{}
+	for rows.Next() {
+		var dbcfg dbConfig
+		if err := rows.StructScan(&dbcfg); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		cfgs = append(cfgs, toConfig(dbcfg))
+	}
+
+	return cfgs, nil
+}
+
+func (cr configRepository) UpdateTemplate(ctx context.Context, t bootstrap.Template, cfgs []bootstrap.Config) (err error) {
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	defer func() {
+		if err != nil {
+			err = cr.rollback("UpdateTemplate method", err, tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = errors.Wrap(repoerr.ErrUpdateEntity, commitErr)
+		}
+	}()
+
+	q := `UPDATE config_templates SET name = $1, content = $2, channels = $3, updated_at = $4
+		  WHERE id = $5 AND domain_id = $6`
+
+	res, err := tx.ExecContext(ctx, q, t.Name, nullString(t.Content), toTextArray(t.Channels), t.UpdatedAt, t.ID, t.DomainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	for _, cfg := range cfgs {
+		rev := bootstrap.Revision{
+			ClientID:  cfg.ClientID,
+			DomainID:  t.DomainID,
+			Content:   cfg.Content,
+			CreatedAt: t.UpdatedAt,
+		}
+		if _, err = insertRevision(ctx, rev, tx); err != nil {
+			return errors.Wrap(errUpdateTemplate, err)
+		}
+	}
+
+	return nil
+}
+
+func (cr configRepository) RemoveTemplate(ctx context.Context, domainID, id string) error {
+	q := `DELETE FROM config_templates WHERE id = $1 AND domain_id = $2`
+
+	res, err := cr.db.ExecContext(ctx, q, id, domainID)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	if cnt == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbTemplate struct {
+	ID        string           `db:"id"`
+	DomainID  string           `db:"domain_id"`
+	Name      string           `db:"name"`
+	Content   sql.NullString   `db:"content"`
+	Channels  pgtype.TextArray `db:"channels"`
+	CreatedAt time.Time        `db:"created_at"`
+	CreatedBy sql.NullString   `db:"created_by"`
+	UpdatedAt sql.NullTime     `db:"updated_at"`
+}
+
+func toDBTemplate(t bootstrap.Template) dbTemplate {
+	return dbTemplate{
+		ID:        t.ID,
+		DomainID:  t.DomainID,
+		Name:      t.Name,
+		Content:   nullString(t.Content),
+		Channels:  toTextArray(t.Channels),
+		CreatedAt: t.CreatedAt,
+		CreatedBy: nullString(t.CreatedBy),
+		UpdatedAt: nullTime(t.UpdatedAt),
+	}
+}
+
+func toTemplate(dbt dbTemplate) bootstrap.Template {
+	t := bootstrap.Template{
+		ID:        dbt.ID,
+		DomainID:  dbt.DomainID,
+		Name:      dbt.Name,
+		Content:   dbt.Content.String,
+		Channels:  fromTextArray(dbt.Channels),
+		CreatedAt: dbt.CreatedAt,
+		CreatedBy: dbt.CreatedBy.String,
+	}
+	if dbt.UpdatedAt.Valid {
+		t.UpdatedAt = dbt.UpdatedAt.Time
+	}
+
+	return t
+}
@@ -18,11 +18,13 @@ import (
 type bootstrapRes struct {
 	ClientID     string       `json:"client_id"`
 	ClientSecret string       `json:"client_secret"`
+	DomainID     string       `json:"domain_id,omitempty"`
 	Channels     []channelRes `json:"channels"`
 	Content      string       `json:"content,omitempty"`
 	ClientCert   string       `json:"client_cert,omitempty"`
 	ClientKey    string       `json:"client_key,omitempty"`
 	CACert       string       `json:"ca_cert,omitempty"`
+	Revision     uint64       `json:"revision,omitempty"`
 }
 
 type channelRes struct {
@@ -62,11 +64,13 @@ func (r reader) ReadConfig(cfg Config, secure bool) (any, error) {
 	res := bootstrapRes{
 		ClientID:     cfg.ClientID,
 		ClientSecret: cfg.ClientSecret,
+		DomainID:     cfg.DomainID,
 		Channels:     channels,
 		Content:      cfg.Content,
 		ClientCert:   cfg.ClientCert,
 		ClientKey:    cfg.ClientKey,
 		CACert:       cfg.CACert,
+		Revision:     cfg.Revision,
 	}
 	if secure {
 		b, err := json.Marshal(res)
@@ -26,6 +26,7 @@ type readChan struct {
 type readResp struct {
 	ClientID     string     `json:"client_id"`
 	ClientSecret string     `json:"client_secret"`
+	DomainID     string     `json:"domain_id,omitempty"`
 	Channels     []readChan `json:"channels"`
 	Content      string     `json:"content,omitempty"`
 	ClientCert   string     `json:"client_cert,omitempty"`
@@ -55,6 +56,7 @@ func TestReadConfig(t *testing.T) {
 		ClientKey:    "client_key",
 		CACert:       "ca_cert",
 		ClientSecret: "smq_key",
+		DomainID:     "smq_domain",
 		Channels: []bootstrap.Channel{
 			{
 				ID:       "smq_id",
@@ -67,6 +69,7 @@ func TestReadConfig(t *testing.T) {
 	ret := readResp{
 		ClientID:     "smq_id",
 		ClientSecret: "smq_key",
+		DomainID:     "smq_domain",
 		Channels: []readChan{
 			{
 				ID:       "smq_id",
@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Revision represents a version of the Config content. Every content change
+// creates a new revision, so the history of the Config is kept and the
+// devices can report which revision they applied.
+type Revision struct {
+	ClientID  string    `json:"client_id"`
+	DomainID  string    `json:"domain_id"`
+	Revision  uint64    `json:"revision"`
+	Content   string    `json:"content,omitempty"`
+	RolloutID string    `json:"rollout_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevisionsPage contains page related metadata as well as list of Config
+// revisions, the latest first.
+type RevisionsPage struct {
+	Total     uint64     `json:"total"`
+	Offset    uint64     `json:"offset"`
+	Limit     uint64     `json:"limit"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// RevisionDiff represents the unified diff of the content of two revisions.
+type RevisionDiff struct {
+	ClientID string `json:"client_id"`
+	From     uint64 `json:"from"`
+	To       uint64 `json:"to"`
+	Diff     string `json:"diff"`
+}
+
+func diffRevisions(from, to Revision) (RevisionDiff, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(from.Content, "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(to.Content, "\n")),
+		FromFile: fmt.Sprintf("revision %d", from.Revision),
+		ToFile:   fmt.Sprintf("revision %d", to.Revision),
+		Context:  3,
+	})
+	if err != nil {
+		return RevisionDiff{}, err
+	}
+
+	return RevisionDiff{
+		ClientID: to.ClientID,
+		From:     from.Revision,
+		To:       to.Revision,
+		Diff:     diff,
+	}, nil
+}
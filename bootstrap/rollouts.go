@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"slices"
+	"time"
+)
+
+// RolloutStatus represents the status of the Rollout.
+type RolloutStatus string
+
+// Possible Rollout statuses.
+const (
+	RolloutInProgress RolloutStatus = "in_progress"
+	RolloutCompleted  RolloutStatus = "completed"
+	RolloutRolledBack RolloutStatus = "rolled_back"
+)
+
+// AckStatus represents the result of applying the Config revision reported
+// by the device.
+type AckStatus string
+
+// Possible acknowledgment statuses.
+const (
+	AckApplied AckStatus = "applied"
+	AckFailed  AckStatus = "failed"
+)
+
+// Rollout represents the staged change of the content of the domain Configs.
+// It targets the percentage of the Configs having all the tags, and creates a
+// new revision for each of them. When the share of the targeted devices that
+// failed to apply the revision reaches the failure threshold, the targeted
+// Configs are rolled back to their previous content. Zero failure threshold
+// disables the automatic rollback.
+type Rollout struct {
+	ID               string        `json:"id"`
+	DomainID         string        `json:"domain_id"`
+	Content          string        `json:"content"`
+	Tags             []string      `json:"tags,omitempty"`
+	Percentage       uint8         `json:"percentage"`
+	FailureThreshold uint8         `json:"failure_threshold"`
+	Status           RolloutStatus `json:"status"`
+	Targets          uint64        `json:"targets"`
+	Applied          uint64        `json:"applied"`
+	Failed           uint64        `json:"failed"`
+	CreatedAt        time.Time     `json:"created_at"`
+	CreatedBy        string        `json:"created_by,omitempty"`
+	UpdatedAt        time.Time     `json:"updated_at,omitzero"`
+}
+
+// Ack represents the device report of the Config revision it applied.
+type Ack struct {
+	ClientID   string    `json:"-"`
+	Revision   uint64    `json:"revision"`
+	Status     AckStatus `json:"status"`
+	Message    string    `json:"message,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// failed reports whether enough targets failed to roll the Rollout back.
+func (ro Rollout) failed() bool {
+	return ro.FailureThreshold > 0 && ro.Targets > 0 && ro.Failed*100 >= uint64(ro.FailureThreshold)*ro.Targets
+}
+
+// done reports whether all the targets acknowledged the Rollout revision.
+func (ro Rollout) done() bool {
+	return ro.Applied+ro.Failed >= ro.Targets
+}
+
+// selectTargets returns the percentage of the Configs targeted by the
+// Rollout. Configs are ordered by the hash of their ID and the Rollout ID,
+// so each Rollout picks a different, but reproducible, subset.
+func selectTargets(rolloutID string, clientIDs []string, percentage uint8) []string {
+	n := (len(clientIDs)*int(percentage) + 99) / 100
+	if n == 0 {
+		return nil
+	}
+
+	ids := slices.Clone(clientIDs)
+	hash := func(id string) [sha256.Size]byte {
+		return sha256.Sum256([]byte(rolloutID + id))
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		ha, hb := hash(a), hash(b)
+		return slices.Compare(ha[:], hb[:])
+	})
+
+	return ids[:n]
+}
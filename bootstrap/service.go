@@ -8,6 +8,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/hex"
+	"time"
 
 	"github.com/absmach/supermq"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -38,6 +39,30 @@ var (
 	// ErrBootstrapState indicates an invalid bootstrap state.
 	ErrBootstrapState = errors.NewRequestError("invalid bootstrap state")
 
+	// ErrEmptyRollout indicates that no Config matches the rollout.
+	ErrEmptyRollout = errors.NewRequestError("rollout does not target any bootstrap configuration")
+
+	// ErrInvalidRevision indicates that the acknowledged revision does not exist.
+	ErrInvalidRevision = errors.NewRequestError("invalid bootstrap configuration revision")
+
+	// ErrInvalidAckStatus indicates an invalid acknowledgment status.
+	ErrInvalidAckStatus = errors.NewRequestError("invalid acknowledgment status")
+
+	// ErrRolloutPercentage indicates the rollout percentage out of the 1-100 range.
+	ErrRolloutPercentage = errors.NewRequestError("rollout percentage must be between 1 and 100")
+
+	// ErrFailureThreshold indicates the rollout failure threshold greater than 100.
+	ErrFailureThreshold = errors.NewRequestError("rollout failure threshold must not be greater than 100")
+
+	// ErrInvalidTemplate indicates the Template content which is not a valid template.
+	ErrInvalidTemplate = errors.NewRequestError("invalid bootstrap configuration template")
+
+	// ErrRenderTemplate indicates failure to render the Config content from the Template.
+	ErrRenderTemplate = errors.NewRequestError("failed to render bootstrap configuration template")
+
+	// ErrBulkSize indicates too many Configs to be added in bulk at once.
+	ErrBulkSize = errors.NewRequestError("too many bootstrap configurations in bulk")
+
 	// ErrNotInSameDomain indicates entities are not in the same domain.
 	errNotInSameDomain = errors.New("entities are not in the same domain")
 
@@ -54,6 +79,12 @@ var (
 	errConnectionChannels = errors.New("failed to check channels connections")
 	errClientNotFound     = errors.New("failed to find client")
 	errUpdateCert         = errors.New("failed to update cert")
+	errCreateRollout      = errors.New("failed to create rollout")
+	errRollback           = errors.New("failed to roll back rollout")
+	errAcknowledge        = errors.New("failed to save acknowledgment")
+	errCreateTemplate     = errors.New("failed to create template")
+	errUpdateTemplate     = errors.New("failed to update template")
+	errRemoveTemplate     = errors.New("failed to remove template")
 )
 
 var _ Service = (*bootstrapService)(nil)
@@ -64,6 +95,11 @@ type Service interface {
 	// Add adds new Client Config to the user identified by the provided token.
 	Add(ctx context.Context, session smqauthn.Session, token string, cfg Config) (Config, error)
 
+	// BulkAdd adds the Client Configs one by one and returns the result of
+	// each. Every Config is added along with its Client and connections,
+	// or not at all, regardless of the others.
+	BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []Config) ([]BulkResult, error)
+
 	// View returns Client Config with given ID belonging to the user identified by the given token.
 	View(ctx context.Context, session smqauthn.Session, id string) (Config, error)
 
@@ -74,6 +110,11 @@ type Service interface {
 	// A non-nil error is returned to indicate operation failure.
 	UpdateCert(ctx context.Context, session smqauthn.Session, clientID, clientCert, clientKey, caCert string) (Config, error)
 
+	// EnrollCert links the certificate the Client enrolled for through the Certs
+	// service to the Config with provided external ID. It is called by the Certs
+	// service only, which is authenticated by the transport layer.
+	EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (Config, error)
+
 	// UpdateConnections updates list of Channels related to given Config.
 	UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error
 
@@ -90,6 +131,41 @@ type Service interface {
 	// ChangeState changes state of the Client with given client ID and domain ID.
 	ChangeState(ctx context.Context, session smqauthn.Session, token, id string, state State) error
 
+	// ListRevisions returns subset of the revisions of the Config with given ID, the latest first.
+	ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (RevisionsPage, error)
+
+	// DiffRevisions returns the difference between the content of two revisions of the Config with given ID.
+	DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (RevisionDiff, error)
+
+	// CreateRollout starts the rollout of the content to the domain Configs.
+	CreateRollout(ctx context.Context, session smqauthn.Session, ro Rollout) (Rollout, error)
+
+	// ViewRollout returns the Rollout with given ID.
+	ViewRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error)
+
+	// RollbackRollout restores the previous content of the Configs targeted by the Rollout with given ID.
+	RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error)
+
+	// Acknowledge saves the revision the Client with provided external ID applied,
+	// and rolls back the rollout of the revision if too many Clients failed to apply it.
+	Acknowledge(ctx context.Context, externalKey, externalID string, ack Ack) error
+
+	// CreateTemplate adds new Template to the domain.
+	CreateTemplate(ctx context.Context, session smqauthn.Session, t Template) (Template, error)
+
+	// ViewTemplate returns the Template with given ID.
+	ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (Template, error)
+
+	// ListTemplates returns subset of the domain Templates.
+	ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (TemplatesPage, error)
+
+	// UpdateTemplate updates the Template and renders again the content of
+	// the Configs instantiating it.
+	UpdateTemplate(ctx context.Context, session smqauthn.Session, t Template) (Template, error)
+
+	// RemoveTemplate removes the Template with given ID.
+	RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) error
+
 	// Methods RemoveConfig, UpdateChannel, and RemoveChannel are used as
 	// handlers for events. That's why these methods surpass ownership check.
 
@@ -137,6 +213,35 @@ func New(policyService policies.Service, configs ConfigRepository, sdk mgsdk.SDK
 }
 
 func (bs bootstrapService) Add(ctx context.Context, session smqauthn.Session, token string, cfg Config) (Config, error) {
+	cfg, err := bs.instantiate(ctx, session.DomainID, cfg, map[string]Template{})
+	if err != nil {
+		return Config{}, err
+	}
+
+	return bs.add(ctx, session, token, cfg)
+}
+
+func (bs bootstrapService) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []Config) ([]BulkResult, error) {
+	templates := map[string]Template{}
+	results := make([]BulkResult, len(cfgs))
+	for i, cfg := range cfgs {
+		results[i].ExternalID = cfg.ExternalID
+
+		cfg, err := bs.instantiate(ctx, session.DomainID, cfg, templates)
+		if err == nil {
+			cfg, err = bs.add(ctx, session, token, cfg)
+		}
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Config = cfg
+	}
+
+	return results, nil
+}
+
+func (bs bootstrapService) add(ctx context.Context, session smqauthn.Session, token string, cfg Config) (Config, error) {
 	toConnect := bs.toIDList(cfg.Channels)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
@@ -209,6 +314,21 @@ func (bs bootstrapService) UpdateCert(ctx context.Context, session smqauthn.Sess
 	return cfg, nil
 }
 
+func (bs bootstrapService) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (Config, error) {
+	cfg, err := bs.configs.RetrieveByExternalID(ctx, externalID)
+	if err != nil {
+		return Config{}, errors.Wrap(errUpdateCert, err)
+	}
+
+	// The private key of the enrolled certificate never leaves the Client.
+	cfg, err = bs.configs.UpdateCert(ctx, cfg.DomainID, cfg.ClientID, clientCert, "", caCert)
+	if err != nil {
+		return Config{}, errors.Wrap(errUpdateCert, err)
+	}
+
+	return cfg, nil
+}
+
 func (bs bootstrapService) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	cfg, err := bs.configs.RetrieveByID(ctx, session.DomainID, id)
 	if err != nil {
@@ -360,6 +480,252 @@ func (bs bootstrapService) ChangeState(ctx context.Context, session smqauthn.Ses
 	return nil
 }
 
+func (bs bootstrapService) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (RevisionsPage, error) {
+	page, err := bs.configs.RetrieveRevisions(ctx, session.DomainID, id, offset, limit)
+	if err != nil {
+		return RevisionsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (bs bootstrapService) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (RevisionDiff, error) {
+	fromRev, err := bs.configs.RetrieveRevision(ctx, session.DomainID, id, from)
+	if err != nil {
+		return RevisionDiff{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	toRev, err := bs.configs.RetrieveRevision(ctx, session.DomainID, id, to)
+	if err != nil {
+		return RevisionDiff{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	diff, err := diffRevisions(fromRev, toRev)
+	if err != nil {
+		return RevisionDiff{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return diff, nil
+}
+
+func (bs bootstrapService) CreateRollout(ctx context.Context, session smqauthn.Session, ro Rollout) (Rollout, error) {
+	ids, err := bs.configs.RetrieveTargets(ctx, session.DomainID, ro.Tags)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errCreateRollout, err)
+	}
+
+	if ro.ID, err = bs.idProvider.ID(); err != nil {
+		return Rollout{}, errors.Wrap(errCreateRollout, err)
+	}
+	targets := selectTargets(ro.ID, ids, ro.Percentage)
+	if len(targets) == 0 {
+		return Rollout{}, ErrEmptyRollout
+	}
+
+	ro.DomainID = session.DomainID
+	ro.Status = RolloutInProgress
+	ro.CreatedAt = time.Now().UTC()
+	ro.CreatedBy = session.UserID
+
+	saved, err := bs.configs.SaveRollout(ctx, ro, targets)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errCreateRollout, err)
+	}
+
+	return saved, nil
+}
+
+func (bs bootstrapService) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error) {
+	ro, err := bs.configs.RetrieveRollout(ctx, session.DomainID, id)
+	if err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return ro, nil
+}
+
+func (bs bootstrapService) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (Rollout, error) {
+	ro, err := bs.configs.RetrieveRollout(ctx, session.DomainID, id)
+	if err != nil {
+		return Rollout{}, errors.Wrap(errRollback, err)
+	}
+	if ro.Status == RolloutRolledBack {
+		return ro, nil
+	}
+	if err := bs.configs.RollbackRollout(ctx, id, time.Now().UTC()); err != nil {
+		return Rollout{}, errors.Wrap(errRollback, err)
+	}
+
+	return bs.ViewRollout(ctx, session, id)
+}
+
+func (bs bootstrapService) Acknowledge(ctx context.Context, externalKey, externalID string, ack Ack) error {
+	cfg, err := bs.Bootstrap(ctx, externalKey, externalID, false)
+	if err != nil {
+		return err
+	}
+	if ack.Revision == 0 || ack.Revision > cfg.Revision {
+		return ErrInvalidRevision
+	}
+
+	ack.ClientID = cfg.ClientID
+	ack.ReceivedAt = time.Now().UTC()
+	rolloutID, err := bs.configs.SaveAck(ctx, ack)
+	if err != nil {
+		return errors.Wrap(errAcknowledge, err)
+	}
+	if rolloutID == "" {
+		return nil
+	}
+
+	return bs.checkRollout(ctx, cfg.DomainID, rolloutID)
+}
+
+// checkRollout rolls the Rollout back once the failure threshold is reached,
+// or completes it when all the targets acknowledged the Rollout revision.
+func (bs bootstrapService) checkRollout(ctx context.Context, domainID, id string) error {
+	ro, err := bs.configs.RetrieveRollout(ctx, domainID, id)
+	if err != nil {
+		return errors.Wrap(errAcknowledge, err)
+	}
+	if ro.Status != RolloutInProgress {
+		return nil
+	}
+
+	switch {
+	case ro.failed():
+		if err := bs.configs.RollbackRollout(ctx, id, time.Now().UTC()); err != nil {
+			return errors.Wrap(errRollback, err)
+		}
+	case ro.done():
+		if err := bs.configs.UpdateRolloutStatus(ctx, id, RolloutCompleted, time.Now().UTC()); err != nil {
+			return errors.Wrap(errAcknowledge, err)
+		}
+	}
+
+	return nil
+}
+
+func (bs bootstrapService) CreateTemplate(ctx context.Context, session smqauthn.Session, t Template) (Template, error) {
+	if _, err := t.parse(); err != nil {
+		return Template{}, errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	id, err := bs.idProvider.ID()
+	if err != nil {
+		return Template{}, errors.Wrap(errCreateTemplate, err)
+	}
+	t.ID = id
+	t.DomainID = session.DomainID
+	t.CreatedAt = time.Now().UTC()
+	t.CreatedBy = session.UserID
+	t.UpdatedAt = time.Time{}
+
+	saved, err := bs.configs.SaveTemplate(ctx, t)
+	if err != nil {
+		return Template{}, errors.Wrap(errCreateTemplate, err)
+	}
+
+	return saved, nil
+}
+
+func (bs bootstrapService) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (Template, error) {
+	t, err := bs.configs.RetrieveTemplate(ctx, session.DomainID, id)
+	if err != nil {
+		return Template{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return t, nil
+}
+
+func (bs bootstrapService) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (TemplatesPage, error) {
+	page, err := bs.configs.RetrieveAllTemplates(ctx, session.DomainID, offset, limit)
+	if err != nil {
+		return TemplatesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (bs bootstrapService) UpdateTemplate(ctx context.Context, session smqauthn.Session, t Template) (Template, error) {
+	if _, err := t.parse(); err != nil {
+		return Template{}, errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	saved, err := bs.configs.RetrieveTemplate(ctx, session.DomainID, t.ID)
+	if err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+	saved.Name = t.Name
+	saved.Content = t.Content
+	saved.Channels = t.Channels
+	saved.UpdatedAt = time.Now().UTC()
+
+	cfgs, err := bs.configs.RetrieveByTemplate(ctx, session.DomainID, t.ID)
+	if err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+
+	// Render all the Configs first, so the Template is not updated
+	// unless the new content suits every one of them.
+	var changed []Config
+	for _, cfg := range cfgs {
+		content, err := saved.render(cfg.Variables)
+		if err != nil {
+			return Template{}, errors.Wrap(ErrRenderTemplate, err)
+		}
+		if content == cfg.Content {
+			continue
+		}
+		cfg.Content = content
+		changed = append(changed, cfg)
+	}
+
+	if err := bs.configs.UpdateTemplate(ctx, saved, changed); err != nil {
+		return Template{}, errors.Wrap(errUpdateTemplate, err)
+	}
+
+	return saved, nil
+}
+
+func (bs bootstrapService) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := bs.configs.RemoveTemplate(ctx, session.DomainID, id); err != nil {
+		return errors.Wrap(errRemoveTemplate, err)
+	}
+
+	return nil
+}
+
+// instantiate renders the content of the Config from its Template, and sets
+// the Template Channels unless the Config has its own. Retrieved Templates
+// are cached, so each one is retrieved only once when adding Configs in bulk.
+func (bs bootstrapService) instantiate(ctx context.Context, domainID string, cfg Config, templates map[string]Template) (Config, error) {
+	if cfg.TemplateID == "" {
+		return cfg, nil
+	}
+
+	t, ok := templates[cfg.TemplateID]
+	if !ok {
+		var err error
+		if t, err = bs.configs.RetrieveTemplate(ctx, domainID, cfg.TemplateID); err != nil {
+			return Config{}, errors.Wrap(ErrAddBootstrap, err)
+		}
+		templates[cfg.TemplateID] = t
+	}
+
+	content, err := t.render(cfg.Variables)
+	if err != nil {
+		return Config{}, errors.Wrap(ErrRenderTemplate, err)
+	}
+	cfg.Content = content
+
+	if len(cfg.Channels) == 0 {
+		for _, id := range t.Channels {
+			cfg.Channels = append(cfg.Channels, Channel{ID: id})
+		}
+	}
+
+	return cfg, nil
+}
+
 func (bs bootstrapService) UpdateChannelHandler(ctx context.Context, channel Channel) error {
 	if err := bs.configs.UpdateChannel(ctx, channel); err != nil {
 		return errors.Wrap(errUpdateChannel, err)
@@ -366,7 +366,7 @@ func TestUpdateCert(t *testing.T) {
 			sort.Slice(tc.expectedConfig.Channels, func(i, j int) bool {
 				return tc.expectedConfig.Channels[i].ID < tc.expectedConfig.Channels[j].ID
 			})
-			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expectedConfig, cfg))
+			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
 			repoCall.Unset()
 		})
 	}
@@ -1111,3 +1111,803 @@ func TestDisconnectClientsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestListRevisions(t *testing.T) {
+	svc := newService()
+
+	revs := []bootstrap.Revision{
+		{ClientID: config.ClientID, DomainID: domainID, Revision: 2, Content: "config update"},
+		{ClientID: config.ClientID, DomainID: domainID, Revision: 1, Content: config.Content},
+	}
+
+	cases := []struct {
+		desc        string
+		id          string
+		page        bootstrap.RevisionsPage
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc: "list revisions of an existing config",
+			id:   config.ClientID,
+			page: bootstrap.RevisionsPage{Total: 2, Offset: 0, Limit: 10, Revisions: revs},
+			err:  nil,
+		},
+		{
+			desc:        "list revisions of a non-existing config",
+			id:          unknown,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveRevisions", context.Background(), domainID, tc.id, uint64(0), uint64(10)).Return(tc.page, tc.retrieveErr)
+			page, err := svc.ListRevisions(context.Background(), session, tc.id, 0, 10)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	svc := newService()
+
+	from := bootstrap.Revision{ClientID: config.ClientID, DomainID: domainID, Revision: 1, Content: "a\nb\n"}
+	to := bootstrap.Revision{ClientID: config.ClientID, DomainID: domainID, Revision: 2, Content: "a\nc\n"}
+
+	cases := []struct {
+		desc    string
+		from    uint64
+		to      uint64
+		fromErr error
+		toErr   error
+		diff    string
+		err     error
+	}{
+		{
+			desc: "diff existing revisions",
+			from: 1,
+			to:   2,
+			diff: "--- revision 1\n+++ revision 2\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+			err:  nil,
+		},
+		{
+			desc:    "diff with non-existing from revision",
+			from:    5,
+			to:      2,
+			fromErr: svcerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:  "diff with non-existing to revision",
+			from:  1,
+			to:    5,
+			toErr: svcerr.ErrNotFound,
+			err:   svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			fromCall := boot.On("RetrieveRevision", context.Background(), domainID, config.ClientID, tc.from).Return(from, tc.fromErr)
+			toCall := boot.On("RetrieveRevision", context.Background(), domainID, config.ClientID, tc.to).Return(to, tc.toErr)
+			diff, err := svc.DiffRevisions(context.Background(), session, config.ClientID, tc.from, tc.to)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.diff, diff.Diff, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.diff, diff.Diff))
+			fromCall.Unset()
+			toCall.Unset()
+		})
+	}
+}
+
+func TestCreateRollout(t *testing.T) {
+	svc := newService()
+
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = testsutil.GenerateUUID(t)
+	}
+
+	cases := []struct {
+		desc        string
+		rollout     bootstrap.Rollout
+		targets     []string
+		retrieveErr error
+		saveErr     error
+		selected    int
+		err         error
+	}{
+		{
+			desc:     "create rollout to all configs",
+			rollout:  bootstrap.Rollout{Content: "new config", Percentage: 100},
+			targets:  ids,
+			selected: 10,
+			err:      nil,
+		},
+		{
+			desc:     "create rollout to a part of configs",
+			rollout:  bootstrap.Rollout{Content: "new config", Tags: []string{"edge"}, Percentage: 25, FailureThreshold: 50},
+			targets:  ids,
+			selected: 3,
+			err:      nil,
+		},
+		{
+			desc:    "create rollout without targets",
+			rollout: bootstrap.Rollout{Content: "new config", Tags: []string{"unknown"}, Percentage: 100},
+			targets: []string{},
+			err:     bootstrap.ErrEmptyRollout,
+		},
+		{
+			desc:        "create rollout with failed to retrieve targets",
+			rollout:     bootstrap.Rollout{Content: "new config", Percentage: 100},
+			retrieveErr: svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "create rollout with failed to save",
+			rollout:  bootstrap.Rollout{Content: "new config", Percentage: 100},
+			targets:  ids,
+			selected: 10,
+			saveErr:  svcerr.ErrCreateEntity,
+			err:      svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveTargets", context.Background(), domainID, tc.rollout.Tags).Return(tc.targets, tc.retrieveErr)
+			repoCall1 := boot.On("SaveRollout", context.Background(), mock.Anything, mock.Anything).Return(func(_ context.Context, ro bootstrap.Rollout, clientIDs []string) (bootstrap.Rollout, error) {
+				ro.Targets = uint64(len(clientIDs))
+				return ro, tc.saveErr
+			})
+			ro, err := svc.CreateRollout(context.Background(), session, tc.rollout)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, uint64(tc.selected), ro.Targets, fmt.Sprintf("%s: expected %d targets got %d\n", tc.desc, tc.selected, ro.Targets))
+				assert.Equal(t, bootstrap.RolloutInProgress, ro.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, bootstrap.RolloutInProgress, ro.Status))
+				assert.Equal(t, domainID, ro.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, ro.DomainID))
+				assert.NotEmpty(t, ro.ID, fmt.Sprintf("%s: expected rollout ID to be set\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestViewRollout(t *testing.T) {
+	svc := newService()
+
+	ro := bootstrap.Rollout{
+		ID:         testsutil.GenerateUUID(t),
+		DomainID:   domainID,
+		Content:    "new config",
+		Percentage: 100,
+		Status:     bootstrap.RolloutInProgress,
+		Targets:    3,
+	}
+
+	cases := []struct {
+		desc        string
+		id          string
+		rollout     bootstrap.Rollout
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc:    "view an existing rollout",
+			id:      ro.ID,
+			rollout: ro,
+			err:     nil,
+		},
+		{
+			desc:        "view a non-existing rollout",
+			id:          unknown,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveRollout", context.Background(), domainID, tc.id).Return(tc.rollout, tc.retrieveErr)
+			rollout, err := svc.ViewRollout(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.rollout, rollout, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rollout, rollout))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRollbackRollout(t *testing.T) {
+	svc := newService()
+
+	ro := bootstrap.Rollout{
+		ID:         testsutil.GenerateUUID(t),
+		DomainID:   domainID,
+		Content:    "new config",
+		Percentage: 100,
+		Status:     bootstrap.RolloutInProgress,
+		Targets:    3,
+	}
+	rolledBack := ro
+	rolledBack.Status = bootstrap.RolloutRolledBack
+
+	cases := []struct {
+		desc        string
+		rollout     bootstrap.Rollout
+		retrieveErr error
+		rollbackErr error
+		rollback    bool
+		err         error
+	}{
+		{
+			desc:     "roll back an in progress rollout",
+			rollout:  ro,
+			rollback: true,
+			err:      nil,
+		},
+		{
+			desc:    "roll back a rolled back rollout",
+			rollout: rolledBack,
+			err:     nil,
+		},
+		{
+			desc:        "roll back a non-existing rollout",
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "roll back with failed to roll back",
+			rollout:     ro,
+			rollback:    true,
+			rollbackErr: svcerr.ErrUpdateEntity,
+			err:         svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			retrieved := false
+			repoCall := boot.On("RetrieveRollout", context.Background(), domainID, ro.ID).Return(func(context.Context, string, string) (bootstrap.Rollout, error) {
+				if retrieved {
+					return rolledBack, nil
+				}
+				retrieved = true
+				return tc.rollout, tc.retrieveErr
+			})
+			repoCall1 := boot.On("RollbackRollout", context.Background(), ro.ID, mock.Anything).Return(tc.rollbackErr)
+			rollout, err := svc.RollbackRollout(context.Background(), session, ro.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, bootstrap.RolloutRolledBack, rollout.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, bootstrap.RolloutRolledBack, rollout.Status))
+			}
+			if tc.rollback {
+				boot.AssertCalled(t, "RollbackRollout", context.Background(), ro.ID, mock.Anything)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestAcknowledge(t *testing.T) {
+	c := config
+	c.DomainID = domainID
+	c.Revision = 2
+	rolloutID := testsutil.GenerateUUID(t)
+
+	inProgress := bootstrap.Rollout{ID: rolloutID, DomainID: domainID, Status: bootstrap.RolloutInProgress, Targets: 4, FailureThreshold: 50}
+	pending := inProgress
+	pending.Applied = 1
+	failed := inProgress
+	failed.Failed = 2
+	done := inProgress
+	done.Applied = 3
+	done.Failed = 1
+
+	cases := []struct {
+		desc        string
+		externalKey string
+		ack         bootstrap.Ack
+		rolloutID   string
+		rollout     bootstrap.Rollout
+		saveErr     error
+		rollback    bool
+		complete    bool
+		err         error
+	}{
+		{
+			desc:        "acknowledge applied revision",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckApplied},
+			err:         nil,
+		},
+		{
+			desc:        "acknowledge with invalid external key",
+			externalKey: "invalid",
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckApplied},
+			err:         bootstrap.ErrExternalKey,
+		},
+		{
+			desc:        "acknowledge non-existing revision",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 3, Status: bootstrap.AckApplied},
+			err:         bootstrap.ErrInvalidRevision,
+		},
+		{
+			desc:        "acknowledge with failed to save",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckApplied},
+			saveErr:     svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:        "acknowledge rollout revision",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckApplied},
+			rolloutID:   rolloutID,
+			rollout:     pending,
+			err:         nil,
+		},
+		{
+			desc:        "acknowledge failed rollout revision reaching the threshold",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckFailed, Message: "invalid config"},
+			rolloutID:   rolloutID,
+			rollout:     failed,
+			rollback:    true,
+			err:         nil,
+		},
+		{
+			desc:        "acknowledge last rollout revision",
+			externalKey: c.ExternalKey,
+			ack:         bootstrap.Ack{Revision: 2, Status: bootstrap.AckApplied},
+			rolloutID:   rolloutID,
+			rollout:     done,
+			complete:    true,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			repoCall := boot.On("RetrieveByExternalID", context.Background(), c.ExternalID).Return(c, nil)
+			repoCall1 := boot.On("SaveAck", context.Background(), mock.Anything).Return(tc.rolloutID, tc.saveErr)
+			repoCall2 := boot.On("RetrieveRollout", context.Background(), domainID, rolloutID).Return(tc.rollout, nil)
+			repoCall3 := boot.On("RollbackRollout", context.Background(), rolloutID, mock.Anything).Return(nil)
+			repoCall4 := boot.On("UpdateRolloutStatus", context.Background(), rolloutID, bootstrap.RolloutCompleted, mock.Anything).Return(nil)
+			err := svc.Acknowledge(context.Background(), tc.externalKey, c.ExternalID, tc.ack)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.rollback {
+				boot.AssertCalled(t, "RollbackRollout", context.Background(), rolloutID, mock.Anything)
+			} else {
+				boot.AssertNotCalled(t, "RollbackRollout", context.Background(), rolloutID, mock.Anything)
+			}
+			if tc.complete {
+				boot.AssertCalled(t, "UpdateRolloutStatus", context.Background(), rolloutID, bootstrap.RolloutCompleted, mock.Anything)
+			} else {
+				boot.AssertNotCalled(t, "UpdateRolloutStatus", context.Background(), rolloutID, bootstrap.RolloutCompleted, mock.Anything)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+		})
+	}
+}
+
+func TestEnrollCert(t *testing.T) {
+	c := config
+	c.DomainID = domainID
+
+	enrolled := c
+	enrolled.ClientCert = "newCert"
+	enrolled.ClientKey = ""
+	enrolled.CACert = "newCA"
+
+	cases := []struct {
+		desc           string
+		externalID     string
+		retrieveErr    error
+		updateErr      error
+		expectedConfig bootstrap.Config
+		err            error
+	}{
+		{
+			desc:           "enroll cert for the valid config",
+			externalID:     c.ExternalID,
+			expectedConfig: enrolled,
+			err:            nil,
+		},
+		{
+			desc:        "enroll cert for a non-existing config",
+			externalID:  unknown,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:       "enroll cert with failed to update",
+			externalID: c.ExternalID,
+			updateErr:  svcerr.ErrUpdateEntity,
+			err:        svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			repoCall := boot.On("RetrieveByExternalID", context.Background(), tc.externalID).Return(c, tc.retrieveErr)
+			repoCall1 := boot.On("UpdateCert", context.Background(), c.DomainID, c.ClientID, "newCert", "", "newCA").Return(tc.expectedConfig, tc.updateErr)
+			cfg, err := svc.EnrollCert(context.Background(), tc.externalID, "newCert", "newCA")
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.expectedConfig, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expectedConfig, cfg))
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestBulkAdd(t *testing.T) {
+	tmpl := bootstrap.Template{
+		ID:       testsutil.GenerateUUID(t),
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  `{"serial": "{{.serial}}"}`,
+		Channels: []string{channel.ID},
+	}
+
+	newConfig := func(tmplID string, vars map[string]string) bootstrap.Config {
+		c := config
+		c.ExternalID = testsutil.GenerateUUID(t)
+		c.TemplateID = tmplID
+		c.Variables = vars
+		if tmplID != "" {
+			c.Content = ""
+			c.Channels = nil
+		}
+		return c
+	}
+
+	plain := newConfig("", nil)
+	templated := newConfig(tmpl.ID, map[string]string{"serial": "SN-1"})
+	noVars := newConfig(tmpl.ID, nil)
+	unknownTmpl := newConfig(unknown, nil)
+	failing := newConfig("", nil)
+
+	cases := []struct {
+		desc     string
+		configs  []bootstrap.Config
+		saveErrs map[string]error
+		errs     []error
+		contents []string
+	}{
+		{
+			desc:     "bulk add configs",
+			configs:  []bootstrap.Config{plain, templated},
+			errs:     []error{nil, nil},
+			contents: []string{plain.Content, `{"serial": "SN-1"}`},
+		},
+		{
+			desc:     "bulk add configs with missing template variable",
+			configs:  []bootstrap.Config{templated, noVars},
+			errs:     []error{nil, bootstrap.ErrRenderTemplate},
+			contents: []string{`{"serial": "SN-1"}`, ""},
+		},
+		{
+			desc:     "bulk add configs with non-existing template",
+			configs:  []bootstrap.Config{unknownTmpl, plain},
+			errs:     []error{svcerr.ErrNotFound, nil},
+			contents: []string{"", plain.Content},
+		},
+		{
+			desc:     "bulk add configs with failed to save",
+			configs:  []bootstrap.Config{failing, plain},
+			saveErrs: map[string]error{failing.ExternalID: svcerr.ErrConflict},
+			errs:     []error{bootstrap.ErrAddBootstrap, nil},
+			contents: []string{"", plain.Content},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveTemplate", context.Background(), domainID, tmpl.ID).Return(tmpl, nil)
+			repoCall1 := boot.On("RetrieveTemplate", context.Background(), domainID, unknown).Return(bootstrap.Template{}, svcerr.ErrNotFound)
+			repoCall2 := sdk.On("Client", mock.Anything, config.ClientID, domainID, validToken).Return(mgsdk.Client{ID: config.ClientID, Credentials: mgsdk.ClientCredentials{Secret: config.ClientSecret}}, nil)
+			repoCall3 := sdk.On("DeleteClient", mock.Anything, mock.Anything, domainID, validToken).Return(nil)
+			repoCall4 := boot.On("ListExisting", context.Background(), domainID, mock.Anything).Return([]bootstrap.Channel{channel}, nil)
+			repoCall5 := boot.On("Save", context.Background(), mock.Anything, mock.Anything).Return(func(_ context.Context, cfg bootstrap.Config, _ []string) (string, error) {
+				return cfg.ClientID, tc.saveErrs[cfg.ExternalID]
+			})
+			results, err := svc.BulkAdd(context.Background(), session, validToken, tc.configs)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+			assert.Len(t, results, len(tc.configs), fmt.Sprintf("%s: expected %d results got %d\n", tc.desc, len(tc.configs), len(results)))
+			for i, res := range results {
+				assert.Equal(t, tc.configs[i].ExternalID, res.ExternalID, fmt.Sprintf("%s: expected external ID %s got %s\n", tc.desc, tc.configs[i].ExternalID, res.ExternalID))
+				assert.True(t, errors.Contains(res.Err, tc.errs[i]), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.errs[i], res.Err))
+				assert.Equal(t, tc.contents[i], res.Config.Content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, tc.contents[i], res.Config.Content))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+		})
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		template bootstrap.Template
+		saveErr  error
+		err      error
+	}{
+		{
+			desc:     "create template",
+			template: bootstrap.Template{Name: "sensor", Content: `{"serial": "{{.serial}}"}`},
+			err:      nil,
+		},
+		{
+			desc:     "create template with invalid content",
+			template: bootstrap.Template{Name: "sensor", Content: `{"serial": "{{.serial"}`},
+			err:      bootstrap.ErrInvalidTemplate,
+		},
+		{
+			desc:     "create template with existing name",
+			template: bootstrap.Template{Name: "sensor", Content: "config"},
+			saveErr:  svcerr.ErrConflict,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("SaveTemplate", context.Background(), mock.Anything).Return(func(_ context.Context, t bootstrap.Template) (bootstrap.Template, error) {
+				return t, tc.saveErr
+			})
+			saved, err := svc.CreateTemplate(context.Background(), session, tc.template)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected template ID to be set\n", tc.desc))
+				assert.Equal(t, domainID, saved.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, domainID, saved.DomainID))
+				assert.Equal(t, validID, saved.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s\n", tc.desc, validID, saved.CreatedBy))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewTemplate(t *testing.T) {
+	svc := newService()
+
+	tmpl := bootstrap.Template{
+		ID:       testsutil.GenerateUUID(t),
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  "config",
+	}
+
+	cases := []struct {
+		desc        string
+		id          string
+		template    bootstrap.Template
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc:     "view an existing template",
+			id:       tmpl.ID,
+			template: tmpl,
+			err:      nil,
+		},
+		{
+			desc:        "view a non-existing template",
+			id:          unknown,
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveTemplate", context.Background(), domainID, tc.id).Return(tc.template, tc.retrieveErr)
+			template, err := svc.ViewTemplate(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.template, template, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.template, template))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	svc := newService()
+
+	templates := []bootstrap.Template{
+		{ID: testsutil.GenerateUUID(t), DomainID: domainID, Name: "gateway", Content: "config"},
+		{ID: testsutil.GenerateUUID(t), DomainID: domainID, Name: "sensor", Content: "config"},
+	}
+
+	cases := []struct {
+		desc        string
+		offset      uint64
+		limit       uint64
+		page        bootstrap.TemplatesPage
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc:   "list templates",
+			offset: 0,
+			limit:  10,
+			page:   bootstrap.TemplatesPage{Total: 2, Limit: 10, Templates: templates},
+			err:    nil,
+		},
+		{
+			desc:        "list templates with failed to retrieve",
+			offset:      0,
+			limit:       10,
+			retrieveErr: svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RetrieveAllTemplates", context.Background(), domainID, tc.offset, tc.limit).Return(tc.page, tc.retrieveErr)
+			page, err := svc.ListTemplates(context.Background(), session, tc.offset, tc.limit)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	tmpl := bootstrap.Template{
+		ID:       testsutil.GenerateUUID(t),
+		DomainID: domainID,
+		Name:     "sensor",
+		Content:  `{"serial": "{{.serial}}"}`,
+	}
+
+	cfgs := []bootstrap.Config{
+		{ClientID: testsutil.GenerateUUID(t), Content: `{"serial": "SN-1"}`, Variables: map[string]string{"serial": "SN-1", "mode": "eco"}},
+		{ClientID: testsutil.GenerateUUID(t), Content: `{"serial": "SN-2"}`, Variables: map[string]string{"serial": "SN-2", "mode": "eco"}},
+	}
+	partial := []bootstrap.Config{
+		cfgs[0],
+		{ClientID: testsutil.GenerateUUID(t), Content: `{"serial": "SN-3"}`, Variables: map[string]string{"serial": "SN-3"}},
+	}
+
+	cases := []struct {
+		desc        string
+		template    bootstrap.Template
+		configs     []bootstrap.Config
+		retrieveErr error
+		updateErr   error
+		updated     int
+		err         error
+	}{
+		{
+			desc:     "update template content",
+			template: bootstrap.Template{ID: tmpl.ID, Name: "sensor", Content: `{"serial": "{{.serial}}", "mode": "{{.mode}}"}`},
+			configs:  cfgs,
+			updated:  2,
+			err:      nil,
+		},
+		{
+			desc:     "update template name",
+			template: bootstrap.Template{ID: tmpl.ID, Name: "thermometer", Content: tmpl.Content},
+			configs:  cfgs,
+			updated:  0,
+			err:      nil,
+		},
+		{
+			desc:     "update template with invalid content",
+			template: bootstrap.Template{ID: tmpl.ID, Name: "sensor", Content: "{{.serial"},
+			err:      bootstrap.ErrInvalidTemplate,
+		},
+		{
+			desc:     "update template with variable missing in a config",
+			template: bootstrap.Template{ID: tmpl.ID, Name: "sensor", Content: `{"serial": "{{.serial}}", "mode": "{{.mode}}"}`},
+			configs:  partial,
+			err:      bootstrap.ErrRenderTemplate,
+		},
+		{
+			desc:        "update non-existing template",
+			template:    bootstrap.Template{ID: unknown, Name: "sensor", Content: tmpl.Content},
+			retrieveErr: svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+		{
+			desc:      "update template with failed to update",
+			template:  bootstrap.Template{ID: tmpl.ID, Name: "sensor", Content: tmpl.Content + " "},
+			configs:   cfgs,
+			updateErr: svcerr.ErrUpdateEntity,
+			updated:   2,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			var changed []bootstrap.Config
+			repoCall := boot.On("RetrieveTemplate", context.Background(), domainID, tc.template.ID).Return(tmpl, tc.retrieveErr)
+			repoCall1 := boot.On("RetrieveByTemplate", context.Background(), domainID, tc.template.ID).Return(tc.configs, nil)
+			repoCall2 := boot.On("UpdateTemplate", context.Background(), mock.Anything, mock.Anything).Return(func(_ context.Context, _ bootstrap.Template, cfgs []bootstrap.Config) error {
+				changed = cfgs
+				return tc.updateErr
+			})
+			saved, err := svc.UpdateTemplate(context.Background(), session, tc.template)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			switch {
+			case tc.updated > 0 || tc.err == nil:
+				assert.Len(t, changed, tc.updated, fmt.Sprintf("%s: expected %d updated configs got %d\n", tc.desc, tc.updated, len(changed)))
+			default:
+				boot.AssertNotCalled(t, "UpdateTemplate", context.Background(), mock.Anything, mock.Anything)
+			}
+			if err == nil {
+				assert.Equal(t, tc.template.Name, saved.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, tc.template.Name, saved.Name))
+				assert.False(t, saved.UpdatedAt.IsZero(), fmt.Sprintf("%s: expected update time to be set\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	svc := newService()
+
+	id := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		id        string
+		removeErr error
+		err       error
+	}{
+		{
+			desc: "remove an existing template",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc:      "remove a non-existing template",
+			id:        unknown,
+			removeErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: validID}
+			repoCall := boot.On("RemoveTemplate", context.Background(), domainID, tc.id).Return(tc.removeErr)
+			err := svc.RemoveTemplate(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Template represents the Config content shared by many devices. Content is
+// a Go text/template rendered with the variables of each Config which
+// instantiates the Template, so the Template update changes the content of
+// all of them. Channels are connected to the Configs which do not specify
+// their own Channels.
+type Template struct {
+	ID        string    `json:"id"`
+	DomainID  string    `json:"domain_id"`
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	Channels  []string  `json:"channels,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// TemplatesPage contains page related metadata as well as list of Templates
+// that belong to this page.
+type TemplatesPage struct {
+	Total     uint64     `json:"total"`
+	Offset    uint64     `json:"offset"`
+	Limit     uint64     `json:"limit"`
+	Templates []Template `json:"templates"`
+}
+
+// BulkResult represents the result of adding a single Config in bulk.
+// Config is set on success, and Err on failure.
+type BulkResult struct {
+	ExternalID string
+	Config     Config
+	Err        error
+}
+
+func (t Template) parse() (*template.Template, error) {
+	return template.New(t.Name).Option("missingkey=error").Parse(t.Content)
+}
+
+// render executes the Template with the variables. Variables the Template
+// refers to must all be set.
+func (t Template) render(vars map[string]string) (string, error) {
+	tmpl, err := t.parse()
+	if err != nil {
+		return "", err
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
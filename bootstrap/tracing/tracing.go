@@ -39,6 +39,16 @@ func (tm *tracingMiddleware) Add(ctx context.Context, session smqauthn.Session,
 	return tm.svc.Add(ctx, session, token, cfg)
 }
 
+// BulkAdd traces the "BulkAdd" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) BulkAdd(ctx context.Context, session smqauthn.Session, token string, cfgs []bootstrap.Config) ([]bootstrap.BulkResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_bulk_add", trace.WithAttributes(
+		attribute.Int("total", len(cfgs)),
+	))
+	defer span.End()
+
+	return tm.svc.BulkAdd(ctx, session, token, cfgs)
+}
+
 // View traces the "View" operation of the wrapped bootstrap.Service.
 func (tm *tracingMiddleware) View(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Config, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_view_user", trace.WithAttributes(
@@ -72,6 +82,16 @@ func (tm *tracingMiddleware) UpdateCert(ctx context.Context, session smqauthn.Se
 	return tm.svc.UpdateCert(ctx, session, clientID, clientCert, clientKey, caCert)
 }
 
+// EnrollCert traces the "EnrollCert" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) EnrollCert(ctx context.Context, externalID, clientCert, caCert string) (bootstrap.Config, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll_cert", trace.WithAttributes(
+		attribute.String("external_id", externalID),
+	))
+	defer span.End()
+
+	return tm.svc.EnrollCert(ctx, externalID, clientCert, caCert)
+}
+
 // UpdateConnections traces the "UpdateConnections" operation of the wrapped bootstrap.Service.
 func (tm *tracingMiddleware) UpdateConnections(ctx context.Context, session smqauthn.Session, token, id string, connections []string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_connections", trace.WithAttributes(
@@ -127,6 +147,126 @@ func (tm *tracingMiddleware) ChangeState(ctx context.Context, session smqauthn.S
 	return tm.svc.ChangeState(ctx, session, token, id, state)
 }
 
+// ListRevisions traces the "ListRevisions" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ListRevisions(ctx context.Context, session smqauthn.Session, id string, offset, limit uint64) (bootstrap.RevisionsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_revisions", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Int64("offset", int64(offset)),
+		attribute.Int64("limit", int64(limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListRevisions(ctx, session, id, offset, limit)
+}
+
+// DiffRevisions traces the "DiffRevisions" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) DiffRevisions(ctx context.Context, session smqauthn.Session, id string, from, to uint64) (bootstrap.RevisionDiff, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_diff_revisions", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	))
+	defer span.End()
+
+	return tm.svc.DiffRevisions(ctx, session, id, from, to)
+}
+
+// CreateRollout traces the "CreateRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) CreateRollout(ctx context.Context, session smqauthn.Session, ro bootstrap.Rollout) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_rollout", trace.WithAttributes(
+		attribute.StringSlice("tags", ro.Tags),
+		attribute.Int("percentage", int(ro.Percentage)),
+		attribute.Int("failure_threshold", int(ro.FailureThreshold)),
+	))
+	defer span.End()
+
+	return tm.svc.CreateRollout(ctx, session, ro)
+}
+
+// ViewRollout traces the "ViewRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ViewRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_rollout", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewRollout(ctx, session, id)
+}
+
+// RollbackRollout traces the "RollbackRollout" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) RollbackRollout(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rollback_rollout", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RollbackRollout(ctx, session, id)
+}
+
+// Acknowledge traces the "Acknowledge" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) Acknowledge(ctx context.Context, externalKey, externalID string, ack bootstrap.Ack) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_acknowledge", trace.WithAttributes(
+		attribute.String("external_id", externalID),
+		attribute.Int64("revision", int64(ack.Revision)),
+		attribute.String("status", string(ack.Status)),
+	))
+	defer span.End()
+
+	return tm.svc.Acknowledge(ctx, externalKey, externalID, ack)
+}
+
+// CreateTemplate traces the "CreateTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) CreateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_template", trace.WithAttributes(
+		attribute.String("name", t.Name),
+	))
+	defer span.End()
+
+	return tm.svc.CreateTemplate(ctx, session, t)
+}
+
+// ViewTemplate traces the "ViewTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ViewTemplate(ctx context.Context, session smqauthn.Session, id string) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_template", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewTemplate(ctx, session, id)
+}
+
+// ListTemplates traces the "ListTemplates" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) ListTemplates(ctx context.Context, session smqauthn.Session, offset, limit uint64) (bootstrap.TemplatesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_templates", trace.WithAttributes(
+		attribute.Int64("offset", int64(offset)),
+		attribute.Int64("limit", int64(limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListTemplates(ctx, session, offset, limit)
+}
+
+// UpdateTemplate traces the "UpdateTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) UpdateTemplate(ctx context.Context, session smqauthn.Session, t bootstrap.Template) (bootstrap.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_template", trace.WithAttributes(
+		attribute.String("id", t.ID),
+		attribute.String("name", t.Name),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateTemplate(ctx, session, t)
+}
+
+// RemoveTemplate traces the "RemoveTemplate" operation of the wrapped bootstrap.Service.
+func (tm *tracingMiddleware) RemoveTemplate(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_template", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveTemplate(ctx, session, id)
+}
+
 // UpdateChannelHandler traces the "UpdateChannelHandler" operation of the wrapped bootstrap.Service.
 func (tm *tracingMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_update_channel_handler", trace.WithAttributes(
@@ -16,6 +16,12 @@ const (
 	// ContentType represents JSON content type.
 	ContentType = "application/json"
 	OCSPType    = "application/ocsp-response"
+	// PKCS10Type represents the content type of EST enrollment requests.
+	PKCS10Type = "application/pkcs10"
+	// PKCS7Type represents the content type of EST CA certificates responses.
+	PKCS7Type = "application/pkcs7-mime"
+	// PKCS7CertsOnlyType represents the content type of EST enrollment responses.
+	PKCS7CertsOnlyType = "application/pkcs7-mime; smime-type=certs-only"
 )
 
 // Response contains HTTP response specific methods.
@@ -46,13 +52,26 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, certs.ErrCertRevoked):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusUnauthorized)
+
+	case errors.Contains(err, certs.ErrAuthentication),
+		errors.Contains(err, ErrMissingCredentials),
+		errors.Contains(err, ErrMissingClientCert):
+		err = unwrap(err)
+		w.Header().Set("WWW-Authenticate", `Basic realm="est"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+	case errors.Contains(err, ErrUnsupportedContentType):
+		err = unwrap(err)
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+
 	case errors.Contains(err, certs.ErrMalformedEntity),
 		errors.Contains(err, ErrMissingEntityID),
 		errors.Contains(err, ErrEmptySerialNo),
 		errors.Contains(err, ErrEmptyToken),
 		errors.Contains(err, ErrInvalidQueryParams),
 		errors.Contains(err, ErrValidation),
-		errors.Contains(err, ErrInvalidRequest):
+		errors.Contains(err, ErrInvalidRequest),
+		errors.Contains(err, certs.ErrSubjectMismatch):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusBadRequest)
 
@@ -69,7 +88,8 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err = unwrap(err)
 		w.WriteHeader(http.StatusNotFound)
 
-	case errors.Contains(err, certs.ErrConflict):
+	case errors.Contains(err, certs.ErrConflict),
+		errors.Contains(err, certs.ErrAlreadyEnrolled):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusConflict)
 
@@ -304,3 +304,141 @@ func issueFromCSRInternalEndpoint(svc certs.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func estCACertsEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(downloadReq)
+		if err := req.validate(); err != nil {
+			return estCertsRes{}, err
+		}
+
+		cert, err := svc.RetrieveCAChain(ctx)
+		if err != nil {
+			return estCertsRes{}, err
+		}
+
+		return estCertsRes{
+			Certificates: cert.Certificate,
+			ContentType:  PKCS7Type,
+		}, nil
+	}
+}
+
+func estEnrollEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(estEnrollReq)
+		if err := req.validate(); err != nil {
+			return estCertsRes{}, err
+		}
+
+		cert, err := svc.EnrollCert(ctx, req.externalID, req.externalKey, certs.CSR{CSR: req.csr})
+		if err != nil {
+			return estCertsRes{}, err
+		}
+
+		return estCertsRes{
+			Certificates: cert.Certificate,
+			ContentType:  PKCS7CertsOnlyType,
+		}, nil
+	}
+}
+
+func estReenrollEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(estReenrollReq)
+		if err := req.validate(); err != nil {
+			return estCertsRes{}, err
+		}
+
+		cert, err := svc.ReenrollCert(ctx, req.externalID, req.externalKey, req.clientCert, certs.CSR{CSR: req.csr})
+		if err != nil {
+			return estCertsRes{}, err
+		}
+
+		return estCertsRes{
+			Certificates: cert.Certificate,
+			ContentType:  PKCS7CertsOnlyType,
+		}, nil
+	}
+}
+
+func listExpiringCertsEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(listExpiringCertsReq)
+		if err := req.validate(); err != nil {
+			return listCertsRes{}, err
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return listCertsRes{}, svcerr.ErrAuthentication
+		}
+
+		certPage, err := svc.ListExpiringCerts(ctx, session, req.within, req.pm)
+		if err != nil {
+			return listCertsRes{}, err
+		}
+
+		var crts []viewCertRes
+		for _, c := range certPage.Certificates {
+			crts = append(crts, viewCertRes{
+				SerialNumber: c.SerialNumber,
+				Revoked:      c.Revoked,
+				EntityID:     c.EntityID,
+				ExpiryTime:   c.ExpiryTime,
+			})
+		}
+
+		return listCertsRes{
+			Total:        certPage.Total,
+			Offset:       certPage.Offset,
+			Limit:        certPage.Limit,
+			Certificates: crts,
+		}, nil
+	}
+}
+
+func setRenewalPolicyEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(setRenewalPolicyReq)
+		if err := req.validate(); err != nil {
+			return renewalPolicyRes{}, err
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return renewalPolicyRes{}, svcerr.ErrAuthentication
+		}
+
+		policy, err := svc.SetRenewalPolicy(ctx, session, certs.RenewalPolicy{
+			AutoRenew: req.AutoRenew,
+			Emails:    req.Emails,
+		})
+		if err != nil {
+			return renewalPolicyRes{}, err
+		}
+
+		return renewalPolicyRes{RenewalPolicy: policy}, nil
+	}
+}
+
+func viewRenewalPolicyEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (response any, err error) {
+		req := request.(viewRenewalPolicyReq)
+		if err := req.validate(); err != nil {
+			return renewalPolicyRes{}, err
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return renewalPolicyRes{}, svcerr.ErrAuthentication
+		}
+
+		policy, err := svc.ViewRenewalPolicy(ctx, session)
+		if err != nil {
+			return renewalPolicyRes{}, err
+		}
+
+		return renewalPolicyRes{RenewalPolicy: policy}, nil
+	}
+}
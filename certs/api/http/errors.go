@@ -36,9 +36,18 @@ var (
 	// ErrInvalidRequest indicates that the request is invalid.
 	ErrInvalidRequest = errors.New("invalid request")
 
+	// ErrInvalidWithin indicates invalid expiry period of the certificates about to expire.
+	ErrInvalidWithin = errors.New("invalid expiry period")
+
 	// ErrMissingCSR indicates missing csr.
 	ErrMissingCSR = errors.New("missing CSR")
 
+	// ErrMissingCredentials indicates missing EST client credentials.
+	ErrMissingCredentials = errors.New("missing EST client credentials")
+
+	// ErrMissingClientCert indicates missing TLS client certificate of the EST reenrollment.
+	ErrMissingClientCert = errors.New("missing TLS client certificate")
+
 	// ErrMissingPrivKey indicates missing csr.
 	ErrMissingPrivKey = errors.New("missing private key")
 )
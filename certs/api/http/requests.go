@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"time"
 
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/certs/api"
@@ -75,6 +76,34 @@ func (req listCertsReq) validate() error {
 	return nil
 }
 
+type listExpiringCertsReq struct {
+	within time.Duration
+	pm     certs.PageMetadata
+}
+
+func (req listExpiringCertsReq) validate() error {
+	if req.within < 0 {
+		return errors.Wrap(certs.ErrMalformedEntity, ErrInvalidWithin)
+	}
+
+	return nil
+}
+
+type setRenewalPolicyReq struct {
+	AutoRenew bool     `json:"auto_renew"`
+	Emails    []string `json:"emails"`
+}
+
+func (req setRenewalPolicyReq) validate() error {
+	return nil
+}
+
+type viewRenewalPolicyReq struct{}
+
+func (req viewRenewalPolicyReq) validate() error {
+	return nil
+}
+
 type ocspReq struct {
 	req          *ocsp.Request
 	StatusParam  string `json:"status,omitempty"`
@@ -150,3 +179,33 @@ func (req IssueFromCSRInternalReq) validate() error {
 
 	return nil
 }
+
+type estEnrollReq struct {
+	externalID  string
+	externalKey string
+	csr         []byte
+}
+
+func (req estEnrollReq) validate() error {
+	if req.externalID == "" || req.externalKey == "" {
+		return ErrMissingCredentials
+	}
+	if len(req.csr) == 0 {
+		return errors.Wrap(certs.ErrMalformedEntity, ErrMissingCSR)
+	}
+
+	return nil
+}
+
+type estReenrollReq struct {
+	estEnrollReq
+	clientCert []byte
+}
+
+func (req estReenrollReq) validate() error {
+	if len(req.clientCert) == 0 {
+		return ErrMissingClientCert
+	}
+
+	return req.estEnrollReq.validate()
+}
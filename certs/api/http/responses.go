@@ -144,6 +144,22 @@ func (res viewCertRes) Empty() bool {
 	return false
 }
 
+type renewalPolicyRes struct {
+	certs.RenewalPolicy
+}
+
+func (res renewalPolicyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res renewalPolicyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res renewalPolicyRes) Empty() bool {
+	return false
+}
+
 type crlRes struct {
 	CrlBytes []byte `json:"crl"`
 }
@@ -203,3 +219,9 @@ func (res issueFromCSRRes) Headers() map[string]string {
 func (res issueFromCSRRes) Empty() bool {
 	return false
 }
+
+// estCertsRes carries the PEM certificates EST responses encode as PKCS #7.
+type estCertsRes struct {
+	Certificates []byte
+	ContentType  string
+}
@@ -7,13 +7,17 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
@@ -31,6 +35,7 @@ const (
 	offsetKey       = "offset"
 	limitKey        = "limit"
 	entityKey       = "entity_id"
+	withinKey       = "within"
 	ocspStatusParam = "force_status"
 	entityIDParam   = "entityID"
 	ttl             = "ttl"
@@ -99,6 +104,24 @@ func MakeHandler(svc certs.Service, authn smqauthn.AuthNMiddleware, logger *slog
 					api.EncodeResponse,
 					opts...,
 				), "list_certs").ServeHTTP)
+				r.Get("/expiring", otelhttp.NewHandler(kithttp.NewServer(
+					listExpiringCertsEndpoint(svc),
+					decodeListExpiringCerts,
+					api.EncodeResponse,
+					opts...,
+				), "list_expiring_certs").ServeHTTP)
+				r.Get("/renewal-policy", otelhttp.NewHandler(kithttp.NewServer(
+					viewRenewalPolicyEndpoint(svc),
+					decodeViewRenewalPolicy,
+					api.EncodeResponse,
+					opts...,
+				), "view_renewal_policy").ServeHTTP)
+				r.Put("/renewal-policy", otelhttp.NewHandler(kithttp.NewServer(
+					setRenewalPolicyEndpoint(svc),
+					decodeSetRenewalPolicy,
+					api.EncodeResponse,
+					opts...,
+				), "set_renewal_policy").ServeHTTP)
 				r.Get("/{id}", otelhttp.NewHandler(kithttp.NewServer(
 					viewCertEndpoint(svc),
 					decodeView,
@@ -144,6 +167,30 @@ func MakeHandler(svc certs.Service, authn smqauthn.AuthNMiddleware, logger *slog
 		), "download_ca").ServeHTTP)
 	})
 
+	// EST enrollment (RFC 7030) of the devices authenticated with their
+	// bootstrap external ID and key as HTTP basic auth credentials. The
+	// reenrolling devices authenticate with their current certificate too.
+	mux.Route("/.well-known/est", func(r chi.Router) {
+		r.Get("/cacerts", otelhttp.NewHandler(kithttp.NewServer(
+			estCACertsEndpoint(svc),
+			decodeDownloadCA,
+			encodeESTResponse,
+			opts...,
+		), "est_cacerts").ServeHTTP)
+		r.Post("/simpleenroll", otelhttp.NewHandler(kithttp.NewServer(
+			estEnrollEndpoint(svc),
+			decodeESTEnrollRequest,
+			encodeESTResponse,
+			opts...,
+		), "est_simple_enroll").ServeHTTP)
+		r.Post("/simplereenroll", otelhttp.NewHandler(kithttp.NewServer(
+			estReenrollEndpoint(svc),
+			decodeESTReenrollRequest,
+			encodeESTResponse,
+			opts...,
+		), "est_simple_reenroll").ServeHTTP)
+	})
+
 	mux.Group(func(r chi.Router) {
 		r.Use(authMiddleware(secret))
 		r.Post("/certs/csrs/{entityID}", otelhttp.NewHandler(kithttp.NewServer(
@@ -268,6 +315,54 @@ func decodeListCerts(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
+func decodeListExpiringCerts(_ context.Context, r *http.Request) (any, error) {
+	o, err := readNumQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := readNumQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := readStringQuery(r, withinKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listExpiringCertsReq{
+		pm: certs.PageMetadata{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+	if w != "" {
+		if req.within, err = time.ParseDuration(w); err != nil {
+			return nil, errors.Wrap(ErrInvalidQueryParams, err)
+		}
+	}
+
+	return req, nil
+}
+
+func decodeSetRenewalPolicy(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), ContentType) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	req := setRenewalPolicyReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err)
+	}
+
+	return req, nil
+}
+
+func decodeViewRenewalPolicy(_ context.Context, r *http.Request) (any, error) {
+	return viewRenewalPolicyReq{}, nil
+}
+
 func decodeIssueFromCSR(_ context.Context, r *http.Request) (any, error) {
 	t, err := readStringQuery(r, ttl, "")
 	if err != nil {
@@ -316,6 +411,82 @@ func decodeIssueFromCSRInternal(_ context.Context, r *http.Request) (any, error)
 	return req, nil
 }
 
+func decodeESTEnrollRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), PKCS10Type) {
+		return nil, ErrUnsupportedContentType
+	}
+
+	externalID, externalKey, _ := r.BasicAuth()
+	req := estEnrollReq{
+		externalID:  externalID,
+		externalKey: externalKey,
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, errors.New("failed to read request body"))
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		return req, nil
+	}
+
+	csr, err := decodeCSR(body)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err)
+	}
+	req.csr = csr
+
+	return req, nil
+}
+
+// decodeESTReenrollRequest decodes the EST enrollment request along with the
+// certificate the device presented in the TLS handshake.
+func decodeESTReenrollRequest(ctx context.Context, r *http.Request) (any, error) {
+	enrollReq, err := decodeESTEnrollRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := estReenrollReq{estEnrollReq: enrollReq.(estEnrollReq)}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		req.clientCert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.TLS.PeerCertificates[0].Raw})
+	}
+
+	return req, nil
+}
+
+// decodeCSR converts the base64 encoded DER CSR of the EST request to PEM.
+func decodeCSR(body []byte) ([]byte, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := x509.ParseCertificateRequest(der); err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+func encodeESTResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	res := response.(estCertsRes)
+
+	p7, err := encodePKCS7(res.Certificates)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", res.ContentType)
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write([]byte(base64.StdEncoding.EncodeToString(p7)))
+	return err
+}
+
 func encodeOSCPResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	res := response.(ocspRawRes)
 
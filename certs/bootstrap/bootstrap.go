@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bootstrap contains the Bootstrap service client the devices
+// enrolling for certificates are authenticated with.
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/pkg/errors"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+)
+
+var _ certs.Bootstrap = (*client)(nil)
+
+type client struct {
+	sdk    mgsdk.SDK
+	secret string
+}
+
+// NewClient returns the Bootstrap service client using the given SDK.
+// The secret authenticates the Certs service to the Bootstrap service.
+func NewClient(sdk mgsdk.SDK, secret string) certs.Bootstrap {
+	return &client{sdk: sdk, secret: secret}
+}
+
+func (c *client) Authenticate(ctx context.Context, externalID, externalKey string) (string, string, error) {
+	cfg, err := c.sdk.Bootstrap(ctx, externalID, externalKey)
+	if err != nil {
+		return "", "", handleError(err)
+	}
+
+	return cfg.ClientID, cfg.DomainID, nil
+}
+
+func (c *client) UpdateCert(ctx context.Context, externalID, clientCert, caCert string) error {
+	if _, err := c.sdk.EnrollBootstrapCert(ctx, externalID, clientCert, caCert, c.secret); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleError reports the devices Bootstrap service refused as not authenticated,
+// so they are told apart from the Bootstrap service failures.
+func handleError(err errors.SDKError) error {
+	switch err.StatusCode() {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return errors.Wrap(certs.ErrAuthentication, err)
+	default:
+		return err
+	}
+}
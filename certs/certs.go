@@ -70,8 +70,12 @@ type Certificate struct {
 	Revoked      bool      `json:"revoked"`
 	ExpiryTime   time.Time `json:"expiry_time"`
 	EntityID     string    `json:"entity_id"`
+	DomainID     string    `json:"domain_id,omitempty"`
 	Type         CertType  `json:"type"`
 	DownloadUrl  string    `json:"-"`
+	// FromCSR is set for the certificates issued from the CSR, whose key is
+	// held by the requester, so they can't be renewed by the service.
+	FromCSR bool `json:"-"`
 }
 
 type CertificatePage struct {
@@ -162,18 +166,71 @@ type Service interface {
 
 	// IssueFromCSRInternal creates a certificate from a given CSR using agent token authentication.
 	IssueFromCSRInternal(ctx context.Context, entityID, ttl string, csr CSR) (Certificate, error)
+
+	// EnrollCert issues a certificate from the CSR of the device bootstrapped with
+	// the given external ID and key, and links the certificate to the device bootstrap config.
+	// ErrAlreadyEnrolled is returned if the device already has a valid certificate.
+	EnrollCert(ctx context.Context, externalID, externalKey string, csr CSR) (Certificate, error)
+
+	// ReenrollCert issues a new certificate from the CSR of the device which proved the
+	// possession of its current PEM encoded certificate, links it to the device bootstrap
+	// config and revokes the current certificate.
+	ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr CSR) (Certificate, error)
+
+	// ListExpiringCerts retrieves the Domain certificates which expire within the given
+	// period. If the period is zero, the configured renewal window is used.
+	ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm PageMetadata) (CertificatePage, error)
+
+	// SetRenewalPolicy sets the policy of handling the Domain certificates about to expire.
+	SetRenewalPolicy(ctx context.Context, session authn.Session, policy RenewalPolicy) (RenewalPolicy, error)
+
+	// ViewRenewalPolicy retrieves the policy of handling the Domain certificates about to expire.
+	ViewRenewalPolicy(ctx context.Context, session authn.Session) (RenewalPolicy, error)
+
+	// RenewExpiringCerts renews the certificates about to expire whose renewal policy
+	// allows it, and sends the expiry digests to the Domain recipients.
+	RenewExpiringCerts(ctx context.Context) ([]Renewal, error)
 }
 
 type Repository interface {
-	// SaveCertEntityMapping saves the mapping between certificate serial number and entity ID.
-	SaveCertEntityMapping(ctx context.Context, serialNumber, entityID string) error
+	// SaveCertEntityMapping saves the mapping between certificate serial number and entity ID,
+	// along with the certificate expiry time and whether it is issued from the CSR. Domain ID
+	// is empty for the certificates which are not issued in a Domain.
+	SaveCertEntityMapping(ctx context.Context, serialNumber, entityID, domainID string, expiryTime time.Time, fromCSR bool) error
+
+	// RetrieveExpiringCerts retrieves the latest certificate of each entity if it expires
+	// between the given times, sorted by the expiry time. The certificates of all the Domains
+	// are retrieved if the Domain ID is empty. The certificates saved without the expiry time
+	// are retrieved too, since it is not known when they expire.
+	RetrieveExpiringCerts(ctx context.Context, domainID string, from, to time.Time) ([]Certificate, error)
 
 	// GetEntityIDBySerial retrieves the entity ID for a given certificate serial number.
 	GetEntityIDBySerial(ctx context.Context, serialNumber string) (string, error)
 
+	// GetDomainIDBySerial retrieves the Domain ID for a given certificate serial number.
+	GetDomainIDBySerial(ctx context.Context, serialNumber string) (string, error)
+
 	// ListCertsByEntityID lists all certificate serial numbers for a given entity ID.
 	ListCertsByEntityID(ctx context.Context, entityID string) ([]string, error)
 
 	// RemoveCertEntityMapping removes the mapping between certificate and entity ID.
 	RemoveCertEntityMapping(ctx context.Context, serialNumber string) error
+
+	// SaveRenewalPolicy saves the Domain renewal policy, replacing the existing one.
+	SaveRenewalPolicy(ctx context.Context, policy RenewalPolicy) error
+
+	// RetrieveRenewalPolicy retrieves the Domain renewal policy.
+	RetrieveRenewalPolicy(ctx context.Context, domainID string) (RenewalPolicy, error)
+}
+
+// Bootstrap represents the Bootstrap service the devices enroll for certificates through.
+type Bootstrap interface {
+	// Authenticate returns the ID of the Client bootstrapped with the given external ID and key,
+	// and the ID of the Domain of its bootstrap config.
+	// ErrAuthentication is returned if there is no such Client.
+	Authenticate(ctx context.Context, externalID, externalKey string) (clientID, domainID string, err error)
+
+	// UpdateCert links the Client certificate and the issuing CA to the bootstrap config
+	// with the given external ID.
+	UpdateCert(ctx context.Context, externalID, clientCert, caCert string) error
 }
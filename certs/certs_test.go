@@ -5,6 +5,14 @@ package certs_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -36,7 +44,7 @@ var (
 func TestIssueCert(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -59,6 +67,7 @@ func TestIssueCert(t *testing.T) {
 			expectedCert: certs.Certificate{
 				SerialNumber: serialNumber,
 				EntityID:     "entityID",
+				DomainID:     domainID,
 			},
 			err: nil,
 		},
@@ -88,7 +97,7 @@ func TestIssueCert(t *testing.T) {
 				CommonName: tc.entityID,
 			}
 			agentCall := agent.On("Issue", tc.ttl, []string{}, options).Return(tc.cert, tc.agentErr)
-			repoCall := repo.On("SaveCertEntityMapping", mock.Anything, tc.cert.SerialNumber, tc.entityID).Return(tc.repoErr)
+			repoCall := repo.On("SaveCertEntityMapping", mock.Anything, tc.cert.SerialNumber, tc.entityID, domainID, tc.cert.ExpiryTime, false).Return(tc.repoErr)
 
 			cert, err := svc.IssueCert(context.Background(), testSession, tc.entityID, tc.ttl, []string{}, options)
 			if tc.err != nil {
@@ -107,7 +116,7 @@ func TestIssueCert(t *testing.T) {
 func TestRevokeBySerial(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -148,7 +157,7 @@ func TestRevokeBySerial(t *testing.T) {
 func TestRenewCert(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	newCert := certs.Certificate{
@@ -215,7 +224,7 @@ func TestRenewCert(t *testing.T) {
 func TestGetEntityID(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -260,7 +269,7 @@ func TestGetEntityID(t *testing.T) {
 func TestListCerts(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	pageMetadata := certs.PageMetadata{Limit: 10, Offset: 0}
@@ -371,7 +380,7 @@ func TestListCerts(t *testing.T) {
 func TestRevokeAll(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -448,7 +457,7 @@ func TestRevokeAll(t *testing.T) {
 func TestIssueFromCSR(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCSR := certs.CSR{
@@ -477,6 +486,8 @@ func TestIssueFromCSR(t *testing.T) {
 			expectedCert: certs.Certificate{
 				SerialNumber: serialNumber,
 				EntityID:     "entity-123",
+				DomainID:     domainID,
+				FromCSR:      true,
 			},
 			err: nil,
 		},
@@ -507,7 +518,7 @@ func TestIssueFromCSR(t *testing.T) {
 			agentCall := agent.On("SignCSR", tc.csr.CSR, tc.ttl).Return(tc.cert, tc.agentErr)
 			var repoCall *mock.Call
 			if tc.agentErr == nil {
-				repoCall = repo.On("SaveCertEntityMapping", mock.Anything, tc.cert.SerialNumber, tc.entityID).Return(tc.repoErr)
+				repoCall = repo.On("SaveCertEntityMapping", mock.Anything, tc.cert.SerialNumber, tc.entityID, domainID, tc.cert.ExpiryTime, true).Return(tc.repoErr)
 			}
 
 			cert, err := svc.IssueFromCSR(context.Background(), testSession, tc.entityID, tc.ttl, tc.csr)
@@ -529,7 +540,7 @@ func TestIssueFromCSR(t *testing.T) {
 func TestGenerateCRL(t *testing.T) {
 	agent := new(mocks.Agent)
 	repo := new(mocks.Repository)
-	svc, err := certs.NewService(context.Background(), agent, repo)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -567,3 +578,698 @@ func TestGenerateCRL(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrollCert(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	bs := new(mocks.Bootstrap)
+	svc, err := certs.NewService(context.Background(), agent, repo, bs, new(mocks.Notifier), certs.RenewalConfig{})
+	require.NoError(t, err)
+
+	csr := certs.CSR{CSR: generateCSR(t, "0001")}
+	caChain := []byte("ca-chain")
+	cert := certs.Certificate{
+		SerialNumber: serialNumber,
+		Certificate:  []byte(testCertPEM),
+	}
+	enrolled := certs.Certificate{
+		SerialNumber: "enrolled",
+		Certificate:  []byte(testCertPEM),
+		ExpiryTime:   time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		desc          string
+		serialNumbers []string
+		enrolled      certs.Certificate
+		authnErr      error
+		listErr       error
+		viewErr       error
+		caErr         error
+		signErr       error
+		updateErr     error
+		revokeErr     error
+		expectedErr   error
+	}{
+		{
+			desc: "enroll cert successfully",
+		},
+		{
+			desc:          "enroll cert of device with expired cert",
+			serialNumbers: []string{enrolled.SerialNumber},
+			enrolled:      certs.Certificate{SerialNumber: enrolled.SerialNumber, ExpiryTime: time.Now().Add(-time.Hour)},
+		},
+		{
+			desc:          "enroll cert of device with revoked cert",
+			serialNumbers: []string{enrolled.SerialNumber},
+			enrolled:      certs.Certificate{SerialNumber: enrolled.SerialNumber, ExpiryTime: time.Now().Add(time.Hour), Revoked: true},
+		},
+		{
+			desc:          "enroll cert of device with valid cert",
+			serialNumbers: []string{enrolled.SerialNumber},
+			enrolled:      enrolled,
+			expectedErr:   certs.ErrAlreadyEnrolled,
+		},
+		{
+			desc:        "enroll cert with invalid device credentials",
+			authnErr:    certs.ErrAuthentication,
+			expectedErr: certs.ErrAuthentication,
+		},
+		{
+			desc:        "enroll cert with failed listing certs",
+			listErr:     errors.New("repo error"),
+			expectedErr: certs.ErrViewEntity,
+		},
+		{
+			desc:          "enroll cert with failed cert view",
+			serialNumbers: []string{enrolled.SerialNumber},
+			viewErr:       errors.New("agent error"),
+			expectedErr:   certs.ErrViewEntity,
+		},
+		{
+			desc:        "enroll cert with failed CA chain retrieval",
+			caErr:       errors.New("agent error"),
+			expectedErr: certs.ErrViewEntity,
+		},
+		{
+			desc:        "enroll cert with failed CSR signing",
+			signErr:     errors.New("agent error"),
+			expectedErr: certs.ErrFailedCertCreation,
+		},
+		{
+			desc:        "enroll cert with failed bootstrap config update",
+			updateErr:   errors.New("bootstrap error"),
+			expectedErr: certs.ErrUpdateEntity,
+		},
+		{
+			desc:        "enroll cert with failed revocation of unlinked cert",
+			updateErr:   errors.New("bootstrap error"),
+			revokeErr:   errors.New("agent error"),
+			expectedErr: certs.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := bs.On("Authenticate", mock.Anything, "external-id", "external-key").Return(entityID, domainID, tc.authnErr)
+			listCall := repo.On("ListCertsByEntityID", mock.Anything, entityID).Return(tc.serialNumbers, tc.listErr)
+			viewCall := agent.On("View", enrolled.SerialNumber).Return(tc.enrolled, tc.viewErr)
+			caCall := agent.On("GetCAChain").Return(caChain, tc.caErr)
+			signCall := agent.On("SignCSR", csr.CSR, certValidityPeriod.String()).Return(cert, tc.signErr)
+			saveCall := repo.On("SaveCertEntityMapping", mock.Anything, serialNumber, entityID, domainID, mock.Anything, true).Return(nil)
+			updateCall := bs.On("UpdateCert", mock.Anything, "external-id", testCertPEM, string(caChain)).Return(tc.updateErr)
+			revokeCall := agent.On("Revoke", serialNumber).Return(tc.revokeErr)
+			removeCall := repo.On("RemoveCertEntityMapping", mock.Anything, serialNumber).Return(nil)
+
+			res, err := svc.EnrollCert(context.Background(), "external-id", "external-key", csr)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Contains(err, tc.expectedErr), "expected error %v, got %v", tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entityID, res.EntityID)
+				assert.Equal(t, domainID, res.DomainID)
+				assert.Equal(t, serialNumber, res.SerialNumber)
+			}
+			if tc.updateErr != nil {
+				agent.AssertCalled(t, "Revoke", serialNumber)
+			}
+
+			authnCall.Unset()
+			listCall.Unset()
+			viewCall.Unset()
+			caCall.Unset()
+			signCall.Unset()
+			saveCall.Unset()
+			updateCall.Unset()
+			revokeCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
+func TestReenrollCert(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	bs := new(mocks.Bootstrap)
+	svc, err := certs.NewService(context.Background(), agent, repo, bs, new(mocks.Notifier), certs.RenewalConfig{})
+	require.NoError(t, err)
+
+	caChain := []byte("ca-chain")
+	current := "current"
+	cert := certs.Certificate{
+		SerialNumber: serialNumber,
+		Certificate:  []byte(testCertPEM),
+	}
+	otherCertPEM := generateCert(t, "0001")
+
+	testCases := []struct {
+		desc          string
+		csr           certs.CSR
+		clientCert    []byte
+		serialNumbers []string
+		revoked       bool
+		expiryTime    time.Time
+		authnErr      error
+		listErr       error
+		viewErr       error
+		revokeErr     error
+		expectedErr   error
+	}{
+		{
+			desc:          "reenroll cert successfully",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+		},
+		{
+			desc:          "reenroll cert with invalid device credentials",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			authnErr:      certs.ErrAuthentication,
+			expectedErr:   certs.ErrAuthentication,
+		},
+		{
+			desc:          "reenroll cert without client cert",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+			expectedErr:   certs.ErrAuthentication,
+		},
+		{
+			desc:          "reenroll cert with client cert not issued to the device",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    otherCertPEM,
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+			expectedErr:   certs.ErrAuthentication,
+		},
+		{
+			desc:          "reenroll cert of device without certs",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{},
+			expectedErr:   certs.ErrAuthentication,
+		},
+		{
+			desc:          "reenroll cert with failed listing certs",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{},
+			listErr:       errors.New("repo error"),
+			expectedErr:   certs.ErrViewEntity,
+		},
+		{
+			desc:          "reenroll cert with failed cert view",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			viewErr:       errors.New("agent error"),
+			expectedErr:   certs.ErrViewEntity,
+		},
+		{
+			desc:          "reenroll cert with revoked cert",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			revoked:       true,
+			expiryTime:    time.Now().Add(time.Hour),
+			expectedErr:   certs.ErrCertRevoked,
+		},
+		{
+			desc:          "reenroll cert with expired cert",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(-time.Hour),
+			expectedErr:   certs.ErrCertExpired,
+		},
+		{
+			desc:          "reenroll cert with mismatched subject",
+			csr:           certs.CSR{CSR: generateCSR(t, "0002")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+			expectedErr:   certs.ErrSubjectMismatch,
+		},
+		{
+			desc:          "reenroll cert with malformed CSR",
+			csr:           certs.CSR{CSR: []byte("invalid-csr")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+			expectedErr:   certs.ErrMalformedEntity,
+		},
+		{
+			desc:          "reenroll cert with failed revocation of current cert",
+			csr:           certs.CSR{CSR: generateCSR(t, "0001")},
+			clientCert:    []byte(testCertPEM),
+			serialNumbers: []string{current},
+			expiryTime:    time.Now().Add(time.Hour),
+			revokeErr:     errors.New("agent error"),
+			expectedErr:   certs.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := bs.On("Authenticate", mock.Anything, "external-id", "external-key").Return(entityID, domainID, tc.authnErr)
+			listCall := repo.On("ListCertsByEntityID", mock.Anything, entityID).Return(tc.serialNumbers, tc.listErr)
+			viewCall := agent.On("View", current).Return(certs.Certificate{SerialNumber: current, Certificate: []byte(testCertPEM), Revoked: tc.revoked, ExpiryTime: tc.expiryTime}, tc.viewErr)
+			caCall := agent.On("GetCAChain").Return(caChain, nil)
+			signCall := agent.On("SignCSR", tc.csr.CSR, certValidityPeriod.String()).Return(cert, nil)
+			saveCall := repo.On("SaveCertEntityMapping", mock.Anything, serialNumber, entityID, domainID, mock.Anything, true).Return(nil)
+			updateCall := bs.On("UpdateCert", mock.Anything, "external-id", testCertPEM, string(caChain)).Return(nil)
+			revokeCall := agent.On("Revoke", current).Return(tc.revokeErr)
+
+			res, err := svc.ReenrollCert(context.Background(), "external-id", "external-key", tc.clientCert, tc.csr)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Contains(err, tc.expectedErr), "expected error %v, got %v", tc.expectedErr, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entityID, res.EntityID)
+				agent.AssertCalled(t, "Revoke", current)
+			}
+
+			authnCall.Unset()
+			listCall.Unset()
+			viewCall.Unset()
+			caCall.Unset()
+			signCall.Unset()
+			saveCall.Unset()
+			updateCall.Unset()
+			revokeCall.Unset()
+		})
+	}
+}
+
+func generateCert(t *testing.T, cn string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func generateCSR(t *testing.T, cn string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: cn}}, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func TestListExpiringCerts(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{Window: certValidityPeriod})
+	require.NoError(t, err)
+
+	now := time.Now()
+	expiring := certs.Certificate{SerialNumber: "expiring", ExpiryTime: now.Add(time.Hour)}
+	later := certs.Certificate{SerialNumber: "later", ExpiryTime: now.Add(time.Hour * 24 * 7)}
+	revoked := certs.Certificate{SerialNumber: "revoked", ExpiryTime: now.Add(time.Hour), Revoked: true}
+	expired := certs.Certificate{SerialNumber: "expired", ExpiryTime: now.Add(-time.Hour)}
+	distant := certs.Certificate{SerialNumber: "distant", ExpiryTime: now.Add(time.Hour * 24 * 365)}
+	all := []certs.Certificate{expiring, later, revoked, expired, distant}
+
+	// The certificates saved without the expiry time are retrieved regardless of it.
+	var latest []certs.Certificate
+	for i, cert := range all {
+		m := certs.Certificate{SerialNumber: cert.SerialNumber, EntityID: fmt.Sprintf("entity-%d", i), DomainID: domainID}
+		if cert.SerialNumber != "expired" && cert.SerialNumber != "distant" {
+			m.ExpiryTime = cert.ExpiryTime
+		}
+		latest = append(latest, m)
+	}
+	withMapping := func(cert certs.Certificate, i int) certs.Certificate {
+		cert.EntityID = latest[i].EntityID
+		cert.DomainID = domainID
+		return cert
+	}
+
+	testCases := []struct {
+		desc     string
+		within   time.Duration
+		pm       certs.PageMetadata
+		repoErr  error
+		viewErr  error
+		expected certs.CertificatePage
+		err      error
+	}{
+		{
+			desc: "list expiring certs within the renewal window",
+			expected: certs.CertificatePage{
+				PageMetadata: certs.PageMetadata{Total: 2},
+				Certificates: []certs.Certificate{withMapping(expiring, 0), withMapping(later, 1)},
+			},
+		},
+		{
+			desc:   "list expiring certs within the given period",
+			within: time.Hour * 2,
+			expected: certs.CertificatePage{
+				PageMetadata: certs.PageMetadata{Total: 1},
+				Certificates: []certs.Certificate{withMapping(expiring, 0)},
+			},
+		},
+		{
+			desc: "list expiring certs with offset and limit",
+			pm:   certs.PageMetadata{Offset: 1, Limit: 1},
+			expected: certs.CertificatePage{
+				PageMetadata: certs.PageMetadata{Total: 2, Offset: 1, Limit: 1},
+				Certificates: []certs.Certificate{withMapping(later, 1)},
+			},
+		},
+		{
+			desc: "list expiring certs with offset out of range",
+			pm:   certs.PageMetadata{Offset: 5, Limit: 1},
+			expected: certs.CertificatePage{
+				PageMetadata: certs.PageMetadata{Total: 2, Offset: 5, Limit: 1},
+				Certificates: []certs.Certificate{},
+			},
+		},
+		{
+			desc:    "list expiring certs with failed repository retrieval",
+			repoErr: errors.New("repo error"),
+			err:     certs.ErrViewEntity,
+		},
+		{
+			desc:    "list expiring certs with failed agent view",
+			viewErr: errors.New("agent error"),
+			err:     certs.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			calls := []*mock.Call{repo.On("RetrieveExpiringCerts", mock.Anything, domainID, mock.Anything, mock.Anything).Return(latest, tc.repoErr)}
+			for _, cert := range all {
+				calls = append(calls, agent.On("View", cert.SerialNumber).Return(cert, tc.viewErr))
+			}
+
+			page, err := svc.ListExpiringCerts(context.Background(), testSession, tc.within, tc.pm)
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, page)
+			}
+
+			for _, call := range calls {
+				call.Unset()
+			}
+		})
+	}
+}
+
+func TestSetRenewalPolicy(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc    string
+		policy  certs.RenewalPolicy
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "set renewal policy successfully",
+			policy: certs.RenewalPolicy{AutoRenew: true, Emails: []string{"admin@example.com"}},
+		},
+		{
+			desc:   "set renewal policy with invalid email",
+			policy: certs.RenewalPolicy{Emails: []string{"admin"}},
+			err:    certs.ErrInvalidEmail,
+		},
+		{
+			desc:    "set renewal policy with failed repository save",
+			policy:  certs.RenewalPolicy{AutoRenew: true},
+			repoErr: errors.New("repo error"),
+			err:     certs.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("SaveRenewalPolicy", mock.Anything, mock.Anything).Return(tc.repoErr)
+
+			policy, err := svc.SetRenewalPolicy(context.Background(), testSession, tc.policy)
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, domainID, policy.DomainID)
+				assert.Equal(t, entityID, policy.UpdatedBy)
+				assert.False(t, policy.UpdatedAt.IsZero())
+				assert.Equal(t, tc.policy.Emails, policy.Emails)
+			}
+
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewRenewalPolicy(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), new(mocks.Notifier), certs.RenewalConfig{AutoRenew: true})
+	require.NoError(t, err)
+
+	saved := certs.RenewalPolicy{DomainID: domainID, Emails: []string{"admin@example.com"}}
+
+	testCases := []struct {
+		desc     string
+		policy   certs.RenewalPolicy
+		repoErr  error
+		expected certs.RenewalPolicy
+		err      error
+	}{
+		{
+			desc:     "view renewal policy successfully",
+			policy:   saved,
+			expected: saved,
+		},
+		{
+			desc:     "view renewal policy of domain without policy",
+			repoErr:  certs.ErrNotFound,
+			expected: certs.RenewalPolicy{DomainID: domainID, AutoRenew: true},
+		},
+		{
+			desc:    "view renewal policy with failed repository retrieval",
+			repoErr: errors.New("repo error"),
+			err:     certs.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveRenewalPolicy", mock.Anything, domainID).Return(tc.policy, tc.repoErr)
+
+			policy, err := svc.ViewRenewalPolicy(context.Background(), testSession)
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, policy)
+			}
+
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRenewExpiringCerts(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	notifier := new(mocks.Notifier)
+	svc, err := certs.NewService(context.Background(), agent, repo, new(mocks.Bootstrap), notifier, certs.RenewalConfig{})
+	require.NoError(t, err)
+
+	cert := certs.Certificate{SerialNumber: serialNumber, Certificate: []byte(testCertPEM), ExpiryTime: time.Now().Add(time.Hour)}
+	expiring := cert
+	expiring.EntityID = entityID
+	expiring.DomainID = domainID
+	expiringCSR := expiring
+	expiringCSR.FromCSR = true
+	renewed := certs.Certificate{SerialNumber: "renewed-serial", Certificate: []byte(testCertPEM), Key: []byte("key")}
+	expectedRenewed := renewed
+	expectedRenewed.EntityID = entityID
+	expectedRenewed.DomainID = domainID
+	emails := []string{"admin@example.com"}
+
+	testCases := []struct {
+		desc      string
+		policy    certs.RenewalPolicy
+		policyErr error
+		fromCSR   bool
+		renewErr  error
+		saveErr   error
+		notifyErr error
+		expected  []certs.Renewal
+		err       error
+	}{
+		{
+			desc:     "renew expiring cert allowed by the domain policy",
+			policy:   certs.RenewalPolicy{DomainID: domainID, AutoRenew: true},
+			expected: []certs.Renewal{{Certificate: expiring, Renewed: expectedRenewed}},
+		},
+		{
+			desc:     "report expiring cert issued from CSR allowed to be renewed by the domain policy",
+			policy:   certs.RenewalPolicy{DomainID: domainID, AutoRenew: true},
+			fromCSR:  true,
+			expected: []certs.Renewal{{Certificate: expiringCSR}},
+		},
+		{
+			desc:     "report expiring cert not allowed to be renewed by the domain policy",
+			policy:   certs.RenewalPolicy{DomainID: domainID, Emails: emails},
+			expected: []certs.Renewal{{Certificate: expiring}},
+		},
+		{
+			desc:      "report expiring cert of domain without policy",
+			policyErr: certs.ErrNotFound,
+			expected:  []certs.Renewal{{Certificate: expiring}},
+		},
+		{
+			desc:      "renew expiring certs with failed policy retrieval",
+			policyErr: errors.New("repo error"),
+			expected:  []certs.Renewal{},
+			err:       certs.ErrViewEntity,
+		},
+		{
+			desc:     "renew expiring cert with failed agent renewal",
+			policy:   certs.RenewalPolicy{DomainID: domainID, AutoRenew: true},
+			renewErr: errors.New("agent error"),
+			expected: []certs.Renewal{{Certificate: expiring, Err: certs.ErrUpdateEntity}},
+		},
+		{
+			desc:     "renew expiring cert with failed mapping save",
+			policy:   certs.RenewalPolicy{DomainID: domainID, AutoRenew: true},
+			saveErr:  errors.New("repo error"),
+			expected: []certs.Renewal{{Certificate: expiring, Err: certs.ErrUpdateEntity}},
+		},
+		{
+			desc:      "renew expiring cert with failed digest",
+			policy:    certs.RenewalPolicy{DomainID: domainID, AutoRenew: true, Emails: emails},
+			notifyErr: errors.New("email error"),
+			expected:  []certs.Renewal{{Certificate: expiring, Renewed: expectedRenewed}},
+			err:       certs.ErrNotifyExpiry,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			calls := []*mock.Call{
+				repo.On("RetrieveExpiringCerts", mock.Anything, "", mock.Anything, mock.Anything).Return([]certs.Certificate{{SerialNumber: serialNumber, EntityID: entityID, DomainID: domainID, FromCSR: tc.fromCSR}}, nil),
+				agent.On("View", serialNumber).Return(cert, nil),
+				repo.On("RetrieveRenewalPolicy", mock.Anything, domainID).Return(tc.policy, tc.policyErr),
+				agent.On("Renew", expiring, certValidityPeriod.String()).Return(renewed, tc.renewErr),
+				repo.On("SaveCertEntityMapping", mock.Anything, renewed.SerialNumber, entityID, domainID, renewed.ExpiryTime, false).Return(tc.saveErr),
+				notifier.On("Notify", mock.Anything, emails, mock.Anything).Return(tc.notifyErr),
+			}
+
+			renewals, err := svc.RenewExpiringCerts(context.Background())
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, renewals, len(tc.expected))
+			for i, r := range renewals {
+				assert.Equal(t, tc.expected[i].Certificate, r.Certificate)
+				assert.Equal(t, tc.expected[i].Renewed, r.Renewed)
+				if tc.expected[i].Err != nil {
+					assert.True(t, errors.Contains(r.Err, tc.expected[i].Err), "expected error %v, got %v", tc.expected[i].Err, r.Err)
+				} else {
+					assert.NoError(t, r.Err)
+				}
+			}
+			if len(tc.policy.Emails) > 0 {
+				notifier.AssertCalled(t, "Notify", mock.Anything, emails, mock.Anything)
+			}
+
+			for _, call := range calls {
+				call.Unset()
+			}
+		})
+	}
+}
+
+func TestEnrollCertAfterAutoRenewal(t *testing.T) {
+	agent := new(mocks.Agent)
+	repo := new(mocks.Repository)
+	bs := new(mocks.Bootstrap)
+	svc, err := certs.NewService(context.Background(), agent, repo, bs, new(mocks.Notifier), certs.RenewalConfig{Window: 24 * time.Hour})
+	require.NoError(t, err)
+
+	csr := certs.CSR{CSR: generateCSR(t, "0001")}
+	caChain := []byte("ca-chain")
+	first := certs.Certificate{SerialNumber: "first", Certificate: []byte(testCertPEM), ExpiryTime: time.Now().Add(time.Hour)}
+	second := certs.Certificate{SerialNumber: "second", Certificate: []byte(testCertPEM), ExpiryTime: time.Now().Add(certValidityPeriod)}
+
+	// The mappings saved by the service, in the order of their creation.
+	var mappings []certs.Certificate
+	renewed := certs.Certificate{SerialNumber: "renewed", Certificate: []byte(testCertPEM), ExpiryTime: time.Now().Add(certValidityPeriod)}
+	views := map[string]certs.Certificate{first.SerialNumber: first, second.SerialNumber: second, renewed.SerialNumber: renewed}
+	repo.On("SaveCertEntityMapping", mock.Anything, mock.Anything, entityID, domainID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mappings = append(mappings, certs.Certificate{
+				SerialNumber: args.String(1),
+				EntityID:     entityID,
+				DomainID:     domainID,
+				ExpiryTime:   args.Get(4).(time.Time),
+				FromCSR:      args.Bool(5),
+			})
+		}).Return(nil)
+	repo.On("ListCertsByEntityID", mock.Anything, entityID).Return(func(context.Context, string) ([]string, error) {
+		var serialNumbers []string
+		for _, m := range mappings {
+			serialNumbers = append(serialNumbers, m.SerialNumber)
+		}
+		return serialNumbers, nil
+	})
+	repo.On("RetrieveExpiringCerts", mock.Anything, "", mock.Anything, mock.Anything).Return(func(context.Context, string, time.Time, time.Time) ([]certs.Certificate, error) {
+		return mappings[len(mappings)-1:], nil
+	})
+	repo.On("RetrieveRenewalPolicy", mock.Anything, domainID).Return(certs.RenewalPolicy{DomainID: domainID, AutoRenew: true}, nil)
+	agent.On("View", mock.Anything).Return(func(serial string) (certs.Certificate, error) {
+		return views[serial], nil
+	})
+	agent.On("GetCAChain").Return(caChain, nil)
+	agent.On("Renew", mock.Anything, mock.Anything).Return(renewed, nil)
+	bs.On("Authenticate", mock.Anything, "external-id", "external-key").Return(entityID, domainID, nil)
+	bs.On("UpdateCert", mock.Anything, "external-id", testCertPEM, string(caChain)).Return(nil)
+
+	signCall := agent.On("SignCSR", csr.CSR, certValidityPeriod.String()).Return(first, nil)
+	_, err = svc.EnrollCert(context.Background(), "external-id", "external-key", csr)
+	require.NoError(t, err)
+	signCall.Unset()
+
+	renewals, err := svc.RenewExpiringCerts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, renewals, 1)
+	assert.Equal(t, first.SerialNumber, renewals[0].Certificate.SerialNumber)
+	assert.Empty(t, renewals[0].Renewed.SerialNumber, "certificate issued from CSR must not be renewed")
+	agent.AssertNotCalled(t, "Renew", mock.Anything, mock.Anything)
+
+	expired := first
+	expired.ExpiryTime = time.Now().Add(-time.Hour)
+	views[first.SerialNumber] = expired
+
+	agent.On("SignCSR", csr.CSR, certValidityPeriod.String()).Return(second, nil)
+	cert, err := svc.EnrollCert(context.Background(), "external-id", "external-key", csr)
+	require.NoError(t, err, "device with expired certificate must enroll again")
+	assert.Equal(t, second.SerialNumber, cert.SerialNumber)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/pkg/events"
+)
+
+const (
+	certPrefix       = "certs.cert."
+	certExpiring     = certPrefix + "expiring"
+	certRenew        = certPrefix + "renew"
+	certRenewFailure = certPrefix + "renew_failure"
+
+	policyPrefix = "certs.renewal_policy."
+	policySet    = policyPrefix + "set"
+)
+
+var (
+	_ events.Event = (*expiringEvent)(nil)
+	_ events.Event = (*renewEvent)(nil)
+	_ events.Event = (*renewFailureEvent)(nil)
+	_ events.Event = (*policyEvent)(nil)
+)
+
+type expiringEvent struct {
+	certs.Certificate
+}
+
+func (ee expiringEvent) Encode() (map[string]any, error) {
+	return encodeCert(ee.Certificate, certExpiring), nil
+}
+
+// renewEvent carries the renewed certificate and its private key, so the
+// consumers can deliver them to the entity.
+type renewEvent struct {
+	certs.Renewal
+}
+
+func (re renewEvent) Encode() (map[string]any, error) {
+	val := encodeCert(re.Certificate, certRenew)
+	val["renewed_serial_number"] = re.Renewed.SerialNumber
+	val["renewed_expiry_time"] = re.Renewed.ExpiryTime.Format(time.RFC3339)
+	val["certificate"] = string(re.Renewed.Certificate)
+	val["key"] = string(re.Renewed.Key)
+
+	return val, nil
+}
+
+type renewFailureEvent struct {
+	certs.Renewal
+}
+
+func (rfe renewFailureEvent) Encode() (map[string]any, error) {
+	val := encodeCert(rfe.Certificate, certRenewFailure)
+	val["error"] = rfe.Err.Error()
+
+	return val, nil
+}
+
+type policyEvent struct {
+	certs.RenewalPolicy
+}
+
+func (pe policyEvent) Encode() (map[string]any, error) {
+	val := map[string]any{
+		"domain":     pe.DomainID,
+		"auto_renew": pe.AutoRenew,
+		"updated_at": pe.UpdatedAt,
+		"updated_by": pe.UpdatedBy,
+		"operation":  policySet,
+	}
+	if len(pe.Emails) > 0 {
+		val["emails"] = pe.Emails
+	}
+
+	return val, nil
+}
+
+func encodeCert(cert certs.Certificate, operation string) map[string]any {
+	val := map[string]any{
+		"serial_number": cert.SerialNumber,
+		"entity_id":     cert.EntityID,
+		"expiry_time":   cert.ExpiryTime.Format(time.RFC3339),
+		"operation":     operation,
+	}
+	if cert.DomainID != "" {
+		val["domain"] = cert.DomainID
+	}
+
+	return val
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/events"
+)
+
+const (
+	magistralaPrefix   = "magistrala."
+	expiringStream     = magistralaPrefix + certExpiring
+	renewStream        = magistralaPrefix + certRenew
+	renewFailureStream = magistralaPrefix + certRenewFailure
+	setPolicyStream    = magistralaPrefix + policySet
+)
+
+var _ certs.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc certs.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around certs service that sends
+// events to event store.
+func NewEventStoreMiddleware(svc certs.Service, publisher events.Publisher) certs.Service {
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}
+}
+
+func (es *eventStore) RenewCert(ctx context.Context, session authn.Session, serialNumber string) (certs.Certificate, error) {
+	return es.svc.RenewCert(ctx, session, serialNumber)
+}
+
+func (es *eventStore) RevokeBySerial(ctx context.Context, session authn.Session, serialNumber string) error {
+	return es.svc.RevokeBySerial(ctx, session, serialNumber)
+}
+
+func (es *eventStore) RevokeAll(ctx context.Context, session authn.Session, entityID string) error {
+	return es.svc.RevokeAll(ctx, session, entityID)
+}
+
+func (es *eventStore) ViewCert(ctx context.Context, session authn.Session, serialNumber string) (certs.Certificate, error) {
+	return es.svc.ViewCert(ctx, session, serialNumber)
+}
+
+func (es *eventStore) ListCerts(ctx context.Context, session authn.Session, pm certs.PageMetadata) (certs.CertificatePage, error) {
+	return es.svc.ListCerts(ctx, session, pm)
+}
+
+func (es *eventStore) IssueCert(ctx context.Context, session authn.Session, entityID, ttl string, ipAddrs []string, options certs.SubjectOptions) (certs.Certificate, error) {
+	return es.svc.IssueCert(ctx, session, entityID, ttl, ipAddrs, options)
+}
+
+func (es *eventStore) OCSP(ctx context.Context, serialNumber string, ocspRequestDER []byte) ([]byte, error) {
+	return es.svc.OCSP(ctx, serialNumber, ocspRequestDER)
+}
+
+func (es *eventStore) GetEntityID(ctx context.Context, serialNumber string) (string, error) {
+	return es.svc.GetEntityID(ctx, serialNumber)
+}
+
+func (es *eventStore) GenerateCRL(ctx context.Context) ([]byte, error) {
+	return es.svc.GenerateCRL(ctx)
+}
+
+func (es *eventStore) RetrieveCAChain(ctx context.Context) (certs.Certificate, error) {
+	return es.svc.RetrieveCAChain(ctx)
+}
+
+func (es *eventStore) IssueFromCSR(ctx context.Context, session authn.Session, entityID, ttl string, csr certs.CSR) (certs.Certificate, error) {
+	return es.svc.IssueFromCSR(ctx, session, entityID, ttl, csr)
+}
+
+func (es *eventStore) IssueFromCSRInternal(ctx context.Context, entityID, ttl string, csr certs.CSR) (certs.Certificate, error) {
+	return es.svc.IssueFromCSRInternal(ctx, entityID, ttl, csr)
+}
+
+func (es *eventStore) EnrollCert(ctx context.Context, externalID, externalKey string, csr certs.CSR) (certs.Certificate, error) {
+	return es.svc.EnrollCert(ctx, externalID, externalKey, csr)
+}
+
+func (es *eventStore) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr certs.CSR) (certs.Certificate, error) {
+	return es.svc.ReenrollCert(ctx, externalID, externalKey, clientCert, csr)
+}
+
+func (es *eventStore) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (certs.CertificatePage, error) {
+	return es.svc.ListExpiringCerts(ctx, session, within, pm)
+}
+
+func (es *eventStore) SetRenewalPolicy(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (certs.RenewalPolicy, error) {
+	saved, err := es.svc.SetRenewalPolicy(ctx, session, policy)
+	if err != nil {
+		return saved, err
+	}
+
+	if err := es.Publish(ctx, setPolicyStream, policyEvent{saved}); err != nil {
+		return saved, err
+	}
+
+	return saved, nil
+}
+
+func (es *eventStore) ViewRenewalPolicy(ctx context.Context, session authn.Session) (certs.RenewalPolicy, error) {
+	return es.svc.ViewRenewalPolicy(ctx, session)
+}
+
+// RenewExpiringCerts publishes the events of the renewals even if the service
+// returns an error, since the error may be caused by the expiry digests only.
+func (es *eventStore) RenewExpiringCerts(ctx context.Context) ([]certs.Renewal, error) {
+	renewals, err := es.svc.RenewExpiringCerts(ctx)
+
+	for _, r := range renewals {
+		var stream string
+		var ev events.Event
+		switch {
+		case r.Err != nil:
+			stream, ev = renewFailureStream, renewFailureEvent{r}
+		case r.Renewed.SerialNumber != "":
+			stream, ev = renewStream, renewEvent{r}
+		default:
+			stream, ev = expiringStream, expiringEvent{r.Certificate}
+		}
+		if perr := es.Publish(ctx, stream, ev); perr != nil {
+			return renewals, perr
+		}
+	}
+
+	return renewals, err
+}
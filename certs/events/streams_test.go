@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/certs/events"
+	"github.com/absmach/supermq/certs/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	evmocks "github.com/absmach/supermq/pkg/events/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errPublish = errors.New("publish error")
+
+func TestRenewExpiringCerts(t *testing.T) {
+	cert := certs.Certificate{SerialNumber: "serial", EntityID: "entity", DomainID: "domain", ExpiryTime: time.Now().Add(time.Hour)}
+	renewed := certs.Certificate{SerialNumber: "renewed", Certificate: []byte("cert"), Key: []byte("key")}
+
+	cases := []struct {
+		desc       string
+		renewals   []certs.Renewal
+		svcErr     error
+		publishErr error
+		streams    []string
+		err        error
+	}{
+		{
+			desc: "publish events of renewals",
+			renewals: []certs.Renewal{
+				{Certificate: cert},
+				{Certificate: cert, Renewed: renewed},
+				{Certificate: cert, Err: certs.ErrUpdateEntity},
+			},
+			streams: []string{"magistrala.certs.cert.expiring", "magistrala.certs.cert.renew", "magistrala.certs.cert.renew_failure"},
+		},
+		{
+			desc:     "publish events of renewals with failed digest",
+			renewals: []certs.Renewal{{Certificate: cert}},
+			svcErr:   certs.ErrNotifyExpiry,
+			streams:  []string{"magistrala.certs.cert.expiring"},
+			err:      certs.ErrNotifyExpiry,
+		},
+		{
+			desc:       "publish events of renewals with failed publish",
+			renewals:   []certs.Renewal{{Certificate: cert}},
+			publishErr: errPublish,
+			streams:    []string{"magistrala.certs.cert.expiring"},
+			err:        errPublish,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(mocks.Service)
+			publisher := new(evmocks.Publisher)
+			svc.On("RenewExpiringCerts", mock.Anything).Return(tc.renewals, tc.svcErr)
+			publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr)
+
+			renewals, err := events.NewEventStoreMiddleware(svc, publisher).RenewExpiringCerts(context.Background())
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			assert.Equal(t, tc.renewals, renewals)
+
+			publisher.AssertNumberOfCalls(t, "Publish", len(tc.streams))
+			for _, stream := range tc.streams {
+				publisher.AssertCalled(t, "Publish", mock.Anything, stream, mock.Anything)
+			}
+		})
+	}
+}
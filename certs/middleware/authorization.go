@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"time"
 
 	crt "github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/pkg/authn"
@@ -94,6 +95,39 @@ func (am *authorizationMiddleware) IssueFromCSRInternal(ctx context.Context, ent
 	return am.svc.IssueFromCSRInternal(ctx, entityID, ttl, csr)
 }
 
+func (am *authorizationMiddleware) EnrollCert(ctx context.Context, externalID, externalKey string, csr crt.CSR) (crt.Certificate, error) {
+	return am.svc.EnrollCert(ctx, externalID, externalKey, csr)
+}
+
+func (am *authorizationMiddleware) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr crt.CSR) (crt.Certificate, error) {
+	return am.svc.ReenrollCert(ctx, externalID, externalKey, clientCert, csr)
+}
+
+func (am *authorizationMiddleware) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm crt.PageMetadata) (crt.CertificatePage, error) {
+	if err := am.checkUserDomainPermission(ctx, session, policies.MembershipPermission); err != nil {
+		return crt.CertificatePage{}, err
+	}
+	return am.svc.ListExpiringCerts(ctx, session, within, pm)
+}
+
+func (am *authorizationMiddleware) SetRenewalPolicy(ctx context.Context, session authn.Session, policy crt.RenewalPolicy) (crt.RenewalPolicy, error) {
+	if err := am.checkUserDomainPermission(ctx, session, policies.AdminPermission); err != nil {
+		return crt.RenewalPolicy{}, err
+	}
+	return am.svc.SetRenewalPolicy(ctx, session, policy)
+}
+
+func (am *authorizationMiddleware) ViewRenewalPolicy(ctx context.Context, session authn.Session) (crt.RenewalPolicy, error) {
+	if err := am.checkUserDomainPermission(ctx, session, policies.MembershipPermission); err != nil {
+		return crt.RenewalPolicy{}, err
+	}
+	return am.svc.ViewRenewalPolicy(ctx, session)
+}
+
+func (am *authorizationMiddleware) RenewExpiringCerts(ctx context.Context) ([]crt.Renewal, error) {
+	return am.svc.RenewExpiringCerts(ctx)
+}
+
 func (am *authorizationMiddleware) checkUserDomainPermission(ctx context.Context, session authn.Session, permission string) error {
 	req := authz.PolicyReq{
 		Domain:      session.DomainID,
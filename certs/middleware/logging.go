@@ -172,3 +172,85 @@ func (lm *loggingMiddleware) IssueFromCSRInternal(ctx context.Context, entityID,
 	}(time.Now())
 	return lm.svc.IssueFromCSRInternal(ctx, entityID, ttl, csr)
 }
+
+func (lm *loggingMiddleware) EnrollCert(ctx context.Context, externalID, externalKey string, csr certs.CSR) (c certs.Certificate, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enroll_cert for device %s took %s to complete", externalID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+	return lm.svc.EnrollCert(ctx, externalID, externalKey, csr)
+}
+
+func (lm *loggingMiddleware) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr certs.CSR) (c certs.Certificate, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method reenroll_cert for device %s took %s to complete", externalID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+	return lm.svc.ReenrollCert(ctx, externalID, externalKey, clientCert, csr)
+}
+
+func (lm *loggingMiddleware) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (cp certs.CertificatePage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_expiring_certs within %s took %s to complete", within, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+	return lm.svc.ListExpiringCerts(ctx, session, within, pm)
+}
+
+func (lm *loggingMiddleware) SetRenewalPolicy(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (p certs.RenewalPolicy, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method set_renewal_policy for domain %s took %s to complete", session.DomainID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+	return lm.svc.SetRenewalPolicy(ctx, session, policy)
+}
+
+func (lm *loggingMiddleware) ViewRenewalPolicy(ctx context.Context, session authn.Session) (p certs.RenewalPolicy, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_renewal_policy for domain %s took %s to complete", session.DomainID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(message)
+	}(time.Now())
+	return lm.svc.ViewRenewalPolicy(ctx, session)
+}
+
+func (lm *loggingMiddleware) RenewExpiringCerts(ctx context.Context) (renewals []certs.Renewal, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method renew_expiring_certs took %s to complete", time.Since(begin))
+		var renewed, failed int
+		for _, r := range renewals {
+			switch {
+			case r.Err != nil:
+				failed++
+				lm.logger.Warn(fmt.Sprintf("Failed to renew cert %s for entity %s with error: %s.", r.Certificate.SerialNumber, r.Certificate.EntityID, r.Err))
+			case r.Renewed.SerialNumber != "":
+				renewed++
+			}
+		}
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s and found %d expiring certs, renewed %d and failed to renew %d.", message, len(renewals), renewed, failed))
+	}(time.Now())
+	return lm.svc.RenewExpiringCerts(ctx)
+}
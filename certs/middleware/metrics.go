@@ -125,3 +125,51 @@ func (mm *metricsMiddleware) IssueFromCSRInternal(ctx context.Context, entityID,
 	}(time.Now())
 	return mm.svc.IssueFromCSRInternal(ctx, entityID, ttl, csr)
 }
+
+func (mm *metricsMiddleware) EnrollCert(ctx context.Context, externalID, externalKey string, csr certs.CSR) (certs.Certificate, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "enroll_cert").Add(1)
+		mm.latency.With("method", "enroll_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.EnrollCert(ctx, externalID, externalKey, csr)
+}
+
+func (mm *metricsMiddleware) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr certs.CSR) (certs.Certificate, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "reenroll_cert").Add(1)
+		mm.latency.With("method", "reenroll_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.ReenrollCert(ctx, externalID, externalKey, clientCert, csr)
+}
+
+func (mm *metricsMiddleware) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (certs.CertificatePage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_expiring_certs").Add(1)
+		mm.latency.With("method", "list_expiring_certs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.ListExpiringCerts(ctx, session, within, pm)
+}
+
+func (mm *metricsMiddleware) SetRenewalPolicy(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (certs.RenewalPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "set_renewal_policy").Add(1)
+		mm.latency.With("method", "set_renewal_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.SetRenewalPolicy(ctx, session, policy)
+}
+
+func (mm *metricsMiddleware) ViewRenewalPolicy(ctx context.Context, session authn.Session) (certs.RenewalPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_renewal_policy").Add(1)
+		mm.latency.With("method", "view_renewal_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.ViewRenewalPolicy(ctx, session)
+}
+
+func (mm *metricsMiddleware) RenewExpiringCerts(ctx context.Context) ([]certs.Renewal, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "renew_expiring_certs").Add(1)
+		mm.latency.With("method", "renew_expiring_certs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.svc.RenewExpiringCerts(ctx)
+}
@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/pkg/authn"
@@ -94,3 +95,39 @@ func (tm *tracingMiddleware) IssueFromCSRInternal(ctx context.Context, entityID,
 	defer span.End()
 	return tm.svc.IssueFromCSRInternal(ctx, entityID, ttl, csr)
 }
+
+func (tm *tracingMiddleware) EnrollCert(ctx context.Context, externalID, externalKey string, csr certs.CSR) (certs.Certificate, error) {
+	ctx, span := tm.tracer.Start(ctx, "enroll_cert")
+	defer span.End()
+	return tm.svc.EnrollCert(ctx, externalID, externalKey, csr)
+}
+
+func (tm *tracingMiddleware) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr certs.CSR) (certs.Certificate, error) {
+	ctx, span := tm.tracer.Start(ctx, "reenroll_cert")
+	defer span.End()
+	return tm.svc.ReenrollCert(ctx, externalID, externalKey, clientCert, csr)
+}
+
+func (tm *tracingMiddleware) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (certs.CertificatePage, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_expiring_certs")
+	defer span.End()
+	return tm.svc.ListExpiringCerts(ctx, session, within, pm)
+}
+
+func (tm *tracingMiddleware) SetRenewalPolicy(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (certs.RenewalPolicy, error) {
+	ctx, span := tm.tracer.Start(ctx, "set_renewal_policy")
+	defer span.End()
+	return tm.svc.SetRenewalPolicy(ctx, session, policy)
+}
+
+func (tm *tracingMiddleware) ViewRenewalPolicy(ctx context.Context, session authn.Session) (certs.RenewalPolicy, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_renewal_policy")
+	defer span.End()
+	return tm.svc.ViewRenewalPolicy(ctx, session)
+}
+
+func (tm *tracingMiddleware) RenewExpiringCerts(ctx context.Context) ([]certs.Renewal, error) {
+	ctx, span := tm.tracer.Start(ctx, "renew_expiring_certs")
+	defer span.End()
+	return tm.svc.RenewExpiringCerts(ctx)
+}
@@ -10,6 +10,9 @@ package mocks
 
 import (
 	"context"
+	"time"
+
+	"github.com/absmach/supermq/certs"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -41,6 +44,72 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// GetDomainIDBySerial provides a mock function for the type Repository
+func (_mock *Repository) GetDomainIDBySerial(ctx context.Context, serialNumber string) (string, error) {
+	ret := _mock.Called(ctx, serialNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDomainIDBySerial")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, serialNumber)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, serialNumber)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serialNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_GetDomainIDBySerial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDomainIDBySerial'
+type Repository_GetDomainIDBySerial_Call struct {
+	*mock.Call
+}
+
+// GetDomainIDBySerial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serialNumber string
+func (_e *Repository_Expecter) GetDomainIDBySerial(ctx interface{}, serialNumber interface{}) *Repository_GetDomainIDBySerial_Call {
+	return &Repository_GetDomainIDBySerial_Call{Call: _e.mock.On("GetDomainIDBySerial", ctx, serialNumber)}
+}
+
+func (_c *Repository_GetDomainIDBySerial_Call) Run(run func(ctx context.Context, serialNumber string)) *Repository_GetDomainIDBySerial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_GetDomainIDBySerial_Call) Return(s string, err error) *Repository_GetDomainIDBySerial_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Repository_GetDomainIDBySerial_Call) RunAndReturn(run func(ctx context.Context, serialNumber string) (string, error)) *Repository_GetDomainIDBySerial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEntityIDBySerial provides a mock function for the type Repository
 func (_mock *Repository) GetEntityIDBySerial(ctx context.Context, serialNumber string) (string, error) {
 	ret := _mock.Called(ctx, serialNumber)
@@ -232,17 +301,163 @@ func (_c *Repository_RemoveCertEntityMapping_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// RetrieveExpiringCerts provides a mock function for the type Repository
+func (_mock *Repository) RetrieveExpiringCerts(ctx context.Context, domainID string, from time.Time, to time.Time) ([]certs.Certificate, error) {
+	ret := _mock.Called(ctx, domainID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveExpiringCerts")
+	}
+
+	var r0 []certs.Certificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]certs.Certificate, error)); ok {
+		return returnFunc(ctx, domainID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []certs.Certificate); ok {
+		r0 = returnFunc(ctx, domainID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]certs.Certificate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, domainID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveExpiringCerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveExpiringCerts'
+type Repository_RetrieveExpiringCerts_Call struct {
+	*mock.Call
+}
+
+// RetrieveExpiringCerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - from time.Time
+//   - to time.Time
+func (_e *Repository_Expecter) RetrieveExpiringCerts(ctx interface{}, domainID interface{}, from interface{}, to interface{}) *Repository_RetrieveExpiringCerts_Call {
+	return &Repository_RetrieveExpiringCerts_Call{Call: _e.mock.On("RetrieveExpiringCerts", ctx, domainID, from, to)}
+}
+
+func (_c *Repository_RetrieveExpiringCerts_Call) Run(run func(ctx context.Context, domainID string, from time.Time, to time.Time)) *Repository_RetrieveExpiringCerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveExpiringCerts_Call) Return(certificates []certs.Certificate, err error) *Repository_RetrieveExpiringCerts_Call {
+	_c.Call.Return(certificates, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveExpiringCerts_Call) RunAndReturn(run func(ctx context.Context, domainID string, from time.Time, to time.Time) ([]certs.Certificate, error)) *Repository_RetrieveExpiringCerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveRenewalPolicy provides a mock function for the type Repository
+func (_mock *Repository) RetrieveRenewalPolicy(ctx context.Context, domainID string) (certs.RenewalPolicy, error) {
+	ret := _mock.Called(ctx, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveRenewalPolicy")
+	}
+
+	var r0 certs.RenewalPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (certs.RenewalPolicy, error)); ok {
+		return returnFunc(ctx, domainID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) certs.RenewalPolicy); ok {
+		r0 = returnFunc(ctx, domainID)
+	} else {
+		r0 = ret.Get(0).(certs.RenewalPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveRenewalPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveRenewalPolicy'
+type Repository_RetrieveRenewalPolicy_Call struct {
+	*mock.Call
+}
+
+// RetrieveRenewalPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+func (_e *Repository_Expecter) RetrieveRenewalPolicy(ctx interface{}, domainID interface{}) *Repository_RetrieveRenewalPolicy_Call {
+	return &Repository_RetrieveRenewalPolicy_Call{Call: _e.mock.On("RetrieveRenewalPolicy", ctx, domainID)}
+}
+
+func (_c *Repository_RetrieveRenewalPolicy_Call) Run(run func(ctx context.Context, domainID string)) *Repository_RetrieveRenewalPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveRenewalPolicy_Call) Return(renewalPolicy certs.RenewalPolicy, err error) *Repository_RetrieveRenewalPolicy_Call {
+	_c.Call.Return(renewalPolicy, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveRenewalPolicy_Call) RunAndReturn(run func(ctx context.Context, domainID string) (certs.RenewalPolicy, error)) *Repository_RetrieveRenewalPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveCertEntityMapping provides a mock function for the type Repository
-func (_mock *Repository) SaveCertEntityMapping(ctx context.Context, serialNumber string, entityID string) error {
-	ret := _mock.Called(ctx, serialNumber, entityID)
+func (_mock *Repository) SaveCertEntityMapping(ctx context.Context, serialNumber string, entityID string, domainID string, expiryTime time.Time, fromCSR bool) error {
+	ret := _mock.Called(ctx, serialNumber, entityID, domainID, expiryTime, fromCSR)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveCertEntityMapping")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, serialNumber, entityID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time, bool) error); ok {
+		r0 = returnFunc(ctx, serialNumber, entityID, domainID, expiryTime, fromCSR)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -258,11 +473,14 @@ type Repository_SaveCertEntityMapping_Call struct {
 //   - ctx context.Context
 //   - serialNumber string
 //   - entityID string
-func (_e *Repository_Expecter) SaveCertEntityMapping(ctx interface{}, serialNumber interface{}, entityID interface{}) *Repository_SaveCertEntityMapping_Call {
-	return &Repository_SaveCertEntityMapping_Call{Call: _e.mock.On("SaveCertEntityMapping", ctx, serialNumber, entityID)}
+//   - domainID string
+//   - expiryTime time.Time
+//   - fromCSR bool
+func (_e *Repository_Expecter) SaveCertEntityMapping(ctx interface{}, serialNumber interface{}, entityID interface{}, domainID interface{}, expiryTime interface{}, fromCSR interface{}) *Repository_SaveCertEntityMapping_Call {
+	return &Repository_SaveCertEntityMapping_Call{Call: _e.mock.On("SaveCertEntityMapping", ctx, serialNumber, entityID, domainID, expiryTime, fromCSR)}
 }
 
-func (_c *Repository_SaveCertEntityMapping_Call) Run(run func(ctx context.Context, serialNumber string, entityID string)) *Repository_SaveCertEntityMapping_Call {
+func (_c *Repository_SaveCertEntityMapping_Call) Run(run func(ctx context.Context, serialNumber string, entityID string, domainID string, expiryTime time.Time, fromCSR bool)) *Repository_SaveCertEntityMapping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -276,10 +494,25 @@ func (_c *Repository_SaveCertEntityMapping_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 bool
+		if args[5] != nil {
+			arg5 = args[5].(bool)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -290,7 +523,64 @@ func (_c *Repository_SaveCertEntityMapping_Call) Return(err error) *Repository_S
 	return _c
 }
 
-func (_c *Repository_SaveCertEntityMapping_Call) RunAndReturn(run func(ctx context.Context, serialNumber string, entityID string) error) *Repository_SaveCertEntityMapping_Call {
+func (_c *Repository_SaveCertEntityMapping_Call) RunAndReturn(run func(ctx context.Context, serialNumber string, entityID string, domainID string, expiryTime time.Time, fromCSR bool) error) *Repository_SaveCertEntityMapping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveRenewalPolicy provides a mock function for the type Repository
+func (_mock *Repository) SaveRenewalPolicy(ctx context.Context, policy certs.RenewalPolicy) error {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRenewalPolicy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, certs.RenewalPolicy) error); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_SaveRenewalPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRenewalPolicy'
+type Repository_SaveRenewalPolicy_Call struct {
+	*mock.Call
+}
+
+// SaveRenewalPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy certs.RenewalPolicy
+func (_e *Repository_Expecter) SaveRenewalPolicy(ctx interface{}, policy interface{}) *Repository_SaveRenewalPolicy_Call {
+	return &Repository_SaveRenewalPolicy_Call{Call: _e.mock.On("SaveRenewalPolicy", ctx, policy)}
+}
+
+func (_c *Repository_SaveRenewalPolicy_Call) Run(run func(ctx context.Context, policy certs.RenewalPolicy)) *Repository_SaveRenewalPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 certs.RenewalPolicy
+		if args[1] != nil {
+			arg1 = args[1].(certs.RenewalPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SaveRenewalPolicy_Call) Return(err error) *Repository_SaveRenewalPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_SaveRenewalPolicy_Call) RunAndReturn(run func(ctx context.Context, policy certs.RenewalPolicy) error) *Repository_SaveRenewalPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/pkg/authn"
@@ -43,6 +44,84 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// EnrollCert provides a mock function for the type Service
+func (_mock *Service) EnrollCert(ctx context.Context, externalID string, externalKey string, csr certs.CSR) (certs.Certificate, error) {
+	ret := _mock.Called(ctx, externalID, externalKey, csr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollCert")
+	}
+
+	var r0 certs.Certificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, certs.CSR) (certs.Certificate, error)); ok {
+		return returnFunc(ctx, externalID, externalKey, csr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, certs.CSR) certs.Certificate); ok {
+		r0 = returnFunc(ctx, externalID, externalKey, csr)
+	} else {
+		r0 = ret.Get(0).(certs.Certificate)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, certs.CSR) error); ok {
+		r1 = returnFunc(ctx, externalID, externalKey, csr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_EnrollCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollCert'
+type Service_EnrollCert_Call struct {
+	*mock.Call
+}
+
+// EnrollCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+//   - externalKey string
+//   - csr certs.CSR
+func (_e *Service_Expecter) EnrollCert(ctx interface{}, externalID interface{}, externalKey interface{}, csr interface{}) *Service_EnrollCert_Call {
+	return &Service_EnrollCert_Call{Call: _e.mock.On("EnrollCert", ctx, externalID, externalKey, csr)}
+}
+
+func (_c *Service_EnrollCert_Call) Run(run func(ctx context.Context, externalID string, externalKey string, csr certs.CSR)) *Service_EnrollCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 certs.CSR
+		if args[3] != nil {
+			arg3 = args[3].(certs.CSR)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_EnrollCert_Call) Return(certificate certs.Certificate, err error) *Service_EnrollCert_Call {
+	_c.Call.Return(certificate, err)
+	return _c
+}
+
+func (_c *Service_EnrollCert_Call) RunAndReturn(run func(ctx context.Context, externalID string, externalKey string, csr certs.CSR) (certs.Certificate, error)) *Service_EnrollCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateCRL provides a mock function for the type Service
 func (_mock *Service) GenerateCRL(ctx context.Context) ([]byte, error) {
 	ret := _mock.Called(ctx)
@@ -495,6 +574,84 @@ func (_c *Service_ListCerts_Call) RunAndReturn(run func(ctx context.Context, ses
 	return _c
 }
 
+// ListExpiringCerts provides a mock function for the type Service
+func (_mock *Service) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (certs.CertificatePage, error) {
+	ret := _mock.Called(ctx, session, within, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiringCerts")
+	}
+
+	var r0 certs.CertificatePage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, time.Duration, certs.PageMetadata) (certs.CertificatePage, error)); ok {
+		return returnFunc(ctx, session, within, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, time.Duration, certs.PageMetadata) certs.CertificatePage); ok {
+		r0 = returnFunc(ctx, session, within, pm)
+	} else {
+		r0 = ret.Get(0).(certs.CertificatePage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, time.Duration, certs.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, session, within, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListExpiringCerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiringCerts'
+type Service_ListExpiringCerts_Call struct {
+	*mock.Call
+}
+
+// ListExpiringCerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - within time.Duration
+//   - pm certs.PageMetadata
+func (_e *Service_Expecter) ListExpiringCerts(ctx interface{}, session interface{}, within interface{}, pm interface{}) *Service_ListExpiringCerts_Call {
+	return &Service_ListExpiringCerts_Call{Call: _e.mock.On("ListExpiringCerts", ctx, session, within, pm)}
+}
+
+func (_c *Service_ListExpiringCerts_Call) Run(run func(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata)) *Service_ListExpiringCerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		var arg3 certs.PageMetadata
+		if args[3] != nil {
+			arg3 = args[3].(certs.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListExpiringCerts_Call) Return(certificatePage certs.CertificatePage, err error) *Service_ListExpiringCerts_Call {
+	_c.Call.Return(certificatePage, err)
+	return _c
+}
+
+func (_c *Service_ListExpiringCerts_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, within time.Duration, pm certs.PageMetadata) (certs.CertificatePage, error)) *Service_ListExpiringCerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OCSP provides a mock function for the type Service
 func (_mock *Service) OCSP(ctx context.Context, serialNumber string, ocspRequestDER []byte) ([]byte, error) {
 	ret := _mock.Called(ctx, serialNumber, ocspRequestDER)
@@ -569,6 +726,90 @@ func (_c *Service_OCSP_Call) RunAndReturn(run func(ctx context.Context, serialNu
 	return _c
 }
 
+// ReenrollCert provides a mock function for the type Service
+func (_mock *Service) ReenrollCert(ctx context.Context, externalID string, externalKey string, clientCert []byte, csr certs.CSR) (certs.Certificate, error) {
+	ret := _mock.Called(ctx, externalID, externalKey, clientCert, csr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReenrollCert")
+	}
+
+	var r0 certs.Certificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte, certs.CSR) (certs.Certificate, error)); ok {
+		return returnFunc(ctx, externalID, externalKey, clientCert, csr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte, certs.CSR) certs.Certificate); ok {
+		r0 = returnFunc(ctx, externalID, externalKey, clientCert, csr)
+	} else {
+		r0 = ret.Get(0).(certs.Certificate)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []byte, certs.CSR) error); ok {
+		r1 = returnFunc(ctx, externalID, externalKey, clientCert, csr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ReenrollCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReenrollCert'
+type Service_ReenrollCert_Call struct {
+	*mock.Call
+}
+
+// ReenrollCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+//   - externalKey string
+//   - clientCert []byte
+//   - csr certs.CSR
+func (_e *Service_Expecter) ReenrollCert(ctx interface{}, externalID interface{}, externalKey interface{}, clientCert interface{}, csr interface{}) *Service_ReenrollCert_Call {
+	return &Service_ReenrollCert_Call{Call: _e.mock.On("ReenrollCert", ctx, externalID, externalKey, clientCert, csr)}
+}
+
+func (_c *Service_ReenrollCert_Call) Run(run func(ctx context.Context, externalID string, externalKey string, clientCert []byte, csr certs.CSR)) *Service_ReenrollCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		var arg4 certs.CSR
+		if args[4] != nil {
+			arg4 = args[4].(certs.CSR)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ReenrollCert_Call) Return(certificate certs.Certificate, err error) *Service_ReenrollCert_Call {
+	_c.Call.Return(certificate, err)
+	return _c
+}
+
+func (_c *Service_ReenrollCert_Call) RunAndReturn(run func(ctx context.Context, externalID string, externalKey string, clientCert []byte, csr certs.CSR) (certs.Certificate, error)) *Service_ReenrollCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RenewCert provides a mock function for the type Service
 func (_mock *Service) RenewCert(ctx context.Context, session authn.Session, serialNumber string) (certs.Certificate, error) {
 	ret := _mock.Called(ctx, session, serialNumber)
@@ -641,6 +882,68 @@ func (_c *Service_RenewCert_Call) RunAndReturn(run func(ctx context.Context, ses
 	return _c
 }
 
+// RenewExpiringCerts provides a mock function for the type Service
+func (_mock *Service) RenewExpiringCerts(ctx context.Context) ([]certs.Renewal, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewExpiringCerts")
+	}
+
+	var r0 []certs.Renewal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]certs.Renewal, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []certs.Renewal); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]certs.Renewal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_RenewExpiringCerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewExpiringCerts'
+type Service_RenewExpiringCerts_Call struct {
+	*mock.Call
+}
+
+// RenewExpiringCerts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Service_Expecter) RenewExpiringCerts(ctx interface{}) *Service_RenewExpiringCerts_Call {
+	return &Service_RenewExpiringCerts_Call{Call: _e.mock.On("RenewExpiringCerts", ctx)}
+}
+
+func (_c *Service_RenewExpiringCerts_Call) Run(run func(ctx context.Context)) *Service_RenewExpiringCerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RenewExpiringCerts_Call) Return(renewals []certs.Renewal, err error) *Service_RenewExpiringCerts_Call {
+	_c.Call.Return(renewals, err)
+	return _c
+}
+
+func (_c *Service_RenewExpiringCerts_Call) RunAndReturn(run func(ctx context.Context) ([]certs.Renewal, error)) *Service_RenewExpiringCerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveCAChain provides a mock function for the type Service
 func (_mock *Service) RetrieveCAChain(ctx context.Context) (certs.Certificate, error) {
 	ret := _mock.Called(ctx)
@@ -827,6 +1130,78 @@ func (_c *Service_RevokeBySerial_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// SetRenewalPolicy provides a mock function for the type Service
+func (_mock *Service) SetRenewalPolicy(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (certs.RenewalPolicy, error) {
+	ret := _mock.Called(ctx, session, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRenewalPolicy")
+	}
+
+	var r0 certs.RenewalPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, certs.RenewalPolicy) (certs.RenewalPolicy, error)); ok {
+		return returnFunc(ctx, session, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, certs.RenewalPolicy) certs.RenewalPolicy); ok {
+		r0 = returnFunc(ctx, session, policy)
+	} else {
+		r0 = ret.Get(0).(certs.RenewalPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, certs.RenewalPolicy) error); ok {
+		r1 = returnFunc(ctx, session, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_SetRenewalPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRenewalPolicy'
+type Service_SetRenewalPolicy_Call struct {
+	*mock.Call
+}
+
+// SetRenewalPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - policy certs.RenewalPolicy
+func (_e *Service_Expecter) SetRenewalPolicy(ctx interface{}, session interface{}, policy interface{}) *Service_SetRenewalPolicy_Call {
+	return &Service_SetRenewalPolicy_Call{Call: _e.mock.On("SetRenewalPolicy", ctx, session, policy)}
+}
+
+func (_c *Service_SetRenewalPolicy_Call) Run(run func(ctx context.Context, session authn.Session, policy certs.RenewalPolicy)) *Service_SetRenewalPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 certs.RenewalPolicy
+		if args[2] != nil {
+			arg2 = args[2].(certs.RenewalPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_SetRenewalPolicy_Call) Return(renewalPolicy certs.RenewalPolicy, err error) *Service_SetRenewalPolicy_Call {
+	_c.Call.Return(renewalPolicy, err)
+	return _c
+}
+
+func (_c *Service_SetRenewalPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, policy certs.RenewalPolicy) (certs.RenewalPolicy, error)) *Service_SetRenewalPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewCert provides a mock function for the type Service
 func (_mock *Service) ViewCert(ctx context.Context, session authn.Session, serialNumber string) (certs.Certificate, error) {
 	ret := _mock.Called(ctx, session, serialNumber)
@@ -898,3 +1273,69 @@ func (_c *Service_ViewCert_Call) RunAndReturn(run func(ctx context.Context, sess
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewRenewalPolicy provides a mock function for the type Service
+func (_mock *Service) ViewRenewalPolicy(ctx context.Context, session authn.Session) (certs.RenewalPolicy, error) {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRenewalPolicy")
+	}
+
+	var r0 certs.RenewalPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session) (certs.RenewalPolicy, error)); ok {
+		return returnFunc(ctx, session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session) certs.RenewalPolicy); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Get(0).(certs.RenewalPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = returnFunc(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewRenewalPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewRenewalPolicy'
+type Service_ViewRenewalPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewRenewalPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+func (_e *Service_Expecter) ViewRenewalPolicy(ctx interface{}, session interface{}) *Service_ViewRenewalPolicy_Call {
+	return &Service_ViewRenewalPolicy_Call{Call: _e.mock.On("ViewRenewalPolicy", ctx, session)}
+}
+
+func (_c *Service_ViewRenewalPolicy_Call) Run(run func(ctx context.Context, session authn.Session)) *Service_ViewRenewalPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewRenewalPolicy_Call) Return(renewalPolicy certs.RenewalPolicy, err error) *Service_ViewRenewalPolicy_Call {
+	_c.Call.Return(renewalPolicy, err)
+	return _c
+}
+
+func (_c *Service_ViewRenewalPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session) (certs.RenewalPolicy, error)) *Service_ViewRenewalPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notifier sends the digests of the certificates about to expire by email.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/pkg/emailer"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	emailHeader = "Certificates expiry digest"
+	emailFooter = "You receive this email because of the certificate renewal policy of the domain."
+)
+
+var errEmail = errors.New("failed to send expiry digest email")
+
+var _ certs.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	emailer emailer.Emailer
+}
+
+// New returns the notifier which sends the expiry digests using the emailer.
+func New(e emailer.Emailer) certs.Notifier {
+	return &notifier{emailer: e}
+}
+
+func (n *notifier) Notify(_ context.Context, emails []string, digest certs.ExpiryDigest) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	if n.emailer == nil {
+		return errEmail
+	}
+	subject := fmt.Sprintf("%d certificates expiring within %s", len(digest.Renewals), digest.Window)
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "Domain: %s\n", digest.DomainID)
+	for _, r := range digest.Renewals {
+		c := r.Certificate
+		fmt.Fprintf(&content, "\nCertificate: %s\n", c.SerialNumber)
+		fmt.Fprintf(&content, "Entity: %s\n", c.EntityID)
+		fmt.Fprintf(&content, "Expires at: %s\n", c.ExpiryTime.Format(time.RFC3339))
+		switch {
+		case r.Err != nil:
+			fmt.Fprintf(&content, "Renewal failed: %s\n", r.Err)
+		case r.Renewed.SerialNumber != "":
+			fmt.Fprintf(&content, "Renewed as: %s, expires at %s\n", r.Renewed.SerialNumber, r.Renewed.ExpiryTime.Format(time.RFC3339))
+		default:
+			fmt.Fprintf(&content, "Renewal required\n")
+		}
+	}
+
+	if err := n.emailer.SendEmailNotification(emails, "", subject, emailHeader, "", content.String(), emailFooter, make(map[string][]byte)); err != nil {
+		return errors.Wrap(errEmail, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifier_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/certs/notifier"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/emailer/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errEmail = errors.New("email error")
+
+func TestNotify(t *testing.T) {
+	expiry := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	digest := certs.ExpiryDigest{
+		DomainID: testsutil.GenerateUUID(t),
+		Window:   certs.DefaultRenewalWindow,
+		Renewals: []certs.Renewal{
+			{
+				Certificate: certs.Certificate{SerialNumber: "pending", EntityID: testsutil.GenerateUUID(t), ExpiryTime: expiry},
+			},
+			{
+				Certificate: certs.Certificate{SerialNumber: "renewed", EntityID: testsutil.GenerateUUID(t), ExpiryTime: expiry},
+				Renewed:     certs.Certificate{SerialNumber: "new-serial", ExpiryTime: expiry.Add(time.Hour)},
+			},
+			{
+				Certificate: certs.Certificate{SerialNumber: "failed", EntityID: testsutil.GenerateUUID(t), ExpiryTime: expiry},
+				Err:         certs.ErrUpdateEntity,
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		emails   []string
+		emailErr error
+		err      error
+	}{
+		{
+			desc:   "notify emails successfully",
+			emails: []string{"admin@example.com"},
+		},
+		{
+			desc: "notify without emails",
+		},
+		{
+			desc:     "notify with failed email",
+			emails:   []string{"admin@example.com"},
+			emailErr: errEmail,
+			err:      errEmail,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			emailer := new(mocks.Emailer)
+			var content string
+			call := emailer.On("SendEmailNotification", tc.emails, "", mock.Anything, mock.Anything, "", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { content = args.String(5) }).
+				Return(tc.emailErr)
+
+			err := notifier.New(emailer).Notify(context.Background(), tc.emails, digest)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+
+			if len(tc.emails) > 0 {
+				emailer.AssertCalled(t, "SendEmailNotification", tc.emails, "", "3 certificates expiring within 168h0m0s", mock.Anything, "", mock.Anything, mock.Anything, mock.Anything)
+				assert.True(t, strings.Contains(content, "Renewal required"))
+				assert.True(t, strings.Contains(content, "Renewed as: new-serial"))
+				assert.True(t, strings.Contains(content, "Renewal failed: "+certs.ErrUpdateEntity.Error()))
+			} else {
+				emailer.AssertNotCalled(t, "SendEmailNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			call.Unset()
+		})
+	}
+}
@@ -0,0 +1,821 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/certs/api"
+	"github.com/absmach/supermq/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	rootCertFile         = "root_ca.pem"
+	rootKeyFile          = "root_ca_key.pem"
+	intermediateCertFile = "intermediate_ca.pem"
+	intermediateKeyFile  = "intermediate_ca_key.pem"
+	issuedDir            = "issued"
+
+	certBlock = "CERTIFICATE"
+	keyBlock  = "RSA PRIVATE KEY"
+	csrBlock  = "CERTIFICATE REQUEST"
+
+	keyBits         = 2048
+	serialBits      = 159
+	rootTTL         = 87600 * time.Hour
+	intermediateTTL = 8760 * time.Hour
+	defaultCertTTL  = 720 * time.Hour
+	crlValidity     = 72 * time.Hour
+	ocspValidity    = 12 * time.Hour
+	clockSkew       = 30 * time.Second
+
+	// The intermediate CA is renewed once it expires within the renewal
+	// period, and it stops issuing the certificates once it expires within
+	// the expiry margin, which happens only when the root CA expires.
+	intermediateRenewBefore = 720 * time.Hour
+	caExpiryMargin          = 24 * time.Hour
+	caCheckInterval         = time.Hour
+)
+
+var (
+	errCertNotFound   = errors.New("certificate not found")
+	errIncompleteCA   = errors.New("incomplete local CA, both certificate and key are required")
+	errInvalidTTL     = errors.New("invalid certificate TTL")
+	errMissingCAName  = errors.New("missing local CA common name")
+	errInvalidCSR     = errors.New("invalid CSR")
+	errInvalidCAFiles = errors.New("invalid local CA files")
+	errCAExpired      = errors.New("local intermediate CA is expired or about to expire")
+	errRootExpiring   = errors.New("local root CA expires before the intermediate CA can be renewed")
+)
+
+// LocalConfig configures the local CA.
+type LocalConfig struct {
+	// Dir is the directory which holds the CA certificates, the CA keys
+	// and the issued certificates.
+	Dir string
+	// Subject is the subject of the root CA. The intermediate CA uses the same
+	// subject with the " Intermediate" suffix of the common name. Its DNS names
+	// and IP addresses are added to all issued certificates.
+	Subject certs.SubjectOptions
+}
+
+// issuedCert is the record of the certificate issued by the local CA.
+type issuedCert struct {
+	Certificate string    `json:"certificate"`
+	RevokedAt   time.Time `json:"revoked_at,omitzero"`
+}
+
+type localPKIAgent struct {
+	dir              string
+	root             *x509.Certificate
+	rootPEM          []byte
+	rootKey          *rsa.PrivateKey
+	intermediate     *x509.Certificate
+	intermediatePEM  []byte
+	intermediateKey  *rsa.PrivateKey
+	defaultDNSNames  []string
+	defaultIPAddress []net.IP
+	logger           *slog.Logger
+	mu               sync.RWMutex
+}
+
+// NewLocalAgent instantiates the local CA that implements certs.Agent.
+// The root and the intermediate CA are loaded from the configured directory,
+// or generated and stored there on the first start.
+func NewLocalAgent(cfg LocalConfig, logger *slog.Logger) (certs.Agent, error) {
+	if err := os.MkdirAll(filepath.Join(cfg.Dir, issuedDir), 0o700); err != nil {
+		return nil, err
+	}
+
+	agent := &localPKIAgent{
+		dir:    cfg.Dir,
+		logger: logger,
+	}
+
+	rootKey, err := agent.loadOrCreateCA(rootCertFile, rootKeyFile, nil, nil, cfg.Subject, rootTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up root CA: %w", err)
+	}
+
+	subject := cfg.Subject
+	subject.CommonName += " Intermediate"
+	intermediateKey, err := agent.loadOrCreateCA(intermediateCertFile, intermediateKeyFile, agent.root, rootKey, subject, intermediateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up intermediate CA: %w", err)
+	}
+	agent.rootKey = rootKey
+	agent.intermediateKey = intermediateKey
+	agent.defaultDNSNames = agent.intermediate.DNSNames
+	agent.defaultIPAddress = agent.intermediate.IPAddresses
+
+	if err := agent.renewIntermediate(); err != nil {
+		logger.Warn("Failed to renew local intermediate CA", "expiry_time", agent.intermediate.NotAfter, "error", err)
+	}
+
+	return agent, nil
+}
+
+// renewIntermediate renews the intermediate CA certificate if it expires within
+// the renewal period. The renewed certificate keeps the subject and the key of
+// the intermediate CA, so the certificates it has already issued, the CRL and
+// the OCSP responses still verify against it.
+func (agent *localPKIAgent) renewIntermediate() error {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	now := time.Now()
+	current := agent.intermediate
+	if now.Add(intermediateRenewBefore).Before(current.NotAfter) {
+		return nil
+	}
+	notAfter := now.Add(intermediateTTL)
+	if notAfter.After(agent.root.NotAfter) {
+		notAfter = agent.root.NotAfter
+	}
+	if !notAfter.After(current.NotAfter) {
+		return errRootExpiring
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               current.Subject,
+		DNSNames:              current.DNSNames,
+		IPAddresses:           current.IPAddresses,
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              notAfter,
+		KeyUsage:              current.KeyUsage,
+		SubjectKeyId:          current.SubjectKeyId,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, agent.root, &agent.intermediateKey.PublicKey, agent.rootKey)
+	if err != nil {
+		return err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: certBlock, Bytes: der})
+	if err := writeFile(filepath.Join(agent.dir, intermediateCertFile), certPEM, 0o644); err != nil {
+		return err
+	}
+	agent.intermediate, agent.intermediatePEM = cert, certPEM
+	agent.logger.Info("Local intermediate CA renewed", "common_name", cert.Subject.CommonName, "expiry_time", cert.NotAfter)
+
+	return nil
+}
+
+// loadOrCreateCA loads the CA from the files, or creates the CA signed by the parent
+// if the files do not exist. The CA is self-signed if the parent is nil.
+func (agent *localPKIAgent) loadOrCreateCA(certFile, keyFile string, parent *x509.Certificate, parentKey *rsa.PrivateKey, subject certs.SubjectOptions, ttl time.Duration) (*rsa.PrivateKey, error) {
+	certPath := filepath.Join(agent.dir, certFile)
+	keyPath := filepath.Join(agent.dir, keyFile)
+
+	certPEM, certErr := os.ReadFile(certPath)
+	keyPEM, keyErr := os.ReadFile(keyPath)
+	switch {
+	case certErr == nil && keyErr == nil:
+		return agent.setCA(parent, certPEM, keyPEM)
+	case !os.IsNotExist(certErr) && certErr != nil:
+		return nil, certErr
+	case !os.IsNotExist(keyErr) && keyErr != nil:
+		return nil, keyErr
+	case certErr == nil || keyErr == nil:
+		return nil, errIncompleteCA
+	}
+
+	if subject.CommonName == "" {
+		return nil, errMissingCAName
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               toPKIXName(subject),
+		DNSNames:              subject.DnsNames,
+		IPAddresses:           subject.IpAddresses,
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(ttl),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        parent != nil,
+	}
+	signer := key
+	if parent == nil {
+		parent = tmpl
+	} else {
+		signer = parentKey
+		if tmpl.NotAfter.After(parent.NotAfter) {
+			tmpl.NotAfter = parent.NotAfter
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		return nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: certBlock, Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: keyBlock, Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	if err := writeFile(keyPath, keyPEM, 0o600); err != nil {
+		return nil, err
+	}
+	if err := writeFile(certPath, certPEM, 0o644); err != nil {
+		return nil, err
+	}
+	agent.logger.Info("Local CA created", "common_name", subject.CommonName, "expiry_time", tmpl.NotAfter)
+
+	if parent == tmpl {
+		parent = nil
+	}
+	return agent.setCA(parent, certPEM, keyPEM)
+}
+
+// setCA parses the CA certificate and key, and sets the CA of the agent.
+// The CA is the root CA if the parent is nil, and the intermediate CA otherwise.
+func (agent *localPKIAgent) setCA(parent *x509.Certificate, certPEM, keyPEM []byte) (*rsa.PrivateKey, error) {
+	cert, err := parseCertPEM(certPEM)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidCAFiles, err)
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.Wrap(errInvalidCAFiles, errors.New("failed to decode CA key PEM"))
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidCAFiles, err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, errors.Wrap(errInvalidCAFiles, errors.New("CA key does not match CA certificate"))
+	}
+
+	if parent == nil {
+		agent.root, agent.rootPEM = cert, certPEM
+		return key, nil
+	}
+	if err := cert.CheckSignatureFrom(parent); err != nil {
+		return nil, errors.Wrap(errInvalidCAFiles, err)
+	}
+	agent.intermediate, agent.intermediatePEM = cert, certPEM
+
+	return key, nil
+}
+
+func (agent *localPKIAgent) Issue(ttl string, ipAddrs []string, options certs.SubjectOptions) (certs.Certificate, error) {
+	validity, err := parseTTL(ttl)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	ips := slices.Clone(options.IpAddresses)
+	for _, addr := range ipAddrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return certs.Certificate{}, fmt.Errorf("invalid IP address %q", addr)
+		}
+		ips = append(ips, ip)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	cert, err := agent.sign(toPKIXName(options), options.DnsNames, ips, &key.PublicKey, validity)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+	cert.Key = pem.EncodeToMemory(&pem.Block{Type: keyBlock, Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return cert, nil
+}
+
+func (agent *localPKIAgent) SignCSR(csr []byte, ttl string) (certs.Certificate, error) {
+	validity, err := parseTTL(ttl)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	block, _ := pem.Decode(csr)
+	if block == nil || block.Type != csrBlock {
+		return certs.Certificate{}, errors.Wrap(errInvalidCSR, errors.New("failed to decode CSR PEM"))
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return certs.Certificate{}, errors.Wrap(errInvalidCSR, err)
+	}
+	if err := req.CheckSignature(); err != nil {
+		return certs.Certificate{}, errors.Wrap(errInvalidCSR, err)
+	}
+
+	return agent.sign(req.Subject, req.DNSNames, req.IPAddresses, req.PublicKey, validity)
+}
+
+// sign issues the certificate with the intermediate CA, and stores it.
+// The DNS names and the IP addresses of the intermediate CA are added to the certificate.
+// The certificate does not outlive the intermediate CA, which must not be about to expire.
+func (agent *localPKIAgent) sign(subject pkix.Name, dnsNames []string, ips []net.IP, pub crypto.PublicKey, validity time.Duration) (certs.Certificate, error) {
+	serial, err := newSerialNumber()
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	dnsNames = slices.Clone(dnsNames)
+	for _, name := range agent.defaultDNSNames {
+		if !slices.Contains(dnsNames, name) {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+	for _, ip := range agent.defaultIPAddress {
+		if !slices.ContainsFunc(ips, ip.Equal) {
+			ips = append(ips, ip)
+		}
+	}
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	now := time.Now()
+	if !now.Add(caExpiryMargin).Before(agent.intermediate.NotAfter) {
+		return certs.Certificate{}, errCAExpired
+	}
+	notAfter := now.Add(validity)
+	if notAfter.After(agent.intermediate.NotAfter) {
+		notAfter = agent.intermediate.NotAfter
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageKeyAgreement,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, agent.intermediate, pub, agent.intermediateKey)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	cert := certs.Certificate{
+		SerialNumber: formatSerialNumber(serial),
+		Certificate:  pem.EncodeToMemory(&pem.Block{Type: certBlock, Bytes: der}),
+		ExpiryTime:   notAfter.Truncate(time.Second),
+	}
+	if err := agent.save(cert.SerialNumber, issuedCert{Certificate: string(cert.Certificate)}); err != nil {
+		return certs.Certificate{}, err
+	}
+
+	return cert, nil
+}
+
+func (agent *localPKIAgent) View(serialNumber string) (certs.Certificate, error) {
+	serialNumber = api.NormalizeSerialNumber(serialNumber)
+
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	return agent.view(serialNumber)
+}
+
+func (agent *localPKIAgent) view(serialNumber string) (certs.Certificate, error) {
+	ic, err := agent.retrieve(serialNumber)
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	x509Cert, err := parseCertPEM([]byte(ic.Certificate))
+	if err != nil {
+		return certs.Certificate{}, err
+	}
+
+	return certs.Certificate{
+		SerialNumber: serialNumber,
+		Certificate:  []byte(ic.Certificate),
+		Revoked:      !ic.RevokedAt.IsZero(),
+		ExpiryTime:   x509Cert.NotAfter,
+	}, nil
+}
+
+func (agent *localPKIAgent) Renew(existingCert certs.Certificate, increment string) (certs.Certificate, error) {
+	x509Cert, err := parseCertPEM(existingCert.Certificate)
+	if err != nil {
+		return certs.Certificate{}, fmt.Errorf("failed to parse existing certificate: %w", err)
+	}
+
+	options := certs.SubjectOptions{
+		CommonName:         x509Cert.Subject.CommonName,
+		Organization:       x509Cert.Subject.Organization,
+		OrganizationalUnit: x509Cert.Subject.OrganizationalUnit,
+		Country:            x509Cert.Subject.Country,
+		Province:           x509Cert.Subject.Province,
+		Locality:           x509Cert.Subject.Locality,
+		StreetAddress:      x509Cert.Subject.StreetAddress,
+		PostalCode:         x509Cert.Subject.PostalCode,
+		DnsNames:           x509Cert.DNSNames,
+		IpAddresses:        x509Cert.IPAddresses,
+	}
+
+	newCert, err := agent.Issue(increment, nil, options)
+	if err != nil {
+		return certs.Certificate{}, fmt.Errorf("failed to issue renewed certificate: %w", err)
+	}
+
+	return newCert, nil
+}
+
+func (agent *localPKIAgent) Revoke(serialNumber string) error {
+	serialNumber = api.NormalizeSerialNumber(serialNumber)
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	ic, err := agent.retrieve(serialNumber)
+	if err != nil {
+		return err
+	}
+	if !ic.RevokedAt.IsZero() {
+		return nil
+	}
+	ic.RevokedAt = time.Now().UTC().Truncate(time.Second)
+
+	return agent.save(serialNumber, ic)
+}
+
+func (agent *localPKIAgent) ListCerts(pm certs.PageMetadata) (certs.CertificatePage, error) {
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	certPage := certs.CertificatePage{
+		Certificates: []certs.Certificate{},
+		PageMetadata: pm,
+	}
+
+	serialNumbers, err := agent.serialNumbers()
+	if err != nil {
+		return certPage, err
+	}
+
+	var allCerts []certs.Certificate
+	for _, serialNumber := range serialNumbers {
+		cert, err := agent.view(serialNumber)
+		if err != nil {
+			agent.logger.Warn("failed to retrieve certificate details", "serial", serialNumber, "error", err)
+			continue
+		}
+
+		allCerts = append(allCerts, cert)
+	}
+
+	certPage.Total = uint64(len(allCerts))
+
+	start := pm.Offset
+	end := pm.Offset + pm.Limit
+	if pm.Limit == 0 {
+		end = uint64(len(allCerts))
+	}
+	if start >= uint64(len(allCerts)) {
+		return certPage, nil
+	}
+	if end > uint64(len(allCerts)) {
+		end = uint64(len(allCerts))
+	}
+
+	for i := start; i < end; i++ {
+		certPage.Certificates = append(certPage.Certificates, allCerts[i])
+	}
+
+	return certPage, nil
+}
+
+func (agent *localPKIAgent) GetCA() ([]byte, error) {
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	return agent.intermediatePEM, nil
+}
+
+// GetCAChain returns the intermediate CA followed by the root CA.
+func (agent *localPKIAgent) GetCAChain() ([]byte, error) {
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	return bytes.Join([][]byte{agent.intermediatePEM, agent.rootPEM}, nil), nil
+}
+
+// GetCRL returns the DER encoded CRL of the certificates revoked by the intermediate CA.
+func (agent *localPKIAgent) GetCRL() ([]byte, error) {
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	serialNumbers, err := agent.serialNumbers()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []x509.RevocationListEntry
+	for _, serialNumber := range serialNumbers {
+		ic, err := agent.retrieve(serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		if ic.RevokedAt.IsZero() {
+			continue
+		}
+		serial, err := parseSerialNumber(serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: ic.RevokedAt,
+		})
+	}
+
+	now := time.Now()
+	tmpl := &x509.RevocationList{
+		Number:                    big.NewInt(now.UnixNano()),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(crlValidity),
+		RevokedCertificateEntries: entries,
+	}
+
+	return x509.CreateRevocationList(rand.Reader, tmpl, agent.intermediate, agent.intermediateKey)
+}
+
+// OCSP returns the DER encoded OCSP response signed by the intermediate CA.
+// If ocspRequestDER is provided, the serial number is taken from the request.
+func (agent *localPKIAgent) OCSP(serialNumber string, ocspRequestDER []byte) ([]byte, error) {
+	var serial *big.Int
+	if len(ocspRequestDER) > 0 {
+		req, err := ocsp.ParseRequest(ocspRequestDER)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse OCSP request: %w", err)
+		}
+		serial = req.SerialNumber
+		serialNumber = formatSerialNumber(serial)
+	} else {
+		serialNumber = api.NormalizeSerialNumber(serialNumber)
+		var err error
+		if serial, err = parseSerialNumber(serialNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Minute)
+	tmpl := ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: serial,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(ocspValidity),
+	}
+
+	agent.mu.RLock()
+	defer agent.mu.RUnlock()
+
+	ic, err := agent.retrieve(serialNumber)
+	switch {
+	case errors.Contains(err, errCertNotFound):
+		tmpl.Status = ocsp.Unknown
+	case err != nil:
+		return nil, err
+	case !ic.RevokedAt.IsZero():
+		tmpl.Status = ocsp.Revoked
+		tmpl.RevokedAt = ic.RevokedAt
+		tmpl.RevocationReason = ocsp.Unspecified
+	}
+
+	return ocsp.CreateResponse(agent.intermediate, agent.intermediate, tmpl, agent.intermediateKey)
+}
+
+// StartSecretRenewal starts the goroutine which periodically renews the
+// intermediate CA before it expires, until the context is canceled.
+func (agent *localPKIAgent) StartSecretRenewal(ctx context.Context) error {
+	go func() {
+		ticker := time.NewTicker(caCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := agent.renewIntermediate(); err != nil {
+					agent.logger.Error("Failed to renew local intermediate CA", "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (agent *localPKIAgent) retrieve(serialNumber string) (issuedCert, error) {
+	path, err := agent.certPath(serialNumber)
+	if err != nil {
+		return issuedCert{}, errors.Wrap(errCertNotFound, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return issuedCert{}, errCertNotFound
+		}
+		return issuedCert{}, err
+	}
+
+	var ic issuedCert
+	if err := json.Unmarshal(data, &ic); err != nil {
+		return issuedCert{}, err
+	}
+
+	return ic, nil
+}
+
+func (agent *localPKIAgent) save(serialNumber string, ic issuedCert) error {
+	path, err := agent.certPath(serialNumber)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(ic)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, data, 0o600)
+}
+
+// serialNumbers returns the sorted serial numbers of the issued certificates.
+func (agent *localPKIAgent) serialNumbers() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(agent.dir, issuedDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var serialNumbers []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		serialNumbers = append(serialNumbers, api.NormalizeSerialNumber(name))
+	}
+	slices.Sort(serialNumbers)
+
+	return serialNumbers, nil
+}
+
+// certPath returns the path of the issued certificate file. The file is named
+// after the parsed serial number, so the serial number never reaches the path as is.
+func (agent *localPKIAgent) certPath(serialNumber string) (string, error) {
+	serial, err := parseSerialNumber(serialNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(agent.dir, issuedDir, hex.EncodeToString(serial.Bytes())+".json"), nil
+}
+
+// writeFile writes the file atomically, so the readers never see partially written file.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// parseTTL parses the TTL as a duration, number of seconds or number of days with the "d" suffix,
+// the same way OpenBao does. The empty TTL is the default certificate TTL.
+func parseTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return defaultCertTTL, nil
+	}
+
+	var d time.Duration
+	switch days, ok := strings.CutSuffix(ttl, "d"); {
+	case ok:
+		n, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return 0, errors.Wrap(errInvalidTTL, err)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	default:
+		if n, err := strconv.ParseUint(ttl, 10, 32); err == nil {
+			d = time.Duration(n) * time.Second
+			break
+		}
+		var err error
+		if d, err = time.ParseDuration(ttl); err != nil {
+			return 0, errors.Wrap(errInvalidTTL, err)
+		}
+	}
+	if d <= 0 {
+		return 0, errInvalidTTL
+	}
+
+	return d, nil
+}
+
+// newSerialNumber returns the random positive serial number.
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return nil, err
+	}
+
+	return serial.Add(serial, big.NewInt(1)), nil
+}
+
+// formatSerialNumber formats the serial number as colon-separated hex, like OpenBao does.
+func formatSerialNumber(serial *big.Int) string {
+	return api.NormalizeSerialNumber(hex.EncodeToString(serial.Bytes()))
+}
+
+func parseSerialNumber(serialNumber string) (*big.Int, error) {
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(serialNumber, ":", ""), 16)
+	if !ok || serial.Sign() < 0 {
+		return nil, fmt.Errorf("invalid serial number %q", serialNumber)
+	}
+
+	return serial, nil
+}
+
+func parseCertPEM(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func toPKIXName(options certs.SubjectOptions) pkix.Name {
+	return pkix.Name{
+		CommonName:         options.CommonName,
+		Organization:       options.Organization,
+		OrganizationalUnit: options.OrganizationalUnit,
+		Country:            options.Country,
+		Province:           options.Province,
+		Locality:           options.Locality,
+		StreetAddress:      options.StreetAddress,
+		PostalCode:         options.PostalCode,
+	}
+}
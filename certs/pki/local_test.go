@@ -0,0 +1,552 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package pki_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/certs/pki"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+var (
+	logger  = slog.New(slog.DiscardHandler)
+	subject = certs.SubjectOptions{
+		CommonName:   "Test Certificate Authority",
+		Organization: []string{"Abstract Machines"},
+		Country:      []string{"FR"},
+		DnsNames:     []string{"localhost"},
+		IpAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+)
+
+func newAgent(t *testing.T) (certs.Agent, string) {
+	dir := t.TempDir()
+	agent, err := pki.NewLocalAgent(pki.LocalConfig{Dir: dir, Subject: subject}, logger)
+	require.NoError(t, err)
+
+	return agent, dir
+}
+
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func verify(t *testing.T, agent certs.Agent, cert *x509.Certificate) {
+	chain, err := agent.GetCAChain()
+	require.NoError(t, err)
+
+	block, rest := pem.Decode(chain)
+	require.NotNil(t, block)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(parseCert(t, pem.EncodeToMemory(block)))
+	roots := x509.NewCertPool()
+	roots.AddCert(parseCert(t, rest))
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NoError(t, err)
+}
+
+func TestNewLocalAgent(t *testing.T) {
+	agent, dir := newAgent(t)
+	ca, err := agent.GetCA()
+	require.NoError(t, err)
+
+	intermediate := parseCert(t, ca)
+	assert.Equal(t, subject.CommonName+" Intermediate", intermediate.Subject.CommonName)
+	assert.True(t, intermediate.IsCA)
+
+	cases := []struct {
+		desc   string
+		config pki.LocalConfig
+		setup  func(dir string)
+		sameCA bool
+		err    bool
+	}{
+		{
+			desc:   "load existing local CA",
+			config: pki.LocalConfig{Dir: dir, Subject: subject},
+			sameCA: true,
+		},
+		{
+			desc:   "load existing local CA without subject",
+			config: pki.LocalConfig{Dir: dir},
+			sameCA: true,
+		},
+		{
+			desc:   "create local CA without common name",
+			config: pki.LocalConfig{Dir: t.TempDir()},
+			err:    true,
+		},
+		{
+			desc:   "load local CA with missing key",
+			config: pki.LocalConfig{Dir: t.TempDir(), Subject: subject},
+			setup: func(d string) {
+				require.NoError(t, os.WriteFile(filepath.Join(d, "root_ca.pem"), ca, 0o644))
+			},
+			err: true,
+		},
+		{
+			desc:   "load local CA with invalid certificate",
+			config: pki.LocalConfig{Dir: t.TempDir(), Subject: subject},
+			setup: func(d string) {
+				require.NoError(t, os.WriteFile(filepath.Join(d, "root_ca.pem"), []byte("invalid"), 0o644))
+				require.NoError(t, os.WriteFile(filepath.Join(d, "root_ca_key.pem"), []byte("invalid"), 0o600))
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.setup != nil {
+				tc.setup(tc.config.Dir)
+			}
+			a, err := pki.NewLocalAgent(tc.config, logger)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got, err := a.GetCA()
+			require.NoError(t, err)
+			assert.Equal(t, tc.sameCA, string(ca) == string(got))
+		})
+	}
+}
+
+func readKey(t *testing.T, path string) *rsa.PrivateKey {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	return key
+}
+
+// resignCA replaces the CA certificate in the file with the one that has the same
+// subject and key, and expires at the given time.
+func resignCA(t *testing.T, path string, key *rsa.PrivateKey, parent *x509.Certificate, parentKey *rsa.PrivateKey, notAfter time.Time) {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	old := parseCert(t, data)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          old.SerialNumber,
+		Subject:               old.Subject,
+		NotBefore:             old.NotBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              old.KeyUsage,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        parent != nil,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644))
+}
+
+func TestRenewIntermediate(t *testing.T) {
+	agent, dir := newAgent(t)
+	cert, err := agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "client"})
+	require.NoError(t, err)
+
+	ca, err := agent.GetCA()
+	require.NoError(t, err)
+	old := parseCert(t, ca)
+	chain, err := agent.GetCAChain()
+	require.NoError(t, err)
+	_, rest := pem.Decode(chain)
+	root := parseCert(t, rest)
+	rootKey := readKey(t, filepath.Join(dir, "root_ca_key.pem"))
+	intermediateKey := readKey(t, filepath.Join(dir, "intermediate_ca_key.pem"))
+
+	resignCA(t, filepath.Join(dir, "intermediate_ca.pem"), intermediateKey, root, rootKey, time.Now().Add(2*time.Hour))
+	agent, err = pki.NewLocalAgent(pki.LocalConfig{Dir: dir}, logger)
+	require.NoError(t, err)
+
+	ca, err = agent.GetCA()
+	require.NoError(t, err)
+	renewed := parseCert(t, ca)
+	assert.NotEqual(t, old.SerialNumber, renewed.SerialNumber)
+	assert.Equal(t, old.Subject.String(), renewed.Subject.String())
+	assert.True(t, intermediateKey.PublicKey.Equal(renewed.PublicKey))
+	assert.WithinDuration(t, time.Now().Add(8760*time.Hour), renewed.NotAfter, time.Minute)
+	verify(t, agent, parseCert(t, cert.Certificate))
+
+	stored, err := os.ReadFile(filepath.Join(dir, "intermediate_ca.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, ca, stored)
+
+	_, err = agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "client"})
+	assert.NoError(t, err)
+
+	resignCA(t, filepath.Join(dir, "root_ca.pem"), rootKey, nil, nil, time.Now().Add(2*time.Hour))
+	rootPEM, err := os.ReadFile(filepath.Join(dir, "root_ca.pem"))
+	require.NoError(t, err)
+	root = parseCert(t, rootPEM)
+	resignCA(t, filepath.Join(dir, "intermediate_ca.pem"), intermediateKey, root, rootKey, time.Now().Add(2*time.Hour))
+	agent, err = pki.NewLocalAgent(pki.LocalConfig{Dir: dir}, logger)
+	require.NoError(t, err)
+
+	_, err = agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "client"})
+	assert.Error(t, err, "issuing with the expiring intermediate CA should fail")
+}
+
+func TestIssue(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	cases := []struct {
+		desc    string
+		ttl     string
+		ipAddrs []string
+		options certs.SubjectOptions
+		expiry  time.Duration
+		err     bool
+	}{
+		{
+			desc:    "issue certificate with default TTL",
+			options: certs.SubjectOptions{CommonName: "client", Organization: []string{"org"}},
+			expiry:  720 * time.Hour,
+		},
+		{
+			desc:    "issue certificate with duration TTL",
+			ttl:     "2h",
+			ipAddrs: []string{"10.0.0.1"},
+			options: certs.SubjectOptions{CommonName: "client", DnsNames: []string{"client.local"}},
+			expiry:  2 * time.Hour,
+		},
+		{
+			desc:    "issue certificate with TTL in days",
+			ttl:     "3d",
+			options: certs.SubjectOptions{CommonName: "client"},
+			expiry:  72 * time.Hour,
+		},
+		{
+			desc:    "issue certificate with TTL in seconds",
+			ttl:     "3600",
+			options: certs.SubjectOptions{CommonName: "client"},
+			expiry:  time.Hour,
+		},
+		{
+			desc:    "issue certificate with invalid TTL",
+			ttl:     "invalid",
+			options: certs.SubjectOptions{CommonName: "client"},
+			err:     true,
+		},
+		{
+			desc:    "issue certificate with invalid IP address",
+			ipAddrs: []string{"invalid"},
+			options: certs.SubjectOptions{CommonName: "client"},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cert, err := agent.Issue(tc.ttl, tc.ipAddrs, tc.options)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			x509Cert := parseCert(t, cert.Certificate)
+			verify(t, agent, x509Cert)
+			assert.Equal(t, tc.options.CommonName, x509Cert.Subject.CommonName)
+			assert.Equal(t, tc.options.Organization, x509Cert.Subject.Organization)
+			assert.Subset(t, x509Cert.DNSNames, append(tc.options.DnsNames, subject.DnsNames...))
+			assert.WithinDuration(t, time.Now().Add(tc.expiry), x509Cert.NotAfter, time.Minute)
+			assert.WithinDuration(t, x509Cert.NotAfter, cert.ExpiryTime, time.Second)
+			for _, ip := range append(tc.ipAddrs, "127.0.0.1") {
+				assert.Contains(t, ipStrings(x509Cert.IPAddresses), ip)
+			}
+
+			block, _ := pem.Decode(cert.Key)
+			require.NotNil(t, block)
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			require.NoError(t, err)
+			assert.True(t, key.PublicKey.Equal(x509Cert.PublicKey))
+
+			view, err := agent.View(cert.SerialNumber)
+			require.NoError(t, err)
+			assert.Equal(t, cert.Certificate, view.Certificate)
+			assert.False(t, view.Revoked)
+		})
+	}
+}
+
+func TestSignCSR(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "device", Organization: []string{"org"}},
+		DNSNames: []string{"device.local"},
+	}, key)
+	require.NoError(t, err)
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+
+	cases := []struct {
+		desc string
+		csr  []byte
+		ttl  string
+		err  bool
+	}{
+		{
+			desc: "sign CSR successfully",
+			csr:  csr,
+			ttl:  "1h",
+		},
+		{
+			desc: "sign invalid CSR",
+			csr:  []byte("invalid"),
+			err:  true,
+		},
+		{
+			desc: "sign CSR with invalid TTL",
+			csr:  csr,
+			ttl:  "-1h",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cert, err := agent.SignCSR(tc.csr, tc.ttl)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, cert.Key)
+
+			x509Cert := parseCert(t, cert.Certificate)
+			verify(t, agent, x509Cert)
+			assert.Equal(t, "device", x509Cert.Subject.CommonName)
+			assert.Contains(t, x509Cert.DNSNames, "device.local")
+			assert.True(t, key.PublicKey.Equal(x509Cert.PublicKey))
+		})
+	}
+}
+
+func TestRenew(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	cert, err := agent.Issue("1h", []string{"10.0.0.1"}, certs.SubjectOptions{CommonName: "client", Organization: []string{"org"}})
+	require.NoError(t, err)
+
+	renewed, err := agent.Renew(cert, "720h0m0s")
+	require.NoError(t, err)
+	assert.NotEqual(t, cert.SerialNumber, renewed.SerialNumber)
+	assert.NotEmpty(t, renewed.Key)
+
+	old := parseCert(t, cert.Certificate)
+	x509Cert := parseCert(t, renewed.Certificate)
+	assert.Equal(t, old.Subject.String(), x509Cert.Subject.String())
+	assert.ElementsMatch(t, old.DNSNames, x509Cert.DNSNames)
+	assert.ElementsMatch(t, ipStrings(old.IPAddresses), ipStrings(x509Cert.IPAddresses))
+	assert.True(t, x509Cert.NotAfter.After(old.NotAfter))
+
+	_, err = agent.Renew(certs.Certificate{Certificate: []byte("invalid")}, "1h")
+	assert.Error(t, err)
+}
+
+func TestRevokeAndList(t *testing.T) {
+	agent, dir := newAgent(t)
+
+	var serials []string
+	for range 3 {
+		cert, err := agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "client"})
+		require.NoError(t, err)
+		serials = append(serials, cert.SerialNumber)
+	}
+
+	require.NoError(t, agent.Revoke(serials[0]))
+	require.NoError(t, agent.Revoke(serials[0]), "revoking revoked certificate must be idempotent")
+	assert.Error(t, agent.Revoke("01:02"))
+
+	view, err := agent.View(serials[0])
+	require.NoError(t, err)
+	assert.True(t, view.Revoked)
+
+	_, err = agent.View("01:02")
+	assert.Error(t, err)
+
+	secret := filepath.Join(dir, "root_ca_key")
+	require.NoError(t, os.WriteFile(secret+".json", []byte(`{"certificate":"x"}`), 0o600))
+	_, err = agent.View("../root_ca_key")
+	assert.Error(t, err, "viewing certificate outside the issued directory must fail")
+	assert.Error(t, agent.Revoke("../root_ca_key"))
+
+	cases := []struct {
+		desc  string
+		pm    certs.PageMetadata
+		total uint64
+		count int
+	}{
+		{
+			desc:  "list all certificates",
+			total: 3,
+			count: 3,
+		},
+		{
+			desc:  "list certificates with offset and limit",
+			pm:    certs.PageMetadata{Offset: 1, Limit: 1},
+			total: 3,
+			count: 1,
+		},
+		{
+			desc:  "list certificates with offset out of range",
+			pm:    certs.PageMetadata{Offset: 5, Limit: 1},
+			total: 3,
+			count: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := agent.ListCerts(tc.pm)
+			require.NoError(t, err)
+			assert.Equal(t, tc.total, page.Total)
+			assert.Len(t, page.Certificates, tc.count)
+			for _, c := range page.Certificates {
+				assert.Contains(t, serials, c.SerialNumber)
+				assert.Equal(t, c.SerialNumber == serials[0], c.Revoked)
+			}
+		})
+	}
+}
+
+func TestGetCRL(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	revoked, err := agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "revoked"})
+	require.NoError(t, err)
+	_, err = agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "valid"})
+	require.NoError(t, err)
+	require.NoError(t, agent.Revoke(revoked.SerialNumber))
+
+	der, err := agent.GetCRL()
+	require.NoError(t, err)
+	crl, err := x509.ParseRevocationList(der)
+	require.NoError(t, err)
+
+	ca, err := agent.GetCA()
+	require.NoError(t, err)
+	assert.NoError(t, crl.CheckSignatureFrom(parseCert(t, ca)))
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, parseCert(t, revoked.Certificate).SerialNumber, crl.RevokedCertificateEntries[0].SerialNumber)
+}
+
+func TestOCSP(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	ca, err := agent.GetCA()
+	require.NoError(t, err)
+	issuer := parseCert(t, ca)
+
+	good, err := agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "good"})
+	require.NoError(t, err)
+	revoked, err := agent.Issue("1h", nil, certs.SubjectOptions{CommonName: "revoked"})
+	require.NoError(t, err)
+	require.NoError(t, agent.Revoke(revoked.SerialNumber))
+
+	request, err := ocsp.CreateRequest(parseCert(t, revoked.Certificate), issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	require.NoError(t, err)
+
+	cases := []struct {
+		desc    string
+		serial  string
+		request []byte
+		status  int
+		err     bool
+	}{
+		{
+			desc:   "OCSP of valid certificate",
+			serial: good.SerialNumber,
+			status: ocsp.Good,
+		},
+		{
+			desc:   "OCSP of revoked certificate",
+			serial: revoked.SerialNumber,
+			status: ocsp.Revoked,
+		},
+		{
+			desc:   "OCSP of unknown certificate",
+			serial: "01:02:03",
+			status: ocsp.Unknown,
+		},
+		{
+			desc:    "OCSP with request",
+			request: request,
+			status:  ocsp.Revoked,
+		},
+		{
+			desc:    "OCSP with invalid request",
+			request: []byte("invalid"),
+			err:     true,
+		},
+		{
+			desc:   "OCSP with invalid serial number",
+			serial: "invalid",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			der, err := agent.OCSP(tc.serial, tc.request)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			res, err := ocsp.ParseResponse(der, issuer)
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, res.Status)
+		})
+	}
+}
+
+func ipStrings(ips []net.IP) []string {
+	var ret []string
+	for _, ip := range ips {
+		ret = append(ret, ip.String())
+	}
+
+	return ret
+}
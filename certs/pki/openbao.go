@@ -1,7 +1,7 @@
 // Copyright (c) Abstract Machines
 // SPDX-License-Identifier: Apache-2.0
 
-// Package pki wraps OpenBao client for PKI operations
+// Package pki provides the OpenBao and the local CA implementations of certs.Agent.
 package pki
 
 import (
@@ -6,10 +6,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -42,15 +44,47 @@ func NewRepository(db postgres.Database) certs.Repository {
 }
 
 // SaveCertEntityMapping saves the mapping between certificate serial number and entity ID.
-func (repo certsRepo) SaveCertEntityMapping(ctx context.Context, serialNumber, entityID string) error {
-	q := `INSERT INTO cert_entity_mappings (serial_number, entity_id) VALUES ($1, $2)`
-	_, err := repo.db.ExecContext(ctx, q, serialNumber, entityID)
+func (repo certsRepo) SaveCertEntityMapping(ctx context.Context, serialNumber, entityID, domainID string, expiryTime time.Time, fromCSR bool) error {
+	q := `INSERT INTO cert_entity_mappings (serial_number, entity_id, domain_id, expiry_time, from_csr) VALUES ($1, $2, $3, $4, $5)`
+	expiry := sql.NullTime{Time: expiryTime.UTC(), Valid: !expiryTime.IsZero()}
+	_, err := repo.db.ExecContext(ctx, q, serialNumber, entityID, domainID, expiry, fromCSR)
 	if err != nil {
 		return handleError(ErrCreateEntity, err)
 	}
 	return nil
 }
 
+// RetrieveExpiringCerts retrieves the latest certificate of each entity if it expires between the given times.
+func (repo certsRepo) RetrieveExpiringCerts(ctx context.Context, domainID string, from, to time.Time) ([]certs.Certificate, error) {
+	q := `SELECT serial_number, entity_id, domain_id, expiry_time, from_csr FROM (
+			SELECT DISTINCT ON (entity_id) serial_number, entity_id, domain_id, expiry_time, from_csr
+			FROM cert_entity_mappings ORDER BY entity_id, created_at DESC
+		) latest
+		WHERE (expiry_time IS NULL OR (expiry_time > $1 AND expiry_time <= $2)) AND ($3 = '' OR domain_id = $3)
+		ORDER BY expiry_time NULLS FIRST, serial_number`
+	rows, err := repo.db.QueryContext(ctx, q, from.UTC(), to.UTC(), domainID)
+	if err != nil {
+		return nil, handleError(ErrNotFound, err)
+	}
+	defer rows.Close()
+
+	var ret []certs.Certificate
+	for rows.Next() {
+		var cert certs.Certificate
+		var expiry sql.NullTime
+		if err := rows.Scan(&cert.SerialNumber, &cert.EntityID, &cert.DomainID, &expiry, &cert.FromCSR); err != nil {
+			return nil, handleError(ErrNotFound, err)
+		}
+		cert.ExpiryTime = expiry.Time
+		ret = append(ret, cert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleError(ErrNotFound, err)
+	}
+
+	return ret, nil
+}
+
 // GetEntityIDBySerial retrieves the entity ID for a given certificate serial number.
 func (repo certsRepo) GetEntityIDBySerial(ctx context.Context, serialNumber string) (string, error) {
 	q := `SELECT entity_id FROM cert_entity_mappings WHERE serial_number = $1`
@@ -64,6 +98,19 @@ func (repo certsRepo) GetEntityIDBySerial(ctx context.Context, serialNumber stri
 	return entityID, nil
 }
 
+// GetDomainIDBySerial retrieves the Domain ID for a given certificate serial number.
+func (repo certsRepo) GetDomainIDBySerial(ctx context.Context, serialNumber string) (string, error) {
+	q := `SELECT domain_id FROM cert_entity_mappings WHERE serial_number = $1`
+	var domainID string
+	if err := repo.db.QueryRowxContext(ctx, q, serialNumber).Scan(&domainID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.Wrap(ErrNotFound, err)
+		}
+		return "", handleError(ErrNotFound, err)
+	}
+	return domainID, nil
+}
+
 // ListCertsByEntityID lists all certificate serial numbers for a given entity ID.
 func (repo certsRepo) ListCertsByEntityID(ctx context.Context, entityID string) ([]string, error) {
 	q := `SELECT serial_number FROM cert_entity_mappings WHERE entity_id = $1 ORDER BY created_at DESC`
@@ -104,6 +151,72 @@ func (repo certsRepo) RemoveCertEntityMapping(ctx context.Context, serialNumber
 	return nil
 }
 
+// SaveRenewalPolicy saves the Domain renewal policy, replacing the existing one.
+func (repo certsRepo) SaveRenewalPolicy(ctx context.Context, policy certs.RenewalPolicy) error {
+	q := `INSERT INTO renewal_policies (domain_id, auto_renew, emails, updated_at, updated_by)
+		VALUES (:domain_id, :auto_renew, :emails, :updated_at, :updated_by)
+		ON CONFLICT (domain_id) DO UPDATE SET auto_renew = EXCLUDED.auto_renew, emails = EXCLUDED.emails,
+		updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by`
+
+	dbp, err := toDBRenewalPolicy(policy)
+	if err != nil {
+		return errors.Wrap(ErrMalformedEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbp); err != nil {
+		return handleError(ErrCreateEntity, err)
+	}
+	return nil
+}
+
+// RetrieveRenewalPolicy retrieves the Domain renewal policy.
+func (repo certsRepo) RetrieveRenewalPolicy(ctx context.Context, domainID string) (certs.RenewalPolicy, error) {
+	q := `SELECT domain_id, auto_renew, emails, updated_at, updated_by FROM renewal_policies WHERE domain_id = $1`
+	var dbp dbRenewalPolicy
+	if err := repo.db.QueryRowxContext(ctx, q, domainID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return certs.RenewalPolicy{}, errors.Wrap(ErrNotFound, err)
+		}
+		return certs.RenewalPolicy{}, handleError(ErrNotFound, err)
+	}
+	return toRenewalPolicy(dbp), nil
+}
+
+type dbRenewalPolicy struct {
+	DomainID  string           `db:"domain_id"`
+	AutoRenew bool             `db:"auto_renew"`
+	Emails    pgtype.TextArray `db:"emails"`
+	UpdatedAt sql.NullTime     `db:"updated_at"`
+	UpdatedBy sql.NullString   `db:"updated_by"`
+}
+
+func toDBRenewalPolicy(p certs.RenewalPolicy) (dbRenewalPolicy, error) {
+	var emails pgtype.TextArray
+	if err := emails.Set(p.Emails); err != nil {
+		return dbRenewalPolicy{}, err
+	}
+	return dbRenewalPolicy{
+		DomainID:  p.DomainID,
+		AutoRenew: p.AutoRenew,
+		Emails:    emails,
+		UpdatedAt: sql.NullTime{Time: p.UpdatedAt, Valid: !p.UpdatedAt.IsZero()},
+		UpdatedBy: sql.NullString{String: p.UpdatedBy, Valid: p.UpdatedBy != ""},
+	}, nil
+}
+
+func toRenewalPolicy(dbp dbRenewalPolicy) certs.RenewalPolicy {
+	var emails []string
+	for _, e := range dbp.Emails.Elements {
+		emails = append(emails, e.String)
+	}
+	return certs.RenewalPolicy{
+		DomainID:  dbp.DomainID,
+		AutoRenew: dbp.AutoRenew,
+		Emails:    emails,
+		UpdatedAt: dbp.UpdatedAt.Time,
+		UpdatedBy: dbp.UpdatedBy.String,
+	}
+}
+
 func handleError(wrapper, err error) error {
 	pqErr, ok := err.(*pgconn.PgError)
 	if ok {
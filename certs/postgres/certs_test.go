@@ -5,8 +5,11 @@ package postgres_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/certs/postgres"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +19,7 @@ import (
 var (
 	entityID     = "bfead30d-5a1d-40f3-be21-fd8ffad49db0"
 	serialNumber = "20:f4:bd:43:2c:c7:06:82:c7:f2:00:47:51:b6:81:6f:fa:c4:46:0c"
+	domainID     = "c8d9e2f1-6b3a-4c5d-8e7f-9a0b1c2d3e4f"
 )
 
 func TestSaveCertEntityMapping(t *testing.T) {
@@ -43,7 +47,7 @@ func TestSaveCertEntityMapping(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			err := repo.SaveCertEntityMapping(context.Background(), tc.serialNumber, tc.entityID)
+			err := repo.SaveCertEntityMapping(context.Background(), tc.serialNumber, tc.entityID, domainID, time.Now().Add(time.Hour), false)
 			if tc.err != nil {
 				require.Error(t, err)
 				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
@@ -54,13 +58,63 @@ func TestSaveCertEntityMapping(t *testing.T) {
 	}
 }
 
+func TestGetDomainIDBySerial(t *testing.T) {
+	repo := postgres.NewRepository(database)
+
+	testSerial := "test-serial-domain"
+	err := repo.SaveCertEntityMapping(context.Background(), testSerial, entityID, domainID, time.Now().Add(time.Hour), false)
+	require.NoError(t, err)
+	noDomainSerial := "test-serial-no-domain"
+	err = repo.SaveCertEntityMapping(context.Background(), noDomainSerial, entityID, "", time.Now().Add(time.Hour), false)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		serialNumber string
+		expectedID   string
+		err          error
+	}{
+		{
+			desc:         "successful retrieval",
+			serialNumber: testSerial,
+			expectedID:   domainID,
+			err:          nil,
+		},
+		{
+			desc:         "successful retrieval of cert without domain",
+			serialNumber: noDomainSerial,
+			expectedID:   "",
+			err:          nil,
+		},
+		{
+			desc:         "serial number not found",
+			serialNumber: "non-existent-serial",
+			expectedID:   "",
+			err:          postgres.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			id, err := repo.GetDomainIDBySerial(context.Background(), tc.serialNumber)
+			if tc.err != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Contains(err, tc.err), "expected error %v, got %v", tc.err, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
 func TestGetEntityIDBySerial(t *testing.T) {
 	repo := postgres.NewRepository(database)
 
 	// Setup: save a mapping first
 	testSerial := "test-serial-456"
 	testEntityID := "test-entity-789"
-	err := repo.SaveCertEntityMapping(context.Background(), testSerial, testEntityID)
+	err := repo.SaveCertEntityMapping(context.Background(), testSerial, testEntityID, domainID, time.Now().Add(time.Hour), false)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -106,7 +160,7 @@ func TestListCertsByEntityID(t *testing.T) {
 	testSerials := []string{"serial-1", "serial-2", "serial-3"}
 
 	for _, serial := range testSerials {
-		err := repo.SaveCertEntityMapping(context.Background(), serial, testEntityID)
+		err := repo.SaveCertEntityMapping(context.Background(), serial, testEntityID, domainID, time.Now().Add(time.Hour), false)
 		require.NoError(t, err)
 	}
 
@@ -158,7 +212,7 @@ func TestRemoveCertEntityMapping(t *testing.T) {
 
 	testSerial := "test-serial-remove"
 	testEntityID := "test-entity-remove"
-	err := repo.SaveCertEntityMapping(context.Background(), testSerial, testEntityID)
+	err := repo.SaveCertEntityMapping(context.Background(), testSerial, testEntityID, domainID, time.Now().Add(time.Hour), false)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -204,7 +258,7 @@ func TestCertEntityMappingWorkflow(t *testing.T) {
 
 	// Save mappings
 	for _, serial := range serials {
-		err := repo.SaveCertEntityMapping(context.Background(), serial, entityID)
+		err := repo.SaveCertEntityMapping(context.Background(), serial, entityID, domainID, time.Now().Add(time.Hour), false)
 		require.NoError(t, err)
 	}
 
@@ -242,3 +296,120 @@ func TestCertEntityMappingWorkflow(t *testing.T) {
 	assert.Len(t, listedSerials, 1)
 	assert.Contains(t, listedSerials, serials[1])
 }
+
+func TestRenewalPolicy(t *testing.T) {
+	repo := postgres.NewRepository(database)
+
+	policy := certs.RenewalPolicy{
+		DomainID:  domainID,
+		AutoRenew: true,
+		Emails:    []string{"admin@example.com"},
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		UpdatedBy: entityID,
+	}
+	updated := policy
+	updated.AutoRenew = false
+	updated.Emails = []string{"admin@example.com", "operator@example.com"}
+
+	testCases := []struct {
+		desc     string
+		policy   certs.RenewalPolicy
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "save new policy",
+			policy:   policy,
+			domainID: domainID,
+		},
+		{
+			desc:     "replace existing policy",
+			policy:   updated,
+			domainID: domainID,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.SaveRenewalPolicy(context.Background(), tc.policy)
+			require.NoError(t, err)
+
+			p, err := repo.RetrieveRenewalPolicy(context.Background(), tc.domainID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.policy, p)
+		})
+	}
+
+	_, err := repo.RetrieveRenewalPolicy(context.Background(), "non-existent-domain")
+	assert.True(t, errors.Contains(err, postgres.ErrNotFound), "expected error %v, got %v", postgres.ErrNotFound, err)
+}
+
+func TestRetrieveExpiringCerts(t *testing.T) {
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	expiringDomainID := "0f3e6b0a-1d2c-4b5a-9e8f-7a6b5c4d3e2f"
+	mappings := []struct {
+		serialNumber string
+		entityID     string
+		domainID     string
+		expiryTime   time.Time
+		fromCSR      bool
+	}{
+		{"expiring-superseded", "expiring-entity-1", expiringDomainID, now.Add(time.Hour), false},
+		{"expiring-latest", "expiring-entity-1", expiringDomainID, now.Add(2 * time.Hour), false},
+		{"expiring-later", "expiring-entity-2", expiringDomainID, now.Add(3 * time.Hour), true},
+		{"expiring-distant", "expiring-entity-3", expiringDomainID, now.Add(48 * time.Hour), false},
+		{"expiring-expired", "expiring-entity-4", expiringDomainID, now.Add(-time.Hour), false},
+		{"expiring-unknown", "expiring-entity-5", expiringDomainID, time.Time{}, false},
+		{"expiring-other-domain", "expiring-entity-6", "", now.Add(time.Hour), true},
+	}
+	for _, m := range mappings {
+		err := repo.SaveCertEntityMapping(context.Background(), m.serialNumber, m.entityID, m.domainID, m.expiryTime, m.fromCSR)
+		require.NoError(t, err)
+		// The latest certificate of the entity is told apart by the creation time.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	testCases := []struct {
+		desc     string
+		domainID string
+		to       time.Time
+		expected []string
+	}{
+		{
+			desc:     "retrieve expiring certs of the domain",
+			domainID: expiringDomainID,
+			to:       now.Add(24 * time.Hour),
+			expected: []string{"expiring-unknown", "expiring-latest", "expiring-later"},
+		},
+		{
+			desc:     "retrieve expiring certs of the domain within the shorter period",
+			domainID: expiringDomainID,
+			to:       now.Add(2 * time.Hour),
+			expected: []string{"expiring-unknown", "expiring-latest"},
+		},
+		{
+			desc:     "retrieve expiring certs of all the domains",
+			to:       now.Add(24 * time.Hour),
+			expected: []string{"expiring-unknown", "expiring-other-domain", "expiring-latest", "expiring-later"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveExpiringCerts(context.Background(), tc.domainID, now, tc.to)
+			require.NoError(t, err)
+			var serialNumbers []string
+			for _, c := range page {
+				// Mappings saved by the other tests expire within the period too.
+				if strings.HasPrefix(c.SerialNumber, "expiring-") {
+					serialNumbers = append(serialNumbers, c.SerialNumber)
+					fromCSR := c.SerialNumber == "expiring-later" || c.SerialNumber == "expiring-other-domain"
+					assert.Equal(t, fromCSR, c.FromCSR, "%s: unexpected CSR flag", c.SerialNumber)
+				}
+			}
+			assert.Equal(t, tc.expected, serialNumbers)
+		})
+	}
+}
@@ -27,6 +27,46 @@ func Migration() *migrate.MemoryMigrationSource {
 					"DROP TABLE cert_entity_mappings",
 				},
 			},
+			{
+				Id: "certs_2",
+				Up: []string{
+					`ALTER TABLE cert_entity_mappings ADD COLUMN IF NOT EXISTS domain_id VARCHAR(36) NOT NULL DEFAULT ''`,
+					`CREATE TABLE IF NOT EXISTS renewal_policies (
+						domain_id  VARCHAR(36) PRIMARY KEY,
+						auto_renew BOOLEAN NOT NULL DEFAULT FALSE,
+						emails     TEXT[],
+						updated_at TIMESTAMP,
+						updated_by VARCHAR(254)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS renewal_policies",
+					"ALTER TABLE cert_entity_mappings DROP COLUMN IF EXISTS domain_id",
+				},
+			},
+			{
+				Id: "certs_3",
+				Up: []string{
+					`ALTER TABLE cert_entity_mappings ADD COLUMN IF NOT EXISTS expiry_time TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS idx_cert_entity_mappings_entity_id_created_at ON cert_entity_mappings(entity_id, created_at DESC)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS idx_cert_entity_mappings_entity_id_created_at",
+					"ALTER TABLE cert_entity_mappings DROP COLUMN IF EXISTS expiry_time",
+				},
+			},
+			{
+				Id: "certs_4",
+				// The certificates saved without the Domain are issued from
+				// the CSR of the enrolled devices.
+				Up: []string{
+					`ALTER TABLE cert_entity_mappings ADD COLUMN IF NOT EXISTS from_csr BOOLEAN NOT NULL DEFAULT FALSE`,
+					`UPDATE cert_entity_mappings SET from_csr = TRUE WHERE domain_id = ''`,
+				},
+				Down: []string{
+					"ALTER TABLE cert_entity_mappings DROP COLUMN IF EXISTS from_csr",
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"context"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// DefaultRenewalWindow is the period before the expiry in which the certificate
+// is about to expire, if the renewal window is not configured.
+const DefaultRenewalWindow = time.Hour * 24 * 7
+
+var (
+	ErrInvalidEmail = errors.New("invalid renewal policy email")
+	ErrNotifyExpiry = errors.New("failed to send expiry digest")
+)
+
+// RenewalConfig configures handling of the certificates about to expire.
+type RenewalConfig struct {
+	// Window is the period before the expiry in which the certificate is about to expire.
+	Window time.Duration
+	// AutoRenew is the renewal policy of the certificates which are not issued
+	// in a Domain, or whose Domain has no renewal policy.
+	AutoRenew bool
+}
+
+// RenewalPolicy defines how the Domain certificates about to expire are handled.
+// The certificates are renewed automatically if AutoRenew is set, and the
+// digest of the certificates about to expire is sent to the Emails.
+type RenewalPolicy struct {
+	DomainID  string    `json:"domain_id"`
+	AutoRenew bool      `json:"auto_renew"`
+	Emails    []string  `json:"emails,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+// Renewal is the result of handling the certificate about to expire.
+// Renewed is set if the certificate is renewed, and Err if the renewal failed.
+type Renewal struct {
+	Certificate Certificate
+	Renewed     Certificate
+	Err         error
+}
+
+// ExpiryDigest lists the Domain certificates about to expire.
+type ExpiryDigest struct {
+	DomainID string
+	Window   time.Duration
+	Renewals []Renewal
+}
+
+// Notifier sends the expiry digests.
+type Notifier interface {
+	Notify(ctx context.Context, emails []string, digest ExpiryDigest) error
+}
+
+func (p RenewalPolicy) Validate() error {
+	for _, e := range p.Emails {
+		if _, err := mail.ParseAddress(e); err != nil {
+			return errors.Wrap(ErrInvalidEmail, err)
+		}
+	}
+
+	return nil
+}
+
+// NewRenewalHandler starts the goroutine which periodically renews the
+// certificates about to expire until the context is canceled.
+func NewRenewalHandler(ctx context.Context, svc Service, checkInterval time.Duration, logger *slog.Logger) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := svc.RenewExpiringCerts(ctx); err != nil {
+					logger.Error("failed to renew expiring certificates", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
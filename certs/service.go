@@ -4,10 +4,13 @@
 package certs
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq/pkg/authn"
@@ -45,27 +48,39 @@ var (
 	ErrFailedParse            = errors.New("failed to parse key PEM")
 	ErrFailedCertCreation     = errors.New("failed to create certificate")
 	ErrInvalidIP              = errors.New("invalid IP address")
+	ErrAuthentication         = errors.New("failed to authenticate the device")
+	ErrSubjectMismatch        = errors.New("CSR subject does not match the enrolled certificate")
+	ErrAlreadyEnrolled        = errors.New("device already has a valid certificate")
 )
 
 type service struct {
-	pki  Agent
-	repo Repository
+	pki       Agent
+	repo      Repository
+	bootstrap Bootstrap
+	notifier  Notifier
+	renewal   RenewalConfig
 }
 
 var _ Service = (*service)(nil)
 
-func NewService(ctx context.Context, pki Agent, repo Repository) (Service, error) {
+func NewService(ctx context.Context, pki Agent, repo Repository, bootstrap Bootstrap, notifier Notifier, renewal RenewalConfig) (Service, error) {
 	var svc service
 
 	svc.pki = pki
 	svc.repo = repo
+	svc.bootstrap = bootstrap
+	svc.notifier = notifier
+	svc.renewal = renewal
+	if svc.renewal.Window == 0 {
+		svc.renewal.Window = DefaultRenewalWindow
+	}
 
 	return &svc, nil
 }
 
 // IssueCert generates and issues a certificate for a given entityID.
 // It uses the PKI agent to generate and issue a certificate.
-// The certificate is managed by OpenBao PKI internally.
+// The certificate is managed by the PKI agent internally.
 // EntityType is used to customize certificate properties based on the entity type.
 func (s *service) IssueCert(ctx context.Context, session authn.Session, entityID, ttl string, ipAddrs []string, options SubjectOptions) (Certificate, error) {
 	cert, err := s.pki.Issue(ttl, ipAddrs, options)
@@ -73,11 +88,12 @@ func (s *service) IssueCert(ctx context.Context, session authn.Session, entityID
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
-	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID); err != nil {
+	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID, session.DomainID, cert.ExpiryTime, false); err != nil {
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
 	cert.EntityID = entityID
+	cert.DomainID = session.DomainID
 
 	return cert, nil
 }
@@ -231,7 +247,7 @@ func (s *service) RenewCert(ctx context.Context, session authn.Session, serialNu
 	return newCert, nil
 }
 
-// OCSP forwards OCSP requests to OpenBao's OCSP endpoint.
+// OCSP forwards OCSP requests to the PKI agent.
 // If ocspRequestDER is provided, it will be used directly; otherwise, a request will be built from the serialNumber.
 func (s *service) OCSP(ctx context.Context, serialNumber string, ocspRequestDER []byte) ([]byte, error) {
 	return s.pki.OCSP(serialNumber, ocspRequestDER)
@@ -263,30 +279,179 @@ func (s *service) IssueFromCSR(ctx context.Context, session authn.Session, entit
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
-	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID); err != nil {
+	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID, session.DomainID, cert.ExpiryTime, true); err != nil {
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
 	cert.EntityID = entityID
+	cert.DomainID = session.DomainID
+	cert.FromCSR = true
 
 	return cert, nil
 }
 
 func (s *service) IssueFromCSRInternal(ctx context.Context, entityID, ttl string, csr CSR) (Certificate, error) {
+	return s.issueFromCSR(ctx, entityID, "", ttl, csr)
+}
+
+func (s *service) issueFromCSR(ctx context.Context, entityID, domainID, ttl string, csr CSR) (Certificate, error) {
 	cert, err := s.pki.SignCSR(csr.CSR, ttl)
 	if err != nil {
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
-	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID); err != nil {
+	if err := s.repo.SaveCertEntityMapping(ctx, cert.SerialNumber, entityID, domainID, cert.ExpiryTime, true); err != nil {
 		return Certificate{}, errors.Wrap(ErrFailedCertCreation, err)
 	}
 
 	cert.EntityID = entityID
+	cert.DomainID = domainID
+	cert.FromCSR = true
 
 	return cert, nil
 }
 
+// EnrollCert authenticates the device using the Bootstrap service, issues
+// a certificate from the device CSR and links it to the device bootstrap config.
+// The device which already has a valid certificate must reenroll instead.
+func (s *service) EnrollCert(ctx context.Context, externalID, externalKey string, csr CSR) (Certificate, error) {
+	clientID, domainID, err := s.bootstrap.Authenticate(ctx, externalID, externalKey)
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	serialNumbers, err := s.repo.ListCertsByEntityID(ctx, clientID)
+	if err != nil {
+		return Certificate{}, errors.Wrap(ErrViewEntity, err)
+	}
+	for _, sn := range serialNumbers {
+		cert, err := s.pki.View(sn)
+		if err != nil {
+			return Certificate{}, errors.Wrap(ErrViewEntity, err)
+		}
+		if !cert.Revoked && time.Now().Before(cert.ExpiryTime) {
+			return Certificate{}, ErrAlreadyEnrolled
+		}
+	}
+
+	return s.enroll(ctx, clientID, domainID, externalID, csr)
+}
+
+// ReenrollCert issues a new certificate for the device which presented its current
+// certificate, and revokes the current certificate once the new one is linked.
+// The CSR subject must match the subject of the current certificate.
+func (s *service) ReenrollCert(ctx context.Context, externalID, externalKey string, clientCert []byte, csr CSR) (Certificate, error) {
+	clientID, domainID, err := s.bootstrap.Authenticate(ctx, externalID, externalKey)
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	current, err := s.currentCert(ctx, clientID, clientCert)
+	if err != nil {
+		return Certificate{}, err
+	}
+	if current.Revoked {
+		return Certificate{}, ErrCertRevoked
+	}
+	if time.Now().After(current.ExpiryTime) {
+		return Certificate{}, ErrCertExpired
+	}
+	if err := matchSubject(current, csr); err != nil {
+		return Certificate{}, err
+	}
+
+	cert, err := s.enroll(ctx, clientID, domainID, externalID, csr)
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	if err := s.pki.Revoke(current.SerialNumber); err != nil {
+		return Certificate{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	return cert, nil
+}
+
+// currentCert returns the certificate issued to the Client which is the same
+// as the certificate the device presented.
+func (s *service) currentCert(ctx context.Context, clientID string, clientCert []byte) (Certificate, error) {
+	presented, _ := pem.Decode(clientCert)
+	if presented == nil {
+		return Certificate{}, errors.Wrap(ErrAuthentication, errors.New("missing client certificate"))
+	}
+
+	serialNumbers, err := s.repo.ListCertsByEntityID(ctx, clientID)
+	if err != nil {
+		return Certificate{}, errors.Wrap(ErrViewEntity, err)
+	}
+	for _, sn := range serialNumbers {
+		cert, err := s.pki.View(sn)
+		if err != nil {
+			return Certificate{}, errors.Wrap(ErrViewEntity, err)
+		}
+		block, _ := pem.Decode(cert.Certificate)
+		if block != nil && bytes.Equal(block.Bytes, presented.Bytes) {
+			return cert, nil
+		}
+	}
+
+	return Certificate{}, errors.Wrap(ErrAuthentication, errors.New("client certificate is not issued to the device"))
+}
+
+// enroll issues the certificate of the device in the Domain of its bootstrap
+// config, so the certificate expiry is reported to the Domain.
+func (s *service) enroll(ctx context.Context, clientID, domainID, externalID string, csr CSR) (Certificate, error) {
+	caChain, err := s.pki.GetCAChain()
+	if err != nil {
+		return Certificate{}, errors.Wrap(ErrViewEntity, err)
+	}
+
+	cert, err := s.issueFromCSR(ctx, clientID, domainID, certValidityPeriod.String(), csr)
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	if err := s.bootstrap.UpdateCert(ctx, externalID, string(cert.Certificate), string(caChain)); err != nil {
+		// The certificate which is not linked to the bootstrap config is never
+		// delivered to the device, so it must not remain valid.
+		if err := s.pki.Revoke(cert.SerialNumber); err != nil {
+			return Certificate{}, errors.Wrap(ErrUpdateEntity, err)
+		}
+		if err := s.repo.RemoveCertEntityMapping(ctx, cert.SerialNumber); err != nil {
+			return Certificate{}, errors.Wrap(ErrDeleteEntity, err)
+		}
+		return Certificate{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	return cert, nil
+}
+
+func matchSubject(cert Certificate, csr CSR) error {
+	block, _ := pem.Decode(cert.Certificate)
+	if block == nil {
+		return errors.Wrap(ErrViewEntity, errors.New("failed to decode certificate PEM"))
+	}
+	x509Cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrap(ErrViewEntity, err)
+	}
+
+	block, _ = pem.Decode(csr.CSR)
+	if block == nil {
+		return errors.Wrap(ErrMalformedEntity, errors.New("failed to decode CSR PEM"))
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	if req.Subject.String() != x509Cert.Subject.String() {
+		return ErrSubjectMismatch
+	}
+
+	return nil
+}
+
 func (s *service) getConcatCAs(_ context.Context) (Certificate, error) {
 	caChain, err := s.pki.GetCAChain()
 	if err != nil {
@@ -308,3 +473,189 @@ func (s *service) getConcatCAs(_ context.Context) (Certificate, error) {
 		ExpiryTime:  cert.NotAfter,
 	}, nil
 }
+
+func (s *service) ListExpiringCerts(ctx context.Context, session authn.Session, within time.Duration, pm PageMetadata) (CertificatePage, error) {
+	if within == 0 {
+		within = s.renewal.Window
+	}
+
+	domainCerts, err := s.expiringCerts(ctx, session.DomainID, within)
+	if err != nil {
+		return CertificatePage{}, err
+	}
+
+	certPg := CertificatePage{
+		PageMetadata: pm,
+		Certificates: make([]Certificate, 0),
+	}
+	certPg.Total = uint64(len(domainCerts))
+
+	start := pm.Offset
+	end := pm.Offset + pm.Limit
+	if pm.Limit == 0 {
+		end = uint64(len(domainCerts))
+	}
+	if start >= uint64(len(domainCerts)) {
+		return certPg, nil
+	}
+	if end > uint64(len(domainCerts)) {
+		end = uint64(len(domainCerts))
+	}
+	certPg.Certificates = append(certPg.Certificates, domainCerts[start:end]...)
+
+	return certPg, nil
+}
+
+func (s *service) SetRenewalPolicy(ctx context.Context, session authn.Session, policy RenewalPolicy) (RenewalPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return RenewalPolicy{}, errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	policy.DomainID = session.DomainID
+	policy.UpdatedAt = time.Now().UTC()
+	policy.UpdatedBy = session.UserID
+	if err := s.repo.SaveRenewalPolicy(ctx, policy); err != nil {
+		return RenewalPolicy{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	return policy, nil
+}
+
+func (s *service) ViewRenewalPolicy(ctx context.Context, session authn.Session) (RenewalPolicy, error) {
+	return s.renewalPolicy(ctx, session.DomainID)
+}
+
+// RenewExpiringCerts renews the certificates about to expire if their renewal
+// policy allows it. The renewals are returned even if sending the expiry
+// digests fails, since the certificates are already renewed.
+// The certificates issued from the CSR are only reported: renewal issues a
+// new key, while the requester keeps the key of its CSR, so the renewed
+// certificate would never be used. The enrolled devices reenroll instead.
+func (s *service) RenewExpiringCerts(ctx context.Context) ([]Renewal, error) {
+	expiring, err := s.expiringCerts(ctx, "", s.renewal.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]RenewalPolicy)
+	renewals := make([]Renewal, 0, len(expiring))
+	for _, cert := range expiring {
+		policy, ok := policies[cert.DomainID]
+		if !ok {
+			if policy, err = s.renewalPolicy(ctx, cert.DomainID); err != nil {
+				return renewals, err
+			}
+			policies[cert.DomainID] = policy
+		}
+
+		r := Renewal{Certificate: cert}
+		if policy.AutoRenew && !cert.FromCSR {
+			r.Renewed, r.Err = s.renew(ctx, cert)
+		}
+		renewals = append(renewals, r)
+	}
+
+	return renewals, s.sendDigests(ctx, policies, renewals)
+}
+
+// expiringCerts retrieves the entity certificates which expire within the given
+// period, sorted by the expiry time. Only the latest certificate of the entity
+// is retrieved, so the certificates superseded by the renewal are left out.
+// The certificates of all the Domains are retrieved if the Domain ID is empty.
+func (s *service) expiringCerts(ctx context.Context, domainID string, within time.Duration) ([]Certificate, error) {
+	now := time.Now()
+	deadline := now.Add(within)
+	latest, err := s.repo.RetrieveExpiringCerts(ctx, domainID, now, deadline)
+	if err != nil {
+		return nil, errors.Wrap(ErrViewEntity, err)
+	}
+
+	var expiring []Certificate
+	for _, c := range latest {
+		cert, err := s.pki.View(c.SerialNumber)
+		if err != nil {
+			return nil, errors.Wrap(ErrViewEntity, err)
+		}
+		if cert.Revoked || cert.ExpiryTime.Before(now) || cert.ExpiryTime.After(deadline) {
+			continue
+		}
+		cert.EntityID = c.EntityID
+		cert.DomainID = c.DomainID
+		cert.FromCSR = c.FromCSR
+		expiring = append(expiring, cert)
+	}
+
+	slices.SortFunc(expiring, func(a, b Certificate) int {
+		return a.ExpiryTime.Compare(b.ExpiryTime)
+	})
+
+	return expiring, nil
+}
+
+// renewalPolicy retrieves the Domain renewal policy, falling back to the
+// configured one if the Domain has no renewal policy.
+func (s *service) renewalPolicy(ctx context.Context, domainID string) (RenewalPolicy, error) {
+	policy := RenewalPolicy{
+		DomainID:  domainID,
+		AutoRenew: s.renewal.AutoRenew,
+	}
+	if domainID == "" {
+		return policy, nil
+	}
+
+	p, err := s.repo.RetrieveRenewalPolicy(ctx, domainID)
+	switch {
+	case err == nil:
+		return p, nil
+	case errors.Contains(err, ErrNotFound):
+		return policy, nil
+	default:
+		return RenewalPolicy{}, errors.Wrap(ErrViewEntity, err)
+	}
+}
+
+func (s *service) renew(ctx context.Context, cert Certificate) (Certificate, error) {
+	newCert, err := s.pki.Renew(cert, certValidityPeriod.String())
+	if err != nil {
+		return Certificate{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	if err := s.repo.SaveCertEntityMapping(ctx, newCert.SerialNumber, cert.EntityID, cert.DomainID, newCert.ExpiryTime, false); err != nil {
+		return Certificate{}, errors.Wrap(ErrUpdateEntity, err)
+	}
+
+	newCert.EntityID = cert.EntityID
+	newCert.DomainID = cert.DomainID
+
+	return newCert, nil
+}
+
+// sendDigests sends the expiry digest of each Domain to the recipients of the Domain policy.
+func (s *service) sendDigests(ctx context.Context, policies map[string]RenewalPolicy, renewals []Renewal) error {
+	digests := make(map[string][]Renewal)
+	for _, r := range renewals {
+		domainID := r.Certificate.DomainID
+		if len(policies[domainID].Emails) > 0 {
+			digests[domainID] = append(digests[domainID], r)
+		}
+	}
+
+	var failed []string
+	var ferr error
+	for domainID, rs := range digests {
+		digest := ExpiryDigest{
+			DomainID: domainID,
+			Window:   s.renewal.Window,
+			Renewals: rs,
+		}
+		if err := s.notifier.Notify(ctx, policies[domainID].Emails, digest); err != nil {
+			failed, ferr = append(failed, domainID), err
+		}
+	}
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		return errors.Wrap(ErrNotifyExpiry, fmt.Errorf("domains %s: %w", strings.Join(failed, ", "), ferr))
+	}
+
+	return nil
+}
@@ -9,23 +9,28 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/absmach/supermq/alarms"
 	httpAPI "github.com/absmach/supermq/alarms/api"
 	"github.com/absmach/supermq/alarms/brokers"
 	"github.com/absmach/supermq/alarms/consumer"
 	"github.com/absmach/supermq/alarms/middleware"
+	"github.com/absmach/supermq/alarms/notifier"
 	"github.com/absmach/supermq/alarms/operations"
 	alarmsRepo "github.com/absmach/supermq/alarms/postgres"
 	dpostgres "github.com/absmach/supermq/domains/postgres"
+	"github.com/absmach/supermq/internal/email"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/authn/authsvc"
 	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
 	dconsumer "github.com/absmach/supermq/pkg/domains/events/consumer"
 	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/emailer"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	"github.com/absmach/supermq/pkg/jaeger"
+	pkglog "github.com/absmach/supermq/pkg/logger"
 	"github.com/absmach/supermq/pkg/messaging"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	"github.com/absmach/supermq/pkg/permissions"
@@ -34,6 +39,7 @@ import (
 	rconsumer "github.com/absmach/supermq/pkg/re/events/consumer"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
+	"github.com/absmach/supermq/pkg/ticker"
 	"github.com/absmach/supermq/pkg/uuid"
 	rpostgres "github.com/absmach/supermq/re/postgres"
 	"github.com/caarlos0/env/v11"
@@ -49,17 +55,23 @@ const (
 	defSvcHTTPPort   = "8050"
 	envPrefixDomains = "MG_DOMAINS_GRPC_"
 	alarmEntity      = "alarm"
+	channBuffer      = 256
 )
 
 type config struct {
-	LogLevel        string  `env:"MG_ALARMS_LOG_LEVEL"    envDefault:"info"`
-	BrokerURL       string  `env:"MG_MESSAGE_BROKER_URL" envDefault:"nats://localhost:4222"`
-	InstanceID      string  `env:"MG_ALARMS_INSTANCE_ID"  envDefault:""`
-	JaegerURL       url.URL `env:"MG_JAEGER_URL"         envDefault:"http://localhost:4318/v1/traces"`
-	TraceRatio      float64 `env:"MG_JAEGER_TRACE_RATIO" envDefault:"1.0"`
-	ESURL           string  `env:"MG_ES_URL"             envDefault:"nats://localhost:4222"`
-	ESConsumerName  string  `env:"MG_ALARMS_EVENT_CONSUMER" envDefault:"alarms"`
-	PermissionsFile string  `env:"MG_PERMISSIONS_FILE"             envDefault:"permission.yaml"`
+	LogLevel          string        `env:"MG_ALARMS_LOG_LEVEL"    envDefault:"info"`
+	BrokerURL         string        `env:"MG_MESSAGE_BROKER_URL" envDefault:"nats://localhost:4222"`
+	InstanceID        string        `env:"MG_ALARMS_INSTANCE_ID"  envDefault:""`
+	JaegerURL         url.URL       `env:"MG_JAEGER_URL"         envDefault:"http://localhost:4318/v1/traces"`
+	TraceRatio        float64       `env:"MG_JAEGER_TRACE_RATIO" envDefault:"1.0"`
+	ESURL             string        `env:"MG_ES_URL"             envDefault:"nats://localhost:4222"`
+	ESConsumerName    string        `env:"MG_ALARMS_EVENT_CONSUMER" envDefault:"alarms"`
+	PermissionsFile   string        `env:"MG_PERMISSIONS_FILE"             envDefault:"permission.yaml"`
+	ResolveOnClear    bool          `env:"MG_ALARMS_RESOLVE_ON_CLEAR"    envDefault:"true"`
+	QuietPeriod       time.Duration `env:"MG_ALARMS_QUIET_PERIOD"        envDefault:"0s"`
+	EscalateAfter     time.Duration `env:"MG_ALARMS_ESCALATE_AFTER"      envDefault:"0s"`
+	EscalationStep    uint8         `env:"MG_ALARMS_ESCALATION_STEP"     envDefault:"10"`
+	SchedulerInterval time.Duration `env:"MG_ALARMS_SCHEDULER_INTERVAL"  envDefault:"1m"`
 }
 
 func main() {
@@ -92,6 +104,13 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		logger.Error(fmt.Sprintf("failed to load email configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
 	dbConfig := postgres.Config{Name: defDB}
 	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
 		logger.Error(err.Error())
@@ -175,7 +194,25 @@ func main() {
 
 	idp := uuid.New()
 
-	svc := alarms.NewService(idp, repo)
+	runInfo := make(chan pkglog.RunInfo, channBuffer)
+	go func() {
+		for info := range runInfo {
+			logger.LogAttrs(context.Background(), info.Level, info.Message, info.Details...)
+		}
+	}()
+
+	lifecycle := alarms.Lifecycle{
+		ResolveOnClear: cfg.ResolveOnClear,
+		QuietPeriod:    cfg.QuietPeriod,
+		EscalateAfter:  cfg.EscalateAfter,
+		EscalationStep: cfg.EscalationStep,
+	}
+	emailClient, err := emailer.New(&ec)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
+	}
+
+	svc := alarms.NewService(idp, repo, notifier.New(emailClient), runInfo, ticker.NewTicker(cfg.SchedulerInterval), lifecycle)
 
 	permConfig, err := permissions.ParsePermissionsFile(cfg.PermissionsFile)
 	if err != nil {
@@ -249,6 +286,10 @@ func main() {
 		return
 	}
 
+	g.Go(func() error {
+		return svc.StartScheduler(ctx)
+	})
+
 	g.Go(func() error {
 		return hs.Start()
 	})
@@ -5,9 +5,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/url"
 	"os"
 	"strings"
@@ -17,19 +19,27 @@ import (
 	"github.com/absmach/supermq/certs"
 	certsgrpc "github.com/absmach/supermq/certs/api/grpc"
 	httpapi "github.com/absmach/supermq/certs/api/http"
+	certsbs "github.com/absmach/supermq/certs/bootstrap"
+	"github.com/absmach/supermq/certs/events"
 	"github.com/absmach/supermq/certs/middleware"
+	"github.com/absmach/supermq/certs/notifier"
 	"github.com/absmach/supermq/certs/pki"
 	"github.com/absmach/supermq/certs/postgres"
+	"github.com/absmach/supermq/internal/email"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
 	smqauthz "github.com/absmach/supermq/pkg/authz"
 	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
 	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/emailer"
+	pkgevents "github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	"github.com/absmach/supermq/pkg/jaeger"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/prometheus"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
 	smq "github.com/absmach/supermq/pkg/server"
 	grpcserver "github.com/absmach/supermq/pkg/server/grpc"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
@@ -53,14 +63,34 @@ const (
 	defSvcGRPCPort   = "7012"
 	defDB            = "certs"
 	serviceTokenKey  = "SERVICE_TOKEN="
+	openBaoAgent     = "openbao"
+	localAgent       = "local"
 )
 
 type config struct {
-	LogLevel   string  `env:"MG_CERTS_LOG_LEVEL"            envDefault:"info"`
-	JaegerURL  url.URL `env:"MG_JAEGER_URL"                 envDefault:"http://jaeger:4318"`
-	InstanceID string  `env:"MG_CERTS_INSTANCE_ID"          envDefault:""`
-	TraceRatio float64 `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
-	Secret     string  `env:"MG_CERTS_SECRET"               envDefault:""`
+	LogLevel     string  `env:"MG_CERTS_LOG_LEVEL"            envDefault:"info"`
+	JaegerURL    url.URL `env:"MG_JAEGER_URL"                 envDefault:"http://jaeger:4318"`
+	InstanceID   string  `env:"MG_CERTS_INSTANCE_ID"          envDefault:""`
+	TraceRatio   float64 `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+	Secret       string  `env:"MG_CERTS_SECRET"               envDefault:""`
+	BootstrapURL string  `env:"MG_BOOTSTRAP_URL"              envDefault:"http://localhost:9013"`
+	ESURL        string  `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
+
+	// Renewal of the certificates about to expire
+	RenewalWindow        time.Duration `env:"MG_CERTS_RENEWAL_WINDOW"          envDefault:"168h"`
+	AutoRenew            bool          `env:"MG_CERTS_AUTO_RENEW"              envDefault:"false"`
+	RenewalCheckInterval time.Duration `env:"MG_CERTS_RENEWAL_CHECK_INTERVAL"  envDefault:"24h"`
+
+	// PKIAgent is either "openbao" or "local"
+	PKIAgent string `env:"MG_CERTS_PKI_AGENT" envDefault:"openbao"`
+
+	// Local CA settings
+	LocalCADir         string   `env:"MG_CERTS_LOCAL_CA_DIR"           envDefault:"ca"`
+	LocalCACommonName  string   `env:"MG_CERTS_LOCAL_CA_CN"            envDefault:"Abstract Machines Certificate Authority"`
+	LocalCAOrg         []string `env:"MG_CERTS_LOCAL_CA_O"             envDefault:"Abstract Machines"   envSeparator:","`
+	LocalCACountry     []string `env:"MG_CERTS_LOCAL_CA_C"                                              envSeparator:","`
+	LocalCADNSNames    []string `env:"MG_CERTS_LOCAL_CA_DNS_NAMES"     envDefault:"localhost"           envSeparator:","`
+	LocalCAIPAddresses []string `env:"MG_CERTS_LOCAL_CA_IP_ADDRESSES"  envDefault:"127.0.0.1,::1"       envSeparator:","`
 
 	// OpenBao PKI settings
 	OpenBaoHost          string `env:"MG_CERTS_OPENBAO_HOST"            envDefault:"http://localhost:8200"`
@@ -103,51 +133,17 @@ func main() {
 		}
 	}
 
-	if cfg.OpenBaoHost == "" {
-		logger.Error("No host specified for OpenBao PKI engine")
-		exitCode = 1
-		return
-	}
-
-	if cfg.OpenBaoAppRole == "" {
-		logger.Error("OpenBao AppRole not specified")
-		exitCode = 1
-		return
-	}
-
-	secretID := cfg.OpenBaoAppSecret
-	if secretID == "" && cfg.SecretIDPath != "" {
-		secretData, err := os.ReadFile(cfg.SecretIDPath)
-		if err != nil {
-			logger.Error("Failed to read secret ID from file", "path", cfg.SecretIDPath, "error", err)
-			exitCode = 1
-			return
-		}
-		secretID = strings.TrimSpace(string(secretData))
-	}
-
-	if secretID == "" {
-		logger.Error("OpenBao secret ID not specified (provide via MG_CERTS_OPENBAO_APP_SECRET or MG_CERTS_SECRET_ID_PATH)")
-		exitCode = 1
-		return
-	}
-
-	serviceToken := cfg.OpenBaoServiceToken
-	if serviceToken == "" && cfg.ServiceTokenPath != "" {
-		tokenData, err := os.ReadFile(cfg.ServiceTokenPath)
-		if err != nil {
-			logger.Warn("Failed to read service token from file, secret renewal will be disabled", "path", cfg.ServiceTokenPath, "error", err)
-		} else {
-			tokenLine := string(tokenData)
-			if strings.HasPrefix(tokenLine, serviceTokenKey) {
-				serviceToken = strings.TrimSpace(strings.TrimPrefix(tokenLine, serviceTokenKey))
-			}
-		}
+	var pkiAgent certs.Agent
+	switch cfg.PKIAgent {
+	case openBaoAgent:
+		pkiAgent, err = newOpenBaoAgent(cfg, logger)
+	case localAgent:
+		pkiAgent, err = newLocalAgent(cfg, logger)
+	default:
+		err = fmt.Errorf("unknown PKI agent %q, supported agents are %q and %q", cfg.PKIAgent, openBaoAgent, localAgent)
 	}
-
-	pkiAgent, err := pki.NewAgent(cfg.OpenBaoAppRole, secretID, cfg.OpenBaoHost, cfg.OpenBaoNamespace, cfg.OpenBaoPKIPath, cfg.OpenBaoRole, serviceToken, cfg.SecretRenewThreshold, cfg.SecretIDTTL, cfg.SecretCheckInterval, logger)
 	if err != nil {
-		logger.Error("failed to configure client for OpenBao PKI engine")
+		logger.Error(fmt.Sprintf("failed to configure PKI agent: %s", err))
 		exitCode = 1
 		return
 	}
@@ -229,7 +225,33 @@ func main() {
 		return
 	}
 
-	svc := newService(ctx, db, dbConfig, tracer, logger, pkiAgent, authz)
+	bsClient := certsbs.NewClient(mgsdk.NewSDK(mgsdk.Config{BootstrapURL: cfg.BootstrapURL}), cfg.Secret)
+
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		logger.Error(fmt.Sprintf("failed to load email configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	emailClient, err := emailer.New(&ec)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to configure e-mailing util: %s", err.Error()))
+	}
+
+	publisher, err := store.NewPublisher(ctx, cfg.ESURL, "certs-es-pub")
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create event store publisher: %s", err))
+		exitCode = 1
+		return
+	}
+
+	renewal := certs.RenewalConfig{
+		Window:    cfg.RenewalWindow,
+		AutoRenew: cfg.AutoRenew,
+	}
+	svc := newService(ctx, db, dbConfig, tracer, logger, pkiAgent, bsClient, authz, publisher, notifier.New(emailClient), renewal)
+
+	certs.NewRenewalHandler(ctx, svc, cfg.RenewalCheckInterval, logger)
 
 	grpcServerConfig := smq.Config{Port: defSvcGRPCPort}
 	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
@@ -263,15 +285,16 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, logger *slog.Logger, pkiAgent certs.Agent, authz smqauthz.Authorization) certs.Service {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, tracer trace.Tracer, logger *slog.Logger, pkiAgent certs.Agent, bsClient certs.Bootstrap, authz smqauthz.Authorization, publisher pkgevents.Publisher, n certs.Notifier, renewal certs.RenewalConfig) certs.Service {
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 	repo := postgres.NewRepository(database)
-	svc, err := certs.NewService(ctx, pkiAgent, repo)
+	svc, err := certs.NewService(ctx, pkiAgent, repo, bsClient, n, renewal)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create service: %s", err))
 		return nil
 	}
 	svc = middleware.AuthorizationMiddleware(authz, svc)
+	svc = events.NewEventStoreMiddleware(svc, publisher)
 	svc = middleware.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
 	svc = middleware.MetricsMiddleware(svc, counter, latency)
@@ -280,6 +303,70 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, trac
 	return svc
 }
 
+func newOpenBaoAgent(cfg config, logger *slog.Logger) (certs.Agent, error) {
+	if cfg.OpenBaoHost == "" {
+		return nil, errors.New("no host specified for OpenBao PKI engine")
+	}
+
+	if cfg.OpenBaoAppRole == "" {
+		return nil, errors.New("OpenBao AppRole not specified")
+	}
+
+	secretID := cfg.OpenBaoAppSecret
+	if secretID == "" && cfg.SecretIDPath != "" {
+		secretData, err := os.ReadFile(cfg.SecretIDPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret ID from file %s: %w", cfg.SecretIDPath, err)
+		}
+		secretID = strings.TrimSpace(string(secretData))
+	}
+
+	if secretID == "" {
+		return nil, errors.New("OpenBao secret ID not specified (provide via MG_CERTS_OPENBAO_APP_SECRET or MG_CERTS_SECRET_ID_PATH)")
+	}
+
+	serviceToken := cfg.OpenBaoServiceToken
+	if serviceToken == "" && cfg.ServiceTokenPath != "" {
+		tokenData, err := os.ReadFile(cfg.ServiceTokenPath)
+		if err != nil {
+			logger.Warn("Failed to read service token from file, secret renewal will be disabled", "path", cfg.ServiceTokenPath, "error", err)
+		} else {
+			tokenLine := string(tokenData)
+			if strings.HasPrefix(tokenLine, serviceTokenKey) {
+				serviceToken = strings.TrimSpace(strings.TrimPrefix(tokenLine, serviceTokenKey))
+			}
+		}
+	}
+
+	pkiAgent, err := pki.NewAgent(cfg.OpenBaoAppRole, secretID, cfg.OpenBaoHost, cfg.OpenBaoNamespace, cfg.OpenBaoPKIPath, cfg.OpenBaoRole, serviceToken, cfg.SecretRenewThreshold, cfg.SecretIDTTL, cfg.SecretCheckInterval, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure client for OpenBao PKI engine: %w", err)
+	}
+
+	return pkiAgent, nil
+}
+
+func newLocalAgent(cfg config, logger *slog.Logger) (certs.Agent, error) {
+	subject := certs.SubjectOptions{
+		CommonName:   cfg.LocalCACommonName,
+		Organization: cfg.LocalCAOrg,
+		Country:      cfg.LocalCACountry,
+		DnsNames:     cfg.LocalCADNSNames,
+	}
+	for _, addr := range cfg.LocalCAIPAddresses {
+		if addr == "" {
+			continue
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid local CA IP address %q", addr)
+		}
+		subject.IpAddresses = append(subject.IpAddresses, ip)
+	}
+
+	return pki.NewLocalAgent(pki.LocalConfig{Dir: cfg.LocalCADir, Subject: subject}, logger)
+}
+
 func initLogger(levelText string) (*slog.Logger, error) {
 	var level slog.Level
 	if err := level.UnmarshalText([]byte(levelText)); err != nil {
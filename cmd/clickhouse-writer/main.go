@@ -0,0 +1,209 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains clickhouse-writer main function to start the clickhouse-writer service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/consumers"
+	consumertracing "github.com/absmach/supermq/consumers/tracing"
+	httpapi "github.com/absmach/supermq/consumers/writers/api"
+	"github.com/absmach/supermq/consumers/writers/batch"
+	"github.com/absmach/supermq/consumers/writers/brokers"
+	"github.com/absmach/supermq/consumers/writers/clickhouse"
+	"github.com/absmach/supermq/consumers/writers/validation"
+	validationch "github.com/absmach/supermq/consumers/writers/validation/clickhouse"
+	validationevents "github.com/absmach/supermq/consumers/writers/validation/events"
+	smqlog "github.com/absmach/supermq/logger"
+	clickhouseclient "github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/absmach/supermq/pkg/errors"
+	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
+	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/server"
+	httpserver "github.com/absmach/supermq/pkg/server/http"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "clickhouse-writer"
+	envPrefixDB    = "MG_CLICKHOUSE_"
+	envPrefixHTTP  = "MG_CLICKHOUSE_WRITER_HTTP_"
+	defDB          = "messages"
+	defSvcHTTPPort = "9024"
+
+	defRetentionCheckInterval = time.Hour
+)
+
+type config struct {
+	LogLevel            string        `env:"MG_CLICKHOUSE_WRITER_LOG_LEVEL"            envDefault:"info"`
+	ConfigPath          string        `env:"MG_CLICKHOUSE_WRITER_CONFIG_PATH"          envDefault:"/config.toml"`
+	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"                     envDefault:"nats://localhost:4222"`
+	ESURL               string        `env:"MG_ES_URL"                                 envDefault:"nats://localhost:4222"`
+	JaegerURL           url.URL       `env:"MG_JAEGER_URL"                             envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"                         envDefault:"true"`
+	InstanceID          string        `env:"MG_CLICKHOUSE_WRITER_INSTANCE_ID"          envDefault:""`
+	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"                     envDefault:"1.0"`
+	QuarantineRetention time.Duration `env:"MG_CLICKHOUSE_WRITER_QUARANTINE_RETENTION" envDefault:"720h"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s service configuration : %s", svcName, err)
+	}
+
+	logger, err := smqlog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer smqlog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := clickhouseclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s ClickHouse configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	db, err := clickhouseclient.Setup(ctx, dbConfig, clickhouse.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	batchCfg, err := batch.LoadConfig(cfg.ConfigPath)
+	switch {
+	case errors.Contains(err, batch.ErrOpenConfFile):
+		logger.Warn(fmt.Sprintf("failed to open ClickHouse writer configuration, batching is disabled: %s", err))
+	case err != nil:
+		logger.Error(fmt.Sprintf("failed to load ClickHouse writer batch configuration: %s", err))
+		exitCode = 1
+		return
+	}
+
+	repo := newService(ctx, db, batchCfg, logger)
+	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
+
+	quarantine := validationch.New(db)
+	vc, err := validation.NewConsumer(ctx, repo, quarantine)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create ClickHouse writer validation: %s", err))
+		exitCode = 1
+		return
+	}
+	repo = vc
+	if err := validationevents.Subscribe(ctx, vc, cfg.ESURL, svcName, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel events: %s", err))
+		exitCode = 1
+		return
+	}
+	validation.NewRetentionHandler(ctx, quarantine, cfg.QuarantineRetention, defRetentionCheckInterval, logger)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, brokers.AllTopic, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create ClickHouse writer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, supermq.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("ClickHouse writer service terminated: %s", err))
+	}
+}
+
+func newService(ctx context.Context, db *clickhouseclient.Client, batchCfg batch.Config, logger *slog.Logger) consumers.BlockingConsumer {
+	var svc consumers.BlockingConsumer
+	switch {
+	case batchCfg.Size > 0:
+		repo := clickhouse.NewBatchRepository(db)
+		repo = httpapi.BatchLoggingMiddleware(repo, logger)
+		counter, latency := prometheus.MakeMetrics("clickhouse", "batch_writer")
+		size := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "clickhouse",
+			Subsystem: "batch_writer",
+			Name:      "batch_size",
+			Help:      "Number of records in the saved batches.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"method"})
+		repo = httpapi.BatchMetricsMiddleware(repo, counter, latency, size)
+		svc = batch.New(ctx, repo, batchCfg)
+	default:
+		svc = clickhouse.New(db)
+	}
+	svc = httpapi.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("clickhouse", "message_writer")
+	svc = httpapi.MetricsMiddleware(svc, counter, latency)
+	return svc
+}
@@ -6,11 +6,17 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
@@ -61,6 +67,15 @@ type config struct {
 	TraceRatio       float64 `env:"MG_JAEGER_TRACE_RATIO"  envDefault:"1.0"`
 	AuthKeyAlgorithm string  `env:"MG_AUTH_KEYS_ALGORITHM" envDefault:"RS256"`
 	JWKSURL          string  `env:"MG_AUTH_JWKS_URL"       envDefault:"http://auth:9001/keys/.well-known/jwks.json"`
+	// ExportKeyPath is the PEM encoded PKCS #8 Ed25519 private key which signs the exports and the archives.
+	// The key is generated and saved to the path if the file does not exist.
+	ExportKeyPath          string        `env:"MG_JOURNAL_EXPORT_KEY_PATH"          envDefault:"journal-export-key.pem"`
+	Retention              time.Duration `env:"MG_JOURNAL_RETENTION"                envDefault:"0"`
+	RetentionCheckInterval time.Duration `env:"MG_JOURNAL_RETENTION_CHECK_INTERVAL" envDefault:"1h"`
+	ArchiveDir             string        `env:"MG_JOURNAL_ARCHIVE_DIR"              envDefault:""`
+	// PresenceTimeout is the inactivity after which the clients without the open sessions are marked offline.
+	PresenceTimeout       time.Duration `env:"MG_JOURNAL_PRESENCE_TIMEOUT"        envDefault:"15m"`
+	PresenceCheckInterval time.Duration `env:"MG_JOURNAL_PRESENCE_CHECK_INTERVAL" envDefault:"1m"`
 }
 
 func main() {
@@ -175,7 +190,24 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	svc := newService(db, dbConfig, authz, logger, tracer)
+	exportKey, created, err := loadExportKey(cfg.ExportKeyPath)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load export key: %s", err))
+		exitCode = 1
+		return
+	}
+	if created {
+		logger.Warn(fmt.Sprintf("export key is generated and saved to %s, its public key is %s", cfg.ExportKeyPath, base64.StdEncoding.EncodeToString(exportKey.Public().(ed25519.PublicKey))))
+	}
+
+	svc := newService(db, dbConfig, authz, exportKey, cfg.ArchiveDir, logger, tracer)
+
+	if cfg.Retention > 0 {
+		journal.NewRetentionHandler(ctx, svc, cfg.Retention, cfg.RetentionCheckInterval, logger)
+	}
+	if cfg.PresenceTimeout > 0 {
+		journal.NewPresenceHandler(ctx, svc, cfg.PresenceTimeout, cfg.PresenceCheckInterval, logger)
+	}
 
 	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, "journal-es-sub", logger)
 	if err != nil {
@@ -219,12 +251,12 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, logger *slog.Logger, tracer trace.Tracer) journal.Service {
+func newService(db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, exportKey ed25519.PrivateKey, archiveDir string, logger *slog.Logger, tracer trace.Tracer) journal.Service {
 	database := postgres.NewDatabase(db, dbConfig, tracer)
 	repo := journalpg.NewRepository(database)
 	idp := uuid.New()
 
-	svc := journal.NewService(idp, repo)
+	svc := journal.NewService(idp, repo, exportKey, archiveDir)
 	svc = middleware.NewAuthorization(svc, authz)
 	svc = middleware.NewLogging(svc, logger)
 	counter, latency := prometheus.MakeMetrics("journal", "journal_writer")
@@ -233,3 +265,60 @@ func newService(db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorizat
 
 	return svc
 }
+
+// loadExportKey reads the export key from the file. If the file does not
+// exist, the new key is generated and saved to it, so the exports and the
+// archives are signed with the same key after the restart.
+func loadExportKey(path string) (ed25519.PrivateKey, bool, error) {
+	if path == "" {
+		return nil, false, fmt.Errorf("export key path is not set")
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		key, err := createExportKey(path)
+		return key, err == nil, err
+	case err != nil:
+		return nil, false, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, false, fmt.Errorf("no PEM data found in %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, false, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, false, fmt.Errorf("%s is not Ed25519 private key", path)
+	}
+
+	return edKey, false, nil
+}
+
+func createExportKey(path string) (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	// The file is not replaced if another instance created it in the meantime.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -11,15 +11,23 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/consumers"
 	consumertracing "github.com/absmach/supermq/consumers/tracing"
 	httpapi "github.com/absmach/supermq/consumers/writers/api"
+	"github.com/absmach/supermq/consumers/writers/batch"
 	"github.com/absmach/supermq/consumers/writers/brokers"
 	writerpg "github.com/absmach/supermq/consumers/writers/postgres"
+	"github.com/absmach/supermq/consumers/writers/retention"
+	retentionpg "github.com/absmach/supermq/consumers/writers/retention/postgres"
+	"github.com/absmach/supermq/consumers/writers/validation"
+	validationevents "github.com/absmach/supermq/consumers/writers/validation/events"
+	validationpg "github.com/absmach/supermq/consumers/writers/validation/postgres"
 	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
@@ -28,7 +36,9 @@ import (
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/caarlos0/env/v11"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -38,16 +48,20 @@ const (
 	envPrefixHTTP  = "MG_POSTGRES_WRITER_HTTP_"
 	defDB          = "messages"
 	defSvcHTTPPort = "9010"
+
+	defRetentionCheckInterval = time.Hour
 )
 
 type config struct {
-	LogLevel      string  `env:"MG_POSTGRES_WRITER_LOG_LEVEL"     envDefault:"info"`
-	ConfigPath    string  `env:"MG_POSTGRES_WRITER_CONFIG_PATH"   envDefault:"/config.toml"`
-	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
-	InstanceID    string  `env:"MG_POSTGRES_WRITER_INSTANCE_ID"   envDefault:""`
-	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+	LogLevel            string        `env:"MG_POSTGRES_WRITER_LOG_LEVEL"            envDefault:"info"`
+	ConfigPath          string        `env:"MG_POSTGRES_WRITER_CONFIG_PATH"          envDefault:"/config.toml"`
+	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"                   envDefault:"nats://localhost:4222"`
+	ESURL               string        `env:"MG_ES_URL"                               envDefault:"nats://localhost:4222"`
+	JaegerURL           url.URL       `env:"MG_JAEGER_URL"                           envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"                       envDefault:"true"`
+	InstanceID          string        `env:"MG_POSTGRES_WRITER_INSTANCE_ID"          envDefault:""`
+	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"                   envDefault:"1.0"`
+	QuarantineRetention time.Duration `env:"MG_POSTGRES_WRITER_QUARANTINE_RETENTION" envDefault:"720h"`
 }
 
 func main() {
@@ -116,15 +130,56 @@ func main() {
 	defer pubSub.Close()
 	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
-	repo := newService(db, logger)
+	batchCfg, err := batch.LoadConfig(cfg.ConfigPath)
+	switch {
+	case errors.Contains(err, batch.ErrOpenConfFile):
+		logger.Warn(fmt.Sprintf("failed to open Postgres writer configuration, batching is disabled: %s", err))
+	case err != nil:
+		logger.Error(fmt.Sprintf("failed to load Postgres writer batch configuration: %s", err))
+		exitCode = 1
+		return
+	}
+
+	repo := newService(ctx, db, batchCfg, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
 
+	quarantine := validationpg.New(db)
+	vc, err := validation.NewConsumer(ctx, repo, quarantine)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create Postgres writer validation: %s", err))
+		exitCode = 1
+		return
+	}
+	repo = vc
+	if err := validationevents.Subscribe(ctx, vc, cfg.ESURL, svcName, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to channel events: %s", err))
+		exitCode = 1
+		return
+	}
+	validation.NewRetentionHandler(ctx, quarantine, cfg.QuarantineRetention, defRetentionCheckInterval, logger)
+
 	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, brokers.AllTopic, logger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create Postgres writer: %s", err))
 		exitCode = 1
 		return
 	}
 
+	retentionCfg, err := retention.LoadConfig(cfg.ConfigPath, defRetentionCheckInterval)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load Postgres writer retention configuration: %s", err))
+		exitCode = 1
+		return
+	}
+	if len(retentionCfg.Policies) > 0 {
+		retentionSvc, err := retention.NewService(retentionpg.New(db), retentionCfg.Policies)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to create Postgres writer retention service: %s", err))
+			exitCode = 1
+			return
+		}
+		retention.NewHandler(ctx, retentionSvc, retentionCfg.CheckInterval, logger)
+	}
+
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
@@ -145,8 +200,25 @@ func main() {
 	}
 }
 
-func newService(db *sqlx.DB, logger *slog.Logger) consumers.BlockingConsumer {
-	svc := writerpg.New(db)
+func newService(ctx context.Context, db *sqlx.DB, batchCfg batch.Config, logger *slog.Logger) consumers.BlockingConsumer {
+	var svc consumers.BlockingConsumer
+	switch {
+	case batchCfg.Size > 0:
+		repo := writerpg.NewBatchRepository(db)
+		repo = httpapi.BatchLoggingMiddleware(repo, logger)
+		counter, latency := prometheus.MakeMetrics("postgres", "batch_writer")
+		size := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "postgres",
+			Subsystem: "batch_writer",
+			Name:      "batch_size",
+			Help:      "Number of records in the saved batches.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"method"})
+		repo = httpapi.BatchMetricsMiddleware(repo, counter, latency, size)
+		svc = batch.New(ctx, repo, batchCfg)
+	default:
+		svc = writerpg.New(db)
+	}
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("postgres", "message_writer")
 	svc = httpapi.MetricsMiddleware(svc, counter, latency)
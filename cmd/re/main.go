@@ -57,6 +57,8 @@ import (
 	"github.com/authzed/grpcutil"
 	"github.com/caarlos0/env/v11"
 	"github.com/go-chi/chi/v5"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -81,21 +83,25 @@ const (
 const channBuffer = 256
 
 type config struct {
-	LogLevel            string        `env:"MG_RE_LOG_LEVEL"             envDefault:"info"`
-	InstanceID          string        `env:"MG_RE_INSTANCE_ID"           envDefault:""`
-	JaegerURL           url.URL       `env:"MG_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"          envDefault:"true"`
-	ESURL               string        `env:"MG_ES_URL"                  envDefault:"nats://localhost:4222"`
-	ESConsumerName      string        `env:"MG_RE_EVENT_CONSUMER"        envDefault:"rules_engine"`
-	CacheURL            string        `env:"MG_RE_CACHE_URL"             envDefault:"redis://localhost:6379/0"`
-	CacheKeyDuration    time.Duration `env:"MG_RE_CACHE_KEY_DURATION"    envDefault:"10m"`
-	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
-	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
-	SpicedbHost         string        `env:"MG_SPICEDB_HOST"            envDefault:"localhost"`
-	SpicedbPort         string        `env:"MG_SPICEDB_PORT"            envDefault:"50051"`
-	SpicedbPreSharedKey string        `env:"MG_SPICEDB_PRE_SHARED_KEY"  envDefault:"12345678"`
-	SpicedbSchemaFile   string        `env:"MG_SPICEDB_SCHEMA_FILE"     envDefault:"schema.zed"`
-	PermissionsFile     string        `env:"MG_PERMISSIONS_FILE"        envDefault:"permission.yaml"`
+	LogLevel               string        `env:"MG_RE_LOG_LEVEL"                envDefault:"info"`
+	InstanceID             string        `env:"MG_RE_INSTANCE_ID"              envDefault:""`
+	JaegerURL              url.URL       `env:"MG_JAEGER_URL"                  envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry          bool          `env:"MG_SEND_TELEMETRY"              envDefault:"true"`
+	ESURL                  string        `env:"MG_ES_URL"                      envDefault:"nats://localhost:4222"`
+	ESConsumerName         string        `env:"MG_RE_EVENT_CONSUMER"           envDefault:"rules_engine"`
+	CacheURL               string        `env:"MG_RE_CACHE_URL"                envDefault:"redis://localhost:6379/0"`
+	CacheKeyDuration       time.Duration `env:"MG_RE_CACHE_KEY_DURATION"       envDefault:"10m"`
+	TraceRatio             float64       `env:"MG_JAEGER_TRACE_RATIO"          envDefault:"1.0"`
+	BrokerURL              string        `env:"MG_MESSAGE_BROKER_URL"          envDefault:"nats://localhost:4222"`
+	SpicedbHost            string        `env:"MG_SPICEDB_HOST"                envDefault:"localhost"`
+	SpicedbPort            string        `env:"MG_SPICEDB_PORT"                envDefault:"50051"`
+	SpicedbPreSharedKey    string        `env:"MG_SPICEDB_PRE_SHARED_KEY"      envDefault:"12345678"`
+	SpicedbSchemaFile      string        `env:"MG_SPICEDB_SCHEMA_FILE"         envDefault:"schema.zed"`
+	PermissionsFile        string        `env:"MG_PERMISSIONS_FILE"            envDefault:"permission.yaml"`
+	ExecutionsRetention    time.Duration `env:"MG_RE_EXECUTIONS_RETENTION"     envDefault:"720h"`
+	ExecutionsSampleRate   float64       `env:"MG_RE_EXECUTIONS_SAMPLE_RATE"   envDefault:"1.0"`
+	DeadLettersRetention   time.Duration `env:"MG_RE_DEAD_LETTERS_RETENTION"   envDefault:"720h"`
+	RetentionCheckInterval time.Duration `env:"MG_RE_RETENTION_CHECK_INTERVAL" envDefault:"1h"`
 }
 
 func main() {
@@ -364,11 +370,33 @@ func newService(ctx context.Context, cfg config, db pgclient.Database, runInfo c
 		return nil, fmt.Errorf("failed to get available actions and built-in roles: %w", err)
 	}
 
-	csvc, err := re.NewService(repo, runInfo, policyService, idp, rePubSub, writersPub, alarmsPub, ticker.NewTicker(time.Second*30), emailerClient, readersClient, availableActions, builtInRoles)
+	execCounter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "re",
+		Subsystem: "rules",
+		Name:      "executions_total",
+		Help:      "Number of rule executions by result level.",
+	}, []string{"domain_id", "rule_id", "level"})
+	execLatency := kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+		Namespace: "re",
+		Subsystem: "rules",
+		Name:      "execution_duration_seconds",
+		Help:      "Duration of rule executions in seconds.",
+		Buckets:   stdprometheus.DefBuckets,
+	}, []string{"domain_id", "rule_id"})
+
+	cache := re.NewRuleCache(repo, cfg.CacheKeyDuration)
+	// Every instance keeps its own cache, so it needs its own events consumer.
+	if err := events.CacheEventsSubscribe(ctx, cache, cfg.ESURL, fmt.Sprintf("%s-cache-%s", cfg.ESConsumerName, cfg.InstanceID), logger); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to rule events: %w", err)
+	}
+
+	csvc, err := re.NewService(repo, cache, runInfo, execCounter, execLatency, cfg.ExecutionsSampleRate, policyService, idp, rePubSub, writersPub, alarmsPub, ticker.NewTicker(time.Second*30), emailerClient, readersClient, availableActions, builtInRoles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RE service: %w", err)
 	}
 
+	re.NewRetentionHandler(ctx, repo, cfg.ExecutionsRetention, cfg.DeadLettersRetention, cfg.RetentionCheckInterval, logger)
+
 	csvc, err = events.NewEventStoreMiddleware(ctx, csvc, cfg.ESURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init re event store middleware: %w", err)
@@ -17,6 +17,8 @@ import (
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/pkg/authn/authsvc"
 	"github.com/absmach/supermq/pkg/grpcclient"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/policies/spicedb"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/prometheus"
 	"github.com/absmach/supermq/pkg/server"
@@ -28,10 +30,13 @@ import (
 	httpapi "github.com/absmach/supermq/readers/api/http"
 	middleware "github.com/absmach/supermq/readers/middleware"
 	"github.com/absmach/supermq/readers/timescale"
+	"github.com/authzed/authzed-go/v1"
+	"github.com/authzed/grpcutil"
 	"github.com/caarlos0/env/v11"
 	"github.com/jmoiron/sqlx"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -49,9 +54,12 @@ const (
 )
 
 type config struct {
-	LogLevel      string `env:"MG_TIMESCALE_READER_LOG_LEVEL"    envDefault:"info"`
-	SendTelemetry bool   `env:"MG_SEND_TELEMETRY"               envDefault:"true"`
-	InstanceID    string `env:"MG_TIMESCALE_READER_INSTANCE_ID"  envDefault:""`
+	LogLevel            string `env:"MG_TIMESCALE_READER_LOG_LEVEL"   envDefault:"info"`
+	SendTelemetry       bool   `env:"MG_SEND_TELEMETRY"               envDefault:"true"`
+	InstanceID          string `env:"MG_TIMESCALE_READER_INSTANCE_ID" envDefault:""`
+	SpicedbHost         string `env:"MG_SPICEDB_HOST"                 envDefault:"localhost"`
+	SpicedbPort         string `env:"MG_SPICEDB_PORT"                 envDefault:"50051"`
+	SpicedbPreSharedKey string `env:"MG_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
 }
 
 func main() {
@@ -155,13 +163,21 @@ func main() {
 	defer authnHandler.Close()
 	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
 
+	policyService, err := newSpiceDBPolicyService(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create policy service: %s", err))
+		exitCode = 1
+		return
+	}
+	logger.Info("Policy service successfully connected to SpiceDB gRPC server")
+
 	httpServerConfig := server.Config{Port: defSvcHTTPPort}
 	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, svcName, cfg.InstanceID), logger)
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(repo, authn, clientsClient, channelsClient, policyService, svcName, cfg.InstanceID), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -195,3 +211,16 @@ func newService(db *sqlx.DB, logger *slog.Logger) readers.MessageRepository {
 
 	return svc
 }
+
+func newSpiceDBPolicyService(cfg config, logger *slog.Logger) (policies.Service, error) {
+	client, err := authzed.NewClientWithExperimentalAPIs(
+		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpcutil.WithInsecureBearerToken(cfg.SpicedbPreSharedKey),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return spicedb.NewPolicyService(client, logger), nil
+}
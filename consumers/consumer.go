@@ -3,7 +3,11 @@
 
 package consumers
 
-import "context"
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
 
 // AsyncConsumer specifies a non-blocking message-consuming API,
 // which can be used for writing data to the DB, publishing messages
@@ -28,3 +32,15 @@ type BlockingConsumer interface {
 	// A non-nil error is returned to indicate operation failure.
 	ConsumeBlocking(ctx context.Context, messages any) error
 }
+
+// Quarantiner specifies the API of the blocking consumers which keep the
+// messages that can not be transformed instead of failing to consume them.
+type Quarantiner interface {
+	// Quarantines reports whether the messages of the channel are quarantined.
+	// The messages of the other channels fail to be consumed as usual.
+	Quarantines(channelID string) bool
+
+	// Quarantine keeps the message with the reason of its rejection.
+	// The message is acknowledged if a nil error is returned.
+	Quarantine(ctx context.Context, msg *messaging.Message, reason error) error
+}
@@ -14,9 +14,14 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/cbor"
 	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/mapping"
+	"github.com/absmach/supermq/pkg/transformers/msgpack"
+	"github.com/absmach/supermq/pkg/transformers/protobuf"
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/pelletier/go-toml"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 const (
@@ -27,6 +32,7 @@ const (
 var (
 	errOpenConfFile  = errors.New("unable to open configuration file")
 	errParseConfFile = errors.New("unable to parse configuration file")
+	errInvalidRule   = errors.New("invalid transformer rule")
 )
 
 // Start method starts consuming messages received from Message broker.
@@ -39,12 +45,18 @@ func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer an
 	}
 
 	transformer := makeTransformer(cfg.TransformerCfg, logger)
+	if len(cfg.TransformerCfg.Rules) > 0 {
+		if transformer, err = makeRegistry(transformer, cfg.TransformerCfg.Rules, logger); err != nil {
+			return err
+		}
+	}
 
 	for _, topic := range cfg.SubscriberCfg.Topics {
 		subCfg := messaging.SubscriberConfig{
 			ID:             id,
 			Topic:          topic,
 			DeliveryPolicy: messaging.DeliverAllPolicy,
+			Concurrency:    cfg.SubscriberCfg.Concurrency,
 		}
 		switch c := consumer.(type) {
 		case AsyncConsumer:
@@ -71,6 +83,9 @@ func handleSync(ctx context.Context, t transformers.Transformer, sc BlockingCons
 		if t != nil {
 			m, err = t.Transform(msg)
 			if err != nil {
+				if q, ok := sc.(Quarantiner); ok && q.Quarantines(msg.GetChannel()) {
+					return q.Quarantine(ctx, msg, err)
+				}
 				return err
 			}
 		}
@@ -106,12 +121,44 @@ func (h handleFunc) Cancel() error {
 
 type subscriberConfig struct {
 	Topics []string `toml:"topics"`
+	// Concurrency is the number of messages handled at the same time. Batching
+	// consumers need it to group the messages of the concurrent handlers.
+	Concurrency int `toml:"concurrency"`
 }
 
 type transformerConfig struct {
 	Format      string           `toml:"format"`
 	ContentType string           `toml:"content_type"`
 	TimeFields  []json.TimeField `toml:"time_fields"`
+	// Rules select the transformers of the messages of the channels or the
+	// topics, which take precedence over the transformer above.
+	Rules []ruleConfig `toml:"rules"`
+}
+
+type ruleConfig struct {
+	ChannelID   string           `toml:"channel_id"`
+	Topic       string           `toml:"topic"`
+	Format      string           `toml:"format"`
+	ContentType string           `toml:"content_type"`
+	TimeFields  []json.TimeField `toml:"time_fields"`
+	// DescriptorSet is the path of the Protobuf file descriptor set which
+	// describes the Message type.
+	DescriptorSet string         `toml:"descriptor_set"`
+	Message       string         `toml:"message"`
+	Mapping       *mappingConfig `toml:"mapping"`
+}
+
+type mappingConfig struct {
+	Flatten      bool                 `toml:"flatten"`
+	KeepUnmapped bool                 `toml:"keep_unmapped"`
+	Fields       []mappingFieldConfig `toml:"fields"`
+}
+
+type mappingFieldConfig struct {
+	From   string  `toml:"from"`
+	To     string  `toml:"to"`
+	Scale  float64 `toml:"scale"`
+	Offset float64 `toml:"offset"`
 }
 
 type config struct {
@@ -155,3 +202,79 @@ func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Tr
 		return nil
 	}
 }
+
+func makeRegistry(def transformers.Transformer, rules []ruleConfig, logger *slog.Logger) (transformers.Transformer, error) {
+	registry := transformers.NewRegistry(def)
+	descriptors := make(map[string]*protoregistry.Files)
+	for _, rc := range rules {
+		t, err := makeRuleTransformer(rc, descriptors)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case rc.ChannelID != "" && rc.Topic == "":
+			registry.RegisterChannel(rc.ChannelID, t)
+			logger.Info(fmt.Sprintf("Using %s transformer for channel %s", rc.Format, rc.ChannelID))
+		case rc.Topic != "" && rc.ChannelID == "":
+			if err := registry.RegisterTopic(rc.Topic, t); err != nil {
+				return nil, errors.Wrap(errInvalidRule, err)
+			}
+			logger.Info(fmt.Sprintf("Using %s transformer for topic %s", rc.Format, rc.Topic))
+		default:
+			return nil, errors.Wrap(errInvalidRule, fmt.Errorf("rule must set either channel_id or topic"))
+		}
+	}
+
+	return registry, nil
+}
+
+func makeRuleTransformer(rc ruleConfig, descriptors map[string]*protoregistry.Files) (transformers.Transformer, error) {
+	var t transformers.Transformer
+	switch strings.ToUpper(rc.Format) {
+	case "SENML":
+		if rc.Mapping != nil {
+			return nil, errors.Wrap(errInvalidRule, fmt.Errorf("field mapping is not supported for SenML"))
+		}
+		return senml.New(rc.ContentType), nil
+	case "JSON":
+		t = json.New(rc.TimeFields)
+	case "CBOR":
+		t = cbor.New(rc.TimeFields)
+	case "MSGPACK":
+		t = msgpack.New(rc.TimeFields)
+	case "PROTOBUF":
+		files, ok := descriptors[rc.DescriptorSet]
+		if !ok {
+			f, err := protobuf.LoadDescriptors(rc.DescriptorSet)
+			if err != nil {
+				return nil, errors.Wrap(errInvalidRule, err)
+			}
+			files = f
+			descriptors[rc.DescriptorSet] = files
+		}
+		pt, err := protobuf.New(files, rc.Message, rc.TimeFields)
+		if err != nil {
+			return nil, errors.Wrap(errInvalidRule, err)
+		}
+		t = pt
+	default:
+		return nil, errors.Wrap(errInvalidRule, fmt.Errorf("unknown transformer type %s", rc.Format))
+	}
+	if rc.Mapping == nil {
+		return t, nil
+	}
+
+	cfg := mapping.Config{
+		Flatten:      rc.Mapping.Flatten,
+		KeepUnmapped: rc.Mapping.KeepUnmapped,
+	}
+	for _, f := range rc.Mapping.Fields {
+		cfg.Fields = append(cfg.Fields, mapping.Field{From: f.From, To: f.To, Scale: f.Scale, Offset: f.Offset})
+	}
+	t, err := mapping.New(t, cfg)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidRule, err)
+	}
+
+	return t, nil
+}
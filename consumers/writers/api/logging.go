@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/batch"
 )
 
 var _ consumers.BlockingConsumer = (*loggingMiddleware)(nil)
@@ -45,3 +46,37 @@ func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msgs any) (err
 
 	return lm.consumer.ConsumeBlocking(ctx, msgs)
 }
+
+var _ batch.Repository = (*batchLoggingMiddleware)(nil)
+
+type batchLoggingMiddleware struct {
+	logger *slog.Logger
+	repo   batch.Repository
+}
+
+// BatchLoggingMiddleware adds logging facilities to the batch repository.
+func BatchLoggingMiddleware(repo batch.Repository, logger *slog.Logger) batch.Repository {
+	return &batchLoggingMiddleware{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// SaveBatch logs the batch save request. It logs the number of records and
+// the time it took to complete the request. If the request fails, it logs the error.
+func (lm *batchLoggingMiddleware) SaveBatch(ctx context.Context, b batch.Batch) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("records", b.Len()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save batch failed", args...)
+			return
+		}
+		lm.logger.Info("Save batch completed successfully", args...)
+	}(time.Now())
+
+	return lm.repo.SaveBatch(ctx, b)
+}
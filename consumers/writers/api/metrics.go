@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/batch"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -39,3 +40,34 @@ func (mm *metricsMiddleware) ConsumeBlocking(ctx context.Context, msgs any) erro
 	}(time.Now())
 	return mm.consumer.ConsumeBlocking(ctx, msgs)
 }
+
+var _ batch.Repository = (*batchMetricsMiddleware)(nil)
+
+type batchMetricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	size    metrics.Histogram
+	repo    batch.Repository
+}
+
+// BatchMetricsMiddleware returns new batch repository with SaveBatch method
+// wrapped to expose metrics. Size histogram observes the number of records
+// of the saved batches.
+func BatchMetricsMiddleware(repo batch.Repository, counter metrics.Counter, latency metrics.Histogram, size metrics.Histogram) batch.Repository {
+	return &batchMetricsMiddleware{
+		counter: counter,
+		latency: latency,
+		size:    size,
+		repo:    repo,
+	}
+}
+
+// SaveBatch instruments SaveBatch method with metrics.
+func (mm *batchMetricsMiddleware) SaveBatch(ctx context.Context, b batch.Batch) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_batch").Add(1)
+		mm.latency.With("method", "save_batch").Observe(time.Since(begin).Seconds())
+		mm.size.With("method", "save_batch").Observe(float64(b.Len()))
+	}(time.Now())
+	return mm.repo.SaveBatch(ctx, b)
+}
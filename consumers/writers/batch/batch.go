@@ -0,0 +1,217 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package batch
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/errors"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/pelletier/go-toml"
+)
+
+const defFlushInterval = time.Second
+
+var (
+	// ErrOpenConfFile indicates that the configuration file can not be read,
+	// so the messages are saved one by one.
+	ErrOpenConfFile = errors.New("unable to open configuration file")
+
+	errInvalidMessage = errors.New("invalid message representation")
+	errParseConfFile  = errors.New("unable to parse configuration file")
+)
+
+// Config defines when the buffered messages are saved. The batch is saved
+// when it holds Size records or when FlushInterval elapses since the first
+// buffered message, whichever comes first. Zero size disables batching.
+type Config struct {
+	Size          int
+	FlushInterval time.Duration
+}
+
+// Batch contains the records of the messages saved together.
+type Batch struct {
+	Senml []senml.Message
+	JSON  []smqjson.Messages
+}
+
+// Len returns the number of records in the batch.
+func (b Batch) Len() int {
+	n := len(b.Senml)
+	for _, msgs := range b.JSON {
+		n += len(msgs.Data)
+	}
+
+	return n
+}
+
+func (b *Batch) add(msgs any) error {
+	switch m := msgs.(type) {
+	case []senml.Message:
+		b.Senml = append(b.Senml, m...)
+	case smqjson.Messages:
+		for i := range b.JSON {
+			if b.JSON[i].Format == m.Format {
+				b.JSON[i].Data = append(b.JSON[i].Data, m.Data...)
+				return nil
+			}
+		}
+		b.JSON = append(b.JSON, smqjson.Messages{Data: append([]smqjson.Message{}, m.Data...), Format: m.Format})
+	default:
+		return errInvalidMessage
+	}
+
+	return nil
+}
+
+// Repository specifies the API of the writers which save the whole batch in
+// a single transaction.
+type Repository interface {
+	// SaveBatch saves all the records of the batch or none of them.
+	SaveBatch(ctx context.Context, b Batch) error
+}
+
+type request struct {
+	msgs any
+	done chan error
+}
+
+var _ consumers.BlockingConsumer = (*consumer)(nil)
+
+type consumer struct {
+	repo     Repository
+	cfg      Config
+	requests chan request
+}
+
+// New returns the blocking consumer which buffers the messages and saves them
+// in batches. The call returns only when the batch containing its messages is
+// saved, so the broker acknowledges the messages only after the commit.
+// The consumer groups the messages of the concurrent calls, so the subscriber
+// concurrency should be large enough to fill the batch.
+func New(ctx context.Context, repo Repository, cfg Config) consumers.BlockingConsumer {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defFlushInterval
+	}
+	c := &consumer{
+		repo:     repo,
+		cfg:      cfg,
+		requests: make(chan request),
+	}
+	go c.run(ctx)
+
+	return c
+}
+
+func (c *consumer) ConsumeBlocking(ctx context.Context, msgs any) error {
+	done := make(chan error, 1)
+	select {
+	case c.requests <- request{msgs: msgs, done: done}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *consumer) run(ctx context.Context) {
+	var (
+		batch   Batch
+		pending []request
+	)
+	timer := time.NewTimer(c.cfg.FlushInterval)
+	timer.Stop()
+
+	flush := func() {
+		timer.Stop()
+		c.flush(ctx, batch, pending)
+		batch, pending = Batch{}, nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for _, r := range pending {
+				r.done <- ctx.Err()
+			}
+			return
+		case r := <-c.requests:
+			if err := batch.add(r.msgs); err != nil {
+				r.done <- err
+				continue
+			}
+			if len(pending) == 0 {
+				timer.Reset(c.cfg.FlushInterval)
+			}
+			pending = append(pending, r)
+			if batch.Len() >= c.cfg.Size {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		}
+	}
+}
+
+// flush saves the batch. If the batch fails, the messages of every request
+// are saved separately, so an invalid message fails only its own request and
+// the others are not redelivered.
+func (c *consumer) flush(ctx context.Context, batch Batch, pending []request) {
+	if len(pending) == 0 {
+		return
+	}
+	err := c.repo.SaveBatch(ctx, batch)
+	if err == nil || len(pending) == 1 {
+		for _, r := range pending {
+			r.done <- err
+		}
+		return
+	}
+	for _, r := range pending {
+		var b Batch
+		if err := b.add(r.msgs); err != nil {
+			r.done <- err
+			continue
+		}
+		r.done <- c.repo.SaveBatch(ctx, b)
+	}
+}
+
+type batchConfig struct {
+	Size          int    `toml:"size"`
+	FlushInterval string `toml:"flush_interval"`
+}
+
+// LoadConfig reads the batch section of the writer configuration file.
+func LoadConfig(configPath string) (Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return Config{}, errors.Wrap(ErrOpenConfFile, err)
+	}
+
+	var file struct {
+		Batch batchConfig `toml:"batch"`
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return Config{}, errors.Wrap(errParseConfFile, err)
+	}
+
+	cfg := Config{Size: file.Batch.Size}
+	if file.Batch.FlushInterval != "" {
+		if cfg.FlushInterval, err = time.ParseDuration(file.Batch.FlushInterval); err != nil {
+			return Config{}, errors.Wrap(errParseConfFile, err)
+		}
+	}
+
+	return cfg, nil
+}
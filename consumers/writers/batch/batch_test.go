@@ -0,0 +1,188 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package batch_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/batch"
+	"github.com/absmach/supermq/consumers/writers/batch/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errSave = errors.New("failed to save batch")
+	valid   = []senml.Message{{Channel: "channel", Name: "valid"}}
+	invalid = []senml.Message{{Channel: "channel", Name: "invalid"}}
+	jsonMsg = smqjson.Messages{Format: "format", Data: []smqjson.Message{{Channel: "channel"}}}
+)
+
+func withLen(n int) any {
+	return mock.MatchedBy(func(b batch.Batch) bool { return b.Len() == n })
+}
+
+func consumeAll(c interface {
+	ConsumeBlocking(context.Context, any) error
+}, msgs []any,
+) []error {
+	errs := make([]error, len(msgs))
+	var wg sync.WaitGroup
+	for i, m := range msgs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.ConsumeBlocking(context.Background(), m)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	cases := []struct {
+		desc  string
+		cfg   batch.Config
+		msgs  []any
+		setup func(repo *mocks.Repository)
+		errs  []error
+	}{
+		{
+			desc: "consume messages flushed by batch size",
+			cfg:  batch.Config{Size: 3, FlushInterval: time.Hour},
+			msgs: []any{valid, valid, jsonMsg},
+			setup: func(repo *mocks.Repository) {
+				repo.On("SaveBatch", mock.Anything, withLen(3)).Return(nil).Once()
+			},
+			errs: []error{nil, nil, nil},
+		},
+		{
+			desc: "consume message flushed by flush interval",
+			cfg:  batch.Config{Size: 100, FlushInterval: 10 * time.Millisecond},
+			msgs: []any{valid},
+			setup: func(repo *mocks.Repository) {
+				repo.On("SaveBatch", mock.Anything, withLen(1)).Return(nil).Once()
+			},
+			errs: []error{nil},
+		},
+		{
+			desc: "consume messages with failed batch",
+			cfg:  batch.Config{Size: 2, FlushInterval: time.Hour},
+			msgs: []any{valid, invalid},
+			setup: func(repo *mocks.Repository) {
+				repo.On("SaveBatch", mock.Anything, withLen(2)).Return(errSave).Once()
+				repo.On("SaveBatch", mock.Anything, mock.MatchedBy(func(b batch.Batch) bool {
+					return b.Len() == 1 && b.Senml[0].Name == "valid"
+				})).Return(nil).Once()
+				repo.On("SaveBatch", mock.Anything, mock.MatchedBy(func(b batch.Batch) bool {
+					return b.Len() == 1 && b.Senml[0].Name == "invalid"
+				})).Return(errSave).Once()
+			},
+			errs: []error{nil, errSave},
+		},
+		{
+			desc:  "consume message of unknown type",
+			cfg:   batch.Config{Size: 1, FlushInterval: time.Hour},
+			msgs:  []any{"message"},
+			setup: func(repo *mocks.Repository) {},
+			errs:  []error{errors.New("invalid message representation")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			repo := new(mocks.Repository)
+			tc.setup(repo)
+			c := batch.New(ctx, repo, tc.cfg)
+			errs := consumeAll(c, tc.msgs)
+			for i, err := range errs {
+				assert.True(t, errors.Contains(err, tc.errs[i]), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.errs[i], err))
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConsumeBlockingCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	repo := new(mocks.Repository)
+	c := batch.New(ctx, repo, batch.Config{Size: 100, FlushInterval: time.Hour})
+
+	errs := make(chan error)
+	go func() {
+		errs <- c.ConsumeBlocking(context.Background(), valid)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	err := <-errs
+	assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("expected error %s got %s\n", context.Canceled, err))
+	repo.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+}
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		desc    string
+		content string
+		missing bool
+		cfg     batch.Config
+		err     bool
+		openErr bool
+	}{
+		{
+			desc:    "load config with batch section",
+			content: "[batch]\nsize = 1000\nflush_interval = \"2s\"\n",
+			cfg:     batch.Config{Size: 1000, FlushInterval: 2 * time.Second},
+		},
+		{
+			desc:    "load config without batch section",
+			content: "[subscriber]\nsubjects = [\"writers.>\"]\n",
+			cfg:     batch.Config{},
+		},
+		{
+			desc:    "load missing config",
+			missing: true,
+			err:     true,
+			openErr: true,
+		},
+		{
+			desc:    "load config with malformed file",
+			content: "[batch\n",
+			err:     true,
+		},
+		{
+			desc:    "load config with invalid flush interval",
+			content: "[batch]\nsize = 1000\nflush_interval = \"soon\"\n",
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if !tc.missing {
+				require.Nil(t, os.WriteFile(path, []byte(tc.content), 0o600))
+			}
+			cfg, err := batch.LoadConfig(path)
+			assert.Equal(t, tc.err, err != nil, "unexpected error %s", err)
+			assert.Equal(t, tc.openErr, errors.Contains(err, batch.ErrOpenConfFile), "expected open error %t, got %s", tc.openErr, err)
+			assert.Equal(t, tc.cfg, cfg)
+		})
+	}
+}
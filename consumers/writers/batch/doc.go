@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package batch contains the blocking consumer which buffers the messages of
+// the concurrent calls and saves them together, so the writers do not run a
+// transaction per broker message.
+package batch
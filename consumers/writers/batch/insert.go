@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Postgres limits the number of the parameters of a single statement.
+const maxParams = 65535
+
+// Insert inserts the rows using multi-row INSERT statements. Each row holds
+// the values of the columns in the same order. The suffix, such as the ON
+// CONFLICT clause, is appended to every statement.
+func Insert(ctx context.Context, tx *sqlx.Tx, table string, columns []string, rows [][]any, suffix string) error {
+	size := maxParams / len(columns)
+	for start := 0; start < len(rows); start += size {
+		end := min(start+size, len(rows))
+		if _, err := tx.ExecContext(ctx, insertQuery(table, columns, end-start, suffix), flatten(rows[start:end])...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertQuery(table string, columns []string, rows int, suffix string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES ", table, strings.Join(columns, ", "))
+	param := 1
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := range columns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "$%d", param)
+			param++
+		}
+		sb.WriteString(")")
+	}
+	if suffix != "" {
+		sb.WriteString(" ")
+		sb.WriteString(suffix)
+	}
+
+	return sb.String()
+}
+
+func flatten(rows [][]any) []any {
+	args := []any{}
+	for _, row := range rows {
+		args = append(args, row...)
+	}
+
+	return args
+}
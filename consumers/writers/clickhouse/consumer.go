@@ -0,0 +1,161 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/batch"
+	clickhouseclient "github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/absmach/supermq/pkg/errors"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// Table for SenML messages.
+const defTable = "messages"
+
+var (
+	errInvalidMessage = errors.New("invalid message representation")
+	errSaveMessage    = errors.New("failed to save message to clickhouse database")
+)
+
+var (
+	_ consumers.BlockingConsumer = (*clickhouseRepo)(nil)
+	_ batch.Repository           = (*clickhouseRepo)(nil)
+)
+
+type clickhouseRepo struct {
+	db *clickhouseclient.Client
+}
+
+// New returns new ClickHouse writer.
+func New(db *clickhouseclient.Client) consumers.BlockingConsumer {
+	return &clickhouseRepo{db: db}
+}
+
+// NewBatchRepository returns new ClickHouse writer of the message batches.
+func NewBatchRepository(db *clickhouseclient.Client) batch.Repository {
+	return &clickhouseRepo{db: db}
+}
+
+func (cr *clickhouseRepo) ConsumeBlocking(ctx context.Context, message any) error {
+	switch m := message.(type) {
+	case smqjson.Messages:
+		return cr.saveJSON(ctx, m)
+	case []senml.Message:
+		return cr.saveSenml(ctx, m)
+	default:
+		return errors.Wrap(errSaveMessage, errInvalidMessage)
+	}
+}
+
+func (cr *clickhouseRepo) saveSenml(ctx context.Context, msgs []senml.Message) error {
+	// SenML messages are encoded with the column names as the JSON keys.
+	rows := make([]any, 0, len(msgs))
+	for _, msg := range msgs {
+		rows = append(rows, msg)
+	}
+	if err := cr.db.Insert(ctx, defTable, rows); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (cr *clickhouseRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) error {
+	if err := cr.insertJSON(ctx, msgs); err != nil {
+		if errors.Contains(err, clickhouseclient.ErrUnknownTable) {
+			if err := cr.createTable(ctx, msgs.Format); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			if err := cr.insertJSON(ctx, msgs); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			return nil
+		}
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (cr *clickhouseRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+	rows := make([]any, 0, len(msgs.Data))
+	for _, m := range msgs.Data {
+		dbmsg, err := toJSONMessage(m)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, dbmsg)
+	}
+
+	return cr.db.Insert(ctx, msgs.Format, rows)
+}
+
+// SaveBatch inserts the records of each table of the batch in a single
+// statement. ClickHouse has no transactions across the tables, so the records
+// of the failed batches may be inserted again when the broker redelivers the
+// messages. Such duplicates are removed when the table parts are merged.
+func (cr *clickhouseRepo) SaveBatch(ctx context.Context, b batch.Batch) error {
+	for _, msgs := range b.JSON {
+		if err := cr.saveJSON(ctx, msgs); err != nil {
+			return err
+		}
+	}
+	if len(b.Senml) > 0 {
+		return cr.saveSenml(ctx, b.Senml)
+	}
+
+	return nil
+}
+
+func (cr *clickhouseRepo) createTable(ctx context.Context, name string) error {
+	q := `CREATE TABLE IF NOT EXISTS %s (
+            created       Int64,
+            channel       String,
+            subtopic      String,
+            publisher     String,
+            protocol      String,
+            payload       String
+        ) ENGINE = ReplacingMergeTree
+        ORDER BY (channel, created, publisher, subtopic)`
+	q = fmt.Sprintf(q, name)
+
+	return cr.db.Exec(ctx, q, nil)
+}
+
+type jsonMessage struct {
+	Channel   string `json:"channel"`
+	Created   int64  `json:"created"`
+	Subtopic  string `json:"subtopic"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   string `json:"payload"`
+}
+
+func toJSONMessage(msg smqjson.Message) (jsonMessage, error) {
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, err
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   string(data),
+	}
+
+	return m, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/batch"
+	"github.com/absmach/supermq/consumers/writers/clickhouse"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	msgsNum     = 42
+	valueFields = 5
+	subtopic    = "topic"
+)
+
+var (
+	v       float64 = 5
+	stringV         = "value"
+	boolV           = true
+	dataV           = "base64"
+	sum     float64 = 42
+)
+
+func TestSaveSenml(t *testing.T) {
+	repo := clickhouse.New(db)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := senml.Message{}
+	msg.Channel = chid.String()
+
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	msg.Publisher = pubid.String()
+
+	now := time.Now().Unix()
+	var msgs []senml.Message
+
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Subtopic = subtopic
+			msg.Value = &v
+		case 1:
+			msg.BoolValue = &boolV
+		case 2:
+			msg.StringValue = &stringV
+		case 3:
+			msg.DataValue = &dataV
+		case 4:
+			msg.Sum = &sum
+		}
+
+		msg.Time = float64(now + int64(i))
+		msgs = append(msgs, msg)
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, msgsNum, count(t, "messages", chid.String()))
+}
+
+func TestSaveJSON(t *testing.T) {
+	repo := clickhouse.New(db)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := json.Message{
+		Channel:   chid.String(),
+		Publisher: pubid.String(),
+		Created:   time.Now().Unix(),
+		Subtopic:  "subtopic/format/some_json",
+		Protocol:  "mqtt",
+		Payload: map[string]any{
+			"field_1": 123,
+			"field_2": "value",
+			"field_3": false,
+			"field_4": 12.344,
+			"field_5": map[string]any{
+				"field_1": "value",
+				"field_2": 42,
+			},
+		},
+	}
+
+	now := time.Now().Unix()
+	msgs := json.Messages{
+		Format: "some_json",
+	}
+
+	for i := 0; i < msgsNum; i++ {
+		msg.Created = now + int64(i)
+		msgs.Data = append(msgs.Data, msg)
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, msgsNum, count(t, "some_json", chid.String()))
+}
+
+func TestSaveBatch(t *testing.T) {
+	repo := clickhouse.NewBatchRepository(db)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	b := batch.Batch{
+		JSON: []json.Messages{{Format: "batch_json"}},
+	}
+	for i := 0; i < msgsNum; i++ {
+		b.Senml = append(b.Senml, senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Time:      float64(now + int64(i)),
+			Value:     &v,
+		})
+		b.JSON[0].Data = append(b.JSON[0].Data, json.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Created:   now + int64(i),
+			Subtopic:  subtopic,
+			Protocol:  "mqtt",
+			Payload:   map[string]any{"field_1": i},
+		})
+	}
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "save batch",
+			err:  nil,
+		},
+		{
+			desc: "save already saved batch",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveBatch(context.TODO(), b)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		n := count(t, "messages", chid.String())
+		assert.Equal(t, msgsNum, n, fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, msgsNum, n))
+		n = count(t, "batch_json", chid.String())
+		assert.Equal(t, msgsNum, n, fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, msgsNum, n))
+	}
+}
+
+// count returns the number of the messages of the channel, counting the
+// duplicates which are not merged yet only once.
+func count(t *testing.T, table, chanID string) int {
+	rows, err := db.Query(context.Background(), fmt.Sprintf("SELECT count() AS count FROM %s FINAL WHERE channel = {channel:String}", table), map[string]any{"channel": chanID})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer rows.Close()
+
+	var res struct {
+		Count int `json:"count"`
+	}
+	require.True(t, rows.Next(), fmt.Sprintf("expected count row got error %s", rows.Err()))
+	err = rows.Scan(&res)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return res.Count
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+// Migration of clickhouse-writer. ClickHouse keeps no record of the applied
+// migrations, so all of them are idempotent and applied on every start.
+//
+// Messages are stored in the ReplacingMergeTree ordered by the columns of
+// the message key, so the duplicates of the redelivered messages are removed
+// when the parts of the table are merged. Payload contracts are versioned the
+// same way, so the latest version of each contract is kept.
+func Migration() []string {
+	return []string{
+		`CREATE TABLE IF NOT EXISTS messages (
+            channel       String,
+            domain        String,
+            subtopic      String,
+            publisher     String,
+            protocol      String,
+            name          String,
+            unit          String,
+            value         Nullable(Float64),
+            string_value  Nullable(String),
+            bool_value    Nullable(Bool),
+            data_value    Nullable(String),
+            sum           Nullable(Float64),
+            time          Float64,
+            update_time   Float64
+        ) ENGINE = ReplacingMergeTree
+        PARTITION BY toYYYYMM(toDateTime(intDiv(toInt64(time), 1000000000)))
+        ORDER BY (channel, time, publisher, subtopic, name, protocol)`,
+		`CREATE TABLE IF NOT EXISTS messages_quarantine (
+            created       Int64,
+            domain        String,
+            channel       String,
+            subtopic      String,
+            publisher     String,
+            protocol      String,
+            payload       String,
+            reason        String
+        ) ENGINE = MergeTree
+        ORDER BY (channel, created)`,
+		`CREATE TABLE IF NOT EXISTS channel_contracts (
+            channel_id    String,
+            schema        String,
+            measurements  String,
+            updated       Int64,
+            removed       UInt8
+        ) ENGINE = ReplacingMergeTree(updated)
+        ORDER BY channel_id`,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clickhouse_test contains tests for ClickHouse repository
+// implementations.
+package clickhouse_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/absmach/supermq/consumers/writers/clickhouse"
+	clickhouseclient "github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+var db *clickhouseclient.Client
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	container, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "clickhouse/clickhouse-server",
+		Tag:        "24.8-alpine",
+		Env: []string{
+			"CLICKHOUSE_USER=test",
+			"CLICKHOUSE_PASSWORD=test",
+			"CLICKHOUSE_DB=test",
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	dbConfig := clickhouseclient.Config{
+		URL:  fmt.Sprintf("http://localhost:%s", container.GetPort("8123/tcp")),
+		User: "test",
+		Pass: "test",
+		Name: "test",
+	}
+
+	if err := pool.Retry(func() error {
+		db, err = clickhouseclient.Setup(context.Background(), dbConfig, clickhouse.Migration())
+		return err
+	}); err != nil {
+		log.Fatalf("Could not setup test DB connection: %s", err)
+	}
+
+	code := m.Run()
+
+	// Defers will not be run when using os.Exit
+	db.Close()
+	if err := pool.Purge(container); err != nil {
+		log.Fatalf("Could not purge container: %s", err)
+	}
+
+	os.Exit(code)
+}
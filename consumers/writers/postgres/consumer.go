@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/batch"
 	"github.com/absmach/supermq/pkg/errors"
 	smqjson "github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
@@ -25,7 +26,15 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
-var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
+var (
+	senmlColumns = []string{"id", "channel", "domain", "subtopic", "publisher", "protocol", "name", "unit", "value", "string_value", "bool_value", "data_value", "sum", "time", "update_time"}
+	jsonColumns  = []string{"id", "channel", "created", "subtopic", "publisher", "protocol", "payload"}
+)
+
+var (
+	_ consumers.BlockingConsumer = (*postgresRepo)(nil)
+	_ batch.Repository           = (*postgresRepo)(nil)
+)
 
 type postgresRepo struct {
 	db *sqlx.DB
@@ -36,6 +45,11 @@ func New(db *sqlx.DB) consumers.BlockingConsumer {
 	return &postgresRepo{db: db}
 }
 
+// NewBatchRepository returns new PostgreSQL writer of the message batches.
+func NewBatchRepository(db *sqlx.DB) batch.Repository {
+	return &postgresRepo{db: db}
+}
+
 func (pr postgresRepo) ConsumeBlocking(ctx context.Context, message any) (err error) {
 	switch m := message.(type) {
 	case smqjson.Messages:
@@ -50,10 +64,10 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages any) (err error)
 	if !ok {
 		return errSaveMessage
 	}
-	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+	q := `INSERT INTO messages (id, channel, domain, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
           time, update_time)
-          VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
+          VALUES (:id, :channel, :domain, :subtopic, :publisher, :protocol, :name, :unit,
           :value, :string_value, :bool_value, :data_value, :sum,
           :time, :update_time);`
 
@@ -155,6 +169,93 @@ func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) er
 	return nil
 }
 
+// SaveBatch inserts all the records of the batch in a single transaction.
+// SenML records already stored are skipped by their primary key. JSON
+// messages get a new ID on every save, so the JSON messages the broker
+// redelivers after their batch was committed are stored again.
+func (pr postgresRepo) SaveBatch(ctx context.Context, b batch.Batch) error {
+	if err := pr.insertBatch(ctx, b); err != nil {
+		if err == errNoTable {
+			for _, msgs := range b.JSON {
+				if err := pr.createTable(msgs.Format); err != nil {
+					return err
+				}
+			}
+			return pr.insertBatch(ctx, b)
+		}
+		return err
+	}
+	return nil
+}
+
+func (pr postgresRepo) insertBatch(ctx context.Context, b batch.Batch) (err error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	if len(b.Senml) > 0 {
+		rows := make([][]any, 0, len(b.Senml))
+		for _, msg := range b.Senml {
+			id, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rows = append(rows, []any{
+				id.String(), msg.Channel, msg.Domain, msg.Subtopic, msg.Publisher, msg.Protocol, msg.Name, msg.Unit,
+				msg.Value, msg.StringValue, msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime,
+			})
+		}
+		if err := batch.Insert(ctx, tx, "messages", senmlColumns, rows, "ON CONFLICT DO NOTHING"); err != nil {
+			return batchError(err)
+		}
+	}
+
+	for _, msgs := range b.JSON {
+		rows := make([][]any, 0, len(msgs.Data))
+		for _, m := range msgs.Data {
+			dbmsg, err := toJSONMessage(m)
+			if err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			rows = append(rows, []any{dbmsg.ID, dbmsg.Channel, dbmsg.Created, dbmsg.Subtopic, dbmsg.Publisher, dbmsg.Protocol, dbmsg.Payload})
+		}
+		if err := batch.Insert(ctx, tx, msgs.Format, jsonColumns, rows, "ON CONFLICT DO NOTHING"); err != nil {
+			return batchError(err)
+		}
+	}
+
+	return nil
+}
+
+func batchError(err error) error {
+	if preErr, ok := err.(*pgconn.PrepareError); ok {
+		err = preErr.Unwrap()
+	}
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case pgerrcode.InvalidTextRepresentation:
+			return errors.Wrap(errSaveMessage, errInvalidMessage)
+		case pgerrcode.UndefinedTable:
+			return errNoTable
+		}
+	}
+
+	return errors.Wrap(errSaveMessage, err)
+}
+
 func (pr postgresRepo) createTable(name string) error {
 	q := `CREATE TABLE IF NOT EXISTS %s (
             id            UUID,
@@ -41,6 +41,84 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE messages ADD PRIMARY KEY (time, publisher, subtopic, name)`,
 				},
 			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					`ALTER TABLE messages ADD COLUMN IF NOT EXISTS domain VARCHAR(254) NOT NULL DEFAULT ''`,
+					`CREATE INDEX IF NOT EXISTS idx_domain_time ON messages (domain, time)`,
+					`CREATE INDEX IF NOT EXISTS idx_channel_time ON messages (channel, time)`,
+					`CREATE TABLE IF NOT EXISTS messages_rollups (
+                        width         FLOAT NOT NULL,
+                        time          FLOAT NOT NULL,
+                        domain        VARCHAR(254) NOT NULL DEFAULT '',
+                        channel       UUID,
+                        subtopic      VARCHAR(254),
+                        publisher     VARCHAR(254),
+                        protocol      TEXT,
+                        name          VARCHAR(254),
+                        unit          TEXT,
+                        count         BIGINT,
+                        sum           FLOAT,
+                        sum_sq        FLOAT,
+                        min           FLOAT,
+                        max           FLOAT,
+                        first_time    FLOAT,
+                        first_value   FLOAT,
+                        last_time     FLOAT,
+                        last_value    FLOAT,
+                        PRIMARY KEY (width, channel, subtopic, publisher, protocol, name, time)
+                    )`,
+					`CREATE TABLE IF NOT EXISTS messages_rollup_watermarks (
+                        policy        VARCHAR(254),
+                        width         FLOAT,
+                        until         FLOAT,
+                        PRIMARY KEY (policy, width)
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS messages_rollup_watermarks",
+					"DROP TABLE IF EXISTS messages_rollups",
+					"DROP INDEX IF EXISTS idx_channel_time",
+					"DROP INDEX IF EXISTS idx_domain_time",
+					"ALTER TABLE messages DROP COLUMN IF EXISTS domain",
+				},
+			},
+			{
+				Id: "messages_4",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS messages_quarantine (
+                        id            BIGSERIAL,
+                        created       BIGINT NOT NULL,
+                        domain        VARCHAR(254) NOT NULL DEFAULT '',
+                        channel       VARCHAR(254) NOT NULL DEFAULT '',
+                        subtopic      VARCHAR(254),
+                        publisher     VARCHAR(254),
+                        protocol      TEXT,
+                        payload       BYTEA,
+                        reason        TEXT,
+                        PRIMARY KEY (id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_quarantine_channel_created ON messages_quarantine (channel, created)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS messages_quarantine",
+				},
+			},
+			{
+				Id: "messages_5",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS channel_contracts (
+                        channel_id    VARCHAR(36) PRIMARY KEY,
+                        schema        TEXT NOT NULL DEFAULT '',
+                        measurements  JSONB NOT NULL DEFAULT '[]'
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_quarantine_created ON messages_quarantine (created)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS channel_contracts",
+					"DROP INDEX IF EXISTS idx_quarantine_created",
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retention contains the retention and downsampling policies of the
+// messages stored by the writers, and the handler that periodically rolls
+// the messages up and removes the expired ones.
+package retention
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package postgres contains the retention repository implementation using
+// Postgres as the underlying database. It is shared by the Postgres and
+// Timescale writers, since both store the SenML messages in the same schema.
+package postgres
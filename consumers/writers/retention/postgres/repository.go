@@ -0,0 +1,144 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/retention"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errRollup            = errors.New("failed to roll up messages")
+	errDeleteRollups     = errors.New("failed to delete rollups")
+	errDeleteMessages    = errors.New("failed to delete messages")
+	errRetrieveWatermark = errors.New("failed to retrieve rollup watermark")
+	errSaveWatermark     = errors.New("failed to save rollup watermark")
+)
+
+var _ retention.Repository = (*repository)(nil)
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// New returns new retention repository.
+func New(db *sqlx.DB) retention.Repository {
+	return &repository{db: db}
+}
+
+// Rollup buckets are aligned to the multiples of their width, like the
+// buckets of the aggregated reads. Messages time is stored in nanoseconds.
+func (repo *repository) Rollup(ctx context.Context, s retention.Scope, width time.Duration, from, to time.Time) error {
+	q := fmt.Sprintf(`INSERT INTO messages_rollups (width, time, domain, channel, subtopic, publisher, protocol,
+          name, unit, count, sum, sum_sq, min, max, first_time, first_value, last_time, last_value)
+          SELECT :width, floor(CAST(time AS DOUBLE PRECISION) / :width) * :width,
+          (array_agg(domain ORDER BY time DESC))[1], channel, COALESCE(subtopic, ''),
+          CAST(publisher AS VARCHAR), COALESCE(protocol, ''), name,
+          (array_agg(unit ORDER BY time DESC))[1], COUNT(value), SUM(value), SUM(value * value),
+          MIN(value), MAX(value), MIN(time), (array_agg(value ORDER BY time))[1],
+          MAX(time), (array_agg(value ORDER BY time DESC))[1]
+          FROM messages WHERE value IS NOT NULL AND time >= :from AND time < :to AND %s
+          GROUP BY 2, channel, COALESCE(subtopic, ''), publisher, COALESCE(protocol, ''), name
+          ON CONFLICT (width, channel, subtopic, publisher, protocol, name, time) DO UPDATE SET
+          domain = EXCLUDED.domain, unit = EXCLUDED.unit, count = EXCLUDED.count, sum = EXCLUDED.sum,
+          sum_sq = EXCLUDED.sum_sq, min = EXCLUDED.min, max = EXCLUDED.max,
+          first_time = EXCLUDED.first_time, first_value = EXCLUDED.first_value,
+          last_time = EXCLUDED.last_time, last_value = EXCLUDED.last_value;`, scopeCondition(s))
+
+	params := scopeParams(s)
+	params["width"] = float64(width.Nanoseconds())
+	params["from"] = float64(from.UnixNano())
+	params["to"] = float64(to.UnixNano())
+
+	if _, err := repo.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errRollup, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) DeleteRollups(ctx context.Context, s retention.Scope, width time.Duration, before time.Time) error {
+	q := fmt.Sprintf(`DELETE FROM messages_rollups WHERE width = :width AND time < :before AND %s;`, scopeCondition(s))
+
+	params := scopeParams(s)
+	params["width"] = float64(width.Nanoseconds())
+	params["before"] = float64(before.UnixNano())
+
+	if _, err := repo.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errDeleteRollups, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) DeleteMessages(ctx context.Context, s retention.Scope, before time.Time) error {
+	q := fmt.Sprintf(`DELETE FROM messages WHERE time < :before AND %s;`, scopeCondition(s))
+
+	params := scopeParams(s)
+	params["before"] = float64(before.UnixNano())
+
+	if _, err := repo.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveWatermark(ctx context.Context, s retention.Scope, width time.Duration) (time.Time, error) {
+	q := `SELECT until FROM messages_rollup_watermarks WHERE policy = $1 AND width = $2;`
+
+	var until []float64
+	if err := repo.db.SelectContext(ctx, &until, q, s.Key(), float64(width.Nanoseconds())); err != nil {
+		return time.Time{}, errors.Wrap(errRetrieveWatermark, err)
+	}
+	if len(until) == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(0, int64(until[0])), nil
+}
+
+func (repo *repository) SaveWatermark(ctx context.Context, s retention.Scope, width time.Duration, until time.Time) error {
+	q := `INSERT INTO messages_rollup_watermarks (policy, width, until) VALUES ($1, $2, $3)
+          ON CONFLICT (policy, width) DO UPDATE SET until = EXCLUDED.until;`
+
+	if _, err := repo.db.ExecContext(ctx, q, s.Key(), float64(width.Nanoseconds()), float64(until.UnixNano())); err != nil {
+		return errors.Wrap(errSaveWatermark, err)
+	}
+
+	return nil
+}
+
+func scopeCondition(s retention.Scope) string {
+	switch {
+	case s.ChannelID != "":
+		return "channel = :channel"
+	case s.DomainID != "":
+		return "domain = :domain AND channel <> ALL(:excluded_channels)"
+	default:
+		return "domain <> ALL(:excluded_domains) AND channel <> ALL(:excluded_channels)"
+	}
+}
+
+func scopeParams(s retention.Scope) map[string]any {
+	excludedDomains, excludedChannels := s.ExcludedDomains, s.ExcludedChannels
+	if excludedDomains == nil {
+		excludedDomains = []string{}
+	}
+	if excludedChannels == nil {
+		excludedChannels = []string{}
+	}
+
+	return map[string]any{
+		"channel":           s.ChannelID,
+		"domain":            s.DomainID,
+		"excluded_domains":  excludedDomains,
+		"excluded_channels": excludedChannels,
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/pelletier/go-toml"
+)
+
+const day = 24 * time.Hour
+
+var (
+	// ErrInvalidPolicy indicates that the retention policy is malformed.
+	ErrInvalidPolicy = errors.New("invalid retention policy")
+
+	errOpenConfFile  = errors.New("unable to open configuration file")
+	errParseConfFile = errors.New("unable to parse configuration file")
+)
+
+// Policy defines how long the SenML messages are kept and which rollups of
+// their values are maintained. A policy with the channel ID applies to that
+// channel, a policy with the domain ID applies to the channels of the domain
+// that have no policy of their own, and a policy with neither applies to all
+// the remaining messages. Zero retention keeps the data forever. The buckets
+// within the lateness behind the rolled up range are aggregated again on every
+// run, so the rollups include the messages that arrive late.
+type Policy struct {
+	DomainID     string
+	ChannelID    string
+	RawRetention time.Duration
+	Lateness     time.Duration
+	Rollups      []Rollup
+}
+
+// Rollup represents the values of the messages aggregated in the buckets of
+// the given interval. Rollups keep the count, sum, sum of squares, minimum,
+// maximum, first and last value of each bucket, so the readers can compute
+// the aggregations of any multiple of the interval from them.
+type Rollup struct {
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+// Scope restricts the messages the policy is applied to.
+type Scope struct {
+	DomainID         string
+	ChannelID        string
+	ExcludedDomains  []string
+	ExcludedChannels []string
+}
+
+// Key uniquely identifies the scope of the policy.
+func (s Scope) Key() string {
+	switch {
+	case s.ChannelID != "":
+		return "channel:" + s.ChannelID
+	case s.DomainID != "":
+		return "domain:" + s.DomainID
+	default:
+		return "default"
+	}
+}
+
+// Config contains the retention policies and the period of their enforcement.
+type Config struct {
+	CheckInterval time.Duration
+	Policies      []Policy
+}
+
+// Repository specifies the retention persistence API.
+type Repository interface {
+	// Rollup aggregates the numeric values of the messages of the scope with
+	// the time in the [from, to) range into the rollups of the given width,
+	// replacing the rollups of the same buckets.
+	Rollup(ctx context.Context, s Scope, width time.Duration, from, to time.Time) error
+
+	// DeleteRollups removes the rollups of the given width of the scope with the
+	// time before the given time.
+	DeleteRollups(ctx context.Context, s Scope, width time.Duration, before time.Time) error
+
+	// DeleteMessages removes the messages of the scope with the time before the
+	// given time.
+	DeleteMessages(ctx context.Context, s Scope, before time.Time) error
+
+	// RetrieveWatermark retrieves the end of the time range rolled up for the
+	// scope. Zero time is returned if nothing is rolled up.
+	RetrieveWatermark(ctx context.Context, s Scope, width time.Duration) (time.Time, error)
+
+	// SaveWatermark saves the end of the time range rolled up for the scope.
+	SaveWatermark(ctx context.Context, s Scope, width time.Duration, until time.Time) error
+}
+
+// Validate checks that the rollup buckets are aligned with the days, so the
+// readers can combine them into the buckets of the aggregated queries, and
+// that the raw messages are kept long enough to be rolled up.
+func (p Policy) Validate() error {
+	if p.RawRetention < 0 {
+		return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("negative raw retention %s", p.RawRetention))
+	}
+	if p.Lateness < 0 {
+		return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("negative lateness %s", p.Lateness))
+	}
+	widths := make(map[time.Duration]bool)
+	for _, r := range p.Rollups {
+		if r.Interval <= 0 || day%r.Interval != 0 {
+			return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("rollup interval %s does not divide a day", r.Interval))
+		}
+		if widths[r.Interval] {
+			return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("duplicate rollup interval %s", r.Interval))
+		}
+		widths[r.Interval] = true
+		if r.Retention < 0 {
+			return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("negative rollup retention %s", r.Retention))
+		}
+		if p.RawRetention != 0 && p.RawRetention <= r.Interval {
+			return errors.Wrap(ErrInvalidPolicy, fmt.Errorf("raw retention %s is not longer than rollup interval %s", p.RawRetention, r.Interval))
+		}
+	}
+
+	return nil
+}
+
+type rollupConfig struct {
+	Interval  string `toml:"interval"`
+	Retention string `toml:"retention"`
+}
+
+type policyConfig struct {
+	DomainID     string         `toml:"domain_id"`
+	ChannelID    string         `toml:"channel_id"`
+	RawRetention string         `toml:"raw_retention"`
+	Lateness     string         `toml:"lateness"`
+	Rollups      []rollupConfig `toml:"rollups"`
+}
+
+type retentionConfig struct {
+	CheckInterval string         `toml:"check_interval"`
+	Policies      []policyConfig `toml:"policies"`
+}
+
+// LoadConfig reads the retention section of the writer configuration file.
+// The default check interval is used if the file does not set it.
+func LoadConfig(configPath string, defCheckInterval time.Duration) (Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return Config{}, errors.Wrap(errOpenConfFile, err)
+	}
+
+	var file struct {
+		Retention retentionConfig `toml:"retention"`
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return Config{}, errors.Wrap(errParseConfFile, err)
+	}
+
+	cfg := Config{CheckInterval: defCheckInterval}
+	if file.Retention.CheckInterval != "" {
+		if cfg.CheckInterval, err = time.ParseDuration(file.Retention.CheckInterval); err != nil {
+			return Config{}, errors.Wrap(errParseConfFile, err)
+		}
+	}
+	for _, pc := range file.Retention.Policies {
+		p := Policy{
+			DomainID:  pc.DomainID,
+			ChannelID: pc.ChannelID,
+		}
+		if p.RawRetention, err = parseDuration(pc.RawRetention); err != nil {
+			return Config{}, errors.Wrap(errParseConfFile, err)
+		}
+		if p.Lateness, err = parseDuration(pc.Lateness); err != nil {
+			return Config{}, errors.Wrap(errParseConfFile, err)
+		}
+		for _, rc := range pc.Rollups {
+			var r Rollup
+			if r.Interval, err = parseDuration(rc.Interval); err != nil {
+				return Config{}, errors.Wrap(errParseConfFile, err)
+			}
+			if r.Retention, err = parseDuration(rc.Retention); err != nil {
+				return Config{}, errors.Wrap(errParseConfFile, err)
+			}
+			p.Rollups = append(p.Rollups, r)
+		}
+		cfg.Policies = append(cfg.Policies, p)
+	}
+
+	return cfg, nil
+}
+
+func parseDuration(d string) (time.Duration, error) {
+	if d == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(d)
+}
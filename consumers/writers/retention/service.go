@@ -0,0 +1,163 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Service specifies the retention API.
+type Service interface {
+	// Enforce rolls up the completed buckets of all the policies and removes
+	// the messages and rollups older than their retention.
+	Enforce(ctx context.Context, now time.Time) error
+}
+
+type policy struct {
+	Policy
+	scope Scope
+}
+
+type service struct {
+	repo     Repository
+	policies []policy
+}
+
+var _ Service = (*service)(nil)
+
+// NewService instantiates the retention service. The scopes of the policies
+// are resolved so that every message is subject to at most one policy.
+func NewService(repo Repository, policies []Policy) (Service, error) {
+	scopes := make(map[string]bool)
+	var domains, channels []string
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		s := Scope{DomainID: p.DomainID, ChannelID: p.ChannelID}
+		if scopes[s.Key()] {
+			return nil, errors.Wrap(ErrInvalidPolicy, fmt.Errorf("duplicate policy for %s", s.Key()))
+		}
+		scopes[s.Key()] = true
+		switch {
+		case p.ChannelID != "":
+			channels = append(channels, p.ChannelID)
+		case p.DomainID != "":
+			domains = append(domains, p.DomainID)
+		}
+	}
+
+	svc := &service{repo: repo}
+	for _, p := range policies {
+		s := Scope{DomainID: p.DomainID, ChannelID: p.ChannelID}
+		switch {
+		case p.ChannelID != "":
+			// Channel policy takes precedence over the others, so it is not
+			// restricted to the domain even if the domain is set.
+			s.DomainID = ""
+		case p.DomainID != "":
+			s.ExcludedChannels = channels
+		default:
+			s.ExcludedDomains = domains
+			s.ExcludedChannels = channels
+		}
+		svc.policies = append(svc.policies, policy{Policy: p, scope: s})
+	}
+
+	return svc, nil
+}
+
+func (svc *service) Enforce(ctx context.Context, now time.Time) error {
+	var retErr error
+	for _, p := range svc.policies {
+		if err := svc.enforce(ctx, p, now); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+
+	return retErr
+}
+
+func (svc *service) enforce(ctx context.Context, p policy, now time.Time) error {
+	for _, r := range p.Rollups {
+		if err := svc.rollup(ctx, p, r, now); err != nil {
+			return err
+		}
+		if r.Retention > 0 {
+			if err := svc.repo.DeleteRollups(ctx, p.scope, r.Interval, now.Add(-r.Retention)); err != nil {
+				return err
+			}
+		}
+	}
+	if p.RawRetention > 0 {
+		return svc.repo.DeleteMessages(ctx, p.scope, now.Add(-p.RawRetention))
+	}
+
+	return nil
+}
+
+// rollup aggregates the buckets completed since the last run, together with
+// the buckets within the lateness behind them, whose rollups are replaced to
+// include the messages that arrived after they were rolled up. Buckets whose
+// raw messages are already expired are skipped, since they cannot be rolled up
+// completely any more.
+func (svc *service) rollup(ctx context.Context, p policy, r Rollup, now time.Time) error {
+	from, err := svc.repo.RetrieveWatermark(ctx, p.scope, r.Interval)
+	if err != nil {
+		return err
+	}
+	if from.IsZero() {
+		from = time.Unix(0, 0)
+	} else {
+		from = from.Add(-p.Lateness).Truncate(r.Interval)
+	}
+	if p.RawRetention > 0 {
+		if expired := ceil(now.Add(-p.RawRetention), r.Interval); from.Before(expired) {
+			from = expired
+		}
+	}
+	to := now.Truncate(r.Interval)
+	if !from.Before(to) {
+		return nil
+	}
+	if err := svc.repo.Rollup(ctx, p.scope, r.Interval, from, to); err != nil {
+		return err
+	}
+
+	return svc.repo.SaveWatermark(ctx, p.scope, r.Interval, to)
+}
+
+func ceil(t time.Time, d time.Duration) time.Time {
+	c := t.Truncate(d)
+	if c.Before(t) {
+		c = c.Add(d)
+	}
+
+	return c
+}
+
+// NewHandler starts the goroutine which periodically enforces the retention
+// policies until the context is canceled.
+func NewHandler(ctx context.Context, svc Service, checkInterval time.Duration, logger *slog.Logger) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := svc.Enforce(ctx, time.Now()); err != nil {
+					logger.Error("failed to enforce retention policies", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
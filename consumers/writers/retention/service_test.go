@@ -0,0 +1,250 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/retention"
+	"github.com/absmach/supermq/consumers/writers/retention/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	now       = time.Date(2024, 5, 10, 13, 45, 0, 0, time.UTC)
+	raw       = 30 * 24 * time.Hour
+	hourly    = retention.Rollup{Interval: time.Hour, Retention: 2 * 365 * 24 * time.Hour}
+	errRepo   = errors.New("repository error")
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+)
+
+func TestNewService(t *testing.T) {
+	cases := []struct {
+		desc     string
+		policies []retention.Policy
+		err      error
+	}{
+		{
+			desc:     "create service with valid policies",
+			policies: []retention.Policy{{DomainID: domainID, RawRetention: raw, Rollups: []retention.Rollup{hourly}}, {ChannelID: channelID, RawRetention: raw}},
+			err:      nil,
+		},
+		{
+			desc:     "create service with rollup interval which does not divide a day",
+			policies: []retention.Policy{{RawRetention: raw, Rollups: []retention.Rollup{{Interval: 7 * time.Hour}}}},
+			err:      retention.ErrInvalidPolicy,
+		},
+		{
+			desc:     "create service with duplicate rollup interval",
+			policies: []retention.Policy{{RawRetention: raw, Rollups: []retention.Rollup{hourly, hourly}}},
+			err:      retention.ErrInvalidPolicy,
+		},
+		{
+			desc:     "create service with raw retention shorter than rollup interval",
+			policies: []retention.Policy{{RawRetention: time.Minute, Rollups: []retention.Rollup{hourly}}},
+			err:      retention.ErrInvalidPolicy,
+		},
+		{
+			desc:     "create service with negative raw retention",
+			policies: []retention.Policy{{RawRetention: -raw}},
+			err:      retention.ErrInvalidPolicy,
+		},
+		{
+			desc:     "create service with negative lateness",
+			policies: []retention.Policy{{RawRetention: raw, Lateness: -time.Hour, Rollups: []retention.Rollup{hourly}}},
+			err:      retention.ErrInvalidPolicy,
+		},
+		{
+			desc:     "create service with duplicate policies",
+			policies: []retention.Policy{{DomainID: domainID, RawRetention: raw}, {DomainID: domainID, RawRetention: 2 * raw}},
+			err:      retention.ErrInvalidPolicy,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := retention.NewService(new(mocks.Repository), tc.policies)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	scope := retention.Scope{DomainID: domainID}
+	from := now.Add(-raw).Truncate(time.Hour).Add(time.Hour)
+	to := now.Truncate(time.Hour)
+
+	cases := []struct {
+		desc           string
+		lateness       time.Duration
+		watermark      time.Time
+		watermarkErr   error
+		rollupFrom     time.Time
+		rollupErr      error
+		saveErr        error
+		deleteRollErr  error
+		deleteMsgsErr  error
+		rollup         bool
+		deleteRollups  bool
+		deleteMessages bool
+		err            error
+	}{
+		{
+			desc:           "enforce policy without watermark",
+			rollupFrom:     from,
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with watermark",
+			watermark:      to.Add(-time.Hour),
+			rollupFrom:     to.Add(-time.Hour),
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with up to date watermark",
+			watermark:      to,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with lateness",
+			lateness:       3 * time.Hour,
+			watermark:      to.Add(-time.Hour),
+			rollupFrom:     to.Add(-4 * time.Hour),
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with lateness not aligned to rollup interval",
+			lateness:       90 * time.Minute,
+			watermark:      to.Add(-time.Hour),
+			rollupFrom:     to.Add(-3 * time.Hour),
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with lateness and up to date watermark",
+			lateness:       time.Hour,
+			watermark:      to,
+			rollupFrom:     to.Add(-time.Hour),
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:           "enforce policy with lateness beyond raw retention",
+			lateness:       2 * raw,
+			watermark:      to.Add(-time.Hour),
+			rollupFrom:     from,
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+		},
+		{
+			desc:         "enforce policy with failed watermark retrieval",
+			watermarkErr: errRepo,
+			err:          errRepo,
+		},
+		{
+			desc:       "enforce policy with failed rollup",
+			rollupFrom: from,
+			rollup:     true,
+			rollupErr:  errRepo,
+			err:        errRepo,
+		},
+		{
+			desc:       "enforce policy with failed watermark save",
+			rollupFrom: from,
+			rollup:     true,
+			saveErr:    errRepo,
+			err:        errRepo,
+		},
+		{
+			desc:          "enforce policy with failed rollups removal",
+			rollupFrom:    from,
+			rollup:        true,
+			deleteRollups: true,
+			deleteRollErr: errRepo,
+			err:           errRepo,
+		},
+		{
+			desc:           "enforce policy with failed messages removal",
+			rollupFrom:     from,
+			rollup:         true,
+			deleteRollups:  true,
+			deleteMessages: true,
+			deleteMsgsErr:  errRepo,
+			err:            errRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			svc, err := retention.NewService(repo, []retention.Policy{{DomainID: domainID, RawRetention: raw, Lateness: tc.lateness, Rollups: []retention.Rollup{hourly}}})
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+			repoCall := repo.On("RetrieveWatermark", context.Background(), scope, time.Hour).Return(tc.watermark, tc.watermarkErr)
+			repoCall1 := repo.On("Rollup", context.Background(), scope, time.Hour, tc.rollupFrom, to).Return(tc.rollupErr)
+			repoCall2 := repo.On("SaveWatermark", context.Background(), scope, time.Hour, to).Return(tc.saveErr)
+			repoCall3 := repo.On("DeleteRollups", context.Background(), scope, time.Hour, now.Add(-hourly.Retention)).Return(tc.deleteRollErr)
+			repoCall4 := repo.On("DeleteMessages", context.Background(), scope, now.Add(-raw)).Return(tc.deleteMsgsErr)
+			err = svc.Enforce(context.Background(), now)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			if tc.rollup {
+				repo.AssertCalled(t, "Rollup", context.Background(), scope, time.Hour, tc.rollupFrom, to)
+			} else {
+				repo.AssertNotCalled(t, "Rollup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if !tc.deleteRollups {
+				repo.AssertNotCalled(t, "DeleteRollups", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.deleteMessages {
+				repo.AssertCalled(t, "DeleteMessages", context.Background(), scope, now.Add(-raw))
+			} else {
+				repo.AssertNotCalled(t, "DeleteMessages", mock.Anything, mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+		})
+	}
+}
+
+func TestEnforceScopes(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc, err := retention.NewService(repo, []retention.Policy{
+		{RawRetention: raw},
+		{DomainID: domainID, RawRetention: raw},
+		{DomainID: domainID, ChannelID: channelID, RawRetention: raw},
+	})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	scopes := []retention.Scope{
+		{ExcludedDomains: []string{domainID}, ExcludedChannels: []string{channelID}},
+		{DomainID: domainID, ExcludedChannels: []string{channelID}},
+		{ChannelID: channelID},
+	}
+	for _, s := range scopes {
+		repo.On("DeleteMessages", context.Background(), s, now.Add(-raw)).Return(nil).Once()
+	}
+	err = svc.Enforce(context.Background(), now)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	repo.AssertExpectations(t)
+}
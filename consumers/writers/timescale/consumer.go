@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/batch"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -27,7 +28,15 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
-var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
+var (
+	senmlColumns = []string{"channel", "domain", "subtopic", "publisher", "protocol", "name", "unit", "value", "string_value", "bool_value", "data_value", "sum", "time", "update_time"}
+	jsonColumns  = []string{"channel", "created", "subtopic", "publisher", "protocol", "payload"}
+)
+
+var (
+	_ consumers.BlockingConsumer = (*timescaleRepo)(nil)
+	_ batch.Repository           = (*timescaleRepo)(nil)
+)
 
 type timescaleRepo struct {
 	db *sqlx.DB
@@ -38,6 +47,11 @@ func New(db *sqlx.DB) consumers.BlockingConsumer {
 	return &timescaleRepo{db: db}
 }
 
+// NewBatchRepository returns new TimescaleSQL writer of the message batches.
+func NewBatchRepository(db *sqlx.DB) batch.Repository {
+	return &timescaleRepo{db: db}
+}
+
 func (tr *timescaleRepo) ConsumeBlocking(ctx context.Context, message any) (err error) {
 	switch m := message.(type) {
 	case smqjson.Messages:
@@ -56,10 +70,10 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages any) (err error)
 	if !ok {
 		return errSaveMessage
 	}
-	q := `INSERT INTO messages (channel, subtopic, publisher, protocol,
+	q := `INSERT INTO messages (channel, domain, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
           time, update_time)
-          VALUES (:channel, :subtopic, :publisher, :protocol, :name, :unit,
+          VALUES (:channel, :domain, :subtopic, :publisher, :protocol, :name, :unit,
           :value, :string_value, :bool_value, :data_value, :sum,
           :time, :update_time);`
 
@@ -157,6 +171,89 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) e
 	return nil
 }
 
+// SaveBatch inserts all the records of the batch in a single transaction.
+// SenML records already stored are skipped by their primary key. JSON
+// messages get a new ID on every save, so the JSON messages the broker
+// redelivers after their batch was committed are stored again.
+func (tr timescaleRepo) SaveBatch(ctx context.Context, b batch.Batch) error {
+	if err := tr.insertBatch(ctx, b); err != nil {
+		if err == errNoTable {
+			for _, msgs := range b.JSON {
+				if err := tr.createTable(msgs.Format); err != nil {
+					return err
+				}
+			}
+			return tr.insertBatch(ctx, b)
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr timescaleRepo) insertBatch(ctx context.Context, b batch.Batch) (err error) {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	if len(b.Senml) > 0 {
+		rows := make([][]any, 0, len(b.Senml))
+		for _, msg := range b.Senml {
+			rows = append(rows, []any{
+				msg.Channel, msg.Domain, msg.Subtopic, msg.Publisher, msg.Protocol, msg.Name, msg.Unit,
+				msg.Value, msg.StringValue, msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime,
+			})
+		}
+		if err := batch.Insert(ctx, tx, "messages", senmlColumns, rows, "ON CONFLICT DO NOTHING"); err != nil {
+			return batchError(err)
+		}
+	}
+
+	for _, msgs := range b.JSON {
+		rows := make([][]any, 0, len(msgs.Data))
+		for _, m := range msgs.Data {
+			dbmsg, err := toJSONMessage(m)
+			if err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			rows = append(rows, []any{dbmsg.Channel, dbmsg.Created, dbmsg.Subtopic, dbmsg.Publisher, dbmsg.Protocol, dbmsg.Payload})
+		}
+		if err := batch.Insert(ctx, tx, msgs.Format, jsonColumns, rows, "ON CONFLICT DO NOTHING"); err != nil {
+			return batchError(err)
+		}
+	}
+
+	return nil
+}
+
+func batchError(err error) error {
+	if preErr, ok := err.(*pgconn.PrepareError); ok {
+		err = preErr.Unwrap()
+	}
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case pgerrcode.InvalidTextRepresentation:
+			return errors.Wrap(errSaveMessage, errInvalidMessage)
+		case pgerrcode.UndefinedTable:
+			return errNoTable
+		}
+	}
+
+	return errors.Wrap(errSaveMessage, err)
+}
+
 func (tr timescaleRepo) createTable(name string) error {
 	q := `CREATE TABLE IF NOT EXISTS %s (
             created       BIGINT NOT NULL,
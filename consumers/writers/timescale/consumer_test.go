@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/absmach/supermq/consumers/writers/batch"
 	"github.com/absmach/supermq/consumers/writers/timescale"
 	"github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
@@ -110,3 +111,58 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.ConsumeBlocking(context.TODO(), msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestSaveBatch(t *testing.T) {
+	repo := timescale.NewBatchRepository(db)
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	b := batch.Batch{
+		JSON: []json.Messages{{Format: "batch_json"}},
+	}
+	for i := 0; i < msgsNum; i++ {
+		b.Senml = append(b.Senml, senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Subtopic:  subtopic,
+			Time:      float64(now + int64(i)),
+			Value:     &v,
+		})
+		b.JSON[0].Data = append(b.JSON[0].Data, json.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Created:   now + int64(i),
+			Subtopic:  subtopic,
+			Protocol:  "mqtt",
+			Payload:   map[string]any{"field_1": i},
+		})
+	}
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "save batch",
+			err:  nil,
+		},
+		{
+			desc: "save already saved batch",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveBatch(context.TODO(), b)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		var count int
+		err = db.Get(&count, "SELECT COUNT(*) FROM messages WHERE channel = $1", chid.String())
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, msgsNum, count, fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, msgsNum, count))
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clickhouse contains the quarantine repository implementation using
+// ClickHouse as the underlying database.
+package clickhouse
@@ -0,0 +1,148 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/validation"
+	clickhouseclient "github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	errSave              = errors.New("failed to save quarantined message")
+	errRemove            = errors.New("failed to remove quarantined messages")
+	errSaveContract      = errors.New("failed to save payload contract")
+	errRemoveContract    = errors.New("failed to remove payload contract")
+	errRetrieveContracts = errors.New("failed to retrieve payload contracts")
+)
+
+var _ validation.Repository = (*repository)(nil)
+
+type repository struct {
+	db *clickhouseclient.Client
+}
+
+// New returns new quarantine and contracts repository.
+func New(db *clickhouseclient.Client) validation.Repository {
+	return &repository{db: db}
+}
+
+type message struct {
+	Created   int64  `json:"created"`
+	Domain    string `json:"domain"`
+	Channel   string `json:"channel"`
+	Subtopic  string `json:"subtopic"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   string `json:"payload"`
+	Reason    string `json:"reason"`
+}
+
+type contract struct {
+	ChannelID    string `json:"channel_id"`
+	Schema       string `json:"schema"`
+	Measurements string `json:"measurements"`
+	Updated      int64  `json:"updated"`
+	Removed      uint8  `json:"removed"`
+}
+
+func (repo *repository) Save(ctx context.Context, msg validation.Message) error {
+	m := message{
+		Created:   msg.Created,
+		Domain:    msg.Domain,
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   string(msg.Payload),
+		Reason:    msg.Reason,
+	}
+	if err := repo.db.Insert(ctx, "messages_quarantine", []any{m}); err != nil {
+		return errors.Wrap(errSave, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Remove(ctx context.Context, before time.Time) error {
+	q := `ALTER TABLE messages_quarantine DELETE WHERE created < {before:Int64}`
+
+	if err := repo.db.Exec(ctx, q, map[string]any{"before": before.UnixNano()}); err != nil {
+		return errors.Wrap(errRemove, err)
+	}
+
+	return nil
+}
+
+// SaveContract inserts the new version of the contract. The versions of the
+// contract are collapsed when the table parts are merged, so the contracts
+// are always read with FINAL.
+func (repo *repository) SaveContract(ctx context.Context, c validation.Contract) error {
+	measurements, err := json.Marshal(c.Measurements)
+	if err != nil {
+		return errors.Wrap(errSaveContract, err)
+	}
+	ct := contract{
+		ChannelID:    c.ChannelID,
+		Schema:       c.Schema,
+		Measurements: string(measurements),
+		Updated:      time.Now().UnixNano(),
+	}
+	if err := repo.db.Insert(ctx, "channel_contracts", []any{ct}); err != nil {
+		return errors.Wrap(errSaveContract, err)
+	}
+
+	return nil
+}
+
+// RemoveContract inserts the version of the contract which marks it removed.
+func (repo *repository) RemoveContract(ctx context.Context, channelID string) error {
+	ct := contract{
+		ChannelID:    channelID,
+		Measurements: "[]",
+		Updated:      time.Now().UnixNano(),
+		Removed:      1,
+	}
+	if err := repo.db.Insert(ctx, "channel_contracts", []any{ct}); err != nil {
+		return errors.Wrap(errRemoveContract, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveContracts(ctx context.Context) ([]validation.Contract, error) {
+	q := `SELECT channel_id, schema, measurements FROM channel_contracts FINAL
+          WHERE removed = 0 ORDER BY channel_id`
+
+	rows, err := repo.db.Query(ctx, q, nil)
+	if err != nil {
+		return nil, errors.Wrap(errRetrieveContracts, err)
+	}
+	defer rows.Close()
+
+	contracts := []validation.Contract{}
+	for rows.Next() {
+		var ct contract
+		if err := rows.Scan(&ct); err != nil {
+			return nil, errors.Wrap(errRetrieveContracts, err)
+		}
+		c := validation.Contract{
+			ChannelID: ct.ChannelID,
+			Schema:    ct.Schema,
+		}
+		if err := json.Unmarshal([]byte(ct.Measurements), &c.Measurements); err != nil {
+			return nil, errors.Wrap(errRetrieveContracts, err)
+		}
+		contracts = append(contracts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errRetrieveContracts, err)
+	}
+
+	return contracts, nil
+}
@@ -0,0 +1,313 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	errQuarantine       = errors.New("failed to quarantine message")
+	errRetrieveContract = errors.New("failed to retrieve payload contracts")
+	errRemoteReference  = errors.New("schema references outside of the schema are not allowed")
+)
+
+// valueKeywords hold the instance values rather than the schemas, so their
+// content is not checked for the references.
+var valueKeywords = map[string]bool{
+	"enum":     true,
+	"const":    true,
+	"default":  true,
+	"examples": true,
+}
+
+var _ Consumer = (*consumer)(nil)
+
+// Consumer represents the blocking consumer which enforces the payload
+// contracts of the channels.
+type Consumer interface {
+	consumers.BlockingConsumer
+	consumers.Quarantiner
+
+	// SaveContract sets the contract of the channel, replacing the existing one.
+	SaveContract(ctx context.Context, c Contract) error
+
+	// RemoveContract removes the contract of the channel.
+	RemoveContract(ctx context.Context, channelID string) error
+}
+
+type contract struct {
+	schema *gojsonschema.Schema
+	// units maps the allowed measurements to their units. Empty unit allows
+	// any unit of the measurement.
+	units map[string]map[string]bool
+}
+
+type consumer struct {
+	consumer  consumers.BlockingConsumer
+	repo      Repository
+	mu        sync.RWMutex
+	contracts map[string]contract
+}
+
+// NewConsumer returns the blocking consumer which passes the messages that
+// satisfy the contracts of their channels to the wrapped consumer, and keeps
+// the rejected ones in the quarantine. Rejected messages are acknowledged
+// once they are quarantined, so the broker does not redeliver them. The
+// contracts are loaded from the repository.
+func NewConsumer(ctx context.Context, c consumers.BlockingConsumer, repo Repository) (Consumer, error) {
+	cs, err := repo.RetrieveContracts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(errRetrieveContract, err)
+	}
+
+	vc := &consumer{
+		consumer:  c,
+		repo:      repo,
+		contracts: make(map[string]contract),
+	}
+	for _, c := range cs {
+		ct, err := compile(c)
+		if err != nil {
+			return nil, err
+		}
+		vc.contracts[c.ChannelID] = ct
+	}
+
+	return vc, nil
+}
+
+func (vc *consumer) SaveContract(ctx context.Context, c Contract) error {
+	ct, err := compile(c)
+	if err != nil {
+		return err
+	}
+	if err := vc.repo.SaveContract(ctx, c); err != nil {
+		return err
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.contracts[c.ChannelID] = ct
+
+	return nil
+}
+
+func (vc *consumer) RemoveContract(ctx context.Context, channelID string) error {
+	if err := vc.repo.RemoveContract(ctx, channelID); err != nil {
+		return err
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	delete(vc.contracts, channelID)
+
+	return nil
+}
+
+func compile(c Contract) (contract, error) {
+	if c.ChannelID == "" {
+		return contract{}, errors.Wrap(ErrInvalidContract, errors.New("missing channel ID"))
+	}
+	var ct contract
+	if c.Schema != "" {
+		var doc any
+		if err := json.Unmarshal([]byte(c.Schema), &doc); err != nil {
+			return contract{}, errors.Wrap(ErrInvalidContract, err)
+		}
+		if err := checkReferences(doc); err != nil {
+			return contract{}, errors.Wrap(ErrInvalidContract, err)
+		}
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(doc))
+		if err != nil {
+			return contract{}, errors.Wrap(ErrInvalidContract, err)
+		}
+		ct.schema = schema
+	}
+	if len(c.Measurements) > 0 {
+		ct.units = make(map[string]map[string]bool)
+		for _, m := range c.Measurements {
+			if m.Name == "" {
+				return contract{}, errors.Wrap(ErrInvalidContract, errors.New("missing measurement name"))
+			}
+			if ct.units[m.Name] == nil {
+				ct.units[m.Name] = make(map[string]bool)
+			}
+			ct.units[m.Name][m.Unit] = true
+		}
+	}
+
+	return ct, nil
+}
+
+// checkReferences rejects the schema which refers to anything but its own
+// subschemas. The schema loader fetches the other references over HTTP or
+// from the file system, and the contracts are set by the channel members.
+func checkReferences(node any) error {
+	switch n := node.(type) {
+	case map[string]any:
+		for k, v := range n {
+			switch k {
+			case "$ref", "$id", "id":
+				if ref, ok := v.(string); ok && !strings.HasPrefix(ref, "#") {
+					return errors.Wrap(errRemoteReference, fmt.Errorf("%s %q", k, ref))
+				}
+			}
+			if valueKeywords[k] {
+				continue
+			}
+			if err := checkReferences(v); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, v := range n {
+			if err := checkReferences(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (vc *consumer) contract(channelID string) (contract, bool) {
+	vc.mu.RLock()
+	defer vc.mu.RUnlock()
+	c, ok := vc.contracts[channelID]
+
+	return c, ok
+}
+
+func (vc *consumer) ConsumeBlocking(ctx context.Context, message any) error {
+	var msg Message
+	var err error
+	switch m := message.(type) {
+	case []senml.Message:
+		if len(m) == 0 {
+			break
+		}
+		msg = Message{
+			Domain:    m[0].Domain,
+			Channel:   m[0].Channel,
+			Subtopic:  m[0].Subtopic,
+			Publisher: m[0].Publisher,
+			Protocol:  m[0].Protocol,
+		}
+		err = vc.validateSenml(m)
+	case smqjson.Messages:
+		if len(m.Data) == 0 {
+			break
+		}
+		msg = Message{
+			Domain:    m.Data[0].Domain,
+			Channel:   m.Data[0].Channel,
+			Subtopic:  m.Data[0].Subtopic,
+			Publisher: m.Data[0].Publisher,
+			Protocol:  m.Data[0].Protocol,
+		}
+		err = vc.validateJSON(m)
+	}
+	if err == nil {
+		return vc.consumer.ConsumeBlocking(ctx, message)
+	}
+
+	payload, merr := json.Marshal(message)
+	if merr != nil {
+		return errors.Wrap(errQuarantine, merr)
+	}
+	msg.Payload = payload
+
+	return vc.quarantine(ctx, msg, err)
+}
+
+// Quarantines reports whether the channel has the contract, so its messages
+// which can not be transformed are quarantined instead of failing to consume.
+func (vc *consumer) Quarantines(channelID string) bool {
+	_, ok := vc.contract(channelID)
+	return ok
+}
+
+func (vc *consumer) Quarantine(ctx context.Context, msg *messaging.Message, reason error) error {
+	m := Message{
+		Domain:    msg.GetDomain(),
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Payload:   msg.GetPayload(),
+	}
+
+	return vc.quarantine(ctx, m, errors.Wrap(ErrMalformedPayload, reason))
+}
+
+func (vc *consumer) quarantine(ctx context.Context, msg Message, reason error) error {
+	msg.Reason = reason.Error()
+	msg.Created = time.Now().UnixNano()
+	if err := vc.repo.Save(ctx, msg); err != nil {
+		return errors.Wrap(errQuarantine, err)
+	}
+
+	return nil
+}
+
+func (vc *consumer) validateSenml(msgs []senml.Message) error {
+	c, ok := vc.contract(msgs[0].Channel)
+	if !ok || c.units == nil {
+		return nil
+	}
+	for i, msg := range msgs {
+		units, ok := c.units[msg.Name]
+		if !ok {
+			return errors.Wrap(ErrContractViolation, fmt.Errorf("record %d: measurement %q is not allowed", i, msg.Name))
+		}
+		if !units[""] && !units[msg.Unit] {
+			return errors.Wrap(ErrContractViolation, fmt.Errorf("record %d: unit %q of measurement %q is not allowed", i, msg.Unit, msg.Name))
+		}
+	}
+
+	return nil
+}
+
+func (vc *consumer) validateJSON(msgs smqjson.Messages) error {
+	c, ok := vc.contract(msgs.Data[0].Channel)
+	if !ok || c.schema == nil {
+		return nil
+	}
+	for i, msg := range msgs.Data {
+		if err := validateSchema(c.schema, msg.Payload); err != nil {
+			return errors.Wrap(ErrContractViolation, fmt.Errorf("message %d: %w", i, err))
+		}
+	}
+
+	return nil
+}
+
+func validateSchema(schema *gojsonschema.Schema, doc any) error {
+	res, err := schema.Validate(gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return err
+	}
+	if res.Valid() {
+		return nil
+	}
+	var reasons []string
+	for _, e := range res.Errors() {
+		reasons = append(reasons, e.String())
+	}
+
+	return fmt.Errorf("%s", strings.Join(reasons, "; "))
+}
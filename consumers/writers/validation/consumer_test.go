@@ -0,0 +1,293 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/writers/validation"
+	"github.com/absmach/supermq/consumers/writers/validation/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const schema = `{
+    "type": "object",
+    "properties": {"temperature": {"type": "number"}},
+    "required": ["temperature"]
+}`
+
+var (
+	errRepo   = errors.New("repository error")
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	channelID = testsutil.GenerateUUID(&testing.T{})
+	otherID   = testsutil.GenerateUUID(&testing.T{})
+	contracts = []validation.Contract{
+		{
+			ChannelID:    channelID,
+			Schema:       schema,
+			Measurements: []validation.Measurement{{Name: "temperature", Unit: "Cel"}, {Name: "humidity"}},
+		},
+	}
+)
+
+type consumerFunc func(ctx context.Context, msgs any) error
+
+func (f consumerFunc) ConsumeBlocking(ctx context.Context, msgs any) error {
+	return f(ctx, msgs)
+}
+
+func TestNewConsumer(t *testing.T) {
+	cases := []struct {
+		desc      string
+		contracts []validation.Contract
+		repoErr   error
+		err       error
+	}{
+		{
+			desc:      "create consumer with valid contracts",
+			contracts: contracts,
+			err:       nil,
+		},
+		{
+			desc:      "create consumer without contracts",
+			contracts: []validation.Contract{},
+			err:       nil,
+		},
+		{
+			desc:      "create consumer with contract without channel",
+			contracts: []validation.Contract{{Schema: schema}},
+			err:       validation.ErrInvalidContract,
+		},
+		{
+			desc:      "create consumer with invalid schema",
+			contracts: []validation.Contract{{ChannelID: channelID, Schema: `{"type": 5}`}},
+			err:       validation.ErrInvalidContract,
+		},
+		{
+			desc:      "create consumer with measurement without name",
+			contracts: []validation.Contract{{ChannelID: channelID, Measurements: []validation.Measurement{{Unit: "Cel"}}}},
+			err:       validation.ErrInvalidContract,
+		},
+		{
+			desc:    "create consumer with failed contracts retrieval",
+			repoErr: errRepo,
+			err:     errRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			repo.On("RetrieveContracts", mock.Anything).Return(tc.contracts, tc.repoErr)
+			_, err := validation.NewConsumer(context.Background(), consumerFunc(nil), repo)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestSaveContract(t *testing.T) {
+	cases := []struct {
+		desc        string
+		contract    validation.Contract
+		quarantines bool
+		repoErr     error
+		err         error
+	}{
+		{
+			desc:        "save valid contract",
+			contract:    validation.Contract{ChannelID: otherID, Measurements: []validation.Measurement{{Name: "pressure"}}},
+			quarantines: true,
+		},
+		{
+			desc:     "save contract with invalid schema",
+			contract: validation.Contract{ChannelID: otherID, Schema: `{"type": 5}`},
+			err:      validation.ErrInvalidContract,
+		},
+		{
+			desc:     "save contract with remote schema reference",
+			contract: validation.Contract{ChannelID: otherID, Schema: `{"$ref": "http://169.254.169.254/latest/meta-data"}`},
+			err:      validation.ErrInvalidContract,
+		},
+		{
+			desc:     "save contract with file schema reference",
+			contract: validation.Contract{ChannelID: otherID, Schema: `{"$ref": "file:///etc/passwd"}`},
+			err:      validation.ErrInvalidContract,
+		},
+		{
+			desc:     "save contract with nested remote schema reference",
+			contract: validation.Contract{ChannelID: otherID, Schema: `{"properties": {"temperature": {"$ref": "http://example.com/schema.json"}}}`},
+			err:      validation.ErrInvalidContract,
+		},
+		{
+			desc:     "save contract with remote schema ID",
+			contract: validation.Contract{ChannelID: otherID, Schema: `{"$id": "http://example.com/schema.json", "$ref": "#/definitions/t", "definitions": {"t": {"type": "object"}}}`},
+			err:      validation.ErrInvalidContract,
+		},
+		{
+			desc:        "save contract with local schema reference",
+			contract:    validation.Contract{ChannelID: otherID, Schema: `{"properties": {"temperature": {"$ref": "#/definitions/t"}, "id": {"type": "string"}}, "definitions": {"t": {"type": "number", "enum": [{"$ref": "http://example.com"}]}}}`},
+			quarantines: true,
+		},
+		{
+			desc:     "save contract with failed repository save",
+			contract: validation.Contract{ChannelID: otherID, Schema: schema},
+			repoErr:  errRepo,
+			err:      errRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			repo.On("RetrieveContracts", mock.Anything).Return(contracts, nil)
+			c, err := validation.NewConsumer(context.Background(), consumerFunc(nil), repo)
+			assert.Nil(t, err, "unexpected error creating consumer: %s", err)
+
+			if tc.err == nil || tc.repoErr != nil {
+				repo.On("SaveContract", mock.Anything, tc.contract).Return(tc.repoErr)
+			}
+			err = c.SaveContract(context.Background(), tc.contract)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			assert.Equal(t, tc.quarantines, c.Quarantines(otherID))
+		})
+	}
+}
+
+func TestRemoveContract(t *testing.T) {
+	cases := []struct {
+		desc        string
+		quarantines bool
+		repoErr     error
+		err         error
+	}{
+		{
+			desc:        "remove contract",
+			quarantines: false,
+		},
+		{
+			desc:        "remove contract with failed repository remove",
+			quarantines: true,
+			repoErr:     errRepo,
+			err:         errRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			repo.On("RetrieveContracts", mock.Anything).Return(contracts, nil)
+			c, err := validation.NewConsumer(context.Background(), consumerFunc(nil), repo)
+			assert.Nil(t, err, "unexpected error creating consumer: %s", err)
+
+			repo.On("RemoveContract", mock.Anything, channelID).Return(tc.repoErr)
+			err = c.RemoveContract(context.Background(), channelID)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			assert.Equal(t, tc.quarantines, c.Quarantines(channelID))
+		})
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	cases := []struct {
+		desc       string
+		msgs       any
+		consumed   bool
+		quarantine bool
+		reason     error
+		repoErr    error
+		err        error
+	}{
+		{
+			desc:     "consume valid SenML message",
+			msgs:     []senml.Message{{Domain: domainID, Channel: channelID, Name: "temperature", Unit: "Cel"}, {Domain: domainID, Channel: channelID, Name: "humidity", Unit: "%RH"}},
+			consumed: true,
+		},
+		{
+			desc:     "consume SenML message of channel without contract",
+			msgs:     []senml.Message{{Channel: otherID, Name: "pressure"}},
+			consumed: true,
+		},
+		{
+			desc:       "consume SenML message with measurement not allowed",
+			msgs:       []senml.Message{{Domain: domainID, Channel: channelID, Name: "temperature", Unit: "Cel"}, {Domain: domainID, Channel: channelID, Name: "pressure"}},
+			quarantine: true,
+			reason:     validation.ErrContractViolation,
+		},
+		{
+			desc:       "consume SenML message with unit not allowed",
+			msgs:       []senml.Message{{Domain: domainID, Channel: channelID, Name: "temperature", Unit: "K"}},
+			quarantine: true,
+			reason:     validation.ErrContractViolation,
+		},
+		{
+			desc:     "consume valid JSON message",
+			msgs:     smqjson.Messages{Format: "format", Data: []smqjson.Message{{Domain: domainID, Channel: channelID, Payload: smqjson.Payload{"temperature": 21.5}}}},
+			consumed: true,
+		},
+		{
+			desc:       "consume JSON message violating schema",
+			msgs:       smqjson.Messages{Format: "format", Data: []smqjson.Message{{Domain: domainID, Channel: channelID, Payload: smqjson.Payload{"temperature": "hot"}}}},
+			quarantine: true,
+			reason:     validation.ErrContractViolation,
+		},
+		{
+			desc:       "consume invalid message with quarantine failure",
+			msgs:       smqjson.Messages{Format: "format", Data: []smqjson.Message{{Domain: domainID, Channel: channelID, Payload: smqjson.Payload{}}}},
+			quarantine: true,
+			reason:     validation.ErrContractViolation,
+			repoErr:    errRepo,
+			err:        errRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			consumed := false
+			next := consumerFunc(func(_ context.Context, _ any) error {
+				consumed = true
+				return nil
+			})
+			repo.On("RetrieveContracts", mock.Anything).Return(contracts, nil)
+			c, err := validation.NewConsumer(context.Background(), next, repo)
+			assert.Nil(t, err, "unexpected error creating consumer: %s", err)
+
+			if tc.quarantine {
+				repo.On("Save", mock.Anything, mock.MatchedBy(func(m validation.Message) bool {
+					return m.Domain == domainID && m.Channel == channelID && strings.HasPrefix(m.Reason, tc.reason.Error()) && m.Created > 0 && len(m.Payload) > 0
+				})).Return(tc.repoErr)
+			}
+			err = c.ConsumeBlocking(context.Background(), tc.msgs)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			assert.Equal(t, tc.consumed, consumed)
+		})
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	repo := mocks.NewRepository(t)
+	repo.On("RetrieveContracts", mock.Anything).Return(contracts, nil)
+	c, err := validation.NewConsumer(context.Background(), consumerFunc(nil), repo)
+	assert.Nil(t, err, "unexpected error creating consumer: %s", err)
+
+	var q consumers.Quarantiner = c
+	assert.True(t, q.Quarantines(channelID), "channel with contract does not quarantine malformed messages")
+	assert.False(t, q.Quarantines(otherID), "channel without contract quarantines malformed messages")
+
+	msg := &messaging.Message{Channel: channelID, Payload: []byte("{")}
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(m validation.Message) bool {
+		return m.Channel == channelID && string(m.Payload) == "{" && strings.HasPrefix(m.Reason, validation.ErrMalformedPayload.Error())
+	})).Return(nil)
+	err = q.Quarantine(context.Background(), msg, errors.New("invalid JSON"))
+	assert.Nil(t, err, "unexpected error quarantining message: %s", err)
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package validation contains the payload contracts of the channels, which
+// are enforced by the writers before the messages are saved, and the
+// quarantine of the rejected messages.
+package validation
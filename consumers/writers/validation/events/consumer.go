@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/supermq/consumers/writers/validation"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	channelsStream = "events.supermq.channel.*"
+
+	channelCreate = "channel.create"
+	channelUpdate = "channel.update"
+	channelRemove = "channel.remove"
+)
+
+var _ events.EventHandler = (*eventHandler)(nil)
+
+type eventHandler struct {
+	contracts validation.Consumer
+	logger    *slog.Logger
+}
+
+// Subscribe subscribes to the channel events and sets the payload contracts
+// of the channels from their metadata. The events are delivered from the
+// start of the stream, so the writer subscribing for the first time receives
+// the contracts of the existing channels.
+func Subscribe(ctx context.Context, contracts validation.Consumer, esURL, consumer string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, consumer+"-es-sub", logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         channelsStream,
+		Consumer:       consumer,
+		Handler:        NewEventHandler(contracts, logger),
+		DeliveryPolicy: messaging.DeliverAllPolicy,
+		Ordered:        true,
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewEventHandler returns new event store handler that sets the payload contracts.
+func NewEventHandler(contracts validation.Consumer, logger *slog.Logger) events.EventHandler {
+	return &eventHandler{
+		contracts: contracts,
+		logger:    logger,
+	}
+}
+
+func (h *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	id := events.Read(data, "id", "")
+	if id == "" {
+		return nil
+	}
+
+	switch op := events.Read(data, "operation", ""); op {
+	case channelCreate, channelUpdate:
+		metadata := events.Read(data, "metadata", map[string]any{})
+		c, ok, err := validation.ContractFromMetadata(id, metadata)
+		if err != nil {
+			return h.invalid(id, err)
+		}
+		if !ok {
+			if op == channelCreate {
+				return nil
+			}
+			return h.contracts.RemoveContract(ctx, id)
+		}
+		if err := h.contracts.SaveContract(ctx, c); err != nil {
+			if errors.Contains(err, validation.ErrInvalidContract) {
+				return h.invalid(id, err)
+			}
+			return err
+		}
+	case channelRemove:
+		return h.contracts.RemoveContract(ctx, id)
+	}
+
+	return nil
+}
+
+// invalid logs the invalid contract. The invalid contract would be rejected
+// on every redelivery of the event, so the channel keeps its previous contract.
+func (h *eventHandler) invalid(channelID string, err error) error {
+	h.logger.Warn("Invalid channel payload contract", slog.String("channel_id", channelID), slog.Any("error", err))
+
+	return nil
+}
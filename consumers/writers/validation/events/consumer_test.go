@@ -0,0 +1,121 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/absmach/supermq/consumers/writers/validation"
+	"github.com/absmach/supermq/consumers/writers/validation/events"
+	"github.com/absmach/supermq/consumers/writers/validation/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const channelID = "channel-id"
+
+var errRepo = errors.New("repository error")
+
+type testEvent struct {
+	data map[string]any
+}
+
+func (e testEvent) Encode() (map[string]any, error) {
+	return e.data, nil
+}
+
+type consumerFunc func(ctx context.Context, msgs any) error
+
+func (f consumerFunc) ConsumeBlocking(ctx context.Context, msgs any) error {
+	return f(ctx, msgs)
+}
+
+func TestHandle(t *testing.T) {
+	contract := validation.Contract{
+		ChannelID:    channelID,
+		Measurements: []validation.Measurement{{Name: "temperature", Unit: "Cel"}},
+	}
+	metadata := map[string]any{
+		validation.ContractKey: map[string]any{
+			"measurements": []any{map[string]any{"name": "temperature", "unit": "Cel"}},
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		data        map[string]any
+		save        bool
+		remove      bool
+		repoErr     error
+		quarantines bool
+		err         error
+	}{
+		{
+			desc:        "handle create event with contract",
+			data:        map[string]any{"operation": "channel.create", "id": channelID, "metadata": metadata},
+			save:        true,
+			quarantines: true,
+		},
+		{
+			desc: "handle create event without contract",
+			data: map[string]any{"operation": "channel.create", "id": channelID},
+		},
+		{
+			desc: "handle create event with invalid contract",
+			data: map[string]any{"operation": "channel.create", "id": channelID, "metadata": map[string]any{
+				validation.ContractKey: map[string]any{"schema": map[string]any{"type": 5}},
+			}},
+		},
+		{
+			desc:        "handle update event with contract",
+			data:        map[string]any{"operation": "channel.update", "id": channelID, "metadata": metadata},
+			save:        true,
+			quarantines: true,
+		},
+		{
+			desc:   "handle update event without contract",
+			data:   map[string]any{"operation": "channel.update", "id": channelID, "metadata": map[string]any{"key": "value"}},
+			remove: true,
+		},
+		{
+			desc:   "handle remove event",
+			data:   map[string]any{"operation": "channel.remove", "id": channelID},
+			remove: true,
+		},
+		{
+			desc:    "handle create event with failed contract save",
+			data:    map[string]any{"operation": "channel.create", "id": channelID, "metadata": metadata},
+			save:    true,
+			repoErr: errRepo,
+			err:     errRepo,
+		},
+		{
+			desc: "handle other event",
+			data: map[string]any{"operation": "channel.connect", "id": channelID},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			repo.On("RetrieveContracts", mock.Anything).Return([]validation.Contract{}, nil)
+			vc, err := validation.NewConsumer(context.Background(), consumerFunc(nil), repo)
+			assert.Nil(t, err, "unexpected error creating consumer: %s", err)
+
+			if tc.save {
+				repo.On("SaveContract", mock.Anything, contract).Return(tc.repoErr)
+			}
+			if tc.remove {
+				repo.On("RemoveContract", mock.Anything, channelID).Return(tc.repoErr)
+			}
+			h := events.NewEventHandler(vc, smqlog.NewMock())
+			err = h.Handle(context.Background(), testEvent{data: tc.data})
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			assert.Equal(t, tc.quarantines, vc.Quarantines(channelID))
+		})
+	}
+}
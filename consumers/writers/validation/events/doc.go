@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events keeps the payload contracts enforced by the writers in sync
+// with the channel metadata, by consuming the channel events.
+package events
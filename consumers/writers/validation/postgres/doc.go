@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package postgres contains the quarantine repository implementation using
+// Postgres as the underlying database. It is shared by the Postgres and
+// Timescale writers, since both create the quarantine table.
+package postgres
@@ -0,0 +1,114 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/validation"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errSave              = errors.New("failed to save quarantined message")
+	errRemove            = errors.New("failed to remove quarantined messages")
+	errSaveContract      = errors.New("failed to save payload contract")
+	errRemoveContract    = errors.New("failed to remove payload contract")
+	errRetrieveContracts = errors.New("failed to retrieve payload contracts")
+)
+
+var _ validation.Repository = (*repository)(nil)
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// New returns new quarantine and contracts repository.
+func New(db *sqlx.DB) validation.Repository {
+	return &repository{db: db}
+}
+
+type dbContract struct {
+	ChannelID    string `db:"channel_id"`
+	Schema       string `db:"schema"`
+	Measurements []byte `db:"measurements"`
+}
+
+func (repo *repository) Save(ctx context.Context, msg validation.Message) error {
+	q := `INSERT INTO messages_quarantine (created, domain, channel, subtopic, publisher, protocol, payload, reason)
+          VALUES (:created, :domain, :channel, :subtopic, :publisher, :protocol, :payload, :reason);`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, msg); err != nil {
+		return errors.Wrap(errSave, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Remove(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM messages_quarantine WHERE created < $1;`
+
+	if _, err := repo.db.ExecContext(ctx, q, before.UnixNano()); err != nil {
+		return errors.Wrap(errRemove, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) SaveContract(ctx context.Context, c validation.Contract) error {
+	measurements, err := json.Marshal(c.Measurements)
+	if err != nil {
+		return errors.Wrap(errSaveContract, err)
+	}
+	dbc := dbContract{
+		ChannelID:    c.ChannelID,
+		Schema:       c.Schema,
+		Measurements: measurements,
+	}
+	q := `INSERT INTO channel_contracts (channel_id, schema, measurements)
+          VALUES (:channel_id, :schema, :measurements)
+          ON CONFLICT (channel_id) DO UPDATE SET schema = EXCLUDED.schema, measurements = EXCLUDED.measurements;`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, dbc); err != nil {
+		return errors.Wrap(errSaveContract, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RemoveContract(ctx context.Context, channelID string) error {
+	q := `DELETE FROM channel_contracts WHERE channel_id = $1;`
+
+	if _, err := repo.db.ExecContext(ctx, q, channelID); err != nil {
+		return errors.Wrap(errRemoveContract, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveContracts(ctx context.Context) ([]validation.Contract, error) {
+	q := `SELECT channel_id, schema, measurements FROM channel_contracts ORDER BY channel_id;`
+
+	var dbcs []dbContract
+	if err := repo.db.SelectContext(ctx, &dbcs, q); err != nil {
+		return nil, errors.Wrap(errRetrieveContracts, err)
+	}
+
+	contracts := []validation.Contract{}
+	for _, dbc := range dbcs {
+		c := validation.Contract{
+			ChannelID: dbc.ChannelID,
+			Schema:    dbc.Schema,
+		}
+		if err := json.Unmarshal(dbc.Measurements, &c.Measurements); err != nil {
+			return nil, errors.Wrap(errRetrieveContracts, err)
+		}
+		contracts = append(contracts, c)
+	}
+
+	return contracts, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewRetentionHandler starts the goroutine which periodically removes the
+// messages kept in the quarantine longer than the retention, until the
+// context is canceled. Zero retention keeps the quarantined messages forever.
+func NewRetentionHandler(ctx context.Context, repo Repository, retention, checkInterval time.Duration, logger *slog.Logger) {
+	if retention <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := repo.Remove(ctx, time.Now().Add(-retention)); err != nil {
+					logger.Error("failed to remove expired quarantined messages", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
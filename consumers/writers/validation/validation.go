@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ContractKey is the key of the channel metadata the payload contract of the
+// channel is set with.
+const ContractKey = "payload_contract"
+
+var (
+	// ErrInvalidContract indicates that the payload contract is malformed.
+	ErrInvalidContract = errors.New("invalid payload contract")
+
+	// ErrMalformedPayload indicates that the message payload can not be
+	// transformed.
+	ErrMalformedPayload = errors.New("malformed message payload")
+
+	// ErrContractViolation indicates that the message payload does not
+	// satisfy the contract of its channel.
+	ErrContractViolation = errors.New("message payload violates channel contract")
+)
+
+// Contract defines the payloads accepted on the channel. The payload of each
+// JSON message must satisfy the JSON Schema, and each SenML record must match
+// one of the measurements. Messages of the channels without the contract, or
+// of the format the contract does not constrain, are saved without validation.
+// Only the channels with the contract quarantine the rejected messages.
+type Contract struct {
+	ChannelID    string        `json:"-"`
+	Schema       string        `json:"schema,omitempty"`
+	Measurements []Measurement `json:"measurements,omitempty"`
+}
+
+// Measurement represents the SenML record allowed by the contract. Empty unit
+// allows any unit of the measurement.
+type Measurement struct {
+	Name string `json:"name"`
+	Unit string `json:"unit,omitempty"`
+}
+
+// Message represents the rejected message kept in the quarantine.
+type Message struct {
+	Domain    string `db:"domain"`
+	Channel   string `db:"channel"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+	Reason    string `db:"reason"`
+	Created   int64  `db:"created"`
+}
+
+// Repository specifies the quarantine and the contracts persistence API.
+type Repository interface {
+	// Save persists the rejected message.
+	Save(ctx context.Context, msg Message) error
+
+	// Remove removes the quarantined messages created before the given time.
+	Remove(ctx context.Context, before time.Time) error
+
+	// SaveContract persists the channel contract, replacing the existing one.
+	SaveContract(ctx context.Context, c Contract) error
+
+	// RemoveContract removes the channel contract.
+	RemoveContract(ctx context.Context, channelID string) error
+
+	// RetrieveContracts retrieves the contracts of all the channels.
+	RetrieveContracts(ctx context.Context) ([]Contract, error)
+}
+
+// ContractFromMetadata returns the contract set in the channel metadata. The
+// JSON Schema is set either as the JSON object or as the string. False is
+// returned if the metadata does not set the contract.
+func ContractFromMetadata(channelID string, metadata map[string]any) (Contract, bool, error) {
+	val, ok := metadata[ContractKey]
+	if !ok || val == nil {
+		return Contract{}, false, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return Contract{}, false, errors.Wrap(ErrInvalidContract, err)
+	}
+	var mc struct {
+		Schema       json.RawMessage `json:"schema"`
+		Measurements []Measurement   `json:"measurements"`
+	}
+	if err := json.Unmarshal(data, &mc); err != nil {
+		return Contract{}, false, errors.Wrap(ErrInvalidContract, err)
+	}
+
+	c := Contract{
+		ChannelID:    channelID,
+		Measurements: mc.Measurements,
+	}
+	if len(mc.Schema) > 0 && string(mc.Schema) != "null" {
+		c.Schema = string(mc.Schema)
+		var s string
+		if err := json.Unmarshal(mc.Schema, &s); err == nil {
+			c.Schema = s
+		}
+	}
+
+	return c, true, nil
+}
@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"io"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/journal"
@@ -59,3 +60,69 @@ func retrieveClientTelemetryEndpoint(svc journal.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func retrieveClientsPresenceEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(retrieveClientsPresenceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.RetrieveClientsPresence(ctx, session, req.page)
+		if err != nil {
+			return nil, err
+		}
+
+		return clientsPresenceRes{
+			ClientsPresencePage: page,
+		}, nil
+	}
+}
+
+func verifyChainEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(verifyChainReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		v, err := svc.VerifyChain(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+
+		return verifyChainRes{
+			ChainVerification: v,
+		}, nil
+	}
+}
+
+func exportJournalsEndpoint(svc journal.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(exportJournalsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		return exportRes{
+			stream: func(ctx context.Context, w io.Writer) error {
+				return svc.Export(ctx, session, req.page, w)
+			},
+		}, nil
+	}
+}
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -571,3 +572,303 @@ func TestRetrieveClientTelemetryEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveClientsPresenceEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	presence := journal.ClientsPresencePage{
+		Total: 1,
+		Limit: 10,
+		Clients: []journal.ClientPresence{
+			{
+				ClientID: testsutil.GenerateUUID(t),
+				DomainID: domainID,
+				Online:   true,
+				LastSeen: time.Now().UTC().Truncate(time.Second),
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  smqauthn.Session
+		url      string
+		page     journal.PresencePage
+		response journal.ClientsPresencePage
+		status   int
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:     "retrieve clients presence successfully",
+			token:    validToken,
+			url:      "?limit=10",
+			page:     journal.PresencePage{Limit: 10},
+			response: presence,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "retrieve online clients presence",
+			token:    validToken,
+			url:      "?status=online&offset=0&limit=10",
+			page:     journal.PresencePage{Limit: 10, Status: journal.OnlineStatus},
+			response: presence,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "retrieve clients presence with default page",
+			token:    validToken,
+			page:     journal.PresencePage{Limit: 10},
+			response: journal.ClientsPresencePage{Limit: 10, Clients: []journal.ClientPresence{}},
+			status:   http.StatusOK,
+		},
+		{
+			desc:   "retrieve clients presence with invalid status",
+			token:  validToken,
+			url:    "?status=unknown",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve clients presence with invalid limit",
+			token:  validToken,
+			url:    "?limit=1000",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve clients presence with malformed offset",
+			token:  validToken,
+			url:    "?offset=invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "retrieve clients presence with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:     "retrieve clients presence with invalid token",
+			token:    "invalid",
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "retrieve clients presence with service error",
+			token:  validToken,
+			page:   journal.PresencePage{Limit: 10},
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = smqauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("RetrieveClientsPresence", mock.Anything, c.session, c.page).Return(c.response, c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/presence%s", es.URL, domainID, c.url),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.status == http.StatusOK {
+				var page journal.ClientsPresencePage
+				err := json.NewDecoder(resp.Body).Decode(&page)
+				assert.Nil(t, err, c.desc)
+				assert.Equal(t, c.response, page, c.desc)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestVerifyChainEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc         string
+		token        string
+		session      smqauthn.Session
+		verification journal.ChainVerification
+		status       int
+		authnErr     error
+		svcErr       error
+	}{
+		{
+			desc:         "verify chain successfully",
+			token:        validToken,
+			verification: journal.ChainVerification{Domain: domainID, Valid: true, Verified: 10, HeadSequence: 10},
+			status:       http.StatusOK,
+		},
+		{
+			desc:  "verify broken chain",
+			token: validToken,
+			verification: journal.ChainVerification{
+				Domain:       domainID,
+				Verified:     10,
+				HeadSequence: 11,
+				Violations:   []journal.ChainViolation{{Sequence: 11, Reason: journal.MissingEntries}},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "verify chain with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:     "verify chain with invalid token",
+			token:    "invalid",
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "verify chain with service error",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = smqauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("VerifyChain", mock.Anything, c.session).Return(c.verification, c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/verify", es.URL, domainID),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.status == http.StatusOK {
+				var v journal.ChainVerification
+				err := json.NewDecoder(resp.Body).Decode(&v)
+				assert.Nil(t, err, c.desc)
+				assert.Equal(t, c.verification, v, c.desc)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestExportJournalsEndpoint(t *testing.T) {
+	es, svc, authn := newjournalServer()
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	from := time.Now().Add(-time.Hour).Unix()
+	to := time.Now().Unix()
+	export := "{\"id\":\"1\"}\n{\"signature\":\"sig\"}\n"
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  smqauthn.Session
+		url      string
+		page     journal.ChainPage
+		status   int
+		body     string
+		authnErr error
+		svcErr   error
+	}{
+		{
+			desc:   "export journals successfully",
+			token:  validToken,
+			url:    fmt.Sprintf("?from=%d&to=%d", from, to),
+			page:   journal.ChainPage{From: time.Unix(from, 0), To: time.Unix(to, 0)},
+			status: http.StatusOK,
+			body:   export,
+		},
+		{
+			desc:   "export all journals successfully",
+			token:  validToken,
+			status: http.StatusOK,
+			body:   export,
+		},
+		{
+			desc:   "export journals with empty token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "export journals with invalid from",
+			token:  validToken,
+			url:    "?from=invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export journals with to before from",
+			token:  validToken,
+			url:    fmt.Sprintf("?from=%d&to=%d", to, from),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export journals with service error",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if c.token == validToken {
+				c.session = smqauthn.Session{
+					UserID:       userID,
+					DomainID:     domainID,
+					DomainUserID: domainID + "_" + userID,
+				}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, c.token).Return(c.session, c.authnErr)
+			svcCall := svc.On("Export", mock.Anything, c.session, c.page, mock.Anything).Run(func(args mock.Arguments) {
+				if c.svcErr == nil {
+					_, _ = io.WriteString(args.Get(3).(io.Writer), c.body)
+				}
+			}).Return(c.svcErr)
+			req := testRequest{
+				client: es.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/journal/export%s", es.URL, domainID, c.url),
+				token:  c.token,
+			}
+			resp, err := req.make()
+			assert.Nil(t, err, c.desc)
+			defer resp.Body.Close()
+			assert.Equal(t, c.status, resp.StatusCode, c.desc)
+			if c.status == http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				assert.Nil(t, err, c.desc)
+				assert.Equal(t, c.body, string(body), c.desc)
+				assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"), c.desc)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
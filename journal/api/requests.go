@@ -7,6 +7,7 @@ import (
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/journal"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 )
 
 type retrieveJournalsReq struct {
@@ -42,3 +43,52 @@ func (req retrieveClientTelemetryReq) validate() error {
 
 	return nil
 }
+
+type retrieveClientsPresenceReq struct {
+	token string
+	page  journal.PresencePage
+}
+
+func (req retrieveClientsPresenceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.page.Limit > api.DefLimit {
+		return apiutil.ErrLimitSize
+	}
+	switch req.page.Status {
+	case "", journal.OnlineStatus, journal.OfflineStatus:
+	default:
+		return svcerr.ErrInvalidStatus
+	}
+
+	return nil
+}
+
+type verifyChainReq struct {
+	token string
+}
+
+func (req verifyChainReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type exportJournalsReq struct {
+	token string
+	page  journal.ChainPage
+}
+
+func (req exportJournalsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if !req.page.From.IsZero() && !req.page.To.IsZero() && req.page.To.Before(req.page.From) {
+		return apiutil.ErrInvalidTimeFormat
+	}
+
+	return nil
+}
@@ -5,10 +5,12 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/journal"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -154,3 +156,106 @@ func TestRetrieveClientTelemetryReqValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveClientsPresenceReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  retrieveClientsPresenceReq
+		err  error
+	}{
+		{
+			desc: "valid",
+			req: retrieveClientsPresenceReq{
+				token: token,
+				page:  journal.PresencePage{Limit: 10},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid with status",
+			req: retrieveClientsPresenceReq{
+				token: token,
+				page:  journal.PresencePage{Limit: 10, Status: journal.OfflineStatus},
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: retrieveClientsPresenceReq{
+				page: journal.PresencePage{Limit: 10},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "invalid limit size",
+			req: retrieveClientsPresenceReq{
+				token: token,
+				page:  journal.PresencePage{Limit: api.DefLimit + 1},
+			},
+			err: apiutil.ErrLimitSize,
+		},
+		{
+			desc: "invalid status",
+			req: retrieveClientsPresenceReq{
+				token: token,
+				page:  journal.PresencePage{Limit: 10, Status: "invalid"},
+			},
+			err: svcerr.ErrInvalidStatus,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.req.validate()
+			assert.Equal(t, c.err, err)
+		})
+	}
+}
+
+func TestExportJournalsReqValidate(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		desc string
+		req  exportJournalsReq
+		err  error
+	}{
+		{
+			desc: "valid",
+			req: exportJournalsReq{
+				token: token,
+				page:  journal.ChainPage{From: now.Add(-time.Hour), To: now},
+			},
+			err: nil,
+		},
+		{
+			desc: "valid without range",
+			req: exportJournalsReq{
+				token: token,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty token",
+			req: exportJournalsReq{
+				page: journal.ChainPage{From: now.Add(-time.Hour), To: now},
+			},
+			err: apiutil.ErrBearerToken,
+		},
+		{
+			desc: "to before from",
+			req: exportJournalsReq{
+				token: token,
+				page:  journal.ChainPage{From: now, To: now.Add(-time.Hour)},
+			},
+			err: apiutil.ErrInvalidTimeFormat,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.req.validate()
+			assert.Equal(t, c.err, err)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"context"
+	"io"
 	"net/http"
 
 	"github.com/absmach/supermq"
@@ -13,6 +15,8 @@ import (
 var (
 	_ supermq.Response = (*pageRes)(nil)
 	_ supermq.Response = (*clientTelemetryRes)(nil)
+	_ supermq.Response = (*clientsPresenceRes)(nil)
+	_ supermq.Response = (*verifyChainRes)(nil)
 )
 
 type pageRes struct {
@@ -46,3 +50,41 @@ func (res clientTelemetryRes) Code() int {
 func (res clientTelemetryRes) Empty() bool {
 	return false
 }
+
+type clientsPresenceRes struct {
+	journal.ClientsPresencePage `json:",inline"`
+}
+
+func (res clientsPresenceRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res clientsPresenceRes) Code() int {
+	return http.StatusOK
+}
+
+func (res clientsPresenceRes) Empty() bool {
+	return false
+}
+
+type verifyChainRes struct {
+	journal.ChainVerification `json:",inline"`
+}
+
+func (res verifyChainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res verifyChainRes) Code() int {
+	return http.StatusOK
+}
+
+func (res verifyChainRes) Empty() bool {
+	return false
+}
+
+// exportRes streams the journals while the response is being encoded, so
+// they are never held in memory all at once.
+type exportRes struct {
+	stream func(ctx context.Context, w io.Writer) error
+}
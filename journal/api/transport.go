@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"math"
 	"net/http"
@@ -29,6 +30,8 @@ const (
 	toKey         = "to"
 	attributesKey = "with_attributes"
 	metadataKey   = "with_metadata"
+
+	ndjsonContentType = "application/x-ndjson"
 )
 
 // MakeHandler returns a HTTP API handler with health check and metrics.
@@ -64,6 +67,27 @@ func MakeHandler(svc journal.Service, authn smqauthn.AuthNMiddleware, logger *sl
 			api.EncodeResponse,
 			opts...,
 		), "view_client_telemetry").ServeHTTP)
+
+		r.Get("/presence", otelhttp.NewHandler(kithttp.NewServer(
+			retrieveClientsPresenceEndpoint(svc),
+			decodeRetrieveClientsPresenceReq,
+			api.EncodeResponse,
+			opts...,
+		), "list_clients_presence").ServeHTTP)
+
+		r.Get("/verify", otelhttp.NewHandler(kithttp.NewServer(
+			verifyChainEndpoint(svc),
+			decodeVerifyChainReq,
+			api.EncodeResponse,
+			opts...,
+		), "verify_journal_chain").ServeHTTP)
+
+		r.Get("/export", otelhttp.NewHandler(kithttp.NewServer(
+			exportJournalsEndpoint(svc),
+			decodeExportJournalsReq,
+			encodeExportResponse,
+			opts...,
+		), "export_journals").ServeHTTP)
 	})
 
 	mux.Get("/health", supermq.Health(svcName, instanceID))
@@ -108,6 +132,76 @@ func decodeRetrieveUserJournalReq(_ context.Context, r *http.Request) (any, erro
 	return req, nil
 }
 
+func decodeRetrieveClientsPresenceReq(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := apiutil.ReadStringQuery(r, api.StatusKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := retrieveClientsPresenceReq{
+		token: apiutil.ExtractBearerToken(r),
+		page: journal.PresencePage{
+			Offset: offset,
+			Limit:  limit,
+			Status: status,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeVerifyChainReq(_ context.Context, r *http.Request) (any, error) {
+	req := verifyChainReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeExportJournalsReq(_ context.Context, r *http.Request) (any, error) {
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := exportJournalsReq{
+		token: apiutil.ExtractBearerToken(r),
+		page: journal.ChainPage{
+			From: from,
+			To:   to,
+		},
+	}
+
+	return req, nil
+}
+
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	t, err := apiutil.ReadNumQuery[int64](r, key, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t > math.MaxInt32 {
+		return time.Time{}, apiutil.ErrInvalidTimeFormat
+	}
+	if t == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(t, 0), nil
+}
+
 func decodePageQuery(r *http.Request) (journal.Page, error) {
 	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
 	if err != nil {
@@ -175,3 +269,29 @@ func decodeRetrieveClientTelemetryReq(_ context.Context, r *http.Request) (any,
 
 	return req, nil
 }
+
+// encodeExportResponse writes the journals as they are read. Once the first
+// journal is streamed the status can't be changed, so later errors abort the
+// response and the client sees an incomplete transfer.
+func encodeExportResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res := response.(exportRes)
+	w.Header().Set("Content-Type", ndjsonContentType)
+
+	sw := &streamWriter{w: w}
+	err := res.stream(ctx, sw)
+	if err != nil && sw.streamed {
+		panic(http.ErrAbortHandler)
+	}
+
+	return err
+}
+
+type streamWriter struct {
+	w        io.Writer
+	streamed bool
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	sw.streamed = true
+	return sw.w.Write(p)
+}
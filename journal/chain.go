@@ -0,0 +1,132 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Reasons of the chain violations.
+const (
+	MissingEntries   = "missing entries"
+	PrevHashMismatch = "previous hash mismatch"
+	HashMismatch     = "hash mismatch"
+	HeadMismatch     = "last entry does not match chain head"
+)
+
+// ChainHead represents the state of the hash chain of the domain. Each domain
+// journal carries the sequence number and the hash of the previous journal of
+// the same domain, so removing, reordering or modifying the journals breaks
+// the chain. Archived sequence and hash belong to the last journal removed by
+// the retention, which anchors the remaining part of the chain.
+type ChainHead struct {
+	Domain           string `json:"domain"            db:"domain"`
+	Sequence         uint64 `json:"sequence"          db:"seq"`
+	Hash             string `json:"hash"              db:"hash"`
+	ArchivedSequence uint64 `json:"archived_sequence" db:"archived_seq"`
+	ArchivedHash     string `json:"archived_hash"     db:"archived_hash"`
+}
+
+// ChainPage is used to retrieve the chained journals of the domain in the
+// sequence order.
+type ChainPage struct {
+	Domain        string    `db:"domain"`
+	AfterSequence uint64    `db:"after_seq"`
+	From          time.Time `db:"from"`
+	To            time.Time `db:"to"`
+	Limit         uint64    `db:"limit"`
+}
+
+// ChainViolation represents the journal at which the chain is broken.
+type ChainViolation struct {
+	Sequence uint64 `json:"sequence"`
+	ID       string `json:"id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// ChainVerification represents the result of the domain chain verification.
+type ChainVerification struct {
+	Domain           string           `json:"domain"`
+	Valid            bool             `json:"valid"`
+	Verified         uint64           `json:"verified"`
+	HeadSequence     uint64           `json:"head_sequence"`
+	ArchivedSequence uint64           `json:"archived_sequence"`
+	Violations       []ChainViolation `json:"violations,omitempty"`
+}
+
+// Chained reports whether the journal is part of the domain hash chain.
+// Messaging telemetry is journaled for every published message, so chaining
+// it would serialize the publishes of the whole domain on the chain head.
+func (j Journal) Chained() bool {
+	switch j.Operation {
+	case messagingPublish, messagingSubscribe, messagingUnsubscribe:
+		return false
+	default:
+		return true
+	}
+}
+
+type chainedJournal struct {
+	Domain     string         `json:"domain"`
+	Sequence   uint64         `json:"sequence"`
+	ID         string         `json:"id"`
+	Operation  string         `json:"operation"`
+	OccurredAt string         `json:"occurred_at"`
+	Attributes map[string]any `json:"attributes"`
+	Metadata   map[string]any `json:"metadata"`
+	PrevHash   string         `json:"prev_hash"`
+}
+
+// ComputeHash returns the hex encoded SHA-256 hash of the journal content,
+// its sequence number and the hash of the previous journal. The attributes
+// and metadata are hashed in their canonical JSON form and the occurrence
+// time with the microsecond precision, so the hash of the stored journal
+// does not depend on the way it is read from the database.
+func (j Journal) ComputeHash() (string, error) {
+	attributes, err := canonicalize(j.Attributes)
+	if err != nil {
+		return "", err
+	}
+	metadata, err := canonicalize(j.Metadata)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(chainedJournal{
+		Domain:     j.Domain,
+		Sequence:   j.Sequence,
+		ID:         j.ID,
+		Operation:  j.Operation,
+		OccurredAt: j.OccurredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		Attributes: attributes,
+		Metadata:   metadata,
+		PrevHash:   j.PrevHash,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalize converts the values to their JSON types, the same way they
+// are decoded after they are read from the database.
+func canonicalize(m map[string]any) (map[string]any, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var ret map[string]any
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
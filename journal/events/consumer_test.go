@@ -51,7 +51,7 @@ func NewTestEvent(data map[string]any, err error) testEvent {
 
 func TestHandle(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	svc := journal.NewService(idProvider, repo, nil, "")
 
 	cases := []struct {
 		desc      string
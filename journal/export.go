@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// SignatureAlgorithm is the algorithm of the export signatures.
+const SignatureAlgorithm = "Ed25519ph"
+
+// ErrInvalidSignature indicates that the export is not signed with the given
+// key or that it is modified after it is signed.
+var ErrInvalidSignature = errors.New("invalid export signature")
+
+// ExportSignature is the last line of the exported NDJSON. It signs the
+// SHA-512 digest of all the preceding lines, one journal per line.
+type ExportSignature struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+	Digest    string `json:"digest"`
+	Signature string `json:"signature"`
+}
+
+// writeSigned writes the journals encoded by the function followed by the
+// signature of everything written before it.
+func writeSigned(w io.Writer, key ed25519.PrivateKey, fn func(enc *json.Encoder) error) error {
+	digest := sha512.New()
+	if err := fn(json.NewEncoder(io.MultiWriter(w, digest))); err != nil {
+		return err
+	}
+	sum := digest.Sum(nil)
+	sig, err := key.Sign(nil, sum, &ed25519.Options{Hash: crypto.SHA512})
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(ExportSignature{
+		Algorithm: SignatureAlgorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Digest:    hex.EncodeToString(sum),
+		Signature: base64.StdEncoding.EncodeToString(sig),
+	})
+}
+
+// VerifyExport checks that the export is signed with the private key of the
+// given public key and that none of its lines is modified, added or removed.
+func VerifyExport(r io.Reader, key ed25519.PublicKey) error {
+	br := bufio.NewReader(r)
+	digest := sha512.New()
+	var last []byte
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if last != nil {
+				digest.Write(last)
+			}
+			last = line
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if last == nil {
+		return errors.Wrap(ErrInvalidSignature, errors.New("missing signature"))
+	}
+
+	var es ExportSignature
+	if err := json.Unmarshal(last, &es); err != nil {
+		return errors.Wrap(ErrInvalidSignature, err)
+	}
+	if es.Algorithm != SignatureAlgorithm {
+		return errors.Wrap(ErrInvalidSignature, errors.New("unsupported algorithm "+es.Algorithm))
+	}
+	sig, err := base64.StdEncoding.DecodeString(es.Signature)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, err)
+	}
+	sum := digest.Sum(nil)
+	if hex.EncodeToString(sum) != es.Digest {
+		return errors.Wrap(ErrInvalidSignature, errors.New("digest mismatch"))
+	}
+	if pk, err := base64.StdEncoding.DecodeString(es.PublicKey); err != nil || !bytes.Equal(pk, key) {
+		return errors.Wrap(ErrInvalidSignature, errors.New("unknown signing key"))
+	}
+	if err := ed25519.VerifyWithOptions(key, sum, sig, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+		return errors.Wrap(ErrInvalidSignature, err)
+	}
+
+	return nil
+}
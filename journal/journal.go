@@ -6,6 +6,7 @@ package journal
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"time"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
@@ -85,6 +86,9 @@ type Journal struct {
 	OccurredAt time.Time      `json:"occurred_at,omitempty" db:"occurred_at,omitempty"`
 	Attributes map[string]any `json:"attributes,omitempty" db:"attributes,omitempty"` // This is extra information about the journal for example client_id, user_id, group_id etc.
 	Metadata   map[string]any `json:"metadata,omitempty" db:"metadata,omitempty"`     // This is decoded metadata from the journal.
+	Sequence   uint64         `json:"sequence,omitempty" db:"seq"`                    // This is the position of the journal in the domain hash chain.
+	PrevHash   string         `json:"prev_hash,omitempty" db:"prev_hash"`
+	Hash       string         `json:"hash,omitempty" db:"hash"`
 }
 
 // JournalsPage represents a page of journals.
@@ -125,15 +129,91 @@ func (page JournalsPage) MarshalJSON() ([]byte, error) {
 }
 
 type ClientTelemetry struct {
-	ClientID         string    `json:"client_id"`
-	DomainID         string    `json:"domain_id"`
-	Subscriptions    uint64    `json:"subscriptions"`
-	InboundMessages  uint64    `json:"inbound_messages"`
-	OutboundMessages uint64    `json:"outbound_messages"`
-	FirstSeen        time.Time `json:"first_seen"`
-	LastSeen         time.Time `json:"last_seen"`
+	ClientID         string             `json:"client_id"`
+	DomainID         string             `json:"domain_id"`
+	Subscriptions    uint64             `json:"subscriptions"`
+	InboundMessages  uint64             `json:"inbound_messages"`
+	OutboundMessages uint64             `json:"outbound_messages"`
+	FirstSeen        time.Time          `json:"first_seen"`
+	LastSeen         time.Time          `json:"last_seen"`
+	Online           bool               `json:"online"`
+	Channels         []ChannelTelemetry `json:"channels,omitempty"`
+	Sessions         []ClientSession    `json:"sessions,omitempty"`
 }
 
+// ChannelTelemetry represents the messages the client published to and
+// received from the channel subtopic.
+type ChannelTelemetry struct {
+	ClientID         string    `json:"-" db:"client_id"`
+	DomainID         string    `json:"-" db:"domain_id"`
+	ChannelID        string    `json:"channel_id" db:"channel_id"`
+	Subtopic         string    `json:"subtopic,omitempty" db:"subtopic"`
+	InboundMessages  uint64    `json:"inbound_messages" db:"inbound_messages"`
+	InboundBytes     uint64    `json:"inbound_bytes" db:"inbound_bytes"`
+	OutboundMessages uint64    `json:"outbound_messages" db:"outbound_messages"`
+	OutboundBytes    uint64    `json:"outbound_bytes" db:"outbound_bytes"`
+	LastMessageAt    time.Time `json:"last_message_at" db:"last_message_at"`
+}
+
+// ClientSession represents the client connection. Zero disconnection time
+// means the session is still open.
+type ClientSession struct {
+	ID             string    `json:"id" db:"id"`
+	ClientID       string    `json:"client_id" db:"client_id"`
+	SubscriberID   string    `json:"subscriber_id" db:"subscriber_id"`
+	Protocol       string    `json:"protocol,omitempty" db:"protocol"`
+	RemoteAddr     string    `json:"remote_addr,omitempty" db:"remote_addr"`
+	ConnectedAt    time.Time `json:"connected_at" db:"connected_at"`
+	DisconnectedAt time.Time `json:"disconnected_at,omitzero" db:"disconnected_at"`
+}
+
+// ClientPresence represents the client online status. Client is online while
+// it has an open session, or until it is inactive for the presence timeout
+// after its last message.
+type ClientPresence struct {
+	ClientID string    `json:"client_id" db:"client_id"`
+	DomainID string    `json:"domain_id" db:"domain_id"`
+	Online   bool      `json:"online" db:"online"`
+	LastSeen time.Time `json:"last_seen" db:"last_seen"`
+}
+
+// PresencePage is used to filter the clients presence. Empty status lists
+// both online and offline clients.
+type PresencePage struct {
+	Offset uint64 `json:"offset" db:"offset"`
+	Limit  uint64 `json:"limit" db:"limit"`
+	Status string `json:"status,omitempty" db:"status"`
+}
+
+// ClientsPresencePage represents a page of the clients presence.
+type ClientsPresencePage struct {
+	Total   uint64           `json:"total"`
+	Offset  uint64           `json:"offset"`
+	Limit   uint64           `json:"limit"`
+	Clients []ClientPresence `json:"clients"`
+}
+
+func (page ClientsPresencePage) MarshalJSON() ([]byte, error) {
+	type Alias ClientsPresencePage
+	a := struct {
+		Alias
+	}{
+		Alias: Alias(page),
+	}
+
+	if a.Clients == nil {
+		a.Clients = make([]ClientPresence, 0)
+	}
+
+	return json.Marshal(a)
+}
+
+// Presence statuses.
+const (
+	OnlineStatus  = "online"
+	OfflineStatus = "offline"
+)
+
 type ClientSubscription struct {
 	ID           string `json:"id" db:"id"`
 	SubscriberID string `json:"subscriber_id" db:"subscriber_id"`
@@ -152,6 +232,26 @@ type Service interface {
 
 	// RetrieveClientTelemetry retrieves telemetry data for a client.
 	RetrieveClientTelemetry(ctx context.Context, session smqauthn.Session, clientID string) (ClientTelemetry, error)
+
+	// RetrieveClientsPresence retrieves the online status of the session
+	// domain clients.
+	RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page PresencePage) (ClientsPresencePage, error)
+
+	// UpdatePresence marks the clients without open sessions which are
+	// inactive since the given time as offline.
+	UpdatePresence(ctx context.Context, inactiveSince time.Time) error
+
+	// VerifyChain verifies the hash chain of the session domain journals and
+	// reports the missing and modified journals.
+	VerifyChain(ctx context.Context, session smqauthn.Session) (ChainVerification, error)
+
+	// Export writes the session domain journals which occurred in the page
+	// time range as the signed NDJSON.
+	Export(ctx context.Context, session smqauthn.Session, page ChainPage, w io.Writer) error
+
+	// Archive archives the journals which occurred before the given time and
+	// removes them, keeping the hash chain anchored to the last removed one.
+	Archive(ctx context.Context, before time.Time) error
 }
 
 // Repository provides access to the journal log database.
@@ -180,9 +280,53 @@ type Repository interface {
 	// RemoveSubscription removes a subscription from the client telemetry.
 	RemoveSubscription(ctx context.Context, subscriberID string) error
 
-	// IncrementInboundMessages increments the inbound messages count for a client.
+	// IncrementInboundMessages increments the inbound messages count for a client
+	// and marks it online.
 	IncrementInboundMessages(ctx context.Context, ct ClientTelemetry) error
 
-	// IncrementOutboundMessages increments the outbound messages count for a client.
-	IncrementOutboundMessages(ctx context.Context, channelID, subtopic string) error
+	// IncrementOutboundMessages increments the outbound messages count of the
+	// clients subscribed to the channel subtopic, in total and per channel.
+	IncrementOutboundMessages(ctx context.Context, channelID, subtopic string, size uint64, at time.Time) error
+
+	// IncrementInboundChannelMessages increments the messages and bytes the
+	// client published to the channel subtopic.
+	IncrementInboundChannelMessages(ctx context.Context, ct ChannelTelemetry) error
+
+	// RetrieveChannelTelemetry retrieves the per channel telemetry of a client.
+	RetrieveChannelTelemetry(ctx context.Context, clientID string) ([]ChannelTelemetry, error)
+
+	// SaveSession persists the client session and marks the client online.
+	SaveSession(ctx context.Context, session ClientSession) error
+
+	// CloseSession sets the disconnection time of the open session of the subscriber.
+	CloseSession(ctx context.Context, subscriberID string, at time.Time) error
+
+	// RetrieveSessions retrieves the latest sessions of a client.
+	RetrieveSessions(ctx context.Context, clientID string, limit uint64) ([]ClientSession, error)
+
+	// MarkOffline marks the online clients without open sessions, which are
+	// inactive since the given time, as offline and returns them. Empty client
+	// ID matches all the clients and zero time ignores the activity.
+	MarkOffline(ctx context.Context, clientID string, inactiveSince time.Time) ([]ClientPresence, error)
+
+	// RetrievePresence retrieves the online status of the domain clients.
+	RetrievePresence(ctx context.Context, domainID string, page PresencePage) (ClientsPresencePage, error)
+
+	// RetrieveChainHead retrieves the hash chain head of the domain.
+	RetrieveChainHead(ctx context.Context, domain string) (ChainHead, error)
+
+	// RetrieveChainHeads retrieves the hash chain heads of all the domains.
+	RetrieveChainHeads(ctx context.Context) ([]ChainHead, error)
+
+	// RetrieveChain retrieves the chained journals of the domain in the
+	// sequence order.
+	RetrieveChain(ctx context.Context, page ChainPage) ([]Journal, error)
+
+	// DeleteChain removes the domain journals up to the given sequence and
+	// anchors the chain to the given hash of the last removed journal.
+	DeleteChain(ctx context.Context, domain string, seq uint64, hash string) error
+
+	// DeleteUnchained removes the journals outside of the hash chains which
+	// occurred before the given time.
+	DeleteUnchained(ctx context.Context, before time.Time) error
 }
@@ -135,3 +135,65 @@ func TestToEntityType(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeHash(t *testing.T) {
+	occurredAt := time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC)
+	j := journal.Journal{
+		ID:         "id",
+		Domain:     "domain",
+		Operation:  "client.create",
+		OccurredAt: occurredAt,
+		Attributes: map[string]any{"count": 5, "tags": []string{"a"}},
+		Sequence:   2,
+		PrevHash:   "prev",
+	}
+	hash, err := j.ComputeHash()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error computing hash: %s", err))
+
+	cases := []struct {
+		desc  string
+		fn    func(j journal.Journal) journal.Journal
+		equal bool
+	}{
+		{
+			desc: "hash of journal read from database",
+			fn: func(j journal.Journal) journal.Journal {
+				j.OccurredAt = occurredAt.Truncate(time.Microsecond).In(time.FixedZone("CET", 3600))
+				j.Attributes = map[string]any{"tags": []any{"a"}, "count": float64(5)}
+				j.Metadata = map[string]any{}
+				j.Hash = "hash"
+				return j
+			},
+			equal: true,
+		},
+		{
+			desc: "hash of journal with modified attributes",
+			fn: func(j journal.Journal) journal.Journal {
+				j.Attributes = map[string]any{"count": 6, "tags": []string{"a"}}
+				return j
+			},
+		},
+		{
+			desc: "hash of journal with modified sequence",
+			fn: func(j journal.Journal) journal.Journal {
+				j.Sequence = 3
+				return j
+			},
+		},
+		{
+			desc: "hash of journal with modified previous hash",
+			fn: func(j journal.Journal) journal.Journal {
+				j.PrevHash = "other"
+				return j
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			h, err := tc.fn(j).ComputeHash()
+			assert.Nil(t, err, fmt.Sprintf("unexpected error computing hash: %s", err))
+			assert.Equal(t, tc.equal, h == hash)
+		})
+	}
+}
@@ -5,6 +5,8 @@ package middleware
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/absmach/supermq/journal"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -15,7 +17,8 @@ import (
 var (
 	_ journal.Service = (*authorizationMiddleware)(nil)
 
-	readPermission = "read_permission"
+	readPermission       = "read_permission"
+	clientReadPermission = "client_read_permission"
 )
 
 type authorizationMiddleware struct {
@@ -82,3 +85,61 @@ func (am *authorizationMiddleware) RetrieveClientTelemetry(ctx context.Context,
 
 	return am.svc.RetrieveClientTelemetry(ctx, session, clientID)
 }
+
+func (am *authorizationMiddleware) RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	req := smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  clientReadPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}
+
+	if err := am.authz.Authorize(ctx, req, nil); err != nil {
+		return journal.ClientsPresencePage{}, err
+	}
+
+	return am.svc.RetrieveClientsPresence(ctx, session, page)
+}
+
+func (am *authorizationMiddleware) UpdatePresence(ctx context.Context, inactiveSince time.Time) error {
+	return am.svc.UpdatePresence(ctx, inactiveSince)
+}
+
+func (am *authorizationMiddleware) VerifyChain(ctx context.Context, session smqauthn.Session) (journal.ChainVerification, error) {
+	if err := am.authorizeDomain(ctx, session); err != nil {
+		return journal.ChainVerification{}, err
+	}
+
+	return am.svc.VerifyChain(ctx, session)
+}
+
+func (am *authorizationMiddleware) Export(ctx context.Context, session smqauthn.Session, page journal.ChainPage, w io.Writer) error {
+	if err := am.authorizeDomain(ctx, session); err != nil {
+		return err
+	}
+
+	return am.svc.Export(ctx, session, page, w)
+}
+
+func (am *authorizationMiddleware) Archive(ctx context.Context, before time.Time) error {
+	return am.svc.Archive(ctx, before)
+}
+
+// authorizeDomain checks that the user is the domain admin, since the hash
+// chain covers the journals of all the domain entities.
+func (am *authorizationMiddleware) authorizeDomain(ctx context.Context, session smqauthn.Session) error {
+	req := smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  policies.AdminPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}
+
+	return am.authz.Authorize(ctx, req, nil)
+}
@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"time"
 
@@ -91,3 +92,106 @@ func (lm *loggingMiddleware) RetrieveClientTelemetry(ctx context.Context, sessio
 
 	return lm.service.RetrieveClientTelemetry(ctx, session, clientID)
 }
+
+func (lm *loggingMiddleware) RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page journal.PresencePage) (cp journal.ClientsPresencePage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("status", page.Status),
+				slog.Uint64("offset", page.Offset),
+				slog.Uint64("limit", page.Limit),
+				slog.Uint64("total", cp.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Retrieve clients presence failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve clients presence completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.RetrieveClientsPresence(ctx, session, page)
+}
+
+func (lm *loggingMiddleware) UpdatePresence(ctx context.Context, inactiveSince time.Time) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Time("inactive_since", inactiveSince),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Update clients presence failed", args...)
+			return
+		}
+		lm.logger.Info("Update clients presence completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.UpdatePresence(ctx, inactiveSince)
+}
+
+func (lm *loggingMiddleware) VerifyChain(ctx context.Context, session smqauthn.Session) (v journal.ChainVerification, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("verification",
+				slog.Bool("valid", v.Valid),
+				slog.Uint64("verified", v.Verified),
+				slog.Int("violations", len(v.Violations)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Verify journal chain failed", args...)
+			return
+		}
+		lm.logger.Info("Verify journal chain completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.VerifyChain(ctx, session)
+}
+
+func (lm *loggingMiddleware) Export(ctx context.Context, session smqauthn.Session, page journal.ChainPage, w io.Writer) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.Time("from", page.From),
+				slog.Time("to", page.To),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Export journals failed", args...)
+			return
+		}
+		lm.logger.Info("Export journals completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.Export(ctx, session, page, w)
+}
+
+func (lm *loggingMiddleware) Archive(ctx context.Context, before time.Time) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Time("before", before),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Archive journals failed", args...)
+			return
+		}
+		lm.logger.Info("Archive journals completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.Archive(ctx, before)
+}
@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/absmach/supermq/journal"
@@ -55,3 +56,48 @@ func (mm *metricsMiddleware) RetrieveClientTelemetry(ctx context.Context, sessio
 
 	return mm.service.RetrieveClientTelemetry(ctx, session, clientID)
 }
+
+func (mm *metricsMiddleware) RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "retrieve_clients_presence").Add(1)
+		mm.latency.With("method", "retrieve_clients_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.RetrieveClientsPresence(ctx, session, page)
+}
+
+func (mm *metricsMiddleware) UpdatePresence(ctx context.Context, inactiveSince time.Time) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_presence").Add(1)
+		mm.latency.With("method", "update_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.UpdatePresence(ctx, inactiveSince)
+}
+
+func (mm *metricsMiddleware) VerifyChain(ctx context.Context, session smqauthn.Session) (journal.ChainVerification, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "verify_chain").Add(1)
+		mm.latency.With("method", "verify_chain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.VerifyChain(ctx, session)
+}
+
+func (mm *metricsMiddleware) Export(ctx context.Context, session smqauthn.Session, page journal.ChainPage, w io.Writer) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export").Add(1)
+		mm.latency.With("method", "export").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.Export(ctx, session, page, w)
+}
+
+func (mm *metricsMiddleware) Archive(ctx context.Context, before time.Time) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "archive").Add(1)
+		mm.latency.With("method", "archive").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.Archive(ctx, before)
+}
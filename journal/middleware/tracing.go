@@ -5,6 +5,8 @@ package middleware
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/absmach/supermq/journal"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -57,3 +59,53 @@ func (tm *tracing) RetrieveClientTelemetry(ctx context.Context, session smqauthn
 
 	return tm.svc.RetrieveClientTelemetry(ctx, session, clientID)
 }
+
+func (tm *tracing) RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "retrieve_clients_presence", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("status", page.Status),
+		attribute.Int64("offset", int64(page.Offset)),
+		attribute.Int64("limit", int64(page.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.RetrieveClientsPresence(ctx, session, page)
+}
+
+func (tm *tracing) UpdatePresence(ctx context.Context, inactiveSince time.Time) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "update_presence", trace.WithAttributes(
+		attribute.String("inactive_since", inactiveSince.String()),
+	))
+	defer span.End()
+
+	return tm.svc.UpdatePresence(ctx, inactiveSince)
+}
+
+func (tm *tracing) VerifyChain(ctx context.Context, session smqauthn.Session) (journal.ChainVerification, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "verify_chain", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+	))
+	defer span.End()
+
+	return tm.svc.VerifyChain(ctx, session)
+}
+
+func (tm *tracing) Export(ctx context.Context, session smqauthn.Session, page journal.ChainPage, w io.Writer) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "export", trace.WithAttributes(
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("from", page.From.String()),
+		attribute.String("to", page.To.String()),
+	))
+	defer span.End()
+
+	return tm.svc.Export(ctx, session, page, w)
+}
+
+func (tm *tracing) Archive(ctx context.Context, before time.Time) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "archive", trace.WithAttributes(
+		attribute.String("before", before.String()),
+	))
+	defer span.End()
+
+	return tm.svc.Archive(ctx, before)
+}
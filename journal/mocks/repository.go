@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/journal"
 	mock "github.com/stretchr/testify/mock"
@@ -99,6 +100,69 @@ func (_c *Repository_AddSubscription_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// CloseSession provides a mock function for the type Repository
+func (_mock *Repository) CloseSession(ctx context.Context, subscriberID string, at time.Time) error {
+	ret := _mock.Called(ctx, subscriberID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, subscriberID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_CloseSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSession'
+type Repository_CloseSession_Call struct {
+	*mock.Call
+}
+
+// CloseSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriberID string
+//   - at time.Time
+func (_e *Repository_Expecter) CloseSession(ctx interface{}, subscriberID interface{}, at interface{}) *Repository_CloseSession_Call {
+	return &Repository_CloseSession_Call{Call: _e.mock.On("CloseSession", ctx, subscriberID, at)}
+}
+
+func (_c *Repository_CloseSession_Call) Run(run func(ctx context.Context, subscriberID string, at time.Time)) *Repository_CloseSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CloseSession_Call) Return(err error) *Repository_CloseSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_CloseSession_Call) RunAndReturn(run func(ctx context.Context, subscriberID string, at time.Time) error) *Repository_CloseSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountSubscriptions provides a mock function for the type Repository
 func (_mock *Repository) CountSubscriptions(ctx context.Context, clientID string) (uint64, error) {
 	ret := _mock.Called(ctx, clientID)
@@ -165,6 +229,75 @@ func (_c *Repository_CountSubscriptions_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// DeleteChain provides a mock function for the type Repository
+func (_mock *Repository) DeleteChain(ctx context.Context, domain string, seq uint64, hash string) error {
+	ret := _mock.Called(ctx, domain, seq, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChain")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, string) error); ok {
+		r0 = returnFunc(ctx, domain, seq, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChain'
+type Repository_DeleteChain_Call struct {
+	*mock.Call
+}
+
+// DeleteChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - seq uint64
+//   - hash string
+func (_e *Repository_Expecter) DeleteChain(ctx interface{}, domain interface{}, seq interface{}, hash interface{}) *Repository_DeleteChain_Call {
+	return &Repository_DeleteChain_Call{Call: _e.mock.On("DeleteChain", ctx, domain, seq, hash)}
+}
+
+func (_c *Repository_DeleteChain_Call) Run(run func(ctx context.Context, domain string, seq uint64, hash string)) *Repository_DeleteChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteChain_Call) Return(err error) *Repository_DeleteChain_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteChain_Call) RunAndReturn(run func(ctx context.Context, domain string, seq uint64, hash string) error) *Repository_DeleteChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteClientTelemetry provides a mock function for the type Repository
 func (_mock *Repository) DeleteClientTelemetry(ctx context.Context, clientID string, domainID string) error {
 	ret := _mock.Called(ctx, clientID, domainID)
@@ -228,6 +361,120 @@ func (_c *Repository_DeleteClientTelemetry_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// DeleteUnchained provides a mock function for the type Repository
+func (_mock *Repository) DeleteUnchained(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnchained")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteUnchained_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnchained'
+type Repository_DeleteUnchained_Call struct {
+	*mock.Call
+}
+
+// DeleteUnchained is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *Repository_Expecter) DeleteUnchained(ctx interface{}, before interface{}) *Repository_DeleteUnchained_Call {
+	return &Repository_DeleteUnchained_Call{Call: _e.mock.On("DeleteUnchained", ctx, before)}
+}
+
+func (_c *Repository_DeleteUnchained_Call) Run(run func(ctx context.Context, before time.Time)) *Repository_DeleteUnchained_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteUnchained_Call) Return(err error) *Repository_DeleteUnchained_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteUnchained_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Repository_DeleteUnchained_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementInboundChannelMessages provides a mock function for the type Repository
+func (_mock *Repository) IncrementInboundChannelMessages(ctx context.Context, ct journal.ChannelTelemetry) error {
+	ret := _mock.Called(ctx, ct)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementInboundChannelMessages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, journal.ChannelTelemetry) error); ok {
+		r0 = returnFunc(ctx, ct)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_IncrementInboundChannelMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementInboundChannelMessages'
+type Repository_IncrementInboundChannelMessages_Call struct {
+	*mock.Call
+}
+
+// IncrementInboundChannelMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ct journal.ChannelTelemetry
+func (_e *Repository_Expecter) IncrementInboundChannelMessages(ctx interface{}, ct interface{}) *Repository_IncrementInboundChannelMessages_Call {
+	return &Repository_IncrementInboundChannelMessages_Call{Call: _e.mock.On("IncrementInboundChannelMessages", ctx, ct)}
+}
+
+func (_c *Repository_IncrementInboundChannelMessages_Call) Run(run func(ctx context.Context, ct journal.ChannelTelemetry)) *Repository_IncrementInboundChannelMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 journal.ChannelTelemetry
+		if args[1] != nil {
+			arg1 = args[1].(journal.ChannelTelemetry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_IncrementInboundChannelMessages_Call) Return(err error) *Repository_IncrementInboundChannelMessages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_IncrementInboundChannelMessages_Call) RunAndReturn(run func(ctx context.Context, ct journal.ChannelTelemetry) error) *Repository_IncrementInboundChannelMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IncrementInboundMessages provides a mock function for the type Repository
 func (_mock *Repository) IncrementInboundMessages(ctx context.Context, ct journal.ClientTelemetry) error {
 	ret := _mock.Called(ctx, ct)
@@ -286,16 +533,16 @@ func (_c *Repository_IncrementInboundMessages_Call) RunAndReturn(run func(ctx co
 }
 
 // IncrementOutboundMessages provides a mock function for the type Repository
-func (_mock *Repository) IncrementOutboundMessages(ctx context.Context, channelID string, subtopic string) error {
-	ret := _mock.Called(ctx, channelID, subtopic)
+func (_mock *Repository) IncrementOutboundMessages(ctx context.Context, channelID string, subtopic string, size uint64, at time.Time) error {
+	ret := _mock.Called(ctx, channelID, subtopic, size, at)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IncrementOutboundMessages")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, channelID, subtopic)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, uint64, time.Time) error); ok {
+		r0 = returnFunc(ctx, channelID, subtopic, size, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -311,11 +558,13 @@ type Repository_IncrementOutboundMessages_Call struct {
 //   - ctx context.Context
 //   - channelID string
 //   - subtopic string
-func (_e *Repository_Expecter) IncrementOutboundMessages(ctx interface{}, channelID interface{}, subtopic interface{}) *Repository_IncrementOutboundMessages_Call {
-	return &Repository_IncrementOutboundMessages_Call{Call: _e.mock.On("IncrementOutboundMessages", ctx, channelID, subtopic)}
+//   - size uint64
+//   - at time.Time
+func (_e *Repository_Expecter) IncrementOutboundMessages(ctx interface{}, channelID interface{}, subtopic interface{}, size interface{}, at interface{}) *Repository_IncrementOutboundMessages_Call {
+	return &Repository_IncrementOutboundMessages_Call{Call: _e.mock.On("IncrementOutboundMessages", ctx, channelID, subtopic, size, at)}
 }
 
-func (_c *Repository_IncrementOutboundMessages_Call) Run(run func(ctx context.Context, channelID string, subtopic string)) *Repository_IncrementOutboundMessages_Call {
+func (_c *Repository_IncrementOutboundMessages_Call) Run(run func(ctx context.Context, channelID string, subtopic string, size uint64, at time.Time)) *Repository_IncrementOutboundMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -329,10 +578,20 @@ func (_c *Repository_IncrementOutboundMessages_Call) Run(run func(ctx context.Co
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -343,7 +602,81 @@ func (_c *Repository_IncrementOutboundMessages_Call) Return(err error) *Reposito
 	return _c
 }
 
-func (_c *Repository_IncrementOutboundMessages_Call) RunAndReturn(run func(ctx context.Context, channelID string, subtopic string) error) *Repository_IncrementOutboundMessages_Call {
+func (_c *Repository_IncrementOutboundMessages_Call) RunAndReturn(run func(ctx context.Context, channelID string, subtopic string, size uint64, at time.Time) error) *Repository_IncrementOutboundMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOffline provides a mock function for the type Repository
+func (_mock *Repository) MarkOffline(ctx context.Context, clientID string, inactiveSince time.Time) ([]journal.ClientPresence, error) {
+	ret := _mock.Called(ctx, clientID, inactiveSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOffline")
+	}
+
+	var r0 []journal.ClientPresence
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]journal.ClientPresence, error)); ok {
+		return returnFunc(ctx, clientID, inactiveSince)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []journal.ClientPresence); ok {
+		r0 = returnFunc(ctx, clientID, inactiveSince)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.ClientPresence)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, clientID, inactiveSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_MarkOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOffline'
+type Repository_MarkOffline_Call struct {
+	*mock.Call
+}
+
+// MarkOffline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - inactiveSince time.Time
+func (_e *Repository_Expecter) MarkOffline(ctx interface{}, clientID interface{}, inactiveSince interface{}) *Repository_MarkOffline_Call {
+	return &Repository_MarkOffline_Call{Call: _e.mock.On("MarkOffline", ctx, clientID, inactiveSince)}
+}
+
+func (_c *Repository_MarkOffline_Call) Run(run func(ctx context.Context, clientID string, inactiveSince time.Time)) *Repository_MarkOffline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_MarkOffline_Call) Return(clientPresences []journal.ClientPresence, err error) *Repository_MarkOffline_Call {
+	_c.Call.Return(clientPresences, err)
+	return _c
+}
+
+func (_c *Repository_MarkOffline_Call) RunAndReturn(run func(ctx context.Context, clientID string, inactiveSince time.Time) ([]journal.ClientPresence, error)) *Repository_MarkOffline_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -471,58 +804,322 @@ func (_c *Repository_RetrieveAll_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
-// RetrieveClientTelemetry provides a mock function for the type Repository
-func (_mock *Repository) RetrieveClientTelemetry(ctx context.Context, clientID string, domainID string) (journal.ClientTelemetry, error) {
-	ret := _mock.Called(ctx, clientID, domainID)
+// RetrieveChain provides a mock function for the type Repository
+func (_mock *Repository) RetrieveChain(ctx context.Context, page journal.ChainPage) ([]journal.Journal, error) {
+	ret := _mock.Called(ctx, page)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveClientTelemetry")
+		panic("no return value specified for RetrieveChain")
 	}
 
-	var r0 journal.ClientTelemetry
+	var r0 []journal.Journal
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (journal.ClientTelemetry, error)); ok {
-		return returnFunc(ctx, clientID, domainID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, journal.ChainPage) ([]journal.Journal, error)); ok {
+		return returnFunc(ctx, page)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) journal.ClientTelemetry); ok {
-		r0 = returnFunc(ctx, clientID, domainID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, journal.ChainPage) []journal.Journal); ok {
+		r0 = returnFunc(ctx, page)
 	} else {
-		r0 = ret.Get(0).(journal.ClientTelemetry)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.Journal)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, clientID, domainID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, journal.ChainPage) error); ok {
+		r1 = returnFunc(ctx, page)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_RetrieveClientTelemetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveClientTelemetry'
-type Repository_RetrieveClientTelemetry_Call struct {
+// Repository_RetrieveChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveChain'
+type Repository_RetrieveChain_Call struct {
 	*mock.Call
 }
 
-// RetrieveClientTelemetry is a helper method to define mock.On call
+// RetrieveChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - clientID string
-//   - domainID string
-func (_e *Repository_Expecter) RetrieveClientTelemetry(ctx interface{}, clientID interface{}, domainID interface{}) *Repository_RetrieveClientTelemetry_Call {
-	return &Repository_RetrieveClientTelemetry_Call{Call: _e.mock.On("RetrieveClientTelemetry", ctx, clientID, domainID)}
+//   - page journal.ChainPage
+func (_e *Repository_Expecter) RetrieveChain(ctx interface{}, page interface{}) *Repository_RetrieveChain_Call {
+	return &Repository_RetrieveChain_Call{Call: _e.mock.On("RetrieveChain", ctx, page)}
 }
 
-func (_c *Repository_RetrieveClientTelemetry_Call) Run(run func(ctx context.Context, clientID string, domainID string)) *Repository_RetrieveClientTelemetry_Call {
+func (_c *Repository_RetrieveChain_Call) Run(run func(ctx context.Context, page journal.ChainPage)) *Repository_RetrieveChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 journal.ChainPage
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(journal.ChainPage)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveChain_Call) Return(journals []journal.Journal, err error) *Repository_RetrieveChain_Call {
+	_c.Call.Return(journals, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveChain_Call) RunAndReturn(run func(ctx context.Context, page journal.ChainPage) ([]journal.Journal, error)) *Repository_RetrieveChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveChainHead provides a mock function for the type Repository
+func (_mock *Repository) RetrieveChainHead(ctx context.Context, domain string) (journal.ChainHead, error) {
+	ret := _mock.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChainHead")
+	}
+
+	var r0 journal.ChainHead
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (journal.ChainHead, error)); ok {
+		return returnFunc(ctx, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) journal.ChainHead); ok {
+		r0 = returnFunc(ctx, domain)
+	} else {
+		r0 = ret.Get(0).(journal.ChainHead)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveChainHead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveChainHead'
+type Repository_RetrieveChainHead_Call struct {
+	*mock.Call
+}
+
+// RetrieveChainHead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+func (_e *Repository_Expecter) RetrieveChainHead(ctx interface{}, domain interface{}) *Repository_RetrieveChainHead_Call {
+	return &Repository_RetrieveChainHead_Call{Call: _e.mock.On("RetrieveChainHead", ctx, domain)}
+}
+
+func (_c *Repository_RetrieveChainHead_Call) Run(run func(ctx context.Context, domain string)) *Repository_RetrieveChainHead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveChainHead_Call) Return(chainHead journal.ChainHead, err error) *Repository_RetrieveChainHead_Call {
+	_c.Call.Return(chainHead, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveChainHead_Call) RunAndReturn(run func(ctx context.Context, domain string) (journal.ChainHead, error)) *Repository_RetrieveChainHead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveChainHeads provides a mock function for the type Repository
+func (_mock *Repository) RetrieveChainHeads(ctx context.Context) ([]journal.ChainHead, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChainHeads")
+	}
+
+	var r0 []journal.ChainHead
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]journal.ChainHead, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []journal.ChainHead); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.ChainHead)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveChainHeads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveChainHeads'
+type Repository_RetrieveChainHeads_Call struct {
+	*mock.Call
+}
+
+// RetrieveChainHeads is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) RetrieveChainHeads(ctx interface{}) *Repository_RetrieveChainHeads_Call {
+	return &Repository_RetrieveChainHeads_Call{Call: _e.mock.On("RetrieveChainHeads", ctx)}
+}
+
+func (_c *Repository_RetrieveChainHeads_Call) Run(run func(ctx context.Context)) *Repository_RetrieveChainHeads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveChainHeads_Call) Return(chainHeads []journal.ChainHead, err error) *Repository_RetrieveChainHeads_Call {
+	_c.Call.Return(chainHeads, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveChainHeads_Call) RunAndReturn(run func(ctx context.Context) ([]journal.ChainHead, error)) *Repository_RetrieveChainHeads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveChannelTelemetry provides a mock function for the type Repository
+func (_mock *Repository) RetrieveChannelTelemetry(ctx context.Context, clientID string) ([]journal.ChannelTelemetry, error) {
+	ret := _mock.Called(ctx, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChannelTelemetry")
+	}
+
+	var r0 []journal.ChannelTelemetry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]journal.ChannelTelemetry, error)); ok {
+		return returnFunc(ctx, clientID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []journal.ChannelTelemetry); ok {
+		r0 = returnFunc(ctx, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.ChannelTelemetry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveChannelTelemetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveChannelTelemetry'
+type Repository_RetrieveChannelTelemetry_Call struct {
+	*mock.Call
+}
+
+// RetrieveChannelTelemetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *Repository_Expecter) RetrieveChannelTelemetry(ctx interface{}, clientID interface{}) *Repository_RetrieveChannelTelemetry_Call {
+	return &Repository_RetrieveChannelTelemetry_Call{Call: _e.mock.On("RetrieveChannelTelemetry", ctx, clientID)}
+}
+
+func (_c *Repository_RetrieveChannelTelemetry_Call) Run(run func(ctx context.Context, clientID string)) *Repository_RetrieveChannelTelemetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveChannelTelemetry_Call) Return(channelTelemetrys []journal.ChannelTelemetry, err error) *Repository_RetrieveChannelTelemetry_Call {
+	_c.Call.Return(channelTelemetrys, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveChannelTelemetry_Call) RunAndReturn(run func(ctx context.Context, clientID string) ([]journal.ChannelTelemetry, error)) *Repository_RetrieveChannelTelemetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveClientTelemetry provides a mock function for the type Repository
+func (_mock *Repository) RetrieveClientTelemetry(ctx context.Context, clientID string, domainID string) (journal.ClientTelemetry, error) {
+	ret := _mock.Called(ctx, clientID, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveClientTelemetry")
+	}
+
+	var r0 journal.ClientTelemetry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (journal.ClientTelemetry, error)); ok {
+		return returnFunc(ctx, clientID, domainID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) journal.ClientTelemetry); ok {
+		r0 = returnFunc(ctx, clientID, domainID)
+	} else {
+		r0 = ret.Get(0).(journal.ClientTelemetry)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, clientID, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveClientTelemetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveClientTelemetry'
+type Repository_RetrieveClientTelemetry_Call struct {
+	*mock.Call
+}
+
+// RetrieveClientTelemetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - domainID string
+func (_e *Repository_Expecter) RetrieveClientTelemetry(ctx interface{}, clientID interface{}, domainID interface{}) *Repository_RetrieveClientTelemetry_Call {
+	return &Repository_RetrieveClientTelemetry_Call{Call: _e.mock.On("RetrieveClientTelemetry", ctx, clientID, domainID)}
+}
+
+func (_c *Repository_RetrieveClientTelemetry_Call) Run(run func(ctx context.Context, clientID string, domainID string)) *Repository_RetrieveClientTelemetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -543,6 +1140,152 @@ func (_c *Repository_RetrieveClientTelemetry_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// RetrievePresence provides a mock function for the type Repository
+func (_mock *Repository) RetrievePresence(ctx context.Context, domainID string, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	ret := _mock.Called(ctx, domainID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePresence")
+	}
+
+	var r0 journal.ClientsPresencePage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, journal.PresencePage) (journal.ClientsPresencePage, error)); ok {
+		return returnFunc(ctx, domainID, page)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, journal.PresencePage) journal.ClientsPresencePage); ok {
+		r0 = returnFunc(ctx, domainID, page)
+	} else {
+		r0 = ret.Get(0).(journal.ClientsPresencePage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, journal.PresencePage) error); ok {
+		r1 = returnFunc(ctx, domainID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrievePresence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrievePresence'
+type Repository_RetrievePresence_Call struct {
+	*mock.Call
+}
+
+// RetrievePresence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID string
+//   - page journal.PresencePage
+func (_e *Repository_Expecter) RetrievePresence(ctx interface{}, domainID interface{}, page interface{}) *Repository_RetrievePresence_Call {
+	return &Repository_RetrievePresence_Call{Call: _e.mock.On("RetrievePresence", ctx, domainID, page)}
+}
+
+func (_c *Repository_RetrievePresence_Call) Run(run func(ctx context.Context, domainID string, page journal.PresencePage)) *Repository_RetrievePresence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 journal.PresencePage
+		if args[2] != nil {
+			arg2 = args[2].(journal.PresencePage)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrievePresence_Call) Return(clientsPresencePage journal.ClientsPresencePage, err error) *Repository_RetrievePresence_Call {
+	_c.Call.Return(clientsPresencePage, err)
+	return _c
+}
+
+func (_c *Repository_RetrievePresence_Call) RunAndReturn(run func(ctx context.Context, domainID string, page journal.PresencePage) (journal.ClientsPresencePage, error)) *Repository_RetrievePresence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetrieveSessions provides a mock function for the type Repository
+func (_mock *Repository) RetrieveSessions(ctx context.Context, clientID string, limit uint64) ([]journal.ClientSession, error) {
+	ret := _mock.Called(ctx, clientID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSessions")
+	}
+
+	var r0 []journal.ClientSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) ([]journal.ClientSession, error)); ok {
+		return returnFunc(ctx, clientID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) []journal.ClientSession); ok {
+		r0 = returnFunc(ctx, clientID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]journal.ClientSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = returnFunc(ctx, clientID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveSessions'
+type Repository_RetrieveSessions_Call struct {
+	*mock.Call
+}
+
+// RetrieveSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - limit uint64
+func (_e *Repository_Expecter) RetrieveSessions(ctx interface{}, clientID interface{}, limit interface{}) *Repository_RetrieveSessions_Call {
+	return &Repository_RetrieveSessions_Call{Call: _e.mock.On("RetrieveSessions", ctx, clientID, limit)}
+}
+
+func (_c *Repository_RetrieveSessions_Call) Run(run func(ctx context.Context, clientID string, limit uint64)) *Repository_RetrieveSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveSessions_Call) Return(clientSessions []journal.ClientSession, err error) *Repository_RetrieveSessions_Call {
+	_c.Call.Return(clientSessions, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveSessions_Call) RunAndReturn(run func(ctx context.Context, clientID string, limit uint64) ([]journal.ClientSession, error)) *Repository_RetrieveSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type Repository
 func (_mock *Repository) Save(ctx context.Context, journal1 journal.Journal) error {
 	ret := _mock.Called(ctx, journal1)
@@ -656,3 +1399,60 @@ func (_c *Repository_SaveClientTelemetry_Call) RunAndReturn(run func(ctx context
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveSession provides a mock function for the type Repository
+func (_mock *Repository) SaveSession(ctx context.Context, session journal.ClientSession) error {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, journal.ClientSession) error); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_SaveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSession'
+type Repository_SaveSession_Call struct {
+	*mock.Call
+}
+
+// SaveSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session journal.ClientSession
+func (_e *Repository_Expecter) SaveSession(ctx interface{}, session interface{}) *Repository_SaveSession_Call {
+	return &Repository_SaveSession_Call{Call: _e.mock.On("SaveSession", ctx, session)}
+}
+
+func (_c *Repository_SaveSession_Call) Run(run func(ctx context.Context, session journal.ClientSession)) *Repository_SaveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 journal.ClientSession
+		if args[1] != nil {
+			arg1 = args[1].(journal.ClientSession)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SaveSession_Call) Return(err error) *Repository_SaveSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_SaveSession_Call) RunAndReturn(run func(ctx context.Context, session journal.ClientSession) error) *Repository_SaveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
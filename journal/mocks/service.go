@@ -10,6 +10,8 @@ package mocks
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/absmach/supermq/journal"
 	"github.com/absmach/supermq/pkg/authn"
@@ -43,6 +45,132 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// Archive provides a mock function for the type Service
+func (_mock *Service) Archive(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type Service_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *Service_Expecter) Archive(ctx interface{}, before interface{}) *Service_Archive_Call {
+	return &Service_Archive_Call{Call: _e.mock.On("Archive", ctx, before)}
+}
+
+func (_c *Service_Archive_Call) Run(run func(ctx context.Context, before time.Time)) *Service_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Archive_Call) Return(err error) *Service_Archive_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Archive_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Service_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function for the type Service
+func (_mock *Service) Export(ctx context.Context, session authn.Session, page journal.ChainPage, w io.Writer) error {
+	ret := _mock.Called(ctx, session, page, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, journal.ChainPage, io.Writer) error); ok {
+		r0 = returnFunc(ctx, session, page, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type Service_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - page journal.ChainPage
+//   - w io.Writer
+func (_e *Service_Expecter) Export(ctx interface{}, session interface{}, page interface{}, w interface{}) *Service_Export_Call {
+	return &Service_Export_Call{Call: _e.mock.On("Export", ctx, session, page, w)}
+}
+
+func (_c *Service_Export_Call) Run(run func(ctx context.Context, session authn.Session, page journal.ChainPage, w io.Writer)) *Service_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 journal.ChainPage
+		if args[2] != nil {
+			arg2 = args[2].(journal.ChainPage)
+		}
+		var arg3 io.Writer
+		if args[3] != nil {
+			arg3 = args[3].(io.Writer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Export_Call) Return(err error) *Service_Export_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Export_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, page journal.ChainPage, w io.Writer) error) *Service_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveAll provides a mock function for the type Service
 func (_mock *Service) RetrieveAll(ctx context.Context, session authn.Session, page journal.Page) (journal.JournalsPage, error) {
 	ret := _mock.Called(ctx, session, page)
@@ -187,6 +315,78 @@ func (_c *Service_RetrieveClientTelemetry_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// RetrieveClientsPresence provides a mock function for the type Service
+func (_mock *Service) RetrieveClientsPresence(ctx context.Context, session authn.Session, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	ret := _mock.Called(ctx, session, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveClientsPresence")
+	}
+
+	var r0 journal.ClientsPresencePage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, journal.PresencePage) (journal.ClientsPresencePage, error)); ok {
+		return returnFunc(ctx, session, page)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, journal.PresencePage) journal.ClientsPresencePage); ok {
+		r0 = returnFunc(ctx, session, page)
+	} else {
+		r0 = ret.Get(0).(journal.ClientsPresencePage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, journal.PresencePage) error); ok {
+		r1 = returnFunc(ctx, session, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_RetrieveClientsPresence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveClientsPresence'
+type Service_RetrieveClientsPresence_Call struct {
+	*mock.Call
+}
+
+// RetrieveClientsPresence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - page journal.PresencePage
+func (_e *Service_Expecter) RetrieveClientsPresence(ctx interface{}, session interface{}, page interface{}) *Service_RetrieveClientsPresence_Call {
+	return &Service_RetrieveClientsPresence_Call{Call: _e.mock.On("RetrieveClientsPresence", ctx, session, page)}
+}
+
+func (_c *Service_RetrieveClientsPresence_Call) Run(run func(ctx context.Context, session authn.Session, page journal.PresencePage)) *Service_RetrieveClientsPresence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 journal.PresencePage
+		if args[2] != nil {
+			arg2 = args[2].(journal.PresencePage)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RetrieveClientsPresence_Call) Return(clientsPresencePage journal.ClientsPresencePage, err error) *Service_RetrieveClientsPresence_Call {
+	_c.Call.Return(clientsPresencePage, err)
+	return _c
+}
+
+func (_c *Service_RetrieveClientsPresence_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, page journal.PresencePage) (journal.ClientsPresencePage, error)) *Service_RetrieveClientsPresence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type Service
 func (_mock *Service) Save(ctx context.Context, journal1 journal.Journal) error {
 	ret := _mock.Called(ctx, journal1)
@@ -243,3 +443,126 @@ func (_c *Service_Save_Call) RunAndReturn(run func(ctx context.Context, journal1
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdatePresence provides a mock function for the type Service
+func (_mock *Service) UpdatePresence(ctx context.Context, inactiveSince time.Time) error {
+	ret := _mock.Called(ctx, inactiveSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePresence")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, inactiveSince)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_UpdatePresence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePresence'
+type Service_UpdatePresence_Call struct {
+	*mock.Call
+}
+
+// UpdatePresence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inactiveSince time.Time
+func (_e *Service_Expecter) UpdatePresence(ctx interface{}, inactiveSince interface{}) *Service_UpdatePresence_Call {
+	return &Service_UpdatePresence_Call{Call: _e.mock.On("UpdatePresence", ctx, inactiveSince)}
+}
+
+func (_c *Service_UpdatePresence_Call) Run(run func(ctx context.Context, inactiveSince time.Time)) *Service_UpdatePresence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_UpdatePresence_Call) Return(err error) *Service_UpdatePresence_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_UpdatePresence_Call) RunAndReturn(run func(ctx context.Context, inactiveSince time.Time) error) *Service_UpdatePresence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyChain provides a mock function for the type Service
+func (_mock *Service) VerifyChain(ctx context.Context, session authn.Session) (journal.ChainVerification, error) {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyChain")
+	}
+
+	var r0 journal.ChainVerification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session) (journal.ChainVerification, error)); ok {
+		return returnFunc(ctx, session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session) journal.ChainVerification); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		r0 = ret.Get(0).(journal.ChainVerification)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = returnFunc(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_VerifyChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyChain'
+type Service_VerifyChain_Call struct {
+	*mock.Call
+}
+
+// VerifyChain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+func (_e *Service_Expecter) VerifyChain(ctx interface{}, session interface{}) *Service_VerifyChain_Call {
+	return &Service_VerifyChain_Call{Call: _e.mock.On("VerifyChain", ctx, session)}
+}
+
+func (_c *Service_VerifyChain_Call) Run(run func(ctx context.Context, session authn.Session)) *Service_VerifyChain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_VerifyChain_Call) Return(chainVerification journal.ChainVerification, err error) *Service_VerifyChain_Call {
+	_c.Call.Return(chainVerification, err)
+	return _c
+}
+
+func (_c *Service_VerifyChain_Call) RunAndReturn(run func(ctx context.Context, session authn.Session) (journal.ChainVerification, error)) *Service_VerifyChain_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -88,6 +88,66 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP INDEX IF EXISTS idx_journal_domain;`,
 				},
 			},
+			{
+				Id: "journal_04",
+				Up: []string{
+					`ALTER TABLE journal
+						ADD COLUMN IF NOT EXISTS seq       BIGINT,
+						ADD COLUMN IF NOT EXISTS prev_hash VARCHAR(64),
+						ADD COLUMN IF NOT EXISTS hash      VARCHAR(64);`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_chain ON journal(domain, seq);`,
+					`CREATE TABLE IF NOT EXISTS journal_chain_heads (
+						domain        VARCHAR PRIMARY KEY,
+						seq           BIGINT NOT NULL DEFAULT 0,
+						hash          VARCHAR(64) NOT NULL DEFAULT '',
+						archived_seq  BIGINT NOT NULL DEFAULT 0,
+						archived_hash VARCHAR(64) NOT NULL DEFAULT ''
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS journal_chain_heads`,
+					`DROP INDEX IF EXISTS idx_journal_chain;`,
+					`ALTER TABLE journal DROP COLUMN IF EXISTS seq, DROP COLUMN IF EXISTS prev_hash, DROP COLUMN IF EXISTS hash;`,
+				},
+			},
+			{
+				Id: "journal_05",
+				Up: []string{
+					`ALTER TABLE clients_telemetry ADD COLUMN IF NOT EXISTS online BOOLEAN NOT NULL DEFAULT FALSE;`,
+					`CREATE INDEX IF NOT EXISTS idx_clients_telemetry_presence ON clients_telemetry(domain_id, online, last_seen DESC);`,
+					`CREATE TABLE IF NOT EXISTS client_sessions (
+						id              VARCHAR(36) PRIMARY KEY,
+						client_id       VARCHAR(36) NOT NULL,
+						subscriber_id   VARCHAR(1024) NOT NULL,
+						protocol        VARCHAR(64) NOT NULL DEFAULT '',
+						remote_addr     VARCHAR(256) NOT NULL DEFAULT '',
+						connected_at    TIMESTAMPTZ NOT NULL,
+						disconnected_at TIMESTAMPTZ,
+						FOREIGN KEY (client_id) REFERENCES clients_telemetry(client_id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_client_sessions_client ON client_sessions(client_id, connected_at DESC);`,
+					`CREATE INDEX IF NOT EXISTS idx_client_sessions_open ON client_sessions(subscriber_id) WHERE disconnected_at IS NULL;`,
+					`CREATE TABLE IF NOT EXISTS clients_channel_telemetry (
+						client_id         VARCHAR(36) NOT NULL,
+						domain_id         VARCHAR(36) NOT NULL,
+						channel_id        VARCHAR(36) NOT NULL,
+						subtopic          VARCHAR(1024) NOT NULL DEFAULT '',
+						inbound_messages  BIGINT NOT NULL DEFAULT 0,
+						inbound_bytes     BIGINT NOT NULL DEFAULT 0,
+						outbound_messages BIGINT NOT NULL DEFAULT 0,
+						outbound_bytes    BIGINT NOT NULL DEFAULT 0,
+						last_message_at   TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (client_id, channel_id, subtopic),
+						FOREIGN KEY (client_id) REFERENCES clients_telemetry(client_id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS clients_channel_telemetry`,
+					`DROP TABLE IF EXISTS client_sessions`,
+					`DROP INDEX IF EXISTS idx_clients_telemetry_presence;`,
+					`ALTER TABLE clients_telemetry DROP COLUMN IF EXISTS online;`,
+				},
+			},
 		},
 	}
 }
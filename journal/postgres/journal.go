@@ -5,6 +5,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -43,17 +44,175 @@ func (repo *repository) Save(ctx context.Context, j journal.Journal) (err error)
 		}
 	}
 
-	q := `INSERT INTO journal (id, operation, occurred_at, attributes, metadata, domain)
-		VALUES (:id, :operation, :occurred_at, :attributes, :metadata, :domain);`
+	dbj, err := toDBJournal(j)
+	if err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	if !j.Chained() {
+		q := `INSERT INTO journal (id, operation, occurred_at, attributes, metadata, domain)
+			VALUES (:id, :operation, :occurred_at, :attributes, :metadata, :domain);`
+		if _, err = repo.db.NamedExecContext(ctx, q, dbj); err != nil {
+			return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+		}
+		return nil
+	}
 
-	dbJournal, err := toDBJournal(j)
+	tx, err := repo.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
 	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	// Locking the chain head serializes the journals of the domain, so each
+	// one is chained to the previous one.
+	hq := `INSERT INTO journal_chain_heads (domain) VALUES ($1) ON CONFLICT (domain) DO NOTHING;`
+	if _, err = tx.ExecContext(ctx, hq, dbj.Domain); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	var head journal.ChainHead
+	hq = `SELECT domain, seq, hash, archived_seq, archived_hash FROM journal_chain_heads WHERE domain = $1 FOR UPDATE;`
+	if err = tx.GetContext(ctx, &head, hq, dbj.Domain); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	j.Domain = dbj.Domain
+	j.OccurredAt = dbj.OccurredAt
+	j.Sequence = head.Sequence + 1
+	j.PrevHash = head.Hash
+	if j.Hash, err = j.ComputeHash(); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, errors.Wrap(repoerr.ErrMalformedEntity, err))
+	}
+	dbj.Seq, dbj.PrevHash, dbj.Hash = j.Sequence, j.PrevHash, j.Hash
 
-	if _, err = repo.db.NamedExecContext(ctx, q, dbJournal); err != nil {
+	q := `INSERT INTO journal (id, operation, occurred_at, attributes, metadata, domain, seq, prev_hash, hash)
+		VALUES (:id, :operation, :occurred_at, :attributes, :metadata, :domain, :seq, :prev_hash, :hash);`
+	if _, err = tx.NamedExecContext(ctx, q, dbj); err != nil {
 		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
 	}
+	hq = `UPDATE journal_chain_heads SET seq = $2, hash = $3 WHERE domain = $1;`
+	if _, err = tx.ExecContext(ctx, hq, dbj.Domain, j.Sequence, j.Hash); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveChainHead(ctx context.Context, domain string) (journal.ChainHead, error) {
+	q := `SELECT domain, seq, hash, archived_seq, archived_hash FROM journal_chain_heads WHERE domain = $1;`
+
+	head := journal.ChainHead{Domain: domain}
+	if err := repo.db.QueryRowxContext(ctx, q, domain).StructScan(&head); err != nil {
+		// Domain without the journals has an empty chain.
+		if err == sql.ErrNoRows {
+			return head, nil
+		}
+		return journal.ChainHead{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return head, nil
+}
+
+func (repo *repository) RetrieveChainHeads(ctx context.Context) ([]journal.ChainHead, error) {
+	q := `SELECT domain, seq, hash, archived_seq, archived_hash FROM journal_chain_heads ORDER BY domain;`
+
+	rows, err := repo.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var heads []journal.ChainHead
+	for rows.Next() {
+		var head journal.ChainHead
+		if err := rows.StructScan(&head); err != nil {
+			return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+		heads = append(heads, head)
+	}
+
+	return heads, nil
+}
+
+func (repo *repository) RetrieveChain(ctx context.Context, page journal.ChainPage) ([]journal.Journal, error) {
+	query := []string{"domain = :domain", "seq > :after_seq"}
+	if !page.From.IsZero() {
+		query = append(query, "occurred_at >= :from")
+	}
+	if !page.To.IsZero() {
+		query = append(query, "occurred_at <= :to")
+	}
+	q := fmt.Sprintf(`SELECT id, operation, occurred_at, domain, attributes, metadata, seq, prev_hash, hash
+		FROM journal WHERE %s ORDER BY seq LIMIT :limit;`, strings.Join(query, " AND "))
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, page)
+	if err != nil {
+		return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.Journal
+	for rows.Next() {
+		var item dbJournal
+		if err := rows.StructScan(&item); err != nil {
+			return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+		j, err := toJournal(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, j)
+	}
+
+	return items, nil
+}
+
+func (repo *repository) DeleteChain(ctx context.Context, domain string, seq uint64, hash string) (err error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return repo.eh.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if err != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				err = errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, errRollback))
+			}
+		}
+	}()
+
+	q := `UPDATE journal_chain_heads SET archived_seq = $2, archived_hash = $3 WHERE domain = $1 AND archived_seq < $2;`
+	res, err := tx.ExecContext(ctx, q, domain, seq, hash)
+	if err != nil {
+		return repo.eh.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return repoerr.ErrNotFound
+	}
+	q = `DELETE FROM journal WHERE domain = $1 AND seq <= $2;`
+	if _, err = tx.ExecContext(ctx, q, domain, seq); err != nil {
+		return repo.eh.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return repo.eh.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) DeleteUnchained(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM journal WHERE seq IS NULL AND occurred_at < $1;`
+	if _, err := repo.db.ExecContext(ctx, q, before); err != nil {
+		return repo.eh.HandleError(repoerr.ErrRemoveEntity, err)
+	}
 
 	return nil
 }
@@ -140,6 +299,9 @@ type dbJournal struct {
 	OccurredAt time.Time `db:"occurred_at"`
 	Attributes []byte    `db:"attributes"`
 	Metadata   []byte    `db:"metadata"`
+	Seq        uint64    `db:"seq"`
+	PrevHash   string    `db:"prev_hash"`
+	Hash       string    `db:"hash"`
 	TotalCount uint64    `db:"total_count"`
 }
 
@@ -147,6 +309,9 @@ func toDBJournal(j journal.Journal) (dbJournal, error) {
 	if j.OccurredAt.IsZero() {
 		j.OccurredAt = time.Now().UTC()
 	}
+	// Postgres keeps the timestamps with the microsecond precision, so the
+	// journal is hashed with the time it is read with.
+	j.OccurredAt = j.OccurredAt.Truncate(time.Microsecond)
 
 	attributes := []byte("{}")
 	if len(j.Attributes) > 0 {
@@ -192,10 +357,14 @@ func toJournal(dbj dbJournal) (journal.Journal, error) {
 	}
 
 	return journal.Journal{
+		ID:         dbj.ID,
 		Operation:  dbj.Operation,
 		Domain:     dbj.Domain,
 		OccurredAt: dbj.OccurredAt.UTC(),
 		Attributes: attributes,
 		Metadata:   metadata,
+		Sequence:   dbj.Seq,
+		PrevHash:   dbj.PrevHash,
+		Hash:       dbj.Hash,
 	}, nil
 }
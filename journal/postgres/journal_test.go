@@ -326,6 +326,94 @@ func TestJournalSave(t *testing.T) {
 	}
 }
 
+func TestJournalChain(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM journal")
+		require.Nil(t, err, fmt.Sprintf("clean journal unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM journal_chain_heads")
+		require.Nil(t, err, fmt.Sprintf("clean journal_chain_heads unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	for i := 0; i < 5; i++ {
+		j := journal.Journal{
+			ID:         testsutil.GenerateUUID(t),
+			Operation:  clientOperation,
+			OccurredAt: time.Now().Add(time.Duration(i) * time.Second),
+			Attributes: map[string]any{"id": testsutil.GenerateUUID(t), "domain": domainID, "metadata": payload},
+			Metadata:   payload,
+		}
+		err := repo.Save(context.Background(), j)
+		require.Nil(t, err, fmt.Sprintf("save journal unexpected error: %s", err))
+	}
+
+	head, err := repo.RetrieveChainHead(context.Background(), domainID)
+	require.Nil(t, err, fmt.Sprintf("retrieve chain head unexpected error: %s", err))
+	assert.Equal(t, uint64(5), head.Sequence)
+
+	chain, err := repo.RetrieveChain(context.Background(), journal.ChainPage{Domain: domainID, Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	require.Len(t, chain, 5)
+	prevHash := ""
+	for i, j := range chain {
+		assert.Equal(t, uint64(i+1), j.Sequence)
+		assert.Equal(t, prevHash, j.PrevHash)
+		hash, err := j.ComputeHash()
+		require.Nil(t, err, fmt.Sprintf("compute hash unexpected error: %s", err))
+		assert.Equal(t, j.Hash, hash, fmt.Sprintf("hash of journal %d does not match", j.Sequence))
+		prevHash = j.Hash
+	}
+	assert.Equal(t, head.Hash, prevHash)
+
+	page, err := repo.RetrieveChain(context.Background(), journal.ChainPage{Domain: domainID, AfterSequence: 3, Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	assert.Equal(t, chain[3:], page)
+
+	heads, err := repo.RetrieveChainHeads(context.Background())
+	require.Nil(t, err, fmt.Sprintf("retrieve chain heads unexpected error: %s", err))
+	assert.Contains(t, heads, head)
+
+	empty, err := repo.RetrieveChainHead(context.Background(), testsutil.GenerateUUID(t))
+	require.Nil(t, err, fmt.Sprintf("retrieve empty chain head unexpected error: %s", err))
+	assert.Equal(t, uint64(0), empty.Sequence)
+
+	cases := []struct {
+		desc string
+		seq  uint64
+		hash string
+		err  error
+	}{
+		{
+			desc: "delete chain prefix",
+			seq:  2,
+			hash: chain[1].Hash,
+			err:  nil,
+		},
+		{
+			desc: "delete already archived chain prefix",
+			seq:  1,
+			hash: chain[0].Hash,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.DeleteChain(context.Background(), domainID, tc.seq, tc.hash)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+		})
+	}
+
+	head, err = repo.RetrieveChainHead(context.Background(), domainID)
+	require.Nil(t, err, fmt.Sprintf("retrieve chain head unexpected error: %s", err))
+	assert.Equal(t, uint64(2), head.ArchivedSequence)
+	assert.Equal(t, chain[1].Hash, head.ArchivedHash)
+	remaining, err := repo.RetrieveChain(context.Background(), journal.ChainPage{Domain: domainID, Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("retrieve chain unexpected error: %s", err))
+	assert.Equal(t, chain[2:], remaining)
+}
+
 func TestJournalRetrieveAll(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM journal")
@@ -1317,7 +1405,7 @@ func TestIncrementOutboundMessages(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			err := repo.IncrementOutboundMessages(context.Background(), tc.channelID, tc.subtopic)
+			err := repo.IncrementOutboundMessages(context.Background(), tc.channelID, tc.subtopic, 10, time.Now().UTC())
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
 
 			if err == nil && tc.expectedIncrement > 0 {
@@ -1325,8 +1413,296 @@ func TestIncrementOutboundMessages(t *testing.T) {
 					result, err := repo.RetrieveClientTelemetry(context.Background(), cid, domainID)
 					require.Nil(t, err)
 					assert.Equal(t, tc.expectedIncrement, result.OutboundMessages)
+
+					channels, err := repo.RetrieveChannelTelemetry(context.Background(), cid)
+					require.Nil(t, err)
+					require.Len(t, channels, 1)
+					assert.Equal(t, tc.channelID, channels[0].ChannelID)
+					assert.Equal(t, tc.subtopic, channels[0].Subtopic)
+					assert.Equal(t, tc.expectedIncrement, channels[0].OutboundMessages)
+					assert.Equal(t, tc.expectedIncrement*10, channels[0].OutboundBytes)
 				}
 			}
 		})
 	}
 }
+
+func TestIncrementInboundChannelMessages(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients_telemetry")
+		require.Nil(t, err)
+	})
+	repo := postgres.NewRepository(database)
+
+	clientID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	err := repo.SaveClientTelemetry(context.Background(), journal.ClientTelemetry{
+		ClientID:  clientID,
+		DomainID:  domainID,
+		FirstSeen: now,
+	})
+	require.Nil(t, err)
+
+	cases := []struct {
+		desc     string
+		ct       journal.ChannelTelemetry
+		expected journal.ChannelTelemetry
+		err      error
+	}{
+		{
+			desc: "increment inbound channel messages for new channel",
+			ct: journal.ChannelTelemetry{
+				ClientID:        clientID,
+				DomainID:        domainID,
+				ChannelID:       channelID,
+				InboundMessages: 1,
+				InboundBytes:    100,
+				LastMessageAt:   now,
+			},
+			expected: journal.ChannelTelemetry{
+				ChannelID:       channelID,
+				InboundMessages: 1,
+				InboundBytes:    100,
+				LastMessageAt:   now,
+			},
+		},
+		{
+			desc: "increment inbound channel messages for existing channel",
+			ct: journal.ChannelTelemetry{
+				ClientID:        clientID,
+				DomainID:        domainID,
+				ChannelID:       channelID,
+				InboundMessages: 1,
+				InboundBytes:    50,
+				LastMessageAt:   now.Add(-time.Hour),
+			},
+			expected: journal.ChannelTelemetry{
+				ChannelID:       channelID,
+				InboundMessages: 2,
+				InboundBytes:    150,
+				LastMessageAt:   now,
+			},
+		},
+		{
+			desc: "increment inbound channel messages for non-existing client",
+			ct: journal.ChannelTelemetry{
+				ClientID:        testsutil.GenerateUUID(t),
+				DomainID:        domainID,
+				ChannelID:       channelID,
+				InboundMessages: 1,
+				LastMessageAt:   now,
+			},
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.IncrementInboundChannelMessages(context.Background(), tc.ct)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+			if err == nil {
+				channels, err := repo.RetrieveChannelTelemetry(context.Background(), tc.ct.ClientID)
+				require.Nil(t, err)
+				require.Len(t, channels, 1)
+				assert.Equal(t, tc.expected.ChannelID, channels[0].ChannelID)
+				assert.Equal(t, tc.expected.InboundMessages, channels[0].InboundMessages)
+				assert.Equal(t, tc.expected.InboundBytes, channels[0].InboundBytes)
+				assert.Equal(t, tc.expected.LastMessageAt.Unix(), channels[0].LastMessageAt.Unix())
+			}
+		})
+	}
+}
+
+func TestClientSessions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients_telemetry")
+		require.Nil(t, err)
+	})
+	repo := postgres.NewRepository(database)
+
+	clientID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	err := repo.SaveClientTelemetry(context.Background(), journal.ClientTelemetry{
+		ClientID:  clientID,
+		DomainID:  domainID,
+		FirstSeen: now.Add(-time.Hour),
+		LastSeen:  now.Add(-time.Hour),
+	})
+	require.Nil(t, err)
+
+	first := journal.ClientSession{
+		ID:           testsutil.GenerateUUID(t),
+		ClientID:     clientID,
+		SubscriberID: "subscriber-1",
+		Protocol:     "mqtt",
+		RemoteAddr:   "127.0.0.1:1883",
+		ConnectedAt:  now.Add(-time.Minute),
+	}
+	second := journal.ClientSession{
+		ID:           testsutil.GenerateUUID(t),
+		ClientID:     clientID,
+		SubscriberID: "subscriber-2",
+		Protocol:     "mqtt",
+		ConnectedAt:  now,
+	}
+
+	for _, s := range []journal.ClientSession{first, second} {
+		err := repo.SaveSession(context.Background(), s)
+		require.Nil(t, err)
+	}
+	err = repo.SaveSession(context.Background(), journal.ClientSession{
+		ID:           testsutil.GenerateUUID(t),
+		ClientID:     testsutil.GenerateUUID(t),
+		SubscriberID: "subscriber-3",
+		ConnectedAt:  now,
+	})
+	require.Nil(t, err)
+
+	ct, err := repo.RetrieveClientTelemetry(context.Background(), clientID, domainID)
+	require.Nil(t, err)
+	assert.True(t, ct.Online)
+	assert.Equal(t, now.Unix(), ct.LastSeen.Unix())
+
+	err = repo.CloseSession(context.Background(), first.SubscriberID, now.Add(time.Minute))
+	require.Nil(t, err)
+
+	cases := []struct {
+		desc     string
+		clientID string
+		limit    uint64
+		sessions []journal.ClientSession
+	}{
+		{
+			desc:     "retrieve all sessions",
+			clientID: clientID,
+			limit:    10,
+			sessions: []journal.ClientSession{second, first},
+		},
+		{
+			desc:     "retrieve latest session",
+			clientID: clientID,
+			limit:    1,
+			sessions: []journal.ClientSession{second},
+		},
+		{
+			desc:     "retrieve sessions of non-existing client",
+			clientID: testsutil.GenerateUUID(t),
+			limit:    10,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sessions, err := repo.RetrieveSessions(context.Background(), tc.clientID, tc.limit)
+			require.Nil(t, err)
+			require.Len(t, sessions, len(tc.sessions))
+			for i, s := range sessions {
+				assert.Equal(t, tc.sessions[i].ID, s.ID)
+				assert.Equal(t, tc.sessions[i].SubscriberID, s.SubscriberID)
+				assert.Equal(t, tc.sessions[i].RemoteAddr, s.RemoteAddr)
+				assert.Equal(t, tc.sessions[i].ConnectedAt.Unix(), s.ConnectedAt.Unix())
+				assert.Equal(t, s.SubscriberID == first.SubscriberID, !s.DisconnectedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestClientsPresence(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients_telemetry")
+		require.Nil(t, err)
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// The stale client is active long ago, the connected client is
+	// active long ago but has the open session and the recent client
+	// has published recently.
+	stale := journal.ClientTelemetry{ClientID: testsutil.GenerateUUID(t), DomainID: domainID, FirstSeen: now, LastSeen: now.Add(-time.Hour)}
+	connected := journal.ClientTelemetry{ClientID: testsutil.GenerateUUID(t), DomainID: domainID, FirstSeen: now, LastSeen: now.Add(-2 * time.Hour)}
+	recent := journal.ClientTelemetry{ClientID: testsutil.GenerateUUID(t), DomainID: domainID, FirstSeen: now, LastSeen: now}
+	for _, ct := range []journal.ClientTelemetry{stale, connected, recent} {
+		err := repo.IncrementInboundMessages(context.Background(), ct)
+		require.Nil(t, err)
+	}
+	err := repo.SaveSession(context.Background(), journal.ClientSession{
+		ID:           testsutil.GenerateUUID(t),
+		ClientID:     connected.ClientID,
+		SubscriberID: "subscriber",
+		ConnectedAt:  now.Add(-2 * time.Hour),
+	})
+	require.Nil(t, err)
+
+	offline, err := repo.MarkOffline(context.Background(), "", now.Add(-time.Minute))
+	require.Nil(t, err)
+	require.Len(t, offline, 1)
+	assert.Equal(t, stale.ClientID, offline[0].ClientID)
+	assert.Equal(t, domainID, offline[0].DomainID)
+	assert.False(t, offline[0].Online)
+
+	offline, err = repo.MarkOffline(context.Background(), "", now.Add(-time.Minute))
+	require.Nil(t, err)
+	assert.Empty(t, offline)
+
+	cases := []struct {
+		desc     string
+		domainID string
+		page     journal.PresencePage
+		total    uint64
+		clients  []string
+	}{
+		{
+			desc:     "retrieve presence of all clients",
+			domainID: domainID,
+			page:     journal.PresencePage{Limit: 10},
+			total:    3,
+			clients:  []string{recent.ClientID, stale.ClientID, connected.ClientID},
+		},
+		{
+			desc:     "retrieve presence of online clients",
+			domainID: domainID,
+			page:     journal.PresencePage{Limit: 10, Status: journal.OnlineStatus},
+			total:    2,
+			clients:  []string{recent.ClientID, connected.ClientID},
+		},
+		{
+			desc:     "retrieve presence of offline clients",
+			domainID: domainID,
+			page:     journal.PresencePage{Limit: 10, Status: journal.OfflineStatus},
+			total:    1,
+			clients:  []string{stale.ClientID},
+		},
+		{
+			desc:     "retrieve presence with offset and limit",
+			domainID: domainID,
+			page:     journal.PresencePage{Offset: 1, Limit: 1},
+			total:    3,
+			clients:  []string{stale.ClientID},
+		},
+		{
+			desc:     "retrieve presence of non-existing domain",
+			domainID: testsutil.GenerateUUID(t),
+			page:     journal.PresencePage{Limit: 10},
+			total:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrievePresence(context.Background(), tc.domainID, tc.page)
+			require.Nil(t, err)
+			assert.Equal(t, tc.total, page.Total)
+			require.Len(t, page.Clients, len(tc.clients))
+			for i, c := range page.Clients {
+				assert.Equal(t, tc.clients[i], c.ClientID)
+			}
+		})
+	}
+}
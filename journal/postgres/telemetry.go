@@ -6,6 +6,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq/journal"
@@ -129,12 +131,13 @@ func (repo *repository) RemoveSubscription(ctx context.Context, subscriberID str
 }
 
 func (repo *repository) IncrementInboundMessages(ctx context.Context, ct journal.ClientTelemetry) error {
-	q := `INSERT INTO clients_telemetry (client_id,domain_id, inbound_messages,first_seen, last_seen)
-		VALUES (:client_id, :domain_id, 1, :first_seen, :last_seen)
+	q := `INSERT INTO clients_telemetry (client_id,domain_id, inbound_messages,first_seen, last_seen, online)
+		VALUES (:client_id, :domain_id, 1, :first_seen, :last_seen, TRUE)
 		ON CONFLICT (client_id)
 		DO UPDATE SET
 			inbound_messages = clients_telemetry.inbound_messages + 1,
-			last_seen = EXCLUDED.last_seen;
+			last_seen = EXCLUDED.last_seen,
+			online = TRUE;
 	`
 
 	dbct, err := toDBClientsTelemetry(ct)
@@ -154,59 +157,221 @@ func (repo *repository) IncrementInboundMessages(ctx context.Context, ct journal
 	return nil
 }
 
-func (repo *repository) IncrementOutboundMessages(ctx context.Context, channelID, subtopic string) error {
-	query := `
-		SELECT client_id, COUNT(*) AS match_count
-		FROM subscriptions
-		WHERE channel_id = :channel_id AND subtopic = :subtopic
-		GROUP BY client_id
+func (repo *repository) IncrementOutboundMessages(ctx context.Context, channelID, subtopic string, size uint64, at time.Time) error {
+	// Clients receive a message once per matching subscription, so the
+	// totals and the channel counters are updated in a single statement.
+	q := `
+		WITH matches AS (
+			SELECT client_id, COUNT(*) AS match_count
+			FROM subscriptions
+			WHERE channel_id = :channel_id AND subtopic = :subtopic
+			GROUP BY client_id
+		), clients AS (
+			UPDATE clients_telemetry AS ct
+			SET outbound_messages = ct.outbound_messages + m.match_count
+			FROM matches AS m
+			WHERE ct.client_id = m.client_id
+			RETURNING ct.client_id, ct.domain_id, m.match_count
+		)
+		INSERT INTO clients_channel_telemetry (client_id, domain_id, channel_id, subtopic, outbound_messages, outbound_bytes, last_message_at)
+		SELECT client_id, domain_id, CAST(:channel_id AS VARCHAR), CAST(:subtopic AS VARCHAR), match_count,
+			match_count * CAST(:outbound_bytes AS BIGINT), CAST(:last_message_at AS TIMESTAMPTZ)
+		FROM clients
+		ON CONFLICT (client_id, channel_id, subtopic)
+		DO UPDATE SET
+			outbound_messages = clients_channel_telemetry.outbound_messages + EXCLUDED.outbound_messages,
+			outbound_bytes = clients_channel_telemetry.outbound_bytes + EXCLUDED.outbound_bytes,
+			last_message_at = GREATEST(clients_channel_telemetry.last_message_at, EXCLUDED.last_message_at);
 	`
-	sb := journal.ClientSubscription{
-		ChannelID: channelID,
-		Subtopic:  subtopic,
+	ct := journal.ChannelTelemetry{
+		ChannelID:     channelID,
+		Subtopic:      subtopic,
+		OutboundBytes: size,
+		LastMessageAt: at,
 	}
 
-	rows, err := repo.db.NamedQueryContext(ctx, query, sb)
-	if err != nil {
+	if _, err := repo.db.NamedExecContext(ctx, q, ct); err != nil {
 		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
 	}
-	defer rows.Close()
 
-	tx, err := repo.db.BeginTxx(ctx, nil)
-	if err != nil {
+	return nil
+}
+
+func (repo *repository) IncrementInboundChannelMessages(ctx context.Context, ct journal.ChannelTelemetry) error {
+	q := `INSERT INTO clients_channel_telemetry (client_id, domain_id, channel_id, subtopic, inbound_messages, inbound_bytes, last_message_at)
+		VALUES (:client_id, :domain_id, :channel_id, :subtopic, :inbound_messages, :inbound_bytes, :last_message_at)
+		ON CONFLICT (client_id, channel_id, subtopic)
+		DO UPDATE SET
+			inbound_messages = clients_channel_telemetry.inbound_messages + EXCLUDED.inbound_messages,
+			inbound_bytes = clients_channel_telemetry.inbound_bytes + EXCLUDED.inbound_bytes,
+			last_message_at = GREATEST(clients_channel_telemetry.last_message_at, EXCLUDED.last_message_at);
+	`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, ct); err != nil {
 		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
 	}
 
-	q := `UPDATE clients_telemetry
-		SET outbound_messages = outbound_messages + $1
-		WHERE client_id = $2;
-		`
+	return nil
+}
+
+func (repo *repository) RetrieveChannelTelemetry(ctx context.Context, clientID string) ([]journal.ChannelTelemetry, error) {
+	q := `SELECT client_id, domain_id, channel_id, subtopic, inbound_messages, inbound_bytes, outbound_messages, outbound_bytes, last_message_at
+		FROM clients_channel_telemetry WHERE client_id = $1 ORDER BY channel_id, subtopic;`
+
+	rows, err := repo.db.QueryxContext(ctx, q, clientID)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
 
+	var items []journal.ChannelTelemetry
 	for rows.Next() {
-		var clientID string
-		var count uint64
-		if err = rows.Scan(&clientID, &count); err != nil {
-			if err := tx.Rollback(); err != nil {
-				return errors.Wrap(errors.ErrRollbackTx, err)
-			}
-			return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		var ct journal.ChannelTelemetry
+		if err := rows.StructScan(&ct); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
 		}
+		ct.LastMessageAt = ct.LastMessageAt.UTC()
+		items = append(items, ct)
+	}
 
-		if _, err = repo.db.ExecContext(ctx, q, count, clientID); err != nil {
-			if err := tx.Rollback(); err != nil {
-				return errors.Wrap(errors.ErrRollbackTx, err)
-			}
-			return errors.Wrap(errors.ErrRollbackTx, err)
-		}
+	return items, nil
+}
+
+func (repo *repository) SaveSession(ctx context.Context, session journal.ClientSession) error {
+	q := `
+		WITH client AS (
+			UPDATE clients_telemetry
+			SET online = TRUE, last_seen = GREATEST(last_seen, CAST(:connected_at AS TIMESTAMP))
+			WHERE client_id = :client_id
+			RETURNING client_id
+		)
+		INSERT INTO client_sessions (id, client_id, subscriber_id, protocol, remote_addr, connected_at)
+		SELECT CAST(:id AS VARCHAR), client_id, CAST(:subscriber_id AS VARCHAR), CAST(:protocol AS VARCHAR),
+			CAST(:remote_addr AS VARCHAR), CAST(:connected_at AS TIMESTAMPTZ)
+		FROM client;
+	`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBClientSession(session)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
 
-	if err = tx.Commit(); err != nil {
+	return nil
+}
+
+func (repo *repository) CloseSession(ctx context.Context, subscriberID string, at time.Time) error {
+	q := `UPDATE client_sessions SET disconnected_at = $2 WHERE subscriber_id = $1 AND disconnected_at IS NULL;`
+
+	if _, err := repo.db.ExecContext(ctx, q, subscriberID, at); err != nil {
 		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
 	}
 
 	return nil
 }
 
+func (repo *repository) RetrieveSessions(ctx context.Context, clientID string, limit uint64) ([]journal.ClientSession, error) {
+	q := `SELECT id, client_id, subscriber_id, protocol, remote_addr, connected_at, disconnected_at
+		FROM client_sessions WHERE client_id = $1 ORDER BY connected_at DESC LIMIT $2;`
+
+	rows, err := repo.db.QueryxContext(ctx, q, clientID, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.ClientSession
+	for rows.Next() {
+		var dbs dbClientSession
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toClientSession(dbs))
+	}
+
+	return items, nil
+}
+
+func (repo *repository) MarkOffline(ctx context.Context, clientID string, inactiveSince time.Time) ([]journal.ClientPresence, error) {
+	query := []string{
+		"ct.online",
+		"NOT EXISTS (SELECT 1 FROM client_sessions AS s WHERE s.client_id = ct.client_id AND s.disconnected_at IS NULL)",
+	}
+	if clientID != "" {
+		query = append(query, "ct.client_id = :client_id")
+	}
+	if !inactiveSince.IsZero() {
+		query = append(query, "(ct.last_seen IS NULL OR ct.last_seen < :last_seen)")
+	}
+	q := fmt.Sprintf(`UPDATE clients_telemetry AS ct SET online = FALSE WHERE %s
+		RETURNING ct.client_id, ct.domain_id, ct.online, ct.last_seen;`, strings.Join(query, " AND "))
+
+	params := dbClientPresence{
+		ClientID: clientID,
+		LastSeen: sql.NullTime{Time: inactiveSince, Valid: true},
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.ClientPresence
+	for rows.Next() {
+		var dbp dbClientPresence
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		items = append(items, toClientPresence(dbp))
+	}
+
+	return items, nil
+}
+
+func (repo *repository) RetrievePresence(ctx context.Context, domainID string, page journal.PresencePage) (journal.ClientsPresencePage, error) {
+	query := []string{"domain_id = :domain_id"}
+	switch page.Status {
+	case journal.OnlineStatus:
+		query = append(query, "online")
+	case journal.OfflineStatus:
+		query = append(query, "NOT online")
+	}
+	where := strings.Join(query, " AND ")
+	q := fmt.Sprintf(`SELECT client_id, domain_id, online, last_seen FROM clients_telemetry WHERE %s
+		ORDER BY last_seen DESC NULLS LAST, client_id LIMIT :limit OFFSET :offset;`, where)
+
+	params := map[string]any{
+		"domain_id": domainID,
+		"limit":     page.Limit,
+		"offset":    page.Offset,
+	}
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return journal.ClientsPresencePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []journal.ClientPresence
+	for rows.Next() {
+		var dbp dbClientPresence
+		if err := rows.StructScan(&dbp); err != nil {
+			return journal.ClientsPresencePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, toClientPresence(dbp))
+	}
+
+	tq := fmt.Sprintf(`SELECT COUNT(*) FROM clients_telemetry WHERE %s;`, where)
+	total, err := postgres.Total(ctx, repo.db, tq, params)
+	if err != nil {
+		return journal.ClientsPresencePage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return journal.ClientsPresencePage{
+		Total:   total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+		Clients: items,
+	}, nil
+}
+
 type dbClientTelemetry struct {
 	ClientID         string       `db:"client_id"`
 	DomainID         string       `db:"domain_id"`
@@ -214,6 +379,7 @@ type dbClientTelemetry struct {
 	OutboundMessages uint64       `db:"outbound_messages"`
 	FirstSeen        time.Time    `db:"first_seen"`
 	LastSeen         sql.NullTime `db:"last_seen"`
+	Online           bool         `db:"online"`
 }
 
 func toDBClientsTelemetry(ct journal.ClientTelemetry) (dbClientTelemetry, error) {
@@ -250,5 +416,71 @@ func toClientsTelemetry(dbct dbClientTelemetry) (journal.ClientTelemetry, error)
 		OutboundMessages: dbct.OutboundMessages,
 		FirstSeen:        dbct.FirstSeen,
 		LastSeen:         lastSeen,
+		Online:           dbct.Online,
 	}, nil
 }
+
+type dbClientSession struct {
+	ID             string       `db:"id"`
+	ClientID       string       `db:"client_id"`
+	SubscriberID   string       `db:"subscriber_id"`
+	Protocol       string       `db:"protocol"`
+	RemoteAddr     string       `db:"remote_addr"`
+	ConnectedAt    time.Time    `db:"connected_at"`
+	DisconnectedAt sql.NullTime `db:"disconnected_at"`
+}
+
+func toDBClientSession(s journal.ClientSession) dbClientSession {
+	var disconnectedAt sql.NullTime
+	if !s.DisconnectedAt.IsZero() {
+		disconnectedAt = sql.NullTime{Time: s.DisconnectedAt, Valid: true}
+	}
+
+	return dbClientSession{
+		ID:             s.ID,
+		ClientID:       s.ClientID,
+		SubscriberID:   s.SubscriberID,
+		Protocol:       s.Protocol,
+		RemoteAddr:     s.RemoteAddr,
+		ConnectedAt:    s.ConnectedAt,
+		DisconnectedAt: disconnectedAt,
+	}
+}
+
+func toClientSession(dbs dbClientSession) journal.ClientSession {
+	var disconnectedAt time.Time
+	if dbs.DisconnectedAt.Valid {
+		disconnectedAt = dbs.DisconnectedAt.Time.UTC()
+	}
+
+	return journal.ClientSession{
+		ID:             dbs.ID,
+		ClientID:       dbs.ClientID,
+		SubscriberID:   dbs.SubscriberID,
+		Protocol:       dbs.Protocol,
+		RemoteAddr:     dbs.RemoteAddr,
+		ConnectedAt:    dbs.ConnectedAt.UTC(),
+		DisconnectedAt: disconnectedAt,
+	}
+}
+
+type dbClientPresence struct {
+	ClientID string       `db:"client_id"`
+	DomainID string       `db:"domain_id"`
+	Online   bool         `db:"online"`
+	LastSeen sql.NullTime `db:"last_seen"`
+}
+
+func toClientPresence(dbp dbClientPresence) journal.ClientPresence {
+	var lastSeen time.Time
+	if dbp.LastSeen.Valid {
+		lastSeen = dbp.LastSeen.Time.UTC()
+	}
+
+	return journal.ClientPresence{
+		ClientID: dbp.ClientID,
+		DomainID: dbp.DomainID,
+		Online:   dbp.Online,
+		LastSeen: lastSeen,
+	}
+}
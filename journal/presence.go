@@ -0,0 +1,32 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewPresenceHandler starts the goroutine which periodically marks the clients
+// inactive for longer than the timeout as offline until the context is
+// canceled. Clients with the open sessions stay online regardless of their
+// activity, since the adapters close the sessions of the dead connections.
+func NewPresenceHandler(ctx context.Context, svc Service, timeout, checkInterval time.Duration, logger *slog.Logger) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := svc.UpdatePresence(ctx, time.Now().Add(-timeout)); err != nil {
+					logger.Error("failed to update clients presence", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
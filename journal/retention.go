@@ -0,0 +1,30 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewRetentionHandler starts the goroutine which periodically archives the
+// journals older than the retention until the context is canceled.
+func NewRetentionHandler(ctx context.Context, svc Service, retention, checkInterval time.Duration, logger *slog.Logger) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := svc.Archive(ctx, time.Now().Add(-retention)); err != nil {
+					logger.Error("failed to archive journals", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
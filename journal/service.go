@@ -5,7 +5,12 @@ package journal
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/absmach/supermq"
@@ -18,28 +23,46 @@ import (
 const (
 	clientCreate         = "client.create"
 	clientRemove         = "client.remove"
+	clientOffline        = "client.went_offline"
+	mqttConnect          = "mqtt.client_connect"
 	mqttSubscribe        = "mqtt.client_subscribe"
 	mqttDisconnect       = "mqtt.client_disconnect"
 	messagingPublish     = "messaging.client_publish"
 	messagingSubscribe   = "messaging.client_subscribe"
 	messagingUnsubscribe = "messaging.client_unsubscribe"
+
+	mqttProtocol = "mqtt"
+	maxSessions  = 10
+
+	chainBatchSize = 1000
+	maxViolations  = 100
+	platformChain  = "platform"
 )
 
 var (
 	errSaveJournal     = errors.New("failed to save journal")
 	errHandleTelemetry = errors.New("failed to handle client telemetry")
 	errInvalidSubTopic = errors.New("invalid subscribe topic")
+	errArchive         = errors.New("failed to archive journals")
+	errUpdatePresence  = errors.New("failed to update clients presence")
 )
 
 type service struct {
 	idProvider supermq.IDProvider
 	repository Repository
+	exportKey  ed25519.PrivateKey
+	archiveDir string
 }
 
-func NewService(idp supermq.IDProvider, repository Repository) Service {
+// NewService returns the journal service. The exports and the archives are
+// signed with the export key. The archived journals are written to the
+// archive directory before they are removed, unless the directory is empty.
+func NewService(idp supermq.IDProvider, repository Repository, exportKey ed25519.PrivateKey, archiveDir string) Service {
 	return &service{
 		idProvider: idp,
 		repository: repository,
+		exportKey:  exportKey,
+		archiveDir: archiveDir,
 	}
 }
 
@@ -82,9 +105,241 @@ func (svc *service) RetrieveClientTelemetry(ctx context.Context, session smqauth
 
 	ct.Subscriptions = subs
 
+	if ct.Channels, err = svc.repository.RetrieveChannelTelemetry(ctx, clientID); err != nil {
+		return ClientTelemetry{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if ct.Sessions, err = svc.repository.RetrieveSessions(ctx, clientID, maxSessions); err != nil {
+		return ClientTelemetry{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
 	return ct, nil
 }
 
+func (svc *service) RetrieveClientsPresence(ctx context.Context, session smqauthn.Session, page PresencePage) (ClientsPresencePage, error) {
+	pp, err := svc.repository.RetrievePresence(ctx, session.DomainID, page)
+	if err != nil {
+		return ClientsPresencePage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return pp, nil
+}
+
+func (svc *service) UpdatePresence(ctx context.Context, inactiveSince time.Time) error {
+	if err := svc.markOffline(ctx, "", inactiveSince); err != nil {
+		return errors.Wrap(errUpdatePresence, err)
+	}
+
+	return nil
+}
+
+// markOffline marks the clients offline and saves the journal of each
+// client that went offline, so it is a part of the client history.
+func (svc *service) markOffline(ctx context.Context, clientID string, inactiveSince time.Time) error {
+	offline, err := svc.repository.MarkOffline(ctx, clientID, inactiveSince)
+	if err != nil {
+		return err
+	}
+	for _, cp := range offline {
+		j := Journal{
+			Operation:  clientOffline,
+			OccurredAt: time.Now().UTC(),
+			Attributes: map[string]any{
+				"id":        cp.ClientID,
+				"domain":    cp.DomainID,
+				"last_seen": cp.LastSeen.Format(time.RFC3339Nano),
+			},
+		}
+		if err := svc.Save(ctx, j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *service) VerifyChain(ctx context.Context, session smqauthn.Session) (ChainVerification, error) {
+	head, err := svc.repository.RetrieveChainHead(ctx, session.DomainID)
+	if err != nil {
+		return ChainVerification{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	v := ChainVerification{
+		Domain:           session.DomainID,
+		HeadSequence:     head.Sequence,
+		ArchivedSequence: head.ArchivedSequence,
+	}
+	seq, hash := head.ArchivedSequence, head.ArchivedHash
+	page := ChainPage{
+		Domain:        session.DomainID,
+		AfterSequence: head.ArchivedSequence,
+	}
+	err = svc.streamChain(ctx, page, func(j Journal) (bool, error) {
+		// Journals saved after the head is read are verified next time.
+		if j.Sequence > head.Sequence {
+			return false, nil
+		}
+		switch {
+		case j.Sequence != seq+1:
+			v.Violations = append(v.Violations, ChainViolation{Sequence: seq + 1, Reason: MissingEntries})
+		case j.PrevHash != hash:
+			v.Violations = append(v.Violations, ChainViolation{Sequence: j.Sequence, ID: j.ID, Reason: PrevHashMismatch})
+		}
+		h, err := j.ComputeHash()
+		if err != nil {
+			return false, err
+		}
+		if h != j.Hash {
+			v.Violations = append(v.Violations, ChainViolation{Sequence: j.Sequence, ID: j.ID, Reason: HashMismatch})
+		}
+		seq, hash = j.Sequence, j.Hash
+		v.Verified++
+
+		return len(v.Violations) < maxViolations, nil
+	})
+	if err != nil {
+		return ChainVerification{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(v.Violations) < maxViolations {
+		switch {
+		case seq < head.Sequence:
+			v.Violations = append(v.Violations, ChainViolation{Sequence: seq + 1, Reason: MissingEntries})
+		case hash != head.Hash:
+			v.Violations = append(v.Violations, ChainViolation{Sequence: seq, Reason: HeadMismatch})
+		}
+	}
+	v.Valid = len(v.Violations) == 0
+
+	return v, nil
+}
+
+func (svc *service) Export(ctx context.Context, session smqauthn.Session, page ChainPage, w io.Writer) error {
+	page.Domain = session.DomainID
+	page.AfterSequence = 0
+	err := writeSigned(w, svc.exportKey, func(enc *json.Encoder) error {
+		return svc.streamChain(ctx, page, func(j Journal) (bool, error) {
+			return true, enc.Encode(j)
+		})
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) Archive(ctx context.Context, before time.Time) error {
+	heads, err := svc.repository.RetrieveChainHeads(ctx)
+	if err != nil {
+		return errors.Wrap(errArchive, err)
+	}
+
+	var retErr error
+	for _, head := range heads {
+		if err := svc.archive(ctx, head, before); err != nil && retErr == nil {
+			retErr = errors.Wrap(errArchive, err)
+		}
+	}
+	// Messaging telemetry is not chained, so it is removed without archiving.
+	if err := svc.repository.DeleteUnchained(ctx, before); err != nil && retErr == nil {
+		retErr = errors.Wrap(errArchive, err)
+	}
+
+	return retErr
+}
+
+// archive removes the longest prefix of the domain chain which occurred
+// before the given time. The chain is ordered by the sequence, so a journal
+// with the occurrence time out of order keeps the following ones as well.
+func (svc *service) archive(ctx context.Context, head ChainHead, before time.Time) error {
+	var last Journal
+	page := ChainPage{
+		Domain:        head.Domain,
+		AfterSequence: head.ArchivedSequence,
+	}
+	collect := func(fn func(Journal) error) error {
+		return svc.streamChain(ctx, page, func(j Journal) (bool, error) {
+			if j.Sequence > head.Sequence || !j.OccurredAt.Before(before) {
+				return false, nil
+			}
+			last = j
+			return true, fn(j)
+		})
+	}
+
+	if svc.archiveDir == "" {
+		if err := collect(func(Journal) error { return nil }); err != nil {
+			return err
+		}
+	} else {
+		if err := svc.writeArchive(head, collect); err != nil {
+			return err
+		}
+	}
+	if last.Sequence == 0 {
+		return nil
+	}
+
+	return svc.repository.DeleteChain(ctx, head.Domain, last.Sequence, last.Hash)
+}
+
+// writeArchive writes the collected journals as the signed NDJSON file named
+// after the domain and the sequence range. The file is written to the
+// temporary one first, so the partially written archives are never left.
+func (svc *service) writeArchive(head ChainHead, collect func(fn func(Journal) error) error) error {
+	f, err := os.CreateTemp(svc.archiveDir, ".journal-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	var first, last uint64
+	err = writeSigned(f, svc.exportKey, func(enc *json.Encoder) error {
+		return collect(func(j Journal) error {
+			if first == 0 {
+				first = j.Sequence
+			}
+			last = j.Sequence
+			return enc.Encode(j)
+		})
+	})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || first == 0 {
+		return err
+	}
+
+	domain := head.Domain
+	if domain == "" {
+		domain = platformChain
+	}
+	name := fmt.Sprintf("journal-%s-%d-%d.ndjson", domain, first, last)
+
+	return os.Rename(f.Name(), filepath.Join(svc.archiveDir, name))
+}
+
+// streamChain passes the chained journals of the page to the function batch
+// by batch, until the function returns false or the journals are exhausted.
+func (svc *service) streamChain(ctx context.Context, page ChainPage, fn func(Journal) (bool, error)) error {
+	page.Limit = chainBatchSize
+	for {
+		journals, err := svc.repository.RetrieveChain(ctx, page)
+		if err != nil {
+			return err
+		}
+		for _, j := range journals {
+			ok, err := fn(j)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		if uint64(len(journals)) < page.Limit {
+			return nil
+		}
+		page.AfterSequence = journals[len(journals)-1].Sequence
+	}
+}
+
 func (svc *service) handleTelemetry(ctx context.Context, journal Journal) error {
 	switch journal.Operation {
 	case clientCreate:
@@ -93,6 +348,9 @@ func (svc *service) handleTelemetry(ctx context.Context, journal Journal) error
 	case clientRemove:
 		return svc.removeClientTelemetry(ctx, journal)
 
+	case mqttConnect:
+		return svc.addMqttSession(ctx, journal)
+
 	case mqttSubscribe:
 		return svc.addMqttSubscription(ctx, journal)
 
@@ -209,7 +467,37 @@ func (svc *service) removeMqttSubscription(ctx context.Context, journal Journal)
 		return err
 	}
 
-	return svc.repository.RemoveSubscription(ctx, ae.subscriberID)
+	if err := svc.repository.RemoveSubscription(ctx, ae.subscriberID); err != nil {
+		return err
+	}
+	if err := svc.repository.CloseSession(ctx, ae.subscriberID, ae.occurredAt); err != nil {
+		return err
+	}
+
+	return svc.markOffline(ctx, ae.clientID, time.Time{})
+}
+
+func (svc *service) addMqttSession(ctx context.Context, journal Journal) error {
+	ae, err := toMqttConnectEvent(journal)
+	if err != nil {
+		return err
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return err
+	}
+
+	session := ClientSession{
+		ID:           id,
+		ClientID:     ae.clientID,
+		SubscriberID: ae.subscriberID,
+		Protocol:     ae.protocol,
+		RemoteAddr:   ae.remoteAddr,
+		ConnectedAt:  ae.occurredAt,
+	}
+
+	return svc.repository.SaveSession(ctx, session)
 }
 
 func (svc *service) updateMessageCount(ctx context.Context, journal Journal) error {
@@ -227,7 +515,19 @@ func (svc *service) updateMessageCount(ctx context.Context, journal Journal) err
 	if err := svc.repository.IncrementInboundMessages(ctx, ct); err != nil {
 		return err
 	}
-	if err := svc.repository.IncrementOutboundMessages(ctx, ae.channelID, ae.subtopic); err != nil {
+	cht := ChannelTelemetry{
+		ClientID:        ae.clientID,
+		DomainID:        ae.domainID,
+		ChannelID:       ae.channelID,
+		Subtopic:        ae.subtopic,
+		InboundMessages: 1,
+		InboundBytes:    ae.payloadSize,
+		LastMessageAt:   ae.occurredAt,
+	}
+	if err := svc.repository.IncrementInboundChannelMessages(ctx, cht); err != nil {
+		return err
+	}
+	if err := svc.repository.IncrementOutboundMessages(ctx, ae.channelID, ae.subtopic, ae.payloadSize, ae.occurredAt); err != nil {
 		return err
 	}
 	return nil
@@ -273,6 +573,9 @@ type adapterEvent struct {
 	subscriberID string
 	topic        string
 	subtopic     string
+	protocol     string
+	remoteAddr   string
+	payloadSize  uint64
 	occurredAt   time.Time
 }
 
@@ -293,13 +596,16 @@ func toPublishEvent(journal Journal) (adapterEvent, error) {
 	if err != nil {
 		return adapterEvent{}, err
 	}
+	// Payload size is missing in the events of the older adapters.
+	size, _ := journal.Attributes["payload_size"].(float64)
 
 	return adapterEvent{
-		clientID:   clientID,
-		channelID:  channelID,
-		domainID:   domainID,
-		subtopic:   subtopic,
-		occurredAt: journal.OccurredAt,
+		clientID:    clientID,
+		channelID:   channelID,
+		domainID:    domainID,
+		subtopic:    subtopic,
+		payloadSize: uint64(size),
+		occurredAt:  journal.OccurredAt,
 	}, nil
 }
 
@@ -379,7 +685,35 @@ func toMqttDisconnectEvent(journal Journal) (adapterEvent, error) {
 
 	return adapterEvent{
 		subscriberID: subscriberID,
-		channelID:    clientID,
+		clientID:     clientID,
+		occurredAt:   journal.OccurredAt,
+	}, nil
+}
+
+func toMqttConnectEvent(journal Journal) (adapterEvent, error) {
+	clientID, err := getStringAttribute(journal, "client_id")
+	if err != nil {
+		return adapterEvent{}, err
+	}
+	subscriberID, err := getStringAttribute(journal, "subscriber_id")
+	if err != nil {
+		return adapterEvent{}, err
+	}
+	protocol, err := getStringAttribute(journal, "protocol")
+	if err != nil {
+		protocol = mqttProtocol
+	}
+	remoteAddr, err := getStringAttribute(journal, "remote_addr")
+	if err != nil {
+		remoteAddr = ""
+	}
+
+	return adapterEvent{
+		clientID:     clientID,
+		subscriberID: subscriberID,
+		protocol:     protocol,
+		remoteAddr:   remoteAddr,
+		occurredAt:   journal.OccurredAt,
 	}, nil
 }
 
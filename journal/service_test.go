@@ -4,9 +4,14 @@
 package journal_test
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,6 +21,7 @@ import (
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,12 +39,36 @@ var (
 			"sensor_id": rand.Intn(1000),
 		},
 	}
-	idProvider = uuid.New()
+	idProvider              = uuid.New()
+	exportPub, exportKey, _ = ed25519.GenerateKey(nil)
 )
 
+func makeChain(t *testing.T, domain string, n int, start time.Time) []journal.Journal {
+	var chain []journal.Journal
+	prevHash := ""
+	for i := 1; i <= n; i++ {
+		j := journal.Journal{
+			ID:         testsutil.GenerateUUID(t),
+			Domain:     domain,
+			Operation:  "client.create",
+			OccurredAt: start.Add(time.Duration(i) * time.Minute),
+			Attributes: map[string]any{"id": testsutil.GenerateUUID(t), "domain": domain},
+			Sequence:   uint64(i),
+			PrevHash:   prevHash,
+		}
+		hash, err := j.ComputeHash()
+		assert.Nil(t, err, fmt.Sprintf("unexpected error computing hash: %s", err))
+		j.Hash = hash
+		prevHash = hash
+		chain = append(chain, j)
+	}
+
+	return chain
+}
+
 func TestSave(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
 
 	cases := []struct {
 		desc    string
@@ -71,7 +101,7 @@ func TestSave(t *testing.T) {
 
 func TestReadAll(t *testing.T) {
 	repo := new(mocks.Repository)
-	svc := journal.NewService(idProvider, repo)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
 
 	validSession := smqauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
 	validPage := journal.Page{
@@ -145,3 +175,542 @@ func TestReadAll(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyChain(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
+
+	domainID := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: domainID}
+	chain := makeChain(t, domainID, 5, time.Now().Add(-time.Hour))
+	head := journal.ChainHead{Domain: domainID, Sequence: 5, Hash: chain[4].Hash}
+
+	modified := chain[3]
+	modified.Attributes = map[string]any{"id": testsutil.GenerateUUID(t)}
+
+	cases := []struct {
+		desc       string
+		head       journal.ChainHead
+		headErr    error
+		journals   []journal.Journal
+		chainErr   error
+		valid      bool
+		violations []journal.ChainViolation
+		err        error
+	}{
+		{
+			desc:     "verify valid chain",
+			head:     head,
+			journals: chain,
+			valid:    true,
+		},
+		{
+			desc:  "verify empty chain",
+			head:  journal.ChainHead{Domain: domainID},
+			valid: true,
+		},
+		{
+			desc: "verify chain with archived journals",
+			head: journal.ChainHead{
+				Domain:           domainID,
+				Sequence:         5,
+				Hash:             chain[4].Hash,
+				ArchivedSequence: 2,
+				ArchivedHash:     chain[1].Hash,
+			},
+			journals: chain[2:],
+			valid:    true,
+		},
+		{
+			desc:     "verify chain with journals saved after head",
+			head:     journal.ChainHead{Domain: domainID, Sequence: 3, Hash: chain[2].Hash},
+			journals: chain,
+			valid:    true,
+		},
+		{
+			desc:       "verify chain with missing journal",
+			head:       head,
+			journals:   append(append([]journal.Journal{}, chain[:2]...), chain[3:]...),
+			violations: []journal.ChainViolation{{Sequence: 3, Reason: journal.MissingEntries}},
+		},
+		{
+			desc:       "verify chain with modified journal",
+			head:       head,
+			journals:   []journal.Journal{chain[0], chain[1], chain[2], modified, chain[4]},
+			violations: []journal.ChainViolation{{Sequence: 4, ID: modified.ID, Reason: journal.HashMismatch}},
+		},
+		{
+			desc:       "verify chain with missing last journal",
+			head:       head,
+			journals:   chain[:4],
+			violations: []journal.ChainViolation{{Sequence: 5, Reason: journal.MissingEntries}},
+		},
+		{
+			desc:       "verify chain with modified head",
+			head:       journal.ChainHead{Domain: domainID, Sequence: 5, Hash: chain[3].Hash},
+			journals:   chain,
+			violations: []journal.ChainViolation{{Sequence: 5, Reason: journal.HeadMismatch}},
+		},
+		{
+			desc:    "verify chain with failed head retrieval",
+			headErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "verify chain with failed chain retrieval",
+			head:     head,
+			chainErr: repoerr.ErrViewEntity,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			headCall := repo.On("RetrieveChainHead", context.Background(), domainID).Return(tc.head, tc.headErr)
+			chainCall := repo.On("RetrieveChain", context.Background(), mock.Anything).Return(tc.journals, tc.chainErr)
+			v, err := svc.VerifyChain(context.Background(), session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.valid, v.Valid, tc.desc)
+				assert.Equal(t, tc.violations, v.Violations, tc.desc)
+			}
+			headCall.Unset()
+			chainCall.Unset()
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
+
+	domainID := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{DomainUserID: testsutil.GenerateUUID(t), UserID: testsutil.GenerateUUID(t), DomainID: domainID}
+	chain := makeChain(t, domainID, 5, time.Now().Add(-time.Hour))
+	page := journal.ChainPage{From: time.Now().Add(-2 * time.Hour), To: time.Now()}
+
+	cases := []struct {
+		desc     string
+		journals []journal.Journal
+		repoErr  error
+		lines    int
+		err      error
+	}{
+		{
+			desc:     "export journals",
+			journals: chain,
+			lines:    6,
+		},
+		{
+			desc:  "export empty range",
+			lines: 1,
+		},
+		{
+			desc:    "export with failed retrieval",
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveChain", context.Background(), journal.ChainPage{Domain: domainID, From: page.From, To: page.To, Limit: 1000}).Return(tc.journals, tc.repoErr)
+			var buf bytes.Buffer
+			err := svc.Export(context.Background(), session, page, &buf)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.lines, bytes.Count(buf.Bytes(), []byte("\n")), tc.desc)
+				err = journal.VerifyExport(bytes.NewReader(buf.Bytes()), exportPub)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error verifying export: %s", tc.desc, err))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestVerifyExport(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
+
+	domainID := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{DomainID: domainID}
+	repo.On("RetrieveChain", context.Background(), mock.Anything).Return(makeChain(t, domainID, 3, time.Now()), nil)
+	var buf bytes.Buffer
+	err := svc.Export(context.Background(), session, journal.ChainPage{}, &buf)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error exporting journals: %s", err))
+	export := buf.Bytes()
+	lines := bytes.SplitAfter(export, []byte("\n"))
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+
+	cases := []struct {
+		desc   string
+		export []byte
+		key    ed25519.PublicKey
+		err    error
+	}{
+		{
+			desc:   "verify export",
+			export: export,
+			key:    exportPub,
+		},
+		{
+			desc:   "verify export with modified journal",
+			export: bytes.Replace(export, []byte("client.create"), []byte("client.remove"), 1),
+			key:    exportPub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "verify export with removed journal",
+			export: bytes.Join(append(lines[:1:1], lines[2:]...), nil),
+			key:    exportPub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "verify export with other key",
+			export: export,
+			key:    otherPub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "verify export without signature",
+			export: bytes.Join(lines[:3], nil),
+			key:    exportPub,
+			err:    journal.ErrInvalidSignature,
+		},
+		{
+			desc:   "verify empty export",
+			export: nil,
+			key:    exportPub,
+			err:    journal.ErrInvalidSignature,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := journal.VerifyExport(bytes.NewReader(tc.export), tc.key)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestArchive(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	start := time.Now().Add(-time.Hour)
+	chain := makeChain(t, domainID, 5, start)
+	head := journal.ChainHead{Domain: domainID, Sequence: 5, Hash: chain[4].Hash}
+
+	cases := []struct {
+		desc      string
+		archive   bool
+		before    time.Time
+		heads     []journal.ChainHead
+		headsErr  error
+		deleteSeq uint64
+		deleteErr error
+		unchErr   error
+		file      string
+		err       error
+	}{
+		{
+			desc:      "archive expired journals",
+			archive:   true,
+			before:    start.Add(3*time.Minute + time.Second),
+			heads:     []journal.ChainHead{head},
+			deleteSeq: 3,
+			file:      fmt.Sprintf("journal-%s-1-3.ndjson", domainID),
+		},
+		{
+			desc:      "remove expired journals without archive",
+			before:    start.Add(2*time.Minute + time.Second),
+			heads:     []journal.ChainHead{head},
+			deleteSeq: 2,
+		},
+		{
+			desc:    "archive without expired journals",
+			archive: true,
+			before:  start,
+			heads:   []journal.ChainHead{head},
+		},
+		{
+			desc:     "archive with failed heads retrieval",
+			archive:  true,
+			before:   start.Add(time.Hour),
+			headsErr: repoerr.ErrViewEntity,
+			err:      repoerr.ErrViewEntity,
+		},
+		{
+			desc:      "archive with failed removal",
+			archive:   true,
+			before:    start.Add(time.Hour),
+			heads:     []journal.ChainHead{head},
+			deleteSeq: 5,
+			deleteErr: repoerr.ErrRemoveEntity,
+			file:      fmt.Sprintf("journal-%s-1-5.ndjson", domainID),
+			err:       repoerr.ErrRemoveEntity,
+		},
+		{
+			desc:    "archive with failed unchained journals removal",
+			before:  start,
+			heads:   []journal.ChainHead{head},
+			unchErr: repoerr.ErrRemoveEntity,
+			err:     repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			dir := ""
+			if tc.archive {
+				dir = t.TempDir()
+			}
+			svc := journal.NewService(idProvider, repo, exportKey, dir)
+
+			repo.On("RetrieveChainHeads", context.Background()).Return(tc.heads, tc.headsErr)
+			if tc.heads != nil {
+				repo.On("RetrieveChain", context.Background(), journal.ChainPage{Domain: domainID, Limit: 1000}).Return(chain, nil)
+			}
+			if tc.deleteSeq > 0 {
+				repo.On("DeleteChain", context.Background(), domainID, tc.deleteSeq, chain[tc.deleteSeq-1].Hash).Return(tc.deleteErr)
+			}
+			if tc.headsErr == nil {
+				repo.On("DeleteUnchained", context.Background(), tc.before).Return(tc.unchErr)
+			}
+			err := svc.Archive(context.Background(), tc.before)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if !tc.archive {
+				return
+			}
+
+			entries, err := os.ReadDir(dir)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error reading archive directory: %s", tc.desc, err))
+			if tc.file == "" {
+				assert.Empty(t, entries, tc.desc)
+				return
+			}
+			assert.Len(t, entries, 1, tc.desc)
+			f, err := os.Open(filepath.Join(dir, tc.file))
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error opening archive: %s", tc.desc, err))
+			defer f.Close()
+			err = journal.VerifyExport(bufio.NewReader(f), exportPub)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error verifying archive: %s", tc.desc, err))
+		})
+	}
+}
+
+func TestRetrieveClientsPresence(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := journal.NewService(idProvider, repo, exportKey, "")
+
+	session := smqauthn.Session{DomainID: testsutil.GenerateUUID(t)}
+	presence := journal.ClientsPresencePage{
+		Total: 1,
+		Limit: 10,
+		Clients: []journal.ClientPresence{
+			{
+				ClientID: testsutil.GenerateUUID(t),
+				DomainID: session.DomainID,
+				Online:   true,
+				LastSeen: time.Now().UTC(),
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		page     journal.PresencePage
+		response journal.ClientsPresencePage
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "retrieve clients presence successfully",
+			page:     journal.PresencePage{Limit: 10, Status: journal.OnlineStatus},
+			response: presence,
+		},
+		{
+			desc:    "retrieve clients presence with repo error",
+			page:    journal.PresencePage{Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrievePresence", context.Background(), session.DomainID, tc.page).Return(tc.response, tc.repoErr)
+			page, err := svc.RetrieveClientsPresence(context.Background(), session, tc.page)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, page, tc.desc)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdatePresence(t *testing.T) {
+	domainID := testsutil.GenerateUUID(t)
+	inactiveSince := time.Now().Add(-time.Minute)
+	offline := []journal.ClientPresence{
+		{ClientID: testsutil.GenerateUUID(t), DomainID: domainID, LastSeen: inactiveSince.Add(-time.Hour)},
+		{ClientID: testsutil.GenerateUUID(t), DomainID: domainID, LastSeen: inactiveSince.Add(-time.Minute)},
+	}
+
+	cases := []struct {
+		desc       string
+		offline    []journal.ClientPresence
+		markErr    error
+		saveErr    error
+		savedCount int
+		err        error
+	}{
+		{
+			desc:       "update presence with clients gone offline",
+			offline:    offline,
+			savedCount: 2,
+		},
+		{
+			desc: "update presence without clients gone offline",
+		},
+		{
+			desc:    "update presence with failed marking",
+			markErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:       "update presence with failed journal save",
+			offline:    offline,
+			saveErr:    repoerr.ErrCreateEntity,
+			savedCount: 1,
+			err:        repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			svc := journal.NewService(idProvider, repo, exportKey, "")
+
+			repo.On("MarkOffline", context.Background(), "", inactiveSince).Return(tc.offline, tc.markErr)
+			if tc.savedCount > 0 {
+				repo.On("Save", context.Background(), mock.MatchedBy(func(j journal.Journal) bool {
+					return j.Operation == "client.went_offline" && j.Attributes["domain"] == domainID
+				})).Return(tc.saveErr).Times(tc.savedCount)
+			}
+			err := svc.UpdatePresence(context.Background(), inactiveSince)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSaveClientSession(t *testing.T) {
+	clientID := testsutil.GenerateUUID(t)
+	occurredAt := time.Now().UTC()
+
+	cases := []struct {
+		desc    string
+		journal journal.Journal
+		setup   func(repo *mocks.Repository)
+		err     error
+	}{
+		{
+			desc: "save client connect",
+			journal: journal.Journal{
+				Operation:  "mqtt.client_connect",
+				OccurredAt: occurredAt,
+				Attributes: map[string]any{
+					"client_id":     clientID,
+					"subscriber_id": "subscriber",
+					"remote_addr":   "127.0.0.1:1883",
+				},
+			},
+			setup: func(repo *mocks.Repository) {
+				repo.On("SaveSession", context.Background(), mock.MatchedBy(func(s journal.ClientSession) bool {
+					return s.ClientID == clientID && s.SubscriberID == "subscriber" && s.Protocol == "mqtt" &&
+						s.RemoteAddr == "127.0.0.1:1883" && s.ConnectedAt.Equal(occurredAt)
+				})).Return(nil)
+			},
+		},
+		{
+			desc: "save client connect without subscriber",
+			journal: journal.Journal{
+				Operation:  "mqtt.client_connect",
+				OccurredAt: occurredAt,
+				Attributes: map[string]any{"client_id": clientID},
+			},
+			err: errors.New("failed to handle client telemetry"),
+		},
+		{
+			desc: "save client disconnect",
+			journal: journal.Journal{
+				Operation:  "mqtt.client_disconnect",
+				OccurredAt: occurredAt,
+				Attributes: map[string]any{
+					"client_id":     clientID,
+					"subscriber_id": "subscriber",
+				},
+			},
+			setup: func(repo *mocks.Repository) {
+				repo.On("RemoveSubscription", context.Background(), "subscriber").Return(nil)
+				repo.On("CloseSession", context.Background(), "subscriber", occurredAt).Return(nil)
+				repo.On("MarkOffline", context.Background(), clientID, time.Time{}).Return(nil, nil)
+			},
+		},
+		{
+			desc: "save client disconnect with failed session close",
+			journal: journal.Journal{
+				Operation:  "mqtt.client_disconnect",
+				OccurredAt: occurredAt,
+				Attributes: map[string]any{
+					"client_id":     clientID,
+					"subscriber_id": "subscriber",
+				},
+			},
+			setup: func(repo *mocks.Repository) {
+				repo.On("RemoveSubscription", context.Background(), "subscriber").Return(nil)
+				repo.On("CloseSession", context.Background(), "subscriber", occurredAt).Return(repoerr.ErrUpdateEntity)
+			},
+			err: repoerr.ErrUpdateEntity,
+		},
+		{
+			desc: "save client publish",
+			journal: journal.Journal{
+				Operation:  "messaging.client_publish",
+				OccurredAt: occurredAt,
+				Attributes: map[string]any{
+					"client_id":    clientID,
+					"domain_id":    "domain",
+					"channel_id":   "channel",
+					"subtopic":     "temperature",
+					"payload_size": float64(128),
+				},
+			},
+			setup: func(repo *mocks.Repository) {
+				repo.On("IncrementInboundMessages", context.Background(), mock.Anything).Return(nil)
+				repo.On("IncrementInboundChannelMessages", context.Background(), journal.ChannelTelemetry{
+					ClientID:        clientID,
+					DomainID:        "domain",
+					ChannelID:       "channel",
+					Subtopic:        "temperature",
+					InboundMessages: 1,
+					InboundBytes:    128,
+					LastMessageAt:   occurredAt,
+				}).Return(nil)
+				repo.On("IncrementOutboundMessages", context.Background(), "channel", "temperature", uint64(128), occurredAt).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			svc := journal.NewService(idProvider, repo, exportKey, "")
+
+			repo.On("Save", context.Background(), mock.Anything).Return(nil)
+			if tc.setup != nil {
+				tc.setup(repo)
+			}
+			err := svc.Save(context.Background(), tc.journal)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
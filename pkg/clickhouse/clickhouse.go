@@ -0,0 +1,275 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Code of the ClickHouse exception raised when the table does not exist.
+const unknownTableCode = "Code: 60."
+
+var (
+	// ErrUnknownTable indicates that the table used in the query does not exist.
+	ErrUnknownTable = errors.New("unknown table")
+
+	errConnect   = errors.New("failed to connect to clickhouse server")
+	errMigration = errors.New("failed to apply migrations")
+	errQuery     = errors.New("failed to execute clickhouse query")
+)
+
+// Parameter values are sent in the escaped format, so the special characters
+// of the strings must be escaped.
+var escaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`)
+
+type Config struct {
+	URL  string `env:"URL"  envDefault:"http://localhost:8123"`
+	User string `env:"USER" envDefault:"supermq"`
+	Pass string `env:"PASS" envDefault:"supermq"`
+	Name string `env:"NAME" envDefault:""`
+}
+
+// Client executes the queries over the ClickHouse HTTP interface.
+type Client struct {
+	url  url.URL
+	cfg  Config
+	http *http.Client
+}
+
+// Setup creates the client of the ClickHouse instance, creates the configured
+// database if it does not exist and applies the given migrations. Migrations
+// are expected to be idempotent, since ClickHouse keeps no record of them.
+//
+// For example:
+//
+//	client, err := clickhouse.Setup(ctx, clickhouse.Config{}, []string{})
+func Setup(ctx context.Context, cfg Config, migrations []string) (*Client, error) {
+	c, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Ping(ctx); err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	if cfg.Name != "" {
+		// Database is created from the default one, since the configured one
+		// does not exist yet.
+		body, err := c.do(ctx, "default", fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", cfg.Name), nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(errMigration, err)
+		}
+		body.Close()
+	}
+	for _, m := range migrations {
+		if err := c.Exec(ctx, m, nil); err != nil {
+			return nil, errors.Wrap(errMigration, err)
+		}
+	}
+
+	return c, nil
+}
+
+// Connect creates the client of the ClickHouse instance.
+//
+// For example:
+//
+//	client, err := clickhouse.Connect(clickhouse.Config{})
+func Connect(cfg Config) (*Client, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	return &Client{url: *u, cfg: cfg, http: &http.Client{}}, nil
+}
+
+// Ping checks that the ClickHouse instance is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	u := c.url
+	u.Path = "/ping"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected ping response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Exec executes the query which returns no rows. Parameters are referenced in
+// the query as {name:Type}.
+func (c *Client) Exec(ctx context.Context, query string, params map[string]any) error {
+	body, err := c.do(ctx, c.cfg.Name, query, params, nil)
+	if err != nil {
+		return err
+	}
+
+	return body.Close()
+}
+
+// Insert inserts the rows into the table in a single statement. Rows are
+// encoded as JSON objects whose keys are the column names, and the columns
+// missing from the object get their default values.
+func (c *Client) Insert(ctx context.Context, table string, rows []any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return errors.Wrap(errQuery, err)
+		}
+	}
+
+	body, err := c.do(ctx, c.cfg.Name, fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", table), nil, &data)
+	if err != nil {
+		return err
+	}
+
+	return body.Close()
+}
+
+// Query executes the query and returns the rows it reads. The rows are read
+// from the response as they are consumed, so they must be closed.
+func (c *Client) Query(ctx context.Context, query string, params map[string]any) (*Rows, error) {
+	body, err := c.do(ctx, c.cfg.Name, query+" FORMAT JSONEachRow", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rows{body: body, dec: json.NewDecoder(body)}, nil
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.http.CloseIdleConnections()
+}
+
+func (c *Client) do(ctx context.Context, database, query string, params map[string]any, data io.Reader) (io.ReadCloser, error) {
+	q := url.Values{}
+	q.Set("query", query)
+	if database != "" {
+		q.Set("database", database)
+	}
+	// Numbers are returned unquoted, so they can be decoded into the numeric
+	// fields.
+	q.Set("output_format_json_quote_64bit_integers", "0")
+	for name, v := range params {
+		q.Set("param_"+name, formatParam(v))
+	}
+	u := c.url
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), data)
+	if err != nil {
+		return nil, errors.Wrap(errQuery, err)
+	}
+	req.Header.Set("X-ClickHouse-User", c.cfg.User)
+	req.Header.Set("X-ClickHouse-Key", c.cfg.Pass)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(errQuery, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(errQuery, err)
+		}
+		return nil, queryError(string(msg))
+	}
+
+	return resp.Body, nil
+}
+
+func queryError(msg string) error {
+	err := errors.New(strings.TrimSpace(msg))
+	if strings.Contains(msg, unknownTableCode) {
+		return errors.Wrap(ErrUnknownTable, err)
+	}
+
+	return errors.Wrap(errQuery, err)
+}
+
+func formatParam(v any) string {
+	switch v := v.(type) {
+	case string:
+		return escaper.Replace(v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = "'" + strings.ReplaceAll(escaper.Replace(s), "'", `\'`) + "'"
+		}
+		return "[" + strings.Join(quoted, ",") + "]"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Rows reads the rows returned by the query one by one.
+type Rows struct {
+	body io.ReadCloser
+	dec  *json.Decoder
+	row  json.RawMessage
+	err  error
+}
+
+// Next reads the next row. It returns false when there are no more rows or
+// the reading failed, which is reported by Err.
+func (r *Rows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	r.row = nil
+	if err := r.dec.Decode(&r.row); err != nil {
+		if err != io.EOF {
+			r.err = errors.Wrap(errQuery, err)
+		}
+		return false
+	}
+
+	return true
+}
+
+// Scan decodes the current row into the given value. Columns are matched to
+// the JSON fields of the value.
+func (r *Rows) Scan(dest any) error {
+	if err := json.Unmarshal(r.row, dest); err != nil {
+		return errors.Wrap(errQuery, err)
+	}
+
+	return nil
+}
+
+// Err returns the error which stopped the reading of the rows.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close releases the response of the query.
+func (r *Rows) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	user = "user"
+	pass = "pass"
+	name = "messages"
+)
+
+type request struct {
+	query url.Values
+	user  string
+	pass  string
+	body  string
+}
+
+// newServer starts the server which records the requests and responds to the
+// queries with the given status and body.
+func newServer(t *testing.T, status int, resp string) (*httptest.Server, *[]request) {
+	reqs := []request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			fmt.Fprint(w, "Ok.\n")
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err, fmt.Sprintf("unexpected error reading request body: %s", err))
+		reqs = append(reqs, request{
+			query: r.URL.Query(),
+			user:  r.Header.Get("X-ClickHouse-User"),
+			pass:  r.Header.Get("X-ClickHouse-Key"),
+			body:  string(body),
+		})
+		w.WriteHeader(status)
+		fmt.Fprint(w, resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &reqs
+}
+
+func TestSetup(t *testing.T) {
+	srv, reqs := newServer(t, http.StatusOK, "")
+
+	c, err := clickhouse.Setup(context.Background(), clickhouse.Config{URL: srv.URL, User: user, Pass: pass, Name: name}, []string{"CREATE TABLE IF NOT EXISTS t (a String) ENGINE = Memory"})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	defer c.Close()
+
+	require.Len(t, *reqs, 2)
+	assert.Equal(t, "CREATE DATABASE IF NOT EXISTS messages", (*reqs)[0].query.Get("query"))
+	assert.Equal(t, "default", (*reqs)[0].query.Get("database"))
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS t (a String) ENGINE = Memory", (*reqs)[1].query.Get("query"))
+	assert.Equal(t, name, (*reqs)[1].query.Get("database"))
+	assert.Equal(t, user, (*reqs)[1].user)
+	assert.Equal(t, pass, (*reqs)[1].pass)
+}
+
+func TestExec(t *testing.T) {
+	cases := []struct {
+		desc   string
+		status int
+		resp   string
+		params map[string]any
+		values map[string]string
+		err    error
+	}{
+		{
+			desc:   "execute query with parameters",
+			status: http.StatusOK,
+			params: map[string]any{
+				"channel": "ch\tannel",
+				"from":    float64(1700000000000000000),
+				"limit":   uint64(10),
+				"names":   []string{"temp", "o'clock"},
+			},
+			values: map[string]string{
+				"param_channel": `ch\tannel`,
+				"param_from":    "1700000000000000000",
+				"param_limit":   "10",
+				"param_names":   `['temp','o\'clock']`,
+			},
+			err: nil,
+		},
+		{
+			desc:   "execute query on unknown table",
+			status: http.StatusNotFound,
+			resp:   "Code: 60. DB::Exception: Table messages.unknown does not exist. (UNKNOWN_TABLE)",
+			err:    clickhouse.ErrUnknownTable,
+		},
+		{
+			desc:   "execute invalid query",
+			status: http.StatusBadRequest,
+			resp:   "Code: 62. DB::Exception: Syntax error. (SYNTAX_ERROR)",
+			err:    errors.New("Code: 62. DB::Exception: Syntax error. (SYNTAX_ERROR)"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			srv, reqs := newServer(t, tc.status, tc.resp)
+			c, err := clickhouse.Connect(clickhouse.Config{URL: srv.URL, Name: name})
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+			err = c.Exec(context.Background(), "ALTER TABLE t DELETE WHERE channel = {channel:String}", tc.params)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+			require.Len(t, *reqs, 1)
+			for k, v := range tc.values {
+				assert.Equal(t, v, (*reqs)[0].query.Get(k), fmt.Sprintf("%s: unexpected value of %s", tc.desc, k))
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	srv, reqs := newServer(t, http.StatusOK, "")
+	c, err := clickhouse.Connect(clickhouse.Config{URL: srv.URL, Name: name})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	type row struct {
+		Channel string  `json:"channel"`
+		Value   float64 `json:"value"`
+	}
+	err = c.Insert(context.Background(), "messages", []any{row{"a", 1}, row{"b", 2.5}})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, "INSERT INTO messages FORMAT JSONEachRow", (*reqs)[0].query.Get("query"))
+	assert.Equal(t, "{\"channel\":\"a\",\"value\":1}\n{\"channel\":\"b\",\"value\":2.5}\n", (*reqs)[0].body)
+
+	err = c.Insert(context.Background(), "messages", nil)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Len(t, *reqs, 1)
+}
+
+func TestQuery(t *testing.T) {
+	type row struct {
+		Channel string   `json:"channel"`
+		Value   *float64 `json:"value"`
+	}
+	v := 2.5
+
+	cases := []struct {
+		desc string
+		resp string
+		rows []row
+		err  error
+	}{
+		{
+			desc: "query rows",
+			resp: "{\"channel\":\"a\",\"value\":null}\n{\"channel\":\"b\",\"value\":2.5}\n",
+			rows: []row{{Channel: "a"}, {Channel: "b", Value: &v}},
+		},
+		{
+			desc: "query without rows",
+			resp: "",
+			rows: []row{},
+		},
+		{
+			desc: "query with exception in the middle of the response",
+			resp: "{\"channel\":\"a\",\"value\":null}\nCode: 241. DB::Exception: Memory limit exceeded.",
+			rows: []row{{Channel: "a"}},
+			err:  errors.New("failed to execute clickhouse query"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			srv, reqs := newServer(t, http.StatusOK, tc.resp)
+			c, err := clickhouse.Connect(clickhouse.Config{URL: srv.URL, Name: name})
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+			rs, err := c.Query(context.Background(), "SELECT channel, value FROM messages", nil)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			defer rs.Close()
+
+			rows := []row{}
+			for rs.Next() {
+				var r row
+				err := rs.Scan(&r)
+				require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+				rows = append(rows, r)
+			}
+			assert.Equal(t, tc.rows, rows, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rows, rows))
+			assert.True(t, errors.Contains(rs.Err(), tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, rs.Err()))
+			assert.Equal(t, "SELECT channel, value FROM messages FORMAT JSONEachRow", (*reqs)[0].query.Get("query"))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clickhouse contains the domain concept definitions needed to support
+// SuperMQ ClickHouse database functionality.
+//
+// It provides the client of the ClickHouse HTTP interface, which is used to
+// configure, setup and query the ClickHouse database.
+package clickhouse
@@ -18,19 +18,21 @@ var (
 )
 
 type publishEvent struct {
-	domainID  string
-	channelID string
-	clientID  string
-	subtopic  string
+	domainID    string
+	channelID   string
+	clientID    string
+	subtopic    string
+	payloadSize int
 }
 
 func (pe publishEvent) Encode() (map[string]any, error) {
 	return map[string]any{
-		"operation":  clientPublish,
-		"domain_id":  pe.domainID,
-		"channel_id": pe.channelID,
-		"client_id":  pe.clientID,
-		"subtopic":   pe.subtopic,
+		"operation":    clientPublish,
+		"domain_id":    pe.domainID,
+		"channel_id":   pe.channelID,
+		"client_id":    pe.clientID,
+		"subtopic":     pe.subtopic,
+		"payload_size": pe.payloadSize,
 	}, nil
 }
 
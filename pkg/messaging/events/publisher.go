@@ -36,10 +36,11 @@ func (es *publisherES) Publish(ctx context.Context, topic string, msg *messaging
 	}
 
 	me := publishEvent{
-		domainID:  msg.Domain,
-		channelID: msg.Channel,
-		clientID:  msg.ClientIdentity(),
-		subtopic:  msg.Subtopic,
+		domainID:    msg.Domain,
+		channelID:   msg.Channel,
+		clientID:    msg.ClientIdentity(),
+		subtopic:    msg.Subtopic,
+		payloadSize: len(msg.GetPayload()),
 	}
 
 	return es.ep.Publish(ctx, publishStream, me)
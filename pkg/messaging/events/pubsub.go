@@ -43,10 +43,11 @@ func (es *pubsubES) Publish(ctx context.Context, topic string, msg *messaging.Me
 	}
 
 	me := publishEvent{
-		domainID:  msg.Domain,
-		channelID: msg.Channel,
-		clientID:  msg.ClientIdentity(),
-		subtopic:  msg.Subtopic,
+		domainID:    msg.Domain,
+		channelID:   msg.Channel,
+		clientID:    msg.ClientIdentity(),
+		subtopic:    msg.Subtopic,
+		payloadSize: len(msg.GetPayload()),
 	}
 
 	return es.ep.Publish(ctx, publishStream, me)
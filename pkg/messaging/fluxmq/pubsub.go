@@ -107,11 +107,16 @@ func (ps *pubsub) Subscribe(_ context.Context, cfg messaging.SubscriberConfig) e
 		opts.Offset = "first"
 	}
 
-	if err := ps.client.SubscribeToStream(opts, func(msg *fluxamqp.QueueMessage) {
+	handle := func(msg *fluxamqp.QueueMessage) {
 		if err := ps.handle(cfg.Handler, msg); err != nil {
 			ps.logWarn("failed to process FluxMQ stream message", "error", err, "topic", cfg.Topic, "consumer_group", group)
 		}
-	}); err != nil {
+	}
+	if cfg.Concurrency > 1 && !cfg.Ordered {
+		handle = concurrentHandler(handle, cfg.Concurrency)
+	}
+
+	if err := ps.client.SubscribeToStream(opts, handle); err != nil {
 		return err
 	}
 
@@ -206,6 +211,21 @@ func (ps *pubsub) handle(h messaging.MessageHandler, msg *fluxamqp.QueueMessage)
 	return nil
 }
 
+// concurrentHandler handles up to n stream messages at the same time. Messages
+// are acknowledged by their own handlers, so the acknowledgement may be delayed
+// until the message is processed together with the other messages.
+func concurrentHandler(h func(msg *fluxamqp.QueueMessage), n int) func(msg *fluxamqp.QueueMessage) {
+	sem := make(chan struct{}, n)
+
+	return func(msg *fluxamqp.QueueMessage) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			h(msg)
+		}()
+	}
+}
+
 func messageFromDelivery(body []byte, headers map[string]any, ts time.Time, prefix, mqttTopic string) (*messaging.Message, error) {
 	domain, channel, subtopic, err := parseMQTTTopic(prefix, mqttTopic)
 	if err != nil {
@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "strings"
+
+const (
+	singleLevelWildcard = "+"
+	multiLevelWildcard  = "#"
+)
+
+// MatchTopic reports whether the topic matches the MQTT-style filter, where
+// + matches a single level and # matches all the remaining levels, including
+// the parent level. A + level of the topic matches only the + filter level.
+func MatchTopic(filter, topic string) bool {
+	f := strings.Split(filter, subtopicSep)
+	t := strings.Split(topic, subtopicSep)
+	for i, l := range f {
+		if l == multiLevelWildcard {
+			return true
+		}
+		if i >= len(t) || (l != singleLevelWildcard && l != t[i]) {
+			return false
+		}
+	}
+
+	return len(f) == len(t)
+}
+
+// TopicTrie indexes the values by the levels of their MQTT-style filters, so
+// a topic is matched against all the filters in a single walk. The topics are
+// matched the same way as by MatchTopic.
+type TopicTrie[T any] struct {
+	root *trieNode[T]
+}
+
+type trieNode[T any] struct {
+	children map[string]*trieNode[T]
+	// values are the values whose filter ends at this node.
+	values []T
+	// wildcard are the values with # at this level, which match any remaining levels.
+	wildcard []T
+}
+
+// NewTopicTrie returns the empty topic trie.
+func NewTopicTrie[T any]() *TopicTrie[T] {
+	return &TopicTrie[T]{root: newTrieNode[T]()}
+}
+
+func newTrieNode[T any]() *trieNode[T] {
+	return &trieNode[T]{children: make(map[string]*trieNode[T])}
+}
+
+// Insert adds the value matched by the filter.
+func (t *TopicTrie[T]) Insert(filter string, value T) {
+	n := t.root
+	for _, level := range strings.Split(filter, subtopicSep) {
+		if level == multiLevelWildcard {
+			n.wildcard = append(n.wildcard, value)
+			return
+		}
+		child, ok := n.children[level]
+		if !ok {
+			child = newTrieNode[T]()
+			n.children[level] = child
+		}
+		n = child
+	}
+	n.values = append(n.values, value)
+}
+
+// Match returns the values with a filter that matches the topic.
+func (t *TopicTrie[T]) Match(topic string) []T {
+	var values []T
+	t.root.match(strings.Split(topic, subtopicSep), &values)
+
+	return values
+}
+
+func (n *trieNode[T]) match(levels []string, values *[]T) {
+	*values = append(*values, n.wildcard...)
+	if len(levels) == 0 {
+		*values = append(*values, n.values...)
+		return
+	}
+	if child, ok := n.children[levels[0]]; ok {
+		child.match(levels[1:], values)
+	}
+	// A topic level "+" is already matched as the exact level above.
+	if child, ok := n.children[singleLevelWildcard]; ok && levels[0] != singleLevelWildcard {
+		child.match(levels[1:], values)
+	}
+}
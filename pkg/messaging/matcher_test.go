@@ -0,0 +1,116 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		desc   string
+		filter string
+		topic  string
+		match  bool
+	}{
+		{
+			desc:   "exact topic",
+			filter: "sensors/temp",
+			topic:  "sensors/temp",
+			match:  true,
+		},
+		{
+			desc:   "different topic",
+			filter: "sensors/temp",
+			topic:  "sensors/hum",
+			match:  false,
+		},
+		{
+			desc:   "longer topic",
+			filter: "sensors/temp",
+			topic:  "sensors/temp/1",
+			match:  false,
+		},
+		{
+			desc:   "shorter topic",
+			filter: "sensors/temp",
+			topic:  "sensors",
+			match:  false,
+		},
+		{
+			desc:   "single level wildcard",
+			filter: "sensors/+/value",
+			topic:  "sensors/temp/value",
+			match:  true,
+		},
+		{
+			desc:   "single level wildcard with missing level",
+			filter: "sensors/+",
+			topic:  "sensors",
+			match:  false,
+		},
+		{
+			desc:   "single level wildcard in topic",
+			filter: "sensors/temp",
+			topic:  "sensors/+",
+			match:  false,
+		},
+		{
+			desc:   "single level wildcard in topic and filter",
+			filter: "sensors/+",
+			topic:  "sensors/+",
+			match:  true,
+		},
+		{
+			desc:   "multi level wildcard",
+			filter: "sensors/#",
+			topic:  "sensors/temp/1",
+			match:  true,
+		},
+		{
+			desc:   "multi level wildcard with parent level",
+			filter: "sensors/#",
+			topic:  "sensors",
+			match:  true,
+		},
+		{
+			desc:   "multi level wildcard with different level",
+			filter: "sensors/#",
+			topic:  "actuators/temp",
+			match:  false,
+		},
+		{
+			desc:   "multi level wildcard only",
+			filter: "#",
+			topic:  "sensors/temp",
+			match:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.match, messaging.MatchTopic(tc.filter, tc.topic))
+
+			trie := messaging.NewTopicTrie[string]()
+			trie.Insert(tc.filter, tc.filter)
+			assert.Equal(t, tc.match, len(trie.Match(tc.topic)) == 1, "trie must match the same way as MatchTopic")
+		})
+	}
+}
+
+func TestTopicTrie(t *testing.T) {
+	trie := messaging.NewTopicTrie[int]()
+	filters := []string{"sensors/temp", "sensors/+", "sensors/#", "#", "sensors/+/value", "actuators/#"}
+	for i, f := range filters {
+		trie.Insert(f, i)
+	}
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3}, trie.Match("sensors/temp"))
+	assert.ElementsMatch(t, []int{2, 3, 4}, trie.Match("sensors/temp/value"))
+	assert.ElementsMatch(t, []int{2, 3}, trie.Match("sensors"))
+	assert.ElementsMatch(t, []int{3, 5}, trie.Match("actuators/valve"))
+}
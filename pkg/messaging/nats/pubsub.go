@@ -108,6 +108,10 @@ func (ps *pubsub) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig)
 		return fmt.Errorf("failed to create consumer: %w", err)
 	}
 
+	if cfg.Concurrency > 1 && !cfg.Ordered {
+		nh = concurrentHandler(nh, cfg.Concurrency)
+	}
+
 	if _, err = consumer.Consume(nh); err != nil {
 		return fmt.Errorf("failed to consume: %w", err)
 	}
@@ -171,6 +175,21 @@ func (ps *pubsub) natsHandler(h messaging.MessageHandler) func(m jetstream.Msg)
 	}
 }
 
+// concurrentHandler handles up to n messages at the same time. Messages are
+// acknowledged by their own handlers, so the acknowledgement may be delayed
+// until the message is processed together with the other messages.
+func concurrentHandler(h func(m jetstream.Msg), n int) func(m jetstream.Msg) {
+	sem := make(chan struct{}, n)
+
+	return func(m jetstream.Msg) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			h(m)
+		}()
+	}
+}
+
 func (ps *pubsub) errAckType(err error) messaging.AckType {
 	if err == nil {
 		return messaging.Ack
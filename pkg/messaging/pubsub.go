@@ -77,6 +77,7 @@ type SubscriberConfig struct {
 	Handler        MessageHandler // Function that handles incoming messages.
 	DeliveryPolicy DeliveryPolicy // DeliverPolicy defines from which point to start delivering messages.
 	Ordered        bool           // Whether message delivery must preserve order.
+	Concurrency    int            // Number of messages handled concurrently, ignored for ordered delivery.
 }
 
 // Subscriber specifies message subscription API.
@@ -13,7 +13,10 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 )
 
-const alarmsEndpoint = "alarms"
+const (
+	alarmsEndpoint     = "alarms"
+	alarmStatsEndpoint = "stats"
+)
 
 // Alarm represents an alarm instance.
 type Alarm struct {
@@ -40,6 +43,9 @@ type Alarm struct {
 	AcknowledgedBy string    `json:"acknowledged_by,omitempty"`
 	ResolvedAt     time.Time `json:"resolved_at,omitempty"`
 	ResolvedBy     string    `json:"resolved_by,omitempty"`
+	Occurrences    uint64    `json:"occurrences,omitempty"`
+	LastOccurredAt time.Time `json:"last_occurred_at,omitempty"`
+	EscalatedAt    time.Time `json:"escalated_at,omitempty"`
 	Metadata       Metadata  `json:"metadata,omitempty"`
 }
 
@@ -50,6 +56,27 @@ type AlarmsPage struct {
 	Alarms []Alarm `json:"alarms"`
 }
 
+type AlarmStatsCount struct {
+	Key                   string    `json:"key"`
+	Bucket                time.Time `json:"bucket,omitempty"`
+	Count                 uint64    `json:"count"`
+	Acknowledged          uint64    `json:"acknowledged"`
+	Resolved              uint64    `json:"resolved"`
+	MeanTimeToAcknowledge float64   `json:"mean_time_to_acknowledge"`
+	MeanTimeToResolve     float64   `json:"mean_time_to_resolve"`
+}
+
+type AlarmStats struct {
+	GroupBy               string            `json:"group_by"`
+	Interval              string            `json:"interval,omitempty"`
+	Total                 uint64            `json:"total"`
+	Acknowledged          uint64            `json:"acknowledged"`
+	Resolved              uint64            `json:"resolved"`
+	MeanTimeToAcknowledge float64           `json:"mean_time_to_acknowledge"`
+	MeanTimeToResolve     float64           `json:"mean_time_to_resolve"`
+	Counts                []AlarmStatsCount `json:"counts"`
+}
+
 func (sdk mgSDK) UpdateAlarm(ctx context.Context, alarm Alarm, domainID, token string) (Alarm, errors.SDKError) {
 	data, err := json.Marshal(alarm)
 	if err != nil {
@@ -107,9 +134,156 @@ func (sdk mgSDK) ListAlarms(ctx context.Context, pm PageMetadata, domainID, toke
 	return ap, nil
 }
 
+func (sdk mgSDK) AlarmStats(ctx context.Context, pm PageMetadata, domainID, token string) (AlarmStats, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s", domainID, alarmsEndpoint, alarmStatsEndpoint)
+	url, err := sdk.withQueryParams(sdk.alarmsURL, endpoint, pm)
+	if err != nil {
+		return AlarmStats{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AlarmStats{}, sdkerr
+	}
+
+	var stats AlarmStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return AlarmStats{}, errors.NewSDKError(err)
+	}
+
+	return stats, nil
+}
+
 func (sdk mgSDK) DeleteAlarm(ctx context.Context, id, domainID, token string) errors.SDKError {
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.alarmsURL, domainID, alarmsEndpoint, id)
 
 	_, _, sdkerr := sdk.processRequest(ctx, http.MethodDelete, url, token, nil, nil, http.StatusNoContent, http.StatusOK)
 	return sdkerr
 }
+
+const notificationPoliciesEndpoint = "notification-policies"
+
+// AlarmNotificationPolicy defines who is notified about the alarms of the domain
+// with severity in the given range, and whom to escalate the alarm to if it is
+// not acknowledged in time.
+type AlarmNotificationPolicy struct {
+	ID          string                 `json:"id,omitempty"`
+	DomainID    string                 `json:"domain_id,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	MinSeverity uint8                  `json:"min_severity"`
+	MaxSeverity uint8                  `json:"max_severity"`
+	Events      []string               `json:"events,omitempty"`
+	Recipients  AlarmRecipients        `json:"recipients"`
+	Escalation  []AlarmEscalationLevel `json:"escalation,omitempty"`
+	CreatedAt   time.Time              `json:"created_at,omitempty"`
+	CreatedBy   string                 `json:"created_by,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
+	UpdatedBy   string                 `json:"updated_by,omitempty"`
+}
+
+type AlarmRecipients struct {
+	Emails   []string       `json:"emails,omitempty"`
+	Webhooks []AlarmWebhook `json:"webhooks,omitempty"`
+}
+
+type AlarmWebhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type AlarmEscalationLevel struct {
+	AfterMinutes uint32          `json:"after_minutes"`
+	Recipients   AlarmRecipients `json:"recipients"`
+}
+
+type AlarmNotificationPoliciesPage struct {
+	Offset   uint64                    `json:"offset"`
+	Limit    uint64                    `json:"limit"`
+	Total    uint64                    `json:"total"`
+	Policies []AlarmNotificationPolicy `json:"policies"`
+}
+
+func (sdk mgSDK) AddAlarmNotificationPolicy(ctx context.Context, policy AlarmNotificationPolicy, domainID, token string) (AlarmNotificationPolicy, errors.SDKError) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return AlarmNotificationPolicy{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.alarmsURL, domainID, alarmsEndpoint, notificationPoliciesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return AlarmNotificationPolicy{}, sdkerr
+	}
+
+	var p AlarmNotificationPolicy
+	if err := json.Unmarshal(body, &p); err != nil {
+		return AlarmNotificationPolicy{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
+func (sdk mgSDK) ViewAlarmNotificationPolicy(ctx context.Context, id, domainID, token string) (AlarmNotificationPolicy, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.alarmsURL, domainID, alarmsEndpoint, notificationPoliciesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AlarmNotificationPolicy{}, sdkerr
+	}
+
+	var p AlarmNotificationPolicy
+	if err := json.Unmarshal(body, &p); err != nil {
+		return AlarmNotificationPolicy{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
+func (sdk mgSDK) UpdateAlarmNotificationPolicy(ctx context.Context, policy AlarmNotificationPolicy, domainID, token string) (AlarmNotificationPolicy, errors.SDKError) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return AlarmNotificationPolicy{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.alarmsURL, domainID, alarmsEndpoint, notificationPoliciesEndpoint, policy.ID)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AlarmNotificationPolicy{}, sdkerr
+	}
+
+	var p AlarmNotificationPolicy
+	if err := json.Unmarshal(body, &p); err != nil {
+		return AlarmNotificationPolicy{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
+func (sdk mgSDK) ListAlarmNotificationPolicies(ctx context.Context, pm PageMetadata, domainID, token string) (AlarmNotificationPoliciesPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s", domainID, alarmsEndpoint, notificationPoliciesEndpoint)
+	url, err := sdk.withQueryParams(sdk.alarmsURL, endpoint, pm)
+	if err != nil {
+		return AlarmNotificationPoliciesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AlarmNotificationPoliciesPage{}, sdkerr
+	}
+
+	var pp AlarmNotificationPoliciesPage
+	if err := json.Unmarshal(body, &pp); err != nil {
+		return AlarmNotificationPoliciesPage{}, errors.NewSDKError(err)
+	}
+
+	return pp, nil
+}
+
+func (sdk mgSDK) RemoveAlarmNotificationPolicy(ctx context.Context, id, domainID, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.alarmsURL, domainID, alarmsEndpoint, notificationPoliciesEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(ctx, http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
@@ -388,3 +388,448 @@ func TestDeleteAlarm(t *testing.T) {
 		})
 	}
 }
+
+func TestAlarmStats(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	bucket := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	svcStats := alarms.Stats{
+		GroupBy:               alarms.GroupByRule,
+		Interval:              alarms.DayInterval,
+		Total:                 3,
+		Acknowledged:          2,
+		Resolved:              1,
+		MeanTimeToAcknowledge: 90,
+		MeanTimeToResolve:     300,
+		Counts: []alarms.StatsCount{
+			{Key: "rule-1", Bucket: bucket, Count: 3, Acknowledged: 2, Resolved: 1, MeanTimeToAcknowledge: 90, MeanTimeToResolve: 300},
+		},
+	}
+
+	cases := []struct {
+		desc            string
+		pm              sdk.PageMetadata
+		token           string
+		session         smqauthn.Session
+		svcRes          alarms.Stats
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:   "alarm stats successfully",
+			pm:     sdk.PageMetadata{GroupBy: alarms.GroupByRule, Interval: alarms.DayInterval},
+			token:  validToken,
+			svcRes: svcStats,
+		},
+		{
+			desc:   "alarm stats with default grouping",
+			pm:     sdk.PageMetadata{},
+			token:  validToken,
+			svcRes: alarms.Stats{GroupBy: alarms.GroupByStatus, Counts: []alarms.StatsCount{}},
+		},
+		{
+			desc:    "alarm stats with invalid group by",
+			pm:      sdk.PageMetadata{GroupBy: "measurement"},
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "alarm stats with invalid interval",
+			pm:      sdk.PageMetadata{GroupBy: alarms.GroupByRule, Interval: "minute"},
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "alarm stats with empty token",
+			pm:      sdk.PageMetadata{GroupBy: alarms.GroupByRule},
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "alarm stats with service error",
+			pm:      sdk.PageMetadata{GroupBy: alarms.GroupByRule},
+			token:   validToken,
+			svcErr:  errors.New("service error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("AlarmStats", mock.Anything, tc.session, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			stats, err := mgsdk.AlarmStats(context.Background(), tc.pm, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.svcRes.Total, stats.Total)
+				assert.Equal(t, tc.svcRes.GroupBy, stats.GroupBy)
+				assert.Len(t, stats.Counts, len(tc.svcRes.Counts))
+				assert.InDelta(t, tc.svcRes.MeanTimeToResolve, stats.MeanTimeToResolve, 0.001)
+				groupBy := tc.pm.GroupBy
+				if groupBy == "" {
+					groupBy = alarms.GroupByStatus
+				}
+				ok := svcCall.Parent.AssertCalled(t, "AlarmStats", mock.Anything, tc.session, mock.MatchedBy(func(q alarms.StatsQuery) bool {
+					return q.GroupBy == groupBy && q.Interval == tc.pm.Interval
+				}))
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+const policyID = "policy-1"
+
+var testNotificationPolicy = sdk.AlarmNotificationPolicy{
+	Name:        "critical",
+	MinSeverity: 70,
+	MaxSeverity: 100,
+	Events:      []string{alarms.CreatedEvent, alarms.ResolvedEvent},
+	Recipients: sdk.AlarmRecipients{
+		Emails:   []string{"operator@example.com"},
+		Webhooks: []sdk.AlarmWebhook{{URL: "https://example.com/alarms", Secret: "secret"}},
+	},
+	Escalation: []sdk.AlarmEscalationLevel{
+		{AfterMinutes: 15, Recipients: sdk.AlarmRecipients{Emails: []string{"lead@example.com"}}},
+	},
+}
+
+var svcNotificationPolicy = alarms.NotificationPolicy{
+	ID:          policyID,
+	DomainID:    domainID,
+	Name:        "critical",
+	MinSeverity: 70,
+	MaxSeverity: 100,
+	Events:      []string{alarms.CreatedEvent, alarms.ResolvedEvent},
+	Recipients: alarms.Recipients{
+		Emails:   []string{"operator@example.com"},
+		Webhooks: []alarms.Webhook{{URL: "https://example.com/alarms", Secret: "secret"}},
+	},
+	Escalation: []alarms.EscalationLevel{
+		{AfterMinutes: 15, Recipients: alarms.Recipients{Emails: []string{"lead@example.com"}}},
+	},
+}
+
+func TestAddAlarmNotificationPolicy(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	invalid := testNotificationPolicy
+	invalid.MinSeverity = 100
+	invalid.MaxSeverity = 10
+
+	cases := []struct {
+		desc            string
+		policy          sdk.AlarmNotificationPolicy
+		token           string
+		session         smqauthn.Session
+		svcRes          alarms.NotificationPolicy
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:   "add notification policy successfully",
+			policy: testNotificationPolicy,
+			token:  validToken,
+			svcRes: svcNotificationPolicy,
+		},
+		{
+			desc:    "add notification policy with invalid severity range",
+			policy:  invalid,
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "add notification policy with empty token",
+			policy:  testNotificationPolicy,
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "add notification policy with service error",
+			policy:  testNotificationPolicy,
+			token:   validToken,
+			svcErr:  errors.New("conflict"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("AddNotificationPolicy", mock.Anything, tc.session, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.AddAlarmNotificationPolicy(context.Background(), tc.policy, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, policyID, result.ID)
+				assert.Equal(t, tc.policy.Recipients, result.Recipients)
+				assert.Equal(t, tc.policy.Escalation, result.Escalation)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewAlarmNotificationPolicy(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		id              string
+		token           string
+		session         smqauthn.Session
+		svcRes          alarms.NotificationPolicy
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:   "view notification policy successfully",
+			id:     policyID,
+			token:  validToken,
+			svcRes: svcNotificationPolicy,
+		},
+		{
+			desc:    "view notification policy with empty token",
+			id:      policyID,
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "view non-existent notification policy",
+			id:      "non-existent",
+			token:   validToken,
+			svcErr:  errors.New("not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("ViewNotificationPolicy", mock.Anything, tc.session, tc.id).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.ViewAlarmNotificationPolicy(context.Background(), tc.id, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.svcRes.Name, result.Name)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateAlarmNotificationPolicy(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	updated := testNotificationPolicy
+	updated.ID = policyID
+	updated.Events = nil
+	withoutRecipients := updated
+	withoutRecipients.Recipients = sdk.AlarmRecipients{}
+	withoutRecipients.Escalation = nil
+
+	cases := []struct {
+		desc            string
+		policy          sdk.AlarmNotificationPolicy
+		token           string
+		session         smqauthn.Session
+		svcRes          alarms.NotificationPolicy
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:   "update notification policy successfully",
+			policy: updated,
+			token:  validToken,
+			svcRes: svcNotificationPolicy,
+		},
+		{
+			desc:    "update notification policy without recipients",
+			policy:  withoutRecipients,
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "update notification policy with empty token",
+			policy:  updated,
+			token:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("UpdateNotificationPolicy", mock.Anything, tc.session, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.UpdateAlarmNotificationPolicy(context.Background(), tc.policy, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, policyID, result.ID)
+				ok := svcCall.Parent.AssertCalled(t, "UpdateNotificationPolicy", mock.Anything, tc.session, mock.MatchedBy(func(p alarms.NotificationPolicy) bool {
+					return p.ID == policyID
+				}))
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListAlarmNotificationPolicies(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		pm              sdk.PageMetadata
+		token           string
+		session         smqauthn.Session
+		svcRes          alarms.NotificationPoliciesPage
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:  "list notification policies successfully",
+			pm:    sdk.PageMetadata{Offset: 0, Limit: 10},
+			token: validToken,
+			svcRes: alarms.NotificationPoliciesPage{
+				Total:    1,
+				Offset:   0,
+				Limit:    10,
+				Policies: []alarms.NotificationPolicy{svcNotificationPolicy},
+			},
+		},
+		{
+			desc:    "list notification policies with invalid limit",
+			pm:      sdk.PageMetadata{Limit: 1001},
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "list notification policies with empty token",
+			pm:      sdk.PageMetadata{Limit: 10},
+			token:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("ListNotificationPolicies", mock.Anything, tc.session, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.ListAlarmNotificationPolicies(context.Background(), tc.pm, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.svcRes.Total, result.Total)
+				assert.Len(t, result.Policies, len(tc.svcRes.Policies))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveAlarmNotificationPolicy(t *testing.T) {
+	as, asvc, auth := setupAlarms()
+	defer as.Close()
+
+	conf := sdk.Config{
+		AlarmsURL: as.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		id              string
+		token           string
+		session         smqauthn.Session
+		svcErr          error
+		authenticateErr error
+		wantErr         bool
+	}{
+		{
+			desc:  "remove notification policy successfully",
+			id:    policyID,
+			token: validToken,
+		},
+		{
+			desc:    "remove notification policy with empty token",
+			id:      policyID,
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "remove non-existent notification policy",
+			id:      "non-existent",
+			token:   validToken,
+			svcErr:  errors.New("not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := asvc.On("RemoveNotificationPolicy", mock.Anything, tc.session, tc.id).Return(tc.svcErr)
+			err := mgsdk.RemoveAlarmNotificationPolicy(context.Background(), tc.id, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
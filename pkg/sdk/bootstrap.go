@@ -39,6 +39,7 @@ type BootstrapConfig struct {
 	ExternalKey  string `json:"external_key,omitempty"`
 	ClientID     string `json:"client_id,omitempty"`
 	ClientSecret string `json:"client_secret,omitempty"`
+	DomainID     string `json:"domain_id,omitempty"`
 	Name         string `json:"name,omitempty"`
 	ClientCert   string `json:"client_cert,omitempty"`
 	ClientKey    string `json:"client_key,omitempty"`
@@ -72,6 +73,7 @@ func (ts *BootstrapConfig) UnmarshalJSON(data []byte) error {
 		ExternalKey  *string `json:"external_key,omitempty"`
 		ClientID     *string `json:"client_id,omitempty"`
 		ClientSecret *string `json:"client_secret,omitempty"`
+		DomainID     *string `json:"domain_id,omitempty"`
 		Name         *string `json:"name,omitempty"`
 		ClientCert   *string `json:"client_cert,omitempty"`
 		ClientKey    *string `json:"client_key,omitempty"`
@@ -83,6 +85,7 @@ func (ts *BootstrapConfig) UnmarshalJSON(data []byte) error {
 		ExternalKey:  &ts.ExternalKey,
 		ClientID:     &ts.ClientID,
 		ClientSecret: &ts.ClientSecret,
+		DomainID:     &ts.DomainID,
 		Name:         &ts.Name,
 		ClientCert:   &ts.ClientCert,
 		ClientKey:    &ts.ClientKey,
@@ -289,6 +292,34 @@ func (sdk mgSDK) BootstrapSecure(ctx context.Context, externalID, externalKey, c
 	return bc, nil
 }
 
+func (sdk mgSDK) EnrollBootstrapCert(ctx context.Context, externalID, clientCert, ca, token string) (BootstrapConfig, errors.SDKError) {
+	if externalID == "" {
+		return BootstrapConfig{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.bootstrapURL, bootstrapEndpoint, certsEndpoint, externalID)
+	request := BootstrapConfig{
+		ClientCert: clientCert,
+		CACert:     ca,
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return BootstrapConfig{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPatch, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return BootstrapConfig{}, sdkerr
+	}
+
+	var bc BootstrapConfig
+	if err := json.Unmarshal(body, &bc); err != nil {
+		return BootstrapConfig{}, errors.NewSDKError(err)
+	}
+
+	return bc, nil
+}
+
 func bootstrapEncrypt(in []byte, cryptoKey string) (string, error) {
 	block, err := aes.NewCipher([]byte(cryptoKey))
 	if err != nil {
@@ -44,6 +44,7 @@ var (
 	state           = 1
 	bsName          = "test"
 	encKey          = []byte("1234567891011121")
+	certsSecret     = "certsSecret"
 	bootstrapConfig = bootstrap.Config{
 		ClientID:   clientId,
 		Name:       "test",
@@ -134,7 +135,7 @@ func setupBootstrap() (*httptest.Server, *bmocks.Service, *bmocks.ConfigReader,
 	authn := new(authnmocks.Authentication)
 	am := smqauthn.NewAuthNMiddleware(authn, smqauthn.WithAllowUnverifiedUser(true))
 
-	mux := api.MakeHandler(bsvc, am, reader, logger, "")
+	mux := api.MakeHandler(bsvc, am, reader, logger, "", certsSecret)
 
 	return httptest.NewServer(mux), bsvc, reader, authn
 }
@@ -920,6 +921,92 @@ func TestUpdateBootstrapCerts(t *testing.T) {
 	}
 }
 
+func TestEnrollBootstrapCert(t *testing.T) {
+	bs, bsvc, _, _ := setupBootstrap()
+	defer bs.Close()
+
+	conf := sdk.Config{
+		BootstrapURL: bs.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	enrolled := bootstrap.Config{
+		ClientID:   clientId,
+		ClientCert: clientCert,
+		CACert:     caCert,
+	}
+	enrollRes := sdk.BootstrapConfig{
+		ClientID:   clientId,
+		ClientCert: clientCert,
+		CACert:     caCert,
+	}
+
+	cases := []struct {
+		desc       string
+		externalID string
+		token      string
+		svcResp    bootstrap.Config
+		svcErr     error
+		response   sdk.BootstrapConfig
+		err        errors.SDKError
+	}{
+		{
+			desc:       "enroll cert successfully",
+			externalID: externalId,
+			token:      certsSecret,
+			svcResp:    enrolled,
+			svcErr:     nil,
+			response:   enrollRes,
+			err:        nil,
+		},
+		{
+			desc:       "enroll cert with empty token",
+			externalID: externalId,
+			token:      "",
+			svcResp:    bootstrap.Config{},
+			svcErr:     nil,
+			err:        errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:       "enroll cert with invalid token",
+			externalID: externalId,
+			token:      invalid,
+			svcResp:    bootstrap.Config{},
+			svcErr:     nil,
+			err:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:       "enroll cert with non-existent external ID",
+			externalID: invalid,
+			token:      certsSecret,
+			svcResp:    bootstrap.Config{},
+			svcErr:     svcerr.ErrNotFound,
+			err:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:       "enroll cert with empty external ID",
+			externalID: "",
+			token:      certsSecret,
+			svcResp:    bootstrap.Config{},
+			svcErr:     nil,
+			err:        errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := bsvc.On("EnrollCert", mock.Anything, tc.externalID, clientCert, caCert).Return(tc.svcResp, tc.svcErr)
+			resp, err := mgsdk.EnrollBootstrapCert(context.Background(), tc.externalID, clientCert, caCert, tc.token)
+			assert.Equal(t, tc.err, err)
+			if err == nil {
+				assert.Equal(t, tc.response, resp)
+				ok := svcCall.Parent.AssertCalled(t, "EnrollCert", mock.Anything, tc.externalID, clientCert, caCert)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestUpdateBootstrapConnection(t *testing.T) {
 	bs, bsvc, _, auth := setupBootstrap()
 	defer bs.Close()
@@ -7,12 +7,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+const (
+	messagesEndpoint = "messages"
+	exportEndpoint   = "export"
+	outputKey        = "output"
+	ndjsonOutput     = "ndjson"
+	seriesEndpoint   = "series"
 )
 
 func (sdk mgSDK) ReadMessages(ctx context.Context, pm MessagePageMetadata, chanName, domainID, token string) (MessagesPage, errors.SDKError) {
@@ -44,6 +54,98 @@ func (sdk mgSDK) ReadMessages(ctx context.Context, pm MessagePageMetadata, chanN
 	return mp, nil
 }
 
+func (sdk mgSDK) ExportMessages(ctx context.Context, pm MessagePageMetadata, chanName, domainID, token string, fn func(senml.Message) error) errors.SDKError {
+	chanNameParts := strings.SplitN(chanName, "/", channelParts)
+	chanID := chanNameParts[0]
+	if len(chanNameParts) == channelParts {
+		pm.Subtopic = chanNameParts[1]
+	}
+
+	msgURL, err := sdk.withMessageQueryParams(sdk.readersURL, fmt.Sprintf("%s/channels/%s/%s/%s", domainID, chanID, messagesEndpoint, exportEndpoint), pm)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	msgURL = fmt.Sprintf("%s&%s=%s", msgURL, outputKey, ndjsonOutput)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msgURL, nil)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	if token != "" {
+		if !strings.Contains(token, ClientPrefix) {
+			token = BearerPrefix + token
+		}
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := sdk.client.Do(req)
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	defer resp.Body.Close()
+
+	if sdkerr := errors.CheckError(resp, http.StatusOK); sdkerr != nil {
+		return sdkerr
+	}
+
+	// Messages are decoded as they arrive, so the export is never held in memory.
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg senml.Message
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.NewSDKError(err)
+		}
+		if err := fn(msg); err != nil {
+			return errors.NewSDKError(err)
+		}
+	}
+}
+
+func (sdk mgSDK) ReadSeries(ctx context.Context, pm SeriesPageMetadata, domainID, token string) (SeriesPage, errors.SDKError) {
+	q := url.Values{}
+	if len(pm.Channels) > 0 {
+		q.Add("channels", strings.Join(pm.Channels, ","))
+	}
+	if pm.GroupID != "" {
+		q.Add("group_id", pm.GroupID)
+	}
+	if len(pm.Names) > 0 {
+		q.Add("names", strings.Join(pm.Names, ","))
+	}
+	if pm.Subtopic != "" {
+		q.Add("subtopic", pm.Subtopic)
+	}
+	if pm.Publisher != "" {
+		q.Add("publisher", pm.Publisher)
+	}
+	if pm.Protocol != "" {
+		q.Add("protocol", pm.Protocol)
+	}
+	q.Add("from", strconv.FormatFloat(pm.From, 'f', -1, 64))
+	q.Add("to", strconv.FormatFloat(pm.To, 'f', -1, 64))
+	q.Add("aggregation", pm.Aggregation)
+	q.Add("interval", pm.Interval)
+	msgURL := fmt.Sprintf("%s/%s/%s/%s?%s", sdk.readersURL, domainID, messagesEndpoint, seriesEndpoint, q.Encode())
+
+	header := make(map[string]string)
+	header["Content-Type"] = string(sdk.msgContentType)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, msgURL, token, nil, header, http.StatusOK)
+	if sdkerr != nil {
+		return SeriesPage{}, sdkerr
+	}
+
+	var sp SeriesPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return SeriesPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
+
 func (sdk mgSDK) withMessageQueryParams(baseURL, endpoint string, mpm MessagePageMetadata) (string, error) {
 	b, err := json.Marshal(mpm)
 	if err != nil {
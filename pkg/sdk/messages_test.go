@@ -17,6 +17,7 @@ import (
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
 	"github.com/absmach/supermq/pkg/sdk"
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/absmach/supermq/readers"
@@ -32,7 +33,7 @@ func setupReaders() (*httptest.Server, *authnmocks.Authentication, *readersmocks
 	clientsGRPCClient = new(climocks.ClientsServiceClient)
 	channelsGRPCClient = new(chmocks.ChannelsServiceClient)
 
-	mux := readersapi.MakeHandler(repo, authn, clientsGRPCClient, channelsGRPCClient, "test", "")
+	mux := readersapi.MakeHandler(repo, authn, clientsGRPCClient, channelsGRPCClient, new(policymocks.Service), "test", "")
 	return httptest.NewServer(mux), authn, repo
 }
 
@@ -238,3 +239,250 @@ func TestReadMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestExportMessages(t *testing.T) {
+	ts, authn, repo := setupReaders()
+	defer ts.Close()
+
+	channelID := "channelID"
+	msgValue := 1.6
+	msgs := []senml.Message{
+		{
+			Name:      "current",
+			Time:      1720000000,
+			Value:     &msgValue,
+			Publisher: validID,
+		},
+		{
+			Name:      "voltage",
+			Time:      1720000001,
+			Value:     &msgValue,
+			Publisher: validID,
+		},
+	}
+
+	sdkConf := sdk.Config{
+		ReaderURL: ts.URL,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc            string
+		token           string
+		chanName        string
+		domainID        string
+		messagePageMeta sdk.MessagePageMetadata
+		authzErr        error
+		authnErr        error
+		repoErr         error
+		response        []senml.Message
+		err             errors.SDKError
+	}{
+		{
+			desc:     "export messages successfully",
+			token:    validToken,
+			chanName: channelID,
+			domainID: validID,
+			messagePageMeta: sdk.MessagePageMetadata{
+				Publisher: validID,
+			},
+			response: msgs,
+			err:      nil,
+		},
+		{
+			desc:     "export messages successfully with subtopic",
+			token:    validToken,
+			chanName: channelID + "/subtopic",
+			domainID: validID,
+			messagePageMeta: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{
+					Limit: 10,
+				},
+			},
+			response: msgs,
+			err:      nil,
+		},
+		{
+			desc:     "export messages with invalid token",
+			token:    invalidToken,
+			chanName: channelID,
+			domainID: validID,
+			authzErr: svcerr.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "export messages with empty channel ID",
+			token:    validToken,
+			chanName: "",
+			domainID: validID,
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrMissingID, http.StatusBadRequest),
+		},
+		{
+			desc:     "export messages with invalid cursor",
+			token:    validToken,
+			chanName: channelID,
+			domainID: validID,
+			messagePageMeta: sdk.MessagePageMetadata{
+				Cursor: "invalid",
+			},
+			err: errors.NewSDKErrorWithStatus(apiutil.ErrInvalidCursor, http.StatusBadRequest),
+		},
+		{
+			desc:     "export messages with failed read",
+			token:    validToken,
+			chanName: channelID,
+			domainID: validID,
+			repoErr:  readers.ErrReadMessages,
+			err:      errors.NewSDKErrorWithStatus(errJsonEOF, http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(smqauthn.Session{UserID: validID}, tc.authnErr)
+			authzCall := channelsGRPCClient.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, tc.authzErr)
+			repoCall := repo.On("StreamAll", mock.Anything, channelID, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if tc.repoErr != nil {
+					return
+				}
+				fn := args.Get(3).(func(readers.Message) error)
+				for _, msg := range msgs {
+					if err := fn(msg); err != nil {
+						return
+					}
+				}
+			}).Return(tc.repoErr)
+			var response []senml.Message
+			err := mgsdk.ExportMessages(context.Background(), tc.messagePageMeta, tc.chanName, tc.domainID, tc.token, func(msg senml.Message) error {
+				response = append(response, msg)
+				return nil
+			})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+			if tc.err == nil {
+				ok := repoCall.Parent.AssertCalled(t, "StreamAll", mock.Anything, channelID, mock.Anything, mock.Anything)
+				assert.True(t, ok)
+			}
+			authCall.Unset()
+			authzCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReadSeries(t *testing.T) {
+	ts, authn, repo := setupReaders()
+	defer ts.Close()
+
+	chanIDs := []string{"channelID", "channelID2"}
+	value := 1.6
+	from := float64(1720000000000000000)
+	to := float64(1720000120000000000)
+
+	sdkConf := sdk.Config{
+		ReaderURL: ts.URL,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		pageMeta sdk.SeriesPageMetadata
+		authzErr error
+		authnErr error
+		repoRes  readers.SeriesPage
+		repoErr  error
+		response sdk.SeriesPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "read series successfully",
+			token:    validToken,
+			domainID: validID,
+			pageMeta: sdk.SeriesPageMetadata{
+				Channels:    chanIDs,
+				Names:       []string{"current"},
+				From:        from,
+				To:          to,
+				Aggregation: "avg",
+				Interval:    "1m",
+			},
+			repoRes: readers.SeriesPage{
+				Times: []float64{from, from + 6e10},
+				Series: []readers.Series{
+					{Channel: chanIDs[0], Name: "current", Values: []*float64{&value, nil}},
+				},
+			},
+			response: sdk.SeriesPage{
+				Times: []float64{from, from + 6e10},
+				Series: []sdk.Series{
+					{Channel: chanIDs[0], Name: "current", Values: []*float64{&value, nil}},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "read series with invalid token",
+			token:    invalidToken,
+			domainID: validID,
+			pageMeta: sdk.SeriesPageMetadata{
+				Channels:    chanIDs,
+				From:        from,
+				To:          to,
+				Aggregation: "avg",
+				Interval:    "1m",
+			},
+			authzErr: svcerr.ErrAuthorization,
+			response: sdk.SeriesPage{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "read series without channels",
+			token:    validToken,
+			domainID: validID,
+			pageMeta: sdk.SeriesPageMetadata{
+				From:        from,
+				To:          to,
+				Aggregation: "avg",
+				Interval:    "1m",
+			},
+			response: sdk.SeriesPage{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrMissingChannelID, http.StatusBadRequest),
+		},
+		{
+			desc:     "read series with invalid aggregation",
+			token:    validToken,
+			domainID: validID,
+			pageMeta: sdk.SeriesPageMetadata{
+				Channels:    chanIDs,
+				From:        from,
+				To:          to,
+				Aggregation: "invalid",
+				Interval:    "1m",
+			},
+			response: sdk.SeriesPage{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrInvalidAggregation, http.StatusBadRequest),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(smqauthn.Session{UserID: validID}, tc.authnErr)
+			authzCall := channelsGRPCClient.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, tc.authzErr)
+			repoCall := repo.On("ReadSeries", mock.Anything, chanIDs, mock.Anything).Return(tc.repoRes, tc.repoErr)
+			response, err := mgsdk.ReadSeries(context.Background(), tc.pageMeta, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+			if tc.err == nil {
+				ok := repoCall.Parent.AssertCalled(t, "ReadSeries", mock.Anything, chanIDs, mock.Anything)
+				assert.True(t, ok)
+			}
+			authCall.Unset()
+			authzCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
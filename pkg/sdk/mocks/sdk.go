@@ -14,6 +14,7 @@ import (
 	"github.com/absmach/supermq/certs"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/pkg/transformers/senml"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -107,6 +108,86 @@ func (_c *SDK_AcceptInvitation_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// AddAlarmNotificationPolicy provides a mock function for the type SDK
+func (_mock *SDK) AddAlarmNotificationPolicy(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError) {
+	ret := _mock.Called(ctx, policy, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAlarmNotificationPolicy")
+	}
+
+	var r0 sdk.AlarmNotificationPolicy
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) (sdk.AlarmNotificationPolicy, errors.SDKError)); ok {
+		return returnFunc(ctx, policy, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) sdk.AlarmNotificationPolicy); ok {
+		r0 = returnFunc(ctx, policy, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmNotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, policy, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_AddAlarmNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAlarmNotificationPolicy'
+type SDK_AddAlarmNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// AddAlarmNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy sdk.AlarmNotificationPolicy
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) AddAlarmNotificationPolicy(ctx interface{}, policy interface{}, domainID interface{}, token interface{}) *SDK_AddAlarmNotificationPolicy_Call {
+	return &SDK_AddAlarmNotificationPolicy_Call{Call: _e.mock.On("AddAlarmNotificationPolicy", ctx, policy, domainID, token)}
+}
+
+func (_c *SDK_AddAlarmNotificationPolicy_Call) Run(run func(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string)) *SDK_AddAlarmNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.AlarmNotificationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(sdk.AlarmNotificationPolicy)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_AddAlarmNotificationPolicy_Call) Return(alarmNotificationPolicy sdk.AlarmNotificationPolicy, sDKError errors.SDKError) *SDK_AddAlarmNotificationPolicy_Call {
+	_c.Call.Return(alarmNotificationPolicy, sDKError)
+	return _c
+}
+
+func (_c *SDK_AddAlarmNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError)) *SDK_AddAlarmNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBootstrap provides a mock function for the type SDK
 func (_mock *SDK) AddBootstrap(ctx context.Context, cfg sdk.BootstrapConfig, domainID string, token string) (string, errors.SDKError) {
 	ret := _mock.Called(ctx, cfg, domainID, token)
@@ -976,6 +1057,86 @@ func (_c *SDK_AddRule_Call) RunAndReturn(run func(ctx context.Context, r sdk.Rul
 	return _c
 }
 
+// AlarmStats provides a mock function for the type SDK
+func (_mock *SDK) AlarmStats(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmStats, errors.SDKError) {
+	ret := _mock.Called(ctx, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmStats")
+	}
+
+	var r0 sdk.AlarmStats
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.PageMetadata, string, string) (sdk.AlarmStats, errors.SDKError)); ok {
+		return returnFunc(ctx, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.PageMetadata, string, string) sdk.AlarmStats); ok {
+		r0 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmStats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_AlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmStats'
+type SDK_AlarmStats_Call struct {
+	*mock.Call
+}
+
+// AlarmStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) AlarmStats(ctx interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_AlarmStats_Call {
+	return &SDK_AlarmStats_Call{Call: _e.mock.On("AlarmStats", ctx, pm, domainID, token)}
+}
+
+func (_c *SDK_AlarmStats_Call) Run(run func(ctx context.Context, pm sdk.PageMetadata, domainID string, token string)) *SDK_AlarmStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(sdk.PageMetadata)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_AlarmStats_Call) Return(alarmStats sdk.AlarmStats, sDKError errors.SDKError) *SDK_AlarmStats_Call {
+	_c.Call.Return(alarmStats, sDKError)
+	return _c
+}
+
+func (_c *SDK_AlarmStats_Call) RunAndReturn(run func(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmStats, errors.SDKError)) *SDK_AlarmStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AvailableClientRoleActions provides a mock function for the type SDK
 func (_mock *SDK) AvailableClientRoleActions(ctx context.Context, domainID string, token string) ([]string, errors.SDKError) {
 	ret := _mock.Called(ctx, domainID, token)
@@ -5529,6 +5690,94 @@ func (_c *SDK_DownloadCA_Call) RunAndReturn(run func(ctx context.Context) (sdk.C
 	return _c
 }
 
+// DryRunRule provides a mock function for the type SDK
+func (_mock *SDK) DryRunRule(ctx context.Context, r sdk.Rule, msgs []sdk.RuleMessage, domainID string, token string) ([]sdk.DryRunResult, errors.SDKError) {
+	ret := _mock.Called(ctx, r, msgs, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRunRule")
+	}
+
+	var r0 []sdk.DryRunResult
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.Rule, []sdk.RuleMessage, string, string) ([]sdk.DryRunResult, errors.SDKError)); ok {
+		return returnFunc(ctx, r, msgs, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.Rule, []sdk.RuleMessage, string, string) []sdk.DryRunResult); ok {
+		r0 = returnFunc(ctx, r, msgs, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.DryRunResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.Rule, []sdk.RuleMessage, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, r, msgs, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_DryRunRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DryRunRule'
+type SDK_DryRunRule_Call struct {
+	*mock.Call
+}
+
+// DryRunRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r sdk.Rule
+//   - msgs []sdk.RuleMessage
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) DryRunRule(ctx interface{}, r interface{}, msgs interface{}, domainID interface{}, token interface{}) *SDK_DryRunRule_Call {
+	return &SDK_DryRunRule_Call{Call: _e.mock.On("DryRunRule", ctx, r, msgs, domainID, token)}
+}
+
+func (_c *SDK_DryRunRule_Call) Run(run func(ctx context.Context, r sdk.Rule, msgs []sdk.RuleMessage, domainID string, token string)) *SDK_DryRunRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.Rule
+		if args[1] != nil {
+			arg1 = args[1].(sdk.Rule)
+		}
+		var arg2 []sdk.RuleMessage
+		if args[2] != nil {
+			arg2 = args[2].([]sdk.RuleMessage)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_DryRunRule_Call) Return(dryRunResults []sdk.DryRunResult, sDKError errors.SDKError) *SDK_DryRunRule_Call {
+	_c.Call.Return(dryRunResults, sDKError)
+	return _c
+}
+
+func (_c *SDK_DryRunRule_Call) RunAndReturn(run func(ctx context.Context, r sdk.Rule, msgs []sdk.RuleMessage, domainID string, token string) ([]sdk.DryRunResult, errors.SDKError)) *SDK_DryRunRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EnableChannel provides a mock function for the type SDK
 func (_mock *SDK) EnableChannel(ctx context.Context, id string, domainID string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _mock.Called(ctx, id, domainID, token)
@@ -6068,6 +6317,90 @@ func (_c *SDK_EnableUser_Call) RunAndReturn(run func(ctx context.Context, id str
 	return _c
 }
 
+// EnrollBootstrapCert provides a mock function for the type SDK
+func (_mock *SDK) EnrollBootstrapCert(ctx context.Context, externalID string, clientCert string, ca string, token string) (sdk.BootstrapConfig, errors.SDKError) {
+	ret := _mock.Called(ctx, externalID, clientCert, ca, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollBootstrapCert")
+	}
+
+	var r0 sdk.BootstrapConfig
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (sdk.BootstrapConfig, errors.SDKError)); ok {
+		return returnFunc(ctx, externalID, clientCert, ca, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) sdk.BootstrapConfig); ok {
+		r0 = returnFunc(ctx, externalID, clientCert, ca, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BootstrapConfig)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, externalID, clientCert, ca, token)
+	} else {
+		r1 = ret.Get(1).(errors.SDKError)
+	}
+	return r0, r1
+}
+
+// SDK_EnrollBootstrapCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollBootstrapCert'
+type SDK_EnrollBootstrapCert_Call struct {
+	*mock.Call
+}
+
+// EnrollBootstrapCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+//   - clientCert string
+//   - ca string
+//   - token string
+func (_e *SDK_Expecter) EnrollBootstrapCert(ctx interface{}, externalID interface{}, clientCert interface{}, ca interface{}, token interface{}) *SDK_EnrollBootstrapCert_Call {
+	return &SDK_EnrollBootstrapCert_Call{Call: _e.mock.On("EnrollBootstrapCert", ctx, externalID, clientCert, ca, token)}
+}
+
+func (_c *SDK_EnrollBootstrapCert_Call) Run(run func(ctx context.Context, externalID string, clientCert string, ca string, token string)) *SDK_EnrollBootstrapCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_EnrollBootstrapCert_Call) Return(bootstrapConfig sdk.BootstrapConfig, sDKError errors.SDKError) *SDK_EnrollBootstrapCert_Call {
+	_c.Call.Return(bootstrapConfig, sDKError)
+	return _c
+}
+
+func (_c *SDK_EnrollBootstrapCert_Call) RunAndReturn(run func(ctx context.Context, externalID string, clientCert string, ca string, token string) (sdk.BootstrapConfig, errors.SDKError)) *SDK_EnrollBootstrapCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EntityID provides a mock function for the type SDK
 func (_mock *SDK) EntityID(ctx context.Context, serialNumber string, domainID string, token string) (string, errors.SDKError) {
 	ret := _mock.Called(ctx, serialNumber, domainID, token)
@@ -6148,17 +6481,100 @@ func (_c *SDK_EntityID_Call) RunAndReturn(run func(ctx context.Context, serialNu
 	return _c
 }
 
-// FreezeDomain provides a mock function for the type SDK
-func (_mock *SDK) FreezeDomain(ctx context.Context, domainID string, token string) errors.SDKError {
-	ret := _mock.Called(ctx, domainID, token)
+// ExportMessages provides a mock function for the type SDK
+func (_mock *SDK) ExportMessages(ctx context.Context, pm sdk.MessagePageMetadata, chanID string, domainID string, token string, fn func(senml.Message) error) errors.SDKError {
+	ret := _mock.Called(ctx, pm, chanID, domainID, token, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FreezeDomain")
+		panic("no return value specified for ExportMessages")
 	}
 
 	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, domainID, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.MessagePageMetadata, string, string, string, func(senml.Message) error) errors.SDKError); ok {
+		r0 = returnFunc(ctx, pm, chanID, domainID, token, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_ExportMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportMessages'
+type SDK_ExportMessages_Call struct {
+	*mock.Call
+}
+
+// ExportMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm sdk.MessagePageMetadata
+//   - chanID string
+//   - domainID string
+//   - token string
+//   - fn func(senml.Message) error
+func (_e *SDK_Expecter) ExportMessages(ctx interface{}, pm interface{}, chanID interface{}, domainID interface{}, token interface{}, fn interface{}) *SDK_ExportMessages_Call {
+	return &SDK_ExportMessages_Call{Call: _e.mock.On("ExportMessages", ctx, pm, chanID, domainID, token, fn)}
+}
+
+func (_c *SDK_ExportMessages_Call) Run(run func(ctx context.Context, pm sdk.MessagePageMetadata, chanID string, domainID string, token string, fn func(senml.Message) error)) *SDK_ExportMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.MessagePageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(sdk.MessagePageMetadata)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 func(senml.Message) error
+		if args[5] != nil {
+			arg5 = args[5].(func(senml.Message) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ExportMessages_Call) Return(sDKError errors.SDKError) *SDK_ExportMessages_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_ExportMessages_Call) RunAndReturn(run func(ctx context.Context, pm sdk.MessagePageMetadata, chanID string, domainID string, token string, fn func(senml.Message) error) errors.SDKError) *SDK_ExportMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeDomain provides a mock function for the type SDK
+func (_mock *SDK) FreezeDomain(ctx context.Context, domainID string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeDomain")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, domainID, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -7469,6 +7885,86 @@ func (_c *SDK_Journal_Call) RunAndReturn(run func(ctx context.Context, entityTyp
 	return _c
 }
 
+// ListAlarmNotificationPolicies provides a mock function for the type SDK
+func (_mock *SDK) ListAlarmNotificationPolicies(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmNotificationPoliciesPage, errors.SDKError) {
+	ret := _mock.Called(ctx, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlarmNotificationPolicies")
+	}
+
+	var r0 sdk.AlarmNotificationPoliciesPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.PageMetadata, string, string) (sdk.AlarmNotificationPoliciesPage, errors.SDKError)); ok {
+		return returnFunc(ctx, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.PageMetadata, string, string) sdk.AlarmNotificationPoliciesPage); ok {
+		r0 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmNotificationPoliciesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ListAlarmNotificationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlarmNotificationPolicies'
+type SDK_ListAlarmNotificationPolicies_Call struct {
+	*mock.Call
+}
+
+// ListAlarmNotificationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListAlarmNotificationPolicies(ctx interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListAlarmNotificationPolicies_Call {
+	return &SDK_ListAlarmNotificationPolicies_Call{Call: _e.mock.On("ListAlarmNotificationPolicies", ctx, pm, domainID, token)}
+}
+
+func (_c *SDK_ListAlarmNotificationPolicies_Call) Run(run func(ctx context.Context, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListAlarmNotificationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(sdk.PageMetadata)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListAlarmNotificationPolicies_Call) Return(alarmNotificationPoliciesPage sdk.AlarmNotificationPoliciesPage, sDKError errors.SDKError) *SDK_ListAlarmNotificationPolicies_Call {
+	_c.Call.Return(alarmNotificationPoliciesPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListAlarmNotificationPolicies_Call) RunAndReturn(run func(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmNotificationPoliciesPage, errors.SDKError)) *SDK_ListAlarmNotificationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListAlarms provides a mock function for the type SDK
 func (_mock *SDK) ListAlarms(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmsPage, errors.SDKError) {
 	ret := _mock.Called(ctx, pm, domainID, token)
@@ -8047,6 +8543,90 @@ func (_c *SDK_ListReportsConfig_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// ListRuleExecutions provides a mock function for the type SDK
+func (_mock *SDK) ListRuleExecutions(ctx context.Context, ruleID string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleExecutionsPage, errors.SDKError) {
+	ret := _mock.Called(ctx, ruleID, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleExecutions")
+	}
+
+	var r0 sdk.RuleExecutionsPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.RuleExecutionsPage, errors.SDKError)); ok {
+		return returnFunc(ctx, ruleID, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.RuleExecutionsPage); ok {
+		r0 = returnFunc(ctx, ruleID, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleExecutionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, ruleID, pm, domainID, token)
+	} else {
+		r1 = ret.Get(1).(errors.SDKError)
+	}
+	return r0, r1
+}
+
+// SDK_ListRuleExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleExecutions'
+type SDK_ListRuleExecutions_Call struct {
+	*mock.Call
+}
+
+// ListRuleExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListRuleExecutions(ctx interface{}, ruleID interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListRuleExecutions_Call {
+	return &SDK_ListRuleExecutions_Call{Call: _e.mock.On("ListRuleExecutions", ctx, ruleID, pm, domainID, token)}
+}
+
+func (_c *SDK_ListRuleExecutions_Call) Run(run func(ctx context.Context, ruleID string, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListRuleExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListRuleExecutions_Call) Return(ruleExecutionsPage sdk.RuleExecutionsPage, sDKError errors.SDKError) *SDK_ListRuleExecutions_Call {
+	_c.Call.Return(ruleExecutionsPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListRuleExecutions_Call) RunAndReturn(run func(ctx context.Context, ruleID string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleExecutionsPage, errors.SDKError)) *SDK_ListRuleExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRules provides a mock function for the type SDK
 func (_mock *SDK) ListRules(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.Page, errors.SDKError) {
 	ret := _mock.Called(ctx, pm, domainID, token)
@@ -8361,6 +8941,86 @@ func (_c *SDK_ReadMessages_Call) RunAndReturn(run func(ctx context.Context, pm s
 	return _c
 }
 
+// ReadSeries provides a mock function for the type SDK
+func (_mock *SDK) ReadSeries(ctx context.Context, pm sdk.SeriesPageMetadata, domainID string, token string) (sdk.SeriesPage, errors.SDKError) {
+	ret := _mock.Called(ctx, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadSeries")
+	}
+
+	var r0 sdk.SeriesPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.SeriesPageMetadata, string, string) (sdk.SeriesPage, errors.SDKError)); ok {
+		return returnFunc(ctx, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.SeriesPageMetadata, string, string) sdk.SeriesPage); ok {
+		r0 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.SeriesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.SeriesPageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ReadSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadSeries'
+type SDK_ReadSeries_Call struct {
+	*mock.Call
+}
+
+// ReadSeries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm sdk.SeriesPageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ReadSeries(ctx interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ReadSeries_Call {
+	return &SDK_ReadSeries_Call{Call: _e.mock.On("ReadSeries", ctx, pm, domainID, token)}
+}
+
+func (_c *SDK_ReadSeries_Call) Run(run func(ctx context.Context, pm sdk.SeriesPageMetadata, domainID string, token string)) *SDK_ReadSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.SeriesPageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(sdk.SeriesPageMetadata)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ReadSeries_Call) Return(seriesPage sdk.SeriesPage, sDKError errors.SDKError) *SDK_ReadSeries_Call {
+	_c.Call.Return(seriesPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ReadSeries_Call) RunAndReturn(run func(ctx context.Context, pm sdk.SeriesPageMetadata, domainID string, token string) (sdk.SeriesPage, errors.SDKError)) *SDK_ReadSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshToken provides a mock function for the type SDK
 func (_mock *SDK) RefreshToken(ctx context.Context, token string) (sdk.Token, errors.SDKError) {
 	ret := _mock.Called(ctx, token)
@@ -8492,6 +9152,77 @@ func (_c *SDK_RejectInvitation_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// RemoveAlarmNotificationPolicy provides a mock function for the type SDK
+func (_mock *SDK) RemoveAlarmNotificationPolicy(ctx context.Context, id string, domainID string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAlarmNotificationPolicy")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, id, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_RemoveAlarmNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAlarmNotificationPolicy'
+type SDK_RemoveAlarmNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// RemoveAlarmNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) RemoveAlarmNotificationPolicy(ctx interface{}, id interface{}, domainID interface{}, token interface{}) *SDK_RemoveAlarmNotificationPolicy_Call {
+	return &SDK_RemoveAlarmNotificationPolicy_Call{Call: _e.mock.On("RemoveAlarmNotificationPolicy", ctx, id, domainID, token)}
+}
+
+func (_c *SDK_RemoveAlarmNotificationPolicy_Call) Run(run func(ctx context.Context, id string, domainID string, token string)) *SDK_RemoveAlarmNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_RemoveAlarmNotificationPolicy_Call) Return(sDKError errors.SDKError) *SDK_RemoveAlarmNotificationPolicy_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_RemoveAlarmNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, token string) errors.SDKError) *SDK_RemoveAlarmNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveAllChildren provides a mock function for the type SDK
 func (_mock *SDK) RemoveAllChildren(ctx context.Context, id string, domainID string, token string) errors.SDKError {
 	ret := _mock.Called(ctx, id, domainID, token)
@@ -11009,6 +11740,86 @@ func (_c *SDK_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm
 	return _c
 }
 
+// UpdateAlarmNotificationPolicy provides a mock function for the type SDK
+func (_mock *SDK) UpdateAlarmNotificationPolicy(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError) {
+	ret := _mock.Called(ctx, policy, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlarmNotificationPolicy")
+	}
+
+	var r0 sdk.AlarmNotificationPolicy
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) (sdk.AlarmNotificationPolicy, errors.SDKError)); ok {
+		return returnFunc(ctx, policy, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) sdk.AlarmNotificationPolicy); ok {
+		r0 = returnFunc(ctx, policy, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmNotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.AlarmNotificationPolicy, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, policy, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_UpdateAlarmNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarmNotificationPolicy'
+type SDK_UpdateAlarmNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// UpdateAlarmNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy sdk.AlarmNotificationPolicy
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) UpdateAlarmNotificationPolicy(ctx interface{}, policy interface{}, domainID interface{}, token interface{}) *SDK_UpdateAlarmNotificationPolicy_Call {
+	return &SDK_UpdateAlarmNotificationPolicy_Call{Call: _e.mock.On("UpdateAlarmNotificationPolicy", ctx, policy, domainID, token)}
+}
+
+func (_c *SDK_UpdateAlarmNotificationPolicy_Call) Run(run func(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string)) *SDK_UpdateAlarmNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.AlarmNotificationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(sdk.AlarmNotificationPolicy)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_UpdateAlarmNotificationPolicy_Call) Return(alarmNotificationPolicy sdk.AlarmNotificationPolicy, sDKError errors.SDKError) *SDK_UpdateAlarmNotificationPolicy_Call {
+	_c.Call.Return(alarmNotificationPolicy, sDKError)
+	return _c
+}
+
+func (_c *SDK_UpdateAlarmNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy sdk.AlarmNotificationPolicy, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError)) *SDK_UpdateAlarmNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBootstrap provides a mock function for the type SDK
 func (_mock *SDK) UpdateBootstrap(ctx context.Context, cfg sdk.BootstrapConfig, domainID string, token string) errors.SDKError {
 	ret := _mock.Called(ctx, cfg, domainID, token)
@@ -13515,6 +14326,86 @@ func (_c *SDK_ViewAlarm_Call) RunAndReturn(run func(ctx context.Context, id stri
 	return _c
 }
 
+// ViewAlarmNotificationPolicy provides a mock function for the type SDK
+func (_mock *SDK) ViewAlarmNotificationPolicy(ctx context.Context, id string, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError) {
+	ret := _mock.Called(ctx, id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewAlarmNotificationPolicy")
+	}
+
+	var r0 sdk.AlarmNotificationPolicy
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (sdk.AlarmNotificationPolicy, errors.SDKError)); ok {
+		return returnFunc(ctx, id, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) sdk.AlarmNotificationPolicy); ok {
+		r0 = returnFunc(ctx, id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmNotificationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ViewAlarmNotificationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewAlarmNotificationPolicy'
+type SDK_ViewAlarmNotificationPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewAlarmNotificationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ViewAlarmNotificationPolicy(ctx interface{}, id interface{}, domainID interface{}, token interface{}) *SDK_ViewAlarmNotificationPolicy_Call {
+	return &SDK_ViewAlarmNotificationPolicy_Call{Call: _e.mock.On("ViewAlarmNotificationPolicy", ctx, id, domainID, token)}
+}
+
+func (_c *SDK_ViewAlarmNotificationPolicy_Call) Run(run func(ctx context.Context, id string, domainID string, token string)) *SDK_ViewAlarmNotificationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ViewAlarmNotificationPolicy_Call) Return(alarmNotificationPolicy sdk.AlarmNotificationPolicy, sDKError errors.SDKError) *SDK_ViewAlarmNotificationPolicy_Call {
+	_c.Call.Return(alarmNotificationPolicy, sDKError)
+	return _c
+}
+
+func (_c *SDK_ViewAlarmNotificationPolicy_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, token string) (sdk.AlarmNotificationPolicy, errors.SDKError)) *SDK_ViewAlarmNotificationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewBootstrap provides a mock function for the type SDK
 func (_mock *SDK) ViewBootstrap(ctx context.Context, id string, domainID string, token string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _mock.Called(ctx, id, domainID, token)
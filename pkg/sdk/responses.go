@@ -33,10 +33,26 @@ type ChannelsPage struct {
 
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
-	Messages []senml.Message `json:"messages,omitempty"`
+	Messages   []senml.Message `json:"messages,omitempty"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 	PageRes
 }
 
+// SeriesPage contains the series of several channels and measurements aligned
+// on the common time buckets.
+type SeriesPage struct {
+	Times  []float64 `json:"times"`
+	Series []Series  `json:"series"`
+}
+
+// Series contains the aggregated values of a measurement of a channel. The
+// value is nil if there are no messages in the time bucket.
+type Series struct {
+	Channel string     `json:"channel"`
+	Name    string     `json:"name"`
+	Values  []*float64 `json:"values"`
+}
+
 type GroupsPage struct {
 	Groups []Group `json:"groups"`
 	PageRes
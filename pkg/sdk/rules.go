@@ -26,6 +26,10 @@ type Rule struct {
 	Logic        any      `json:"logic,omitempty"`
 	Outputs      any      `json:"outputs,omitempty"`
 	Schedule     any      `json:"schedule,omitempty"`
+	Window       any      `json:"window,omitempty"`
+	RetryPolicy  any      `json:"retry_policy,omitempty"`
+	LastRunAt    string   `json:"last_run_at,omitempty"`
+	LastError    string   `json:"last_error,omitempty"`
 	Status       string   `json:"status,omitempty"`
 	CreatedAt    string   `json:"created_at,omitempty"`
 	CreatedBy    string   `json:"created_by,omitempty"`
@@ -33,6 +37,52 @@ type Rule struct {
 	UpdatedBy    string   `json:"updated_by,omitempty"`
 }
 
+// RuleMessage is a sample message used to dry run a rule.
+// Payload is sent as a plain JSON value.
+type RuleMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Created   int64  `json:"created,omitempty"`
+	Payload   any    `json:"payload"`
+}
+
+// DryRunResult is the result of the rule logic for a single sample message.
+type DryRunResult struct {
+	Result  any             `json:"result"`
+	Outputs []OutputPreview `json:"outputs,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// OutputPreview contains the data a rule output would send.
+type OutputPreview struct {
+	Type    string `json:"type"`
+	Preview any    `json:"preview,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RuleExecution is a single run of a rule.
+type RuleExecution struct {
+	ID        string   `json:"id"`
+	RuleID    string   `json:"rule_id"`
+	DomainID  string   `json:"domain"`
+	MessageID string   `json:"message_id,omitempty"`
+	Level     string   `json:"level"`
+	Message   string   `json:"message,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	StartedAt string   `json:"started_at"`
+	Duration  string   `json:"duration"`
+}
+
+type RuleExecutionsPage struct {
+	Offset     uint64          `json:"offset"`
+	Limit      uint64          `json:"limit"`
+	Total      uint64          `json:"total"`
+	Executions []RuleExecution `json:"executions"`
+}
+
 type Page struct {
 	Offset uint64 `json:"offset"`
 	Limit  uint64 `json:"limit"`
@@ -198,3 +248,57 @@ func (sdk mgSDK) DisableRule(ctx context.Context, id, domainID, token string) (R
 
 	return a, nil
 }
+
+func (sdk mgSDK) DryRunRule(ctx context.Context, r Rule, msgs []RuleMessage, domainID, token string) ([]DryRunResult, errors.SDKError) {
+	req := struct {
+		Rule     *Rule         `json:"rule,omitempty"`
+		Messages []RuleMessage `json:"messages"`
+	}{
+		Messages: msgs,
+	}
+	url := fmt.Sprintf("%s/%s/%s/dry-run", sdk.rulesEngineURL, domainID, rulesEndpoint)
+	switch r.ID {
+	case "":
+		req.Rule = &r
+	default:
+		url = fmt.Sprintf("%s/%s/%s/%s/dry-run", sdk.rulesEngineURL, domainID, rulesEndpoint, r.ID)
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Results []DryRunResult `json:"results"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Results, nil
+}
+
+func (sdk mgSDK) ListRuleExecutions(ctx context.Context, ruleID string, pm PageMetadata, domainID, token string) (RuleExecutionsPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s/executions", domainID, rulesEndpoint, ruleID)
+	url, err := sdk.withQueryParams(sdk.rulesEngineURL, endpoint, pm)
+	if err != nil {
+		return RuleExecutionsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleExecutionsPage{}, sdkerr
+	}
+
+	var ep RuleExecutionsPage
+	if err := json.Unmarshal(body, &ep); err != nil {
+		return RuleExecutionsPage{}, errors.NewSDKError(err)
+	}
+
+	return ep, nil
+}
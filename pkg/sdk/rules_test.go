@@ -6,12 +6,15 @@ package sdk_test
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/roles"
 	"github.com/absmach/supermq/pkg/sdk"
 	"github.com/absmach/supermq/re"
@@ -584,3 +587,183 @@ func TestRemoveRule(t *testing.T) {
 		})
 	}
 }
+
+func TestDryRunRule(t *testing.T) {
+	rs, rsvc, auth := setupRules()
+	defer rs.Close()
+
+	conf := sdk.Config{
+		RulesEngineURL: rs.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	msgs := []sdk.RuleMessage{{Channel: "chan-1", Payload: map[string]any{"temperature": 31.5}}}
+	svcRes := []re.DryRunResult{{Result: true, Outputs: []re.OutputPreview{{Type: "channels", Preview: map[string]any{"channel": "chan-2"}}}}}
+	inline := sdk.Rule{
+		Name:  "temperature-rule",
+		Logic: map[string]any{"type": 0, "value": "return message.payload.temperature > 30"},
+	}
+
+	cases := []struct {
+		desc            string
+		rule            sdk.Rule
+		msgs            []sdk.RuleMessage
+		token           string
+		session         smqauthn.Session
+		svcRes          []re.DryRunResult
+		svcErr          error
+		authenticateErr error
+		response        []sdk.DryRunResult
+		wantErr         bool
+	}{
+		{
+			desc:     "dry run inline rule successfully",
+			rule:     inline,
+			msgs:     msgs,
+			token:    validToken,
+			svcRes:   svcRes,
+			response: []sdk.DryRunResult{{Result: true, Outputs: []sdk.OutputPreview{{Type: "channels", Preview: map[string]any{"channel": "chan-2"}}}}},
+		},
+		{
+			desc:     "dry run stored rule successfully",
+			rule:     sdk.Rule{ID: ruleID},
+			msgs:     msgs,
+			token:    validToken,
+			svcRes:   []re.DryRunResult{{Error: "syntax error"}},
+			response: []sdk.DryRunResult{{Error: "syntax error"}},
+		},
+		{
+			desc:    "dry run rule without messages",
+			rule:    inline,
+			token:   validToken,
+			wantErr: true,
+		},
+		{
+			desc:    "dry run rule with empty token",
+			rule:    inline,
+			msgs:    msgs,
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "dry run rule with service error",
+			rule:    sdk.Rule{ID: ruleID},
+			msgs:    msgs,
+			token:   validToken,
+			svcErr:  errors.New("not found"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := rsvc.On("DryRunRule", mock.Anything, tc.session, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.DryRunRule(context.Background(), tc.rule, tc.msgs, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.response, result)
+				ok := rsvc.AssertCalled(t, "DryRunRule", mock.Anything, tc.session, mock.MatchedBy(func(r re.Rule) bool { return r.ID == tc.rule.ID }), mock.Anything)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListRuleExecutions(t *testing.T) {
+	rs, rsvc, auth := setupRules()
+	defer rs.Close()
+
+	conf := sdk.Config{
+		RulesEngineURL: rs.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	startedAt := time.Now().UTC().Truncate(time.Second)
+	svcPage := re.ExecutionPage{
+		Total: 1,
+		Limit: 10,
+		Executions: []re.Execution{{
+			ID:        validID,
+			RuleID:    ruleID,
+			DomainID:  domainID,
+			Level:     slog.LevelError,
+			Message:   "failed to run rule outputs",
+			Errors:    []string{"failed to publish"},
+			StartedAt: startedAt,
+			Duration:  time.Millisecond,
+		}},
+	}
+
+	cases := []struct {
+		desc            string
+		ruleID          string
+		pm              sdk.PageMetadata
+		token           string
+		session         smqauthn.Session
+		svcRes          re.ExecutionPage
+		svcErr          error
+		authenticateErr error
+		response        sdk.RuleExecutionsPage
+		wantErr         bool
+	}{
+		{
+			desc:   "list rule executions successfully",
+			ruleID: ruleID,
+			pm:     sdk.PageMetadata{Limit: 10},
+			token:  validToken,
+			svcRes: svcPage,
+			response: sdk.RuleExecutionsPage{
+				Total: 1,
+				Limit: 10,
+				Executions: []sdk.RuleExecution{{
+					ID:        validID,
+					RuleID:    ruleID,
+					DomainID:  domainID,
+					Level:     "ERROR",
+					Message:   "failed to run rule outputs",
+					Errors:    []string{"failed to publish"},
+					StartedAt: startedAt.Format(time.RFC3339),
+					Duration:  "1ms",
+				}},
+			},
+		},
+		{
+			desc:    "list rule executions with empty token",
+			ruleID:  ruleID,
+			pm:      sdk.PageMetadata{Limit: 10},
+			token:   "",
+			wantErr: true,
+		},
+		{
+			desc:    "list rule executions with service error",
+			ruleID:  ruleID,
+			pm:      sdk.PageMetadata{Limit: 10},
+			token:   validToken,
+			svcErr:  svcerr.ErrAuthorization,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := rsvc.On("ListExecutions", mock.Anything, tc.session, re.ExecutionPageMeta{RuleID: tc.ruleID, Limit: tc.pm.Limit}).Return(tc.svcRes, tc.svcErr)
+			result, err := mgsdk.ListRuleExecutions(context.Background(), tc.ruleID, tc.pm, domainID, tc.token)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.response, result)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
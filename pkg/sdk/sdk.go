@@ -22,6 +22,7 @@ import (
 
 	"github.com/absmach/supermq/certs"
 	smqerrors "github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/senml"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"moul.io/http2curl"
 )
@@ -91,6 +92,22 @@ type MessagePageMetadata struct {
 	Interval    string  `json:"interval,omitempty"`
 	Value       float64 `json:"value,omitempty"`
 	Protocol    string  `json:"protocol,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
+}
+
+// SeriesPageMetadata contains the parameters of the query of the series of
+// several channels and measurements.
+type SeriesPageMetadata struct {
+	Channels    []string `json:"channels,omitempty"`
+	GroupID     string   `json:"group_id,omitempty"`
+	Names       []string `json:"names,omitempty"`
+	Subtopic    string   `json:"subtopic,omitempty"`
+	Publisher   string   `json:"publisher,omitempty"`
+	Protocol    string   `json:"protocol,omitempty"`
+	From        float64  `json:"from"`
+	To          float64  `json:"to"`
+	Aggregation string   `json:"aggregation"`
+	Interval    string   `json:"interval"`
 }
 
 type Operator uint8
@@ -184,6 +201,8 @@ type PageMetadata struct {
 	EntityID        string    `json:"entity_id,omitempty"`
 	CommonName      string    `json:"common_name,omitempty"`
 	TTL             string    `json:"ttl,omitempty"`
+	GroupBy         string    `json:"group_by,omitempty"`
+	Interval        string    `json:"interval,omitempty"`
 }
 
 type Role struct {
@@ -1584,6 +1603,11 @@ type SDK interface {
 	// BootstrapSecure retrieves a configuration with given external ID and encrypted external key.
 	BootstrapSecure(ctx context.Context, externalID, externalKey, cryptoKey string) (BootstrapConfig, smqerrors.SDKError)
 
+	// EnrollBootstrapCert links the certificate the Client enrolled for to the
+	// Config with provided external ID. The token is the secret the Certs service
+	// shares with the Bootstrap service.
+	EnrollBootstrapCert(ctx context.Context, externalID, clientCert, ca, token string) (BootstrapConfig, smqerrors.SDKError)
+
 	// Bootstraps retrieves a list of managed configs.
 	Bootstraps(ctx context.Context, pm PageMetadata, domainID, token string) (BootstrapPage, smqerrors.SDKError)
 
@@ -1593,6 +1617,14 @@ type SDK interface {
 	// ReadMessages reads messages of specified channel.
 	ReadMessages(ctx context.Context, pm MessagePageMetadata, chanID, domainID, token string) (MessagesPage, smqerrors.SDKError)
 
+	// ExportMessages streams messages of specified channel and passes them one
+	// by one to the given function. A zero limit exports all the messages.
+	ExportMessages(ctx context.Context, pm MessagePageMetadata, chanID, domainID, token string, fn func(senml.Message) error) smqerrors.SDKError
+
+	// ReadSeries reads the values of several measurements of several channels
+	// aggregated in the common time buckets.
+	ReadSeries(ctx context.Context, pm SeriesPageMetadata, domainID, token string) (SeriesPage, smqerrors.SDKError)
+
 	// CreateSubscription creates a new subscription.
 	CreateSubscription(ctx context.Context, topic, contact, token string) (string, smqerrors.SDKError)
 
@@ -1617,6 +1649,26 @@ type SDK interface {
 	// DeleteAlarm deletes an alarm.
 	DeleteAlarm(ctx context.Context, id, domainID, token string) smqerrors.SDKError
 
+	// AlarmStats retrieves the alarm counts grouped by status, severity, rule,
+	// channel or client, optionally per hour, day, week or month, with the
+	// mean times to acknowledge and resolve the alarms in seconds.
+	AlarmStats(ctx context.Context, pm PageMetadata, domainID, token string) (AlarmStats, smqerrors.SDKError)
+
+	// AddAlarmNotificationPolicy adds a new alarm notification policy to the domain.
+	AddAlarmNotificationPolicy(ctx context.Context, policy AlarmNotificationPolicy, domainID, token string) (AlarmNotificationPolicy, smqerrors.SDKError)
+
+	// ViewAlarmNotificationPolicy retrieves an alarm notification policy by its ID.
+	ViewAlarmNotificationPolicy(ctx context.Context, id, domainID, token string) (AlarmNotificationPolicy, smqerrors.SDKError)
+
+	// UpdateAlarmNotificationPolicy replaces an existing alarm notification policy.
+	UpdateAlarmNotificationPolicy(ctx context.Context, policy AlarmNotificationPolicy, domainID, token string) (AlarmNotificationPolicy, smqerrors.SDKError)
+
+	// ListAlarmNotificationPolicies retrieves a page of alarm notification policies of the domain.
+	ListAlarmNotificationPolicies(ctx context.Context, pm PageMetadata, domainID, token string) (AlarmNotificationPoliciesPage, smqerrors.SDKError)
+
+	// RemoveAlarmNotificationPolicy removes an alarm notification policy.
+	RemoveAlarmNotificationPolicy(ctx context.Context, id, domainID, token string) smqerrors.SDKError
+
 	// AddReportConfig creates a new report configuration.
 	AddReportConfig(ctx context.Context, cfg ReportConfig, domainID, token string) (ReportConfig, smqerrors.SDKError)
 
@@ -1680,6 +1732,24 @@ type SDK interface {
 	// DisableRule disables a rule.
 	DisableRule(ctx context.Context, id, domainID, token string) (Rule, smqerrors.SDKError)
 
+	// DryRunRule runs the rule logic against the sample messages and returns the
+	// script results and the rendered outputs, without executing the outputs.
+	// If the rule ID is set, the stored rule is used.
+	//
+	// example:
+	//  msgs := []sdk.RuleMessage{{Payload: map[string]any{"temperature": 31.5}}}
+	//  results, _ := sdk.DryRunRule(context.Background(), sdk.Rule{ID: "ruleID"}, msgs, "domainID", "token")
+	//  fmt.Println(results)
+	DryRunRule(ctx context.Context, r Rule, msgs []RuleMessage, domainID, token string) ([]DryRunResult, smqerrors.SDKError)
+
+	// ListRuleExecutions retrieves a page of the rule executions, newest first.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{Offset: 0, Limit: 10}
+	//  page, _ := sdk.ListRuleExecutions(context.Background(), "ruleID", pm, "domainID", "token")
+	//  fmt.Println(page)
+	ListRuleExecutions(ctx context.Context, ruleID string, pm PageMetadata, domainID, token string) (RuleExecutionsPage, smqerrors.SDKError)
+
 	// IssueCert issues a certificate for an entity.
 	//
 	// example:
@@ -2053,6 +2123,12 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.TTL != "" {
 		q.Add("ttl", pm.TTL)
 	}
+	if pm.GroupBy != "" {
+		q.Add("group_by", pm.GroupBy)
+	}
+	if pm.Interval != "" {
+		q.Add("interval", pm.Interval)
+	}
 
 	return q.Encode(), nil
 }
@@ -59,10 +59,24 @@ func (s *httpServer) Start() error {
 
 		tlsConf := s.server.TLSConfig.Clone()
 		tlsConf.Certificates = append(tlsConf.Certificates, certs)
+
+		// HTTP APIs authenticate with tokens, so the client certificate is
+		// verified only if the client presents one.
+		clientCA, err := server.LoadRootCACerts(s.Config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client ca file: %w", err)
+		}
+		mtls := ""
+		if clientCA != nil {
+			tlsConf.ClientCAs = clientCA
+			tlsConf.ClientAuth = tls.VerifyClientCertIfGiven
+			mtls = fmt.Sprintf(" and client ca %s", s.Config.ClientCAFile)
+		}
+
 		s.server.TLSConfig = tlsConf
 		s.Protocol = httpsProtocol
 
-		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS", s.Name, s.Protocol, s.Address))
+		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS%s", s.Name, s.Protocol, s.Address, mtls))
 		go func() {
 			errCh <- s.server.ListenAndServeTLS("", "")
 		}()
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cbor contains the transformer of the plain CBOR maps to the JSON
+// messages.
+package cbor
@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cbor
+
+import (
+	"reflect"
+
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ContentType represents the plain CBOR content type.
+const ContentType = "application/cbor"
+
+// Maps are decoded with the string keys, so the payloads are represented the
+// same way as the decoded JSON objects. Maps with the keys of other types are
+// rejected.
+var decMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+}.DecMode()
+
+// New returns a new transformer of the CBOR maps, or the arrays of the maps,
+// to the JSON messages.
+func New(tfs []json.TimeField) transformers.Transformer {
+	return json.NewWithDecoder(decode, tfs)
+}
+
+func decode(data []byte) (any, error) {
+	var payload any
+	if err := decMode.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
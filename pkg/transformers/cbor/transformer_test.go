@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cbor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers/cbor"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	fxcbor "github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(t *testing.T, v any) []byte {
+	b, err := fxcbor.Marshal(v)
+	assert.Nil(t, err, "unexpected error encoding CBOR: %s", err)
+	return b
+}
+
+func TestTransform(t *testing.T) {
+	now := time.Now().UnixNano()
+	tr := cbor.New([]json.TimeField{{FieldName: "ts", FieldFormat: "unix"}})
+	msg := &messaging.Message{
+		Channel:   "channel-1",
+		Subtopic:  "subtopic-1",
+		Publisher: "publisher-1",
+		Protocol:  "protocol",
+		Created:   now,
+	}
+	base := json.Message{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   now,
+	}
+
+	cases := []struct {
+		desc    string
+		payload []byte
+		msgs    any
+		err     error
+	}{
+		{
+			desc:    "transform CBOR map",
+			payload: encode(t, map[string]any{"temp": 21.5, "count": 3, "nested": map[string]any{"on": true}}),
+			msgs: json.Messages{
+				Data:   []json.Message{withPayload(base, json.Payload{"temp": 21.5, "count": uint64(3), "nested": map[string]any{"on": true}})},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform CBOR array of maps",
+			payload: encode(t, []any{map[string]any{"temp": 21.5}, map[string]any{"temp": -1}}),
+			msgs: json.Messages{
+				Data:   []json.Message{withPayload(base, json.Payload{"temp": 21.5}), withPayload(base, json.Payload{"temp": int64(-1)})},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform CBOR map with time field",
+			payload: encode(t, map[string]any{"ts": 1638310819}),
+			msgs: json.Messages{
+				Data:   []json.Message{withCreated(withPayload(base, json.Payload{"ts": uint64(1638310819)}), 1638310819000000000)},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform CBOR map with integer keys",
+			payload: encode(t, map[int]any{1: "a"}),
+			err:     json.ErrTransform,
+		},
+		{
+			desc:    "transform invalid CBOR",
+			payload: []byte{0xff, 0x01},
+			err:     json.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			m := &messaging.Message{
+				Channel:   msg.Channel,
+				Subtopic:  msg.Subtopic,
+				Publisher: msg.Publisher,
+				Protocol:  msg.Protocol,
+				Created:   msg.Created,
+				Payload:   tc.payload,
+			}
+			msgs, err := tr.Transform(m)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.msgs, msgs)
+			}
+		})
+	}
+}
+
+func withPayload(m json.Message, p json.Payload) json.Message {
+	m.Payload = p
+	return m
+}
+
+func withCreated(m json.Message, created int64) json.Message {
+	m.Created = created
+	return m
+}
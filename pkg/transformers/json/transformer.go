@@ -36,20 +36,43 @@ type TimeField struct {
 	Location    string `toml:"location"`
 }
 
+// Decoder decodes the message payload into the object or the array of the
+// objects, represented the same way as the decoded JSON.
+type Decoder func(data []byte) (any, error)
+
 type transformerService struct {
+	decode     Decoder
 	timeFields []TimeField
 }
 
 // New returns a new JSON transformer.
 func New(tfs []TimeField) transformers.Transformer {
+	return NewWithDecoder(decode, tfs)
+}
+
+// NewWithDecoder returns a new transformer of the payloads decoded by the
+// given decoder to the list of JSON messages. It is used by the transformers
+// of the other encodings of the JSON-like objects.
+func NewWithDecoder(d Decoder, tfs []TimeField) transformers.Transformer {
 	return &transformerService{
+		decode:     d,
 		timeFields: tfs,
 	}
 }
 
+func decode(data []byte) (any, error) {
+	var payload any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
 // Transform transforms SuperMQ message to a list of JSON messages.
 func (ts *transformerService) Transform(msg *messaging.Message) (any, error) {
 	ret := Message{
+		Domain:    msg.GetDomain(),
 		Publisher: msg.GetPublisher(),
 		Created:   msg.GetCreated(),
 		Protocol:  msg.GetProtocol(),
@@ -67,8 +90,8 @@ func (ts *transformerService) Transform(msg *messaging.Message) (any, error) {
 	}
 
 	format := subs[len(subs)-1]
-	var payload any
-	if err := json.Unmarshal(msg.GetPayload(), &payload); err != nil {
+	payload, err := ts.decode(msg.GetPayload())
+	if err != nil {
 		return nil, errors.Wrap(ErrTransform, err)
 	}
 
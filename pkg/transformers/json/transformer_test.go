@@ -36,6 +36,7 @@ func TestTransformJSON(t *testing.T) {
 	}
 	tr := json.New(ts)
 	msg := messaging.Message{
+		Domain:    "domain-1",
 		Channel:   "channel-1",
 		Subtopic:  "subtopic-1",
 		Publisher: "publisher-1",
@@ -100,6 +101,7 @@ func TestTransformJSON(t *testing.T) {
 	jsonMsgs := json.Messages{
 		Data: []json.Message{
 			{
+				Domain:    msg.Domain,
 				Channel:   msg.Channel,
 				Subtopic:  msg.Subtopic,
 				Publisher: msg.Publisher,
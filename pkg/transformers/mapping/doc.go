@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mapping contains the transformer which renames, converts and
+// flattens the fields of the JSON messages produced by the other transformers.
+package mapping
@@ -0,0 +1,186 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mapping
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+)
+
+// Sep separates the keys of the nested objects in the field paths. It is the
+// separator of the flattened keys too.
+const Sep = "/"
+
+var (
+	// ErrInvalidMapping indicates that the field mapping is malformed.
+	ErrInvalidMapping = errors.New("invalid field mapping")
+
+	// ErrMapping indicates that the message fields can not be mapped.
+	ErrMapping = errors.New("failed to map message fields")
+
+	errUnsupportedMessage = errors.New("field mapping supports only JSON messages")
+)
+
+// Field maps the payload field at the From path to the To path. If the scale
+// or the offset is set, the numeric value is converted to value*Scale+Offset,
+// e.g. the scale 0.5556 and the offset -17.7778 convert Fahrenheit to Celsius.
+// Zero scale is treated as one.
+type Field struct {
+	From   string
+	To     string
+	Scale  float64
+	Offset float64
+}
+
+// Config defines the mapping of the payload fields. If Flatten is set, the
+// nested objects of the payload are flattened before the fields are mapped,
+// so the paths address the flattened keys. Payload fields without the mapping
+// are kept only if KeepUnmapped is set.
+type Config struct {
+	Fields       []Field
+	Flatten      bool
+	KeepUnmapped bool
+}
+
+type transformer struct {
+	transformer transformers.Transformer
+	cfg         Config
+}
+
+// New returns the transformer which maps the fields of the JSON messages
+// returned by the given transformer.
+func New(t transformers.Transformer, cfg Config) (transformers.Transformer, error) {
+	for _, f := range cfg.Fields {
+		if f.From == "" || f.To == "" {
+			return nil, errors.Wrap(ErrInvalidMapping, fmt.Errorf("missing path of field %q to %q", f.From, f.To))
+		}
+	}
+
+	return &transformer{
+		transformer: t,
+		cfg:         cfg,
+	}, nil
+}
+
+func (t *transformer) Transform(msg *messaging.Message) (any, error) {
+	m, err := t.transformer.Transform(msg)
+	if err != nil {
+		return nil, err
+	}
+	msgs, ok := m.(smqjson.Messages)
+	if !ok {
+		return nil, errors.Wrap(ErrMapping, errUnsupportedMessage)
+	}
+	for i := range msgs.Data {
+		p, err := t.mapPayload(msgs.Data[i].Payload)
+		if err != nil {
+			return nil, errors.Wrap(ErrMapping, err)
+		}
+		msgs.Data[i].Payload = p
+	}
+
+	return msgs, nil
+}
+
+func (t *transformer) mapPayload(payload smqjson.Payload) (smqjson.Payload, error) {
+	src := map[string]any(payload)
+	if t.cfg.Flatten {
+		flat, err := smqjson.Flatten(src)
+		if err != nil {
+			return nil, err
+		}
+		src = flat
+	}
+
+	dst := make(map[string]any)
+	if t.cfg.KeepUnmapped {
+		dst = src
+	}
+	for _, f := range t.cfg.Fields {
+		v, ok := t.take(src, f.From)
+		if !ok {
+			continue
+		}
+		if f.Scale != 0 || f.Offset != 0 {
+			val, err := convert(v, f)
+			if err != nil {
+				return nil, err
+			}
+			v = val
+		}
+		t.put(dst, f.To, v)
+	}
+
+	return dst, nil
+}
+
+// take removes the value at the path from the payload and returns it.
+func (t *transformer) take(payload map[string]any, path string) (any, bool) {
+	if t.cfg.Flatten {
+		v, ok := payload[path]
+		delete(payload, path)
+		return v, ok
+	}
+	keys := strings.Split(path, Sep)
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := payload[k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		payload = next
+	}
+	k := keys[len(keys)-1]
+	v, ok := payload[k]
+	delete(payload, k)
+
+	return v, ok
+}
+
+// put sets the value at the path of the payload, creating the missing
+// nested objects.
+func (t *transformer) put(payload map[string]any, path string, v any) {
+	if t.cfg.Flatten {
+		payload[path] = v
+		return
+	}
+	keys := strings.Split(path, Sep)
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := payload[k].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			payload[k] = next
+		}
+		payload = next
+	}
+	payload[keys[len(keys)-1]] = v
+}
+
+func convert(v any, f Field) (float64, error) {
+	var val float64
+	switch n := v.(type) {
+	case float64:
+		val = n
+	case float32:
+		val = float64(n)
+	case int64:
+		val = float64(n)
+	case uint64:
+		val = float64(n)
+	case int:
+		val = float64(n)
+	default:
+		return 0, fmt.Errorf("field %q of type %T is not numeric", f.From, v)
+	}
+	scale := f.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	return val*scale + f.Offset, nil
+}
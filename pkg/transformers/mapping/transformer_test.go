@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mapping_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/mapping"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+const payload = `{"device": {"temp_f": 212, "hum": 40}, "status": "ok"}`
+
+var scaled = mapping.Field{From: "device/temp_f", To: "temperature", Scale: 0.5, Offset: -6}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  mapping.Config
+		err  error
+	}{
+		{
+			desc: "create transformer with valid mapping",
+			cfg:  mapping.Config{Fields: []mapping.Field{scaled}},
+			err:  nil,
+		},
+		{
+			desc: "create transformer with field without source path",
+			cfg:  mapping.Config{Fields: []mapping.Field{{To: "temperature"}}},
+			err:  mapping.ErrInvalidMapping,
+		},
+		{
+			desc: "create transformer with field without target path",
+			cfg:  mapping.Config{Fields: []mapping.Field{{From: "temp"}}},
+			err:  mapping.ErrInvalidMapping,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := mapping.New(json.New(nil), tc.cfg)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	cases := []struct {
+		desc    string
+		cfg     mapping.Config
+		payload string
+		want    json.Payload
+		err     error
+	}{
+		{
+			desc:    "map nested fields",
+			cfg:     mapping.Config{Fields: []mapping.Field{scaled, {From: "device/hum", To: "env/humidity"}}},
+			payload: payload,
+			want:    json.Payload{"temperature": 100.0, "env": map[string]any{"humidity": 40.0}},
+		},
+		{
+			desc:    "map fields keeping unmapped ones",
+			cfg:     mapping.Config{Fields: []mapping.Field{scaled}, KeepUnmapped: true},
+			payload: payload,
+			want:    json.Payload{"temperature": 100.0, "device": map[string]any{"hum": 40.0}, "status": "ok"},
+		},
+		{
+			desc:    "map flattened fields",
+			cfg:     mapping.Config{Fields: []mapping.Field{scaled}, Flatten: true, KeepUnmapped: true},
+			payload: payload,
+			want:    json.Payload{"temperature": 100.0, "device/hum": 40.0, "status": "ok"},
+		},
+		{
+			desc:    "flatten payload without fields",
+			cfg:     mapping.Config{Flatten: true, KeepUnmapped: true},
+			payload: payload,
+			want:    json.Payload{"device/temp_f": 212.0, "device/hum": 40.0, "status": "ok"},
+		},
+		{
+			desc:    "map missing field",
+			cfg:     mapping.Config{Fields: []mapping.Field{{From: "device/pressure", To: "pressure"}}},
+			payload: payload,
+			want:    json.Payload{},
+		},
+		{
+			desc:    "convert non-numeric field",
+			cfg:     mapping.Config{Fields: []mapping.Field{{From: "status", To: "status", Scale: 2}}},
+			payload: payload,
+			err:     mapping.ErrMapping,
+		},
+		{
+			desc:    "flatten payload with reserved key",
+			cfg:     mapping.Config{Flatten: true},
+			payload: `{"channel": "x"}`,
+			err:     mapping.ErrMapping,
+		},
+		{
+			desc:    "map invalid payload",
+			cfg:     mapping.Config{Fields: []mapping.Field{scaled}},
+			payload: `{"device": }`,
+			err:     json.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr, err := mapping.New(json.New(nil), tc.cfg)
+			assert.Nil(t, err, "unexpected error creating transformer: %s", err)
+
+			msg := messaging.Message{Channel: "channel-1", Subtopic: "readings", Payload: []byte(tc.payload)}
+			res, err := tr.Transform(&msg)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			if tc.err != nil {
+				return
+			}
+			msgs, ok := res.(json.Messages)
+			assert.True(t, ok, "expected JSON messages, got %T", res)
+			assert.Len(t, msgs.Data, 1)
+			assert.Equal(t, tc.want, msgs.Data[0].Payload)
+		})
+	}
+}
+
+func TestTransformSenML(t *testing.T) {
+	tr, err := mapping.New(senml.New(senml.JSON), mapping.Config{Fields: []mapping.Field{scaled}})
+	assert.Nil(t, err, "unexpected error creating transformer: %s", err)
+
+	msg := messaging.Message{Channel: "channel-1", Payload: []byte(`[{"n": "temp", "v": 1}]`)}
+	_, err = tr.Transform(&msg)
+	assert.True(t, errors.Contains(err, mapping.ErrMapping), "expected error %s, got %s", mapping.ErrMapping, err)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package msgpack_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/transformers/msgpack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		desc  string
+		data  []byte
+		value any
+		fails bool
+	}{
+		{
+			desc:  "decode positive fixint",
+			data:  []byte{0x07},
+			value: int64(7),
+		},
+		{
+			desc:  "decode negative fixint",
+			data:  []byte{0xff},
+			value: int64(-1),
+		},
+		{
+			desc:  "decode nil, false and true",
+			data:  []byte{0x93, 0xc0, 0xc2, 0xc3},
+			value: []any{nil, false, true},
+		},
+		{
+			desc:  "decode unsigned integers",
+			data:  []byte{0x94, 0xcc, 0xff, 0xcd, 0x01, 0x00, 0xce, 0x00, 0x01, 0x00, 0x00, 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			value: []any{uint64(255), uint64(256), uint64(65536), uint64(math.MaxUint64)},
+		},
+		{
+			desc:  "decode signed integers",
+			data:  []byte{0x94, 0xd0, 0x80, 0xd1, 0xff, 0x00, 0xd2, 0xff, 0xff, 0xff, 0xfe, 0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0},
+			value: []any{int64(-128), int64(-256), int64(-2), int64(math.MinInt64)},
+		},
+		{
+			desc:  "decode floats",
+			data:  []byte{0x92, 0xca, 0x3f, 0xc0, 0x00, 0x00, 0xcb, 0x40, 0x35, 0x80, 0, 0, 0, 0, 0},
+			value: []any{1.5, 21.5},
+		},
+		{
+			desc:  "decode strings and binary",
+			data:  []byte{0x93, 0xa2, 'h', 'i', 0xd9, 0x01, 'x', 0xc4, 0x02, 0x01, 0x02},
+			value: []any{"hi", "x", []byte{0x01, 0x02}},
+		},
+		{
+			desc:  "decode nested map",
+			data:  []byte{0x82, 0xa4, 't', 'e', 'm', 'p', 0xcb, 0x40, 0x35, 0x80, 0, 0, 0, 0, 0, 0xa1, 'n', 0x81, 0xa2, 'o', 'n', 0xc3},
+			value: map[string]any{"temp": 21.5, "n": map[string]any{"on": true}},
+		},
+		{
+			desc:  "decode map16 and array16",
+			data:  []byte{0xde, 0x00, 0x01, 0xa1, 'a', 0xdc, 0x00, 0x01, 0x01},
+			value: map[string]any{"a": []any{int64(1)}},
+		},
+		{
+			desc:  "decode 32-bit timestamp",
+			data:  []byte{0xd6, 0xff, 0x61, 0xa6, 0xa3, 0xa3},
+			value: time.Unix(1638310819, 0).UTC(),
+		},
+		{
+			desc:  "decode 96-bit timestamp",
+			data:  []byte{0xc7, 0x0c, 0xff, 0x00, 0x00, 0x00, 0x05, 0, 0, 0, 0, 0x61, 0xa6, 0xa3, 0xa3},
+			value: time.Unix(1638310819, 5).UTC(),
+		},
+		{
+			desc:  "decode map with integer key",
+			data:  []byte{0x81, 0x01, 0x02},
+			fails: true,
+		},
+		{
+			desc:  "decode unsupported extension",
+			data:  []byte{0xd4, 0x01, 0x00},
+			fails: true,
+		},
+		{
+			desc:  "decode truncated string",
+			data:  []byte{0xa5, 'a'},
+			fails: true,
+		},
+		{
+			desc:  "decode array with length exceeding data",
+			data:  []byte{0xdd, 0xff, 0xff, 0xff, 0xff},
+			fails: true,
+		},
+		{
+			desc:  "decode invalid type code",
+			data:  []byte{0xc1},
+			fails: true,
+		},
+		{
+			desc:  "decode value with trailing bytes",
+			data:  []byte{0x01, 0x02},
+			fails: true,
+		},
+		{
+			desc:  "decode empty data",
+			data:  []byte{},
+			fails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			v, err := msgpack.Decode(tc.data)
+			if tc.fails {
+				assert.NotNil(t, err, "expected error decoding %x", tc.data)
+				return
+			}
+			assert.Nil(t, err, "unexpected error decoding %x: %s", tc.data, err)
+			assert.Equal(t, tc.value, v)
+		})
+	}
+}
+
+func TestDecodeDepth(t *testing.T) {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = 0x91
+	}
+	_, err := msgpack.Decode(append(data, 0x01))
+	assert.NotNil(t, err, "expected error decoding deeply nested arrays")
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package msgpack contains the transformer of the MessagePack maps to the
+// JSON messages.
+package msgpack
@@ -0,0 +1,18 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package msgpack
+
+import (
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/json"
+)
+
+// ContentType represents the MessagePack content type.
+const ContentType = "application/msgpack"
+
+// New returns a new transformer of the MessagePack maps, or the arrays of the
+// maps, to the JSON messages.
+func New(tfs []json.TimeField) transformers.Transformer {
+	return json.NewWithDecoder(Decode, tfs)
+}
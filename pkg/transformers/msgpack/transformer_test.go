@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package msgpack_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/msgpack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransform(t *testing.T) {
+	tr := msgpack.New(nil)
+	msg := &messaging.Message{
+		Channel:   "channel-1",
+		Subtopic:  "subtopic-1",
+		Publisher: "publisher-1",
+		Protocol:  "protocol",
+		Created:   1,
+	}
+	base := json.Message{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+	}
+
+	cases := []struct {
+		desc    string
+		payload []byte
+		msgs    any
+		err     error
+	}{
+		{
+			desc:    "transform MessagePack map",
+			payload: []byte{0x82, 0xa4, 't', 'e', 'm', 'p', 0xcb, 0x40, 0x35, 0x80, 0, 0, 0, 0, 0, 0xa2, 'o', 'n', 0xc3},
+			msgs: json.Messages{
+				Data:   []json.Message{withPayload(base, json.Payload{"temp": 21.5, "on": true})},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform MessagePack array of maps",
+			payload: []byte{0x92, 0x81, 0xa1, 'a', 0x01, 0x81, 0xa1, 'a', 0x02},
+			msgs: json.Messages{
+				Data:   []json.Message{withPayload(base, json.Payload{"a": int64(1)}), withPayload(base, json.Payload{"a": int64(2)})},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform MessagePack scalar",
+			payload: []byte{0x01},
+			err:     json.ErrTransform,
+		},
+		{
+			desc:    "transform invalid MessagePack",
+			payload: []byte{0xc1},
+			err:     json.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			m := &messaging.Message{
+				Channel:   msg.Channel,
+				Subtopic:  msg.Subtopic,
+				Publisher: msg.Publisher,
+				Protocol:  msg.Protocol,
+				Created:   msg.Created,
+				Payload:   tc.payload,
+			}
+			msgs, err := tr.Transform(m)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.msgs, msgs)
+			}
+		})
+	}
+}
+
+func withPayload(m json.Message, p json.Payload) json.Message {
+	m.Payload = p
+	return m
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protobuf contains the transformer of the Protobuf messages, described
+// by the registered descriptors, to the JSON messages.
+package protobuf
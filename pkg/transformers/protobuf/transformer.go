@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ContentType represents the Protobuf content type.
+const ContentType = "application/x-protobuf"
+
+var (
+	// ErrUnknownMessage indicates that the message type is not described by
+	// the registered descriptors.
+	ErrUnknownMessage = errors.New("unknown protobuf message type")
+
+	errReadDescriptors  = errors.New("failed to read protobuf descriptor set")
+	errParseDescriptors = errors.New("failed to parse protobuf descriptor set")
+)
+
+// Payloads are converted using the field names from the descriptor, so they
+// match the names in the vendor .proto files.
+var marshalOpts = protojson.MarshalOptions{UseProtoNames: true}
+
+// LoadDescriptors reads the file descriptor set, as produced by
+// `protoc --descriptor_set_out --include_imports`, and registers its files.
+func LoadDescriptors(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errReadDescriptors, err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrap(errParseDescriptors, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, errors.Wrap(errParseDescriptors, err)
+	}
+
+	return files, nil
+}
+
+// New returns a new transformer of the Protobuf messages of the given fully
+// qualified type to the JSON messages. Payloads are converted following the
+// Protobuf JSON mapping.
+func New(files *protoregistry.Files, message string, tfs []smqjson.TimeField) (transformers.Transformer, error) {
+	d, err := files.FindDescriptorByName(protoreflect.FullName(message))
+	if err != nil {
+		return nil, errors.Wrap(ErrUnknownMessage, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, ErrUnknownMessage
+	}
+
+	decode := func(data []byte) (any, error) {
+		msg := dynamicpb.NewMessage(md)
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return nil, err
+		}
+		b, err := marshalOpts.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		var payload any
+		if err := json.Unmarshal(b, &payload); err != nil {
+			return nil, err
+		}
+
+		return payload, nil
+	}
+
+	return smqjson.NewWithDecoder(decode, tfs), nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package protobuf_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const readingType = "vendor.v1.Reading"
+
+// Descriptor of the message:
+//
+//	package vendor.v1;
+//	message Reading {
+//	  string sensor_id = 1;
+//	  double temperature = 2;
+//	  bool active = 3;
+//	}
+var descriptors = &descriptorpb.FileDescriptorSet{
+	File: []*descriptorpb.FileDescriptorProto{
+		{
+			Name:    proto.String("vendor/v1/reading.proto"),
+			Package: proto.String("vendor.v1"),
+			Syntax:  proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Reading"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("sensor_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						field("temperature", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+						field("active", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+					},
+				},
+			},
+		},
+	},
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(name),
+	}
+}
+
+func loadDescriptors(t *testing.T) *protoregistry.Files {
+	data, err := proto.Marshal(descriptors)
+	assert.Nil(t, err, "unexpected error encoding descriptors: %s", err)
+	path := filepath.Join(t.TempDir(), "descriptors.binpb")
+	err = os.WriteFile(path, data, 0o600)
+	assert.Nil(t, err, "unexpected error writing descriptors: %s", err)
+
+	files, err := protobuf.LoadDescriptors(path)
+	assert.Nil(t, err, "unexpected error loading descriptors: %s", err)
+
+	return files
+}
+
+func TestLoadDescriptors(t *testing.T) {
+	files := loadDescriptors(t)
+	_, err := files.FindDescriptorByName(readingType)
+	assert.Nil(t, err, "expected registered message type, got %s", err)
+
+	_, err = protobuf.LoadDescriptors(filepath.Join(t.TempDir(), "missing.binpb"))
+	assert.NotNil(t, err, "expected error loading missing descriptors")
+
+	path := filepath.Join(t.TempDir(), "invalid.binpb")
+	err = os.WriteFile(path, []byte{0xff, 0xff}, 0o600)
+	assert.Nil(t, err, "unexpected error writing descriptors: %s", err)
+	_, err = protobuf.LoadDescriptors(path)
+	assert.NotNil(t, err, "expected error loading invalid descriptors")
+}
+
+func TestNew(t *testing.T) {
+	files := loadDescriptors(t)
+
+	cases := []struct {
+		desc    string
+		message string
+		err     error
+	}{
+		{
+			desc:    "create transformer of registered message",
+			message: readingType,
+			err:     nil,
+		},
+		{
+			desc:    "create transformer of unknown message",
+			message: "vendor.v1.Unknown",
+			err:     protobuf.ErrUnknownMessage,
+		},
+		{
+			desc:    "create transformer of package",
+			message: "vendor.v1",
+			err:     protobuf.ErrUnknownMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := protobuf.New(files, tc.message, nil)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	files := loadDescriptors(t)
+	tr, err := protobuf.New(files, readingType, nil)
+	assert.Nil(t, err, "unexpected error creating transformer: %s", err)
+
+	d, err := files.FindDescriptorByName(readingType)
+	assert.Nil(t, err, "unexpected error finding message: %s", err)
+	md := d.(protoreflect.MessageDescriptor)
+	reading := dynamicpb.NewMessage(md)
+	reading.Set(md.Fields().ByName("sensor_id"), protoreflect.ValueOfString("s-1"))
+	reading.Set(md.Fields().ByName("temperature"), protoreflect.ValueOfFloat64(21.5))
+	reading.Set(md.Fields().ByName("active"), protoreflect.ValueOfBool(true))
+	payload, err := proto.Marshal(reading)
+	assert.Nil(t, err, "unexpected error encoding message: %s", err)
+
+	msg := &messaging.Message{
+		Channel:   "channel-1",
+		Subtopic:  "readings",
+		Publisher: "publisher-1",
+		Protocol:  "protocol",
+		Created:   1,
+	}
+
+	cases := []struct {
+		desc    string
+		payload []byte
+		msgs    any
+		err     error
+	}{
+		{
+			desc:    "transform Protobuf message",
+			payload: payload,
+			msgs: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   msg.Channel,
+						Subtopic:  msg.Subtopic,
+						Publisher: msg.Publisher,
+						Protocol:  msg.Protocol,
+						Created:   msg.Created,
+						Payload:   json.Payload{"sensor_id": "s-1", "temperature": 21.5, "active": true},
+					},
+				},
+				Format: msg.Subtopic,
+			},
+		},
+		{
+			desc:    "transform invalid Protobuf message",
+			payload: []byte{0xff, 0xff, 0xff},
+			err:     json.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			m := &messaging.Message{
+				Channel:   msg.Channel,
+				Subtopic:  msg.Subtopic,
+				Publisher: msg.Publisher,
+				Protocol:  msg.Protocol,
+				Created:   msg.Created,
+				Payload:   tc.payload,
+			}
+			msgs, err := tr.Transform(m)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.msgs, msgs)
+			}
+		})
+	}
+}
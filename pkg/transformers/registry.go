@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	topicSep       = "/"
+	singleWildcard = "+"
+	multiWildcard  = "#"
+)
+
+// ErrInvalidTopicFilter indicates that the topic filter is malformed.
+var ErrInvalidTopicFilter = errors.New("invalid topic filter")
+
+// Registry specifies the transformer which delegates each message to the
+// transformer registered for its channel or topic.
+type Registry interface {
+	Transformer
+
+	// RegisterChannel registers the transformer of the messages of the channel.
+	// Channel transformers take precedence over the topic ones.
+	RegisterChannel(channelID string, t Transformer)
+
+	// RegisterTopic registers the transformer of the messages with the topic
+	// matching the MQTT-style filter, e.g. "m/+/c/<channel_id>/sensors/#". The
+	// filters are matched against the "m/<domain_id>/c/<channel_id>/<subtopic>"
+	// topic of the message in the registration order.
+	RegisterTopic(filter string, t Transformer) error
+}
+
+type registry struct {
+	def      Transformer
+	channels map[string]Transformer
+	// topics are the topic transformers in the registration order, indexed
+	// by their filters in the trie.
+	topics  []Transformer
+	filters *messaging.TopicTrie[int]
+}
+
+var _ Registry = (*registry)(nil)
+
+// NewRegistry returns the transformer registry. Messages without the
+// registered transformer are transformed by the default transformer, or
+// passed as they are if the default transformer is nil.
+func NewRegistry(def Transformer) Registry {
+	return &registry{
+		def:      def,
+		channels: make(map[string]Transformer),
+		filters:  messaging.NewTopicTrie[int](),
+	}
+}
+
+func (r *registry) RegisterChannel(channelID string, t Transformer) {
+	r.channels[channelID] = t
+}
+
+func (r *registry) RegisterTopic(filter string, t Transformer) error {
+	levels := strings.Split(filter, topicSep)
+	for i, l := range levels {
+		if l == multiWildcard && i != len(levels)-1 {
+			return errors.Wrap(ErrInvalidTopicFilter, fmt.Errorf("%q is not the last level of %q", multiWildcard, filter))
+		}
+		if l != singleWildcard && l != multiWildcard && strings.ContainsAny(l, singleWildcard+multiWildcard) {
+			return errors.Wrap(ErrInvalidTopicFilter, fmt.Errorf("wildcard is not the whole level of %q", filter))
+		}
+	}
+	r.filters.Insert(filter, len(r.topics))
+	r.topics = append(r.topics, t)
+
+	return nil
+}
+
+func (r *registry) Transform(msg *messaging.Message) (any, error) {
+	t := r.transformer(msg)
+	if t == nil {
+		return msg, nil
+	}
+
+	return t.Transform(msg)
+}
+
+func (r *registry) transformer(msg *messaging.Message) Transformer {
+	if t, ok := r.channels[msg.GetChannel()]; ok {
+		return t
+	}
+	if len(r.topics) > 0 {
+		if matched := r.filters.Match(messaging.EncodeMessageMQTTTopic(msg)); len(matched) > 0 {
+			return r.topics[slices.Min(matched)]
+		}
+	}
+
+	return r.def
+}
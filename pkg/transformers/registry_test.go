@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+type namedTransformer string
+
+func (nt namedTransformer) Transform(_ *messaging.Message) (any, error) {
+	return string(nt), nil
+}
+
+func TestRegisterTopic(t *testing.T) {
+	cases := []struct {
+		desc   string
+		filter string
+		err    error
+	}{
+		{
+			desc:   "register topic with exact filter",
+			filter: "m/domain/c/channel/sensors",
+			err:    nil,
+		},
+		{
+			desc:   "register topic with wildcard filter",
+			filter: "m/+/c/+/sensors/#",
+			err:    nil,
+		},
+		{
+			desc:   "register topic with multi-level wildcard not at the end",
+			filter: "m/#/c/channel",
+			err:    transformers.ErrInvalidTopicFilter,
+		},
+		{
+			desc:   "register topic with wildcard inside level",
+			filter: "m/domain/c/chan+",
+			err:    transformers.ErrInvalidTopicFilter,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := transformers.NewRegistry(nil)
+			err := r.RegisterTopic(tc.filter, namedTransformer("topic"))
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s, got %s", tc.err, err)
+		})
+	}
+}
+
+func TestRegistryTransform(t *testing.T) {
+	r := transformers.NewRegistry(namedTransformer("default"))
+	r.RegisterChannel("channel-1", namedTransformer("channel"))
+	err := r.RegisterTopic("m/+/c/+/vendor/#", namedTransformer("vendor"))
+	assert.Nil(t, err, "unexpected error registering topic: %s", err)
+	err = r.RegisterTopic("m/domain/c/channel-2/+", namedTransformer("single"))
+	assert.Nil(t, err, "unexpected error registering topic: %s", err)
+
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		want any
+	}{
+		{
+			desc: "transform message of registered channel",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-1", Subtopic: "vendor/x"},
+			want: "channel",
+		},
+		{
+			desc: "transform message matching multi-level wildcard",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-3", Subtopic: "vendor/a/b"},
+			want: "vendor",
+		},
+		{
+			desc: "transform message matching multi-level wildcard without subtopic levels",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-3", Subtopic: "vendor"},
+			want: "vendor",
+		},
+		{
+			desc: "transform message matching single-level wildcard",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-2", Subtopic: "temp"},
+			want: "single",
+		},
+		{
+			desc: "transform message with extra level for single-level wildcard",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-2", Subtopic: "temp/x"},
+			want: "default",
+		},
+		{
+			desc: "transform message without registered transformer",
+			msg:  &messaging.Message{Domain: "domain", Channel: "channel-3"},
+			want: "default",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := r.Transform(tc.msg)
+			assert.Nil(t, err, "unexpected error transforming message: %s", err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegistryTransformWithoutDefault(t *testing.T) {
+	r := transformers.NewRegistry(nil)
+	msg := &messaging.Message{Channel: "channel"}
+	got, err := r.Transform(msg)
+	assert.Nil(t, err, "unexpected error transforming message: %s", err)
+	assert.Equal(t, msg, got)
+}
@@ -6,6 +6,7 @@ package senml
 // Message represents a resolved (normalized) SenML record.
 type Message struct {
 	Channel     string   `json:"channel,omitempty" db:"channel" bson:"channel"`
+	Domain      string   `json:"domain,omitempty" db:"domain" bson:"domain,omitempty"`
 	Subtopic    string   `json:"subtopic,omitempty" db:"subtopic" bson:"subtopic,omitempty"`
 	Publisher   string   `json:"publisher,omitempty" db:"publisher" bson:"publisher"`
 	Protocol    string   `json:"protocol,omitempty" db:"protocol" bson:"protocol"`
@@ -75,6 +75,7 @@ func (t transformer) Transform(msg *messaging.Message) (any, error) {
 
 		msgs[i] = Message{
 			Channel:     msg.GetChannel(),
+			Domain:      msg.GetDomain(),
 			Subtopic:    msg.GetSubtopic(),
 			Publisher:   msg.GetPublisher(),
 			Protocol:    msg.GetProtocol(),
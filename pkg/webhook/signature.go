@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook contains the request signing shared by the services
+// which send webhook requests.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 signature of the webhook request.
+	SignatureHeader = "X-Signature"
+	// SignatureTimestampHeader carries the Unix timestamp used to compute the signature.
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns hex-encoded HMAC-SHA256 of the timestamp and the body joined with a dot.
+// Webhook receivers can use it to verify the value of the SignatureHeader.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the signature headers of the request carrying the given body.
+func SignRequest(req *http.Request, secret string, body []byte) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(SignatureTimestampHeader, ts)
+	req.Header.Set(SignatureHeader, signaturePrefix+Sign(secret, ts, body))
+}
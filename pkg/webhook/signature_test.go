@@ -0,0 +1,32 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// Expected signature is computed with:
+	// printf '1700000000.{"id":"alarm"}' | openssl dgst -sha256 -hmac secret
+	sig := webhook.Sign("secret", "1700000000", []byte(`{"id":"alarm"}`))
+	assert.Equal(t, "e030000106d5a96e4e2b3bc3ab89587d29a4fb36979094033776e28e6eaffd40", sig)
+	assert.NotEqual(t, sig, webhook.Sign("other", "1700000000", []byte(`{"id":"alarm"}`)))
+	assert.NotEqual(t, sig, webhook.Sign("secret", "1700000001", []byte(`{"id":"alarm"}`)))
+}
+
+func TestSignRequest(t *testing.T) {
+	body := []byte(`{"id":"alarm"}`)
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	assert.Nil(t, err, "unexpected error creating request: %s", err)
+
+	webhook.SignRequest(req, "secret", body)
+	ts := req.Header.Get(webhook.SignatureTimestampHeader)
+	assert.NotEmpty(t, ts)
+	assert.Equal(t, "sha256="+webhook.Sign("secret", ts, body), req.Header.Get(webhook.SignatureHeader))
+}
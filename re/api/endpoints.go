@@ -203,3 +203,115 @@ func disableRuleEndpoint(s re.Service) endpoint.Endpoint {
 		return updateRuleStatusRes{Rule: rule}, err
 	}
 }
+
+func listDeadLettersEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(listDeadLettersReq)
+		if err := req.validate(); err != nil {
+			return deadLettersPageRes{}, err
+		}
+		page, err := s.ListDeadLetters(ctx, session, req.DeadLetterPageMeta)
+		if err != nil {
+			return deadLettersPageRes{}, err
+		}
+		return deadLettersPageRes{DeadLetterPage: page}, nil
+	}
+}
+
+func listExecutionsEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(listExecutionsReq)
+		if err := req.validate(); err != nil {
+			return executionsPageRes{}, err
+		}
+		page, err := s.ListExecutions(ctx, session, req.ExecutionPageMeta)
+		if err != nil {
+			return executionsPageRes{}, err
+		}
+		return executionsPageRes{ExecutionPage: page}, nil
+	}
+}
+
+func viewDeadLetterEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return viewDeadLetterRes{}, err
+		}
+		dl, err := s.ViewDeadLetter(ctx, session, req.ruleID, req.id)
+		if err != nil {
+			return viewDeadLetterRes{}, err
+		}
+		return viewDeadLetterRes{DeadLetter: dl}, nil
+	}
+}
+
+func replayDeadLetterEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return replayDeadLetterRes{}, err
+		}
+		if err := s.ReplayDeadLetter(ctx, session, req.ruleID, req.id); err != nil {
+			return replayDeadLetterRes{}, err
+		}
+		return replayDeadLetterRes{}, nil
+	}
+}
+
+func purgeDeadLettersEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(purgeDeadLettersReq)
+		if err := req.validate(); err != nil {
+			return deleteRuleRes{}, err
+		}
+		if err := s.PurgeDeadLetters(ctx, session, req.ruleID); err != nil {
+			return deleteRuleRes{false}, err
+		}
+		return deleteRuleRes{true}, nil
+	}
+}
+
+func dryRunRuleEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(dryRunRuleReq)
+		if err := req.validate(); err != nil {
+			return dryRunRuleRes{}, err
+		}
+		results, err := s.DryRunRule(ctx, session, req.Rule, req.messages())
+		if err != nil {
+			return dryRunRuleRes{}, err
+		}
+		return dryRunRuleRes{Results: results}, nil
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -122,6 +123,12 @@ func TestAddRuleEndpoint(t *testing.T) {
 
 	ruleInPast := rule
 	ruleInPast.Schedule = scheduleInPast
+	windowedRule := rule
+	windowedRule.Window = &re.Window{Type: re.SlidingWindow, Duration: 5 * time.Minute}
+	invalidWindowRule := rule
+	invalidWindowRule.Window = &re.Window{Type: re.CountWindow}
+	invalidRetryRule := rule
+	invalidRetryRule.RetryPolicy = &re.RetryPolicy{MaxAttempts: 100}
 
 	cases := []struct {
 		desc        string
@@ -211,6 +218,36 @@ func TestAddRuleEndpoint(t *testing.T) {
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
+		{
+			desc:        "add rule with window successfully",
+			rule:        windowedRule,
+			token:       validToken,
+			contentType: contentType,
+			domainID:    domainID,
+			authnRes:    smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID},
+			status:      http.StatusCreated,
+			svcRes:      windowedRule,
+		},
+		{
+			desc:        "add rule with invalid window",
+			token:       validToken,
+			domainID:    domainID,
+			authnRes:    smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID},
+			rule:        invalidWindowRule,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "add rule with invalid retry policy",
+			token:       validToken,
+			domainID:    domainID,
+			authnRes:    smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID},
+			rule:        invalidRetryRule,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
 		{
 			desc:        "add rule with service error",
 			token:       validToken,
@@ -1276,6 +1313,580 @@ func TestDeleteRuleEndpoint(t *testing.T) {
 	}
 }
 
+func TestListExecutionsEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	e := re.Execution{
+		ID:        testsutil.GenerateUUID(t),
+		RuleID:    validID,
+		DomainID:  domainID,
+		Level:     slog.LevelError,
+		Message:   "failed to run rule outputs",
+		Errors:    []string{"failed to publish"},
+		StartedAt: time.Now().UTC(),
+		Duration:  time.Millisecond,
+	}
+	errLevel := slog.LevelError
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		query    string
+		pm       re.ExecutionPageMeta
+		session  smqauthn.Session
+		authnErr error
+		svcRes   re.ExecutionPage
+		svcErr   error
+		status   int
+		total    uint64
+		err      error
+	}{
+		{
+			desc:     "list executions successfully",
+			token:    validToken,
+			domainID: domainID,
+			pm:       re.ExecutionPageMeta{RuleID: validID, Limit: 10},
+			svcRes:   re.ExecutionPage{Total: 1, Limit: 10, Executions: []re.Execution{e}},
+			status:   http.StatusOK,
+			total:    1,
+		},
+		{
+			desc:     "list executions with offset, limit and level",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?offset=1&limit=5&level=error",
+			pm:       re.ExecutionPageMeta{RuleID: validID, Offset: 1, Limit: 5, Level: &errLevel},
+			svcRes:   re.ExecutionPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+			total:    1,
+		},
+		{
+			desc:     "list executions with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list executions with invalid level",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?level=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list executions with invalid limit",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?limit=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list executions with limit greater than max",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?limit=1001",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrLimitSize,
+		},
+		{
+			desc:     "list executions with service error",
+			token:    validToken,
+			domainID: domainID,
+			pm:       re.ExecutionPageMeta{RuleID: validID, Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s/executions%s", ts.URL, tc.domainID, validID, tc.query),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListExecutions", mock.Anything, tc.session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, resBody.Total))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListDeadLettersEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	dl := re.DeadLetter{
+		ID:       testsutil.GenerateUUID(t),
+		RuleID:   validID,
+		DomainID: domainID,
+		Error:    "failed to publish",
+		Attempts: 3,
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		query    string
+		pm       re.DeadLetterPageMeta
+		session  smqauthn.Session
+		authnErr error
+		svcRes   re.DeadLetterPage
+		svcErr   error
+		status   int
+		total    uint64
+		err      error
+	}{
+		{
+			desc:     "list dead letters successfully",
+			token:    validToken,
+			domainID: domainID,
+			pm:       re.DeadLetterPageMeta{RuleID: validID, Limit: 10},
+			svcRes:   re.DeadLetterPage{Total: 1, Limit: 10, DeadLetters: []re.DeadLetter{dl}},
+			status:   http.StatusOK,
+			total:    1,
+		},
+		{
+			desc:     "list dead letters with offset and limit",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?offset=1&limit=5",
+			pm:       re.DeadLetterPageMeta{RuleID: validID, Offset: 1, Limit: 5},
+			svcRes:   re.DeadLetterPage{Total: 1, Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+			total:    1,
+		},
+		{
+			desc:     "list dead letters with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list dead letters with invalid limit",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?limit=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list dead letters with limit greater than max",
+			token:    validToken,
+			domainID: domainID,
+			query:    "?limit=1001",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrLimitSize,
+		},
+		{
+			desc:     "list dead letters with service error",
+			token:    validToken,
+			domainID: domainID,
+			pm:       re.DeadLetterPageMeta{RuleID: validID, Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters%s", ts.URL, tc.domainID, validID, tc.query),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListDeadLetters", mock.Anything, tc.session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, resBody.Total))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewDeadLetterEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	dl := re.DeadLetter{
+		ID:       testsutil.GenerateUUID(t),
+		RuleID:   validID,
+		DomainID: domainID,
+		Attempts: 3,
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcRes   re.DeadLetter
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:     "view dead letter successfully",
+			token:    validToken,
+			domainID: domainID,
+			id:       dl.ID,
+			svcRes:   dl,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "view dead letter with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			id:       dl.ID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "view dead letter with service error",
+			token:    validToken,
+			domainID: domainID,
+			id:       dl.ID,
+			svcErr:   svcerr.ErrNotFound,
+			status:   http.StatusNotFound,
+			err:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters/%s", ts.URL, tc.domainID, validID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewDeadLetter", mock.Anything, tc.session, validID, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, tc.svcRes.ID, resBody.ID)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetterEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	dlID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		session  smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "replay dead letter successfully",
+			token:    validToken,
+			domainID: domainID,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "replay dead letter with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "replay dead letter with failing output",
+			token:    validToken,
+			domainID: domainID,
+			svcErr:   errors.Wrap(re.ErrReplayDeadLetter, errors.New("output failed")),
+			status:   http.StatusUnprocessableEntity,
+		},
+		{
+			desc:     "replay dead letter with service error",
+			token:    validToken,
+			domainID: domainID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters/%s/replay", ts.URL, tc.domainID, validID, dlID),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ReplayDeadLetter", mock.Anything, tc.session, validID, dlID).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestPurgeDeadLettersEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		domainID string
+		session  smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+	}{
+		{
+			desc:     "purge dead letters successfully",
+			token:    validToken,
+			domainID: domainID,
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "purge dead letters with invalid token",
+			token:    invalidToken,
+			domainID: domainID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:   "purge dead letters with empty domainID",
+			token:  validToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "purge dead letters with service error",
+			token:    validToken,
+			domainID: domainID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters", ts.URL, tc.domainID, validID),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("PurgeDeadLetters", mock.Anything, tc.session, validID).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDryRunRuleEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	msg := map[string]any{
+		"channel": validID,
+		"payload": map[string]any{"temperature": 31.5},
+	}
+	inline := map[string]any{
+		"rule": map[string]any{
+			"name":  rule.Name,
+			"logic": map[string]any{"type": 0, "value": "return message.payload.temperature > 30"},
+		},
+		"messages": []any{msg},
+	}
+	results := []re.DryRunResult{{Result: true}}
+
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		ruleID      string
+		data        string
+		contentType string
+		session     smqauthn.Session
+		authnErr    error
+		svcRes      []re.DryRunResult
+		svcErr      error
+		status      int
+	}{
+		{
+			desc:        "dry run inline rule successfully",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(inline),
+			contentType: contentType,
+			svcRes:      results,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "dry run stored rule successfully",
+			token:       validToken,
+			domainID:    domainID,
+			ruleID:      validID,
+			data:        toJSON(map[string]any{"messages": []any{msg}}),
+			contentType: contentType,
+			svcRes:      results,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "dry run rule with invalid token",
+			token:       invalidToken,
+			domainID:    domainID,
+			data:        toJSON(inline),
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "dry run rule without messages",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(map[string]any{"rule": inline["rule"]}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "dry run rule with invalid content type",
+			token:       validToken,
+			domainID:    domainID,
+			data:        toJSON(inline),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "dry run rule with malformed body",
+			token:       validToken,
+			domainID:    domainID,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "dry run rule with service error",
+			token:       validToken,
+			domainID:    domainID,
+			ruleID:      validID,
+			data:        toJSON(map[string]any{"messages": []any{msg}}),
+			contentType: contentType,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			url := fmt.Sprintf("%s/%s/rules/dry-run", ts.URL, tc.domainID)
+			if tc.ruleID != "" {
+				url = fmt.Sprintf("%s/%s/rules/%s/dry-run", ts.URL, tc.domainID, tc.ruleID)
+			}
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         url,
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("DryRunRule", mock.Anything, tc.session, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Results []re.DryRunResult `json:"results"`
+				}
+				err := json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes, body.Results)
+				svc.AssertCalled(t, "DryRunRule", mock.Anything, tc.session, mock.MatchedBy(func(r re.Rule) bool { return r.ID == tc.ruleID }), mock.Anything)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type respBody struct {
 	Err     string    `json:"error"`
 	Message string    `json:"message"`
@@ -4,9 +4,12 @@
 package api
 
 import (
+	"encoding/json"
+
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/schedule"
 	"github.com/absmach/supermq/re"
 )
@@ -28,6 +31,16 @@ func (req addRuleReq) validate() error {
 	if err := req.Rule.Schedule.Validate(); err != nil {
 		return errors.Wrap(err, apiutil.ErrValidation)
 	}
+	if req.Rule.Window != nil {
+		if err := req.Rule.Window.Validate(); err != nil {
+			return errors.Wrap(err, apiutil.ErrValidation)
+		}
+	}
+	if req.Rule.RetryPolicy != nil {
+		if err := req.Rule.RetryPolicy.Validate(); err != nil {
+			return errors.Wrap(err, apiutil.ErrValidation)
+		}
+	}
 
 	return nil
 }
@@ -78,6 +91,16 @@ func (req updateRuleReq) validate() error {
 	if len(req.Rule.Name) > api.MaxNameSize {
 		return apiutil.ErrNameSize
 	}
+	if req.Rule.Window != nil {
+		if err := req.Rule.Window.Validate(); err != nil {
+			return errors.Wrap(err, apiutil.ErrValidation)
+		}
+	}
+	if req.Rule.RetryPolicy != nil {
+		if err := req.Rule.RetryPolicy.Validate(); err != nil {
+			return errors.Wrap(err, apiutil.ErrValidation)
+		}
+	}
 
 	return nil
 }
@@ -135,3 +158,107 @@ func (req deleteRuleReq) validate() error {
 
 	return nil
 }
+
+type listDeadLettersReq struct {
+	re.DeadLetterPageMeta
+}
+
+func (req listDeadLettersReq) validate() error {
+	if req.RuleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type listExecutionsReq struct {
+	re.ExecutionPageMeta
+}
+
+func (req listExecutionsReq) validate() error {
+	if req.RuleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type deadLetterReq struct {
+	ruleID string
+	id     string
+}
+
+func (req deadLetterReq) validate() error {
+	if req.ruleID == "" || req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type purgeDeadLettersReq struct {
+	ruleID string
+}
+
+func (req purgeDeadLettersReq) validate() error {
+	if req.ruleID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+// dryRunMessage is a sample message with a plain JSON payload.
+type dryRunMessage struct {
+	Channel   string          `json:"channel,omitempty"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	ClientID  string          `json:"client_id,omitempty"`
+	Protocol  string          `json:"protocol,omitempty"`
+	Created   int64           `json:"created,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type dryRunRuleReq struct {
+	Rule     re.Rule         `json:"rule"`
+	Messages []dryRunMessage `json:"messages"`
+}
+
+func (req dryRunRuleReq) validate() error {
+	if len(req.Messages) == 0 || len(req.Messages) > re.MaxDryRunMessages {
+		return errors.Wrap(re.ErrDryRunMessages, apiutil.ErrValidation)
+	}
+	if req.Rule.ID != "" {
+		return nil
+	}
+	if req.Rule.Window != nil {
+		if err := req.Rule.Window.Validate(); err != nil {
+			return errors.Wrap(err, apiutil.ErrValidation)
+		}
+	}
+
+	return nil
+}
+
+func (req dryRunRuleReq) messages() []*messaging.Message {
+	msgs := make([]*messaging.Message, len(req.Messages))
+	for i, m := range req.Messages {
+		msgs[i] = &messaging.Message{
+			Channel:   m.Channel,
+			Subtopic:  m.Subtopic,
+			Publisher: m.Publisher,
+			ClientId:  m.ClientID,
+			Protocol:  m.Protocol,
+			Created:   m.Created,
+			Payload:   m.Payload,
+		}
+	}
+
+	return msgs
+}
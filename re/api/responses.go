@@ -18,6 +18,11 @@ var (
 	_ supermq.Response = (*rulesPageRes)(nil)
 	_ supermq.Response = (*updateRuleRes)(nil)
 	_ supermq.Response = (*deleteRuleRes)(nil)
+	_ supermq.Response = (*deadLettersPageRes)(nil)
+	_ supermq.Response = (*executionsPageRes)(nil)
+	_ supermq.Response = (*viewDeadLetterRes)(nil)
+	_ supermq.Response = (*replayDeadLetterRes)(nil)
+	_ supermq.Response = (*dryRunRuleRes)(nil)
 )
 
 type pageRes struct {
@@ -136,3 +141,81 @@ func (res deleteRuleRes) Headers() map[string]string {
 func (res deleteRuleRes) Empty() bool {
 	return true
 }
+
+type deadLettersPageRes struct {
+	re.DeadLetterPage `json:",inline"`
+}
+
+func (res deadLettersPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLettersPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLettersPageRes) Empty() bool {
+	return false
+}
+
+type executionsPageRes struct {
+	re.ExecutionPage `json:",inline"`
+}
+
+func (res executionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res executionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res executionsPageRes) Empty() bool {
+	return false
+}
+
+type viewDeadLetterRes struct {
+	re.DeadLetter `json:",inline"`
+}
+
+func (res viewDeadLetterRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewDeadLetterRes) Empty() bool {
+	return false
+}
+
+type replayDeadLetterRes struct{}
+
+func (res replayDeadLetterRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res replayDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayDeadLetterRes) Empty() bool {
+	return true
+}
+
+type dryRunRuleRes struct {
+	Results []re.DryRunResult `json:"results"`
+}
+
+func (res dryRunRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res dryRunRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res dryRunRuleRes) Empty() bool {
+	return false
+}
@@ -25,7 +25,9 @@ import (
 
 const (
 	ruleIdKey       = "ruleID"
+	deadLetterIdKey = "deadLetterID"
 	inputChannelKey = "input_channel"
+	levelKey        = "level"
 )
 
 // MakeHandler creates an HTTP handler for the service endpoints.
@@ -53,6 +55,13 @@ func MakeHandler(svc re.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mux, l
 					opts...,
 				), "list_rules").ServeHTTP)
 
+				r.Post("/dry-run", otelhttp.NewHandler(kithttp.NewServer(
+					dryRunRuleEndpoint(svc),
+					decodeDryRunRuleRequest,
+					api.EncodeResponse,
+					opts...,
+				), "dry_run_rule").ServeHTTP)
+
 				r = roleManagerHttp.EntityAvailableActionsRouter(svc, d, r, opts)
 
 				r.Route("/{ruleID}", func(r chi.Router) {
@@ -105,6 +114,50 @@ func MakeHandler(svc re.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mux, l
 						opts...,
 					), "disable_rule").ServeHTTP)
 
+					r.Post("/dry-run", otelhttp.NewHandler(kithttp.NewServer(
+						dryRunRuleEndpoint(svc),
+						decodeDryRunRuleRequest,
+						api.EncodeResponse,
+						opts...,
+					), "dry_run_rule").ServeHTTP)
+
+					r.Get("/executions", otelhttp.NewHandler(kithttp.NewServer(
+						listExecutionsEndpoint(svc),
+						decodeListExecutionsRequest,
+						api.EncodeResponse,
+						opts...,
+					), "list_executions").ServeHTTP)
+
+					r.Route("/dead-letters", func(r chi.Router) {
+						r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+							listDeadLettersEndpoint(svc),
+							decodeListDeadLettersRequest,
+							api.EncodeResponse,
+							opts...,
+						), "list_dead_letters").ServeHTTP)
+
+						r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+							purgeDeadLettersEndpoint(svc),
+							decodePurgeDeadLettersRequest,
+							api.EncodeResponse,
+							opts...,
+						), "purge_dead_letters").ServeHTTP)
+
+						r.Get("/{deadLetterID}", otelhttp.NewHandler(kithttp.NewServer(
+							viewDeadLetterEndpoint(svc),
+							decodeDeadLetterRequest,
+							api.EncodeResponse,
+							opts...,
+						), "view_dead_letter").ServeHTTP)
+
+						r.Post("/{deadLetterID}/replay", otelhttp.NewHandler(kithttp.NewServer(
+							replayDeadLetterEndpoint(svc),
+							decodeDeadLetterRequest,
+							api.EncodeResponse,
+							opts...,
+						), "replay_dead_letter").ServeHTTP)
+					})
+
 					roleManagerHttp.EntityRoleMangerRouter(svc, d, r, opts)
 				})
 			})
@@ -245,3 +298,81 @@ func decodeDeleteRuleRequest(_ context.Context, r *http.Request) (any, error) {
 
 	return deleteRuleReq{id: id}, nil
 }
+
+func decodeListDeadLettersRequest(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listDeadLettersReq{
+		DeadLetterPageMeta: re.DeadLetterPageMeta{
+			RuleID: chi.URLParam(r, ruleIdKey),
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func decodeListExecutionsRequest(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	l, err := apiutil.ReadStringQuery(r, levelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	var level *slog.Level
+	if l != "" {
+		level = new(slog.Level)
+		if err := level.UnmarshalText([]byte(l)); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidQueryParams)
+		}
+	}
+
+	return listExecutionsReq{
+		ExecutionPageMeta: re.ExecutionPageMeta{
+			RuleID: chi.URLParam(r, ruleIdKey),
+			Offset: offset,
+			Limit:  limit,
+			Level:  level,
+		},
+	}, nil
+}
+
+func decodeDeadLetterRequest(_ context.Context, r *http.Request) (any, error) {
+	return deadLetterReq{
+		ruleID: chi.URLParam(r, ruleIdKey),
+		id:     chi.URLParam(r, deadLetterIdKey),
+	}, nil
+}
+
+func decodePurgeDeadLettersRequest(_ context.Context, r *http.Request) (any, error) {
+	return purgeDeadLettersReq{ruleID: chi.URLParam(r, ruleIdKey)}, nil
+}
+
+func decodeDryRunRuleRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+	var req dryRunRuleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+	// The stored rule is used only if the rule ID is in the path.
+	id := chi.URLParam(r, ruleIdKey)
+	if id != "" {
+		req.Rule = re.Rule{}
+	}
+	req.Rule.ID = id
+	return req, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"golang.org/x/sync/singleflight"
+)
+
+// RuleCache keeps the rules triggered by messages in memory, indexed by
+// domain, channel and input topic, so the rules are not listed from the
+// database for every message.
+type RuleCache interface {
+	// Match returns the enabled, non-scheduled rules of the domain and
+	// channel with an input topic that matches the message subtopic.
+	Match(ctx context.Context, domainID, channel, subtopic string) ([]Rule, error)
+
+	// Invalidate removes the cached rules of the domain and channel, as well
+	// as the cached rules of the channel the rule was previously cached for.
+	Invalidate(domainID, channel, ruleID string)
+}
+
+var _ RuleCache = (*ruleCache)(nil)
+
+type cacheKey struct {
+	domainID string
+	channel  string
+}
+
+type cacheEntry struct {
+	rules     *messaging.TopicTrie[Rule]
+	ruleIDs   []string
+	expiresAt time.Time
+}
+
+type ruleCache struct {
+	repo    Repository
+	ttl     time.Duration
+	loads   singleflight.Group
+	mu      sync.RWMutex
+	version uint64
+	entries map[cacheKey]cacheEntry
+	// index maps the rule ID to the key the rule is cached under.
+	index map[string]cacheKey
+}
+
+// NewRuleCache returns a rule cache that loads the rules from the repository.
+// Cached rules expire after ttl, which limits how long the cache stays stale if a
+// rule change is missed. If ttl is not positive, rules are listed on every match.
+func NewRuleCache(repo Repository, ttl time.Duration) RuleCache {
+	return &ruleCache{
+		repo:    repo,
+		ttl:     ttl,
+		entries: make(map[cacheKey]cacheEntry),
+		index:   make(map[string]cacheKey),
+	}
+}
+
+func (c *ruleCache) Match(ctx context.Context, domainID, channel, subtopic string) ([]Rule, error) {
+	key := cacheKey{domainID: domainID, channel: channel}
+	if c.ttl <= 0 {
+		rules, err := c.list(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		var ret []Rule
+		for _, r := range rules {
+			if messaging.MatchTopic(r.InputTopic, subtopic) {
+				ret = append(ret, r)
+			}
+		}
+		return ret, nil
+	}
+
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.rules.Match(subtopic), nil
+	}
+
+	// Concurrent messages of the same channel wait for a single load.
+	t, err, _ := c.loads.Do(domainID+"/"+channel, func() (any, error) {
+		return c.load(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.(*messaging.TopicTrie[Rule]).Match(subtopic), nil
+}
+
+func (c *ruleCache) Invalidate(domainID, channel, ruleID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	if key, ok := c.index[ruleID]; ok {
+		c.remove(key)
+	}
+	if channel != "" {
+		c.remove(cacheKey{domainID: domainID, channel: channel})
+	}
+}
+
+func (c *ruleCache) load(ctx context.Context, key cacheKey) (*messaging.TopicTrie[Rule], error) {
+	c.mu.RLock()
+	version := c.version
+	c.mu.RUnlock()
+
+	rules, err := c.list(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	t := messaging.NewTopicTrie[Rule]()
+	for _, r := range rules {
+		t.Insert(r.InputTopic, r)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Rules changed while they were loaded, so they may be stale.
+	// They are still used for the current message, but not cached.
+	if c.version != version {
+		return t, nil
+	}
+	c.remove(key)
+	e := cacheEntry{
+		rules:     t,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	for _, r := range rules {
+		e.ruleIDs = append(e.ruleIDs, r.ID)
+		c.index[r.ID] = key
+	}
+	c.entries[key] = e
+
+	return t, nil
+}
+
+func (c *ruleCache) list(ctx context.Context, key cacheKey) ([]Rule, error) {
+	pm := PageMeta{
+		Domain:       key.domainID,
+		InputChannel: key.channel,
+		Status:       EnabledStatus,
+		Scheduled:    &scheduledFalse,
+	}
+	page, err := c.repo.ListAllRules(ctx, pm)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Rules, nil
+}
+
+func (c *ruleCache) remove(key cacheKey) {
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	for _, id := range e.ruleIDs {
+		if c.index[id] == key {
+			delete(c.index, id)
+		}
+	}
+	delete(c.entries, key)
+}
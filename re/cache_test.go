@@ -0,0 +1,226 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/re"
+	"github.com/absmach/supermq/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func cachePageMeta(domainID, channel string) re.PageMeta {
+	scheduled := false
+	return re.PageMeta{
+		Domain:       domainID,
+		InputChannel: channel,
+		Status:       re.EnabledStatus,
+		Scheduled:    &scheduled,
+	}
+}
+
+func ruleIDs(rules []re.Rule) []string {
+	ids := []string{}
+	for _, r := range rules {
+		ids = append(ids, r.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRuleCacheMatch(t *testing.T) {
+	topics := []string{"", "a", "a/b", "a/+", "a/#", "+", "+/b", "#", "a/b/c", "a/+/c", "+/+", "b/#"}
+	var rules []re.Rule
+	for _, topic := range topics {
+		rules = append(rules, re.Rule{ID: "rule " + topic, InputTopic: topic})
+	}
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		rules    []string
+	}{
+		{
+			desc:     "match empty subtopic",
+			subtopic: "",
+			rules:    []string{"rule ", "rule +", "rule #"},
+		},
+		{
+			desc:     "match single level subtopic",
+			subtopic: "a",
+			rules:    []string{"rule a", "rule a/#", "rule +", "rule #"},
+		},
+		{
+			desc:     "match two level subtopic",
+			subtopic: "a/b",
+			rules:    []string{"rule a/b", "rule a/+", "rule a/#", "rule +/b", "rule #", "rule +/+"},
+		},
+		{
+			desc:     "match three level subtopic",
+			subtopic: "a/b/c",
+			rules:    []string{"rule a/#", "rule #", "rule a/b/c", "rule a/+/c"},
+		},
+		{
+			desc:     "match subtopic of other branch",
+			subtopic: "b/b",
+			rules:    []string{"rule +/b", "rule #", "rule +/+", "rule b/#"},
+		},
+		{
+			desc:     "match subtopic with plus level",
+			subtopic: "a/+",
+			rules:    []string{"rule a/+", "rule a/#", "rule #", "rule +/+"},
+		},
+		{
+			desc:     "match subtopic of unknown branch",
+			subtopic: "c/d/e",
+			rules:    []string{"rule #"},
+		},
+	}
+
+	for _, ttl := range []time.Duration{0, time.Minute} {
+		repo := new(mocks.Repository)
+		repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, inputChannel)).Return(re.Page{Rules: rules}, nil)
+		cache := re.NewRuleCache(repo, ttl)
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s with ttl %s", tc.desc, ttl), func(t *testing.T) {
+				matched, err := cache.Match(context.Background(), domainID, inputChannel, tc.subtopic)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				sort.Strings(tc.rules)
+				assert.Equal(t, tc.rules, ruleIDs(matched), fmt.Sprintf("%s: unexpected rules", tc.desc))
+			})
+		}
+	}
+}
+
+func TestRuleCacheInvalidate(t *testing.T) {
+	repo := new(mocks.Repository)
+	cache := re.NewRuleCache(repo, time.Minute)
+	rule := re.Rule{ID: ruleID, DomainID: domainID, InputChannel: inputChannel, InputTopic: "#"}
+	otherChannel := "other.channel"
+
+	repoCall := repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, inputChannel)).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	repoCall1 := repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, otherChannel)).Return(re.Page{}, nil)
+
+	// Rules are listed once and then matched from the cache.
+	for range 3 {
+		rules, err := cache.Match(context.Background(), domainID, inputChannel, "a")
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Equal(t, []re.Rule{rule}, rules)
+		rules, err = cache.Match(context.Background(), domainID, otherChannel, "a")
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Empty(t, rules)
+	}
+	repo.AssertNumberOfCalls(t, "ListAllRules", 2)
+
+	// The rule is moved to the other channel.
+	cache.Invalidate(domainID, otherChannel, ruleID)
+	repoCall.Unset()
+	repoCall1.Unset()
+	moved := rule
+	moved.InputChannel = otherChannel
+	repoCall = repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, inputChannel)).Return(re.Page{}, nil)
+	repoCall1 = repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, otherChannel)).Return(re.Page{Rules: []re.Rule{moved}}, nil)
+
+	rules, err := cache.Match(context.Background(), domainID, inputChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, rules)
+	rules, err = cache.Match(context.Background(), domainID, otherChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []re.Rule{moved}, rules)
+	repo.AssertNumberOfCalls(t, "ListAllRules", 4)
+
+	// The rule is removed, so the event carries the rule ID only.
+	cache.Invalidate(domainID, "", ruleID)
+	repoCall1.Unset()
+	repoCall1 = repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, otherChannel)).Return(re.Page{}, repoerr.ErrViewEntity)
+
+	_, err = cache.Match(context.Background(), domainID, otherChannel, "a")
+	assert.Equal(t, repoerr.ErrViewEntity, err)
+	// Failed list is not cached.
+	_, err = cache.Match(context.Background(), domainID, otherChannel, "a")
+	assert.Equal(t, repoerr.ErrViewEntity, err)
+	// Channel without the rule stays cached.
+	_, err = cache.Match(context.Background(), domainID, inputChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	repo.AssertNumberOfCalls(t, "ListAllRules", 6)
+
+	repoCall.Unset()
+	repoCall1.Unset()
+}
+
+func TestRuleCacheExpiration(t *testing.T) {
+	repo := new(mocks.Repository)
+	cache := re.NewRuleCache(repo, 50*time.Millisecond)
+	repoCall := repo.On("ListAllRules", mock.Anything, cachePageMeta(domainID, inputChannel)).Return(re.Page{}, nil)
+	defer repoCall.Unset()
+
+	_, err := cache.Match(context.Background(), domainID, inputChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = cache.Match(context.Background(), domainID, inputChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	repo.AssertNumberOfCalls(t, "ListAllRules", 1)
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = cache.Match(context.Background(), domainID, inputChannel, "a")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	repo.AssertNumberOfCalls(t, "ListAllRules", 2)
+}
+
+// listRepository lists the same rules for every channel, without the overhead of mocks.
+type listRepository struct {
+	re.Repository
+	rules []re.Rule
+}
+
+func (repo listRepository) ListAllRules(context.Context, re.PageMeta) (re.Page, error) {
+	return re.Page{Rules: repo.rules}, nil
+}
+
+// BenchmarkRuleCacheMatch compares listing and matching the rules of a channel
+// for every message (ttl 0) with matching the rules from the cache.
+// The database round trip is not included, so the gain in production is larger.
+func BenchmarkRuleCacheMatch(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		rules := make([]re.Rule, n)
+		for i := range rules {
+			var topic string
+			switch i % 4 {
+			case 0:
+				topic = fmt.Sprintf("building/%d/temperature", i)
+			case 1:
+				topic = fmt.Sprintf("building/+/sensor/%d", i)
+			case 2:
+				topic = fmt.Sprintf("floor/%d/#", i)
+			default:
+				topic = fmt.Sprintf("room/%d", i)
+			}
+			rules[i] = re.Rule{ID: fmt.Sprintf("rule-%d", i), InputTopic: topic}
+		}
+		repo := listRepository{rules: rules}
+		subtopic := "building/1/sensor/5"
+
+		for _, ttl := range []time.Duration{0, time.Hour} {
+			name := fmt.Sprintf("%d rules listed", n)
+			if ttl > 0 {
+				name = fmt.Sprintf("%d rules cached", n)
+			}
+			b.Run(name, func(b *testing.B) {
+				cache := re.NewRuleCache(repo, ttl)
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := cache.Match(context.Background(), domainID, inputChannel, subtopic); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/re/outputs"
+)
+
+const (
+	defRetryBackoff  = time.Second
+	maxRetryBackoff  = time.Minute
+	maxRetryAttempts = 10
+)
+
+var (
+	ErrInvalidRetryPolicy = errors.NewRequestError("retry policy max attempts must be between 1 and 10")
+	ErrReplayDeadLetter   = errors.NewServiceError("failed to replay dead letter")
+)
+
+// RetryPolicy configures how many times a failed rule output is invoked
+// before the invocation is stored as a dead letter. Backoff is the delay
+// before the first retry and it doubles on every subsequent retry.
+type RetryPolicy struct {
+	MaxAttempts uint          `json:"max_attempts"`
+	Backoff     time.Duration `json:"backoff,omitempty"`
+}
+
+func (rp RetryPolicy) Validate() error {
+	if rp.MaxAttempts == 0 || rp.MaxAttempts > maxRetryAttempts || rp.Backoff < 0 {
+		return ErrInvalidRetryPolicy
+	}
+	return nil
+}
+
+func (rp RetryPolicy) MarshalJSON() ([]byte, error) {
+	m := map[string]any{
+		"max_attempts": rp.MaxAttempts,
+	}
+	if rp.Backoff > 0 {
+		m["backoff"] = rp.Backoff.String()
+	}
+	return json.Marshal(m)
+}
+
+func (rp *RetryPolicy) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		MaxAttempts uint   `json:"max_attempts"`
+		Backoff     string `json:"backoff,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	rp.MaxAttempts = aux.MaxAttempts
+	rp.Backoff = 0
+	if aux.Backoff != "" {
+		d, err := time.ParseDuration(aux.Backoff)
+		if err != nil {
+			return errors.Wrap(ErrInvalidRetryPolicy, err)
+		}
+		rp.Backoff = d
+	}
+	return nil
+}
+
+// DeadLetter is a rule output invocation that failed after all retry attempts.
+// It holds everything needed to replay the output: the output itself,
+// the message that triggered the rule and the result of the rule logic.
+type DeadLetter struct {
+	ID        string             `json:"id"`
+	RuleID    string             `json:"rule_id"`
+	DomainID  string             `json:"domain"`
+	Output    Runnable           `json:"output"`
+	Message   *messaging.Message `json:"message"`
+	Result    any                `json:"result,omitempty"`
+	Error     string             `json:"error"`
+	Attempts  uint               `json:"attempts"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at,omitempty"`
+}
+
+func (dl *DeadLetter) UnmarshalJSON(data []byte) error {
+	type alias DeadLetter
+	aux := struct {
+		Output json.RawMessage `json:"output"`
+		*alias
+	}{
+		alias: (*alias)(dl),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	dl.Output = nil
+	if len(aux.Output) > 0 && string(aux.Output) != "null" {
+		o, err := UnmarshalOutput(aux.Output)
+		if err != nil {
+			return err
+		}
+		dl.Output = o
+	}
+	return nil
+}
+
+// DeadLetterPageMeta contains dead letters page metadata that helps navigation.
+type DeadLetterPageMeta struct {
+	Total  uint64 `json:"total"               db:"total"`
+	Offset uint64 `json:"offset"              db:"offset"`
+	Limit  uint64 `json:"limit"               db:"limit"`
+	RuleID string `json:"rule_id,omitempty"   db:"rule_id"`
+	Domain string `json:"domain_id,omitempty" db:"domain_id"`
+}
+
+type DeadLetterPage struct {
+	Offset      uint64       `json:"offset"`
+	Limit       uint64       `json:"limit"`
+	Total       uint64       `json:"total"`
+	DeadLetters []DeadLetter `json:"dead_letters"`
+}
+
+// SecretMarshaler is implemented by the outputs whose secrets are left out of
+// their JSON encoding, so they are not returned by the API.
+type SecretMarshaler interface {
+	MarshalSecret() ([]byte, error)
+}
+
+// MarshalOutput encodes a single rule output for the repository, together
+// with its secrets.
+func MarshalOutput(o Runnable) ([]byte, error) {
+	if sm, ok := o.(SecretMarshaler); ok {
+		return sm.MarshalSecret()
+	}
+	return json.Marshal(o)
+}
+
+// MarshalOutputs encodes the rule outputs for the repository, together with
+// their secrets.
+func MarshalOutputs(o Outputs) ([]byte, error) {
+	if o == nil {
+		return json.Marshal(o)
+	}
+	raws := make([]json.RawMessage, len(o))
+	for i, out := range o {
+		raw, err := MarshalOutput(out)
+		if err != nil {
+			return nil, err
+		}
+		raws[i] = raw
+	}
+	return json.Marshal(raws)
+}
+
+// UnmarshalOutput decodes a single rule output using its type discriminator.
+func UnmarshalOutput(data []byte) (Runnable, error) {
+	var meta struct {
+		Type outputs.OutputType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	factory, ok := outputRegistry[meta.Type]
+	if !ok {
+		return nil, errors.New("unknown output type: " + meta.Type.String())
+	}
+
+	instance := factory()
+	if err := json.Unmarshal(data, instance); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/re"
+	"github.com/absmach/supermq/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		policy re.RetryPolicy
+		err    error
+	}{
+		{
+			desc:   "valid retry policy",
+			policy: re.RetryPolicy{MaxAttempts: 3, Backoff: time.Second},
+			err:    nil,
+		},
+		{
+			desc:   "retry policy without backoff",
+			policy: re.RetryPolicy{MaxAttempts: 1},
+			err:    nil,
+		},
+		{
+			desc:   "retry policy without attempts",
+			policy: re.RetryPolicy{Backoff: time.Second},
+			err:    re.ErrInvalidRetryPolicy,
+		},
+		{
+			desc:   "retry policy with too many attempts",
+			policy: re.RetryPolicy{MaxAttempts: 11},
+			err:    re.ErrInvalidRetryPolicy,
+		},
+		{
+			desc:   "retry policy with negative backoff",
+			policy: re.RetryPolicy{MaxAttempts: 3, Backoff: -time.Second},
+			err:    re.ErrInvalidRetryPolicy,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestRetryPolicyJSON(t *testing.T) {
+	data := `{"max_attempts":5,"backoff":"2s"}`
+
+	var rp re.RetryPolicy
+	err := json.Unmarshal([]byte(data), &rp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.Equal(t, re.RetryPolicy{MaxAttempts: 5, Backoff: 2 * time.Second}, rp)
+
+	out, err := json.Marshal(rp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.JSONEq(t, data, string(out))
+
+	err = json.Unmarshal([]byte(`{"max_attempts":5,"backoff":"two seconds"}`), &rp)
+	assert.NotNil(t, err, "expected invalid backoff error")
+}
+
+func TestDeadLetterJSON(t *testing.T) {
+	dl := re.DeadLetter{
+		ID:       "dead-letter",
+		RuleID:   "rule",
+		DomainID: "domain",
+		Output: &outputs.Webhook{
+			URL:     "http://localhost/hook",
+			Retries: 2,
+			Backoff: time.Second,
+		},
+		Message:   &messaging.Message{Domain: "domain", Channel: "channel", Payload: []byte(`{"t":1}`)},
+		Result:    map[string]any{"t": float64(1)},
+		Error:     "webhook request failed",
+		Attempts:  3,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	data, err := json.Marshal(dl)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+
+	var got re.DeadLetter
+	err = json.Unmarshal(data, &got)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.Equal(t, dl.Output, got.Output)
+	assert.Equal(t, dl.Result, got.Result)
+	assert.Equal(t, dl.Message.Payload, got.Message.Payload)
+	assert.Equal(t, dl.Attempts, got.Attempts)
+
+	err = json.Unmarshal([]byte(`{"id":"dead-letter","output":{"type":"unknown"}}`), &got)
+	assert.NotNil(t, err, "expected unknown output type error")
+}
+
+func TestMarshalOutputs(t *testing.T) {
+	wh := &outputs.Webhook{
+		Method: "POST",
+		URL:    "http://localhost/hook",
+		Secret: "secret",
+	}
+	outs := re.Outputs{wh, &outputs.ChannelPublisher{Channel: "channel"}}
+
+	data, err := json.Marshal(outs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.NotContains(t, string(data), wh.Secret, "expected secret to be left out of the JSON encoding")
+
+	data, err = re.MarshalOutputs(outs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	var got re.Outputs
+	err = json.Unmarshal(data, &got)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.Equal(t, outs, got)
+
+	data, err = re.MarshalOutput(wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	out, err := re.UnmarshalOutput(data)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.Equal(t, wh, out)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/re/outputs"
+	golang "github.com/traefik/yaegi/interp"
+	"github.com/traefik/yaegi/stdlib"
+	"github.com/vadv/gopher-lua-libs/argparse"
+	"github.com/vadv/gopher-lua-libs/base64"
+	bit "github.com/vadv/gopher-lua-libs/bit"
+	"github.com/vadv/gopher-lua-libs/crypto"
+	luajson "github.com/vadv/gopher-lua-libs/json"
+	"github.com/vadv/gopher-lua-libs/regexp"
+	luastrings "github.com/vadv/gopher-lua-libs/strings"
+	luatime "github.com/vadv/gopher-lua-libs/time"
+	"github.com/vadv/gopher-lua-libs/yaml"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const (
+	// MaxDryRunMessages is the maximum number of sample messages in a single dry run.
+	MaxDryRunMessages = 100
+
+	dryRunTimeout = 5 * time.Second
+)
+
+var (
+	ErrDryRunMessages = errors.NewRequestError("dry run requires between 1 and 100 sample messages")
+
+	// sandboxPackages are the standard library packages that are not available
+	// to Go scripts during dry runs since they access files, network or processes.
+	sandboxPackages = []string{"os", "net", "io/ioutil", "path/filepath", "database/sql", "crypto/tls", "log/syslog", "plugin"}
+
+	sandboxSymbols = func() golang.Exports {
+		symbols := golang.Exports{}
+		for pkg, s := range stdlib.Symbols {
+			if !sandboxed(pkg) {
+				symbols[pkg] = s
+			}
+		}
+		return symbols
+	}()
+)
+
+// DryRunResult is the result of running the rule logic against a sample message.
+// Outputs contain the data each output would send, but none of them is executed.
+type DryRunResult struct {
+	Result  any             `json:"result"`
+	Outputs []OutputPreview `json:"outputs,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// OutputPreview is the rendered data of a single rule output.
+type OutputPreview struct {
+	Type    string `json:"type"`
+	Preview any    `json:"preview,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// dryRun runs the rule logic in a sandbox and renders the outputs that would fire.
+func dryRun(ctx context.Context, r Rule, msg *messaging.Message, st *State) DryRunResult {
+	ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
+
+	var val any
+	var err error
+	switch r.Logic.Type {
+	case GoType:
+		val, err = evalGo(ctx, r.Logic.Value, msg, st)
+	default:
+		val, err = evalLua(ctx, r.Logic.Value, msg, st)
+	}
+	if err != nil {
+		return DryRunResult{Error: err.Error()}
+	}
+
+	ret := DryRunResult{Result: val}
+	// Same as for the regular processing, outputs don't fire
+	// if the result is nil or false.
+	if b, ok := val.(bool); val == nil || (ok && !b) {
+		return ret
+	}
+	for _, o := range r.Outputs {
+		ret.Outputs = append(ret.Outputs, previewOutput(o, r, msg, val))
+	}
+
+	return ret
+}
+
+func previewOutput(o Runnable, r Rule, msg *messaging.Message, val any) OutputPreview {
+	op := OutputPreview{Type: outputType(o)}
+	if a, ok := o.(*outputs.Alarm); ok {
+		a.RuleID = r.ID
+	}
+	p, ok := o.(outputs.Previewer)
+	if !ok {
+		op.Error = fmt.Sprintf("unknown output type: %T", o)
+		return op
+	}
+	preview, err := p.Preview(msg, val)
+	if err != nil {
+		op.Error = err.Error()
+		return op
+	}
+	op.Preview = preview
+
+	return op
+}
+
+func outputType(o Runnable) string {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return "unknown"
+	}
+	var meta struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(data, &meta)
+
+	return meta.Type
+}
+
+// evalLua runs the Lua logic in a state without access to files, network
+// or processes and returns the converted result. Only the preloaded modules
+// can be required.
+func evalLua(ctx context.Context, logic string, msg *messaging.Message, st *State) (any, error) {
+	l := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer l.Close()
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.LoadLibName, lua.OpenPackage},
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		l.Push(l.NewFunction(lib.open))
+		l.Push(lua.LString(lib.name))
+		l.Call(1, 0)
+	}
+	// The base library loads the files with dofile and loadfile, and require
+	// loads the Lua files next to the preloaded modules, so only the preloaded
+	// loader is kept.
+	l.SetGlobal("dofile", lua.LNil)
+	l.SetGlobal("loadfile", lua.LNil)
+	if loaders, ok := l.GetField(l.GetGlobal(lua.LoadLibName), "loaders").(*lua.LTable); ok {
+		for i := loaders.Len(); i > 1; i-- {
+			loaders.RawSetInt(i, lua.LNil)
+		}
+	}
+	luajson.Preload(l)
+	yaml.Preload(l)
+	crypto.Preload(l)
+	regexp.Preload(l)
+	luatime.Preload(l)
+	base64.Preload(l)
+	argparse.Preload(l)
+	luastrings.Preload(l)
+	bit.Preload(l)
+	l.SetContext(ctx)
+
+	result, err := runLua(l, logic, msg, st)
+	if err != nil {
+		return nil, err
+	}
+	if result == lua.LNil {
+		return nil, nil
+	}
+
+	return convertLua(result), nil
+}
+
+// evalGo runs the Go logic with the sandboxed standard library and returns the result.
+func evalGo(ctx context.Context, logic string, msg *messaging.Message, st *State) (val any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in Go script: %v", r)
+		}
+	}()
+
+	i, err := newInterpreter(sandboxSymbols, msg, st)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := logicFunc(i, logic); err != nil {
+		return nil, err
+	}
+	// Call the function through the interpreter so the call can be canceled.
+	res, err := i.EvalWithContext(ctx, logicFunction+"()")
+	if err != nil {
+		return nil, err
+	}
+	if !res.IsValid() {
+		return nil, nil
+	}
+
+	return res.Interface(), nil
+}
+
+func sandboxed(pkg string) bool {
+	for _, p := range sandboxPackages {
+		if pkg == p || strings.HasPrefix(pkg, p+"/") {
+			return true
+		}
+	}
+	return false
+}
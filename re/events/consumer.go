@@ -0,0 +1,64 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/re"
+)
+
+const rulesStream = "events." + supermqPrefix + rulePrefix + "*"
+
+var _ events.EventHandler = (*cacheEventHandler)(nil)
+
+type cacheEventHandler struct {
+	cache re.RuleCache
+}
+
+// CacheEventsSubscribe subscribes to rule events and invalidates the cached rules
+// on every rule change. Each service instance keeps its own cache, so each
+// instance must subscribe with a unique consumer name to receive all the events.
+func CacheEventsSubscribe(ctx context.Context, cache re.RuleCache, esURL, consumer string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, "re-cache-es-sub", logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         rulesStream,
+		Consumer:       consumer,
+		Handler:        NewCacheEventHandler(cache),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+		Ordered:        true,
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewCacheEventHandler returns new event store handler that invalidates the cached rules.
+func NewCacheEventHandler(cache re.RuleCache) events.EventHandler {
+	return &cacheEventHandler{cache: cache}
+}
+
+func (h *cacheEventHandler) Handle(ctx context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch events.Read(data, "operation", "") {
+	case ruleCreate, ruleUpdate, ruleUpdateSchedule, ruleEnable, ruleDisable, ruleRemove:
+		h.cache.Invalidate(
+			events.Read(data, "domain", ""),
+			events.Read(data, "input_channel", ""),
+			events.Read(data, "id", ""),
+		)
+	}
+
+	return nil
+}
@@ -23,6 +23,8 @@ const (
 	ruleEnable         = rulePrefix + "enable"
 	ruleDisable        = rulePrefix + "disable"
 	ruleRemove         = rulePrefix + "remove"
+	ruleReplayDLQ      = rulePrefix + "replay_dead_letter"
+	rulePurgeDLQ       = rulePrefix + "purge_dead_letters"
 )
 
 var (
@@ -190,3 +192,29 @@ func (rre removeRuleEvent) Encode() (map[string]any, error) {
 	val["operation"] = ruleRemove
 	return val, nil
 }
+
+type replayDeadLetterEvent struct {
+	ruleID string
+	id     string
+	baseRuleEvent
+}
+
+func (rde replayDeadLetterEvent) Encode() (map[string]any, error) {
+	val := rde.baseRuleEvent.Encode()
+	val["rule_id"] = rde.ruleID
+	val["id"] = rde.id
+	val["operation"] = ruleReplayDLQ
+	return val, nil
+}
+
+type purgeDeadLettersEvent struct {
+	ruleID string
+	baseRuleEvent
+}
+
+func (pde purgeDeadLettersEvent) Encode() (map[string]any, error) {
+	val := pde.baseRuleEvent.Encode()
+	val["rule_id"] = pde.ruleID
+	val["operation"] = rulePurgeDLQ
+	return val, nil
+}
@@ -27,6 +27,8 @@ const (
 	EnableStream         = supermqPrefix + ruleEnable
 	DisableStream        = supermqPrefix + ruleDisable
 	RemoveStream         = supermqPrefix + ruleRemove
+	ReplayDLQStream      = supermqPrefix + ruleReplayDLQ
+	PurgeDLQStream       = supermqPrefix + rulePurgeDLQ
 )
 
 var _ re.Service = (*eventStore)(nil)
@@ -190,6 +192,51 @@ func (es *eventStore) DisableRule(ctx context.Context, session authn.Session, id
 	return rule, nil
 }
 
+func (es *eventStore) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	return es.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (es *eventStore) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	return es.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (es *eventStore) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	return es.svc.DryRunRule(ctx, session, r, msgs)
+}
+
+func (es *eventStore) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	return es.svc.ListExecutions(ctx, session, pm)
+}
+
+func (es *eventStore) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	if err := es.svc.ReplayDeadLetter(ctx, session, ruleID, id); err != nil {
+		return err
+	}
+	event := replayDeadLetterEvent{
+		ruleID:        ruleID,
+		id:            id,
+		baseRuleEvent: newBaseRuleEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, ReplayDLQStream, event); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (es *eventStore) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	if err := es.svc.PurgeDeadLetters(ctx, session, ruleID); err != nil {
+		return err
+	}
+	event := purgeDeadLettersEvent{
+		ruleID:        ruleID,
+		baseRuleEvent: newBaseRuleEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, PurgeDLQStream, event); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (es *eventStore) StartScheduler(ctx context.Context) error {
 	return es.svc.StartScheduler(ctx)
 }
@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// Execution is a single run of a rule, triggered either by
+// a message or by the rule schedule.
+type Execution struct {
+	ID        string        `json:"id"`
+	RuleID    string        `json:"rule_id"`
+	DomainID  string        `json:"domain"`
+	MessageID string        `json:"message_id"`
+	Level     slog.Level    `json:"level"`
+	Message   string        `json:"message"`
+	Errors    []string      `json:"errors,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+}
+
+func (e Execution) MarshalJSON() ([]byte, error) {
+	type alias Execution
+	return json.Marshal(struct {
+		Duration string `json:"duration"`
+		alias
+	}{
+		Duration: e.Duration.String(),
+		alias:    alias(e),
+	})
+}
+
+func (e *Execution) UnmarshalJSON(data []byte) error {
+	type alias Execution
+	aux := struct {
+		Duration string `json:"duration"`
+		*alias
+	}{
+		alias: (*alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Duration = 0
+	if aux.Duration != "" {
+		d, err := time.ParseDuration(aux.Duration)
+		if err != nil {
+			return err
+		}
+		e.Duration = d
+	}
+	return nil
+}
+
+// ExecutionPageMeta contains execution page metadata that helps navigation.
+type ExecutionPageMeta struct {
+	Total  uint64      `json:"total"               db:"total"`
+	Offset uint64      `json:"offset"              db:"offset"`
+	Limit  uint64      `json:"limit"               db:"limit"`
+	RuleID string      `json:"rule_id,omitempty"   db:"rule_id"`
+	Domain string      `json:"domain_id,omitempty" db:"domain_id"`
+	Level  *slog.Level `json:"level,omitempty"     db:"level"`
+}
+
+type ExecutionPage struct {
+	Offset     uint64      `json:"offset"`
+	Limit      uint64      `json:"limit"`
+	Total      uint64      `json:"total"`
+	Executions []Execution `json:"executions"`
+}
+
+// messageID identifies the message that triggered the rule. Messages
+// don't carry an ID, so it's derived from the message content.
+func messageID(msg *messaging.Message) string {
+	h := sha256.New()
+	for _, f := range []string{msg.Domain, msg.Channel, msg.Subtopic, msg.Publisher, msg.ClientId, msg.Protocol} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	_ = binary.Write(h, binary.BigEndian, msg.Created)
+	h.Write(msg.Payload)
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
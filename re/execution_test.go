@@ -0,0 +1,50 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionJSON(t *testing.T) {
+	e := re.Execution{
+		ID:        "execution",
+		RuleID:    "rule",
+		DomainID:  "domain",
+		MessageID: "message",
+		Level:     slog.LevelError,
+		Message:   "failed to run rule outputs",
+		Errors:    []string{"failed to publish"},
+		StartedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:  1500 * time.Millisecond,
+	}
+
+	data, err := json.Marshal(e)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "execution",
+		"rule_id": "rule",
+		"domain": "domain",
+		"message_id": "message",
+		"level": "ERROR",
+		"message": "failed to run rule outputs",
+		"errors": ["failed to publish"],
+		"started_at": "2025-01-01T00:00:00Z",
+		"duration": "1.5s"
+	}`, string(data))
+
+	var got re.Execution
+	require.Nil(t, json.Unmarshal(data, &got))
+	assert.Equal(t, e, got)
+
+	err = json.Unmarshal([]byte(`{"duration": "invalid"}`), &got)
+	assert.NotNil(t, err)
+}
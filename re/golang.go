@@ -4,7 +4,6 @@
 package re
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -23,6 +22,8 @@ const logicFunction = "main.logicFunction"
 var (
 	goKeywordRegex = regexp.MustCompile(`\bgo\s+func\s*\(|^\s*go\s+\w+\(|[;\s{]go\s+func\s*\(|[;\s{]go\s+\w+\(`)
 	panicRegex     = regexp.MustCompile(`\bpanic\s*\(`)
+
+	errInvalidSignature = errors.New("invalid logic function signature")
 )
 
 // Type message is an SMQ message with payload replaces by JSON deserialized payload.
@@ -37,9 +38,10 @@ type message struct {
 	Payload   any    `json:"payload,omitempty"`
 }
 
-func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (ret pkglog.RunInfo) {
+func processGo(details []slog.Attr, r Rule, msg *messaging.Message, st *State) (res any, ret pkglog.RunInfo, done bool) {
 	defer func() {
 		if r := recover(); r != nil {
+			res, done = nil, true
 			ret = pkglog.RunInfo{
 				Level:   slog.LevelError,
 				Details: details,
@@ -48,9 +50,28 @@ func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *m
 		}
 	}()
 
+	i, err := newInterpreter(stdlib.Symbols, msg, st)
+	if err != nil {
+		return nil, pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}, true
+	}
+	f, err := logicFunc(i, r.Logic.Value)
+	if err != nil {
+		return nil, pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}, true
+	}
+	res = f()
+	if b, ok := res.(bool); ok && !b {
+		return nil, pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}, true
+	}
+
+	return res, pkglog.RunInfo{}, false
+}
+
+// newInterpreter creates a Go interpreter with the given standard library symbols and
+// the message and the rule state exposed through the "messaging" package.
+func newInterpreter(stdSymbols golang.Exports, msg *messaging.Message, st *State) (*golang.Interpreter, error) {
 	i := golang.New(golang.Options{})
-	if err := i.Use(stdlib.Symbols); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+	if err := i.Use(stdSymbols); err != nil {
+		return nil, err
 	}
 	m := message{
 		Created:   msg.Created,
@@ -67,38 +88,35 @@ func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *m
 	}
 	m.Payload = pld
 
-	err := i.Use(golang.Exports{
-		"messaging/m": {
-			"message": reflect.ValueOf(m),
-		},
-	})
-	if err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+	symbols := map[string]reflect.Value{
+		"message": reflect.ValueOf(m),
+	}
+	// Windowed rules can use the state, e.g. m.state.Push("temp", 21.5).
+	if st != nil {
+		symbols["state"] = reflect.ValueOf(st)
 	}
-	if _, err = i.Eval(r.Logic.Value); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+	if err := i.Use(golang.Exports{
+		"messaging/m": symbols,
+	}); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// logicFunc evaluates the rule logic and returns the logic function.
+func logicFunc(i *golang.Interpreter, logic string) (func() any, error) {
+	if _, err := i.Eval(logic); err != nil {
+		return nil, err
 	}
 	ifc, err := i.Eval(logicFunction)
 	if err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+		return nil, err
 	}
 	f, ok := ifc.Interface().(func() any)
 	if !ok {
-		return pkglog.RunInfo{Level: slog.LevelError, Message: "invalid logic function signature", Details: details}
-	}
-	res := f()
-	if b, ok := res.(bool); ok && !b {
-		return pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}
+		return nil, errInvalidSignature
 	}
-	for _, o := range r.Outputs {
-		if e := re.handleOutput(ctx, o, r, msg, res); e != nil {
-			err = errors.Wrap(e, err)
-		}
-	}
-	ret = pkglog.RunInfo{Level: slog.LevelInfo, Details: details, Message: "rule processed successfully"}
-	if err != nil {
-		ret.Level = slog.LevelError
-		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
-	}
-	return ret
+
+	return f, nil
 }
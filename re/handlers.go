@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	pkglog "github.com/absmach/supermq/pkg/logger"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/re/outputs"
@@ -33,82 +35,263 @@ func (re *re) Handle(msg *messaging.Message) error {
 	if n := len(msg.Payload); n > maxPayload {
 		return errors.New(pldExceededFmt + strconv.Itoa(n))
 	}
-	// Skip filtering by message topic and fetch all non-scheduled rules instead.
-	// It's cleaner and more efficient to match wildcards in Go, but we can
-	// revisit this if it ever becomes a performance bottleneck.
-	pm := PageMeta{
-		Domain:       msg.Domain,
-		InputChannel: msg.Channel,
-		Status:       EnabledStatus,
-		Scheduled:    &scheduledFalse,
-	}
 	ctx := context.Background()
-	page, err := re.repo.ListAllRules(ctx, pm)
+	rules, err := re.cache.Match(ctx, msg.Domain, msg.Channel, msg.Subtopic)
 	if err != nil {
 		return err
 	}
-	for _, r := range page.Rules {
-		if matchTopic(msg.Subtopic, r.InputTopic) {
-			go func(ctx context.Context) {
-				re.runInfo <- re.process(ctx, r, msg)
-			}(ctx)
-		}
+	for _, r := range rules {
+		go func(ctx context.Context) {
+			re.runInfo <- re.process(ctx, r, msg)
+		}(ctx)
 	}
 
 	return nil
 }
 
-// matchTopic matches a published subtopic against a subscription pattern
-// using MQTT-style wildcards: + (single level) and # (multi-level).
-func matchTopic(published, subscribed string) bool {
-	p := strings.Split(published, "/")
-	s := strings.Split(subscribed, "/")
-	n := len(p)
-
-	for i := range s {
-		if s[i] == "#" {
-			return true
-		}
-		if i >= n {
-			return false
-		}
-		if s[i] != "+" && p[i] != s[i] {
-			return false
-		}
-	}
-	return len(s) == n
-}
-
 func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message) pkglog.RunInfo {
+	start := time.Now()
 	details := []slog.Attr{
 		slog.String("domain_id", r.DomainID),
 		slog.String("rule_id", r.ID),
 		slog.String("rule_name", r.Name),
-		slog.Time("exec_time", time.Now().UTC()),
+		slog.Time("exec_time", start.UTC()),
+	}
+	ret, errs := re.processRule(ctx, details, r, msg)
+	if err := re.saveExecution(ctx, r, msg, start, ret, errs); err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to save rule execution: %s", err), Details: details}
+	}
+
+	return ret
+}
+
+func (re *re) processRule(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (pkglog.RunInfo, []string) {
+	res, ret, done := re.evaluate(ctx, details, r, msg)
+	if done {
+		return ret, nil
+	}
+
+	return re.runOutputs(ctx, details, r, msg, res)
+}
+
+// evaluate runs the rule logic and returns its result. If the logic ends the
+// execution, the returned run info is final and the outputs are not run.
+func (re *re) evaluate(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (any, pkglog.RunInfo, bool) {
+	if r.Window == nil {
+		return re.run(details, r, msg, nil)
+	}
+
+	// Serialize executions of the same windowed rule so concurrent
+	// messages don't overwrite each other's state. The outputs run
+	// after the state is saved, so their retries don't hold the lock.
+	mu := re.lockState(r.ID)
+	defer mu.Unlock()
+
+	st, err := re.loadState(ctx, r)
+	if err != nil {
+		return nil, pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to load rule state: %s", err), Details: details}, true
+	}
+	res, ret, done := re.run(details, r, msg, st)
+	st.UpdatedAt = time.Now().UTC()
+	if err := re.repo.SaveState(ctx, *st); err != nil {
+		return nil, pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to save rule state: %s", err), Details: details}, true
+	}
+
+	return res, ret, done
+}
+
+// lockState locks and returns the state lock of the rule. The lock removed
+// by removeStateLock while waiting for it is dropped in favor of the current one,
+// so the executions of the rule are never serialized by different locks.
+func (re *re) lockState(id string) *sync.Mutex {
+	for {
+		v, _ := re.stateLocks.LoadOrStore(id, &sync.Mutex{})
+		mu := v.(*sync.Mutex)
+		mu.Lock()
+		if cur, ok := re.stateLocks.Load(id); ok && cur == mu {
+			return mu
+		}
+		mu.Unlock()
+	}
+}
+
+// removeStateLock removes the state lock of the deleted or updated rule, so
+// the locks don't pile up. It waits for the running state update to finish.
+func (re *re) removeStateLock(id string) {
+	v, ok := re.stateLocks.Load(id)
+	if !ok {
+		return
 	}
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	re.stateLocks.CompareAndDelete(id, mu)
+	mu.Unlock()
+}
+
+func (re *re) run(details []slog.Attr, r Rule, msg *messaging.Message, st *State) (any, pkglog.RunInfo, bool) {
 	switch r.Logic.Type {
 	case GoType:
-		return re.processGo(ctx, details, r, msg)
+		return processGo(details, r, msg, st)
 	default:
-		return re.processLua(ctx, details, r, msg)
+		return processLua(details, r, msg, st)
+	}
+}
+
+func (re *re) runOutputs(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message, res any) (pkglog.RunInfo, []string) {
+	var err error
+	var errs []string
+	for _, o := range r.Outputs {
+		if e := re.runOutput(ctx, o, r, msg, res); e != nil {
+			err = errors.Wrap(e, err)
+			errs = append(errs, e.Error())
+		}
+	}
+	ret := pkglog.RunInfo{Level: slog.LevelInfo, Message: "rule processed successfully", Details: details}
+	if err != nil {
+		ret.Level = slog.LevelError
+		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
+	}
+
+	return ret, errs
+}
+
+// saveExecution updates the rule execution metrics and stores the execution
+// together with the rule last run summary. Successful executions are sampled,
+// so the rule summary is not updated by the ones which are not stored.
+func (re *re) saveExecution(ctx context.Context, r Rule, msg *messaging.Message, start time.Time, ret pkglog.RunInfo, errs []string) error {
+	duration := time.Since(start)
+	re.execCounter.With("domain_id", r.DomainID, "rule_id", r.ID, "level", ret.Level.String()).Add(1)
+	re.execLatency.With("domain_id", r.DomainID, "rule_id", r.ID).Observe(duration.Seconds())
+	if ret.Level < slog.LevelWarn && rand.Float64() >= re.sampleRate {
+		return nil
+	}
+
+	id, err := re.idp.ID()
+	if err != nil {
+		return err
+	}
+	e := Execution{
+		ID:        id,
+		RuleID:    r.ID,
+		DomainID:  r.DomainID,
+		MessageID: messageID(msg),
+		Level:     ret.Level,
+		Message:   ret.Message,
+		Errors:    errs,
+		StartedAt: start.UTC(),
+		Duration:  duration,
+	}
+
+	return re.repo.AddExecution(ctx, e)
+}
+
+func (re *re) loadState(ctx context.Context, r Rule) (*State, error) {
+	st, err := re.repo.RetrieveState(ctx, r.ID)
+	switch {
+	case err == nil:
+		// Rule window may have been updated in the meantime.
+		st.Window = *r.Window
+		return &st, nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return NewState(r.ID, *r.Window), nil
+	default:
+		return nil, err
+	}
+}
+
+// runOutput runs the rule output following the rule retry policy. If all
+// the attempts fail, the invocation is stored as a dead letter so that it
+// can be inspected and replayed later. Outputs of the rules without the
+// retry policy run once and are not stored when they fail.
+func (re *re) runOutput(ctx context.Context, o Runnable, r Rule, msg *messaging.Message, val any) error {
+	if r.RetryPolicy == nil {
+		return re.handleOutput(ctx, o, r, msg, val)
+	}
+
+	attempts, backoff := max(r.RetryPolicy.MaxAttempts, 1), defRetryBackoff
+	if r.RetryPolicy.Backoff > 0 {
+		backoff = r.RetryPolicy.Backoff
+	}
+
+	var err error
+	attempt := uint(1)
+	for ; ; attempt++ {
+		if err = re.handleOutput(ctx, o, r, msg, val); err == nil {
+			return nil
+		}
+		if attempt >= attempts || !wait(ctx, backoff) {
+			break
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+
+	if e := re.addDeadLetter(ctx, o, r, msg, val, err, attempt); e != nil {
+		return errors.Wrap(err, e)
+	}
+	return err
+}
+
+func (re *re) addDeadLetter(ctx context.Context, o Runnable, r Rule, msg *messaging.Message, val any, cause error, attempts uint) error {
+	id, err := re.idp.ID()
+	if err != nil {
+		return err
+	}
+	dl := DeadLetter{
+		ID:        id,
+		RuleID:    r.ID,
+		DomainID:  r.DomainID,
+		Output:    o,
+		Message:   msg,
+		Result:    val,
+		Error:     cause.Error(),
+		Attempts:  attempts,
+		CreatedAt: time.Now().UTC(),
+	}
+	// Use a fresh context since the output may have failed due to cancellation.
+	return re.repo.AddDeadLetter(context.WithoutCancel(ctx), dl)
+}
+
+// wait blocks for the given duration and reports whether the context is still active.
+func wait(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
 	}
 }
 
 func (re *re) handleOutput(ctx context.Context, o Runnable, r Rule, msg *messaging.Message, val any) error {
+	// Cached rules are shared between concurrent executions,
+	// so dependencies are set on a copy of the output.
 	switch o := o.(type) {
 	case *outputs.Alarm:
-		o.AlarmsPub = re.alarmsPub
-		o.RuleID = r.ID
-		return o.Run(ctx, msg, val)
+		a := *o
+		a.AlarmsPub = re.alarmsPub
+		a.RuleID = r.ID
+		return a.Run(ctx, msg, val)
 	case *outputs.Email:
-		o.Emailer = re.email
-		return o.Run(ctx, msg, val)
+		e := *o
+		e.Emailer = re.email
+		return e.Run(ctx, msg, val)
 	case *outputs.ChannelPublisher:
-		o.RePubSub = re.rePubSub
-		return o.Run(ctx, msg, val)
+		cp := *o
+		cp.RePubSub = re.rePubSub
+		return cp.Run(ctx, msg, val)
 	case *outputs.SenML:
-		o.WritersPub = re.writersPub
-		return o.Run(ctx, msg, val)
+		s := *o
+		s.WritersPub = re.writersPub
+		return s.Run(ctx, msg, val)
+	case *outputs.Webhook:
+		// The rule retry policy replaces the webhook retries,
+		// so the failed requests are not retried twice.
+		w := *o
+		if r.RetryPolicy != nil {
+			w.Retries = 0
+		}
+		return w.Run(ctx, msg, val)
 	case *outputs.Postgres, *outputs.Slack:
 		return o.Run(ctx, msg, val)
 	default:
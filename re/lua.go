@@ -4,12 +4,10 @@
 package re
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 
-	"github.com/absmach/supermq/pkg/errors"
 	pkglog "github.com/absmach/supermq/pkg/logger"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/vadv/gopher-lua-libs/argparse"
@@ -29,47 +27,48 @@ import (
 	lua "github.com/yuin/gopher-lua"
 )
 
-const payloadKey = "payload"
+const (
+	payloadKey = "payload"
+	stateKey   = "state"
+)
 
-func (re *re) processLua(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) pkglog.RunInfo {
+func processLua(details []slog.Attr, r Rule, msg *messaging.Message, st *State) (any, pkglog.RunInfo, bool) {
 	l := lua.NewState()
 	defer l.Close()
 	preload(l)
-	message := prepareMsg(l, msg)
-
-	// Set the message object as a Lua global variable.
-	l.SetGlobal("message", message)
-	if err := l.DoString(r.Logic.Value); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}
+	result, err := runLua(l, r.Logic.Value, msg, st)
+	if err != nil {
+		return nil, pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}, true
 	}
-	// Get the last result.
-	result := l.Get(-1)
 	if result == lua.LNil {
-		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with nil script result", Details: details}
+		return nil, pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with nil script result", Details: details}, true
 	}
 	// Converting Lua is an expensive operation, so
 	// don't do it if there are no outputs.
 	if len(r.Outputs) == 0 {
-		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with no outputs", Details: details}
+		return nil, pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with no outputs", Details: details}, true
 	}
-	var err error
 	res := convertLua(result)
+	// If value is false, don't run the follow-up.
+	if v, ok := res.(bool); ok && !v {
+		return nil, pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}, true
+	}
 
-	for _, o := range r.Outputs {
-		// If value is false, don't run the follow-up.
-		if v, ok := res.(bool); ok && !v {
-			return pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}
-		}
-		if e := re.handleOutput(ctx, o, r, msg, res); e != nil {
-			err = errors.Wrap(e, err)
-		}
+	return res, pkglog.RunInfo{}, false
+}
+
+// runLua runs the rule logic in the given Lua state and returns the last result.
+func runLua(l *lua.LState, logic string, msg *messaging.Message, st *State) (lua.LValue, error) {
+	// Set the message object as a Lua global variable.
+	l.SetGlobal("message", prepareMsg(l, msg))
+	if st != nil {
+		l.SetGlobal(stateKey, prepareState(l, st))
 	}
-	ret := pkglog.RunInfo{Level: slog.LevelInfo, Message: "rule processed successfully", Details: details}
-	if err != nil {
-		ret.Level = slog.LevelError
-		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
+	if err := l.DoString(logic); err != nil {
+		return lua.LNil, err
 	}
-	return ret
+	// Get the last result.
+	return l.Get(-1), nil
 }
 
 func preload(l *lua.LState) {
@@ -116,6 +115,57 @@ func prepareMsg(l *lua.LState, msg *messaging.Message) lua.LValue {
 	return message
 }
 
+// prepareState exposes the rule state to Lua as a table of functions, for example
+// state.push("temp", message.payload.t) or state.avg("temp").
+func prepareState(l *lua.LState, st *State) lua.LValue {
+	num := func(f func(string) float64) lua.LGFunction {
+		return func(l *lua.LState) int {
+			l.Push(lua.LNumber(f(l.CheckString(1))))
+			return 1
+		}
+	}
+	fns := map[string]lua.LGFunction{
+		"push": func(l *lua.LState) int {
+			st.Push(l.CheckString(1), float64(l.CheckNumber(2)))
+			return 0
+		},
+		"series": func(l *lua.LState) int {
+			t := l.NewTable()
+			for _, v := range st.Series(l.CheckString(1)) {
+				t.Append(lua.LNumber(v))
+			}
+			l.Push(t)
+			return 1
+		},
+		"count": func(l *lua.LState) int {
+			l.Push(lua.LNumber(st.Count(l.CheckString(1))))
+			return 1
+		},
+		"consecutive": func(l *lua.LState) int {
+			l.Push(lua.LNumber(st.Consecutive(l.CheckString(1), float64(l.CheckNumber(2)))))
+			return 1
+		},
+		"sum": num(st.Sum),
+		"avg": num(st.Avg),
+		"min": num(st.Min),
+		"max": num(st.Max),
+		"get": func(l *lua.LState) int {
+			l.Push(traverseJson(l, st.Get(l.CheckString(1))))
+			return 1
+		},
+		"set": func(l *lua.LState) int {
+			st.Set(l.CheckString(1), convertLua(l.CheckAny(2)))
+			return 0
+		},
+		"clear": func(l *lua.LState) int {
+			st.Clear(l.CheckString(1))
+			return 0
+		},
+	}
+
+	return l.SetFuncs(l.NewTable(), fns)
+}
+
 func traverseJson(l *lua.LState, value any) lua.LValue {
 	switch val := value.(type) {
 	case string:
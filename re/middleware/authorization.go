@@ -126,6 +126,62 @@ func (am *authorizationMiddleware) DisableRule(ctx context.Context, session auth
 	return am.svc.DisableRule(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	if err := am.authorize(ctx, operations.OpListDeadLetters, session, operations.EntityType, pm.RuleID); err != nil {
+		return re.DeadLetterPage{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	if err := am.authorize(ctx, operations.OpViewDeadLetter, session, operations.EntityType, ruleID); err != nil {
+		return re.DeadLetter{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (am *authorizationMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	if err := am.authorize(ctx, operations.OpReplayDeadLetter, session, operations.EntityType, ruleID); err != nil {
+		return errors.Wrap(errDomainUpdateRules, err)
+	}
+
+	return am.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
+func (am *authorizationMiddleware) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	if err := am.authorize(ctx, operations.OpPurgeDeadLetters, session, operations.EntityType, ruleID); err != nil {
+		return errors.Wrap(errDomainDeleteRules, err)
+	}
+
+	return am.svc.PurgeDeadLetters(ctx, session, ruleID)
+}
+
+func (am *authorizationMiddleware) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	// Running an inline rule is allowed to users who can create rules in the domain.
+	switch r.ID {
+	case "":
+		if err := am.authorize(ctx, operations.OpAddRule, session, policies.DomainType, session.DomainID); err != nil {
+			return nil, errors.Wrap(errDomainCreateRules, err)
+		}
+	default:
+		if err := am.authorize(ctx, operations.OpDryRunRule, session, operations.EntityType, r.ID); err != nil {
+			return nil, errors.Wrap(errDomainViewRules, err)
+		}
+	}
+
+	return am.svc.DryRunRule(ctx, session, r, msgs)
+}
+
+func (am *authorizationMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	if err := am.authorize(ctx, operations.OpListExecutions, session, operations.EntityType, pm.RuleID); err != nil {
+		return re.ExecutionPage{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ListExecutions(ctx, session, pm)
+}
+
 func (am *authorizationMiddleware) StartScheduler(ctx context.Context) error {
 	return am.svc.StartScheduler(ctx)
 }
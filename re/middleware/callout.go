@@ -157,6 +157,86 @@ func (cm *calloutMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return cm.svc.DisableRule(ctx, session, id)
 }
 
+func (cm *calloutMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	params := map[string]any{
+		"entity_id": pm.RuleID,
+		"pagemeta":  pm,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpListDeadLetters, params); err != nil {
+		return re.DeadLetterPage{}, err
+	}
+
+	return cm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (cm *calloutMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	params := map[string]any{
+		"entity_id":      ruleID,
+		"dead_letter_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpViewDeadLetter, params); err != nil {
+		return re.DeadLetter{}, err
+	}
+
+	return cm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (cm *calloutMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	params := map[string]any{
+		"entity_id":      ruleID,
+		"dead_letter_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpReplayDeadLetter, params); err != nil {
+		return err
+	}
+
+	return cm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
+func (cm *calloutMiddleware) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	params := map[string]any{
+		"entity_id": ruleID,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpPurgeDeadLetters, params); err != nil {
+		return err
+	}
+
+	return cm.svc.PurgeDeadLetters(ctx, session, ruleID)
+}
+
+func (cm *calloutMiddleware) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	params := map[string]any{
+		"entity_id": r.ID,
+		"count":     len(msgs),
+	}
+	if r.ID == "" {
+		params["entities"] = r
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpDryRunRule, params); err != nil {
+		return nil, err
+	}
+
+	return cm.svc.DryRunRule(ctx, session, r, msgs)
+}
+
+func (cm *calloutMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	params := map[string]any{
+		"entity_id": pm.RuleID,
+		"pagemeta":  pm,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpListExecutions, params); err != nil {
+		return re.ExecutionPage{}, err
+	}
+
+	return cm.svc.ListExecutions(ctx, session, pm)
+}
+
 func (cm *calloutMiddleware) StartScheduler(ctx context.Context) error {
 	return cm.svc.StartScheduler(ctx)
 }
@@ -208,6 +208,126 @@ func (lm *loggingMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return lm.svc.DisableRule(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (pg re.DeadLetterPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", pm.RuleID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", pg.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List dead letters failed", args...)
+			return
+		}
+		lm.logger.Info("List dead letters completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (dl re.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", ruleID),
+			slog.String("dead_letter_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("View dead letter failed", args...)
+			return
+		}
+		lm.logger.Info("View dead letter completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (lm *loggingMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", ruleID),
+			slog.String("dead_letter_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Replay dead letter failed", args...)
+			return
+		}
+		lm.logger.Info("Replay dead letter completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
+func (lm *loggingMiddleware) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", ruleID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Purge dead letters failed", args...)
+			return
+		}
+		lm.logger.Info("Purge dead letters completed successfully", args...)
+	}(time.Now())
+	return lm.svc.PurgeDeadLetters(ctx, session, ruleID)
+}
+
+func (lm *loggingMiddleware) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) (res []re.DryRunResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Int("messages", len(msgs)),
+		}
+		if r.ID != "" {
+			args = append(args, slog.String("rule_id", r.ID))
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Dry run rule failed", args...)
+			return
+		}
+		lm.logger.Info("Dry run rule completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DryRunRule(ctx, session, r, msgs)
+}
+
+func (lm *loggingMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (pg re.ExecutionPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", pm.RuleID),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", pg.Total),
+			),
+		}
+		if pm.Level != nil {
+			args = append(args, slog.String("level", pm.Level.String()))
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List rule executions failed", args...)
+			return
+		}
+		lm.logger.Info("List rule executions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListExecutions(ctx, session, pm)
+}
+
 func (lm *loggingMiddleware) StartScheduler(ctx context.Context) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -123,6 +123,60 @@ func (mm *metricsMiddleware) Handle(msg *messaging.Message) error {
 	return mm.service.Handle(msg)
 }
 
+func (mm *metricsMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_dead_letters").Add(1)
+		mm.latency.With("method", "list_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListDeadLetters(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_dead_letter").Add(1)
+		mm.latency.With("method", "view_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (mm *metricsMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "replay_dead_letter").Add(1)
+		mm.latency.With("method", "replay_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
+func (mm *metricsMiddleware) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "purge_dead_letters").Add(1)
+		mm.latency.With("method", "purge_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.PurgeDeadLetters(ctx, session, ruleID)
+}
+
+func (mm *metricsMiddleware) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "dry_run_rule").Add(1)
+		mm.latency.With("method", "dry_run_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.DryRunRule(ctx, session, r, msgs)
+}
+
+func (mm *metricsMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_executions").Add(1)
+		mm.latency.With("method", "list_executions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListExecutions(ctx, session, pm)
+}
+
 func (mm *metricsMiddleware) StartScheduler(ctx context.Context) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "start_scheduler").Add(1)
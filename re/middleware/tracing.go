@@ -125,6 +125,67 @@ func (tm *tracingMiddleware) Handle(msg *messaging.Message) error {
 	return tm.svc.Handle(msg)
 }
 
+func (tm *tracingMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_dead_letters", trace.WithAttributes(
+		attribute.String("rule_id", pm.RuleID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_dead_letter", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (tm *tracingMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "replay_dead_letter", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
+func (tm *tracingMiddleware) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "purge_dead_letters", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+	))
+	defer span.End()
+
+	return tm.svc.PurgeDeadLetters(ctx, session, ruleID)
+}
+
+func (tm *tracingMiddleware) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "dry_run_rule", trace.WithAttributes(
+		attribute.String("rule_id", r.ID),
+		attribute.Int("messages", len(msgs)),
+	))
+	defer span.End()
+
+	return tm.svc.DryRunRule(ctx, session, r, msgs)
+}
+
+func (tm *tracingMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_executions", trace.WithAttributes(
+		attribute.String("rule_id", pm.RuleID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListExecutions(ctx, session, pm)
+}
+
 func (tm *tracingMiddleware) StartScheduler(ctx context.Context) error {
 	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "start_scheduler")
 	defer span.End()
@@ -44,6 +44,120 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// AddDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) AddDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	ret := _mock.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetter) error); ok {
+		r0 = returnFunc(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDeadLetter'
+type Repository_AddDeadLetter_Call struct {
+	*mock.Call
+}
+
+// AddDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl re.DeadLetter
+func (_e *Repository_Expecter) AddDeadLetter(ctx interface{}, dl interface{}) *Repository_AddDeadLetter_Call {
+	return &Repository_AddDeadLetter_Call{Call: _e.mock.On("AddDeadLetter", ctx, dl)}
+}
+
+func (_c *Repository_AddDeadLetter_Call) Run(run func(ctx context.Context, dl re.DeadLetter)) *Repository_AddDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetter
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddDeadLetter_Call) Return(err error) *Repository_AddDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dl re.DeadLetter) error) *Repository_AddDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddExecution provides a mock function for the type Repository
+func (_mock *Repository) AddExecution(ctx context.Context, e re.Execution) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddExecution")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Execution) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddExecution'
+type Repository_AddExecution_Call struct {
+	*mock.Call
+}
+
+// AddExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e re.Execution
+func (_e *Repository_Expecter) AddExecution(ctx interface{}, e interface{}) *Repository_AddExecution_Call {
+	return &Repository_AddExecution_Call{Call: _e.mock.On("AddExecution", ctx, e)}
+}
+
+func (_c *Repository_AddExecution_Call) Run(run func(ctx context.Context, e re.Execution)) *Repository_AddExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.Execution
+		if args[1] != nil {
+			arg1 = args[1].(re.Execution)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddExecution_Call) Return(err error) *Repository_AddExecution_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddExecution_Call) RunAndReturn(run func(ctx context.Context, e re.Execution) error) *Repository_AddExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddRoles provides a mock function for the type Repository
 func (_mock *Repository) AddRoles(ctx context.Context, rps []roles.RoleProvision) ([]roles.RoleProvision, error) {
 	ret := _mock.Called(ctx, rps)
@@ -244,6 +358,72 @@ func (_c *Repository_ListAllRules_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// ListDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) ListDeadLetters(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 re.DeadLetterPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetterPageMeta) (re.DeadLetterPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetterPageMeta) re.DeadLetterPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetterPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.DeadLetterPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type Repository_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.DeadLetterPageMeta
+func (_e *Repository_Expecter) ListDeadLetters(ctx interface{}, pm interface{}) *Repository_ListDeadLetters_Call {
+	return &Repository_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, pm)}
+}
+
+func (_c *Repository_ListDeadLetters_Call) Run(run func(ctx context.Context, pm re.DeadLetterPageMeta)) *Repository_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetterPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetterPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListDeadLetters_Call) Return(deadLetterPage re.DeadLetterPage, err error) *Repository_ListDeadLetters_Call {
+	_c.Call.Return(deadLetterPage, err)
+	return _c
+}
+
+func (_c *Repository_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error)) *Repository_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListEntityMembers provides a mock function for the type Repository
 func (_mock *Repository) ListEntityMembers(ctx context.Context, entityID string, pageQuery roles.MembersRolePageQuery) (roles.MembersRolePage, error) {
 	ret := _mock.Called(ctx, entityID, pageQuery)
@@ -316,6 +496,72 @@ func (_c *Repository_ListEntityMembers_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListExecutions provides a mock function for the type Repository
+func (_mock *Repository) ListExecutions(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 re.ExecutionPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.ExecutionPageMeta) (re.ExecutionPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.ExecutionPageMeta) re.ExecutionPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.ExecutionPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.ExecutionPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type Repository_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.ExecutionPageMeta
+func (_e *Repository_Expecter) ListExecutions(ctx interface{}, pm interface{}) *Repository_ListExecutions_Call {
+	return &Repository_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, pm)}
+}
+
+func (_c *Repository_ListExecutions_Call) Run(run func(ctx context.Context, pm re.ExecutionPageMeta)) *Repository_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.ExecutionPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.ExecutionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListExecutions_Call) Return(executionPage re.ExecutionPage, err error) *Repository_ListExecutions_Call {
+	_c.Call.Return(executionPage, err)
+	return _c
+}
+
+func (_c *Repository_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionPage, error)) *Repository_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUserRules provides a mock function for the type Repository
 func (_mock *Repository) ListUserRules(ctx context.Context, userID string, pm re.PageMeta) (re.Page, error) {
 	ret := _mock.Called(ctx, userID, pm)
@@ -355,7 +601,184 @@ func (_e *Repository_Expecter) ListUserRules(ctx interface{}, userID interface{}
 	return &Repository_ListUserRules_Call{Call: _e.mock.On("ListUserRules", ctx, userID, pm)}
 }
 
-func (_c *Repository_ListUserRules_Call) Run(run func(ctx context.Context, userID string, pm re.PageMeta)) *Repository_ListUserRules_Call {
+func (_c *Repository_ListUserRules_Call) Run(run func(ctx context.Context, userID string, pm re.PageMeta)) *Repository_ListUserRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 re.PageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.PageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListUserRules_Call) Return(page re.Page, err error) *Repository_ListUserRules_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_ListUserRules_Call) RunAndReturn(run func(ctx context.Context, userID string, pm re.PageMeta) (re.Page, error)) *Repository_ListUserRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) RemoveDeadLetter(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadLetter'
+type Repository_RemoveDeadLetter_Call struct {
+	*mock.Call
+}
+
+// RemoveDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) RemoveDeadLetter(ctx interface{}, id interface{}) *Repository_RemoveDeadLetter_Call {
+	return &Repository_RemoveDeadLetter_Call{Call: _e.mock.On("RemoveDeadLetter", ctx, id)}
+}
+
+func (_c *Repository_RemoveDeadLetter_Call) Run(run func(ctx context.Context, id string)) *Repository_RemoveDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveDeadLetter_Call) Return(err error) *Repository_RemoveDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RemoveDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_RemoveDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) RemoveDeadLetters(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeadLetters")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadLetters'
+type Repository_RemoveDeadLetters_Call struct {
+	*mock.Call
+}
+
+// RemoveDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *Repository_Expecter) RemoveDeadLetters(ctx interface{}, before interface{}) *Repository_RemoveDeadLetters_Call {
+	return &Repository_RemoveDeadLetters_Call{Call: _e.mock.On("RemoveDeadLetters", ctx, before)}
+}
+
+func (_c *Repository_RemoveDeadLetters_Call) Run(run func(ctx context.Context, before time.Time)) *Repository_RemoveDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveDeadLetters_Call) Return(err error) *Repository_RemoveDeadLetters_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RemoveDeadLetters_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Repository_RemoveDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveEntityMembers provides a mock function for the type Repository
+func (_mock *Repository) RemoveEntityMembers(ctx context.Context, entityID string, members []string) error {
+	ret := _mock.Called(ctx, entityID, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveEntityMembers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, entityID, members)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveEntityMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveEntityMembers'
+type Repository_RemoveEntityMembers_Call struct {
+	*mock.Call
+}
+
+// RemoveEntityMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - members []string
+func (_e *Repository_Expecter) RemoveEntityMembers(ctx interface{}, entityID interface{}, members interface{}) *Repository_RemoveEntityMembers_Call {
+	return &Repository_RemoveEntityMembers_Call{Call: _e.mock.On("RemoveEntityMembers", ctx, entityID, members)}
+}
+
+func (_c *Repository_RemoveEntityMembers_Call) Run(run func(ctx context.Context, entityID string, members []string)) *Repository_RemoveEntityMembers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -365,9 +788,9 @@ func (_c *Repository_ListUserRules_Call) Run(run func(ctx context.Context, userI
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 re.PageMeta
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(re.PageMeta)
+			arg2 = args[2].([]string)
 		}
 		run(
 			arg0,
@@ -378,75 +801,69 @@ func (_c *Repository_ListUserRules_Call) Run(run func(ctx context.Context, userI
 	return _c
 }
 
-func (_c *Repository_ListUserRules_Call) Return(page re.Page, err error) *Repository_ListUserRules_Call {
-	_c.Call.Return(page, err)
+func (_c *Repository_RemoveEntityMembers_Call) Return(err error) *Repository_RemoveEntityMembers_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_ListUserRules_Call) RunAndReturn(run func(ctx context.Context, userID string, pm re.PageMeta) (re.Page, error)) *Repository_ListUserRules_Call {
+func (_c *Repository_RemoveEntityMembers_Call) RunAndReturn(run func(ctx context.Context, entityID string, members []string) error) *Repository_RemoveEntityMembers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveEntityMembers provides a mock function for the type Repository
-func (_mock *Repository) RemoveEntityMembers(ctx context.Context, entityID string, members []string) error {
-	ret := _mock.Called(ctx, entityID, members)
+// RemoveExecutions provides a mock function for the type Repository
+func (_mock *Repository) RemoveExecutions(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveEntityMembers")
+		panic("no return value specified for RemoveExecutions")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
-		r0 = returnFunc(ctx, entityID, members)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Repository_RemoveEntityMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveEntityMembers'
-type Repository_RemoveEntityMembers_Call struct {
+// Repository_RemoveExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExecutions'
+type Repository_RemoveExecutions_Call struct {
 	*mock.Call
 }
 
-// RemoveEntityMembers is a helper method to define mock.On call
+// RemoveExecutions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - entityID string
-//   - members []string
-func (_e *Repository_Expecter) RemoveEntityMembers(ctx interface{}, entityID interface{}, members interface{}) *Repository_RemoveEntityMembers_Call {
-	return &Repository_RemoveEntityMembers_Call{Call: _e.mock.On("RemoveEntityMembers", ctx, entityID, members)}
+//   - before time.Time
+func (_e *Repository_Expecter) RemoveExecutions(ctx interface{}, before interface{}) *Repository_RemoveExecutions_Call {
+	return &Repository_RemoveExecutions_Call{Call: _e.mock.On("RemoveExecutions", ctx, before)}
 }
 
-func (_c *Repository_RemoveEntityMembers_Call) Run(run func(ctx context.Context, entityID string, members []string)) *Repository_RemoveEntityMembers_Call {
+func (_c *Repository_RemoveExecutions_Call) Run(run func(ctx context.Context, before time.Time)) *Repository_RemoveExecutions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 []string
-		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_RemoveEntityMembers_Call) Return(err error) *Repository_RemoveEntityMembers_Call {
+func (_c *Repository_RemoveExecutions_Call) Return(err error) *Repository_RemoveExecutions_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_RemoveEntityMembers_Call) RunAndReturn(run func(ctx context.Context, entityID string, members []string) error) *Repository_RemoveEntityMembers_Call {
+func (_c *Repository_RemoveExecutions_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Repository_RemoveExecutions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -622,6 +1039,63 @@ func (_c *Repository_RemoveRule_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// RemoveRuleDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) RemoveRuleDeadLetters(ctx context.Context, ruleID string) error {
+	ret := _mock.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRuleDeadLetters")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, ruleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveRuleDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRuleDeadLetters'
+type Repository_RemoveRuleDeadLetters_Call struct {
+	*mock.Call
+}
+
+// RemoveRuleDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *Repository_Expecter) RemoveRuleDeadLetters(ctx interface{}, ruleID interface{}) *Repository_RemoveRuleDeadLetters_Call {
+	return &Repository_RemoveRuleDeadLetters_Call{Call: _e.mock.On("RemoveRuleDeadLetters", ctx, ruleID)}
+}
+
+func (_c *Repository_RemoveRuleDeadLetters_Call) Run(run func(ctx context.Context, ruleID string)) *Repository_RemoveRuleDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveRuleDeadLetters_Call) Return(err error) *Repository_RemoveRuleDeadLetters_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RemoveRuleDeadLetters_Call) RunAndReturn(run func(ctx context.Context, ruleID string) error) *Repository_RemoveRuleDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveAllRoles provides a mock function for the type Repository
 func (_mock *Repository) RetrieveAllRoles(ctx context.Context, entityID string, limit uint64, offset uint64) (roles.RolePage, error) {
 	ret := _mock.Called(ctx, entityID, limit, offset)
@@ -986,6 +1460,72 @@ func (_c *Repository_RetrieveRole_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// RetrieveState provides a mock function for the type Repository
+func (_mock *Repository) RetrieveState(ctx context.Context, ruleID string) (re.State, error) {
+	ret := _mock.Called(ctx, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveState")
+	}
+
+	var r0 re.State
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (re.State, error)); ok {
+		return returnFunc(ctx, ruleID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) re.State); ok {
+		r0 = returnFunc(ctx, ruleID)
+	} else {
+		r0 = ret.Get(0).(re.State)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, ruleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveState'
+type Repository_RetrieveState_Call struct {
+	*mock.Call
+}
+
+// RetrieveState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+func (_e *Repository_Expecter) RetrieveState(ctx interface{}, ruleID interface{}) *Repository_RetrieveState_Call {
+	return &Repository_RetrieveState_Call{Call: _e.mock.On("RetrieveState", ctx, ruleID)}
+}
+
+func (_c *Repository_RetrieveState_Call) Run(run func(ctx context.Context, ruleID string)) *Repository_RetrieveState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveState_Call) Return(state re.State, err error) *Repository_RetrieveState_Call {
+	_c.Call.Return(state, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveState_Call) RunAndReturn(run func(ctx context.Context, ruleID string) (re.State, error)) *Repository_RetrieveState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RoleAddActions provides a mock function for the type Repository
 func (_mock *Repository) RoleAddActions(ctx context.Context, role roles.Role, actions []string) ([]string, error) {
 	ret := _mock.Called(ctx, role, actions)
@@ -1664,6 +2204,120 @@ func (_c *Repository_RoleRemoveMembers_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// SaveState provides a mock function for the type Repository
+func (_mock *Repository) SaveState(ctx context.Context, s re.State) error {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveState")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.State) error); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_SaveState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveState'
+type Repository_SaveState_Call struct {
+	*mock.Call
+}
+
+// SaveState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s re.State
+func (_e *Repository_Expecter) SaveState(ctx interface{}, s interface{}) *Repository_SaveState_Call {
+	return &Repository_SaveState_Call{Call: _e.mock.On("SaveState", ctx, s)}
+}
+
+func (_c *Repository_SaveState_Call) Run(run func(ctx context.Context, s re.State)) *Repository_SaveState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.State
+		if args[1] != nil {
+			arg1 = args[1].(re.State)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SaveState_Call) Return(err error) *Repository_SaveState_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_SaveState_Call) RunAndReturn(run func(ctx context.Context, s re.State) error) *Repository_SaveState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) UpdateDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	ret := _mock.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetter) error); ok {
+		r0 = returnFunc(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_UpdateDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDeadLetter'
+type Repository_UpdateDeadLetter_Call struct {
+	*mock.Call
+}
+
+// UpdateDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl re.DeadLetter
+func (_e *Repository_Expecter) UpdateDeadLetter(ctx interface{}, dl interface{}) *Repository_UpdateDeadLetter_Call {
+	return &Repository_UpdateDeadLetter_Call{Call: _e.mock.On("UpdateDeadLetter", ctx, dl)}
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) Run(run func(ctx context.Context, dl re.DeadLetter)) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetter
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) Return(err error) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dl re.DeadLetter) error) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRole provides a mock function for the type Repository
 func (_mock *Repository) UpdateRole(ctx context.Context, ro roles.Role) (roles.Role, error) {
 	ret := _mock.Called(ctx, ro)
@@ -2066,6 +2720,72 @@ func (_c *Repository_UpdateRuleTags_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ViewDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) ViewDeadLetter(ctx context.Context, id string) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewDeadLetter'
+type Repository_ViewDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ViewDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) ViewDeadLetter(ctx interface{}, id interface{}) *Repository_ViewDeadLetter_Call {
+	return &Repository_ViewDeadLetter_Call{Call: _e.mock.On("ViewDeadLetter", ctx, id)}
+}
+
+func (_c *Repository_ViewDeadLetter_Call) Run(run func(ctx context.Context, id string)) *Repository_ViewDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Repository_ViewDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Repository_ViewDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string) (re.DeadLetter, error)) *Repository_ViewDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewRule provides a mock function for the type Repository
 func (_mock *Repository) ViewRule(ctx context.Context, id string) (re.Rule, error) {
 	ret := _mock.Called(ctx, id)
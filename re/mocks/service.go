@@ -331,6 +331,86 @@ func (_c *Service_DisableRule_Call) RunAndReturn(run func(ctx context.Context, s
 	return _c
 }
 
+// DryRunRule provides a mock function for the type Service
+func (_mock *Service) DryRunRule(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error) {
+	ret := _mock.Called(ctx, session, r, msgs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRunRule")
+	}
+
+	var r0 []re.DryRunResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.Rule, []*messaging.Message) ([]re.DryRunResult, error)); ok {
+		return returnFunc(ctx, session, r, msgs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.Rule, []*messaging.Message) []re.DryRunResult); ok {
+		r0 = returnFunc(ctx, session, r, msgs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.DryRunResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.Rule, []*messaging.Message) error); ok {
+		r1 = returnFunc(ctx, session, r, msgs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_DryRunRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DryRunRule'
+type Service_DryRunRule_Call struct {
+	*mock.Call
+}
+
+// DryRunRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - r re.Rule
+//   - msgs []*messaging.Message
+func (_e *Service_Expecter) DryRunRule(ctx interface{}, session interface{}, r interface{}, msgs interface{}) *Service_DryRunRule_Call {
+	return &Service_DryRunRule_Call{Call: _e.mock.On("DryRunRule", ctx, session, r, msgs)}
+}
+
+func (_c *Service_DryRunRule_Call) Run(run func(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message)) *Service_DryRunRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.Rule
+		if args[2] != nil {
+			arg2 = args[2].(re.Rule)
+		}
+		var arg3 []*messaging.Message
+		if args[3] != nil {
+			arg3 = args[3].([]*messaging.Message)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DryRunRule_Call) Return(dryRunResults []re.DryRunResult, err error) *Service_DryRunRule_Call {
+	_c.Call.Return(dryRunResults, err)
+	return _c
+}
+
+func (_c *Service_DryRunRule_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, r re.Rule, msgs []*messaging.Message) ([]re.DryRunResult, error)) *Service_DryRunRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EnableRule provides a mock function for the type Service
 func (_mock *Service) EnableRule(ctx context.Context, session authn.Session, id string) (re.Rule, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -522,6 +602,78 @@ func (_c *Service_ListAvailableActions_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListDeadLetters provides a mock function for the type Service
+func (_mock *Service) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 re.DeadLetterPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.DeadLetterPageMeta) (re.DeadLetterPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.DeadLetterPageMeta) re.DeadLetterPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetterPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.DeadLetterPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type Service_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm re.DeadLetterPageMeta
+func (_e *Service_Expecter) ListDeadLetters(ctx interface{}, session interface{}, pm interface{}) *Service_ListDeadLetters_Call {
+	return &Service_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, session, pm)}
+}
+
+func (_c *Service_ListDeadLetters_Call) Run(run func(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta)) *Service_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.DeadLetterPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.DeadLetterPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListDeadLetters_Call) Return(deadLetterPage re.DeadLetterPage, err error) *Service_ListDeadLetters_Call {
+	_c.Call.Return(deadLetterPage, err)
+	return _c
+}
+
+func (_c *Service_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error)) *Service_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListEntityMembers provides a mock function for the type Service
 func (_mock *Service) ListEntityMembers(ctx context.Context, session authn.Session, entityID string, pq roles.MembersRolePageQuery) (roles.MembersRolePage, error) {
 	ret := _mock.Called(ctx, session, entityID, pq)
@@ -600,6 +752,78 @@ func (_c *Service_ListEntityMembers_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ListExecutions provides a mock function for the type Service
+func (_mock *Service) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 re.ExecutionPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.ExecutionPageMeta) (re.ExecutionPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.ExecutionPageMeta) re.ExecutionPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(re.ExecutionPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.ExecutionPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type Service_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm re.ExecutionPageMeta
+func (_e *Service_Expecter) ListExecutions(ctx interface{}, session interface{}, pm interface{}) *Service_ListExecutions_Call {
+	return &Service_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, session, pm)}
+}
+
+func (_c *Service_ListExecutions_Call) Run(run func(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta)) *Service_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.ExecutionPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.ExecutionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListExecutions_Call) Return(executionPage re.ExecutionPage, err error) *Service_ListExecutions_Call {
+	_c.Call.Return(executionPage, err)
+	return _c
+}
+
+func (_c *Service_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionPage, error)) *Service_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRules provides a mock function for the type Service
 func (_mock *Service) ListRules(ctx context.Context, session authn.Session, pm re.PageMeta) (re.Page, error) {
 	ret := _mock.Called(ctx, session, pm)
@@ -672,6 +896,69 @@ func (_c *Service_ListRules_Call) RunAndReturn(run func(ctx context.Context, ses
 	return _c
 }
 
+// PurgeDeadLetters provides a mock function for the type Service
+func (_mock *Service) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	ret := _mock.Called(ctx, session, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeadLetters")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, ruleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_PurgeDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeadLetters'
+type Service_PurgeDeadLetters_Call struct {
+	*mock.Call
+}
+
+// PurgeDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+func (_e *Service_Expecter) PurgeDeadLetters(ctx interface{}, session interface{}, ruleID interface{}) *Service_PurgeDeadLetters_Call {
+	return &Service_PurgeDeadLetters_Call{Call: _e.mock.On("PurgeDeadLetters", ctx, session, ruleID)}
+}
+
+func (_c *Service_PurgeDeadLetters_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string)) *Service_PurgeDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_PurgeDeadLetters_Call) Return(err error) *Service_PurgeDeadLetters_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_PurgeDeadLetters_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string) error) *Service_PurgeDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveEntityMembers provides a mock function for the type Service
 func (_mock *Service) RemoveEntityMembers(ctx context.Context, session authn.Session, entityID string, members []string) error {
 	ret := _mock.Called(ctx, session, entityID, members)
@@ -936,6 +1223,75 @@ func (_c *Service_RemoveRule_Call) RunAndReturn(run func(ctx context.Context, se
 	return _c
 }
 
+// ReplayDeadLetter provides a mock function for the type Service
+func (_mock *Service) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID string, id string) error {
+	ret := _mock.Called(ctx, session, ruleID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) error); ok {
+		r0 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_ReplayDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayDeadLetter'
+type Service_ReplayDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ReplayDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - id string
+func (_e *Service_Expecter) ReplayDeadLetter(ctx interface{}, session interface{}, ruleID interface{}, id interface{}) *Service_ReplayDeadLetter_Call {
+	return &Service_ReplayDeadLetter_Call{Call: _e.mock.On("ReplayDeadLetter", ctx, session, ruleID, id)}
+}
+
+func (_c *Service_ReplayDeadLetter_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, id string)) *Service_ReplayDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ReplayDeadLetter_Call) Return(err error) *Service_ReplayDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_ReplayDeadLetter_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, id string) error) *Service_ReplayDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveAllRoles provides a mock function for the type Service
 func (_mock *Service) RetrieveAllRoles(ctx context.Context, session authn.Session, entityID string, limit uint64, offset uint64) (roles.RolePage, error) {
 	ret := _mock.Called(ctx, session, entityID, limit, offset)
@@ -2247,6 +2603,84 @@ func (_c *Service_UpdateRuleTags_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// ViewDeadLetter provides a mock function for the type Service
+func (_mock *Service) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, session, ruleID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, session, ruleID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewDeadLetter'
+type Service_ViewDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ViewDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - id string
+func (_e *Service_Expecter) ViewDeadLetter(ctx interface{}, session interface{}, ruleID interface{}, id interface{}) *Service_ViewDeadLetter_Call {
+	return &Service_ViewDeadLetter_Call{Call: _e.mock.On("ViewDeadLetter", ctx, session, ruleID, id)}
+}
+
+func (_c *Service_ViewDeadLetter_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, id string)) *Service_ViewDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Service_ViewDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Service_ViewDeadLetter_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error)) *Service_ViewDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewRule provides a mock function for the type Service
 func (_mock *Service) ViewRule(ctx context.Context, session authn.Session, id string, withRoles bool) (re.Rule, error) {
 	ret := _mock.Called(ctx, session, id, withRoles)
@@ -18,6 +18,12 @@ const (
 	OpListRules
 	OpEnableRule
 	OpDisableRule
+	OpListDeadLetters
+	OpViewDeadLetter
+	OpReplayDeadLetter
+	OpPurgeDeadLetters
+	OpDryRunRule
+	OpListExecutions
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -58,5 +64,29 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "disable",
 			PermissionRequired: true,
 		},
+		OpListDeadLetters: {
+			Name:               "list_dead_letters",
+			PermissionRequired: true,
+		},
+		OpViewDeadLetter: {
+			Name:               "view_dead_letter",
+			PermissionRequired: true,
+		},
+		OpReplayDeadLetter: {
+			Name:               "replay_dead_letter",
+			PermissionRequired: true,
+		},
+		OpPurgeDeadLetters: {
+			Name:               "purge_dead_letters",
+			PermissionRequired: true,
+		},
+		OpDryRunRule: {
+			Name:               "dry_run",
+			PermissionRequired: true,
+		},
+		OpListExecutions: {
+			Name:               "list_executions",
+			PermissionRequired: true,
+		},
 	}
 }
@@ -19,35 +19,60 @@ type Alarm struct {
 }
 
 func (a *Alarm) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	data, err := json.Marshal(val)
+	alarmsList, err := decodeAlarms(val)
 	if err != nil {
 		return err
 	}
 
+	for _, alarm := range alarmsList {
+		if err := a.processAlarm(ctx, msg, alarm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Alarm) Preview(msg *messaging.Message, val any) (any, error) {
+	alarmsList, err := decodeAlarms(val)
+	if err != nil {
+		return nil, err
+	}
+	for i := range alarmsList {
+		a.fill(msg, &alarmsList[i])
+	}
+
+	return alarmsList, nil
+}
+
+func decodeAlarms(val any) ([]alarms.Alarm, error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
 	var alarmsList []alarms.Alarm
 	if err := json.Unmarshal(data, &alarmsList); err != nil {
 		var single alarms.Alarm
 		if err := json.Unmarshal(data, &single); err != nil {
-			return err
+			return nil, err
 		}
 		alarmsList = []alarms.Alarm{single}
 	}
 
-	for _, alarm := range alarmsList {
-		if err := a.processAlarm(ctx, msg, alarm); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return alarmsList, nil
 }
 
-func (a *Alarm) processAlarm(ctx context.Context, msg *messaging.Message, alarm alarms.Alarm) error {
+func (a *Alarm) fill(msg *messaging.Message, alarm *alarms.Alarm) {
 	alarm.RuleID = a.RuleID
 	alarm.DomainID = msg.Domain
 	alarm.ClientID = msg.ClientIdentity()
 	alarm.ChannelID = msg.Channel
 	alarm.Subtopic = msg.Subtopic
+}
+
+func (a *Alarm) processAlarm(ctx context.Context, msg *messaging.Message, alarm alarms.Alarm) error {
+	a.fill(msg, &alarm)
 
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(alarm); err != nil {
@@ -43,6 +43,19 @@ func (p *ChannelPublisher) Run(ctx context.Context, msg *messaging.Message, val
 	return nil
 }
 
+func (p *ChannelPublisher) Preview(msg *messaging.Message, val any) (any, error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"channel": p.Channel,
+		"topic":   p.Topic,
+		"payload": json.RawMessage(data),
+	}, nil
+}
+
 func (cp *ChannelPublisher) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]string{
 		"type":    ChannelsType.String(),
@@ -4,10 +4,8 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"text/template"
 
 	"github.com/absmach/supermq/pkg/emailer"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -21,27 +19,37 @@ type Email struct {
 }
 
 func (e *Email) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("email").Parse(e.Content)
+	content, err := e.content(msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
+	if err := e.Emailer.SendEmailNotification(e.To, "", e.Subject, "", "", content, "", make(map[string][]byte)); err != nil {
 		return err
 	}
+	return nil
+}
 
-	content := output.String()
+func (e *Email) Preview(msg *messaging.Message, val any) (any, error) {
+	content, err := e.content(msg, val)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := e.Emailer.SendEmailNotification(e.To, "", e.Subject, "", "", content, "", make(map[string][]byte)); err != nil {
-		return err
+	return map[string]any{
+		"to":      e.To,
+		"subject": e.Subject,
+		"content": content,
+	}, nil
+}
+
+func (e *Email) content(msg *messaging.Message, val any) (string, error) {
+	templData := templateVal{
+		Message: msg,
+		Result:  val,
 	}
-	return nil
+
+	return render("email", e.Content, templData)
 }
 
 func (e *Email) MarshalJSON() ([]byte, error) {
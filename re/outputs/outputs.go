@@ -4,8 +4,10 @@
 package outputs
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
+	"text/template"
 
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -16,6 +18,12 @@ type templateVal struct {
 	Result  any
 }
 
+// Previewer renders the data an output would send for the given
+// message and rule result without actually sending it.
+type Previewer interface {
+	Preview(msg *messaging.Message, val any) (any, error)
+}
+
 // OutputType is the indicator for type of the output
 // so we can move it to the Go instead calling Go from Lua.
 type OutputType uint
@@ -27,10 +35,11 @@ const (
 	EmailType
 	SaveRemotePgType
 	SlackType
+	WebhookType
 )
 
 var (
-	scriptKindToString = [...]string{"channels", "alarms", "save_senml", "email", "save_remote_pg", "slack"}
+	scriptKindToString = [...]string{"channels", "alarms", "save_senml", "email", "save_remote_pg", "slack", "webhook"}
 	stringToScriptKind = map[string]OutputType{
 		"channels":       ChannelsType,
 		"alarms":         AlarmsType,
@@ -38,6 +47,7 @@ var (
 		"email":          EmailType,
 		"save_remote_pg": SaveRemotePgType,
 		"slack":          SlackType,
+		"webhook":        WebhookType,
 	}
 )
 
@@ -66,3 +76,17 @@ func (s *OutputType) UnmarshalJSON(data []byte) error {
 	}
 	return errors.New("invalid OutputType: " + str)
 }
+
+func render(name, text string, data templateVal) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, data); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
@@ -4,12 +4,10 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
-	"text/template"
 
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -28,27 +26,11 @@ type Postgres struct {
 }
 
 func (p *Postgres) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("postgres").Parse(p.Mapping)
+	columns, err := p.columns(msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
-		return err
-	}
-
-	mapping := output.String()
-	var columns map[string]any
-	if err = json.Unmarshal([]byte(mapping), &columns); err != nil {
-		return err
-	}
-
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		p.Host, p.Port, p.User, p.Password, p.Database,
@@ -93,6 +75,37 @@ func (p *Postgres) Run(ctx context.Context, msg *messaging.Message, val any) err
 	return nil
 }
 
+func (p *Postgres) Preview(msg *messaging.Message, val any) (any, error) {
+	columns, err := p.columns(msg, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"table":   p.Table,
+		"columns": columns,
+	}, nil
+}
+
+func (p *Postgres) columns(msg *messaging.Message, val any) (map[string]any, error) {
+	templData := templateVal{
+		Message: msg,
+		Result:  val,
+	}
+
+	mapping, err := render("postgres", p.Mapping, templData)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns map[string]any
+	if err := json.Unmarshal([]byte(mapping), &columns); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
 func (p *Postgres) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"type":     SaveRemotePgType.String(),
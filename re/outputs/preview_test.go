@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/senml"
+	"github.com/absmach/supermq/alarms"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/re/outputs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreview(t *testing.T) {
+	msg := &messaging.Message{
+		Domain:   "domain",
+		Channel:  "channel",
+		Subtopic: "room1",
+	}
+	value := 31.5
+
+	cases := []struct {
+		desc    string
+		output  outputs.Previewer
+		result  any
+		preview any
+		err     bool
+	}{
+		{
+			desc:    "preview email",
+			output:  &outputs.Email{To: []string{"admin@example.com"}, Subject: "Alert", Content: "Temperature in {{.Message.Subtopic}} is {{.Result.t}}"},
+			result:  map[string]any{"t": 31.5},
+			preview: map[string]any{"to": []string{"admin@example.com"}, "subject": "Alert", "content": "Temperature in room1 is 31.5"},
+		},
+		{
+			desc:   "preview email with invalid template",
+			output: &outputs.Email{Content: "{{.Result.t"},
+			result: map[string]any{"t": 31.5},
+			err:    true,
+		},
+		{
+			desc:    "preview postgres",
+			output:  &outputs.Postgres{Table: "readings", Password: "secret", Mapping: `{"channel": "{{.Message.Channel}}", "value": {{.Result.t}}}`},
+			result:  map[string]any{"t": 31.5},
+			preview: map[string]any{"table": "readings", "columns": map[string]any{"channel": "channel", "value": 31.5}},
+		},
+		{
+			desc:   "preview postgres with invalid mapping",
+			output: &outputs.Postgres{Mapping: `{"value": }`},
+			result: map[string]any{"t": 31.5},
+			err:    true,
+		},
+		{
+			desc:    "preview channel publisher",
+			output:  &outputs.ChannelPublisher{Channel: "out", Topic: "alerts"},
+			result:  map[string]any{"t": 31.5},
+			preview: map[string]any{"channel": "out", "topic": "alerts", "payload": json.RawMessage(`{"t":31.5}`)},
+		},
+		{
+			desc:   "preview alarm",
+			output: &outputs.Alarm{RuleID: "rule"},
+			result: map[string]any{"measurement": "t", "value": "31.5", "severity": 2},
+			preview: []alarms.Alarm{{
+				RuleID:      "rule",
+				DomainID:    "domain",
+				ChannelID:   "channel",
+				Subtopic:    "room1",
+				Measurement: "t",
+				Value:       "31.5",
+				Severity:    2,
+			}},
+		},
+		{
+			desc:    "preview SenML",
+			output:  &outputs.SenML{},
+			result:  map[string]any{"n": "t", "v": 31.5},
+			preview: []senml.Record{{Name: "t", Value: &value}},
+		},
+		{
+			desc:    "preview webhook",
+			output:  &outputs.Webhook{URL: "http://localhost/{{.Message.Channel}}", Body: `{"t": {{.Result.t}}}`, Secret: "secret"},
+			result:  map[string]any{"t": 31.5},
+			preview: map[string]any{"method": "POST", "url": "http://localhost/channel", "headers": map[string]any{"Content-Type": "application/json"}, "body": `{"t": 31.5}`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			preview, err := tc.output.Preview(msg, tc.result)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			if tc.err {
+				return
+			}
+			// Webhook request is not exported, so compare its JSON representation.
+			if _, ok := tc.output.(*outputs.Webhook); ok {
+				data, err := json.Marshal(preview)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+				var got map[string]any
+				assert.Nil(t, json.Unmarshal(data, &got))
+				preview = got
+			}
+			assert.Equal(t, tc.preview, preview, fmt.Sprintf("%s: unexpected preview", tc.desc))
+		})
+	}
+}
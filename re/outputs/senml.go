@@ -16,17 +16,10 @@ type SenML struct {
 }
 
 func (s *SenML) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	// In case there is a single SenML value, convert to slice so we can decode.
-	if _, ok := val.([]any); !ok {
-		val = []any{val}
-	}
-	data, err := json.Marshal(val)
+	data, _, err := decodeSenML(val)
 	if err != nil {
 		return err
 	}
-	if _, err := senml.Decode(data, senml.JSON); err != nil {
-		return err
-	}
 
 	m := &messaging.Message{
 		Domain:    msg.Domain,
@@ -46,6 +39,32 @@ func (s *SenML) Run(ctx context.Context, msg *messaging.Message, val any) error
 	return nil
 }
 
+func (s *SenML) Preview(msg *messaging.Message, val any) (any, error) {
+	_, pack, err := decodeSenML(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return pack.Records, nil
+}
+
+func decodeSenML(val any) ([]byte, senml.Pack, error) {
+	// In case there is a single SenML value, convert to slice so we can decode.
+	if _, ok := val.([]any); !ok {
+		val = []any{val}
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, senml.Pack{}, err
+	}
+	pack, err := senml.Decode(data, senml.JSON)
+	if err != nil {
+		return nil, senml.Pack{}, err
+	}
+
+	return data, pack, nil
+}
+
 func (senml *SenML) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]string{
 		"type": SaveSenMLType.String(),
@@ -4,10 +4,8 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"text/template"
 
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/slack-go/slack"
@@ -20,28 +18,11 @@ type Slack struct {
 }
 
 func (s *Slack) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("slack").Parse(s.Message)
+	message, err := s.message(msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
-		return err
-	}
-
-	mapping := output.String()
-
-	var message slack.Msg
-	if err := json.Unmarshal([]byte(mapping), &message); err != nil {
-		return err
-	}
-
 	slackClient := slack.New(s.Token)
 
 	var opts []slack.MsgOption
@@ -62,6 +43,37 @@ func (s *Slack) Run(ctx context.Context, msg *messaging.Message, val any) error
 	return nil
 }
 
+func (s *Slack) Preview(msg *messaging.Message, val any) (any, error) {
+	message, err := s.message(msg, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"channel_id": s.ChannelID,
+		"message":    message,
+	}, nil
+}
+
+func (s *Slack) message(msg *messaging.Message, val any) (slack.Msg, error) {
+	templData := templateVal{
+		Message: msg,
+		Result:  val,
+	}
+
+	mapping, err := render("slack", s.Message, templData)
+	if err != nil {
+		return slack.Msg{}, err
+	}
+
+	var message slack.Msg
+	if err := json.Unmarshal([]byte(mapping), &message); err != nil {
+		return slack.Msg{}, err
+	}
+
+	return message, nil
+}
+
 func (s *Slack) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"type":       SlackType.String(),
@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/webhook"
+)
+
+const (
+	defBackoff     = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+	maxRetries     = 10
+	webhookTimeout = 10 * time.Second
+)
+
+var (
+	errWebhookMethod = errors.New("unsupported webhook method")
+	errWebhookURL    = errors.New("missing webhook URL")
+	errWebhookStatus = errors.New("webhook request failed")
+
+	webhookClient = &http.Client{Timeout: webhookTimeout}
+)
+
+// Webhook sends the rule result to an HTTP endpoint. URL, header values and body
+// are Go templates rendered with the incoming message and the script result.
+// Failed requests (network errors, 429 and 5xx responses) are retried with an
+// exponential backoff. If the secret is set, each request is signed using HMAC-SHA256
+// over the timestamp and the body joined with a dot.
+type Webhook struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+	Retries uint              `json:"retries,omitempty"`
+	Backoff time.Duration     `json:"backoff,omitempty"`
+	Secret  string            `json:"secret,omitempty"`
+}
+
+func (w *Webhook) Run(ctx context.Context, msg *messaging.Message, val any) error {
+	req, err := w.request(msg, val)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.Backoff
+	if backoff <= 0 {
+		backoff = defBackoff
+	}
+	retries := min(w.Retries, maxRetries)
+	for attempt := uint(0); ; attempt++ {
+		retry, err := w.send(ctx, req)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (w *Webhook) Preview(msg *messaging.Message, val any) (any, error) {
+	return w.request(msg, val)
+}
+
+// webhookRequest is a webhook request with all the templates rendered.
+type webhookRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+func (w *Webhook) request(msg *messaging.Message, val any) (webhookRequest, error) {
+	method := strings.ToUpper(w.Method)
+	switch method {
+	case "":
+		method = http.MethodPost
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return webhookRequest{}, errors.Wrap(errWebhookMethod, errors.New(w.Method))
+	}
+	if w.URL == "" {
+		return webhookRequest{}, errWebhookURL
+	}
+
+	templData := templateVal{
+		Message: msg,
+		Result:  val,
+	}
+	url, err := render("webhook_url", w.URL, templData)
+	if err != nil {
+		return webhookRequest{}, err
+	}
+	body, err := render("webhook_body", w.Body, templData)
+	if err != nil {
+		return webhookRequest{}, err
+	}
+	headers := make(map[string]string, len(w.Headers)+1)
+	for k, v := range w.Headers {
+		if headers[http.CanonicalHeaderKey(k)], err = render("webhook_header", v, templData); err != nil {
+			return webhookRequest{}, err
+		}
+	}
+	if _, ok := headers["Content-Type"]; !ok && body != "" {
+		headers["Content-Type"] = "application/json"
+	}
+
+	return webhookRequest{Method: method, URL: url, Headers: headers, Body: body}, nil
+}
+
+// send executes a single webhook request and reports whether it can be retried.
+func (w *Webhook) send(ctx context.Context, wr webhookRequest) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, wr.Method, wr.URL, strings.NewReader(wr.Body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range wr.Headers {
+		req.Header.Set(k, v)
+	}
+	if w.Secret != "" {
+		webhook.SignRequest(req, w.Secret, []byte(wr.Body))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+	err = errors.Wrap(errWebhookStatus, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+	return retry, err
+}
+
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	type alias Webhook
+	aux := struct {
+		Backoff string `json:"backoff,omitempty"`
+		*alias
+	}{
+		alias: (*alias)(w),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	w.Backoff = 0
+	if aux.Backoff != "" {
+		d, err := time.ParseDuration(aux.Backoff)
+		if err != nil {
+			return err
+		}
+		w.Backoff = d
+	}
+	return nil
+}
+
+// MarshalJSON leaves the secret out, so it is not returned by the API.
+func (w *Webhook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.encode())
+}
+
+// MarshalSecret encodes the webhook together with its secret, for the repository.
+func (w *Webhook) MarshalSecret() ([]byte, error) {
+	m := w.encode()
+	if w.Secret != "" {
+		m["secret"] = w.Secret
+	}
+	return json.Marshal(m)
+}
+
+func (w *Webhook) encode() map[string]any {
+	m := map[string]any{
+		"type":    WebhookType.String(),
+		"method":  w.Method,
+		"url":     w.URL,
+		"headers": w.Headers,
+		"body":    w.Body,
+		"retries": w.Retries,
+	}
+	if w.Backoff > 0 {
+		m["backoff"] = w.Backoff.String()
+	}
+	return m
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/webhook"
+	"github.com/absmach/supermq/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookReq struct {
+	method  string
+	path    string
+	body    string
+	headers http.Header
+}
+
+func TestWebhookRun(t *testing.T) {
+	msg := &messaging.Message{
+		Domain:  "domain",
+		Channel: "channel",
+	}
+	result := map[string]any{"temperature": 31.5}
+
+	cases := []struct {
+		desc     string
+		webhook  outputs.Webhook
+		statuses []int
+		attempts int32
+		path     string
+		body     string
+		headers  map[string]string
+		err      bool
+	}{
+		{
+			desc: "send webhook successfully",
+			webhook: outputs.Webhook{
+				Method:  http.MethodPut,
+				URL:     "/{{.Message.Channel}}",
+				Headers: map[string]string{"X-Domain": "{{.Message.Domain}}"},
+				Body:    `{"t": {{.Result.temperature}}}`,
+			},
+			statuses: []int{http.StatusOK},
+			attempts: 1,
+			path:     "/channel",
+			body:     `{"t": 31.5}`,
+			headers:  map[string]string{"X-Domain": "domain", "Content-Type": "application/json"},
+		},
+		{
+			desc: "send signed webhook successfully",
+			webhook: outputs.Webhook{
+				URL:    "/",
+				Body:   `{"t": {{.Result.temperature}}}`,
+				Secret: "secret",
+			},
+			statuses: []int{http.StatusNoContent},
+			attempts: 1,
+			path:     "/",
+			body:     `{"t": 31.5}`,
+		},
+		{
+			desc: "send webhook after retries",
+			webhook: outputs.Webhook{
+				URL:     "/",
+				Body:    "ok",
+				Retries: 3,
+				Backoff: time.Millisecond,
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			attempts: 3,
+			path:     "/",
+			body:     "ok",
+		},
+		{
+			desc: "send webhook with exhausted retries",
+			webhook: outputs.Webhook{
+				URL:     "/",
+				Retries: 2,
+				Backoff: time.Millisecond,
+			},
+			statuses: []int{http.StatusInternalServerError},
+			attempts: 3,
+			path:     "/",
+			err:      true,
+		},
+		{
+			desc: "send webhook with client error is not retried",
+			webhook: outputs.Webhook{
+				URL:     "/",
+				Retries: 2,
+				Backoff: time.Millisecond,
+			},
+			statuses: []int{http.StatusBadRequest},
+			attempts: 1,
+			path:     "/",
+			err:      true,
+		},
+		{
+			desc: "send webhook with invalid method",
+			webhook: outputs.Webhook{
+				Method: "CONNECT",
+				URL:    "/",
+			},
+			attempts: 0,
+			err:      true,
+		},
+		{
+			desc: "send webhook with invalid body template",
+			webhook: outputs.Webhook{
+				URL:  "/",
+				Body: "{{.Result.temperature",
+			},
+			attempts: 0,
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var attempts atomic.Int32
+			var last webhookReq
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				last = webhookReq{method: r.Method, path: r.URL.Path, body: string(body), headers: r.Header}
+				status := tc.statuses[min(int(n), len(tc.statuses))-1]
+				w.WriteHeader(status)
+			}))
+			defer ts.Close()
+
+			wh := tc.webhook
+			wh.URL = ts.URL + wh.URL
+			err := wh.Run(context.Background(), msg, result)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			assert.Equal(t, tc.attempts, attempts.Load(), fmt.Sprintf("%s: unexpected number of attempts", tc.desc))
+			if tc.attempts == 0 || tc.err {
+				return
+			}
+			method := tc.webhook.Method
+			if method == "" {
+				method = http.MethodPost
+			}
+			assert.Equal(t, method, last.method)
+			assert.Equal(t, tc.path, last.path)
+			assert.Equal(t, tc.body, last.body)
+			for k, v := range tc.headers {
+				assert.Equal(t, v, last.headers.Get(k), fmt.Sprintf("%s: unexpected header %s", tc.desc, k))
+			}
+			if tc.webhook.Secret != "" {
+				ts := last.headers.Get(webhook.SignatureTimestampHeader)
+				sig := webhook.Sign(tc.webhook.Secret, ts, []byte(tc.body))
+				assert.Equal(t, "sha256="+sig, last.headers.Get(webhook.SignatureHeader))
+			}
+		})
+	}
+}
+
+func TestWebhookJSON(t *testing.T) {
+	data := `{"type":"webhook","method":"POST","url":"http://localhost/hook","headers":{"X-Key":"value"},"body":"{}","retries":3,"backoff":"2s","secret":"secret"}`
+
+	var wh outputs.Webhook
+	err := json.Unmarshal([]byte(data), &wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.Equal(t, 2*time.Second, wh.Backoff)
+	assert.Equal(t, uint(3), wh.Retries)
+
+	assert.Equal(t, "secret", wh.Secret)
+
+	out, err := json.Marshal(&wh)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.JSONEq(t, strings.Replace(data, `,"secret":"secret"`, "", 1), string(out))
+
+	out, err = wh.MarshalSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %v", err))
+	assert.JSONEq(t, data, string(out))
+
+	err = json.Unmarshal([]byte(strings.Replace(data, `"2s"`, `"two seconds"`, 1)), &wh)
+	assert.NotNil(t, err, "expected invalid backoff error")
+}
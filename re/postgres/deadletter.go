@@ -0,0 +1,233 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/re"
+)
+
+type dbDeadLetter struct {
+	ID        string       `db:"id"`
+	RuleID    string       `db:"rule_id"`
+	DomainID  string       `db:"domain_id"`
+	Output    []byte       `db:"output"`
+	Message   []byte       `db:"message"`
+	Result    []byte       `db:"result"`
+	Error     string       `db:"error"`
+	Attempts  uint         `db:"attempts"`
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
+
+func (repo *PostgresRepository) AddDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	q := `
+		INSERT INTO rules_dead_letters (id, rule_id, domain_id, output, message, result, error, attempts, created_at, updated_at)
+		VALUES (:id, :rule_id, :domain_id, :output, :message, :result, :error, :attempts, :created_at, :updated_at);
+	`
+	dbdl, err := deadLetterToDb(dl)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbdl); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ViewDeadLetter(ctx context.Context, id string) (re.DeadLetter, error) {
+	q := `
+		SELECT id, rule_id, domain_id, output, message, result, error, attempts, created_at, updated_at
+		FROM rules_dead_letters
+		WHERE id = $1;
+	`
+	rows, err := repo.DB.QueryxContext(ctx, q, id)
+	if err != nil {
+		return re.DeadLetter{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.DeadLetter{}, repoerr.ErrNotFound
+	}
+	var dbdl dbDeadLetter
+	if err := rows.StructScan(&dbdl); err != nil {
+		return re.DeadLetter{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return dbToDeadLetter(dbdl)
+}
+
+func (repo *PostgresRepository) UpdateDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	q := `
+		UPDATE rules_dead_letters
+		SET error = :error, attempts = :attempts, updated_at = :updated_at
+		WHERE id = :id;
+	`
+	dbdl, err := deadLetterToDb(dl)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	result, err := repo.DB.NamedExecContext(ctx, q, dbdl)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ListDeadLetters(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLetterPage, error) {
+	pq := "WHERE rule_id = :rule_id"
+	if pm.Domain != "" {
+		pq += " AND domain_id = :domain_id"
+	}
+	pgData := ""
+	if pm.Limit != 0 {
+		pgData = "LIMIT :limit"
+	}
+	if pm.Offset != 0 {
+		pgData += " OFFSET :offset"
+	}
+
+	q := `
+		SELECT id, rule_id, domain_id, output, message, result, error, attempts, created_at, updated_at
+		FROM rules_dead_letters ` + pq + ` ORDER BY created_at DESC, id DESC ` + pgData + `;`
+	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return re.DeadLetterPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dls := []re.DeadLetter{}
+	for rows.Next() {
+		var dbdl dbDeadLetter
+		if err := rows.StructScan(&dbdl); err != nil {
+			return re.DeadLetterPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		dl, err := dbToDeadLetter(dbdl)
+		if err != nil {
+			return re.DeadLetterPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		dls = append(dls, dl)
+	}
+
+	cq := `SELECT COUNT(*) FROM rules_dead_letters ` + pq + `;`
+	total, err := postgres.Total(ctx, repo.DB, cq, pm)
+	if err != nil {
+		return re.DeadLetterPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.DeadLetterPage{
+		Total:       total,
+		Offset:      pm.Offset,
+		Limit:       pm.Limit,
+		DeadLetters: dls,
+	}, nil
+}
+
+func (repo *PostgresRepository) RemoveDeadLetter(ctx context.Context, id string) error {
+	q := `DELETE FROM rules_dead_letters WHERE id = $1;`
+	result, err := repo.DB.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) RemoveRuleDeadLetters(ctx context.Context, ruleID string) error {
+	q := `DELETE FROM rules_dead_letters WHERE rule_id = $1;`
+	if _, err := repo.DB.ExecContext(ctx, q, ruleID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) RemoveDeadLetters(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM rules_dead_letters WHERE COALESCE(updated_at, created_at) < $1;`
+	if _, err := repo.DB.ExecContext(ctx, q, before); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func deadLetterToDb(dl re.DeadLetter) (dbDeadLetter, error) {
+	output, err := re.MarshalOutput(dl.Output)
+	if err != nil {
+		return dbDeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	msg, err := json.Marshal(dl.Message)
+	if err != nil {
+		return dbDeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	result, err := json.Marshal(dl.Result)
+	if err != nil {
+		return dbDeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	updatedAt := sql.NullTime{Time: dl.UpdatedAt}
+	if !dl.UpdatedAt.IsZero() {
+		updatedAt.Valid = true
+	}
+
+	return dbDeadLetter{
+		ID:        dl.ID,
+		RuleID:    dl.RuleID,
+		DomainID:  dl.DomainID,
+		Output:    output,
+		Message:   msg,
+		Result:    result,
+		Error:     dl.Error,
+		Attempts:  dl.Attempts,
+		CreatedAt: dl.CreatedAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func dbToDeadLetter(dbdl dbDeadLetter) (re.DeadLetter, error) {
+	dl := re.DeadLetter{
+		ID:        dbdl.ID,
+		RuleID:    dbdl.RuleID,
+		DomainID:  dbdl.DomainID,
+		Error:     dbdl.Error,
+		Attempts:  dbdl.Attempts,
+		CreatedAt: dbdl.CreatedAt,
+		UpdatedAt: dbdl.UpdatedAt.Time,
+	}
+	output, err := re.UnmarshalOutput(dbdl.Output)
+	if err != nil {
+		return re.DeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	dl.Output = output
+	if dbdl.Message != nil {
+		var msg messaging.Message
+		if err := json.Unmarshal(dbdl.Message, &msg); err != nil {
+			return re.DeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		dl.Message = &msg
+	}
+	if dbdl.Result != nil {
+		if err := json.Unmarshal(dbdl.Result, &dl.Result); err != nil {
+			return re.DeadLetter{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return dl, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/re"
+	"github.com/jackc/pgtype"
+)
+
+type dbExecution struct {
+	ID        string           `db:"id"`
+	RuleID    string           `db:"rule_id"`
+	DomainID  string           `db:"domain_id"`
+	MessageID sql.NullString   `db:"message_id"`
+	Level     int              `db:"level"`
+	Message   string           `db:"message"`
+	Errors    pgtype.TextArray `db:"errors"`
+	StartedAt time.Time        `db:"started_at"`
+	Duration  int64            `db:"duration"`
+	LastError sql.NullString   `db:"last_error"`
+}
+
+func (repo *PostgresRepository) AddExecution(ctx context.Context, e re.Execution) error {
+	// The rule summary is updated in the same statement, so the last run
+	// of the rule always matches the latest stored execution.
+	q := `
+		WITH execution AS (
+			INSERT INTO rules_executions (id, rule_id, domain_id, message_id, level, message, errors, started_at, duration)
+			VALUES (:id, :rule_id, :domain_id, :message_id, :level, :message, :errors, :started_at, :duration)
+			RETURNING rule_id, started_at
+		)
+		UPDATE rules r SET last_run_at = e.started_at, last_error = :last_error
+		FROM execution e
+		WHERE r.id = e.rule_id;
+	`
+	dbe, err := executionToDb(e)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbe); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ListExecutions(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionPage, error) {
+	pq := "WHERE rule_id = :rule_id"
+	if pm.Domain != "" {
+		pq += " AND domain_id = :domain_id"
+	}
+	if pm.Level != nil {
+		pq += " AND level >= :level"
+	}
+	pgData := ""
+	if pm.Limit != 0 {
+		pgData = "LIMIT :limit"
+	}
+	if pm.Offset != 0 {
+		pgData += " OFFSET :offset"
+	}
+
+	q := `
+		SELECT id, rule_id, domain_id, message_id, level, message, errors, started_at, duration
+		FROM rules_executions ` + pq + ` ORDER BY started_at DESC, id DESC ` + pgData + `;`
+	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return re.ExecutionPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	execs := []re.Execution{}
+	for rows.Next() {
+		var dbe dbExecution
+		if err := rows.StructScan(&dbe); err != nil {
+			return re.ExecutionPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		execs = append(execs, dbToExecution(dbe))
+	}
+
+	cq := `SELECT COUNT(*) FROM rules_executions ` + pq + `;`
+	total, err := postgres.Total(ctx, repo.DB, cq, pm)
+	if err != nil {
+		return re.ExecutionPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.ExecutionPage{
+		Total:      total,
+		Offset:     pm.Offset,
+		Limit:      pm.Limit,
+		Executions: execs,
+	}, nil
+}
+
+func (repo *PostgresRepository) RemoveExecutions(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM rules_executions WHERE started_at < $1;`
+	if _, err := repo.DB.ExecContext(ctx, q, before); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func executionToDb(e re.Execution) (dbExecution, error) {
+	var errs pgtype.TextArray
+	if err := errs.Set(e.Errors); err != nil {
+		return dbExecution{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	// Last error of the rule is cleared as soon as the rule runs successfully.
+	lastError := sql.NullString{}
+	if e.Level >= slog.LevelError {
+		lastError = sql.NullString{String: e.Message, Valid: true}
+	}
+
+	return dbExecution{
+		ID:        e.ID,
+		RuleID:    e.RuleID,
+		DomainID:  e.DomainID,
+		MessageID: toNullString(e.MessageID),
+		Level:     int(e.Level),
+		Message:   e.Message,
+		Errors:    errs,
+		StartedAt: e.StartedAt,
+		Duration:  int64(e.Duration),
+		LastError: lastError,
+	}, nil
+}
+
+func dbToExecution(dbe dbExecution) re.Execution {
+	var errs []string
+	for _, e := range dbe.Errors.Elements {
+		errs = append(errs, e.String)
+	}
+
+	return re.Execution{
+		ID:        dbe.ID,
+		RuleID:    dbe.RuleID,
+		DomainID:  dbe.DomainID,
+		MessageID: fromNullString(dbe.MessageID),
+		Level:     slog.Level(dbe.Level),
+		Message:   dbe.Message,
+		Errors:    errs,
+		StartedAt: dbe.StartedAt,
+		Duration:  time.Duration(dbe.Duration),
+	}
+}
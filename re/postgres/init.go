@@ -135,6 +135,76 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 						WHERE jsonb_typeof(r.outputs) = 'array'`,
 				},
 			},
+			{
+				Id: "rules_06",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN state_window JSONB;`,
+					`CREATE TABLE IF NOT EXISTS rules_state (
+						rule_id     VARCHAR(36) PRIMARY KEY REFERENCES rules (id) ON DELETE CASCADE,
+						samples     JSONB,
+						vals        JSONB,
+						updated_at  TIMESTAMP
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules_state`,
+					`ALTER TABLE rules DROP COLUMN state_window;`,
+				},
+			},
+			{
+				Id: "rules_07",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN retry_policy JSONB;`,
+					`CREATE TABLE IF NOT EXISTS rules_dead_letters (
+						id          VARCHAR(36) PRIMARY KEY,
+						rule_id     VARCHAR(36) NOT NULL REFERENCES rules (id) ON DELETE CASCADE,
+						domain_id   VARCHAR(36) NOT NULL,
+						output      JSONB NOT NULL,
+						message     JSONB,
+						result      JSONB,
+						error       TEXT,
+						attempts    INTEGER NOT NULL DEFAULT 0,
+						created_at  TIMESTAMP,
+						updated_at  TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rules_dead_letters_rule_id ON rules_dead_letters (rule_id, created_at DESC)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules_dead_letters`,
+					`ALTER TABLE rules DROP COLUMN retry_policy;`,
+				},
+			},
+			{
+				Id: "rules_08",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN last_run_at TIMESTAMP, ADD COLUMN last_error TEXT;`,
+					`CREATE TABLE IF NOT EXISTS rules_executions (
+						id          VARCHAR(36) PRIMARY KEY,
+						rule_id     VARCHAR(36) NOT NULL REFERENCES rules (id) ON DELETE CASCADE,
+						domain_id   VARCHAR(36) NOT NULL,
+						message_id  VARCHAR(64),
+						level       SMALLINT NOT NULL,
+						message     TEXT,
+						errors      TEXT[],
+						started_at  TIMESTAMP NOT NULL,
+						duration    BIGINT NOT NULL DEFAULT 0
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rules_executions_rule_id ON rules_executions (rule_id, started_at DESC)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules_executions`,
+					`ALTER TABLE rules DROP COLUMN last_run_at, DROP COLUMN last_error;`,
+				},
+			},
+			{
+				Id: "rules_09",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS idx_rules_executions_started_at ON rules_executions (started_at)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_rules_executions_started_at`,
+				},
+			},
 		},
 	}
 
@@ -41,11 +41,11 @@ func NewRepository(db postgres.Database) re.Repository {
 func (repo *PostgresRepository) AddRule(ctx context.Context, r re.Rule) (re.Rule, error) {
 	q := `
 	INSERT INTO rules (id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status)
+		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy)
 	VALUES (:id, :name, :domain_id, :tags, :metadata, :input_channel, :input_topic, :logic_type, :logic_value,
-		:outputs, :start_datetime, :time, :recurring, :recurring_period, :created_at, :created_by, :updated_at, :updated_by, :status)
+		:outputs, :start_datetime, :time, :recurring, :recurring_period, :created_at, :created_by, :updated_at, :updated_by, :status, :state_window, :retry_policy)
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;
 `
 	dbr, err := ruleToDb(r)
 	if err != nil {
@@ -75,7 +75,7 @@ func (repo *PostgresRepository) AddRule(ctx context.Context, r re.Rule) (re.Rule
 func (repo *PostgresRepository) ViewRule(ctx context.Context, id string) (re.Rule, error) {
 	q := `
 		SELECT id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value, outputs,
-			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error
 		FROM rules
 		WHERE id = $1;
 	`
@@ -215,6 +215,10 @@ func (repo *PostgresRepository) RetrieveByIDWithRoles(ctx context.Context, id, m
 		r2.created_by,
 		r2.updated_at,
 		r2.updated_by,
+		r2.state_window,
+		r2.retry_policy,
+		r2.last_run_at,
+		r2.last_error,
 		fr.member_id,
 		fr.roles
 	FROM rules r2
@@ -251,7 +255,7 @@ func (repo *PostgresRepository) UpdateRuleStatus(ctx context.Context, r re.Rule)
 	SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 	WHERE id = :id
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;`
+			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;`
 
 	return repo.update(ctx, r, q)
 }
@@ -274,6 +278,12 @@ func (repo *PostgresRepository) UpdateRule(ctx context.Context, r re.Rule) (re.R
 		query = append(query, "logic_type = :logic_type,")
 		query = append(query, "logic_value = :logic_value,")
 	}
+	if r.Window != nil {
+		query = append(query, "state_window = :state_window,")
+	}
+	if r.RetryPolicy != nil {
+		query = append(query, "retry_policy = :retry_policy,")
+	}
 
 	if len(query) > 0 {
 		upq = strings.Join(query, " ")
@@ -283,7 +293,7 @@ func (repo *PostgresRepository) UpdateRule(ctx context.Context, r re.Rule) (re.R
 		UPDATE rules
 		SET %s updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;
 	`, upq)
 
 	return repo.update(ctx, r, q)
@@ -293,7 +303,7 @@ func (repo *PostgresRepository) UpdateRuleTags(ctx context.Context, r re.Rule) (
 	q := `UPDATE rules SET tags = :tags, updated_at = :updated_at, updated_by = :updated_by
 	WHERE id = :id AND status = :status
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;`
+		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;`
 	r.Status = re.EnabledStatus
 
 	return repo.update(ctx, r, q)
@@ -305,7 +315,7 @@ func (repo *PostgresRepository) UpdateRuleSchedule(ctx context.Context, r re.Rul
 		SET start_datetime = :start_datetime, time = :time, recurring = :recurring,
 			recurring_period = :recurring_period, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;
 	`
 	return repo.update(ctx, r, q)
 }
@@ -364,7 +374,7 @@ func (repo *PostgresRepository) ListAllRules(ctx context.Context, pm re.PageMeta
 
 	q := fmt.Sprintf(`
 		SELECT id, name, domain_id, tags, input_channel, input_topic, logic_type, logic_value, outputs,
-			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error
 		FROM rules r %s %s %s;
 	`, pq, orderClause, pgData)
 	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
@@ -431,7 +441,7 @@ func (repo *PostgresRepository) ListUserRules(ctx context.Context, userID string
 
 	innerQ := fmt.Sprintf(`
 		SELECT DISTINCT r.id, r.name, r.domain_id, r.tags, r.input_channel, r.input_topic, r.logic_type, r.logic_value, r.outputs,
-			r.start_datetime, r.time, r.recurring, r.recurring_period, r.created_at, r.created_by, r.updated_at, r.updated_by, r.status
+			r.start_datetime, r.time, r.recurring, r.recurring_period, r.created_at, r.created_by, r.updated_at, r.updated_by, r.status, r.state_window, r.retry_policy, r.last_run_at, r.last_error
 		FROM rules r
 		%s
 	`, whereClause)
@@ -478,7 +488,7 @@ func (repo *PostgresRepository) UpdateRuleDue(ctx context.Context, id string, du
 		UPDATE rules
 		SET time = :time, updated_at = :updated_at WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, state_window, retry_policy, last_run_at, last_error;
 	`
 	dbr := dbRule{
 		ID:        id,
@@ -6,6 +6,7 @@ package postgres_test
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/0x6flab/namegenerator"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/schedule"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/absmach/supermq/re"
@@ -1214,3 +1216,273 @@ func generateUUID(t *testing.T) string {
 	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	return ulid
 }
+
+func TestSaveAndRetrieveState(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	window := &re.Window{Type: re.SlidingWindow, Duration: 5 * time.Minute}
+	rule := re.Rule{
+		ID:           generateUUID(t),
+		Name:         namegen.Generate(),
+		DomainID:     generateUUID(t),
+		InputChannel: generateUUID(t),
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: `state.push("t", message.payload.t) return state.avg("t") > 30`,
+		},
+		Window:    window,
+		Status:    re.EnabledStatus,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+	}
+	saved, err := repo.AddRule(context.Background(), rule)
+	assert.Nil(t, err, fmt.Sprintf("add rule unexpected error: %s", err))
+	assert.Equal(t, window, saved.Window)
+
+	sampleTime := time.Now().UTC().Truncate(time.Microsecond)
+	state := re.State{
+		RuleID:    rule.ID,
+		Window:    *window,
+		Samples:   map[string][]re.Sample{"t": {{Value: 31.5, Time: sampleTime}}},
+		Values:    map[string]any{"alarm": true},
+		UpdatedAt: sampleTime,
+	}
+
+	cases := []struct {
+		desc    string
+		ruleID  string
+		save    bool
+		state   re.State
+		saveErr error
+		err     error
+	}{
+		{
+			desc:   "retrieve state that was never saved",
+			ruleID: rule.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "save and retrieve state",
+			ruleID: rule.ID,
+			save:   true,
+			state:  state,
+		},
+		{
+			desc:   "save and retrieve updated state",
+			ruleID: rule.ID,
+			save:   true,
+			state: re.State{
+				RuleID:    rule.ID,
+				Window:    *window,
+				Samples:   map[string][]re.Sample{"t": {{Value: 31.5, Time: sampleTime}, {Value: 29, Time: sampleTime}}},
+				Values:    map[string]any{"alarm": false},
+				UpdatedAt: sampleTime,
+			},
+		},
+		{
+			desc:    "save state of non-existing rule",
+			ruleID:  generateUUID(t),
+			save:    true,
+			state:   re.State{RuleID: generateUUID(t)},
+			saveErr: repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.save {
+				err := repo.SaveState(context.Background(), tc.state)
+				assert.True(t, errors.Contains(err, tc.saveErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.saveErr, err))
+				if tc.saveErr != nil {
+					return
+				}
+			}
+			st, err := repo.RetrieveState(context.Background(), tc.ruleID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.state, st)
+			}
+		})
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	retryPolicy := &re.RetryPolicy{MaxAttempts: 3, Backoff: time.Second}
+	rule := re.Rule{
+		ID:           generateUUID(t),
+		Name:         namegen.Generate(),
+		DomainID:     generateUUID(t),
+		InputChannel: generateUUID(t),
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: `return message.payload`,
+		},
+		RetryPolicy: retryPolicy,
+		Status:      re.EnabledStatus,
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy:   generateUUID(t),
+	}
+	saved, err := repo.AddRule(context.Background(), rule)
+	assert.Nil(t, err, fmt.Sprintf("add rule unexpected error: %s", err))
+	assert.Equal(t, retryPolicy, saved.RetryPolicy)
+
+	var dls []re.DeadLetter
+	for i := range 5 {
+		dl := re.DeadLetter{
+			ID:        generateUUID(t),
+			RuleID:    rule.ID,
+			DomainID:  rule.DomainID,
+			Output:    &outputs.ChannelPublisher{Channel: "output.channel", Topic: "output.topic"},
+			Message:   &messaging.Message{Domain: rule.DomainID, Channel: rule.InputChannel, Payload: []byte(`{"t":1}`)},
+			Result:    map[string]any{"t": float64(i)},
+			Error:     "failed to publish",
+			Attempts:  3,
+			CreatedAt: time.Now().UTC().Add(time.Duration(i) * time.Second).Truncate(time.Microsecond),
+		}
+		err := repo.AddDeadLetter(context.Background(), dl)
+		assert.Nil(t, err, fmt.Sprintf("add dead letter unexpected error: %s", err))
+		dls = append([]re.DeadLetter{dl}, dls...)
+	}
+
+	err = repo.AddDeadLetter(context.Background(), re.DeadLetter{ID: generateUUID(t), RuleID: generateUUID(t), Output: &outputs.ChannelPublisher{}})
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("add dead letter of non-existing rule: expected %s got %s\n", repoerr.ErrCreateEntity, err))
+
+	page, err := repo.ListDeadLetters(context.Background(), re.DeadLetterPageMeta{RuleID: rule.ID, Domain: rule.DomainID, Offset: 1, Limit: 2})
+	assert.Nil(t, err, fmt.Sprintf("list dead letters unexpected error: %s", err))
+	assert.Equal(t, uint64(5), page.Total)
+	assert.Equal(t, dls[1:3], page.DeadLetters)
+
+	dl, err := repo.ViewDeadLetter(context.Background(), dls[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("view dead letter unexpected error: %s", err))
+	assert.Equal(t, dls[0], dl)
+
+	_, err = repo.ViewDeadLetter(context.Background(), generateUUID(t))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("view non-existing dead letter: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	dl.Attempts++
+	dl.Error = "failed to replay"
+	dl.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	err = repo.UpdateDeadLetter(context.Background(), dl)
+	assert.Nil(t, err, fmt.Sprintf("update dead letter unexpected error: %s", err))
+	updated, err := repo.ViewDeadLetter(context.Background(), dl.ID)
+	assert.Nil(t, err, fmt.Sprintf("view dead letter unexpected error: %s", err))
+	assert.Equal(t, dl, updated)
+
+	err = repo.RemoveDeadLetter(context.Background(), dl.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove dead letter unexpected error: %s", err))
+	err = repo.RemoveDeadLetter(context.Background(), dl.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("remove removed dead letter: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveDeadLetters(context.Background(), dls[2].CreatedAt)
+	assert.Nil(t, err, fmt.Sprintf("remove expired dead letters unexpected error: %s", err))
+	page, err = repo.ListDeadLetters(context.Background(), re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list dead letters unexpected error: %s", err))
+	assert.Equal(t, dls[1:3], page.DeadLetters)
+
+	err = repo.RemoveRuleDeadLetters(context.Background(), rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("purge dead letters unexpected error: %s", err))
+	page, err = repo.ListDeadLetters(context.Background(), re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list dead letters unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total)
+	assert.Empty(t, page.DeadLetters)
+}
+
+func TestExecutions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	rule := re.Rule{
+		ID:           generateUUID(t),
+		Name:         namegen.Generate(),
+		DomainID:     generateUUID(t),
+		InputChannel: generateUUID(t),
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: `return message.payload`,
+		},
+		Status:    re.EnabledStatus,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+	}
+	_, err := repo.AddRule(context.Background(), rule)
+	assert.Nil(t, err, fmt.Sprintf("add rule unexpected error: %s", err))
+
+	var execs []re.Execution
+	for i := range 5 {
+		e := re.Execution{
+			ID:        generateUUID(t),
+			RuleID:    rule.ID,
+			DomainID:  rule.DomainID,
+			MessageID: fmt.Sprintf("message-%d", i),
+			Level:     slog.LevelInfo,
+			Message:   "rule processed successfully",
+			StartedAt: time.Now().UTC().Add(time.Duration(i) * time.Second).Truncate(time.Microsecond),
+			Duration:  time.Duration(i) * time.Millisecond,
+		}
+		if i%2 == 1 {
+			e.Level = slog.LevelError
+			e.Message = "failed to run rule outputs"
+			e.Errors = []string{"failed to publish"}
+		}
+		err := repo.AddExecution(context.Background(), e)
+		assert.Nil(t, err, fmt.Sprintf("add execution unexpected error: %s", err))
+		execs = append([]re.Execution{e}, execs...)
+	}
+
+	err = repo.AddExecution(context.Background(), re.Execution{ID: generateUUID(t), RuleID: generateUUID(t), StartedAt: time.Now().UTC()})
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("add execution of non-existing rule: expected %s got %s\n", repoerr.ErrCreateEntity, err))
+
+	page, err := repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: rule.ID, Domain: rule.DomainID, Offset: 1, Limit: 2})
+	assert.Nil(t, err, fmt.Sprintf("list executions unexpected error: %s", err))
+	assert.Equal(t, uint64(5), page.Total)
+	assert.Equal(t, execs[1:3], page.Executions)
+
+	level := slog.LevelError
+	page, err = repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: rule.ID, Level: &level, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list executions unexpected error: %s", err))
+	assert.Equal(t, uint64(2), page.Total)
+	assert.Equal(t, []re.Execution{execs[1], execs[3]}, page.Executions)
+
+	// The latest execution succeeded, so the rule has no last error.
+	saved, err := repo.ViewRule(context.Background(), rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rule unexpected error: %s", err))
+	assert.Equal(t, execs[0].StartedAt, *saved.LastRunAt)
+	assert.Empty(t, saved.LastError)
+
+	failed := re.Execution{
+		ID:        generateUUID(t),
+		RuleID:    rule.ID,
+		DomainID:  rule.DomainID,
+		Level:     slog.LevelError,
+		Message:   "failed to run rule outputs",
+		StartedAt: time.Now().UTC().Add(time.Minute).Truncate(time.Microsecond),
+	}
+	err = repo.AddExecution(context.Background(), failed)
+	assert.Nil(t, err, fmt.Sprintf("add execution unexpected error: %s", err))
+	saved, err = repo.ViewRule(context.Background(), rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rule unexpected error: %s", err))
+	assert.Equal(t, failed.StartedAt, *saved.LastRunAt)
+	assert.Equal(t, failed.Message, saved.LastError)
+
+	err = repo.RemoveExecutions(context.Background(), execs[2].StartedAt)
+	assert.Nil(t, err, fmt.Sprintf("remove executions unexpected error: %s", err))
+	page, err = repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: rule.ID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list executions unexpected error: %s", err))
+	assert.Equal(t, []re.Execution{failed, execs[0], execs[1], execs[2]}, page.Executions)
+}
@@ -32,6 +32,10 @@ type dbRule struct {
 	Recurring       schedule.Recurring `db:"recurring"`
 	RecurringPeriod uint               `db:"recurring_period"`
 	Status          re.Status          `db:"status"`
+	Window          []byte             `db:"state_window"`
+	RetryPolicy     []byte             `db:"retry_policy"`
+	LastRunAt       sql.NullTime       `db:"last_run_at"`
+	LastError       sql.NullString     `db:"last_error"`
 	CreatedAt       time.Time          `db:"created_at"`
 	CreatedBy       string             `db:"created_by"`
 	UpdatedAt       time.Time          `db:"updated_at"`
@@ -63,11 +67,27 @@ func ruleToDb(r re.Rule) (dbRule, error) {
 		return dbRule{}, err
 	}
 
-	outputs, err := json.Marshal(r.Outputs)
+	outputs, err := re.MarshalOutputs(r.Outputs)
 	if err != nil {
 		return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
 	}
 
+	var window []byte
+	if r.Window != nil {
+		window, err = json.Marshal(r.Window)
+		if err != nil {
+			return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	var retryPolicy []byte
+	if r.RetryPolicy != nil {
+		retryPolicy, err = json.Marshal(r.RetryPolicy)
+		if err != nil {
+			return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
 	return dbRule{
 		ID:              r.ID,
 		Name:            r.Name,
@@ -84,6 +104,8 @@ func ruleToDb(r re.Rule) (dbRule, error) {
 		Recurring:       r.Schedule.Recurring,
 		RecurringPeriod: r.Schedule.RecurringPeriod,
 		Status:          r.Status,
+		Window:          window,
+		RetryPolicy:     retryPolicy,
 		CreatedAt:       r.CreatedAt,
 		CreatedBy:       r.CreatedBy,
 		UpdatedAt:       r.UpdatedAt,
@@ -111,6 +133,27 @@ func dbToRule(dto dbRule) (re.Rule, error) {
 		}
 	}
 
+	var window *re.Window
+	if dto.Window != nil {
+		window = &re.Window{}
+		if err := json.Unmarshal(dto.Window, window); err != nil {
+			return re.Rule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	var retryPolicy *re.RetryPolicy
+	if dto.RetryPolicy != nil {
+		retryPolicy = &re.RetryPolicy{}
+		if err := json.Unmarshal(dto.RetryPolicy, retryPolicy); err != nil {
+			return re.Rule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	var lastRunAt *time.Time
+	if dto.LastRunAt.Valid {
+		lastRunAt = &dto.LastRunAt.Time
+	}
+
 	var roles []roles.MemberRoleActions
 	if dto.Roles != nil {
 		if err := json.Unmarshal(dto.Roles, &roles); err != nil {
@@ -137,12 +180,16 @@ func dbToRule(dto dbRule) (re.Rule, error) {
 			Recurring:       dto.Recurring,
 			RecurringPeriod: dto.RecurringPeriod,
 		},
-		Status:    dto.Status,
-		CreatedAt: dto.CreatedAt,
-		CreatedBy: dto.CreatedBy,
-		UpdatedAt: dto.UpdatedAt,
-		UpdatedBy: dto.UpdatedBy,
-		Roles:     roles,
+		Window:      window,
+		RetryPolicy: retryPolicy,
+		Status:      dto.Status,
+		LastRunAt:   lastRunAt,
+		LastError:   fromNullString(dto.LastError),
+		CreatedAt:   dto.CreatedAt,
+		CreatedBy:   dto.CreatedBy,
+		UpdatedAt:   dto.UpdatedAt,
+		UpdatedBy:   dto.UpdatedBy,
+		Roles:       roles,
 	}, nil
 }
 
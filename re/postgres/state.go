@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/re"
+)
+
+type dbState struct {
+	RuleID    string    `db:"rule_id"`
+	Window    []byte    `db:"state_window"`
+	Samples   []byte    `db:"samples"`
+	Values    []byte    `db:"vals"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (repo *PostgresRepository) RetrieveState(ctx context.Context, ruleID string) (re.State, error) {
+	q := `
+		SELECT s.rule_id, r.state_window, s.samples, s.vals, s.updated_at
+		FROM rules_state s
+		JOIN rules r ON r.id = s.rule_id
+		WHERE s.rule_id = $1;
+	`
+	rows, err := repo.DB.QueryxContext(ctx, q, ruleID)
+	if err != nil {
+		return re.State{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.State{}, repoerr.ErrNotFound
+	}
+	var dbs dbState
+	if err := rows.StructScan(&dbs); err != nil {
+		return re.State{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return dbToState(dbs)
+}
+
+func (repo *PostgresRepository) SaveState(ctx context.Context, s re.State) error {
+	q := `
+		INSERT INTO rules_state (rule_id, samples, vals, updated_at)
+		VALUES (:rule_id, :samples, :vals, :updated_at)
+		ON CONFLICT (rule_id) DO UPDATE
+		SET samples = EXCLUDED.samples, vals = EXCLUDED.vals, updated_at = EXCLUDED.updated_at;
+	`
+	dbs, err := stateToDb(s)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbs); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func stateToDb(s re.State) (dbState, error) {
+	samples, err := json.Marshal(s.Samples)
+	if err != nil {
+		return dbState{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	vals, err := json.Marshal(s.Values)
+	if err != nil {
+		return dbState{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbState{
+		RuleID:    s.RuleID,
+		Samples:   samples,
+		Values:    vals,
+		UpdatedAt: s.UpdatedAt,
+	}, nil
+}
+
+func dbToState(dbs dbState) (re.State, error) {
+	s := re.State{
+		RuleID:    dbs.RuleID,
+		UpdatedAt: dbs.UpdatedAt,
+	}
+	if dbs.Window != nil {
+		if err := json.Unmarshal(dbs.Window, &s.Window); err != nil {
+			return re.State{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	if dbs.Samples != nil {
+		if err := json.Unmarshal(dbs.Samples, &s.Samples); err != nil {
+			return re.State{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	if dbs.Values != nil {
+		if err := json.Unmarshal(dbs.Values, &s.Values); err != nil {
+			return re.State{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewRetentionHandler starts the goroutine which periodically removes the
+// rule executions and the dead letters older than their retention, until the
+// context is canceled. Zero retention keeps the records forever.
+func NewRetentionHandler(ctx context.Context, repo Repository, execRetention, dlRetention, checkInterval time.Duration, logger *slog.Logger) {
+	if execRetention <= 0 && dlRetention <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				now := time.Now().UTC()
+				if execRetention > 0 {
+					if err := repo.RemoveExecutions(ctx, now.Add(-execRetention)); err != nil {
+						logger.Error("failed to remove expired rule executions", slog.Any("error", err))
+					}
+				}
+				if dlRetention > 0 {
+					if err := repo.RemoveDeadLetters(ctx, now.Add(-dlRetention)); err != nil {
+						logger.Error("failed to remove expired dead letters", slog.Any("error", err))
+					}
+				}
+			}
+		}
+	}()
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/absmach/supermq/pkg/authn"
-	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/roles"
 	"github.com/absmach/supermq/pkg/schedule"
@@ -42,6 +41,7 @@ var outputRegistry = map[outputs.OutputType]func() Runnable{
 	outputs.ChannelsType:     func() Runnable { return &outputs.ChannelPublisher{} },
 	outputs.SaveSenMLType:    func() Runnable { return &outputs.SenML{} },
 	outputs.SlackType:        func() Runnable { return &outputs.Slack{} },
+	outputs.WebhookType:      func() Runnable { return &outputs.Webhook{} },
 }
 
 type Rule struct {
@@ -55,6 +55,10 @@ type Rule struct {
 	Logic        Script                    `json:"logic"`
 	Outputs      Outputs                   `json:"outputs,omitempty"`
 	Schedule     schedule.Schedule         `json:"schedule,omitempty"`
+	Window       *Window                   `json:"window,omitempty"`
+	RetryPolicy  *RetryPolicy              `json:"retry_policy,omitempty"`
+	LastRunAt    *time.Time                `json:"last_run_at,omitempty"`
+	LastError    string                    `json:"last_error,omitempty"`
 	Status       Status                    `json:"status"`
 	CreatedAt    time.Time                 `json:"created_at"`
 	CreatedBy    string                    `json:"created_by"`
@@ -113,6 +117,21 @@ func (r Rule) EventEncode() (map[string]any, error) {
 		}
 	}
 
+	if r.Window != nil {
+		m["window"] = map[string]any{
+			"type":     r.Window.Type.String(),
+			"duration": r.Window.Duration.String(),
+			"count":    r.Window.Count,
+		}
+	}
+
+	if r.RetryPolicy != nil {
+		m["retry_policy"] = map[string]any{
+			"max_attempts": r.RetryPolicy.MaxAttempts,
+			"backoff":      r.RetryPolicy.Backoff.String(),
+		}
+	}
+
 	return m, nil
 }
 
@@ -126,23 +145,10 @@ func (o *Outputs) UnmarshalJSON(data []byte) error {
 
 	var runnables []Runnable
 	for _, raw := range rawList {
-		var meta struct {
-			Type outputs.OutputType `json:"type"`
-		}
-		if err := json.Unmarshal(raw, &meta); err != nil {
+		instance, err := UnmarshalOutput(raw)
+		if err != nil {
 			return err
 		}
-
-		factory, ok := outputRegistry[meta.Type]
-		if !ok {
-			return errors.New("unknown output type: " + meta.Type.String())
-		}
-
-		instance := factory()
-		if err := json.Unmarshal(raw, instance); err != nil {
-			return err
-		}
-
 		runnables = append(runnables, instance)
 	}
 	v := Outputs(runnables)
@@ -237,6 +243,14 @@ type Service interface {
 	RemoveRule(ctx context.Context, session authn.Session, id string) error
 	EnableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
 	DisableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
+	ListDeadLetters(ctx context.Context, session authn.Session, pm DeadLetterPageMeta) (DeadLetterPage, error)
+	ListExecutions(ctx context.Context, session authn.Session, pm ExecutionPageMeta) (ExecutionPage, error)
+	ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error
+	PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error
+	// DryRunRule runs the rule logic against the sample messages and renders the outputs
+	// that would fire without executing them. If the rule ID is set, the stored rule is used.
+	DryRunRule(ctx context.Context, session authn.Session, r Rule, msgs []*messaging.Message) ([]DryRunResult, error)
 
 	StartScheduler(ctx context.Context) error
 	roles.RoleManager
@@ -254,5 +268,29 @@ type Repository interface {
 	ListAllRules(ctx context.Context, pm PageMeta) (Page, error)
 	ListUserRules(ctx context.Context, userID string, pm PageMeta) (Page, error)
 	UpdateRuleDue(ctx context.Context, id string, due time.Time) (Rule, error)
+	// RetrieveState returns the persisted state of the windowed rule.
+	RetrieveState(ctx context.Context, ruleID string) (State, error)
+	// SaveState persists the state of the windowed rule.
+	SaveState(ctx context.Context, s State) error
+	// AddDeadLetter stores the failed output invocation.
+	AddDeadLetter(ctx context.Context, dl DeadLetter) error
+	// ViewDeadLetter returns the dead letter with the given ID.
+	ViewDeadLetter(ctx context.Context, id string) (DeadLetter, error)
+	// UpdateDeadLetter updates the error, attempts count and update time of the dead letter.
+	UpdateDeadLetter(ctx context.Context, dl DeadLetter) error
+	// ListDeadLetters returns a page of dead letters of the rule, newest first.
+	ListDeadLetters(ctx context.Context, pm DeadLetterPageMeta) (DeadLetterPage, error)
+	// RemoveDeadLetter removes the dead letter with the given ID.
+	RemoveDeadLetter(ctx context.Context, id string) error
+	// RemoveRuleDeadLetters removes all the dead letters of the rule.
+	RemoveRuleDeadLetters(ctx context.Context, ruleID string) error
+	// RemoveDeadLetters removes the dead letters which were not updated since the given time.
+	RemoveDeadLetters(ctx context.Context, before time.Time) error
+	// AddExecution stores the rule execution and updates the rule last run summary.
+	AddExecution(ctx context.Context, e Execution) error
+	// ListExecutions returns a page of the rule executions, newest first.
+	ListExecutions(ctx context.Context, pm ExecutionPageMeta) (ExecutionPage, error)
+	// RemoveExecutions removes the executions started before the given time.
+	RemoveExecutions(ctx context.Context, before time.Time) error
 	roles.Repository
 }
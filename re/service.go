@@ -5,6 +5,7 @@ package re
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/absmach/supermq"
@@ -19,35 +20,54 @@ import (
 	"github.com/absmach/supermq/pkg/roles"
 	"github.com/absmach/supermq/pkg/ticker"
 	"github.com/absmach/supermq/re/operations"
+	"github.com/go-kit/kit/metrics"
 )
 
 var (
 	ErrGoroutinesNotAllowed = errors.New("goroutines are not allowed in Go scripts")
 	ErrPanicNotAllowed      = errors.New("panic is not allowed in Go scripts")
+	ErrInvalidSampleRate    = errors.New("execution sample rate must be between 0 and 1")
 )
 
 type re struct {
-	repo       Repository
-	runInfo    chan pkglog.RunInfo
-	idp        supermq.IDProvider
-	rePubSub   messaging.PubSub
-	writersPub messaging.Publisher
-	alarmsPub  messaging.Publisher
-	ticker     ticker.Ticker
-	email      emailer.Emailer
-	readers    grpcReadersV1.ReadersServiceClient
+	repo        Repository
+	cache       RuleCache
+	runInfo     chan pkglog.RunInfo
+	execCounter metrics.Counter
+	execLatency metrics.Histogram
+	sampleRate  float64
+	idp         supermq.IDProvider
+	rePubSub    messaging.PubSub
+	writersPub  messaging.Publisher
+	alarmsPub   messaging.Publisher
+	ticker      ticker.Ticker
+	email       emailer.Emailer
+	readers     grpcReadersV1.ReadersServiceClient
+	stateLocks  sync.Map
 	roles.ProvisionManageService
 }
 
-func NewService(repo Repository, runInfo chan pkglog.RunInfo, policy policies.Service, idp supermq.IDProvider, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, tck ticker.Ticker, emailer emailer.Emailer, readers grpcReadersV1.ReadersServiceClient, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+// NewService returns a new rules engine service. Rules triggered by messages are
+// looked up in the cache. Rule executions are counted by execCounter and their
+// duration in seconds is observed by execLatency. Successful executions are
+// stored with the probability given by the sample rate, while the executions
+// with warnings or errors are always stored.
+func NewService(repo Repository, cache RuleCache, runInfo chan pkglog.RunInfo, execCounter metrics.Counter, execLatency metrics.Histogram, sampleRate float64, policy policies.Service, idp supermq.IDProvider, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, tck ticker.Ticker, emailer emailer.Emailer, readers grpcReadersV1.ReadersServiceClient, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+	if sampleRate < 0 || sampleRate > 1 {
+		return nil, ErrInvalidSampleRate
+	}
 	rpms, err := roles.NewProvisionManageService(operations.EntityType, repo, policy, idp, availableActions, builtInRoles)
 	if err != nil {
 		return nil, err
 	}
 	return &re{
 		repo:                   repo,
+		cache:                  cache,
 		idp:                    idp,
 		runInfo:                runInfo,
+		execCounter:            execCounter,
+		execLatency:            execLatency,
+		sampleRate:             sampleRate,
 		rePubSub:               rePubSub,
 		writersPub:             writersPub,
 		alarmsPub:              alarmsPub,
@@ -147,6 +167,7 @@ func (re *re) UpdateRule(ctx context.Context, session authn.Session, r Rule) (Ru
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	re.removeStateLock(r.ID)
 
 	return rule, nil
 }
@@ -193,6 +214,7 @@ func (re *re) RemoveRule(ctx context.Context, session authn.Session, id string)
 	if err := re.repo.RemoveRule(ctx, id); err != nil {
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
+	re.removeStateLock(id)
 
 	return nil
 }
@@ -233,6 +255,104 @@ func (re *re) DisableRule(ctx context.Context, session authn.Session, id string)
 	return rule, nil
 }
 
+func (re *re) ListDeadLetters(ctx context.Context, session authn.Session, pm DeadLetterPageMeta) (DeadLetterPage, error) {
+	pm.Domain = session.DomainID
+	page, err := re.repo.ListDeadLetters(ctx, pm)
+	if err != nil {
+		return DeadLetterPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	return page, nil
+}
+
+func (re *re) ListExecutions(ctx context.Context, session authn.Session, pm ExecutionPageMeta) (ExecutionPage, error) {
+	pm.Domain = session.DomainID
+	page, err := re.repo.ListExecutions(ctx, pm)
+	if err != nil {
+		return ExecutionPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	return page, nil
+}
+
+func (re *re) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error) {
+	dl, err := re.repo.ViewDeadLetter(ctx, id)
+	if err != nil {
+		return DeadLetter{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if dl.RuleID != ruleID {
+		return DeadLetter{}, svcerr.ErrNotFound
+	}
+	return dl, nil
+}
+
+func (re *re) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) error {
+	dl, err := re.ViewDeadLetter(ctx, session, ruleID, id)
+	if err != nil {
+		return err
+	}
+	r, err := re.repo.ViewRule(ctx, ruleID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	if err := re.handleOutput(ctx, dl.Output, r, dl.Message, dl.Result); err != nil {
+		dl.Error = err.Error()
+		dl.Attempts++
+		dl.UpdatedAt = time.Now().UTC()
+		if e := re.repo.UpdateDeadLetter(ctx, dl); e != nil {
+			return errors.Wrap(ErrReplayDeadLetter, errors.Wrap(err, e))
+		}
+		return errors.Wrap(ErrReplayDeadLetter, err)
+	}
+
+	if err := re.repo.RemoveDeadLetter(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+func (re *re) PurgeDeadLetters(ctx context.Context, session authn.Session, ruleID string) error {
+	if err := re.repo.RemoveRuleDeadLetters(ctx, ruleID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+func (re *re) DryRunRule(ctx context.Context, session authn.Session, r Rule, msgs []*messaging.Message) ([]DryRunResult, error) {
+	if r.ID != "" {
+		rule, err := re.repo.ViewRule(ctx, r.ID)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		r = rule
+	}
+	if r.Logic.Type == GoType && goKeywordRegex.MatchString(r.Logic.Value) {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, ErrGoroutinesNotAllowed)
+	}
+	if r.Logic.Type == GoType && panicRegex.MatchString(r.Logic.Value) {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, ErrPanicNotAllowed)
+	}
+
+	// Windowed rules use a fresh state shared between the sample
+	// messages so the stored state is never read or modified.
+	var st *State
+	if r.Window != nil {
+		st = NewState(r.ID, *r.Window)
+	}
+	results := make([]DryRunResult, len(msgs))
+	for i, msg := range msgs {
+		msg.Domain = session.DomainID
+		if msg.Channel == "" {
+			msg.Channel = r.InputChannel
+		}
+		if msg.Created == 0 {
+			msg.Created = time.Now().UnixNano()
+		}
+		results[i] = dryRun(ctx, r, msg, st)
+	}
+
+	return results, nil
+}
+
 func (re *re) Cancel() error {
 	return nil
 }
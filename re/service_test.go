@@ -5,8 +5,12 @@ package re_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -29,6 +33,7 @@ import (
 	"github.com/absmach/supermq/re/mocks"
 	"github.com/absmach/supermq/re/outputs"
 	readmocks "github.com/absmach/supermq/readers/mocks"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -73,7 +78,7 @@ func newService(t *testing.T, runInfo chan pkglog.RunInfo) (re.Service, *mocks.R
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		"admin": availableActions,
 	}
-	svc, err := re.NewService(repo, runInfo, policy, idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc, availableActions, builtInRoles)
+	svc, err := re.NewService(repo, re.NewRuleCache(repo, 0), runInfo, discard.NewCounter(), discard.NewHistogram(), 1, policy, idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc, availableActions, builtInRoles)
 	if err != nil {
 		t.Fatalf("Failed to create service: %v", err)
 	}
@@ -1164,6 +1169,432 @@ func TestDisableRule(t *testing.T) {
 	}
 }
 
+func TestListDeadLetters(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	dl := re.DeadLetter{
+		ID:       testsutil.GenerateUUID(t),
+		RuleID:   ruleID,
+		DomainID: domainID,
+		Output:   &outputs.ChannelPublisher{Channel: "output.channel"},
+		Error:    "failed to publish",
+		Attempts: 3,
+	}
+
+	cases := []struct {
+		desc    string
+		pm      re.DeadLetterPageMeta
+		page    re.DeadLetterPage
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "list dead letters successfully",
+			pm:   re.DeadLetterPageMeta{RuleID: ruleID, Limit: 10},
+			page: re.DeadLetterPage{Total: 1, Limit: 10, DeadLetters: []re.DeadLetter{dl}},
+		},
+		{
+			desc:    "list dead letters with failed repo",
+			pm:      re.DeadLetterPageMeta{RuleID: ruleID, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.Domain = session.DomainID
+			repoCall := repo.On("ListDeadLetters", mock.Anything, pm).Return(tc.page, tc.repoErr)
+			page, err := svc.ListDeadLetters(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListExecutions(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	e := re.Execution{
+		ID:        testsutil.GenerateUUID(t),
+		RuleID:    ruleID,
+		DomainID:  domainID,
+		Level:     slog.LevelInfo,
+		Message:   "rule processed successfully",
+		StartedAt: time.Now().UTC(),
+		Duration:  time.Millisecond,
+	}
+
+	cases := []struct {
+		desc    string
+		pm      re.ExecutionPageMeta
+		page    re.ExecutionPage
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "list executions successfully",
+			pm:   re.ExecutionPageMeta{RuleID: ruleID, Limit: 10},
+			page: re.ExecutionPage{Total: 1, Limit: 10, Executions: []re.Execution{e}},
+		},
+		{
+			desc:    "list executions with failed repo",
+			pm:      re.ExecutionPageMeta{RuleID: ruleID, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.Domain = session.DomainID
+			repoCall := repo.On("ListExecutions", mock.Anything, pm).Return(tc.page, tc.repoErr)
+			page, err := svc.ListExecutions(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	dl := re.DeadLetter{
+		ID:       testsutil.GenerateUUID(t),
+		RuleID:   ruleID,
+		DomainID: domainID,
+		Output:   &outputs.ChannelPublisher{Channel: "output.channel"},
+	}
+
+	cases := []struct {
+		desc    string
+		ruleID  string
+		dl      re.DeadLetter
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "view dead letter successfully",
+			ruleID: ruleID,
+			dl:     dl,
+		},
+		{
+			desc:   "view dead letter of another rule",
+			ruleID: testsutil.GenerateUUID(t),
+			dl:     dl,
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:    "view dead letter with failed repo",
+			ruleID:  ruleID,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ViewDeadLetter", mock.Anything, dl.ID).Return(tc.dl, tc.repoErr)
+			res, err := svc.ViewDeadLetter(context.Background(), session, tc.ruleID, dl.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.dl, res)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, pubmocks, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	dl := re.DeadLetter{
+		ID:       testsutil.GenerateUUID(t),
+		RuleID:   ruleID,
+		DomainID: domainID,
+		Output:   &outputs.ChannelPublisher{Channel: "output.channel", Topic: "output.topic"},
+		Message:  &messaging.Message{Domain: domainID, Channel: inputChannel},
+		Result:   map[string]any{"temperature": 35.0},
+		Attempts: 3,
+	}
+	rule := re.Rule{ID: ruleID, DomainID: domainID}
+
+	cases := []struct {
+		desc       string
+		viewErr    error
+		ruleErr    error
+		publishErr error
+		updateErr  error
+		removeErr  error
+		attempts   uint
+		err        error
+	}{
+		{
+			desc: "replay dead letter successfully",
+		},
+		{
+			desc:    "replay non-existing dead letter",
+			viewErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "replay dead letter with failed rule retrieval",
+			ruleErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:       "replay dead letter with failing output",
+			publishErr: repoerr.ErrCreateEntity,
+			attempts:   4,
+			err:        re.ErrReplayDeadLetter,
+		},
+		{
+			desc:       "replay dead letter with failing output and failed update",
+			publishErr: repoerr.ErrCreateEntity,
+			updateErr:  repoerr.ErrUpdateEntity,
+			attempts:   4,
+			err:        re.ErrReplayDeadLetter,
+		},
+		{
+			desc:      "replay dead letter with failed removal",
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var updated re.DeadLetter
+			repoCall := repo.On("ViewDeadLetter", mock.Anything, dl.ID).Return(dl, tc.viewErr)
+			repoCall1 := repo.On("ViewRule", mock.Anything, ruleID).Return(rule, tc.ruleErr)
+			pubCall := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr)
+			repoCall2 := repo.On("UpdateDeadLetter", mock.Anything, mock.Anything).Return(tc.updateErr).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(re.DeadLetter)
+			})
+			repoCall3 := repo.On("RemoveDeadLetter", mock.Anything, dl.ID).Return(tc.removeErr)
+			err := svc.ReplayDeadLetter(context.Background(), session, ruleID, dl.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.attempts > 0 {
+				assert.Equal(t, tc.attempts, updated.Attempts)
+				assert.NotEmpty(t, updated.Error)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestPurgeDeadLetters(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc    string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "purge dead letters successfully",
+		},
+		{
+			desc:    "purge dead letters with failed repo",
+			repoErr: repoerr.ErrRemoveEntity,
+			err:     svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RemoveRuleDeadLetters", mock.Anything, ruleID).Return(tc.repoErr)
+			err := svc.PurgeDeadLetters(context.Background(), session, ruleID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDryRunRule(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	msg := func(pld string) *messaging.Message {
+		return &messaging.Message{Channel: inputChannel, Created: 1, Payload: []byte(pld)}
+	}
+	outs := re.Outputs{
+		&outputs.ChannelPublisher{Channel: "output.channel", Topic: "alerts"},
+		&outputs.Email{To: []string{"admin@example.com"}, Subject: "Alert", Content: "Temperature is {{.Result.t}}"},
+	}
+	luaRule := re.Rule{
+		Name:    ruleName,
+		Logic:   re.Script{Type: re.LuaType, Value: `if message.payload.t > 30 then return {t = message.payload.t} end return false`},
+		Outputs: outs,
+	}
+	goRule := re.Rule{
+		Name: ruleName,
+		Logic: re.Script{Type: re.GoType, Value: `package main
+
+import "messaging"
+
+func logicFunction() any {
+	pld := m.message.Payload.(map[string]any)
+	return map[string]any{"t": pld["t"], "channel": m.message.Channel}
+}`},
+	}
+	firing := re.DryRunResult{
+		Result: map[string]any{"t": float64(35)},
+		Outputs: []re.OutputPreview{
+			{
+				Type: "channels",
+				Preview: map[string]any{
+					"channel": "output.channel",
+					"topic":   "alerts",
+					"payload": json.RawMessage(`{"t":35}`),
+				},
+			},
+			{
+				Type: "email",
+				Preview: map[string]any{
+					"to":      []string{"admin@example.com"},
+					"subject": "Alert",
+					"content": "Temperature is 35",
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		rule     re.Rule
+		msgs     []*messaging.Message
+		repoRule re.Rule
+		repoErr  error
+		results  []re.DryRunResult
+		err      error
+	}{
+		{
+			desc:    "dry run Lua rule successfully",
+			rule:    luaRule,
+			msgs:    []*messaging.Message{msg(`{"t": 35}`), msg(`{"t": 20}`)},
+			results: []re.DryRunResult{firing, {Result: false}},
+		},
+		{
+			desc:     "dry run stored rule successfully",
+			rule:     re.Rule{ID: ruleID},
+			msgs:     []*messaging.Message{msg(`{"t": 35}`)},
+			repoRule: luaRule,
+			results:  []re.DryRunResult{firing},
+		},
+		{
+			desc:    "dry run stored rule with failed repo",
+			rule:    re.Rule{ID: ruleID},
+			msgs:    []*messaging.Message{msg(`{"t": 35}`)},
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:    "dry run Go rule successfully",
+			rule:    goRule,
+			msgs:    []*messaging.Message{msg(`{"t": 35}`)},
+			results: []re.DryRunResult{{Result: map[string]any{"t": float64(35), "channel": inputChannel}}},
+		},
+		{
+			desc: "dry run windowed rule with shared state",
+			rule: re.Rule{
+				Window: &re.Window{Type: re.CountWindow, Count: 5},
+				Logic:  re.Script{Type: re.LuaType, Value: `state.push("t", message.payload.t) return state.count("t")`},
+			},
+			msgs:    []*messaging.Message{msg(`{"t": 35}`), msg(`{"t": 20}`)},
+			results: []re.DryRunResult{{Result: float64(1)}, {Result: float64(2)}},
+		},
+		{
+			desc: "dry run Lua rule with invalid output template",
+			rule: re.Rule{
+				Logic:   re.Script{Type: re.LuaType, Value: `return true`},
+				Outputs: re.Outputs{&outputs.Email{Content: "{{.Result"}},
+			},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Result: true, Outputs: []re.OutputPreview{{Type: "email", Error: "unclosed action"}}}},
+		},
+		{
+			desc:    "dry run Lua rule with invalid logic",
+			rule:    re.Rule{Logic: re.Script{Type: re.LuaType, Value: `return {`}},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Error: "syntax error"}},
+		},
+		{
+			desc:    "dry run Lua rule with sandboxed module",
+			rule:    re.Rule{Logic: re.Script{Type: re.LuaType, Value: `local http = require("http") return true`}},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Error: "module http not found"}},
+		},
+		{
+			desc:    "dry run Lua rule with file module",
+			rule:    re.Rule{Logic: re.Script{Type: re.LuaType, Value: `package.path = "./?_test.go" local m = require("service") return true`}},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Error: "module service not found"}},
+		},
+		{
+			desc:    "dry run Lua rule with file access",
+			rule:    re.Rule{Logic: re.Script{Type: re.LuaType, Value: `return dofile("service_test.go")`}},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Error: "attempt to call a non-function object"}},
+		},
+		{
+			desc: "dry run Go rule with sandboxed package",
+			rule: re.Rule{Logic: re.Script{Type: re.GoType, Value: `package main
+
+import "os"
+
+func logicFunction() any {
+	return os.Getenv("HOME")
+}`}},
+			msgs:    []*messaging.Message{msg(`{}`)},
+			results: []re.DryRunResult{{Error: "unable to find source related to: \"os\""}},
+		},
+		{
+			desc: "dry run Go rule with goroutines",
+			rule: re.Rule{Logic: re.Script{Type: re.GoType, Value: "func logicFunction() any {\n\tgo func() {}()\n\treturn true\n}"}},
+			msgs: []*messaging.Message{msg(`{}`)},
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ViewRule", mock.Anything, tc.rule.ID).Return(tc.repoRule, tc.repoErr)
+			results, err := svc.DryRunRule(context.Background(), session, tc.rule, tc.msgs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Len(t, results, len(tc.results), fmt.Sprintf("%s: unexpected number of results", tc.desc))
+			for i, res := range results {
+				exp := tc.results[i]
+				assert.Contains(t, res.Error, exp.Error, fmt.Sprintf("%s: unexpected result error", tc.desc))
+				exp.Error, res.Error = "", ""
+				if !assert.Len(t, res.Outputs, len(exp.Outputs), fmt.Sprintf("%s: unexpected number of outputs", tc.desc)) {
+					continue
+				}
+				for j := range res.Outputs {
+					assert.Contains(t, res.Outputs[j].Error, exp.Outputs[j].Error, fmt.Sprintf("%s: unexpected output error", tc.desc))
+					exp.Outputs[j].Error, res.Outputs[j].Error = "", ""
+				}
+				assert.Equal(t, exp, res, fmt.Sprintf("%s: unexpected result", tc.desc))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestHandle(t *testing.T) {
 	svc, repo, pubmocks, _, emailer, _ := newService(t, make(chan pkglog.RunInfo))
 	now := time.Now()
@@ -1819,6 +2250,8 @@ func TestHandle(t *testing.T) {
 			})
 			repoCall1 := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr).Maybe()
 			repoCall2 := emailer.On("SendEmailNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			repoCall3 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repoCall4 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 			err = svc.Handle(tc.message)
 			assert.Nil(t, err)
@@ -1830,6 +2263,582 @@ func TestHandle(t *testing.T) {
 			repoCall.Unset()
 			repoCall1.Unset()
 			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+		})
+	}
+}
+
+func TestHandleWindowedRule(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, pubmocks, _, _, _ := newService(t, ri)
+	scheduled := false
+	window := &re.Window{Type: re.CountWindow, Count: 3}
+	msg := &messaging.Message{
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+
+	cases := []struct {
+		desc     string
+		logic    re.Script
+		state    re.State
+		stateErr error
+		saveErr  error
+		series   []float64
+		level    slog.Level
+	}{
+		{
+			desc: "process Lua rule with new state",
+			logic: re.Script{
+				Type:  re.LuaType,
+				Value: `state.push("t", message.payload.temperature) return state.consecutive("t", 30) >= 2`,
+			},
+			stateErr: repoerr.ErrNotFound,
+			series:   []float64{35},
+			level:    slog.LevelInfo,
+		},
+		{
+			desc: "process Lua rule with existing state",
+			logic: re.Script{
+				Type:  re.LuaType,
+				Value: `state.push("t", message.payload.temperature) return {avg = state.avg("t")}`,
+			},
+			state: re.State{
+				Samples: map[string][]re.Sample{"t": {{Value: 10, Time: time.Now()}, {Value: 20, Time: time.Now()}, {Value: 31, Time: time.Now()}}},
+			},
+			series: []float64{20, 31, 35},
+			level:  slog.LevelInfo,
+		},
+		{
+			desc: "process Go rule with existing state",
+			logic: re.Script{
+				Type: re.GoType,
+				Value: `package main
+
+import "messaging"
+
+func logicFunction() any {
+	pld := m.message.Payload.(map[string]any)
+	m.state.Push("t", pld["temperature"].(float64))
+	return m.state.Max("t")
+}`,
+			},
+			state: re.State{
+				Samples: map[string][]re.Sample{"t": {{Value: 40, Time: time.Now()}}},
+			},
+			series: []float64{40, 35},
+			level:  slog.LevelInfo,
+		},
+		{
+			desc: "process rule with failed state retrieval",
+			logic: re.Script{
+				Type:  re.LuaType,
+				Value: `return true`,
+			},
+			stateErr: repoerr.ErrViewEntity,
+			level:    slog.LevelError,
+		},
+		{
+			desc: "process rule with failed state save",
+			logic: re.Script{
+				Type:  re.LuaType,
+				Value: `state.push("t", 1) return true`,
+			},
+			stateErr: repoerr.ErrNotFound,
+			saveErr:  repoerr.ErrUpdateEntity,
+			series:   []float64{1},
+			level:    slog.LevelError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				Name:         namegen.Generate(),
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        tc.logic,
+				Window:       window,
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{
+						Channel: "output.channel",
+						Topic:   "output.topic",
+					},
+				},
+			}
+			tc.state.RuleID = rule.ID
+			var saved re.State
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("RetrieveState", mock.Anything, rule.ID).Return(tc.state, tc.stateErr)
+			repoCall2 := repo.On("SaveState", mock.Anything, mock.Anything).Return(tc.saveErr).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(re.State)
+			})
+			repoCall3 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil)
+			pubCall := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case info := <-ri:
+				assert.Equal(t, tc.level, info.Level, fmt.Sprintf("%s: expected level %s got %s: %s", tc.desc, tc.level, info.Level, info.Message))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			if tc.series != nil {
+				assert.Equal(t, rule.ID, saved.RuleID)
+				assert.Equal(t, *window, saved.Window)
+				assert.Equal(t, tc.series, saved.Series("t"), fmt.Sprintf("%s: unexpected state series", tc.desc))
+			}
+
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestRemoveWindowedRuleState(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, _, _, _, _ := newService(t, ri)
+	scheduled := false
+	msg := &messaging.Message{
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc   string
+		remove func(rule re.Rule) error
+	}{
+		{
+			desc: "remove rule while processing message",
+			remove: func(rule re.Rule) error {
+				return svc.RemoveRule(context.Background(), session, rule.ID)
+			},
+		},
+		{
+			desc: "update rule while processing message",
+			remove: func(rule re.Rule) error {
+				_, err := svc.UpdateRule(context.Background(), session, rule)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				Name:         namegen.Generate(),
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        re.Script{Type: re.LuaType, Value: `state.push("t", message.payload.temperature) return false`},
+				Window:       &re.Window{Type: re.CountWindow, Count: 3},
+			}
+			loading := make(chan struct{})
+			release := make(chan struct{})
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("RetrieveState", mock.Anything, rule.ID).Return(re.State{}, repoerr.ErrNotFound).Run(func(args mock.Arguments) {
+				close(loading)
+				<-release
+			})
+			repoCall2 := repo.On("SaveState", mock.Anything, mock.Anything).Return(nil)
+			repoCall3 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil)
+			repoCall4 := repo.On("RemoveRule", mock.Anything, rule.ID).Return(nil)
+			repoCall5 := repo.On("UpdateRule", mock.Anything, mock.Anything).Return(rule, nil)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			<-loading
+
+			done := make(chan error, 1)
+			go func() {
+				done <- tc.remove(rule)
+			}()
+			select {
+			case <-done:
+				t.Fatalf("%s: state lock removed while the state is updated", tc.desc)
+			case <-time.After(100 * time.Millisecond):
+			}
+			close(release)
+
+			select {
+			case err := <-done:
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for the state lock removal", tc.desc)
+			}
+			select {
+			case <-ri:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			repo.AssertCalled(t, "SaveState", mock.Anything, mock.Anything)
+
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			repoCall5.Unset()
+		})
+	}
+}
+
+func TestHandleDeadLetter(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, pubmocks, _, _, _ := newService(t, ri)
+	scheduled := false
+	msg := &messaging.Message{
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+
+	cases := []struct {
+		desc        string
+		retryPolicy *re.RetryPolicy
+		publishErrs []error
+		publishes   int
+		deadLetter  bool
+		addErr      error
+		level       slog.Level
+	}{
+		{
+			desc:        "process rule with successful output",
+			retryPolicy: &re.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			publishErrs: []error{nil},
+			publishes:   1,
+			level:       slog.LevelInfo,
+		},
+		{
+			desc:        "process rule with output succeeding after retries",
+			retryPolicy: &re.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			publishErrs: []error{repoerr.ErrCreateEntity, repoerr.ErrCreateEntity, nil},
+			publishes:   3,
+			level:       slog.LevelInfo,
+		},
+		{
+			desc:        "process rule with output failing all attempts",
+			retryPolicy: &re.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			publishErrs: []error{repoerr.ErrCreateEntity, repoerr.ErrCreateEntity, repoerr.ErrCreateEntity},
+			publishes:   3,
+			deadLetter:  true,
+			level:       slog.LevelError,
+		},
+		{
+			desc:        "process rule without retry policy with failing output",
+			publishErrs: []error{repoerr.ErrCreateEntity},
+			publishes:   1,
+			level:       slog.LevelError,
+		},
+		{
+			desc:        "process rule with failing output and failed dead letter save",
+			retryPolicy: &re.RetryPolicy{MaxAttempts: 1},
+			publishErrs: []error{repoerr.ErrCreateEntity},
+			publishes:   1,
+			deadLetter:  true,
+			addErr:      repoerr.ErrCreateEntity,
+			level:       slog.LevelError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				Name:         namegen.Generate(),
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return message.payload",
+				},
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{
+						Channel: "output.channel",
+						Topic:   "output.topic",
+					},
+				},
+				RetryPolicy: tc.retryPolicy,
+			}
+			publishes := 0
+			var dl re.DeadLetter
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			pubCall := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, string, *messaging.Message) error {
+				err := tc.publishErrs[publishes]
+				publishes++
+				return err
+			})
+			repoCall1 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(tc.addErr).Run(func(args mock.Arguments) {
+				dl = args.Get(1).(re.DeadLetter)
+			}).Maybe()
+			repoCall2 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case info := <-ri:
+				assert.Equal(t, tc.level, info.Level, fmt.Sprintf("%s: expected level %s got %s: %s", tc.desc, tc.level, info.Level, info.Message))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			assert.Equal(t, tc.publishes, publishes, fmt.Sprintf("%s: unexpected number of output attempts", tc.desc))
+			assert.Equal(t, tc.deadLetter, dl.ID != "", fmt.Sprintf("%s: expected dead letter %t", tc.desc, tc.deadLetter))
+			if tc.deadLetter {
+				assert.Equal(t, rule.ID, dl.RuleID)
+				assert.Equal(t, rule.DomainID, dl.DomainID)
+				assert.Equal(t, uint(tc.publishes), dl.Attempts)
+				assert.Equal(t, msg, dl.Message)
+				assert.NotEmpty(t, dl.Error)
+				assert.IsType(t, &outputs.ChannelPublisher{}, dl.Output)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestHandleWebhookRetries(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, _, _, _, _ := newService(t, ri)
+	scheduled := false
+	msg := &messaging.Message{
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+
+	cases := []struct {
+		desc        string
+		retryPolicy *re.RetryPolicy
+		requests    int32
+	}{
+		{
+			desc:     "run webhook with its own retries",
+			requests: 3,
+		},
+		{
+			desc:        "run webhook with rule retry policy",
+			retryPolicy: &re.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+			requests:    2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var requests atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer ts.Close()
+
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return message.payload",
+				},
+				Outputs: re.Outputs{
+					&outputs.Webhook{URL: ts.URL, Retries: 2, Backoff: time.Millisecond},
+				},
+				RetryPolicy: tc.retryPolicy,
+			}
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repoCall2 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case info := <-ri:
+				assert.Equal(t, slog.LevelError, info.Level, fmt.Sprintf("%s: expected level %s got %s: %s", tc.desc, slog.LevelError, info.Level, info.Message))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			assert.Equal(t, tc.requests, requests.Load(), fmt.Sprintf("%s: unexpected number of webhook requests", tc.desc))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestHandleExecution(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, pubmocks, _, _, _ := newService(t, ri)
+	scheduled := false
+	msg := &messaging.Message{
+		Domain:  domainID,
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+
+	cases := []struct {
+		desc       string
+		publishErr error
+		addErr     error
+		level      slog.Level
+		errors     int
+	}{
+		{
+			desc:  "store successful execution",
+			level: slog.LevelInfo,
+		},
+		{
+			desc:       "store execution with failing output",
+			publishErr: repoerr.ErrCreateEntity,
+			level:      slog.LevelError,
+			errors:     1,
+		},
+		{
+			desc:   "store execution with failed save",
+			addErr: repoerr.ErrCreateEntity,
+			level:  slog.LevelError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				Name:         namegen.Generate(),
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return message.payload",
+				},
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{
+						Channel: "output.channel",
+						Topic:   "output.topic",
+					},
+				},
+			}
+			var e re.Execution
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			repoCall2 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(tc.addErr).Run(func(args mock.Arguments) {
+				e = args.Get(1).(re.Execution)
+			})
+			pubCall := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case info := <-ri:
+				assert.Equal(t, tc.level, info.Level, fmt.Sprintf("%s: expected level %s got %s: %s", tc.desc, tc.level, info.Level, info.Message))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			assert.NotEmpty(t, e.ID)
+			assert.Equal(t, rule.ID, e.RuleID)
+			assert.Equal(t, rule.DomainID, e.DomainID)
+			assert.NotEmpty(t, e.MessageID)
+			assert.False(t, e.StartedAt.IsZero())
+			assert.Len(t, e.Errors, tc.errors, fmt.Sprintf("%s: unexpected output errors %v", tc.desc, e.Errors))
+			if tc.addErr == nil {
+				assert.Equal(t, tc.level, e.Level)
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestHandleSampledExecution(t *testing.T) {
+	repo := new(mocks.Repository)
+	pubsub := pubsubmocks.NewPubSub(t)
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, err := re.NewService(repo, re.NewRuleCache(repo, 0), ri, discard.NewCounter(), discard.NewHistogram(), 0, new(policymocks.Service), uuid.NewMock(), pubsub, pubsub, pubsub, new(tmocks.Ticker), new(emocks.Emailer), new(readmocks.ReadersServiceClient), []roles.Action{}, map[roles.BuiltInRoleName][]roles.Action{})
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	scheduled := false
+	msg := &messaging.Message{
+		Domain:  domainID,
+		Channel: inputChannel,
+		Created: time.Now().Unix(),
+		Payload: []byte(`{"temperature": 35}`),
+	}
+
+	cases := []struct {
+		desc       string
+		publishErr error
+		stored     bool
+	}{
+		{
+			desc: "skip successful execution",
+		},
+		{
+			desc:       "store failed execution",
+			publishErr: repoerr.ErrCreateEntity,
+			stored:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return message.payload",
+				},
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{
+						Channel: "output.channel",
+						Topic:   "output.topic",
+					},
+				},
+			}
+			stored := false
+			repoCall := repo.On("ListAllRules", mock.Anything, re.PageMeta{Domain: msg.Domain, InputChannel: msg.Channel, Scheduled: &scheduled}).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				stored = true
+			}).Maybe()
+			repoCall2 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case <-ri:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timeout waiting for run info", tc.desc)
+			}
+			assert.Equal(t, tc.stored, stored, fmt.Sprintf("%s: expected stored %t got %t", tc.desc, tc.stored, stored))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
 		})
 	}
 }
@@ -2025,6 +3034,7 @@ func TestStartScheduler(t *testing.T) {
 
 			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(page, tc.listErr)
 			repoCall2 := repo.On("UpdateRuleDue", mock.Anything, mock.Anything, mock.Anything).Return(re.Rule{}, tc.updateDueErr)
+			repoCall3 := repo.On("AddExecution", mock.Anything, mock.Anything).Return(nil).Maybe()
 			tickChan := make(chan time.Time, 1)
 			tickCall := ticker.On("Tick").Return((<-chan time.Time)(tickChan))
 			tickCall1 := ticker.On("Stop").Return()
@@ -2063,6 +3073,7 @@ func TestStartScheduler(t *testing.T) {
 
 			repoCall.Unset()
 			repoCall2.Unset()
+			repoCall3.Unset()
 			tickCall.Unset()
 			tickCall1.Unset()
 		})
@@ -0,0 +1,304 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	tumblingWindow = "tumbling"
+	slidingWindow  = "sliding"
+	countWindow    = "count"
+)
+
+var (
+	ErrInvalidWindowType = errors.NewRequestError("invalid window type")
+	ErrInvalidWindowSize = errors.NewRequestError("window duration or count must be greater than zero")
+)
+
+// WindowType defines how samples are retained in the rule state.
+type WindowType uint8
+
+const (
+	// TumblingWindow keeps samples from the current fixed-size, non-overlapping time bucket.
+	TumblingWindow WindowType = iota
+	// SlidingWindow keeps samples recorded within the last window duration.
+	SlidingWindow
+	// CountWindow keeps the last N samples regardless of their age.
+	CountWindow
+)
+
+func (wt WindowType) String() string {
+	switch wt {
+	case TumblingWindow:
+		return tumblingWindow
+	case SlidingWindow:
+		return slidingWindow
+	case CountWindow:
+		return countWindow
+	default:
+		return Unknown
+	}
+}
+
+// ToWindowType converts string value to a valid window type.
+func ToWindowType(wt string) (WindowType, error) {
+	switch wt {
+	case tumblingWindow:
+		return TumblingWindow, nil
+	case slidingWindow:
+		return SlidingWindow, nil
+	case countWindow:
+		return CountWindow, nil
+	}
+	return WindowType(0), ErrInvalidWindowType
+}
+
+func (wt WindowType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wt.String())
+}
+
+func (wt *WindowType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	val, err := ToWindowType(s)
+	if err != nil {
+		return err
+	}
+	*wt = val
+	return nil
+}
+
+// Window configures the rule state. Duration is used by time-based
+// windows (tumbling and sliding), and Count by count-based windows.
+type Window struct {
+	Type     WindowType    `json:"type"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Count    uint64        `json:"count,omitempty"`
+}
+
+func (w Window) Validate() error {
+	switch w.Type {
+	case TumblingWindow, SlidingWindow:
+		if w.Duration <= 0 {
+			return ErrInvalidWindowSize
+		}
+	case CountWindow:
+		if w.Count == 0 {
+			return ErrInvalidWindowSize
+		}
+	default:
+		return ErrInvalidWindowType
+	}
+	return nil
+}
+
+func (w Window) MarshalJSON() ([]byte, error) {
+	m := map[string]any{
+		"type": w.Type.String(),
+	}
+	if w.Duration > 0 {
+		m["duration"] = w.Duration.String()
+	}
+	if w.Count > 0 {
+		m["count"] = w.Count
+	}
+	return json.Marshal(m)
+}
+
+func (w *Window) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     WindowType `json:"type"`
+		Duration string     `json:"duration,omitempty"`
+		Count    uint64     `json:"count,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	w.Type = aux.Type
+	w.Count = aux.Count
+	w.Duration = 0
+	if aux.Duration != "" {
+		d, err := time.ParseDuration(aux.Duration)
+		if err != nil {
+			return errors.Wrap(ErrInvalidWindowSize, err)
+		}
+		w.Duration = d
+	}
+	return nil
+}
+
+// Sample is a single value recorded in the rule state.
+type Sample struct {
+	Value float64   `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+// State is a per-rule store that keeps windowed samples and arbitrary
+// values between rule executions. Samples are grouped by a key chosen
+// by the rule logic (for example a measurement name or a client ID).
+type State struct {
+	RuleID    string              `json:"rule_id"`
+	Window    Window              `json:"window"`
+	Samples   map[string][]Sample `json:"samples,omitempty"`
+	Values    map[string]any      `json:"values,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	now       func() time.Time
+}
+
+// NewState returns an empty state of the rule with the given window.
+func NewState(ruleID string, w Window) *State {
+	return &State{
+		RuleID:  ruleID,
+		Window:  w,
+		Samples: make(map[string][]Sample),
+		Values:  make(map[string]any),
+	}
+}
+
+// Push records a new sample under the key and evicts the samples
+// that fell out of the window.
+func (s *State) Push(key string, value float64) {
+	if s.Samples == nil {
+		s.Samples = make(map[string][]Sample)
+	}
+	s.Samples[key] = append(s.Samples[key], Sample{Value: value, Time: s.clock()})
+	s.evict(key)
+}
+
+// Series returns values of the key currently in the window.
+func (s *State) Series(key string) []float64 {
+	samples := s.evict(key)
+	ret := make([]float64, len(samples))
+	for i, smp := range samples {
+		ret[i] = smp.Value
+	}
+	return ret
+}
+
+// Count returns the number of samples of the key in the window.
+func (s *State) Count(key string) int {
+	return len(s.evict(key))
+}
+
+// Sum returns the sum of samples of the key in the window.
+func (s *State) Sum(key string) float64 {
+	var sum float64
+	for _, smp := range s.evict(key) {
+		sum += smp.Value
+	}
+	return sum
+}
+
+// Avg returns the mean of samples of the key in the window, or 0 if there are none.
+func (s *State) Avg(key string) float64 {
+	n := s.Count(key)
+	if n == 0 {
+		return 0
+	}
+	return s.Sum(key) / float64(n)
+}
+
+// Min returns the smallest sample of the key in the window, or 0 if there are none.
+func (s *State) Min(key string) float64 {
+	samples := s.evict(key)
+	if len(samples) == 0 {
+		return 0
+	}
+	ret := math.Inf(1)
+	for _, smp := range samples {
+		ret = math.Min(ret, smp.Value)
+	}
+	return ret
+}
+
+// Max returns the largest sample of the key in the window, or 0 if there are none.
+func (s *State) Max(key string) float64 {
+	samples := s.evict(key)
+	if len(samples) == 0 {
+		return 0
+	}
+	ret := math.Inf(-1)
+	for _, smp := range samples {
+		ret = math.Max(ret, smp.Value)
+	}
+	return ret
+}
+
+// Consecutive returns the number of the most recent samples of
+// the key that are strictly greater than the threshold.
+func (s *State) Consecutive(key string, threshold float64) int {
+	samples := s.evict(key)
+	n := 0
+	for i := len(samples) - 1; i >= 0 && samples[i].Value > threshold; i-- {
+		n++
+	}
+	return n
+}
+
+// Get returns the value stored under the key.
+func (s *State) Get(key string) any {
+	return s.Values[key]
+}
+
+// Set stores an arbitrary value under the key. Values are not windowed.
+func (s *State) Set(key string, value any) {
+	if s.Values == nil {
+		s.Values = make(map[string]any)
+	}
+	s.Values[key] = value
+}
+
+// Clear removes both samples and the value stored under the key.
+func (s *State) Clear(key string) {
+	delete(s.Samples, key)
+	delete(s.Values, key)
+}
+
+func (s *State) evict(key string) []Sample {
+	samples := s.Samples[key]
+	if len(samples) == 0 {
+		return samples
+	}
+	switch s.Window.Type {
+	case CountWindow:
+		if n := uint64(len(samples)); n > s.Window.Count {
+			samples = samples[n-s.Window.Count:]
+		}
+	case SlidingWindow:
+		from := s.clock().Add(-s.Window.Duration)
+		samples = after(samples, from)
+	case TumblingWindow:
+		from := s.clock().Truncate(s.Window.Duration)
+		samples = after(samples, from.Add(-time.Nanosecond))
+	}
+	if len(samples) != len(s.Samples[key]) {
+		s.Samples[key] = samples
+	}
+	return samples
+}
+
+// after returns samples recorded strictly after t. Samples are ordered by time.
+func after(samples []Sample, t time.Time) []Sample {
+	for i, smp := range samples {
+		if smp.Time.After(t) {
+			return samples[i:]
+		}
+	}
+	return samples[:0]
+}
+
+func (s *State) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		window re.Window
+		err    error
+	}{
+		{
+			desc:   "valid tumbling window",
+			window: re.Window{Type: re.TumblingWindow, Duration: time.Minute},
+			err:    nil,
+		},
+		{
+			desc:   "valid sliding window",
+			window: re.Window{Type: re.SlidingWindow, Duration: 5 * time.Minute},
+			err:    nil,
+		},
+		{
+			desc:   "valid count window",
+			window: re.Window{Type: re.CountWindow, Count: 3},
+			err:    nil,
+		},
+		{
+			desc:   "sliding window without duration",
+			window: re.Window{Type: re.SlidingWindow},
+			err:    re.ErrInvalidWindowSize,
+		},
+		{
+			desc:   "count window without count",
+			window: re.Window{Type: re.CountWindow, Duration: time.Minute},
+			err:    re.ErrInvalidWindowSize,
+		},
+		{
+			desc:   "invalid window type",
+			window: re.Window{Type: re.WindowType(10), Count: 1},
+			err:    re.ErrInvalidWindowType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.window.Validate()
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestWindowJSON(t *testing.T) {
+	cases := []struct {
+		desc   string
+		data   string
+		window re.Window
+		err    bool
+	}{
+		{
+			desc:   "sliding window",
+			data:   `{"type":"sliding","duration":"5m0s"}`,
+			window: re.Window{Type: re.SlidingWindow, Duration: 5 * time.Minute},
+		},
+		{
+			desc:   "count window",
+			data:   `{"count":3,"type":"count"}`,
+			window: re.Window{Type: re.CountWindow, Count: 3},
+		},
+		{
+			desc: "invalid window type",
+			data: `{"type":"hopping","duration":"5m0s"}`,
+			err:  true,
+		},
+		{
+			desc: "invalid duration",
+			data: `{"type":"tumbling","duration":"five minutes"}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var w re.Window
+			err := json.Unmarshal([]byte(tc.data), &w)
+			if tc.err {
+				assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+				return
+			}
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			assert.Equal(t, tc.window, w)
+
+			data, err := json.Marshal(w)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			assert.JSONEq(t, tc.data, string(data))
+		})
+	}
+}
+
+func TestStateCountWindow(t *testing.T) {
+	st := re.NewState("rule", re.Window{Type: re.CountWindow, Count: 3})
+	for _, v := range []float64{10, 40, 20, 35, 50} {
+		st.Push("t", v)
+	}
+
+	assert.Equal(t, []float64{20, 35, 50}, st.Series("t"))
+	assert.Equal(t, 3, st.Count("t"))
+	assert.Equal(t, float64(105), st.Sum("t"))
+	assert.Equal(t, float64(35), st.Avg("t"))
+	assert.Equal(t, float64(20), st.Min("t"))
+	assert.Equal(t, float64(50), st.Max("t"))
+	assert.Equal(t, 2, st.Consecutive("t", 30))
+	assert.Equal(t, 0, st.Count("unknown"))
+	assert.Equal(t, float64(0), st.Avg("unknown"))
+}
+
+func TestStateTimeWindows(t *testing.T) {
+	old := time.Now().UTC().Add(-time.Hour)
+	cases := []struct {
+		desc   string
+		window re.Window
+		series []float64
+	}{
+		{
+			desc:   "sliding window evicts samples older than duration",
+			window: re.Window{Type: re.SlidingWindow, Duration: time.Minute},
+			series: []float64{2},
+		},
+		{
+			desc:   "sliding window keeps samples within duration",
+			window: re.Window{Type: re.SlidingWindow, Duration: 24 * time.Hour},
+			series: []float64{1, 2},
+		},
+		{
+			desc:   "tumbling window evicts samples from previous bucket",
+			window: re.Window{Type: re.TumblingWindow, Duration: time.Minute},
+			series: []float64{2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			st := re.State{
+				Window:  tc.window,
+				Samples: map[string][]re.Sample{"t": {{Value: 1, Time: old}}},
+			}
+			st.Push("t", 2)
+			assert.Equal(t, tc.series, st.Series("t"))
+		})
+	}
+}
+
+func TestStateValues(t *testing.T) {
+	st := re.NewState("rule", re.Window{Type: re.CountWindow, Count: 1})
+	st.Set("alarm", true)
+	st.Push("alarm", 1)
+	assert.Equal(t, true, st.Get("alarm"))
+	assert.Equal(t, 1, st.Count("alarm"))
+
+	st.Clear("alarm")
+	assert.Nil(t, st.Get("alarm"))
+	assert.Equal(t, 0, st.Count("alarm"))
+}
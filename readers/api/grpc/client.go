@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ var _ grpcReadersV1.ReadersServiceClient = (*readersGrpcClient)(nil)
 
 type readersGrpcClient struct {
 	readMessages endpoint.Endpoint
+	streams      grpcReadersV1.ReadersServiceClient
 	timeout      time.Duration
 }
 
@@ -42,6 +44,7 @@ func NewReadersClient(conn *grpc.ClientConn, timeout time.Duration) grpcReadersV
 			decodeReadMessagesResponse,
 			grpcReadersV1.ReadMessagesRes{},
 		).Endpoint(),
+		streams: grpcReadersV1.NewReadersServiceClient(conn),
 		timeout: timeout,
 	}
 }
@@ -70,6 +73,7 @@ func (client readersGrpcClient) ReadMessages(ctx context.Context, in *grpcReader
 			StringValue: in.GetPageMetadata().GetStringValue(),
 			DataValue:   in.GetPageMetadata().GetDataValue(),
 			Format:      in.GetPageMetadata().GetFormat(),
+			Cursor:      in.GetPageMetadata().GetCursor(),
 		},
 	})
 	if err != nil {
@@ -78,8 +82,9 @@ func (client readersGrpcClient) ReadMessages(ctx context.Context, in *grpcReader
 
 	dpr := res.(readMessagesRes)
 	return &grpcReadersV1.ReadMessagesRes{
-		Total:    dpr.Total,
-		Messages: toResponseMessages(dpr.Messages),
+		Total:      dpr.Total,
+		NextCursor: dpr.NextCursor,
+		Messages:   toResponseMessages(dpr.Messages),
 		PageMetadata: &grpcReadersV1.PageMetadata{
 			Offset: dpr.PageMetadata.Offset,
 			Limit:  dpr.PageMetadata.Limit,
@@ -87,11 +92,37 @@ func (client readersGrpcClient) ReadMessages(ctx context.Context, in *grpcReader
 	}, nil
 }
 
+// StreamMessages is not limited by the client timeout, since streams may last
+// long for large time ranges. Use the context to cancel the stream.
+func (client readersGrpcClient) StreamMessages(ctx context.Context, in *grpcReadersV1.ReadMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[grpcReadersV1.Message], error) {
+	stream, err := client.streams.StreamMessages(ctx, in, opts...)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	return messagesStream{ServerStreamingClient: stream}, nil
+}
+
+// messagesStream decodes the errors of the received messages.
+type messagesStream struct {
+	grpc.ServerStreamingClient[grpcReadersV1.Message]
+}
+
+func (s messagesStream) Recv() (*grpcReadersV1.Message, error) {
+	msg, err := s.ServerStreamingClient.Recv()
+	if err != nil && err != io.EOF {
+		return nil, decodeError(err)
+	}
+
+	return msg, err
+}
+
 func decodeReadMessagesResponse(_ context.Context, grpcRes any) (any, error) {
 	res := grpcRes.(*grpcReadersV1.ReadMessagesRes)
 	return readMessagesRes{
-		Total:    res.Total,
-		Messages: fromResponseMessages(res.Messages),
+		Total:      res.Total,
+		NextCursor: res.GetNextCursor(),
+		Messages:   fromResponseMessages(res.Messages),
 		PageMetadata: readers.PageMetadata{
 			Offset: res.GetPageMetadata().GetOffset(),
 			Limit:  res.GetPageMetadata().GetLimit(),
@@ -125,6 +156,7 @@ func encodeReadMessagesRequest(_ context.Context, grpcReq any) (any, error) {
 			Format:      req.pageMeta.Format,
 			Order:       req.pageMeta.Order,
 			Dir:         req.pageMeta.Dir,
+			Cursor:      req.pageMeta.Cursor,
 		},
 	}, nil
 }
@@ -184,6 +216,20 @@ func parseAggregation(agg string) grpcReadersV1.Aggregation {
 		return grpcReadersV1.Aggregation_AGGREGATION_COUNT
 	case "AVG":
 		return grpcReadersV1.Aggregation_AGGREGATION_AVG
+	case "P50":
+		return grpcReadersV1.Aggregation_AGGREGATION_P50
+	case "P95":
+		return grpcReadersV1.Aggregation_AGGREGATION_P95
+	case "P99":
+		return grpcReadersV1.Aggregation_AGGREGATION_P99
+	case "FIRST":
+		return grpcReadersV1.Aggregation_AGGREGATION_FIRST
+	case "LAST":
+		return grpcReadersV1.Aggregation_AGGREGATION_LAST
+	case "STDDEV":
+		return grpcReadersV1.Aggregation_AGGREGATION_STDDEV
+	case "RATE":
+		return grpcReadersV1.Aggregation_AGGREGATION_RATE
 	default:
 		return grpcReadersV1.Aggregation_AGGREGATION_UNSPECIFIED
 	}
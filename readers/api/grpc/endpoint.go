@@ -25,7 +25,22 @@ func readMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 		return readMessagesRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
+			NextCursor:   page.NextCursor,
 			Messages:     page.Messages,
 		}, nil
 	}
 }
+
+// streamHandler validates the request and passes the messages to the given
+// function. go-kit endpoints are unary, so streams are handled separately.
+type streamHandler func(ctx context.Context, req streamMessagesReq, fn func(readers.Message) error) error
+
+func streamMessagesEndpoint(svc readers.MessageRepository) streamHandler {
+	return func(ctx context.Context, req streamMessagesReq, fn func(readers.Message) error) error {
+		if err := req.validate(); err != nil {
+			return err
+		}
+
+		return svc.StreamAll(ctx, req.chanID, req.pageMeta, fn)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -217,6 +218,117 @@ func TestReadMessages(t *testing.T) {
 	}
 }
 
+func TestStreamMessages(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewReadersClient(conn, time.Second)
+
+	var msgs []readers.Message
+	for i := 0; i < 3; i++ {
+		msgs = append(msgs, senml.Message{
+			Channel:   channelID,
+			Publisher: validID,
+			Name:      "temperature",
+			Time:      float64(1672531200 + i),
+			Value:     float64Ptr(22.5),
+		})
+	}
+
+	cases := []struct {
+		desc     string
+		req      *grpcReadersV1.ReadMessagesReq
+		pageMeta readers.PageMetadata
+		count    int
+		svcErr   error
+		err      error
+	}{
+		{
+			desc: "stream all messages",
+			req: &grpcReadersV1.ReadMessagesReq{
+				ChannelId:    channelID,
+				DomainId:     domain,
+				PageMetadata: &grpcReadersV1.PageMetadata{},
+			},
+			count: len(msgs),
+		},
+		{
+			desc: "stream messages with limit over the page limit",
+			req: &grpcReadersV1.ReadMessagesReq{
+				ChannelId: channelID,
+				DomainId:  domain,
+				PageMetadata: &grpcReadersV1.PageMetadata{
+					Limit: 10000,
+				},
+			},
+			pageMeta: readers.PageMetadata{Limit: 10000},
+			count:    len(msgs),
+		},
+		{
+			desc: "stream messages with missing channel id",
+			req: &grpcReadersV1.ReadMessagesReq{
+				DomainId:     domain,
+				PageMetadata: &grpcReadersV1.PageMetadata{},
+			},
+			err: errors.ErrMalformedEntity,
+		},
+		{
+			desc: "stream messages with invalid cursor",
+			req: &grpcReadersV1.ReadMessagesReq{
+				ChannelId: channelID,
+				DomainId:  domain,
+				PageMetadata: &grpcReadersV1.PageMetadata{
+					Cursor: "invalid",
+				},
+			},
+			err: apiutil.ErrInvalidCursor,
+		},
+		{
+			desc: "stream messages with failed read",
+			req: &grpcReadersV1.ReadMessagesReq{
+				ChannelId:    channelID,
+				DomainId:     domain,
+				PageMetadata: &grpcReadersV1.PageMetadata{},
+			},
+			svcErr: readers.ErrReadMessages,
+			err:    readers.ErrReadMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := svc.On("StreamAll", mock.Anything, channelID, tc.pageMeta, mock.Anything).Run(func(args mock.Arguments) {
+				if tc.svcErr != nil {
+					return
+				}
+				fn := args.Get(3).(func(readers.Message) error)
+				for _, msg := range msgs {
+					if err := fn(msg); err != nil {
+						return
+					}
+				}
+			}).Return(tc.svcErr)
+			defer repoCall.Unset()
+
+			stream, err := grpcClient.StreamMessages(context.Background(), tc.req)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			count := 0
+			for {
+				_, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				count++
+			}
+			if tc.err == nil {
+				assert.Equal(t, io.EOF, err, fmt.Sprintf("%s: expected end of stream got %s", tc.desc, err))
+				assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.count, count))
+				return
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
 func float64Ptr(v float64) *float64 {
 	return &v
 }
@@ -14,7 +14,7 @@ import (
 
 const maxLimitSize = 1000
 
-var validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT"}
+var validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT", "P50", "P95", "P99", "FIRST", "LAST", "STDDEV", "RATE"}
 
 type readMessagesReq struct {
 	chanID   string
@@ -34,35 +34,68 @@ func (req readMessagesReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	return validatePageMetadata(req.pageMeta)
+}
+
+// streamMessagesReq has no upper limit, and a zero limit streams all the
+// matching messages.
+type streamMessagesReq struct {
+	chanID   string
+	domain   string
+	pageMeta readers.PageMetadata
+}
+
+func (req streamMessagesReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.domain == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return validatePageMetadata(req.pageMeta)
+}
+
+func validatePageMetadata(pm readers.PageMetadata) error {
+	if pm.Comparator != "" &&
+		pm.Comparator != readers.EqualKey &&
+		pm.Comparator != readers.LowerThanKey &&
+		pm.Comparator != readers.LowerThanEqualKey &&
+		pm.Comparator != readers.GreaterThanKey &&
+		pm.Comparator != readers.GreaterThanEqualKey {
 		return apiutil.ErrInvalidComparator
 	}
 
-	if req.pageMeta.Aggregation == "AGGREGATION_UNSPECIFIED" {
-		req.pageMeta.Aggregation = ""
+	if pm.Aggregation == "AGGREGATION_UNSPECIFIED" {
+		pm.Aggregation = ""
 	}
 
-	if agg := strings.ToUpper(req.pageMeta.Aggregation); agg != "" && agg != "AGGREGATION_UNSPECIFIED" {
-		if req.pageMeta.From == 0 {
+	if agg := strings.ToUpper(pm.Aggregation); agg != "" && agg != "AGGREGATION_UNSPECIFIED" {
+		if pm.From == 0 {
 			return apiutil.ErrMissingFrom
 		}
 
-		if req.pageMeta.To == 0 {
+		if pm.To == 0 {
 			return apiutil.ErrMissingTo
 		}
 
-		if !slices.Contains(validAggregations, strings.ToUpper(req.pageMeta.Aggregation)) {
+		if !slices.Contains(validAggregations, strings.ToUpper(pm.Aggregation)) {
 			return apiutil.ErrInvalidAggregation
 		}
 
-		if _, err := time.ParseDuration(req.pageMeta.Interval); err != nil {
+		if _, err := time.ParseDuration(pm.Interval); err != nil {
 			return apiutil.ErrInvalidInterval
 		}
+
+		if pm.Cursor != "" {
+			return apiutil.ErrInvalidCursor
+		}
+	}
+
+	if pm.Cursor != "" {
+		if _, err := readers.DecodeCursor(pm.Cursor); err != nil {
+			return apiutil.ErrInvalidCursor
+		}
 	}
 
 	return nil
@@ -8,8 +8,9 @@ import (
 )
 
 type readMessagesRes struct {
-	Total    uint64
-	Messages []readers.Message
+	Total      uint64
+	NextCursor string
+	Messages   []readers.Message
 	readers.PageMetadata
 }
 
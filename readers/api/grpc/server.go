@@ -12,13 +12,15 @@ import (
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/absmach/supermq/readers"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
 )
 
 var _ grpcReadersV1.ReadersServiceServer = (*readersGrpcServer)(nil)
 
 type readersGrpcServer struct {
 	grpcReadersV1.UnimplementedReadersServiceServer
-	readMessages kitgrpc.Handler
+	readMessages   kitgrpc.Handler
+	streamMessages streamHandler
 }
 
 func NewReadersServer(svc readers.MessageRepository) grpcReadersV1.ReadersServiceServer {
@@ -28,43 +30,58 @@ func NewReadersServer(svc readers.MessageRepository) grpcReadersV1.ReadersServic
 			decodeReadMessagesRequest,
 			encodeReadMessagesResponse,
 		),
+		streamMessages: streamMessagesEndpoint(svc),
 	}
 }
 
 func decodeReadMessagesRequest(_ context.Context, grpcReq any) (any, error) {
 	req := grpcReq.(*grpcReadersV1.ReadMessagesReq)
 	return readMessagesReq{
-		chanID: req.GetChannelId(),
-		domain: req.GetDomainId(),
-		pageMeta: readers.PageMetadata{
-			Offset:      req.GetPageMetadata().GetOffset(),
-			Limit:       req.GetPageMetadata().GetLimit(),
-			Comparator:  req.GetPageMetadata().GetComparator(),
-			Aggregation: stringifyAggregation(req.GetPageMetadata().GetAggregation()),
-			From:        req.GetPageMetadata().GetFrom(),
-			To:          req.GetPageMetadata().GetTo(),
-			Interval:    req.GetPageMetadata().GetInterval(),
-			Subtopic:    req.GetPageMetadata().GetSubtopic(),
-			Publisher:   req.GetPageMetadata().GetPublisher(),
-			Protocol:    req.GetPageMetadata().GetProtocol(),
-			Name:        req.GetPageMetadata().GetName(),
-			Value:       req.GetPageMetadata().GetValue(),
-			BoolValue:   req.GetPageMetadata().GetBoolValue(),
-			StringValue: req.GetPageMetadata().GetStringValue(),
-			DataValue:   req.GetPageMetadata().GetDataValue(),
-			Format:      req.GetPageMetadata().GetFormat(),
-			Order:       req.GetPageMetadata().GetOrder(),
-			Dir:         req.GetPageMetadata().GetDir(),
-		},
+		chanID:   req.GetChannelId(),
+		domain:   req.GetDomainId(),
+		pageMeta: toPageMetadata(req.GetPageMetadata()),
 	}, nil
 }
 
+func decodeStreamMessagesRequest(req *grpcReadersV1.ReadMessagesReq) streamMessagesReq {
+	return streamMessagesReq{
+		chanID:   req.GetChannelId(),
+		domain:   req.GetDomainId(),
+		pageMeta: toPageMetadata(req.GetPageMetadata()),
+	}
+}
+
+func toPageMetadata(pm *grpcReadersV1.PageMetadata) readers.PageMetadata {
+	return readers.PageMetadata{
+		Offset:      pm.GetOffset(),
+		Limit:       pm.GetLimit(),
+		Comparator:  pm.GetComparator(),
+		Aggregation: stringifyAggregation(pm.GetAggregation()),
+		From:        pm.GetFrom(),
+		To:          pm.GetTo(),
+		Interval:    pm.GetInterval(),
+		Subtopic:    pm.GetSubtopic(),
+		Publisher:   pm.GetPublisher(),
+		Protocol:    pm.GetProtocol(),
+		Name:        pm.GetName(),
+		Value:       pm.GetValue(),
+		BoolValue:   pm.GetBoolValue(),
+		StringValue: pm.GetStringValue(),
+		DataValue:   pm.GetDataValue(),
+		Format:      pm.GetFormat(),
+		Order:       pm.GetOrder(),
+		Dir:         pm.GetDir(),
+		Cursor:      pm.GetCursor(),
+	}
+}
+
 func encodeReadMessagesResponse(_ context.Context, grpcRes any) (any, error) {
 	res := grpcRes.(readMessagesRes)
 
 	resp := &grpcReadersV1.ReadMessagesRes{
-		Total:    res.Total,
-		Messages: toResponseMessages(res.Messages),
+		Total:      res.Total,
+		NextCursor: res.NextCursor,
+		Messages:   toResponseMessages(res.Messages),
 		PageMetadata: &grpcReadersV1.PageMetadata{
 			Offset: res.PageMetadata.Offset,
 			Limit:  res.PageMetadata.Limit,
@@ -83,6 +100,21 @@ func (s *readersGrpcServer) ReadMessages(ctx context.Context, req *grpcReadersV1
 	return res.(*grpcReadersV1.ReadMessagesRes), nil
 }
 
+func (s *readersGrpcServer) StreamMessages(req *grpcReadersV1.ReadMessagesReq, stream grpc.ServerStreamingServer[grpcReadersV1.Message]) error {
+	err := s.streamMessages(stream.Context(), decodeStreamMessagesRequest(req), func(msg readers.Message) error {
+		for _, m := range toResponseMessages([]readers.Message{msg}) {
+			if err := stream.Send(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return grpcapi.EncodeError(err)
+	}
+	return nil
+}
+
 func toResponseMessages(messages []readers.Message) []*grpcReadersV1.Message {
 	var res []*grpcReadersV1.Message
 	for _, m := range messages {
@@ -148,6 +180,20 @@ func stringifyAggregation(agg grpcReadersV1.Aggregation) string {
 		return "SUM"
 	case grpcReadersV1.Aggregation_AGGREGATION_COUNT:
 		return "COUNT"
+	case grpcReadersV1.Aggregation_AGGREGATION_P50:
+		return "P50"
+	case grpcReadersV1.Aggregation_AGGREGATION_P95:
+		return "P95"
+	case grpcReadersV1.Aggregation_AGGREGATION_P99:
+		return "P99"
+	case grpcReadersV1.Aggregation_AGGREGATION_FIRST:
+		return "FIRST"
+	case grpcReadersV1.Aggregation_AGGREGATION_LAST:
+		return "LAST"
+	case grpcReadersV1.Aggregation_AGGREGATION_STDDEV:
+		return "STDDEV"
+	case grpcReadersV1.Aggregation_AGGREGATION_RATE:
+		return "RATE"
 	default:
 		return ""
 	}
@@ -12,6 +12,7 @@ import (
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/readers"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -35,7 +36,89 @@ func listMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authenti
 		return pageRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
+			NextCursor:   page.NextCursor,
 			Messages:     page.Messages,
 		}, nil
 	}
 }
+
+func exportMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(exportMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := authnAuthz(ctx, req.listMessagesReq, authn, clients, channels); err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+
+		return exportRes{
+			output: req.output,
+			stream: func(ctx context.Context, fn func(readers.Message) error) error {
+				return svc.StreamAll(ctx, req.chanID, req.pageMeta, fn)
+			},
+		}, nil
+	}
+}
+
+func listFailuresEndpoint(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listFailuresReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		clientID, clientType, err := authenticate(ctx, req.token, req.key, req.domain, authn, clients)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if err := authorize(ctx, clientID, clientType, req.chanID, req.domain, channels); err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+
+		page, err := svc.ReadFailures(ctx, req.chanID, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return failuresRes{
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Total:    page.Total,
+			Failures: page.Failures,
+		}, nil
+	}
+}
+
+func readSeriesEndpoint(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, policy policies.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(readSeriesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		chanIDs, err := seriesAuthnAuthz(ctx, req, authn, clients, channels, policy)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if len(chanIDs) > maxSeriesSize {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrSeriesSize)
+		}
+
+		// The group without the channels the client can read has empty series.
+		width, _ := req.pageMeta.BucketWidth()
+		page := readers.NewSeriesPage(req.pageMeta, width, nil)
+		if len(chanIDs) > 0 {
+			if page, err = svc.ReadSeries(ctx, chanIDs, req.pageMeta); err != nil {
+				return nil, err
+			}
+		}
+
+		return seriesRes{
+			SeriesPageMetadata: page.SeriesPageMetadata,
+			Times:              page.Times,
+			Series:             page.Series,
+		}, nil
+	}
+}
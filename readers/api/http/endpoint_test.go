@@ -6,8 +6,11 @@ package http_test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,7 +22,10 @@ import (
 	"github.com/absmach/supermq/internal/testsutil"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/absmach/supermq/readers"
 	customhttp "github.com/absmach/supermq/readers/api/http"
@@ -52,8 +58,8 @@ var (
 	validSession         = smqauthn.Session{UserID: testsutil.GenerateUUID(&testing.T{})}
 )
 
-func newServer(repo *mocks.MessageRepository, authn *authnmocks.Authentication, clients *climocks.ClientsServiceClient, channels *chmocks.ChannelsServiceClient) *httptest.Server {
-	mux := customhttp.MakeHandler(repo, authn, clients, channels, svcName, instanceID)
+func newServer(repo *mocks.MessageRepository, authn *authnmocks.Authentication, clients *climocks.ClientsServiceClient, channels *chmocks.ChannelsServiceClient, policy *policymocks.Service) *httptest.Server {
+	mux := customhttp.MakeHandler(repo, authn, clients, channels, policy, svcName, instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -134,9 +140,12 @@ func TestReadAll(t *testing.T) {
 	authn := new(authnmocks.Authentication)
 	clients := new(climocks.ClientsServiceClient)
 	channels := new(chmocks.ChannelsServiceClient)
-	ts := newServer(repo, authn, clients, channels)
+	ts := newServer(repo, authn, clients, channels, new(policymocks.Service))
 	defer ts.Close()
 
+	cursor, _ := readers.NewCursor(messages[9])
+	nextCursor, _ := readers.NewCursor(messages[19])
+
 	cases := []struct {
 		desc     string
 		req      string
@@ -161,6 +170,30 @@ func TestReadAll(t *testing.T) {
 				Messages:     messages[0:10],
 			},
 		},
+		{
+			desc:   "read page with cursor",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, domainID, chanID, cursor.Encode()),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Order: "time", Dir: "desc", Cursor: cursor.Encode()},
+				Total:        uint64(len(messages)),
+				NextCursor:   nextCursor.Encode(),
+				Messages:     messages[10:20],
+			},
+		},
+		{
+			desc:   "read page with invalid cursor",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages?limit=10&cursor=invalid", ts.URL, domainID, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with cursor and aggregation",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages?aggregation=MAX&interval=10h&from=%f&to=%f&cursor=%s", ts.URL, domainID, chanID, messages[19].Time, messages[4].Time, cursor.Encode()),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "read page with valid offset and limit as user",
 			url:    fmt.Sprintf("%s/%s/channels/%s/messages?offset=0&limit=10", ts.URL, domainID, chanID),
@@ -491,6 +524,28 @@ func TestReadAll(t *testing.T) {
 				Messages:     messages[5:15],
 			},
 		},
+		{
+			desc:   "read page with P95 aggregation, interval, to and from as client",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages?aggregation=P95&interval=10h&from=%f&to=%f", ts.URL, domainID, chanID, messages[19].Time, messages[4].Time),
+			key:    clientToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Aggregation: "P95", Interval: "10h", From: messages[19].Time, To: messages[4].Time, Order: "time", Dir: "desc"},
+				Total:        uint64(len(messages[5:20])),
+				Messages:     messages[5:15],
+			},
+		},
+		{
+			desc:   "read page with RATE aggregation, interval, to and from as client",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages?aggregation=RATE&interval=10h&from=%f&to=%f", ts.URL, domainID, chanID, messages[19].Time, messages[4].Time),
+			key:    clientToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages", Aggregation: "RATE", Interval: "10h", From: messages[19].Time, To: messages[4].Time, Order: "time", Dir: "desc"},
+				Total:        uint64(len(messages[5:20])),
+				Messages:     messages[5:15],
+			},
+		},
 		{
 			desc:   "read page with invalid aggregation and valid interval, to and from as client",
 			url:    fmt.Sprintf("%s/%s/channels/%s/messages?aggregation=invalid&interval=10h&from=%f&to=%f", ts.URL, domainID, chanID, messages[19].Time, messages[4].Time),
@@ -927,7 +982,7 @@ func TestReadAll(t *testing.T) {
 				tc.authzRes = &grpcChannelsV1.AuthzRes{Authorized: true}
 			}
 			authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(tc.authzRes, tc.authzErr)
-			repoCall := repo.On("ReadAll", chanID, tc.res.PageMetadata).Return(readers.MessagesPage{Total: tc.res.Total, Messages: fromSenml(tc.res.Messages)}, nil)
+			repoCall := repo.On("ReadAll", chanID, tc.res.PageMetadata).Return(readers.MessagesPage{Total: tc.res.Total, NextCursor: tc.res.NextCursor, Messages: fromSenml(tc.res.Messages)}, nil)
 			req := testRequest{
 				client: ts.Client(),
 				method: http.MethodGet,
@@ -945,6 +1000,148 @@ func TestReadAll(t *testing.T) {
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
 			assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.res.Total, page.Total))
 			assert.ElementsMatch(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: got incorrect body from response", tc.desc))
+			assert.Equal(t, tc.res.NextCursor, page.NextCursor, fmt.Sprintf("%s: expected next cursor %s got %s", tc.desc, tc.res.NextCursor, page.NextCursor))
+			authzCall.Unset()
+			authnCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestExportMessages(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	now := time.Now().UnixNano()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		})
+	}
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels, new(policymocks.Service))
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		pageMeta    readers.PageMetadata
+		status      int
+		contentType string
+		lines       int
+		authnErr    error
+		repoErr     error
+	}{
+		{
+			desc:        "export messages as ndjson",
+			url:         fmt.Sprintf("%s/%s/channels/%s/messages/export", ts.URL, domainID, chanID),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Format: "messages", Order: "time", Dir: "desc"},
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       numOfMessages,
+		},
+		{
+			desc:        "export messages as csv",
+			url:         fmt.Sprintf("%s/%s/channels/%s/messages/export?output=csv&from=%f", ts.URL, domainID, chanID, messages[10].Time),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Format: "messages", Order: "time", Dir: "desc", From: messages[10].Time},
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       numOfMessages + 1,
+		},
+		{
+			desc:        "export messages as senml",
+			url:         fmt.Sprintf("%s/%s/channels/%s/messages/export?output=senml&limit=1000", ts.URL, domainID, chanID),
+			token:       userToken,
+			pageMeta:    readers.PageMetadata{Limit: 1000, Format: "messages", Order: "time", Dir: "desc"},
+			status:      http.StatusOK,
+			contentType: "application/senml+json",
+			lines:       1,
+		},
+		{
+			desc:   "export json messages as senml",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages/export?output=senml&format=json", ts.URL, domainID, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid output",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages/export?output=xml", ts.URL, domainID, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid cursor",
+			url:    fmt.Sprintf("%s/%s/channels/%s/messages/export?cursor=invalid", ts.URL, domainID, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "export messages with invalid token",
+			url:      fmt.Sprintf("%s/%s/channels/%s/messages/export", ts.URL, domainID, chanID),
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "export messages with failed read",
+			url:      fmt.Sprintf("%s/%s/channels/%s/messages/export", ts.URL, domainID, chanID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Format: "messages", Order: "time", Dir: "desc"},
+			status:   http.StatusInternalServerError,
+			repoErr:  readers.ErrReadMessages,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(validSession, tc.authnErr)
+			authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+			repoCall := repo.On("StreamAll", mock.Anything, chanID, tc.pageMeta, mock.Anything).Run(func(args mock.Arguments) {
+				if tc.repoErr != nil {
+					return
+				}
+				fn := args.Get(3).(func(readers.Message) error)
+				for _, msg := range messages {
+					if err := fn(msg); err != nil {
+						return
+					}
+				}
+			}).Return(tc.repoErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    tc.url,
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while reading response body: %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: got incorrect content type", tc.desc))
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				assert.Len(t, lines, tc.lines, fmt.Sprintf("%s: got incorrect number of lines", tc.desc))
+			}
+			if tc.contentType == "application/senml+json" {
+				var pack []map[string]any
+				err = json.Unmarshal(body, &pack)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding SenML pack: %s", tc.desc, err))
+				assert.Len(t, pack, numOfMessages, fmt.Sprintf("%s: got incorrect number of records", tc.desc))
+			}
 			authzCall.Unset()
 			authnCall.Unset()
 			repoCall.Unset()
@@ -954,8 +1151,9 @@ func TestReadAll(t *testing.T) {
 
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64          `json:"total"`
-	Messages []senml.Message `json:"messages"`
+	Total      uint64          `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Messages   []senml.Message `json:"messages"`
 }
 
 func fromSenml(in []senml.Message) []readers.Message {
@@ -965,3 +1163,441 @@ func fromSenml(in []senml.Message) []readers.Message {
 	}
 	return ret
 }
+
+func TestReadSeries(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	chanID2 := testsutil.GenerateUUID(t)
+	from := float64(time.Now().Add(-time.Hour).UnixNano())
+	to := float64(time.Now().UnixNano())
+
+	pageMeta := readers.SeriesPageMetadata{
+		Names:       []string{msgName, "humidity"},
+		From:        from,
+		To:          to,
+		Aggregation: "avg",
+		Interval:    "1m",
+	}
+	page := readers.NewSeriesPage(pageMeta, int64(time.Minute), []readers.SeriesPoint{
+		{Time: math.Floor(from/float64(time.Minute)) * float64(time.Minute), Channel: chanID, Name: msgName, Value: &v},
+		{Time: math.Floor(from/float64(time.Minute)) * float64(time.Minute), Channel: chanID2, Name: "humidity", Value: &v},
+	})
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels, new(policymocks.Service))
+	defer ts.Close()
+
+	cases := []struct {
+		desc       string
+		url        string
+		token      string
+		chanIDs    []string
+		pageMeta   readers.SeriesPageMetadata
+		authorized bool
+		authnErr   error
+		repoRes    readers.SeriesPage
+		repoErr    error
+		status     int
+	}{
+		{
+			desc:       "read series of multiple channels and measurements",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s,%s&names=%s,humidity&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, chanID2, msgName, from, to),
+			token:      userToken,
+			chanIDs:    []string{chanID, chanID2},
+			pageMeta:   pageMeta,
+			authorized: true,
+			repoRes:    page,
+			status:     http.StatusOK,
+		},
+		{
+			desc:       "read series of all measurements",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=max&interval=1m", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			chanIDs:    []string{chanID},
+			pageMeta:   readers.SeriesPageMetadata{Names: []string{}, From: from, To: to, Aggregation: "max", Interval: "1m"},
+			authorized: true,
+			repoRes:    readers.SeriesPage{Times: []float64{}, Series: []readers.Series{}},
+			status:     http.StatusOK,
+		},
+		{
+			desc:       "read series with unauthorized channel",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s,%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, chanID2, from, to),
+			token:      userToken,
+			authorized: false,
+			status:     http.StatusForbidden,
+		},
+		{
+			desc:       "read series with invalid token",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, from, to),
+			token:      invalidToken,
+			authorized: true,
+			authnErr:   svcerr.ErrAuthentication,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			desc:       "read series without channels",
+			url:        fmt.Sprintf("%s/%s/messages/series?from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, from, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series with empty channel",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s,&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series without from",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series without to",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, from),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series with invalid aggregation",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=invalid&interval=1m", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series with invalid interval",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=avg&interval=invalid", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series with too many buckets",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=avg&interval=1ms", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "read series with failed read",
+			url:        fmt.Sprintf("%s/%s/messages/series?channels=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID, from, to),
+			token:      userToken,
+			chanIDs:    []string{chanID},
+			pageMeta:   readers.SeriesPageMetadata{Names: []string{}, From: from, To: to, Aggregation: "avg", Interval: "1m"},
+			authorized: true,
+			repoErr:    readers.ErrReadMessages,
+			status:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(validSession, tc.authnErr)
+			authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: tc.authorized}, nil)
+			repoCall := repo.On("ReadSeries", mock.Anything, tc.chanIDs, tc.pageMeta).Return(tc.repoRes, tc.repoErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    tc.url,
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Times  []float64        `json:"times"`
+					Series []readers.Series `json:"series"`
+				}
+				err = json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				assert.Equal(t, tc.repoRes.Times, body.Times, fmt.Sprintf("%s: got incorrect times", tc.desc))
+				assert.Equal(t, tc.repoRes.Series, body.Series, fmt.Sprintf("%s: got incorrect series", tc.desc))
+				ok := repoCall.Parent.AssertCalled(t, "ReadSeries", mock.Anything, tc.chanIDs, tc.pageMeta)
+				assert.True(t, ok)
+			}
+			authzCall.Unset()
+			authnCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReadGroupSeries(t *testing.T) {
+	groupID := testsutil.GenerateUUID(t)
+	subgroupID := testsutil.GenerateUUID(t)
+	chanID := testsutil.GenerateUUID(t)
+	chanID2 := testsutil.GenerateUUID(t)
+	chanID3 := testsutil.GenerateUUID(t)
+	deniedChanID := testsutil.GenerateUUID(t)
+	from := float64(time.Now().Add(-time.Hour).UnixNano())
+	to := float64(time.Now().UnixNano())
+	pageMeta := readers.SeriesPageMetadata{Names: []string{}, From: from, To: to, Aggregation: "avg", Interval: "1m"}
+	page := readers.NewSeriesPage(pageMeta, int64(time.Minute), []readers.SeriesPoint{
+		{Time: math.Floor(from/float64(time.Minute)) * float64(time.Minute), Channel: chanID, Name: msgName, Value: &v},
+	})
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	policy := new(policymocks.Service)
+	ts := newServer(repo, authn, clients, channels, policy)
+	defer ts.Close()
+
+	groupChannels := policies.Policy{
+		SubjectType: policies.GroupType,
+		Subject:     groupID,
+		Permission:  policies.ParentGroupRelation,
+		ObjectType:  policies.ChannelType,
+	}
+	subgroups := groupChannels
+	subgroups.ObjectType = policies.GroupType
+	subgroupChannels := groupChannels
+	subgroupChannels.Subject = subgroupID
+	subgroupSubgroups := subgroups
+	subgroupSubgroups.Subject = subgroupID
+
+	authn.On("Authenticate", mock.Anything, userToken).Return(validSession, nil)
+	allowed := mock.MatchedBy(func(req *grpcChannelsV1.AuthzReq) bool { return req.GetChannelId() != deniedChanID })
+	denied := mock.MatchedBy(func(req *grpcChannelsV1.AuthzReq) bool { return req.GetChannelId() == deniedChanID })
+	channels.On("Authorize", mock.Anything, allowed).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+	channels.On("Authorize", mock.Anything, denied).Return(&grpcChannelsV1.AuthzRes{Authorized: false}, nil)
+
+	cases := []struct {
+		desc         string
+		url          string
+		group        []string
+		policyErr    error
+		subgroups    []string
+		subgroup     []string
+		subgroupsErr error
+		chanIDs      []string
+		repoRes      readers.SeriesPage
+		status       int
+	}{
+		{
+			desc:    "read series of the group channels the client can read",
+			url:     fmt.Sprintf("%s/%s/messages/series?group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, groupID, from, to),
+			group:   []string{chanID, deniedChanID, chanID2},
+			chanIDs: []string{chanID, chanID2},
+			repoRes: page,
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "read series of the channels and the group channels",
+			url:     fmt.Sprintf("%s/%s/messages/series?channels=%s&group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, chanID2, groupID, from, to),
+			group:   []string{chanID, chanID2},
+			chanIDs: []string{chanID2, chanID},
+			repoRes: page,
+			status:  http.StatusOK,
+		},
+		{
+			desc:      "read series of the group and subgroup channels",
+			url:       fmt.Sprintf("%s/%s/messages/series?group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, groupID, from, to),
+			group:     []string{chanID},
+			subgroups: []string{subgroupID},
+			subgroup:  []string{chanID3, deniedChanID},
+			chanIDs:   []string{chanID, chanID3},
+			repoRes:   page,
+			status:    http.StatusOK,
+		},
+		{
+			desc:    "read series of the group without readable channels",
+			url:     fmt.Sprintf("%s/%s/messages/series?group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, groupID, from, to),
+			group:   []string{deniedChanID},
+			repoRes: readers.NewSeriesPage(pageMeta, int64(time.Minute), nil),
+			status:  http.StatusOK,
+		},
+		{
+			desc:   "read series of the group with unauthorized channel",
+			url:    fmt.Sprintf("%s/%s/messages/series?channels=%s&group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, deniedChanID, groupID, from, to),
+			group:  []string{chanID},
+			status: http.StatusForbidden,
+		},
+		{
+			desc:      "read series of the group with failed channels lookup",
+			url:       fmt.Sprintf("%s/%s/messages/series?group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, groupID, from, to),
+			policyErr: errors.New("failed to list group channels"),
+			status:    http.StatusUnprocessableEntity,
+		},
+		{
+			desc:         "read series of the group with failed subgroups lookup",
+			url:          fmt.Sprintf("%s/%s/messages/series?group_id=%s&from=%f&to=%f&aggregation=avg&interval=1m", ts.URL, domainID, groupID, from, to),
+			group:        []string{chanID},
+			subgroupsErr: errors.New("failed to list subgroups"),
+			status:       http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			policyCall := policy.On("ListAllObjects", mock.Anything, groupChannels).Return(policies.PolicyPage{Policies: tc.group}, tc.policyErr)
+			policyCall1 := policy.On("ListAllObjects", mock.Anything, subgroups).Return(policies.PolicyPage{Policies: tc.subgroups}, tc.subgroupsErr)
+			policyCall2 := policy.On("ListAllObjects", mock.Anything, subgroupChannels).Return(policies.PolicyPage{Policies: tc.subgroup}, nil)
+			// The parent group listed as the subgroup must not be visited again.
+			policyCall3 := policy.On("ListAllObjects", mock.Anything, subgroupSubgroups).Return(policies.PolicyPage{Policies: []string{groupID}}, nil)
+			repoCall := repo.On("ReadSeries", mock.Anything, tc.chanIDs, pageMeta).Return(tc.repoRes, nil)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    tc.url,
+				token:  userToken,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Times  []float64        `json:"times"`
+					Series []readers.Series `json:"series"`
+				}
+				err = json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				assert.Equal(t, tc.repoRes.Times, body.Times, fmt.Sprintf("%s: got incorrect times", tc.desc))
+				assert.Equal(t, tc.repoRes.Series, body.Series, fmt.Sprintf("%s: got incorrect series", tc.desc))
+				if len(tc.chanIDs) == 0 {
+					repo.AssertNotCalled(t, "ReadSeries", mock.Anything, nil, mock.Anything)
+				}
+			}
+			policyCall.Unset()
+			policyCall1.Unset()
+			policyCall2.Unset()
+			policyCall3.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListFailures(t *testing.T) {
+	chanID := testsutil.GenerateUUID(t)
+	page := readers.FailuresPage{
+		Offset: 0,
+		Limit:  10,
+		Total:  1,
+		Failures: []readers.Failure{
+			{Created: time.Now().UnixNano(), Protocol: mqttProt, Payload: `{"temperature":"hot"}`, Reason: "message payload violates channel contract"},
+		},
+	}
+
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels, new(policymocks.Service))
+	defer ts.Close()
+
+	cases := []struct {
+		desc       string
+		url        string
+		token      string
+		offset     uint64
+		limit      uint64
+		authorized bool
+		authnErr   error
+		repoRes    readers.FailuresPage
+		repoErr    error
+		status     int
+	}{
+		{
+			desc:       "list failures",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures", ts.URL, domainID, chanID),
+			token:      userToken,
+			limit:      10,
+			authorized: true,
+			repoRes:    page,
+			status:     http.StatusOK,
+		},
+		{
+			desc:       "list failures with offset and limit",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures?offset=5&limit=20", ts.URL, domainID, chanID),
+			token:      userToken,
+			offset:     5,
+			limit:      20,
+			authorized: true,
+			repoRes:    readers.FailuresPage{Offset: 5, Limit: 20, Total: 1, Failures: []readers.Failure{}},
+			status:     http.StatusOK,
+		},
+		{
+			desc:       "list failures with unauthorized channel",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures", ts.URL, domainID, chanID),
+			token:      userToken,
+			authorized: false,
+			status:     http.StatusForbidden,
+		},
+		{
+			desc:       "list failures with invalid token",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures", ts.URL, domainID, chanID),
+			token:      invalidToken,
+			authorized: true,
+			authnErr:   svcerr.ErrAuthentication,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			desc:       "list failures without token",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures", ts.URL, domainID, chanID),
+			authorized: true,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			desc:       "list failures with limit too big",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures?limit=1001", ts.URL, domainID, chanID),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "list failures with invalid offset",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures?offset=invalid", ts.URL, domainID, chanID),
+			token:      userToken,
+			authorized: true,
+			status:     http.StatusBadRequest,
+		},
+		{
+			desc:       "list failures with failed read",
+			url:        fmt.Sprintf("%s/%s/channels/%s/messages/failures", ts.URL, domainID, chanID),
+			token:      userToken,
+			limit:      10,
+			authorized: true,
+			repoErr:    readers.ErrReadMessages,
+			status:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(validSession, tc.authnErr)
+			authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: tc.authorized}, nil)
+			repoCall := repo.On("ReadFailures", mock.Anything, chanID, tc.offset, tc.limit).Return(tc.repoRes, tc.repoErr)
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    tc.url,
+				token:  tc.token,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var body struct {
+					Total    uint64            `json:"total"`
+					Failures []readers.Failure `json:"failures"`
+				}
+				err = json.NewDecoder(res.Body).Decode(&body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				assert.Equal(t, tc.repoRes.Total, body.Total, fmt.Sprintf("%s: got incorrect total", tc.desc))
+				assert.Equal(t, tc.repoRes.Failures, body.Failures, fmt.Sprintf("%s: got incorrect failures", tc.desc))
+			}
+			authzCall.Unset()
+			authnCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
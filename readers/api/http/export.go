@@ -0,0 +1,221 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/absmach/senml"
+	smqsenml "github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+)
+
+// Output formats of the exported messages.
+const (
+	ndjsonOutput = "ndjson"
+	csvOutput    = "csv"
+	senmlOutput  = "senml"
+)
+
+// Messages are stored with nanosecond timestamps, while SenML uses seconds.
+const nanosInSecond = 1e9
+
+var outputContentTypes = map[string]string{
+	ndjsonOutput: "application/x-ndjson",
+	csvOutput:    "text/csv",
+	senmlOutput:  smqsenml.JSON,
+}
+
+var (
+	senmlHeader = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit", "time", "update_time", "value", "string_value", "data_value", "bool_value", "sum"}
+	jsonHeader  = []string{"channel", "created", "subtopic", "publisher", "protocol", "payload"}
+)
+
+// messageEncoder writes the messages one by one, so the export does not
+// need to hold them in memory.
+type messageEncoder interface {
+	Encode(msg readers.Message) error
+	Close() error
+}
+
+func newMessageEncoder(output string, w io.Writer) messageEncoder {
+	switch output {
+	case csvOutput:
+		return &csvEncoder{w: csv.NewWriter(w)}
+	case senmlOutput:
+		return &senmlEncoder{w: w}
+	default:
+		return ndjsonEncoder{enc: json.NewEncoder(w)}
+	}
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e ndjsonEncoder) Encode(msg readers.Message) error {
+	return e.enc.Encode(msg)
+}
+
+func (e ndjsonEncoder) Close() error {
+	return nil
+}
+
+// csvEncoder writes the header of the first message type, since all the
+// messages of the export are read from the same table.
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvEncoder) Encode(msg readers.Message) error {
+	var header, record []string
+	switch m := msg.(type) {
+	case smqsenml.Message:
+		header = senmlHeader
+		record = []string{
+			m.Channel,
+			m.Subtopic,
+			m.Publisher,
+			m.Protocol,
+			m.Name,
+			m.Unit,
+			formatFloat(m.Time),
+			formatFloat(m.UpdateTime),
+			formatOptionalFloat(m.Value),
+			formatOptionalString(m.StringValue),
+			formatOptionalString(m.DataValue),
+			formatOptionalBool(m.BoolValue),
+			formatOptionalFloat(m.Sum),
+		}
+	case map[string]any:
+		payload, err := json.Marshal(m["payload"])
+		if err != nil {
+			return err
+		}
+		header = jsonHeader
+		record = []string{
+			formatAny(m["channel"]),
+			formatAny(m["created"]),
+			formatAny(m["subtopic"]),
+			formatAny(m["publisher"]),
+			formatAny(m["protocol"]),
+			string(payload),
+		}
+	default:
+		return nil
+	}
+	if !e.header {
+		if err := e.w.Write(header); err != nil {
+			return err
+		}
+		e.header = true
+	}
+
+	return e.w.Write(record)
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// senmlEncoder writes the messages as a SenML pack, element by element.
+type senmlEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *senmlEncoder) Encode(msg readers.Message) error {
+	m, ok := msg.(smqsenml.Message)
+	if !ok {
+		return nil
+	}
+	t := m.Time
+	if t >= 1e18 {
+		t /= nanosInSecond
+	}
+	ut := m.UpdateTime
+	if ut >= 1e18 {
+		ut /= nanosInSecond
+	}
+	data, err := json.Marshal(senml.Record{
+		Name:        m.Name,
+		Unit:        m.Unit,
+		Time:        t,
+		UpdateTime:  ut,
+		Value:       m.Value,
+		StringValue: m.StringValue,
+		DataValue:   m.DataValue,
+		BoolValue:   m.BoolValue,
+		Sum:         m.Sum,
+	})
+	if err != nil {
+		return err
+	}
+	sep := ","
+	if e.count == 0 {
+		sep = "["
+	}
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+	e.count++
+
+	return nil
+}
+
+func (e *senmlEncoder) Close() error {
+	end := "]"
+	if e.count == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatOptionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+func formatOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func formatAny(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return formatFloat(v)
+	default:
+		return ""
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/absmach/supermq/readers"
 )
 
-const maxLimitSize = 1000
+const (
+	maxLimitSize   = 1000
+	maxSeriesSize  = 100
+	maxBucketsSize = 10000
+)
 
-var validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT"}
+var validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT", "P50", "P95", "P99", "FIRST", "LAST", "STDDEV", "RATE"}
 
 type listMessagesReq struct {
 	chanID   string
@@ -37,31 +41,150 @@ func (req listMessagesReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	return validatePageMetadata(req.pageMeta)
+}
+
+type exportMessagesReq struct {
+	listMessagesReq
+	output string
+}
+
+// validate does not limit the number of the exported messages, and a zero
+// limit exports all the matching messages.
+func (req exportMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	switch req.output {
+	case ndjsonOutput, csvOutput:
+	case senmlOutput:
+		if req.pageMeta.Format != defFormat {
+			return apiutil.ErrInvalidExportFormat
+		}
+	default:
+		return apiutil.ErrInvalidExportFormat
+	}
+
+	return validatePageMetadata(req.pageMeta)
+}
+
+type listFailuresReq struct {
+	chanID string
+	token  string
+	domain string
+	key    string
+	offset uint64
+	limit  uint64
+}
+
+func (req listFailuresReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type readSeriesReq struct {
+	chanIDs  []string
+	groupID  string
+	token    string
+	domain   string
+	key      string
+	pageMeta readers.SeriesPageMetadata
+}
+
+func (req readSeriesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.chanIDs) == 0 && req.groupID == "" {
+		return apiutil.ErrMissingChannelID
+	}
+
+	if slices.Contains(req.chanIDs, "") {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.chanIDs) > maxSeriesSize || len(req.pageMeta.Names) > maxSeriesSize {
+		return apiutil.ErrSeriesSize
+	}
+
+	if req.pageMeta.From == 0 {
+		return apiutil.ErrMissingFrom
+	}
+
+	if req.pageMeta.To == 0 {
+		return apiutil.ErrMissingTo
+	}
+
+	if !slices.Contains(validAggregations, strings.ToUpper(req.pageMeta.Aggregation)) {
+		return apiutil.ErrInvalidAggregation
+	}
+
+	width, err := req.pageMeta.BucketWidth()
+	if err != nil || width <= 0 {
+		return apiutil.ErrInvalidInterval
+	}
+
+	if (req.pageMeta.To-req.pageMeta.From)/float64(width) > maxBucketsSize {
+		return apiutil.ErrBucketsSize
+	}
+
+	return nil
+}
+
+func validatePageMetadata(pm readers.PageMetadata) error {
+	if pm.Comparator != "" &&
+		pm.Comparator != readers.EqualKey &&
+		pm.Comparator != readers.LowerThanKey &&
+		pm.Comparator != readers.LowerThanEqualKey &&
+		pm.Comparator != readers.GreaterThanKey &&
+		pm.Comparator != readers.GreaterThanEqualKey {
 		return apiutil.ErrInvalidComparator
 	}
 
-	if req.pageMeta.Aggregation != "" {
-		if req.pageMeta.From == 0 {
+	if pm.Aggregation != "" {
+		if pm.From == 0 {
 			return apiutil.ErrMissingFrom
 		}
 
-		if req.pageMeta.To == 0 {
+		if pm.To == 0 {
 			return apiutil.ErrMissingTo
 		}
 
-		if !slices.Contains(validAggregations, strings.ToUpper(req.pageMeta.Aggregation)) {
+		if !slices.Contains(validAggregations, strings.ToUpper(pm.Aggregation)) {
 			return apiutil.ErrInvalidAggregation
 		}
 
-		if _, err := time.ParseDuration(req.pageMeta.Interval); err != nil {
+		if _, err := time.ParseDuration(pm.Interval); err != nil {
 			return apiutil.ErrInvalidInterval
 		}
+
+		// Aggregated messages are not keyed by the cursor.
+		if pm.Cursor != "" {
+			return apiutil.ErrInvalidCursor
+		}
+	}
+
+	if pm.Cursor != "" {
+		if _, err := readers.DecodeCursor(pm.Cursor); err != nil {
+			return apiutil.ErrInvalidCursor
+		}
 	}
 
 	return nil
@@ -4,18 +4,24 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/readers"
 )
 
-var _ supermq.Response = (*pageRes)(nil)
+var (
+	_ supermq.Response = (*pageRes)(nil)
+	_ supermq.Response = (*seriesRes)(nil)
+	_ supermq.Response = (*failuresRes)(nil)
+)
 
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64            `json:"total"`
-	Messages []readers.Message `json:"messages"`
+	Total      uint64            `json:"total"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Messages   []readers.Message `json:"messages"`
 }
 
 func (res pageRes) Headers() map[string]string {
@@ -29,3 +35,47 @@ func (res pageRes) Code() int {
 func (res pageRes) Empty() bool {
 	return false
 }
+
+type seriesRes struct {
+	readers.SeriesPageMetadata
+	Times  []float64        `json:"times"`
+	Series []readers.Series `json:"series"`
+}
+
+func (res seriesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res seriesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res seriesRes) Empty() bool {
+	return false
+}
+
+type failuresRes struct {
+	Offset   uint64            `json:"offset"`
+	Limit    uint64            `json:"limit"`
+	Total    uint64            `json:"total"`
+	Failures []readers.Failure `json:"failures"`
+}
+
+func (res failuresRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res failuresRes) Code() int {
+	return http.StatusOK
+}
+
+func (res failuresRes) Empty() bool {
+	return false
+}
+
+// exportRes streams the messages while the response is being encoded, so
+// they are never held in memory all at once.
+type exportRes struct {
+	output string
+	stream func(ctx context.Context, fn func(readers.Message) error) error
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
@@ -22,6 +24,8 @@ import (
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -42,6 +46,11 @@ const (
 	toKey          = "to"
 	aggregationKey = "aggregation"
 	intervalKey    = "interval"
+	cursorKey      = "cursor"
+	outputKey      = "output"
+	channelsKey    = "channels"
+	groupKey       = "group_id"
+	namesKey       = "names"
 	defInterval    = "1s"
 	defLimit       = 10
 	defOffset      = 0
@@ -49,7 +58,7 @@ const (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, svcName, instanceID string) http.Handler {
+func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, policy policies.Service, svcName, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(api.EncodeError),
 	}
@@ -62,6 +71,27 @@ func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, c
 		opts...,
 	).ServeHTTP)
 
+	mux.Get("/{domainID}/channels/{chanID}/messages/export", kithttp.NewServer(
+		exportMessagesEndpoint(svc, authn, clients, channels),
+		decodeExport,
+		encodeExportResponse,
+		opts...,
+	).ServeHTTP)
+
+	mux.Get("/{domainID}/channels/{chanID}/messages/failures", kithttp.NewServer(
+		listFailuresEndpoint(svc, authn, clients, channels),
+		decodeFailures,
+		encodeResponse,
+		opts...,
+	).ServeHTTP)
+
+	mux.Get("/{domainID}/messages/series", kithttp.NewServer(
+		readSeriesEndpoint(svc, authn, clients, channels, policy),
+		decodeSeries,
+		encodeResponse,
+		opts...,
+	).ServeHTTP)
+
 	mux.Get("/health", supermq.Health(svcName, instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -69,6 +99,46 @@ func MakeHandler(svc readers.MessageRepository, authn smqauthn.Authentication, c
 }
 
 func decodeList(_ context.Context, r *http.Request) (any, error) {
+	pm, err := decodePageMetadata(r, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listMessagesReq{
+		chanID:   chi.URLParam(r, "chanID"),
+		token:    apiutil.ExtractBearerToken(r),
+		domain:   chi.URLParam(r, "domainID"),
+		key:      apiutil.ExtractClientSecret(r),
+		pageMeta: pm,
+	}
+	return req, nil
+}
+
+func decodeExport(_ context.Context, r *http.Request) (any, error) {
+	pm, err := decodePageMetadata(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := apiutil.ReadStringQuery(r, outputKey, ndjsonOutput)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := exportMessagesReq{
+		listMessagesReq: listMessagesReq{
+			chanID:   chi.URLParam(r, "chanID"),
+			token:    apiutil.ExtractBearerToken(r),
+			domain:   chi.URLParam(r, "domainID"),
+			key:      apiutil.ExtractClientSecret(r),
+			pageMeta: pm,
+		},
+		output: output,
+	}
+	return req, nil
+}
+
+func decodeFailures(_ context.Context, r *http.Request) (any, error) {
 	offset, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
@@ -79,7 +149,29 @@ func decodeList(_ context.Context, r *http.Request) (any, error) {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	req := listFailuresReq{
+		chanID: chi.URLParam(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		domain: chi.URLParam(r, "domainID"),
+		key:    apiutil.ExtractClientSecret(r),
+		offset: offset,
+		limit:  limit,
+	}
+	return req, nil
+}
+
+func decodeSeries(_ context.Context, r *http.Request) (any, error) {
+	chanIDs, err := readListQuery(r, channelsKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	groupID, err := apiutil.ReadStringQuery(r, groupKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	names, err := readListQuery(r, namesKey)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
@@ -99,96 +191,180 @@ func decodeList(_ context.Context, r *http.Request) (any, error) {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	from, err := apiutil.ReadNumQuery[float64](r, fromKey, 0)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	v, err := apiutil.ReadNumQuery[float64](r, valueKey, 0)
+	to, err := apiutil.ReadNumQuery[float64](r, toKey, 0)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	comparator, err := apiutil.ReadStringQuery(r, comparatorKey, "")
+	aggregation, err := apiutil.ReadStringQuery(r, aggregationKey, "")
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
-	vs, err := apiutil.ReadStringQuery(r, stringValueKey, "")
+	interval, err := apiutil.ReadStringQuery(r, intervalKey, defInterval)
 	if err != nil {
 		return nil, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
+	req := readSeriesReq{
+		chanIDs: chanIDs,
+		groupID: groupID,
+		token:   apiutil.ExtractBearerToken(r),
+		domain:  chi.URLParam(r, "domainID"),
+		key:     apiutil.ExtractClientSecret(r),
+		pageMeta: readers.SeriesPageMetadata{
+			Names:       names,
+			Subtopic:    subtopic,
+			Publisher:   publisher,
+			Protocol:    protocol,
+			From:        from,
+			To:          to,
+			Aggregation: aggregation,
+			Interval:    interval,
+		},
+	}
+	return req, nil
+}
+
+// readListQuery reads the comma separated list of values.
+func readListQuery(r *http.Request, key string) ([]string, error) {
+	val, err := apiutil.ReadStringQuery(r, key, "")
+	if err != nil {
+		return nil, err
+	}
+	if val == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(val, ","), nil
+}
+
+func decodePageMetadata(r *http.Request, defLimit uint64) (readers.PageMetadata, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, offsetKey, defOffset)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	limit, err := apiutil.ReadNumQuery[uint64](r, limitKey, defLimit)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	v, err := apiutil.ReadNumQuery[float64](r, valueKey, 0)
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	comparator, err := apiutil.ReadStringQuery(r, comparatorKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	vs, err := apiutil.ReadStringQuery(r, stringValueKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	vd, err := apiutil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
 	if err != nil && err != apiutil.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := apiutil.ReadNumQuery[float64](r, fromKey, 0)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	to, err := apiutil.ReadNumQuery[float64](r, toKey, 0)
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	aggregation, err := apiutil.ReadStringQuery(r, aggregationKey, "")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	order, err := apiutil.ReadStringQuery(r, api.OrderKey, "time")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	dir, err := apiutil.ReadStringQuery(r, api.DirKey, "desc")
 	if err != nil {
-		return nil, errors.Wrap(apiutil.ErrValidation, err)
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	var interval string
 	if aggregation != "" {
 		interval, err = apiutil.ReadStringQuery(r, intervalKey, defInterval)
 		if err != nil {
-			return nil, errors.Wrap(apiutil.ErrValidation, err)
+			return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 		}
 	}
 
-	req := listMessagesReq{
-		chanID: chi.URLParam(r, "chanID"),
-		token:  apiutil.ExtractBearerToken(r),
-		domain: chi.URLParam(r, "domainID"),
-		key:    apiutil.ExtractClientSecret(r),
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			BoolValue:   vb,
-			From:        from,
-			To:          to,
-			Aggregation: aggregation,
-			Interval:    interval,
-			Order:       order,
-			Dir:         dir,
-		},
+	cursor, err := apiutil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	return req, nil
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Publisher:   publisher,
+		Protocol:    protocol,
+		Name:        name,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		BoolValue:   vb,
+		From:        from,
+		To:          to,
+		Aggregation: aggregation,
+		Interval:    interval,
+		Order:       order,
+		Dir:         dir,
+		Cursor:      cursor,
+	}
+	return pm, nil
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response any) error {
@@ -209,8 +385,31 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response any) erro
 	return json.NewEncoder(w).Encode(response)
 }
 
+// encodeExportResponse writes the messages as they are read. Once the first
+// message is streamed the status can't be changed, so later errors abort the
+// response and the client sees an incomplete transfer.
+func encodeExportResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res := response.(exportRes)
+	w.Header().Set("Content-Type", outputContentTypes[res.output])
+
+	enc := newMessageEncoder(res.output, w)
+	streamed := false
+	err := res.stream(ctx, func(msg readers.Message) error {
+		streamed = true
+		return enc.Encode(msg)
+	})
+	if err == nil {
+		err = enc.Close()
+	}
+	if err != nil && streamed {
+		panic(http.ErrAbortHandler)
+	}
+
+	return err
+}
+
 func authnAuthz(ctx context.Context, req listMessagesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) error {
-	clientID, clientType, err := authenticate(ctx, req, authn, clients)
+	clientID, clientType, err := authenticate(ctx, req.token, req.key, req.domain, authn, clients)
 	if err != nil {
 		return err
 	}
@@ -220,10 +419,10 @@ func authnAuthz(ctx context.Context, req listMessagesReq, authn smqauthn.Authent
 	return nil
 }
 
-func authenticate(ctx context.Context, req listMessagesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient) (clientID string, clientType string, err error) {
+func authenticate(ctx context.Context, token, key, domain string, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient) (clientID string, clientType string, err error) {
 	switch {
-	case req.token != "":
-		session, err := authn.Authenticate(ctx, req.token)
+	case token != "":
+		session, err := authn.Authenticate(ctx, token)
 		if err != nil {
 			return "", "", err
 		}
@@ -231,10 +430,10 @@ func authenticate(ctx context.Context, req listMessagesReq, authn smqauthn.Authe
 			return session.UserID, policies.UserType, nil
 		}
 
-		return policies.EncodeDomainUserID(req.domain, session.UserID), policies.UserType, nil
-	case req.key != "":
+		return policies.EncodeDomainUserID(domain, session.UserID), policies.UserType, nil
+	case key != "":
 		res, err := clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{
-			Token: smqauthn.AuthPack(smqauthn.DomainAuth, req.domain, req.key),
+			Token: smqauthn.AuthPack(smqauthn.DomainAuth, domain, key),
 		})
 		if err != nil {
 			return "", "", err
@@ -248,7 +447,90 @@ func authenticate(ctx context.Context, req listMessagesReq, authn smqauthn.Authe
 	}
 }
 
+// seriesAuthnAuthz authorizes the client for each channel of the series, so the
+// series can't include the messages of the channels the client can't read, and
+// returns the channels of the series. The channels of the group are resolved from
+// the policies, and the ones the client can't read are left out of the series.
+func seriesAuthnAuthz(ctx context.Context, req readSeriesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, policy policies.Service) ([]string, error) {
+	clientID, clientType, err := authenticate(ctx, req.token, req.key, req.domain, authn, clients)
+	if err != nil {
+		return nil, err
+	}
+	for _, chanID := range req.chanIDs {
+		if err := authorize(ctx, clientID, clientType, chanID, req.domain, channels); err != nil {
+			return nil, err
+		}
+	}
+	if req.groupID == "" {
+		return req.chanIDs, nil
+	}
+
+	groupChanIDs, err := groupChannels(ctx, req.groupID, policy)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	chanIDs := slices.Clone(req.chanIDs)
+	for _, chanID := range groupChanIDs {
+		if slices.Contains(chanIDs, chanID) {
+			continue
+		}
+		ok, err := authorized(ctx, clientID, clientType, chanID, req.domain, channels)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if ok {
+			chanIDs = append(chanIDs, chanID)
+		}
+	}
+	return chanIDs, nil
+}
+
+// groupChannels lists the channels of the group and of all its subgroups.
+func groupChannels(ctx context.Context, groupID string, policy policies.Service) ([]string, error) {
+	var chanIDs []string
+	visited := map[string]bool{groupID: true}
+	for groupIDs := []string{groupID}; len(groupIDs) > 0; groupIDs = groupIDs[1:] {
+		children := policies.Policy{
+			SubjectType: policies.GroupType,
+			Subject:     groupIDs[0],
+			Permission:  policies.ParentGroupRelation,
+			ObjectType:  policies.ChannelType,
+		}
+		page, err := policy.ListAllObjects(ctx, children)
+		if err != nil {
+			return nil, err
+		}
+		chanIDs = append(chanIDs, page.Policies...)
+
+		children.ObjectType = policies.GroupType
+		page, err = policy.ListAllObjects(ctx, children)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range page.Policies {
+			if !visited[id] {
+				visited[id] = true
+				groupIDs = append(groupIDs, id)
+			}
+		}
+	}
+	return chanIDs, nil
+}
+
 func authorize(ctx context.Context, clientID, clientType, chanID, domain string, channels grpcChannelsV1.ChannelsServiceClient) (err error) {
+	ok, err := authorized(ctx, clientID, clientType, chanID, domain, channels)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !ok {
+		return svcerr.ErrAuthorization
+	}
+	return nil
+}
+
+// authorized reports whether the client can read the channel. The denied
+// authorization is reported as false rather than as the error.
+func authorized(ctx context.Context, clientID, clientType, chanID, domain string, channels grpcChannelsV1.ChannelsServiceClient) (bool, error) {
 	res, err := channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
 		ClientId:   clientID,
 		ClientType: clientType,
@@ -256,11 +538,11 @@ func authorize(ctx context.Context, clientID, clientType, chanID, domain string,
 		ChannelId:  chanID,
 		DomainId:   domain,
 	})
-	if err != nil {
-		return errors.Wrap(svcerr.ErrAuthorization, err)
+	if status.Code(err) == codes.PermissionDenied {
+		return false, nil
 	}
-	if !res.GetAuthorized() {
-		return svcerr.ErrAuthorization
+	if err != nil {
+		return false, err
 	}
-	return nil
+	return res.GetAuthorized(), nil
 }
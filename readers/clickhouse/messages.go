@@ -0,0 +1,633 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/absmach/supermq/api/http"
+	clickhouseclient "github.com/absmach/supermq/pkg/clickhouse"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+)
+
+// Table for SenML messages.
+const (
+	defTable       = "messages"
+	orderByTime    = "time"
+	orderByCreated = "created"
+)
+
+// Message time is stored in nanoseconds.
+const timeDivisor = 1000000000
+
+var _ readers.MessageRepository = (*clickhouseRepository)(nil)
+
+type clickhouseRepository struct {
+	db *clickhouseclient.Client
+}
+
+// New returns new ClickHouse reader.
+func New(db *clickhouseclient.Client) readers.MessageRepository {
+	return &clickhouseRepository{
+		db: db,
+	}
+}
+
+func (cr clickhouseRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	ctx := context.Background()
+	sq := newSelectQuery(&rpm)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	rows, err := cr.db.Query(ctx, sq.messages, params)
+	if err != nil {
+		if errors.Contains(err, clickhouseclient.ErrUnknownTable) {
+			return readers.MessagesPage{}, nil
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+
+	if err := scanMessages(rows, sq.isSenml, func(msg readers.Message) error {
+		page.Messages = append(page.Messages, msg)
+		return nil
+	}); err != nil {
+		return readers.MessagesPage{}, err
+	}
+	if sq.keyset {
+		page.NextCursor = readers.NextCursor(rpm, page.Messages)
+	}
+
+	rows, err = cr.db.Query(ctx, sq.total, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	var total struct {
+		Total uint64 `json:"total"`
+	}
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return page, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return page, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = total.Total
+
+	return page, nil
+}
+
+func (cr clickhouseRepository) StreamAll(ctx context.Context, chanID string, rpm readers.PageMetadata, fn func(readers.Message) error) error {
+	sq := newSelectQuery(&rpm)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	rows, err := cr.db.Query(ctx, sq.messages, params)
+	if err != nil {
+		if errors.Contains(err, clickhouseclient.ErrUnknownTable) {
+			return nil
+		}
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	return scanMessages(rows, sq.isSenml, fn)
+}
+
+func (cr clickhouseRepository) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error) {
+	width, err := pm.BucketWidth()
+	if err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	params := map[string]any{
+		"channels":  chanIDs,
+		"names":     pm.Names,
+		"subtopic":  pm.Subtopic,
+		"publisher": pm.Publisher,
+		"protocol":  pm.Protocol,
+		"from":      pm.From,
+		"to":        pm.To,
+		"bucket":    width,
+	}
+
+	// Aggregated value is not aliased as value, since ClickHouse would
+	// substitute the alias for the column in the aggregation.
+	q := fmt.Sprintf(`SELECT toFloat64(intDiv(toInt64(time), {bucket:Int64}) * {bucket:Int64}) AS bucket, channel, name, %s AS agg_value
+	FROM %s WHERE %s
+	GROUP BY bucket, channel, name
+	ORDER BY bucket, channel, name`, aggregation(pm.Aggregation), defTable, seriesCondition(pm))
+
+	rows, err := cr.db.Query(ctx, q, params)
+	if err != nil {
+		if errors.Contains(err, clickhouseclient.ErrUnknownTable) {
+			return readers.NewSeriesPage(pm, width, nil), nil
+		}
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	points := []readers.SeriesPoint{}
+	for rows.Next() {
+		var p seriesPoint
+		if err := rows.Scan(&p); err != nil {
+			return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		points = append(points, readers.SeriesPoint(p))
+	}
+	if err := rows.Err(); err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return readers.NewSeriesPage(pm, width, points), nil
+}
+
+// selectQuery holds the queries of the messages and of their total count.
+// Keyset queries are ordered by the keyset columns, so the next cursor can be
+// taken from the last message.
+type selectQuery struct {
+	messages string
+	total    string
+	isSenml  bool
+	keyset   bool
+}
+
+func (cr clickhouseRepository) ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (readers.FailuresPage, error) {
+	params := map[string]any{
+		"channel": chanID,
+		"offset":  offset,
+		"limit":   limit,
+	}
+	q := `SELECT created, subtopic, publisher, protocol, payload, reason FROM messages_quarantine
+	WHERE channel = {channel:String}
+	ORDER BY created DESC LIMIT {limit:UInt64} OFFSET {offset:UInt64}`
+
+	page := readers.FailuresPage{
+		Offset:   offset,
+		Limit:    limit,
+		Failures: []readers.Failure{},
+	}
+	rows, err := cr.db.Query(ctx, q, params)
+	if err != nil {
+		if errors.Contains(err, clickhouseclient.ErrUnknownTable) {
+			return page, nil
+		}
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f readers.Failure
+		if err := rows.Scan(&f); err != nil {
+			return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Failures = append(page.Failures, f)
+	}
+	if err := rows.Err(); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	rows, err = cr.db.Query(ctx, `SELECT count() AS total FROM messages_quarantine WHERE channel = {channel:String}`, params)
+	if err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	var total struct {
+		Total uint64 `json:"total"`
+	}
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = total.Total
+
+	return page, nil
+}
+
+func newSelectQuery(rpm *readers.PageMetadata) selectQuery {
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		format = rpm.Format
+	}
+
+	isSenml := (format == defTable)
+
+	// If aggregation is provided, add time bucket and aggregation to the query
+	isAggregated := isSenml && rpm.Aggregation != "" && rpm.Interval != ""
+
+	// Cursor is a position in the keyset ordering, so it requires ordering by time.
+	if rpm.Order == "" || (rpm.Cursor != "" && !isAggregated) {
+		switch {
+		case isSenml:
+			rpm.Order = orderByTime
+		default:
+			rpm.Order = orderByCreated
+		}
+	}
+
+	orderClause := applyOrdering(*rpm, isAggregated, isSenml)
+
+	chData := ""
+	if rpm.Limit != 0 {
+		chData = "LIMIT {limit:UInt64}"
+	}
+	if rpm.Offset != 0 && rpm.Cursor == "" {
+		if chData != "" {
+			chData += " "
+		}
+		chData += "OFFSET {offset:UInt64}"
+	}
+
+	where := fmtCondition(*rpm, isSenml)
+
+	sq := selectQuery{
+		total:   fmt.Sprintf(`SELECT count() AS total FROM %s WHERE %s`, format, where),
+		isSenml: isSenml,
+		keyset:  !isAggregated && orderClause == keysetOrdering(isSenml, direction(*rpm)),
+	}
+
+	if isAggregated {
+		// Aggregated columns are aliased in the subquery, since ClickHouse
+		// would substitute the aliases named as the columns for the columns in
+		// the aggregations.
+		sq.messages = fmt.Sprintf(`SELECT
+				toFloat64(bucket) AS time,
+				agg_value AS value,
+				agg_publisher AS publisher,
+				agg_protocol AS protocol,
+				agg_subtopic AS subtopic,
+				agg_name AS name,
+				agg_unit AS unit
+			FROM (
+				SELECT
+					intDiv(toInt64(time), {bucket:Int64}) * {bucket:Int64} AS bucket,
+					%s AS agg_value,
+					argMin(publisher, time) AS agg_publisher,
+					argMin(protocol, time) AS agg_protocol,
+					argMin(subtopic, time) AS agg_subtopic,
+					argMin(name, time) AS agg_name,
+					argMin(unit, time) AS agg_unit
+				FROM
+					%s
+				WHERE
+					%s
+				GROUP BY bucket
+			)
+			%s
+			%s`,
+			aggregation(rpm.Aggregation), format, where, orderClause, chData)
+
+		sq.total = fmt.Sprintf(`SELECT count() AS total FROM (SELECT intDiv(toInt64(time), {bucket:Int64}) AS bucket FROM %s WHERE %s GROUP BY bucket)`, format, where)
+
+		return sq
+	}
+
+	if sq.keyset && rpm.Cursor != "" {
+		where = withCursor(where, *rpm, isSenml)
+	}
+	sq.messages = fmt.Sprintf(`SELECT * FROM %s WHERE %s %s %s`, format, where, orderClause, chData)
+
+	return sq
+}
+
+func scanMessages(rows *clickhouseclient.Rows, isSenml bool, fn func(readers.Message) error) error {
+	for rows.Next() {
+		var msg readers.Message
+		if isSenml {
+			sm := senml.Message{}
+			if err := rows.Scan(&sm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msg = sm
+		} else {
+			jm := jsonMessage{}
+			if err := rows.Scan(&jm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			m, err := jm.toMap()
+			if err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msg = m
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return nil
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) (map[string]any, error) {
+	params := map[string]any{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+	if rpm.Aggregation != "" && rpm.Interval != "" {
+		interval, err := time.ParseDuration(rpm.Interval)
+		if err != nil {
+			return nil, err
+		}
+		params["bucket"] = interval.Nanoseconds()
+	}
+	if rpm.Cursor != "" {
+		c, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		params["cursor_time"] = c.Time
+		params["cursor_created"] = c.Created
+		params["cursor_publisher"] = c.Publisher
+		params["cursor_subtopic"] = c.Subtopic
+		params["cursor_name"] = c.Name
+		params["cursor_protocol"] = c.Protocol
+	}
+
+	return params, nil
+}
+
+func withCursor(cond string, rpm readers.PageMetadata, isSenml bool) string {
+	op := "<"
+	if direction(rpm) == api.AscDir {
+		op = ">"
+	}
+	if isSenml {
+		return fmt.Sprintf(`%s AND (time, publisher, subtopic, name, protocol) %s
+	({cursor_time:Float64}, {cursor_publisher:String}, {cursor_subtopic:String}, {cursor_name:String}, {cursor_protocol:String})`, cond, op)
+	}
+
+	return fmt.Sprintf(`%s AND (created, publisher, subtopic) %s ({cursor_created:Int64}, {cursor_publisher:String}, {cursor_subtopic:String})`, cond, op)
+}
+
+func fmtCondition(rpm readers.PageMetadata, isSenml bool) string {
+	// Primary key columns conditions based on the table ordering.
+	chCondition := " channel = {channel:String} "
+
+	var query map[string]any
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return chCondition
+	}
+	if err := json.Unmarshal(meta, &query); err != nil {
+		return chCondition
+	}
+
+	conditions := []string{chCondition}
+
+	timeCol := orderByTime
+	if !isSenml {
+		timeCol = orderByCreated
+	}
+	if _, ok := query["from"]; ok {
+		conditions = append(conditions, fmt.Sprintf(" %s >= {from:Float64} ", timeCol))
+	}
+
+	if _, ok := query["to"]; ok {
+		conditions = append(conditions, fmt.Sprintf(" %s < {to:Float64} ", timeCol))
+	}
+
+	if _, ok := query["subtopic"]; ok {
+		conditions = append(conditions, " subtopic = {subtopic:String} ")
+	}
+
+	if _, ok := query["publisher"]; ok {
+		conditions = append(conditions, " publisher = {publisher:String} ")
+	}
+
+	if _, ok := query["name"]; ok {
+		conditions = append(conditions, " name = {name:String} ")
+	}
+
+	if _, ok := query["protocol"]; ok {
+		conditions = append(conditions, " protocol = {protocol:String} ")
+	}
+
+	for name := range query {
+		switch name {
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			conditions = append(conditions, fmt.Sprintf(" value %s {value:Float64} ", comparator))
+		case "vb":
+			conditions = append(conditions, "bool_value = {bool_value:Bool}")
+		case "vs":
+			comparator := readers.ParseValueComparator(query)
+			switch comparator {
+			case "=":
+				conditions = append(conditions, " string_value = {string_value:String} ")
+			case ">":
+				conditions = append(conditions, " position(string_value, {string_value:String}) > 0 AND string_value <> {string_value:String} ")
+			case ">=":
+				conditions = append(conditions, " position(string_value, {string_value:String}) > 0 ")
+			case "<=":
+				conditions = append(conditions, " position({string_value:String}, string_value) > 0 ")
+			case "<":
+				conditions = append(conditions, " position({string_value:String}, string_value) > 0 AND string_value <> {string_value:String} ")
+			}
+		case "vd":
+			comparator := readers.ParseValueComparator(query)
+			conditions = append(conditions, fmt.Sprintf(" data_value %s {data_value:String} ", comparator))
+		}
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// aggregation returns the expression of the value aggregated in a time bucket.
+func aggregation(agg string) string {
+	switch strings.ToUpper(agg) {
+	case "P50":
+		return "quantileExactInclusive(0.5)(value)"
+	case "P95":
+		return "quantileExactInclusive(0.95)(value)"
+	case "P99":
+		return "quantileExactInclusive(0.99)(value)"
+	case "FIRST":
+		return "argMin(value, time)"
+	case "LAST":
+		return "argMax(value, time)"
+	case "STDDEV":
+		return "stddevSamp(value)"
+	case "RATE":
+		// Rate of change per second between the first and the last value of
+		// the bucket. Buckets with a single message have no rate.
+		return fmt.Sprintf("(argMax(value, time) - argMin(value, time)) / nullIf(max(time) - min(time), 0) * %d", timeDivisor)
+	default:
+		return fmt.Sprintf("%s(value)", agg)
+	}
+}
+
+func seriesCondition(pm readers.SeriesPageMetadata) string {
+	conditions := []string{"has({channels:Array(String)}, channel)"}
+	if pm.Subtopic != "" {
+		conditions = append(conditions, "subtopic = {subtopic:String}")
+	}
+	if pm.Publisher != "" {
+		conditions = append(conditions, "publisher = {publisher:String}")
+	}
+	if len(pm.Names) > 0 {
+		conditions = append(conditions, "has({names:Array(String)}, name)")
+	}
+	conditions = append(conditions, "time >= {from:Float64}", "time < {to:Float64}")
+	if pm.Protocol != "" {
+		conditions = append(conditions, "protocol = {protocol:String}")
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+type seriesPoint struct {
+	Time    float64  `json:"bucket"`
+	Channel string   `json:"channel"`
+	Name    string   `json:"name"`
+	Value   *float64 `json:"agg_value"`
+}
+
+type jsonMessage struct {
+	Channel   string `json:"channel"`
+	Created   int64  `json:"created"`
+	Subtopic  string `json:"subtopic"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   string `json:"payload"`
+}
+
+func (msg jsonMessage) toMap() (map[string]any, error) {
+	ret := map[string]any{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]any{},
+	}
+	pld := make(map[string]any)
+	if err := json.Unmarshal([]byte(msg.Payload), &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+	return ret, nil
+}
+
+func applyOrdering(pm readers.PageMetadata, isAggregated bool, isSenml bool) string {
+	timeCol := orderByTime
+	if !isSenml {
+		timeCol = orderByCreated
+	}
+
+	dir := direction(pm)
+
+	aggCols := map[string]bool{
+		orderByTime: true,
+		"value":     true,
+		"publisher": true,
+		"protocol":  true,
+		"subtopic":  true,
+		"name":      true,
+		"unit":      true,
+	}
+
+	senmlCols := map[string]bool{
+		orderByTime:    true,
+		"value":        true,
+		"bool_value":   true,
+		"string_value": true,
+		"data_value":   true,
+		"publisher":    true,
+		"name":         true,
+		"protocol":     true,
+		"channel":      true,
+		"subtopic":     true,
+		"unit":         true,
+	}
+
+	jsonCols := map[string]bool{
+		orderByCreated: true, "publisher": true, "protocol": true,
+		"channel": true, "subtopic": true,
+	}
+
+	if isAggregated {
+		col := pm.Order
+		if !aggCols[col] {
+			col = orderByTime
+		}
+		if col == orderByTime {
+			return fmt.Sprintf("ORDER BY time %s", dir)
+		}
+		return fmt.Sprintf("ORDER BY %s %s, time %s", col, dir, dir)
+	}
+
+	col := pm.Order
+	switch {
+	case isSenml:
+		if !senmlCols[col] {
+			col = orderByTime
+		}
+	case !isSenml:
+		if !jsonCols[col] {
+			col = orderByCreated
+		}
+	}
+
+	secondary := fmt.Sprintf("%s DESC", timeCol)
+
+	if col == timeCol {
+		return keysetOrdering(isSenml, dir)
+	}
+	return fmt.Sprintf("ORDER BY %s %s, %s", col, dir, secondary)
+}
+
+// keysetOrdering orders the messages by the columns of the message key, so
+// that the ordering is total and the cursor can point to any message.
+func keysetOrdering(isSenml bool, dir string) string {
+	if isSenml {
+		return fmt.Sprintf("ORDER BY time %[1]s, publisher %[1]s, subtopic %[1]s, name %[1]s, protocol %[1]s", dir)
+	}
+
+	return fmt.Sprintf("ORDER BY created %[1]s, publisher %[1]s, subtopic %[1]s", dir)
+}
+
+func direction(pm readers.PageMetadata) string {
+	if pm.Dir != api.AscDir && pm.Dir != api.DescDir {
+		return api.DescDir
+	}
+
+	return pm.Dir
+}
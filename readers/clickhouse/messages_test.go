@@ -0,0 +1,617 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	chwriter "github.com/absmach/supermq/consumers/writers/clickhouse"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+	chreader "github.com/absmach/supermq/readers/clickhouse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic    = "subtopic"
+	msgsNum     = 100
+	limit       = 10
+	valueFields = 5
+	mqttProt    = "mqtt"
+	httpProt    = "http"
+	msgName     = "temperature"
+	format1     = "format1"
+)
+
+var (
+	v   float64 = 5
+	vs          = "stringValue"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+)
+
+func TestReadSenml(t *testing.T) {
+	writer := chwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	pubID2 := testsutil.GenerateUUID(t)
+	wrongID := testsutil.GenerateUUID(t)
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		{
+			desc:   "read message page for existing channel",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		{
+			desc:   "read message page for non-existent channel",
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		{
+			desc:   "read message last page",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages[msgsNum-20 : msgsNum]),
+			},
+		},
+		{
+			desc:   "read message with non-existent subtopic",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    msgsNum,
+				Subtopic: "not-present",
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		{
+			desc:   "read message with subtopic, publisher, name and protocol",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     uint64(len(queryMsgs)),
+				Subtopic:  subtopic,
+				Publisher: pubID2,
+				Name:      msgName,
+				Protocol:  httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		{
+			desc:   "read message with value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Value:  v,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with value and lower-than comparator",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with boolean value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with string value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      0,
+				Limit:       limit,
+				StringValue: vs,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with string value and greater-than-or-equal comparator",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      0,
+				Limit:       limit,
+				StringValue: vs[1:4],
+				Comparator:  readers.GreaterThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with data value",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs[0:limit]),
+			},
+		},
+		{
+			desc:   "read message with from and to",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  uint64(len(messages[5:20])),
+				From:   messages[19].Time,
+				To:     messages[4].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[5:20])),
+				Messages: fromSenml(messages[5:20]),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadMessagesWithAggregationFunctions(t *testing.T) {
+	writer := chwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	// All the messages are in the same one minute bucket, a second apart.
+	width := time.Minute.Nanoseconds()
+	start := (time.Now().Add(-time.Hour).UnixNano() / width) * width
+	messages := []senml.Message{}
+	for i := 0; i < 10; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(start + int64(i)*time.Second.Nanoseconds()),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	cases := []struct {
+		aggregation string
+		value       float64
+	}{
+		{aggregation: "AVG", value: 4.5},
+		{aggregation: "MAX", value: 9},
+		{aggregation: "COUNT", value: 10},
+		{aggregation: "P50", value: 4.5},
+		{aggregation: "P95", value: 8.55},
+		{aggregation: "P99", value: 8.91},
+		{aggregation: "FIRST", value: 0},
+		{aggregation: "LAST", value: 9},
+		{aggregation: "STDDEV", value: 3.0277},
+		{aggregation: "RATE", value: 1},
+	}
+
+	for _, tc := range cases {
+		pm := readers.PageMetadata{
+			Limit:       10,
+			Aggregation: tc.aggregation,
+			Interval:    "1m",
+			From:        float64(start),
+			To:          float64(start + width),
+		}
+		page, err := reader.ReadAll(chanID, pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.aggregation, err))
+		assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("%s: expected a single bucket got %d", tc.aggregation, page.Total))
+		require.Len(t, page.Messages, 1, fmt.Sprintf("%s: expected a single aggregated message", tc.aggregation))
+		msg, ok := page.Messages[0].(senml.Message)
+		require.True(t, ok, fmt.Sprintf("%s: expected SenML message", tc.aggregation))
+		require.NotNil(t, msg.Value, fmt.Sprintf("%s: expected aggregated value", tc.aggregation))
+		assert.InDelta(t, tc.value, *msg.Value, 0.001, fmt.Sprintf("%s: expected %f got %f", tc.aggregation, tc.value, *msg.Value))
+		assert.Equal(t, float64(start), msg.Time, fmt.Sprintf("%s: expected bucket time %f got %f", tc.aggregation, float64(start), msg.Time))
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	writer := chwriter.New(db)
+
+	id := testsutil.GenerateUUID(t)
+	messages := json.Messages{
+		Format: format1,
+	}
+	msgs := []map[string]any{}
+	httpMsgs := []map[string]any{}
+	timeNow := time.Now().UnixMilli()
+	for i := 0; i < msgsNum; i++ {
+		m := json.Message{
+			Channel:   id,
+			Publisher: id,
+			Created:   timeNow - int64(i),
+			Subtopic:  "subtopic/format/some_json",
+			Protocol:  mqttProt,
+			Payload: map[string]any{
+				"field_1": 123.0,
+				"field_2": "value",
+				"field_3": false,
+				"field_4": 12.344,
+				"field_5": map[string]any{
+					"field_1": "value",
+					"field_2": 42.0,
+				},
+			},
+		}
+		if i%2 == 0 {
+			m.Protocol = httpProt
+			httpMsgs = append(httpMsgs, toMap(m))
+		}
+		messages.Data = append(messages.Data, m)
+		msgs = append(msgs, toMap(m))
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read message page for existing channel": {
+			chanID: id,
+			pageMeta: readers.PageMetadata{
+				Format: messages.Format,
+				Offset: 0,
+				Limit:  10,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs[:10]),
+			},
+		},
+		"read message page for non-existent format": {
+			chanID: id,
+			pageMeta: readers.PageMetadata{
+				Format: "unknown_format",
+				Offset: 0,
+				Limit:  10,
+			},
+			page: readers.MessagesPage{},
+		},
+		"read message with protocol": {
+			chanID: id,
+			pageMeta: readers.PageMetadata{
+				Format:   messages.Format,
+				Offset:   0,
+				Limit:    uint64(msgsNum / 2),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(msgsNum / 2),
+				Messages: fromJSON(httpMsgs),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: got incorrect list of json Messages from ReadAll()", desc))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadSenmlWithCursor(t *testing.T) {
+	writer := chwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	pm := readers.PageMetadata{
+		Limit: limit,
+	}
+	var result []readers.Message
+	for i := 0; i < msgsNum/limit; i++ {
+		page, err := reader.ReadAll(chanID, pm)
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, fromSenml(messages[i*limit:(i+1)*limit]), page.Messages, fmt.Sprintf("page %d: got incorrect list of senml Messages from ReadAll()", i))
+		assert.Equal(t, uint64(msgsNum), page.Total, fmt.Sprintf("page %d: expected %d got %d", i, msgsNum, page.Total))
+		assert.NotEmpty(t, page.NextCursor, fmt.Sprintf("page %d: expected next cursor", i))
+		result = append(result, page.Messages...)
+		pm.Cursor = page.NextCursor
+	}
+	assert.Equal(t, fromSenml(messages), result, "got incorrect list of senml Messages using cursor")
+
+	page, err := reader.ReadAll(chanID, pm)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Empty(t, page.Messages, "expected no messages after the last page")
+	assert.Empty(t, page.NextCursor, "expected no next cursor after the last page")
+}
+
+func TestStreamSenml(t *testing.T) {
+	writer := chwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	var result []readers.Message
+	err = reader.StreamAll(context.Background(), chanID, readers.PageMetadata{}, func(msg readers.Message) error {
+		result = append(result, msg)
+		return nil
+	})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, fromSenml(messages), result, "got incorrect list of senml Messages from StreamAll()")
+}
+
+func TestReadSeries(t *testing.T) {
+	writer := chwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	chanID2 := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	width := time.Minute.Nanoseconds()
+	start := (time.Now().Add(-time.Hour).UnixNano() / width) * width
+	v2 := v * 3
+
+	var messages []senml.Message
+	for i := 0; i < valueFields; i++ {
+		ts := float64(start + int64(i)*width)
+		messages = append(messages,
+			senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: ts, Value: &v},
+			senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: ts + float64(time.Second), Value: &v2},
+		)
+		// The second channel has messages only in every other bucket.
+		if i%2 == 0 {
+			messages = append(messages, senml.Message{Channel: chanID2, Publisher: pubID, Protocol: mqttProt, Name: "humidity", Time: ts, Value: &v})
+		}
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := chreader.New(db)
+
+	avg := (v + v2) / 2
+	from := float64(start)
+	to := float64(start + valueFields*width)
+
+	cases := []struct {
+		desc    string
+		chanIDs []string
+		pm      readers.SeriesPageMetadata
+		series  []readers.Series
+	}{
+		{
+			desc:    "read series of multiple channels",
+			chanIDs: []string{chanID, chanID2},
+			pm: readers.SeriesPageMetadata{
+				From:        from,
+				To:          to,
+				Aggregation: "AVG",
+				Interval:    "1m",
+			},
+			series: []readers.Series{
+				{Channel: chanID, Name: msgName, Values: []*float64{&avg, &avg, &avg, &avg, &avg}},
+				{Channel: chanID2, Name: "humidity", Values: []*float64{&v, nil, &v, nil, &v}},
+			},
+		},
+		{
+			desc:    "read series of a single measurement",
+			chanIDs: []string{chanID, chanID2},
+			pm: readers.SeriesPageMetadata{
+				Names:       []string{"humidity"},
+				From:        from,
+				To:          to,
+				Aggregation: "MAX",
+				Interval:    "1m",
+			},
+			series: []readers.Series{
+				{Channel: chanID2, Name: "humidity", Values: []*float64{&v, nil, &v, nil, &v}},
+			},
+		},
+		{
+			desc:    "read series of non-existing channel",
+			chanIDs: []string{testsutil.GenerateUUID(t)},
+			pm: readers.SeriesPageMetadata{
+				From:        from,
+				To:          to,
+				Aggregation: "AVG",
+				Interval:    "1m",
+			},
+			series: []readers.Series{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := reader.ReadSeries(context.Background(), tc.chanIDs, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Len(t, page.Times, valueFields, fmt.Sprintf("%s: expected %d buckets got %d", tc.desc, valueFields, len(page.Times)))
+		assert.ElementsMatch(t, tc.series, page.Series, fmt.Sprintf("%s: got incorrect series from ReadSeries()", tc.desc))
+	}
+}
+
+func fromSenml(msg []senml.Message) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func fromJSON(msg []map[string]any) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func toMap(msg json.Message) map[string]any {
+	return map[string]any{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]any(msg.Payload),
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// Cursor marks the position of the last read message in the keyset ordering
+// of the messages table. SenML messages are ordered by time, publisher,
+// subtopic, name and protocol, and JSON messages by created, publisher,
+// subtopic and, where the table has one, ID, so the cursor uniquely identifies
+// the message within a channel.
+type Cursor struct {
+	ID        string  `json:"id,omitempty"`
+	Time      float64 `json:"time,omitempty"`
+	Created   int64   `json:"created,omitempty"`
+	Publisher string  `json:"publisher"`
+	Subtopic  string  `json:"subtopic"`
+	Name      string  `json:"name,omitempty"`
+	Protocol  string  `json:"protocol,omitempty"`
+}
+
+// NewCursor returns the cursor pointing to the given message. The second
+// return value is false if the message is of an unknown type.
+func NewCursor(msg Message) (Cursor, bool) {
+	switch m := msg.(type) {
+	case senml.Message:
+		return Cursor{
+			Time:      m.Time,
+			Publisher: m.Publisher,
+			Subtopic:  m.Subtopic,
+			Name:      m.Name,
+			Protocol:  m.Protocol,
+		}, true
+	case map[string]any:
+		c := Cursor{}
+		c.ID, _ = m["id"].(string)
+		c.Publisher, _ = m["publisher"].(string)
+		c.Subtopic, _ = m["subtopic"].(string)
+		switch created := m["created"].(type) {
+		case int64:
+			c.Created = created
+		case float64:
+			c.Created = int64(created)
+		default:
+			return Cursor{}, false
+		}
+		return c, true
+	default:
+		return Cursor{}, false
+	}
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses the opaque cursor returned as the next cursor of a page.
+func DecodeCursor(cursor string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, err
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cursor{}, err
+	}
+
+	return c, nil
+}
+
+// NextCursor returns the encoded cursor of the last message of the page if the
+// page is full, and an empty string otherwise. Aggregated pages are not keyed
+// by messages, so there is no next cursor for them.
+func NextCursor(pm PageMetadata, msgs []Message) string {
+	if pm.Aggregation != "" || pm.Limit == 0 || uint64(len(msgs)) < pm.Limit {
+		return ""
+	}
+	c, ok := NewCursor(msgs[len(msgs)-1])
+	if !ok {
+		return ""
+	}
+
+	return c.Encode()
+}
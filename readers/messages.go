@@ -3,7 +3,10 @@
 
 package readers
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 const (
 	// EqualKey represents the equal comparison operator key.
@@ -24,8 +27,24 @@ var ErrReadMessages = errors.New("failed to read messages from database")
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
-	// limited number of messages.
+	// limited number of messages. If the cursor is set, messages are read after
+	// the cursor position instead of skipping the offset.
 	ReadAll(chanID string, pm PageMetadata) (MessagesPage, error)
+
+	// StreamAll passes the messages of the given channel one by one to the given
+	// function, reading them from the database as they are consumed. A zero
+	// limit streams all the matching messages. Streaming stops on the first
+	// error returned by the function.
+	StreamAll(ctx context.Context, chanID string, pm PageMetadata, fn func(Message) error) error
+
+	// ReadSeries returns the SenML values of the given channels aggregated in
+	// the time buckets, so the series of different channels and measurements
+	// are aligned on the same timestamps.
+	ReadSeries(ctx context.Context, chanIDs []string, pm SeriesPageMetadata) (SeriesPage, error)
+
+	// ReadFailures skips given number of the quarantined messages of the
+	// given channel and returns next limited number of them.
+	ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (FailuresPage, error)
 }
 
 // Message represents any message format.
@@ -35,8 +54,9 @@ type Message any
 // belong to this page.
 type MessagesPage struct {
 	PageMetadata
-	Total    uint64
-	Messages []Message
+	Total      uint64
+	NextCursor string
+	Messages   []Message
 }
 
 // PageMetadata represents the parameters used to create database queries.
@@ -59,6 +79,7 @@ type PageMetadata struct {
 	Format      string  `json:"format,omitempty"`
 	Aggregation string  `json:"aggregation,omitempty"`
 	Interval    string  `json:"interval,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation.
@@ -6,6 +6,7 @@
 package middleware
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -54,3 +55,76 @@ func (lm *loggingMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (p
 
 	return lm.svc.ReadAll(chanID, rpm)
 }
+
+func (lm *loggingMiddleware) StreamAll(ctx context.Context, chanID string, rpm readers.PageMetadata, fn func(readers.Message) error) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+			slog.Group("page",
+				slog.Uint64("offset", rpm.Offset),
+				slog.Uint64("limit", rpm.Limit),
+			),
+		}
+		if rpm.Subtopic != "" {
+			args = append(args, slog.String("subtopic", rpm.Subtopic))
+		}
+		if rpm.Publisher != "" {
+			args = append(args, slog.String("publisher", rpm.Publisher))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Stream all failed", args...)
+			return
+		}
+		lm.logger.Info("Stream all completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StreamAll(ctx, chanID, rpm, fn)
+}
+
+func (lm *loggingMiddleware) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (page readers.SeriesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Any("channel_ids", chanIDs),
+			slog.Group("series",
+				slog.Any("names", pm.Names),
+				slog.Float64("from", pm.From),
+				slog.Float64("to", pm.To),
+				slog.String("aggregation", pm.Aggregation),
+				slog.String("interval", pm.Interval),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Read series failed", args...)
+			return
+		}
+		lm.logger.Info("Read series completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ReadSeries(ctx, chanIDs, pm)
+}
+
+func (lm *loggingMiddleware) ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (page readers.FailuresPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+			slog.Group("page",
+				slog.Uint64("offset", offset),
+				slog.Uint64("limit", limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Read failures failed", args...)
+			return
+		}
+		lm.logger.Info("Read failures completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ReadFailures(ctx, chanID, offset, limit)
+}
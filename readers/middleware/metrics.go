@@ -6,6 +6,7 @@
 package middleware
 
 import (
+	"context"
 	"time"
 
 	"github.com/absmach/supermq/readers"
@@ -37,3 +38,30 @@ func (mm *metricsMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (r
 
 	return mm.svc.ReadAll(chanID, rpm)
 }
+
+func (mm *metricsMiddleware) StreamAll(ctx context.Context, chanID string, rpm readers.PageMetadata, fn func(readers.Message) error) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "stream_all").Add(1)
+		mm.latency.With("method", "stream_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StreamAll(ctx, chanID, rpm, fn)
+}
+
+func (mm *metricsMiddleware) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_series").Add(1)
+		mm.latency.With("method", "read_series").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadSeries(ctx, chanIDs, pm)
+}
+
+func (mm *metricsMiddleware) ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (readers.FailuresPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_failures").Add(1)
+		mm.latency.With("method", "read_failures").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadFailures(ctx, chanID, offset, limit)
+}
@@ -9,6 +9,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/absmach/supermq/readers"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -105,3 +107,222 @@ func (_c *MessageRepository_ReadAll_Call) RunAndReturn(run func(chanID string, p
 	_c.Call.Return(run)
 	return _c
 }
+
+// ReadFailures provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) ReadFailures(ctx context.Context, chanID string, offset uint64, limit uint64) (readers.FailuresPage, error) {
+	ret := _mock.Called(ctx, chanID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadFailures")
+	}
+
+	var r0 readers.FailuresPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) (readers.FailuresPage, error)); ok {
+		return returnFunc(ctx, chanID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) readers.FailuresPage); ok {
+		r0 = returnFunc(ctx, chanID, offset, limit)
+	} else {
+		r0 = ret.Get(0).(readers.FailuresPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, chanID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageRepository_ReadFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadFailures'
+type MessageRepository_ReadFailures_Call struct {
+	*mock.Call
+}
+
+// ReadFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chanID string
+//   - offset uint64
+//   - limit uint64
+func (_e *MessageRepository_Expecter) ReadFailures(ctx interface{}, chanID interface{}, offset interface{}, limit interface{}) *MessageRepository_ReadFailures_Call {
+	return &MessageRepository_ReadFailures_Call{Call: _e.mock.On("ReadFailures", ctx, chanID, offset, limit)}
+}
+
+func (_c *MessageRepository_ReadFailures_Call) Run(run func(ctx context.Context, chanID string, offset uint64, limit uint64)) *MessageRepository_ReadFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_ReadFailures_Call) Return(failuresPage readers.FailuresPage, err error) *MessageRepository_ReadFailures_Call {
+	_c.Call.Return(failuresPage, err)
+	return _c
+}
+
+func (_c *MessageRepository_ReadFailures_Call) RunAndReturn(run func(ctx context.Context, chanID string, offset uint64, limit uint64) (readers.FailuresPage, error)) *MessageRepository_ReadFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadSeries provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error) {
+	ret := _mock.Called(ctx, chanIDs, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadSeries")
+	}
+
+	var r0 readers.SeriesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, readers.SeriesPageMetadata) (readers.SeriesPage, error)); ok {
+		return returnFunc(ctx, chanIDs, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, readers.SeriesPageMetadata) readers.SeriesPage); ok {
+		r0 = returnFunc(ctx, chanIDs, pm)
+	} else {
+		r0 = ret.Get(0).(readers.SeriesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, readers.SeriesPageMetadata) error); ok {
+		r1 = returnFunc(ctx, chanIDs, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageRepository_ReadSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadSeries'
+type MessageRepository_ReadSeries_Call struct {
+	*mock.Call
+}
+
+// ReadSeries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chanIDs []string
+//   - pm readers.SeriesPageMetadata
+func (_e *MessageRepository_Expecter) ReadSeries(ctx interface{}, chanIDs interface{}, pm interface{}) *MessageRepository_ReadSeries_Call {
+	return &MessageRepository_ReadSeries_Call{Call: _e.mock.On("ReadSeries", ctx, chanIDs, pm)}
+}
+
+func (_c *MessageRepository_ReadSeries_Call) Run(run func(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata)) *MessageRepository_ReadSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 readers.SeriesPageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(readers.SeriesPageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_ReadSeries_Call) Return(seriesPage readers.SeriesPage, err error) *MessageRepository_ReadSeries_Call {
+	_c.Call.Return(seriesPage, err)
+	return _c
+}
+
+func (_c *MessageRepository_ReadSeries_Call) RunAndReturn(run func(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error)) *MessageRepository_ReadSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamAll provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) StreamAll(ctx context.Context, chanID string, pm readers.PageMetadata, fn func(readers.Message) error) error {
+	ret := _mock.Called(ctx, chanID, pm, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, readers.PageMetadata, func(readers.Message) error) error); ok {
+		r0 = returnFunc(ctx, chanID, pm, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageRepository_StreamAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamAll'
+type MessageRepository_StreamAll_Call struct {
+	*mock.Call
+}
+
+// StreamAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chanID string
+//   - pm readers.PageMetadata
+//   - fn func(readers.Message) error
+func (_e *MessageRepository_Expecter) StreamAll(ctx interface{}, chanID interface{}, pm interface{}, fn interface{}) *MessageRepository_StreamAll_Call {
+	return &MessageRepository_StreamAll_Call{Call: _e.mock.On("StreamAll", ctx, chanID, pm, fn)}
+}
+
+func (_c *MessageRepository_StreamAll_Call) Run(run func(ctx context.Context, chanID string, pm readers.PageMetadata, fn func(readers.Message) error)) *MessageRepository_StreamAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 readers.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(readers.PageMetadata)
+		}
+		var arg3 func(readers.Message) error
+		if args[3] != nil {
+			arg3 = args[3].(func(readers.Message) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_StreamAll_Call) Return(err error) *MessageRepository_StreamAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageRepository_StreamAll_Call) RunAndReturn(run func(ctx context.Context, chanID string, pm readers.PageMetadata, fn func(readers.Message) error) error) *MessageRepository_StreamAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -125,3 +125,86 @@ func (_c *ReadersServiceClient_ReadMessages_Call) RunAndReturn(run func(ctx cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// StreamMessages provides a mock function for the type ReadersServiceClient
+func (_mock *ReadersServiceClient) StreamMessages(ctx context.Context, in *v1.ReadMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.Message], error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, in, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMessages")
+	}
+
+	var r0 grpc.ServerStreamingClient[v1.Message]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1.ReadMessagesReq, ...grpc.CallOption) (grpc.ServerStreamingClient[v1.Message], error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *v1.ReadMessagesReq, ...grpc.CallOption) grpc.ServerStreamingClient[v1.Message]); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(grpc.ServerStreamingClient[v1.Message])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *v1.ReadMessagesReq, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ReadersServiceClient_StreamMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamMessages'
+type ReadersServiceClient_StreamMessages_Call struct {
+	*mock.Call
+}
+
+// StreamMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.ReadMessagesReq
+//   - opts ...grpc.CallOption
+func (_e *ReadersServiceClient_Expecter) StreamMessages(ctx interface{}, in interface{}, opts ...interface{}) *ReadersServiceClient_StreamMessages_Call {
+	return &ReadersServiceClient_StreamMessages_Call{Call: _e.mock.On("StreamMessages",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ReadersServiceClient_StreamMessages_Call) Run(run func(ctx context.Context, in *v1.ReadMessagesReq, opts ...grpc.CallOption)) *ReadersServiceClient_StreamMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *v1.ReadMessagesReq
+		if args[1] != nil {
+			arg1 = args[1].(*v1.ReadMessagesReq)
+		}
+		var arg2 []grpc.CallOption
+		var variadicArgs []grpc.CallOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]grpc.CallOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *ReadersServiceClient_StreamMessages_Call) Return(serverStreamingClient grpc.ServerStreamingClient[v1.Message], err error) *ReadersServiceClient_StreamMessages_Call {
+	_c.Call.Return(serverStreamingClient, err)
+	return _c
+}
+
+func (_c *ReadersServiceClient_StreamMessages_Call) RunAndReturn(run func(ctx context.Context, in *v1.ReadMessagesReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.Message], error)) *ReadersServiceClient_StreamMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -72,6 +72,37 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`ALTER TABLE messages ADD COLUMN IF NOT EXISTS domain VARCHAR(254) NOT NULL DEFAULT ''`,
+					`CREATE TABLE IF NOT EXISTS messages_rollups (
+            			width         FLOAT NOT NULL,
+            			time          FLOAT NOT NULL,
+            			domain        VARCHAR(254) NOT NULL DEFAULT '',
+            			channel       UUID,
+            			subtopic      VARCHAR(254),
+            			publisher     VARCHAR(254),
+            			protocol      TEXT,
+            			name          VARCHAR(254),
+            			unit          TEXT,
+            			count         BIGINT,
+            			sum           FLOAT,
+            			sum_sq        FLOAT,
+            			min           FLOAT,
+            			max           FLOAT,
+            			first_time    FLOAT,
+            			first_value   FLOAT,
+            			last_time     FLOAT,
+            			last_value    FLOAT,
+            			PRIMARY KEY (width, channel, subtopic, publisher, protocol, name, time)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE messages_rollups",
+					"ALTER TABLE messages DROP COLUMN domain",
+				},
+			},
 		},
 	}
 
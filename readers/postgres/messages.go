@@ -4,8 +4,11 @@
 package postgres
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/transformers/senml"
@@ -15,6 +18,15 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+const (
+	// Message time is stored in nanoseconds.
+	timeDivisor = 1000000000
+
+	// Time buckets are aligned to the multiples of their width, the same
+	// way as TimescaleDB time_bucket aligns them.
+	timeBucket = "floor(time / :bucket) * :bucket"
+)
+
 var _ readers.MessageRepository = (*postgresRepository)(nil)
 
 type postgresRepository struct {
@@ -29,34 +41,24 @@ func New(db *sqlx.DB) readers.MessageRepository {
 }
 
 func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	order := "time"
-	format := defTable
-
-	if rpm.Format != "" && rpm.Format != defTable {
-		order = "created"
-		format = rpm.Format
+	format := table(rpm)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
-	cond := fmtCondition(chanID, rpm)
-
-	q := fmt.Sprintf(`SELECT * FROM %s
-    WHERE %s ORDER BY %s DESC
-	LIMIT :limit OFFSET :offset;`, format, cond, order)
 
-	params := map[string]any{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	pgData := "LIMIT :limit OFFSET :offset"
+	if rpm.Cursor != "" {
+		pgData = "LIMIT :limit"
+	}
+	q, totalQuery := selectQuery(format, chanID, rpm, pgData)
+	if r, ok, err := tr.rollup(context.Background(), chanID, rpm); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	} else if ok {
+		q, totalQuery = rollupQuery(chanID, rpm, pgData)
+		params = rollupParams(params, r)
 	}
+
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if preErr, ok := err.(*pgconn.PrepareError); ok {
@@ -75,32 +77,15 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
-	switch format {
-	case defTable:
-		for rows.Next() {
-			msg := senmlMessage{Message: senml.Message{}}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-
-			page.Messages = append(page.Messages, msg.Message)
-		}
-	default:
-		for rows.Next() {
-			msg := jsonMessage{}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			m, err := msg.toMap()
-			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
-			page.Messages = append(page.Messages, m)
-		}
+	if err := scanMessages(rows, format, func(msg readers.Message) error {
+		page.Messages = append(page.Messages, msg)
+		return nil
+	}); err != nil {
+		return readers.MessagesPage{}, err
 	}
+	page.NextCursor = readers.NextCursor(rpm, page.Messages)
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, cond)
-	rows, err = tr.db.NamedQuery(q, params)
+	rows, err = tr.db.NamedQuery(totalQuery, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
@@ -117,6 +102,405 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	return page, nil
 }
 
+func (tr postgresRepository) StreamAll(ctx context.Context, chanID string, rpm readers.PageMetadata, fn func(readers.Message) error) error {
+	format := table(rpm)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	var pgData string
+	if rpm.Limit != 0 {
+		pgData = "LIMIT :limit"
+	}
+	if rpm.Offset != 0 && rpm.Cursor == "" {
+		pgData += " OFFSET :offset"
+	}
+	q, _ := selectQuery(format, chanID, rpm, pgData)
+	if r, ok, err := tr.rollup(ctx, chanID, rpm); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	} else if ok {
+		q, _ = rollupQuery(chanID, rpm, pgData)
+		params = rollupParams(params, r)
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return nil
+			}
+		}
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	return scanMessages(rows, format, fn)
+}
+
+func (tr postgresRepository) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error) {
+	width, err := pm.BucketWidth()
+	if err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	params := map[string]any{
+		"channels":  chanIDs,
+		"names":     pm.Names,
+		"subtopic":  pm.Subtopic,
+		"publisher": pm.Publisher,
+		"protocol":  pm.Protocol,
+		"from":      pm.From,
+		"to":        pm.To,
+		"bucket":    float64(width),
+	}
+
+	q := fmt.Sprintf(`SELECT %s AS bucket, channel, name, %s AS value
+	FROM %s WHERE %s
+	GROUP BY 1, channel, name
+	ORDER BY 1, channel, name;`, timeBucket, aggregation(pm.Aggregation), defTable, seriesCondition(pm))
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return readers.NewSeriesPage(pm, width, nil), nil
+			}
+		}
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	points := []readers.SeriesPoint{}
+	for rows.Next() {
+		var p readers.SeriesPoint
+		if err := rows.Scan(&p.Time, &p.Channel, &p.Name, &p.Value); err != nil {
+			return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return readers.NewSeriesPage(pm, width, points), nil
+}
+
+func (tr postgresRepository) ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (readers.FailuresPage, error) {
+	params := map[string]any{
+		"channel": chanID,
+		"offset":  offset,
+		"limit":   limit,
+	}
+	q := `SELECT created, COALESCE(subtopic, '') AS subtopic, COALESCE(publisher, '') AS publisher,
+	COALESCE(protocol, '') AS protocol, COALESCE(payload, ''::bytea) AS payload, COALESCE(reason, '') AS reason
+	FROM messages_quarantine WHERE channel = :channel
+	ORDER BY created DESC, id DESC LIMIT :limit OFFSET :offset;`
+
+	page := readers.FailuresPage{
+		Offset:   offset,
+		Limit:    limit,
+		Failures: []readers.Failure{},
+	}
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return page, nil
+			}
+		}
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f readers.Failure
+		var payload []byte
+		if err := rows.Scan(&f.Created, &f.Subtopic, &f.Publisher, &f.Protocol, &payload, &f.Reason); err != nil {
+			return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		f.Payload = string(payload)
+		page.Failures = append(page.Failures, f)
+	}
+	if err := rows.Err(); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	totalQuery := `SELECT COUNT(*) FROM messages_quarantine WHERE channel = $1;`
+	if err := tr.db.GetContext(ctx, &page.Total, totalQuery, chanID); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return page, nil
+}
+
+func scanMessages(rows *sqlx.Rows, format string, fn func(readers.Message) error) error {
+	for rows.Next() {
+		var msg readers.Message
+		switch format {
+		case defTable:
+			sm := senmlMessage{Message: senml.Message{}}
+			if err := rows.StructScan(&sm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msg = sm.Message
+		default:
+			jm := jsonMessage{}
+			if err := rows.StructScan(&jm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			m, err := jm.toMap()
+			if err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msg = m
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return nil
+}
+
+// selectQuery returns the query of the messages and the query of their total
+// count. Aggregated SenML values are grouped in the time buckets of the interval.
+func selectQuery(format, chanID string, rpm readers.PageMetadata, pgData string) (string, string) {
+	cond := fmtCondition(chanID, rpm)
+	if !isAggregated(format, rpm) {
+		messages := fmt.Sprintf(`SELECT * FROM %s
+    WHERE %s ORDER BY %s
+	%s;`, format, withCursor(format, cond, rpm), orderBy(format), pgData)
+		total := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, cond)
+
+		return messages, total
+	}
+
+	messages := fmt.Sprintf(`SELECT
+		%s AS time,
+		%s AS value,
+		(array_agg(publisher ORDER BY time))[1] AS publisher,
+		(array_agg(protocol ORDER BY time))[1] AS protocol,
+		(array_agg(subtopic ORDER BY time))[1] AS subtopic,
+		(array_agg(name ORDER BY time))[1] AS name,
+		(array_agg(unit ORDER BY time))[1] AS unit
+	FROM %s
+	WHERE %s
+	GROUP BY 1
+	ORDER BY time DESC
+	%s;`, timeBucket, aggregation(rpm.Aggregation), format, cond, pgData)
+	total := fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT %s FROM %s WHERE %s GROUP BY 1) AS subquery;`, timeBucket, format, cond)
+
+	return messages, total
+}
+
+// rollup returns the range of the rollups of the channel which the aggregated
+// query can read instead of the raw messages.
+func (tr postgresRepository) rollup(ctx context.Context, chanID string, rpm readers.PageMetadata) (readers.RollupRange, bool, error) {
+	if !isAggregated(table(rpm), rpm) {
+		return readers.RollupRange{}, false, nil
+	}
+	q := `SELECT width, MIN(time) AS "from", MAX(time) + width AS "to"
+	FROM messages_rollups WHERE channel = $1 GROUP BY width;`
+
+	ranges := []readers.RollupRange{}
+	if err := tr.db.SelectContext(ctx, &ranges, q, chanID); err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		// Rollups table is created by the writers migrations.
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UndefinedTable {
+			return readers.RollupRange{}, false, nil
+		}
+		return readers.RollupRange{}, false, err
+	}
+	r, ok := readers.SelectRollup(rpm, ranges)
+
+	return r, ok, nil
+}
+
+// rollupQuery returns the aggregated query which reads the rollups in their
+// range and the raw messages outside of it. Raw messages are read as the
+// rollups of a single value, so both are aggregated the same way.
+func rollupQuery(chanID string, rpm readers.PageMetadata, pgData string) (string, string) {
+	cond := fmtCondition(chanID, rpm)
+	src := fmt.Sprintf(`WITH src AS (
+		SELECT time, channel, publisher, protocol, subtopic, name, unit, count, sum, sum_sq, min, max,
+			first_time, first_value, last_time, last_value
+		FROM messages_rollups
+		WHERE %s AND width = :rollup_width AND time >= :rollup_from AND time < :rollup_to
+		UNION ALL
+		SELECT time, channel, CAST(publisher AS VARCHAR), protocol, subtopic, name, unit, 1, value, value * value, value, value,
+			time, value, time, value
+		FROM %s
+		WHERE %s AND value IS NOT NULL AND (time < :rollup_from OR time >= :rollup_to)
+	)`, cond, defTable, cond)
+
+	messages := fmt.Sprintf(`%s SELECT
+		%s AS time,
+		%s AS value,
+		(array_agg(publisher ORDER BY time))[1] AS publisher,
+		(array_agg(protocol ORDER BY time))[1] AS protocol,
+		(array_agg(subtopic ORDER BY time))[1] AS subtopic,
+		(array_agg(name ORDER BY time))[1] AS name,
+		(array_agg(unit ORDER BY time))[1] AS unit
+	FROM src
+	GROUP BY 1
+	ORDER BY time DESC
+	%s;`, src, timeBucket, rollupAggregation(rpm.Aggregation), pgData)
+	total := fmt.Sprintf(`%s SELECT COUNT(*) FROM (SELECT %s FROM src GROUP BY 1) AS subquery;`, src, timeBucket)
+
+	return messages, total
+}
+
+func rollupParams(params map[string]any, r readers.RollupRange) map[string]any {
+	params["rollup_width"] = r.Width
+	params["rollup_from"] = r.From
+	params["rollup_to"] = r.To
+
+	return params
+}
+
+// rollupAggregation returns the expression of the value aggregated in a time
+// bucket from the rollups of the values.
+func rollupAggregation(agg string) string {
+	first := "(array_agg(first_value ORDER BY first_time))[1]"
+	last := "(array_agg(last_value ORDER BY last_time DESC))[1]"
+	switch strings.ToUpper(agg) {
+	case "AVG":
+		return "SUM(sum) / NULLIF(SUM(count), 0)"
+	case "SUM":
+		return "SUM(sum)"
+	case "COUNT":
+		return "CAST(SUM(count) AS FLOAT)"
+	case "MIN":
+		return "MIN(min)"
+	case "MAX":
+		return "MAX(max)"
+	case "FIRST":
+		return first
+	case "LAST":
+		return last
+	case "STDDEV":
+		return "sqrt(GREATEST((SUM(sum_sq) - SUM(sum) * SUM(sum) / SUM(count)) / NULLIF(SUM(count) - 1, 0), 0))"
+	case "RATE":
+		return fmt.Sprintf("(%s - %s) / NULLIF(MAX(last_time) - MIN(first_time), 0) * %d", last, first, timeDivisor)
+	default:
+		return aggregation(agg)
+	}
+}
+
+func isAggregated(format string, rpm readers.PageMetadata) bool {
+	return format == defTable && rpm.Aggregation != "" && rpm.Interval != ""
+}
+
+// aggregation returns the expression of the value aggregated in a time bucket.
+// First and last values are taken by the message time, the same way as the
+// TimescaleDB first and last functions take them.
+func aggregation(agg string) string {
+	switch strings.ToUpper(agg) {
+	case "P50":
+		return "percentile_cont(0.5) WITHIN GROUP (ORDER BY value)"
+	case "P95":
+		return "percentile_cont(0.95) WITHIN GROUP (ORDER BY value)"
+	case "P99":
+		return "percentile_cont(0.99) WITHIN GROUP (ORDER BY value)"
+	case "FIRST":
+		return "(array_agg(value ORDER BY time))[1]"
+	case "LAST":
+		return "(array_agg(value ORDER BY time DESC))[1]"
+	case "STDDEV":
+		return "stddev_samp(value)"
+	case "RATE":
+		// Rate of change per second between the first and the last value of
+		// the bucket. Buckets with a single message have no rate.
+		return fmt.Sprintf("((array_agg(value ORDER BY time DESC))[1] - (array_agg(value ORDER BY time))[1]) / NULLIF(MAX(time) - MIN(time), 0) * %d", timeDivisor)
+	default:
+		return fmt.Sprintf("%s(value)", agg)
+	}
+}
+
+func table(rpm readers.PageMetadata) string {
+	if rpm.Format != "" && rpm.Format != defTable {
+		return rpm.Format
+	}
+
+	return defTable
+}
+
+// orderBy returns the keyset ordering of the table, which the cursor follows.
+func orderBy(format string) string {
+	if format == defTable {
+		return "time DESC, publisher DESC, subtopic DESC, name DESC, protocol DESC"
+	}
+
+	return "created DESC, publisher DESC, subtopic DESC, id DESC"
+}
+
+func withCursor(format, cond string, rpm readers.PageMetadata) string {
+	if rpm.Cursor == "" {
+		return cond
+	}
+	if format == defTable {
+		return fmt.Sprintf(`%s AND (time, publisher, subtopic, name, protocol) <
+	(:cursor_time, :cursor_publisher, :cursor_subtopic, :cursor_name, :cursor_protocol)`, cond)
+	}
+
+	return fmt.Sprintf(`%s AND (created, publisher, subtopic, id) <
+	(:cursor_created, :cursor_publisher, :cursor_subtopic, CAST(:cursor_id AS UUID))`, cond)
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) (map[string]any, error) {
+	params := map[string]any{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+	if rpm.Cursor != "" {
+		c, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		params["cursor_id"] = c.ID
+		params["cursor_time"] = c.Time
+		params["cursor_created"] = c.Created
+		params["cursor_publisher"] = c.Publisher
+		params["cursor_subtopic"] = c.Subtopic
+		params["cursor_name"] = c.Name
+		params["cursor_protocol"] = c.Protocol
+	}
+	if isAggregated(table(rpm), rpm) {
+		interval, err := time.ParseDuration(rpm.Interval)
+		if err != nil {
+			return nil, err
+		}
+		params["bucket"] = float64(interval.Nanoseconds())
+	}
+
+	return params, nil
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	condition := `channel = :channel`
 
@@ -200,3 +584,22 @@ func (msg jsonMessage) toMap() (map[string]any, error) {
 	ret["payload"] = pld
 	return ret, nil
 }
+
+func seriesCondition(pm readers.SeriesPageMetadata) string {
+	conditions := []string{"channel = ANY(:channels)"}
+	if pm.Subtopic != "" {
+		conditions = append(conditions, "subtopic = :subtopic")
+	}
+	if pm.Publisher != "" {
+		conditions = append(conditions, "publisher = :publisher")
+	}
+	if len(pm.Names) > 0 {
+		conditions = append(conditions, "name = ANY(:names)")
+	}
+	conditions = append(conditions, "time >= :from", "time < :to")
+	if pm.Protocol != "" {
+		conditions = append(conditions, "protocol = :protocol")
+	}
+
+	return strings.Join(conditions, " AND ")
+}
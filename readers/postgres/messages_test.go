@@ -10,7 +10,10 @@ import (
 	"time"
 
 	pwriter "github.com/absmach/supermq/consumers/writers/postgres"
+	"github.com/absmach/supermq/consumers/writers/retention"
+	retentionpg "github.com/absmach/supermq/consumers/writers/retention/postgres"
 	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/absmach/supermq/readers"
@@ -518,6 +521,142 @@ func TestReadSenml(t *testing.T) {
 	}
 }
 
+func TestReadMessagesWithAggregationFunctions(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	// All the messages are in the same one minute bucket, a second apart.
+	width := time.Minute.Nanoseconds()
+	start := (time.Now().Add(-time.Hour).UnixNano() / width) * width
+	messages := []senml.Message{}
+	for i := 0; i < 10; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(start + int64(i)*time.Second.Nanoseconds()),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	cases := []struct {
+		aggregation string
+		value       float64
+	}{
+		{aggregation: "AVG", value: 4.5},
+		{aggregation: "MAX", value: 9},
+		{aggregation: "COUNT", value: 10},
+		{aggregation: "P50", value: 4.5},
+		{aggregation: "P95", value: 8.55},
+		{aggregation: "P99", value: 8.91},
+		{aggregation: "FIRST", value: 0},
+		{aggregation: "LAST", value: 9},
+		{aggregation: "STDDEV", value: 3.0277},
+		{aggregation: "RATE", value: 1},
+	}
+
+	for _, tc := range cases {
+		pm := readers.PageMetadata{
+			Limit:       10,
+			Aggregation: tc.aggregation,
+			Interval:    "1m",
+			From:        float64(start),
+			To:          float64(start + width),
+		}
+		page, err := reader.ReadAll(chanID, pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.aggregation, err))
+		assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("%s: expected a single bucket got %d", tc.aggregation, page.Total))
+		require.Len(t, page.Messages, 1, fmt.Sprintf("%s: expected a single aggregated message", tc.aggregation))
+		msg, ok := page.Messages[0].(senml.Message)
+		require.True(t, ok, fmt.Sprintf("%s: expected SenML message", tc.aggregation))
+		require.NotNil(t, msg.Value, fmt.Sprintf("%s: expected aggregated value", tc.aggregation))
+		assert.InDelta(t, tc.value, *msg.Value, 0.001, fmt.Sprintf("%s: expected %f got %f", tc.aggregation, tc.value, *msg.Value))
+		assert.Equal(t, float64(start), msg.Time, fmt.Sprintf("%s: expected bucket time %f got %f", tc.aggregation, float64(start), msg.Time))
+	}
+}
+
+func TestReadMessagesWithRollups(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	// Messages are a minute apart in a two hours bucket. The first hour is
+	// rolled up and its raw messages are removed.
+	width := 2 * time.Hour.Nanoseconds()
+	start := (time.Now().Add(-4*time.Hour).UnixNano() / width) * width
+	messages := []senml.Message{}
+	for i := 0; i < 120; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(start + int64(i)*time.Minute.Nanoseconds()),
+			Value:     &v,
+		})
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	repo := retentionpg.New(db)
+	scope := retention.Scope{ChannelID: chanID}
+	rolledUp := time.Unix(0, start).Add(time.Hour)
+	err = repo.Rollup(context.TODO(), scope, time.Hour, time.Unix(0, start), rolledUp)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	err = repo.DeleteMessages(context.TODO(), scope, rolledUp)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	cases := []struct {
+		aggregation string
+		interval    string
+		value       float64
+	}{
+		{aggregation: "AVG", interval: "2h", value: 59.5},
+		{aggregation: "SUM", interval: "2h", value: 7140},
+		{aggregation: "COUNT", interval: "2h", value: 120},
+		{aggregation: "MIN", interval: "2h", value: 0},
+		{aggregation: "MAX", interval: "2h", value: 119},
+		{aggregation: "FIRST", interval: "2h", value: 0},
+		{aggregation: "LAST", interval: "2h", value: 119},
+		{aggregation: "STDDEV", interval: "2h", value: 34.7851},
+		{aggregation: "RATE", interval: "2h", value: 1.0 / 60},
+		// Percentiles are not derivable from the rollups, so only the remaining
+		// raw messages are aggregated.
+		{aggregation: "P50", interval: "2h", value: 89.5},
+	}
+
+	for _, tc := range cases {
+		pm := readers.PageMetadata{
+			Limit:       10,
+			Aggregation: tc.aggregation,
+			Interval:    tc.interval,
+			From:        float64(start),
+			To:          float64(start + width),
+		}
+		page, err := reader.ReadAll(chanID, pm)
+		assert.Nil(t, err, fmt.Sprintf("%s %s: expected no error got %s", tc.aggregation, tc.interval, err))
+		require.NotEmpty(t, page.Messages, fmt.Sprintf("%s %s: expected aggregated messages", tc.aggregation, tc.interval))
+		msg, ok := page.Messages[0].(senml.Message)
+		require.True(t, ok, fmt.Sprintf("%s %s: expected SenML message", tc.aggregation, tc.interval))
+		require.NotNil(t, msg.Value, fmt.Sprintf("%s %s: expected aggregated value", tc.aggregation, tc.interval))
+		assert.InDelta(t, tc.value, *msg.Value, 0.001, fmt.Sprintf("%s %s: expected %f got %f", tc.aggregation, tc.interval, tc.value, *msg.Value))
+	}
+}
+
 func TestReadJSON(t *testing.T) {
 	writer := pwriter.New(db)
 
@@ -659,6 +798,227 @@ func TestReadJSON(t *testing.T) {
 	}
 }
 
+func TestReadSenmlWithCursor(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	pm := readers.PageMetadata{
+		Limit: limit,
+	}
+	var result []readers.Message
+	for i := 0; i < msgsNum/limit; i++ {
+		page, err := reader.ReadAll(chanID, pm)
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, fromSenml(messages[i*limit:(i+1)*limit]), page.Messages, fmt.Sprintf("page %d: got incorrect list of senml Messages from ReadAll()", i))
+		assert.Equal(t, uint64(msgsNum), page.Total, fmt.Sprintf("page %d: expected %d got %d", i, msgsNum, page.Total))
+		assert.NotEmpty(t, page.NextCursor, fmt.Sprintf("page %d: expected next cursor", i))
+		result = append(result, page.Messages...)
+		pm.Cursor = page.NextCursor
+	}
+	assert.Equal(t, fromSenml(messages), result, "got incorrect list of senml Messages using cursor")
+
+	page, err := reader.ReadAll(chanID, pm)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Empty(t, page.Messages, "expected no messages after the last page")
+	assert.Empty(t, page.NextCursor, "expected no next cursor after the last page")
+}
+
+func TestStreamSenml(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+	wrongID := testsutil.GenerateUUID(t)
+
+	messages := []senml.Message{}
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	errStop := errors.New("stop")
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		pageMeta readers.PageMetadata
+		stopAt   int
+		messages []readers.Message
+		err      error
+	}{
+		{
+			desc:     "stream all messages",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{},
+			messages: fromSenml(messages),
+		},
+		{
+			desc:   "stream messages with limit",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Limit: limit,
+			},
+			messages: fromSenml(messages[:limit]),
+		},
+		{
+			desc:   "stream messages with cursor",
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Cursor: readers.NextCursor(readers.PageMetadata{Limit: limit}, fromSenml(messages[:limit])),
+			},
+			messages: fromSenml(messages[limit:]),
+		},
+		{
+			desc:     "stream messages stopped by the callback",
+			chanID:   chanID,
+			pageMeta: readers.PageMetadata{},
+			stopAt:   limit,
+			messages: fromSenml(messages[:limit]),
+			err:      errStop,
+		},
+		{
+			desc:     "stream messages of non-existing channel",
+			chanID:   wrongID,
+			pageMeta: readers.PageMetadata{},
+		},
+	}
+
+	for _, tc := range cases {
+		var result []readers.Message
+		err := reader.StreamAll(context.Background(), tc.chanID, tc.pageMeta, func(msg readers.Message) error {
+			if tc.stopAt > 0 && len(result) == tc.stopAt {
+				return errStop
+			}
+			result = append(result, msg)
+			return nil
+		})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.messages, result, fmt.Sprintf("%s: got incorrect list of senml Messages from StreamAll()", tc.desc))
+	}
+}
+
+func TestReadSeries(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID := testsutil.GenerateUUID(t)
+	chanID2 := testsutil.GenerateUUID(t)
+	pubID := testsutil.GenerateUUID(t)
+
+	width := time.Minute.Nanoseconds()
+	start := (time.Now().Add(-time.Hour).UnixNano() / width) * width
+	v2 := v * 3
+
+	var messages []senml.Message
+	for i := 0; i < valueFields; i++ {
+		ts := float64(start + int64(i)*width)
+		messages = append(messages,
+			senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: ts, Value: &v},
+			senml.Message{Channel: chanID, Publisher: pubID, Protocol: mqttProt, Name: msgName, Time: ts + float64(time.Second), Value: &v2},
+		)
+		// The second channel has messages only in every other bucket.
+		if i%2 == 0 {
+			messages = append(messages, senml.Message{Channel: chanID2, Publisher: pubID, Protocol: mqttProt, Name: "humidity", Time: ts, Value: &v})
+		}
+	}
+
+	err := writer.ConsumeBlocking(context.TODO(), messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+
+	avg := (v + v2) / 2
+	from := float64(start)
+	to := float64(start + valueFields*width)
+
+	cases := []struct {
+		desc    string
+		chanIDs []string
+		pm      readers.SeriesPageMetadata
+		series  []readers.Series
+	}{
+		{
+			desc:    "read series of multiple channels",
+			chanIDs: []string{chanID, chanID2},
+			pm: readers.SeriesPageMetadata{
+				From:        from,
+				To:          to,
+				Aggregation: "AVG",
+				Interval:    "1m",
+			},
+			series: []readers.Series{
+				{Channel: chanID, Name: msgName, Values: []*float64{&avg, &avg, &avg, &avg, &avg}},
+				{Channel: chanID2, Name: "humidity", Values: []*float64{&v, nil, &v, nil, &v}},
+			},
+		},
+		{
+			desc:    "read series of a single measurement",
+			chanIDs: []string{chanID, chanID2},
+			pm: readers.SeriesPageMetadata{
+				Names:       []string{"humidity"},
+				From:        from,
+				To:          to,
+				Aggregation: "MAX",
+				Interval:    "1m",
+			},
+			series: []readers.Series{
+				{Channel: chanID2, Name: "humidity", Values: []*float64{&v, nil, &v, nil, &v}},
+			},
+		},
+		{
+			desc:    "read series of non-existing channel",
+			chanIDs: []string{testsutil.GenerateUUID(t)},
+			pm: readers.SeriesPageMetadata{
+				From:        from,
+				To:          to,
+				Aggregation: "AVG",
+				Interval:    "1m",
+			},
+			series: []readers.Series{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := reader.ReadSeries(context.Background(), tc.chanIDs, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Len(t, page.Times, valueFields, fmt.Sprintf("%s: expected %d buckets got %d", tc.desc, valueFields, len(page.Times)))
+		assert.ElementsMatch(t, tc.series, page.Series, fmt.Sprintf("%s: got incorrect series from ReadSeries()", tc.desc))
+	}
+}
+
 func fromSenml(msg []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range msg {
@@ -0,0 +1,25 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+// Failure represents the message the writer rejected because its payload
+// violates the contract of its channel or can not be transformed. Rejected
+// messages are kept in the quarantine until the writer retention removes them.
+type Failure struct {
+	Created   int64  `json:"created" db:"created"`
+	Subtopic  string `json:"subtopic,omitempty" db:"subtopic"`
+	Publisher string `json:"publisher,omitempty" db:"publisher"`
+	Protocol  string `json:"protocol,omitempty" db:"protocol"`
+	Payload   string `json:"payload" db:"payload"`
+	Reason    string `json:"reason" db:"reason"`
+}
+
+// FailuresPage contains the page of the quarantined messages of the channel,
+// the most recent first.
+type FailuresPage struct {
+	Offset   uint64
+	Limit    uint64
+	Total    uint64
+	Failures []Failure
+}
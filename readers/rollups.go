@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Aggregations which can be derived from the rollups of the values, since the
+// rollups keep the count, sum, sum of squares, minimum, maximum, first and last
+// value of each bucket. Percentiles require the raw values.
+var rollupAggregations = []string{"AVG", "SUM", "COUNT", "MIN", "MAX", "FIRST", "LAST", "RATE", "STDDEV"}
+
+// RollupRange represents the [From, To) time range covered by the stored
+// rollups of the given bucket width. All the values are in nanoseconds.
+type RollupRange struct {
+	Width float64 `db:"width"`
+	From  float64 `db:"from"`
+	To    float64 `db:"to"`
+}
+
+// SelectRollup returns the part of the range of the aggregated query which can
+// be read from the rollups instead of the raw messages. The widest rollup whose
+// width divides the query interval is used, so every rollup bucket falls into
+// a single bucket of the query. The second return value is false if no rollup
+// can be used.
+func SelectRollup(pm PageMetadata, ranges []RollupRange) (RollupRange, bool) {
+	if !slices.Contains(rollupAggregations, strings.ToUpper(pm.Aggregation)) {
+		return RollupRange{}, false
+	}
+	// Rollups hold only the numeric values, so they cannot be filtered.
+	if pm.Value != 0 || pm.BoolValue || pm.StringValue != "" || pm.DataValue != "" {
+		return RollupRange{}, false
+	}
+	interval, err := time.ParseDuration(pm.Interval)
+	if err != nil || interval <= 0 {
+		return RollupRange{}, false
+	}
+
+	var ret RollupRange
+	for _, r := range ranges {
+		if r.Width <= 0 || r.Width <= ret.Width || interval.Nanoseconds()%int64(r.Width) != 0 {
+			continue
+		}
+		from := math.Max(math.Ceil(pm.From/r.Width)*r.Width, r.From)
+		to := r.To
+		if pm.To != 0 {
+			to = math.Min(math.Floor(pm.To/r.Width)*r.Width, r.To)
+		}
+		if from >= to {
+			continue
+		}
+		ret = RollupRange{Width: r.Width, From: from, To: to}
+	}
+
+	return ret, ret.Width > 0
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"math"
+	"time"
+)
+
+// SeriesPageMetadata represents the parameters of the query of several
+// measurements of several channels. The values are aggregated in the time
+// buckets of the given interval, so the series of all the channels and
+// measurements share the same timestamps.
+type SeriesPageMetadata struct {
+	Names       []string `json:"names,omitempty"`
+	Subtopic    string   `json:"subtopic,omitempty"`
+	Publisher   string   `json:"publisher,omitempty"`
+	Protocol    string   `json:"protocol,omitempty"`
+	From        float64  `json:"from"`
+	To          float64  `json:"to"`
+	Aggregation string   `json:"aggregation"`
+	Interval    string   `json:"interval"`
+}
+
+// Series represents the aggregated values of a single measurement of a single
+// channel. The value at the given index belongs to the time bucket at the same
+// index of the series page, and it is nil if there are no messages in the bucket.
+type Series struct {
+	Channel string     `json:"channel"`
+	Name    string     `json:"name"`
+	Values  []*float64 `json:"values"`
+}
+
+// SeriesPage contains the series aligned on the common time buckets.
+type SeriesPage struct {
+	SeriesPageMetadata
+	Times  []float64
+	Series []Series
+}
+
+// SeriesPoint represents the aggregated value of a measurement of a channel
+// in the time bucket starting at the given time.
+type SeriesPoint struct {
+	Time    float64
+	Channel string
+	Name    string
+	Value   *float64
+}
+
+// BucketWidth returns the width of the time bucket in the units of the message
+// time, which is stored in nanoseconds.
+func (pm SeriesPageMetadata) BucketWidth() (int64, error) {
+	interval, err := time.ParseDuration(pm.Interval)
+	if err != nil {
+		return 0, err
+	}
+
+	return interval.Nanoseconds(), nil
+}
+
+// Buckets returns the start times of all the buckets in the [From, To) range.
+// Buckets are aligned to the multiples of their width.
+func (pm SeriesPageMetadata) Buckets(width int64) []float64 {
+	if width <= 0 {
+		return []float64{}
+	}
+	w := float64(width)
+	start := math.Floor(pm.From/w) * w
+	times := []float64{}
+	for i := 0; start+float64(i)*w < pm.To; i++ {
+		times = append(times, start+float64(i)*w)
+	}
+
+	return times
+}
+
+// NewSeriesPage aligns the points on the buckets of the page metadata. Series
+// are created in the order of their first point, and the points outside of
+// the buckets are ignored.
+func NewSeriesPage(pm SeriesPageMetadata, width int64, points []SeriesPoint) SeriesPage {
+	page := SeriesPage{
+		SeriesPageMetadata: pm,
+		Times:              pm.Buckets(width),
+		Series:             []Series{},
+	}
+
+	type key struct{ channel, name string }
+	series := make(map[key]int)
+	for _, p := range points {
+		if len(page.Times) == 0 {
+			break
+		}
+		// Times are large floats, so the bucket is found by its index rather
+		// than by comparing the times.
+		i := int(math.Round((p.Time - page.Times[0]) / float64(width)))
+		if i < 0 || i >= len(page.Times) {
+			continue
+		}
+		k := key{p.Channel, p.Name}
+		s, ok := series[k]
+		if !ok {
+			s = len(page.Series)
+			series[k] = s
+			page.Series = append(page.Series, Series{
+				Channel: p.Channel,
+				Name:    p.Name,
+				Values:  make([]*float64, len(page.Times)),
+			})
+		}
+		page.Series[s].Values[i] = p.Value
+	}
+
+	return page
+}
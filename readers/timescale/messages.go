@@ -4,6 +4,7 @@
 package timescale
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -24,6 +25,9 @@ const (
 	orderByCreated = "created"
 )
 
+// Message time is stored in nanoseconds.
+const timeDivisor = 1000000000
+
 var _ readers.MessageRepository = (*timescaleRepository)(nil)
 
 type timescaleRepository struct {
@@ -38,6 +42,157 @@ func New(db *sqlx.DB) readers.MessageRepository {
 }
 
 func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	r, useRollup, err := tr.rollup(context.Background(), chanID, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	sq := newSelectQuery(&rpm, useRollup)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if useRollup {
+		params = rollupParams(params, r)
+	}
+
+	rows, err := tr.db.NamedQuery(sq.messages, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return readers.MessagesPage{}, nil
+			}
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+
+	if err := scanMessages(rows, sq.isSenml, func(msg readers.Message) error {
+		page.Messages = append(page.Messages, msg)
+		return nil
+	}); err != nil {
+		return readers.MessagesPage{}, err
+	}
+	if sq.keyset {
+		page.NextCursor = readers.NextCursor(rpm, page.Messages)
+	}
+
+	rows, err = tr.db.NamedQuery(sq.total, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return page, err
+		}
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func (tr timescaleRepository) StreamAll(ctx context.Context, chanID string, rpm readers.PageMetadata, fn func(readers.Message) error) error {
+	r, useRollup, err := tr.rollup(ctx, chanID, rpm)
+	if err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	sq := newSelectQuery(&rpm, useRollup)
+	params, err := queryParams(chanID, rpm)
+	if err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if useRollup {
+		params = rollupParams(params, r)
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, sq.messages, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return nil
+			}
+		}
+		return errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	return scanMessages(rows, sq.isSenml, fn)
+}
+
+func (tr timescaleRepository) ReadSeries(ctx context.Context, chanIDs []string, pm readers.SeriesPageMetadata) (readers.SeriesPage, error) {
+	width, err := pm.BucketWidth()
+	if err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	params := map[string]any{
+		"channels":  chanIDs,
+		"names":     pm.Names,
+		"subtopic":  pm.Subtopic,
+		"publisher": pm.Publisher,
+		"protocol":  pm.Protocol,
+		"from":      pm.From,
+		"to":        pm.To,
+		"bucket":    width,
+	}
+
+	q := fmt.Sprintf(`SELECT time_bucket(CAST(:bucket AS BIGINT), time) AS bucket, channel, name, %s AS value
+	FROM %s WHERE %s
+	GROUP BY 1, channel, name
+	ORDER BY 1, channel, name;`, aggregation(pm.Aggregation), defTable, seriesCondition(pm))
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return readers.NewSeriesPage(pm, width, nil), nil
+			}
+		}
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	points := []readers.SeriesPoint{}
+	for rows.Next() {
+		var p readers.SeriesPoint
+		if err := rows.Scan(&p.Time, &p.Channel, &p.Name, &p.Value); err != nil {
+			return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return readers.SeriesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	return readers.NewSeriesPage(pm, width, points), nil
+}
+
+// selectQuery holds the queries of the messages and of their total count.
+// Keyset queries are ordered by the keyset columns, so the next cursor can be
+// taken from the last message.
+type selectQuery struct {
+	messages string
+	total    string
+	isSenml  bool
+	keyset   bool
+}
+
+func newSelectQuery(rpm *readers.PageMetadata, useRollup bool) selectQuery {
 	format := defTable
 
 	if rpm.Format != "" && rpm.Format != defTable {
@@ -47,10 +202,10 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	isSenml := (format == defTable)
 
 	// If aggregation is provided, add time_bucket and aggregation to the query
-	const timeDivisor = 1000000000
 	isAggregated := isSenml && rpm.Aggregation != "" && rpm.Interval != ""
 
-	if rpm.Order == "" {
+	// Cursor is a position in the keyset ordering, so it requires ordering by time.
+	if rpm.Order == "" || (rpm.Cursor != "" && !isAggregated) {
 		switch {
 		case isSenml:
 			rpm.Order = orderByTime
@@ -59,29 +214,37 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		}
 	}
 
-	orderClause := applyOrdering(rpm, isAggregated, isSenml)
+	orderClause := applyOrdering(*rpm, isAggregated, isSenml)
 
 	pgData := ""
 	if rpm.Limit != 0 {
 		pgData = "LIMIT :limit"
 	}
-	if rpm.Offset != 0 {
+	if rpm.Offset != 0 && rpm.Cursor == "" {
 		if pgData != "" {
 			pgData += " "
 		}
 		pgData += "OFFSET :offset"
 	}
 
-	where := fmtCondition(rpm)
+	where := fmtCondition(*rpm)
 
-	var q string
-	totalQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, where)
+	sq := selectQuery{
+		total:   fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, where),
+		isSenml: isSenml,
+		keyset:  !isAggregated && orderClause == keysetOrdering(isSenml, direction(*rpm)),
+	}
 
 	if isAggregated {
-		q = fmt.Sprintf(`
+		var src string
+		agg := aggregation(rpm.Aggregation)
+		if useRollup {
+			src, format, agg = rollupSource(where), "src", rollupAggregation(rpm.Aggregation)
+		}
+		sq.messages = fmt.Sprintf(`%s
 			SELECT
 				EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) *%d AS time,
-				%s(value) AS value,
+				%s AS value,
 				FIRST(publisher, time) AS publisher,
 				FIRST(protocol, time) AS protocol,
 				FIRST(subtopic, time) AS subtopic,
@@ -95,87 +258,196 @@ func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 			%s
 			%s;
 			`,
-			rpm.Interval, timeDivisor, timeDivisor, rpm.Aggregation, format, where, orderClause, pgData)
+			src, rpm.Interval, timeDivisor, timeDivisor, agg, format, where, orderClause, pgData)
+
+		sq.total = fmt.Sprintf(`%sSELECT COUNT(*) FROM (SELECT EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) AS time FROM %s WHERE %s GROUP BY 1) AS subquery;`, src, rpm.Interval, timeDivisor, format, where)
 
-		totalQuery = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT EXTRACT(epoch FROM time_bucket('%s', to_timestamp(time/%d))) AS time, %s(value) AS value FROM %s WHERE %s GROUP BY 1) AS subquery;`, rpm.Interval, timeDivisor, rpm.Aggregation, format, where)
-	} else {
-		q = fmt.Sprintf(`SELECT * FROM %s WHERE %s %s %s;`, format, where, orderClause, pgData)
+		return sq
 	}
 
-	params := map[string]any{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	if sq.keyset && rpm.Cursor != "" {
+		where = withCursor(where, *rpm, isSenml)
 	}
+	sq.messages = fmt.Sprintf(`SELECT * FROM %s WHERE %s %s %s;`, format, where, orderClause, pgData)
 
-	rows, err := tr.db.NamedQuery(q, params)
+	return sq
+}
+
+// rollup returns the range of the rollups of the channel which the aggregated
+// query can read instead of the raw messages.
+func (tr timescaleRepository) rollup(ctx context.Context, chanID string, rpm readers.PageMetadata) (readers.RollupRange, bool, error) {
+	if rpm.Format != "" && rpm.Format != defTable || rpm.Aggregation == "" || rpm.Interval == "" {
+		return readers.RollupRange{}, false, nil
+	}
+	q := `SELECT width, MIN(time) AS "from", MAX(time) + width AS "to"
+	FROM messages_rollups WHERE channel = $1 GROUP BY width;`
+
+	ranges := []readers.RollupRange{}
+	if err := tr.db.SelectContext(ctx, &ranges, q, chanID); err != nil {
+		if preErr, ok := err.(*pgconn.PrepareError); ok {
+			err = preErr.Unwrap()
+		}
+		// Rollups table is created by the writers migrations.
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UndefinedTable {
+			return readers.RollupRange{}, false, nil
+		}
+		return readers.RollupRange{}, false, err
+	}
+	r, ok := readers.SelectRollup(rpm, ranges)
+
+	return r, ok, nil
+}
+
+// rollupSource returns the common table expression of the rollups in their
+// range and the raw messages outside of it. Raw messages are read as the
+// rollups of a single value, so both are aggregated the same way.
+func rollupSource(cond string) string {
+	return fmt.Sprintf(`WITH src AS (
+		SELECT CAST(time AS BIGINT) AS time, channel, publisher, protocol, subtopic, name, unit, count, sum, sum_sq,
+			min, max, first_time, first_value, last_time, last_value
+		FROM messages_rollups
+		WHERE %s AND width = :rollup_width AND time >= :rollup_from AND time < :rollup_to
+		UNION ALL
+		SELECT time, channel, publisher, protocol, subtopic, name, unit, 1, value, value * value, value, value,
+			time, value, time, value
+		FROM %s
+		WHERE %s AND value IS NOT NULL AND (time < :rollup_from OR time >= :rollup_to)
+	) `, cond, defTable, cond)
+}
+
+func rollupParams(params map[string]any, r readers.RollupRange) map[string]any {
+	params["rollup_width"] = r.Width
+	params["rollup_from"] = r.From
+	params["rollup_to"] = r.To
+
+	return params
+}
+
+func (tr timescaleRepository) ReadFailures(ctx context.Context, chanID string, offset, limit uint64) (readers.FailuresPage, error) {
+	params := map[string]any{
+		"channel": chanID,
+		"offset":  offset,
+		"limit":   limit,
+	}
+	q := `SELECT created, COALESCE(subtopic, '') AS subtopic, COALESCE(publisher, '') AS publisher,
+	COALESCE(protocol, '') AS protocol, COALESCE(payload, ''::bytea) AS payload, COALESCE(reason, '') AS reason
+	FROM messages_quarantine WHERE channel = :channel
+	ORDER BY created DESC, id DESC LIMIT :limit OFFSET :offset;`
+
+	page := readers.FailuresPage{
+		Offset:   offset,
+		Limit:    limit,
+		Failures: []readers.Failure{},
+	}
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
 		if preErr, ok := err.(*pgconn.PrepareError); ok {
 			err = preErr.Unwrap()
 		}
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			if pgErr.Code == pgerrcode.UndefinedTable {
-				return readers.MessagesPage{}, nil
+				return page, nil
 			}
 		}
-		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
 	defer rows.Close()
 
-	page := readers.MessagesPage{
-		PageMetadata: rpm,
-		Messages:     []readers.Message{},
+	for rows.Next() {
+		var f readers.Failure
+		var payload []byte
+		if err := rows.Scan(&f.Created, &f.Subtopic, &f.Publisher, &f.Protocol, &payload, &f.Reason); err != nil {
+			return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		f.Payload = string(payload)
+		page.Failures = append(page.Failures, f)
+	}
+	if err := rows.Err(); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
 	}
 
-	switch format {
-	case defTable:
-		for rows.Next() {
-			msg := senmlMessage{Message: senml.Message{}}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
-			}
+	totalQuery := `SELECT COUNT(*) FROM messages_quarantine WHERE channel = $1;`
+	if err := tr.db.GetContext(ctx, &page.Total, totalQuery, chanID); err != nil {
+		return readers.FailuresPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
 
-			page.Messages = append(page.Messages, msg.Message)
-		}
-	default:
-		for rows.Next() {
-			msg := jsonMessage{}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	return page, nil
+}
+
+func scanMessages(rows *sqlx.Rows, isSenml bool, fn func(readers.Message) error) error {
+	for rows.Next() {
+		var msg readers.Message
+		if isSenml {
+			sm := senmlMessage{Message: senml.Message{}}
+			if err := rows.StructScan(&sm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
 			}
-			m, err := msg.toMap()
+			msg = sm.Message
+		} else {
+			jm := jsonMessage{}
+			if err := rows.StructScan(&jm); err != nil {
+				return errors.Wrap(readers.ErrReadMessages, err)
+			}
+			m, err := jm.toMap()
 			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+				return errors.Wrap(readers.ErrReadMessages, err)
 			}
-			page.Messages = append(page.Messages, m)
+			msg = m
+		}
+		if err := fn(msg); err != nil {
+			return err
 		}
 	}
-
-	rows, err = tr.db.NamedQuery(totalQuery, params)
-	if err != nil {
-		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(readers.ErrReadMessages, err)
 	}
-	defer rows.Close()
 
-	total := uint64(0)
-	if rows.Next() {
-		if err := rows.Scan(&total); err != nil {
-			return page, err
+	return nil
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) (map[string]any, error) {
+	params := map[string]any{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+	if rpm.Cursor != "" {
+		c, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return nil, err
 		}
+		params["cursor_time"] = int64(c.Time)
+		params["cursor_created"] = c.Created
+		params["cursor_publisher"] = c.Publisher
+		params["cursor_subtopic"] = c.Subtopic
+		params["cursor_name"] = c.Name
+		params["cursor_protocol"] = c.Protocol
 	}
-	page.Total = total
 
-	return page, nil
+	return params, nil
+}
+
+func withCursor(cond string, rpm readers.PageMetadata, isSenml bool) string {
+	op := "<"
+	if direction(rpm) == api.AscDir {
+		op = ">"
+	}
+	if isSenml {
+		return fmt.Sprintf(`%s AND (time, publisher, subtopic, name, protocol) %s
+	(:cursor_time, :cursor_publisher, :cursor_subtopic, :cursor_name, :cursor_protocol)`, cond, op)
+	}
+
+	return fmt.Sprintf(`%s AND (created, publisher, subtopic) %s (:cursor_created, :cursor_publisher, :cursor_subtopic)`, cond, op)
 }
 
 func fmtCondition(rpm readers.PageMetadata) string {
@@ -248,6 +520,76 @@ func fmtCondition(rpm readers.PageMetadata) string {
 	return strings.Join(conditions, " AND ")
 }
 
+// aggregation returns the expression of the value aggregated in a time bucket.
+func aggregation(agg string) string {
+	switch strings.ToUpper(agg) {
+	case "P50":
+		return "percentile_cont(0.5) WITHIN GROUP (ORDER BY value)"
+	case "P95":
+		return "percentile_cont(0.95) WITHIN GROUP (ORDER BY value)"
+	case "P99":
+		return "percentile_cont(0.99) WITHIN GROUP (ORDER BY value)"
+	case "FIRST":
+		return "FIRST(value, time)"
+	case "LAST":
+		return "LAST(value, time)"
+	case "STDDEV":
+		return "stddev_samp(value)"
+	case "RATE":
+		// Rate of change per second between the first and the last value of
+		// the bucket. Buckets with a single message have no rate.
+		return fmt.Sprintf("(LAST(value, time) - FIRST(value, time)) / NULLIF(MAX(time) - MIN(time), 0) * %d", timeDivisor)
+	default:
+		return fmt.Sprintf("%s(value)", agg)
+	}
+}
+
+// rollupAggregation returns the expression of the value aggregated in a time
+// bucket from the rollups of the values.
+func rollupAggregation(agg string) string {
+	switch strings.ToUpper(agg) {
+	case "AVG":
+		return "SUM(sum) / NULLIF(SUM(count), 0)"
+	case "SUM":
+		return "SUM(sum)"
+	case "COUNT":
+		return "CAST(SUM(count) AS FLOAT)"
+	case "MIN":
+		return "MIN(min)"
+	case "MAX":
+		return "MAX(max)"
+	case "FIRST":
+		return "FIRST(first_value, first_time)"
+	case "LAST":
+		return "LAST(last_value, last_time)"
+	case "STDDEV":
+		return "sqrt(GREATEST((SUM(sum_sq) - SUM(sum) * SUM(sum) / SUM(count)) / NULLIF(SUM(count) - 1, 0), 0))"
+	case "RATE":
+		return fmt.Sprintf("(LAST(last_value, last_time) - FIRST(first_value, first_time)) / NULLIF(MAX(last_time) - MIN(first_time), 0) * %d", timeDivisor)
+	default:
+		return aggregation(agg)
+	}
+}
+
+func seriesCondition(pm readers.SeriesPageMetadata) string {
+	conditions := []string{"channel = ANY(:channels)"}
+	if pm.Subtopic != "" {
+		conditions = append(conditions, "subtopic = :subtopic")
+	}
+	if pm.Publisher != "" {
+		conditions = append(conditions, "publisher = :publisher")
+	}
+	if len(pm.Names) > 0 {
+		conditions = append(conditions, "name = ANY(:names)")
+	}
+	conditions = append(conditions, "time >= :from", "time < :to")
+	if pm.Protocol != "" {
+		conditions = append(conditions, "protocol = :protocol")
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
@@ -285,10 +627,7 @@ func applyOrdering(pm readers.PageMetadata, isAggregated bool, isSenml bool) str
 		timeCol = orderByCreated
 	}
 
-	dir := pm.Dir
-	if dir != api.AscDir && dir != api.DescDir {
-		dir = api.DescDir
-	}
+	dir := direction(pm)
 
 	aggCols := map[string]bool{
 		orderByTime: true,
@@ -346,7 +685,25 @@ func applyOrdering(pm readers.PageMetadata, isAggregated bool, isSenml bool) str
 	secondary := fmt.Sprintf("%s DESC", timeCol)
 
 	if col == timeCol {
-		return fmt.Sprintf("ORDER BY %s %s", col, dir)
+		return keysetOrdering(isSenml, dir)
 	}
 	return fmt.Sprintf("ORDER BY %s %s, %s", col, dir, secondary)
 }
+
+// keysetOrdering orders the messages by the primary key columns, so that
+// the ordering is total and the cursor can point to any message.
+func keysetOrdering(isSenml bool, dir string) string {
+	if isSenml {
+		return fmt.Sprintf("ORDER BY time %[1]s, publisher %[1]s, subtopic %[1]s, name %[1]s, protocol %[1]s", dir)
+	}
+
+	return fmt.Sprintf("ORDER BY created %[1]s, publisher %[1]s, subtopic %[1]s", dir)
+}
+
+func direction(pm readers.PageMetadata) string {
+	if pm.Dir != api.AscDir && pm.Dir != api.DescDir {
+		return api.DescDir
+	}
+
+	return pm.Dir
+}
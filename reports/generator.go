@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
@@ -22,6 +23,7 @@ import (
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	pkglog "github.com/absmach/supermq/pkg/logger"
 	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/xuri/excelize/v2"
 )
 
 const nanosecondThreshold = float64(10 * time.Second / time.Nanosecond)
@@ -35,6 +37,22 @@ type ReportData struct {
 }
 
 func (r *report) generatePDFReport(ctx context.Context, title string, reports []Report, template ReportTemplate, timezone string) ([]byte, error) {
+	htmlContent, err := r.generateHTMLReport(ctx, title, reports, template, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	pdfBytes, err := r.htmlToPDF(ctx, string(htmlContent))
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return pdfBytes, nil
+}
+
+// generateHTMLReport renders the reports using the report template, or the
+// default template if the report template is not set.
+func (r *report) generateHTMLReport(_ context.Context, title string, reports []Report, template ReportTemplate, timezone string) ([]byte, error) {
 	for i := range reports {
 		sort.Slice(reports[i].Messages, func(j, k int) bool {
 			return reports[i].Messages[j].Time < reports[i].Messages[k].Time
@@ -71,10 +89,10 @@ func (r *report) generatePDFReport(ctx context.Context, title string, reports []
 	if template.String() != "" {
 		templateContent = template.String()
 	}
-	return r.generate(ctx, templateContent, data)
+	return r.render(templateContent, data)
 }
 
-func (r *report) generate(ctx context.Context, templateContent string, data ReportData) ([]byte, error) {
+func (r *report) render(templateContent string, data ReportData) ([]byte, error) {
 	tmpl := template.New("report").Funcs(template.FuncMap{
 		"formatTime":  func(t float64) string { return r.formatTimeWithTimezone(t, data.Timezone) },
 		"formatValue": formatValue,
@@ -110,13 +128,7 @@ func (r *report) generate(ctx context.Context, templateContent string, data Repo
 		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
 
-	htmlContent := htmlBuf.String()
-	pdfBytes, err := r.htmlToPDF(ctx, htmlContent)
-	if err != nil {
-		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
-	}
-
-	return pdfBytes, nil
+	return htmlBuf.Bytes(), nil
 }
 
 func (r *report) htmlToPDF(ctx context.Context, htmlContent string) ([]byte, error) {
@@ -340,3 +352,166 @@ func (r *report) generateCSVReport(_ context.Context, title string, reports []Re
 
 	return buf.Bytes(), nil
 }
+
+// xlsxSheetNameLimit is the maximum length of the worksheet name.
+const xlsxSheetNameLimit = 31
+
+var xlsxSheetNameReplacer = strings.NewReplacer(":", " ", "\\", " ", "/", " ", "?", " ", "*", " ", "[", "(", "]", ")")
+
+// generateXLSXReport writes each report, that is one metric of one client,
+// to its own worksheet of the workbook.
+func (r *report) generateXLSXReport(_ context.Context, title string, reports []Report, timezone string) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	defaultSheet := f.GetSheetName(0)
+	if len(reports) == 0 {
+		if err := f.SetSheetName(defaultSheet, "Report"); err != nil {
+			return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		if err := f.SetCellStr("Report", "A1", title); err != nil {
+			return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+
+	used := map[string]bool{}
+	for i, report := range reports {
+		sheet := xlsxSheetName(report.Metric, used)
+		switch i {
+		case 0:
+			err = f.SetSheetName(defaultSheet, sheet)
+		default:
+			_, err = f.NewSheet(sheet)
+		}
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+
+		if err := r.writeXLSXSheet(f, sheet, bold, title, report, timezone); err != nil {
+			return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *report) writeXLSXSheet(f *excelize.File, sheet string, style int, title string, report Report, timezone string) error {
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	if err := sw.SetColWidth(1, 1, 22); err != nil {
+		return err
+	}
+	if err := sw.SetColWidth(2, 5, 16); err != nil {
+		return err
+	}
+
+	rows := [][]any{
+		{excelize.Cell{StyleID: style, Value: title}},
+		{},
+		{"Name", report.Metric.Name},
+	}
+	if report.Metric.ClientID != "" {
+		rows = append(rows, []any{"Device ID", report.Metric.ClientID})
+	}
+	rows = append(rows, []any{"Channel ID", report.Metric.ChannelID}, []any{})
+
+	var headers []any
+	for _, h := range []string{"Time", "Value", "Unit", "Protocol", "Subtopic"} {
+		headers = append(headers, excelize.Cell{StyleID: style, Value: h})
+	}
+	rows = append(rows, headers)
+
+	sort.Slice(report.Messages, func(i, j int) bool {
+		return report.Messages[i].Time < report.Messages[j].Time
+	})
+	for _, msg := range report.Messages {
+		var value any = formatValue(msg)
+		if msg.Value != nil {
+			value = *msg.Value
+		}
+		rows = append(rows, []any{r.formatTimeWithTimezone(msg.Time, timezone), value, msg.Unit, msg.Protocol, msg.Subtopic})
+	}
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, row); err != nil {
+			return err
+		}
+	}
+
+	return sw.Flush()
+}
+
+// xlsxSheetName returns the unique worksheet name for the metric, stripped of
+// the characters not allowed in the worksheet names.
+func xlsxSheetName(metric Metric, used map[string]bool) string {
+	base := strings.TrimSpace(xlsxSheetNameReplacer.Replace(metric.Name))
+	if base == "" {
+		base = "Report"
+	}
+	base = truncate(base, xlsxSheetNameLimit)
+
+	name := base
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		name = truncate(base, xlsxSheetNameLimit-len(suffix)) + suffix
+	}
+	used[strings.ToLower(name)] = true
+
+	return name
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+type jsonReport struct {
+	Title       string   `json:"title"`
+	GeneratedAt string   `json:"generated_at"`
+	Timezone    string   `json:"timezone"`
+	Reports     []Report `json:"reports"`
+}
+
+func (r *report) generateJSONReport(_ context.Context, title string, reports []Report, timezone string) ([]byte, error) {
+	for i := range reports {
+		sort.Slice(reports[i].Messages, func(j, k int) bool {
+			return reports[i].Messages[j].Time < reports[i].Messages[k].Time
+		})
+	}
+	if reports == nil {
+		reports = []Report{}
+	}
+	if strings.TrimSpace(timezone) == "" {
+		timezone = "UTC"
+	}
+
+	data, err := json.Marshal(jsonReport{
+		Title:       title,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Timezone:    timezone,
+		Reports:     reports,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return data, nil
+}
@@ -179,6 +179,9 @@ type EmailSetting struct {
 	To      []string `json:"to,omitempty"`
 	Subject string   `json:"subject,omitempty"`
 	Content string   `json:"content,omitempty"`
+	// Inline renders the report from the report template as the HTML body
+	// of the email, in addition to the report file attachment.
+	Inline bool `json:"inline,omitempty"`
 }
 
 func (es *EmailSetting) Validate() error {
@@ -198,12 +201,18 @@ type Format uint8
 const (
 	PDF = iota
 	CSV
+	XLSX
+	JSON
+	HTML
 	AllFormats
 )
 
 const (
 	PdfFormat   = "pdf"
 	CsvFormat   = "csv"
+	XlsxFormat  = "xlsx"
+	JsonFormat  = "json"
+	HtmlFormat  = "html"
 	All_Formats = "AllFormats"
 )
 
@@ -213,6 +222,12 @@ func (f Format) String() string {
 		return PdfFormat
 	case CSV:
 		return CsvFormat
+	case XLSX:
+		return XlsxFormat
+	case JSON:
+		return JsonFormat
+	case HTML:
+		return HtmlFormat
 	case AllFormats:
 		return All_Formats
 	default:
@@ -226,6 +241,12 @@ func (f Format) Extension() string {
 		return PdfFormat
 	case CSV:
 		return CsvFormat
+	case XLSX:
+		return XlsxFormat
+	case JSON:
+		return JsonFormat
+	case HTML:
+		return HtmlFormat
 	default:
 		return Unknown
 	}
@@ -237,6 +258,12 @@ func (f Format) ContentType() string {
 		return "application/pdf"
 	case CSV:
 		return "text/csv"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case JSON:
+		return "application/json"
+	case HTML:
+		return "text/html"
 	default:
 		return Unknown
 	}
@@ -248,6 +275,12 @@ func ToFormat(format string) (Format, error) {
 		return PDF, nil
 	case CsvFormat:
 		return CSV, nil
+	case XlsxFormat:
+		return XLSX, nil
+	case JsonFormat:
+		return JSON, nil
+	case HtmlFormat:
+		return HTML, nil
 	case All_Formats:
 		return AllFormats, nil
 	}
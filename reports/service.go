@@ -6,6 +6,7 @@ package reports
 import (
 	"context"
 	"fmt"
+	"html"
 	"log/slog"
 	"strings"
 	"time"
@@ -375,7 +376,7 @@ func (r *report) generateReport(ctx context.Context, cfg ReportConfig, action Re
 
 		switch action {
 		case EmailReport:
-			if err := r.emailReports(*cfg.Email, file); err != nil {
+			if err := r.emailReports(ctx, cfg, reports, file); err != nil {
 				return ReportPage{}, errors.Wrap(err, svcerr.ErrCreateEntity)
 			}
 
@@ -409,6 +410,18 @@ func (r *report) generateFileFunc(_ context.Context, action ReportAction, format
 			return func(ctx context.Context, title string, reports []Report) ([]byte, error) {
 				return r.generateCSVReport(ctx, title, reports, timezone)
 			}, nil
+		case XLSX:
+			return func(ctx context.Context, title string, reports []Report) ([]byte, error) {
+				return r.generateXLSXReport(ctx, title, reports, timezone)
+			}, nil
+		case JSON:
+			return func(ctx context.Context, title string, reports []Report) ([]byte, error) {
+				return r.generateJSONReport(ctx, title, reports, timezone)
+			}, nil
+		case HTML:
+			return func(ctx context.Context, title string, reports []Report) ([]byte, error) {
+				return r.generateHTMLReport(ctx, title, reports, customTemplate, timezone)
+			}, nil
 		default:
 			return nil, errors.New("file format not supported")
 		}
@@ -417,22 +430,45 @@ func (r *report) generateFileFunc(_ context.Context, action ReportAction, format
 	}
 }
 
-func (r *report) emailReports(es EmailSetting, file ReportFile) error {
+// emailReports sends the report file as the attachment. If the inline email
+// setting is set or the file is HTML, the report rendered from the report
+// template is sent as the email body. HTML files are not attached.
+func (r *report) emailReports(ctx context.Context, cfg ReportConfig, reports []Report, file ReportFile) error {
+	es := *cfg.Email
 	if err := es.Validate(); err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
 
+	content := es.Content
 	attachments := map[string][]byte{
 		file.Name: file.Data,
 	}
 
+	if es.Inline || file.Format == HTML {
+		body := file.Data
+		switch file.Format {
+		case HTML:
+			delete(attachments, file.Name)
+		default:
+			var err error
+			body, err = r.generateHTMLReport(ctx, cfg.Config.Title, reports, cfg.ReportTemplate, cfg.Config.Timezone)
+			if err != nil {
+				return err
+			}
+		}
+		content = string(body)
+		if es.Content != "" {
+			content = fmt.Sprintf("<p>%s</p>\n%s", html.EscapeString(es.Content), body)
+		}
+	}
+
 	if err := r.email.SendEmailNotification(
 		es.To,
 		"",
 		es.Subject,
 		"",
 		"",
-		es.Content,
+		content,
 		"",
 		attachments,
 	); err != nil {
@@ -4,12 +4,16 @@
 package reports_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/0x6flab/namegenerator"
+	grpcReadersV1 "github.com/absmach/supermq/api/grpc/readers/v1"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	emocks "github.com/absmach/supermq/pkg/emailer/mocks"
@@ -27,6 +31,8 @@ import (
 	"github.com/absmach/supermq/reports/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 )
 
 var (
@@ -700,3 +706,143 @@ func TestGenerateInstantEmailReport(t *testing.T) {
 		})
 	}
 }
+
+func newReportService(t *testing.T) (reports.Service, *readmocks.ReadersServiceClient, *emocks.Emailer) {
+	readersSvc := new(readmocks.ReadersServiceClient)
+	e := new(emocks.Emailer)
+	availableActions := []roles.Action{}
+	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
+		"admin": availableActions,
+	}
+
+	svc, err := reports.NewService(new(mocks.Repository), make(chan pkglog.RunInfo, 10), new(policymocks.Service), uuid.NewMock(), new(tmocks.Ticker), e, readersSvc, reportTemplate, "", availableActions, builtInRoles)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return svc, readersSvc, e
+}
+
+const reportTemplate = reports.ReportTemplate(`<html><body><h1>{{$.Title}}</h1>{{range .Reports}}<h2>{{.Metric.Name}}</h2>{{range .Messages}}<p>{{formatTime .Time}} {{formatValue .}}</p>{{end}}{{end}}</body></html>`)
+
+func TestGenerateReportFormats(t *testing.T) {
+	svc, readersSvc, e := newReportService(t)
+
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	channelID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+	value := 21.5
+	state := "on"
+	msgs := &grpcReadersV1.ReadMessagesRes{
+		Total: 2,
+		Messages: []*grpcReadersV1.Message{
+			{Payload: &grpcReadersV1.Message_Senml{Senml: &grpcReadersV1.SenMLMessage{
+				Base: &grpcReadersV1.BaseMessage{Channel: channelID, Publisher: clientID, Protocol: "mqtt"},
+				Name: "temperature", Unit: "C", Time: 1700000060, Value: &value,
+			}}},
+			{Payload: &grpcReadersV1.Message_Senml{Senml: &grpcReadersV1.SenMLMessage{
+				Base:        &grpcReadersV1.BaseMessage{Channel: channelID, Publisher: clientID, Protocol: "mqtt"},
+				Name:        "temperature",
+				Time:        1700000000,
+				StringValue: &state,
+			}}},
+		},
+	}
+	readersCall := readersSvc.On("ReadMessages", mock.Anything, mock.Anything).Return(msgs, nil)
+	defer readersCall.Unset()
+
+	newConfig := func(format reports.Format, inline bool) reports.ReportConfig {
+		return reports.ReportConfig{
+			Name:     "formats",
+			DomainID: domainID,
+			Email: &reports.EmailSetting{
+				To:      []string{"test@example.com"},
+				Subject: "Report",
+				Content: "Daily <report>",
+				Inline:  inline,
+			},
+			Config: &reports.MetricConfig{
+				Title:      "Formats Report",
+				FileFormat: format,
+				From:       "now()-1h",
+				To:         "now()",
+			},
+			Metrics: []reports.ReqMetric{
+				{ChannelID: channelID, Name: "temperature", ClientIDs: []string{clientID}},
+				{ChannelID: channelID, Name: "temperature", ClientIDs: []string{testsutil.GenerateUUID(t)}},
+			},
+			ReportTemplate: reportTemplate,
+		}
+	}
+
+	t.Run("download xlsx report with a sheet per metric", func(t *testing.T) {
+		page, err := svc.GenerateReport(context.Background(), session, newConfig(reports.XLSX, false), reports.DownloadReport)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, reports.Format(reports.XLSX), page.File.Format)
+		assert.True(t, strings.HasSuffix(page.File.Name, ".xlsx"))
+
+		f, err := excelize.OpenReader(bytes.NewReader(page.File.Data))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		defer f.Close()
+		assert.Equal(t, []string{"temperature", "temperature (2)"}, f.GetSheetList())
+
+		rows, err := f.GetRows("temperature")
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, "Formats Report", rows[0][0])
+		assert.Equal(t, []string{"Time", "Value", "Unit", "Protocol", "Subtopic"}, rows[6])
+		assert.Equal(t, "on", rows[7][1])
+		assert.Equal(t, "21.5", rows[8][1])
+		assert.Equal(t, "C", rows[8][2])
+	})
+
+	t.Run("download json report", func(t *testing.T) {
+		page, err := svc.GenerateReport(context.Background(), session, newConfig(reports.JSON, false), reports.DownloadReport)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, "application/json", page.File.Format.ContentType())
+
+		var got struct {
+			Title    string           `json:"title"`
+			Timezone string           `json:"timezone"`
+			Reports  []reports.Report `json:"reports"`
+		}
+		require.Nil(t, json.Unmarshal(page.File.Data, &got))
+		assert.Equal(t, "Formats Report", got.Title)
+		assert.Equal(t, "UTC", got.Timezone)
+		require.Len(t, got.Reports, 2)
+		require.Len(t, got.Reports[0].Messages, 2)
+		assert.Equal(t, float64(1700000000), got.Reports[0].Messages[0].Time)
+	})
+
+	t.Run("download html report rendered from template", func(t *testing.T) {
+		page, err := svc.GenerateReport(context.Background(), session, newConfig(reports.HTML, false), reports.DownloadReport)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, "text/html", page.File.Format.ContentType())
+		assert.Contains(t, string(page.File.Data), "<h1>Formats Report</h1>")
+		assert.Contains(t, string(page.File.Data), "21.50")
+	})
+
+	t.Run("email html report inline without attachment", func(t *testing.T) {
+		emailCall := e.On("SendEmailNotification", []string{"test@example.com"}, "", "Report", "", "", mock.Anything, "", mock.Anything).Return(nil)
+		defer emailCall.Unset()
+
+		_, err := svc.GenerateReport(context.Background(), session, newConfig(reports.HTML, false), reports.EmailReport)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		e.AssertCalled(t, "SendEmailNotification", []string{"test@example.com"}, "", "Report", "", "", mock.MatchedBy(func(content string) bool {
+			return strings.HasPrefix(content, "<p>Daily &lt;report&gt;</p>") && strings.Contains(content, "<h1>Formats Report</h1>")
+		}), "", map[string][]byte{})
+	})
+
+	t.Run("email csv report inline with attachment", func(t *testing.T) {
+		emailCall := e.On("SendEmailNotification", []string{"test@example.com"}, "", "Report", "", "", mock.Anything, "", mock.Anything).Return(nil)
+		defer emailCall.Unset()
+
+		_, err := svc.GenerateReport(context.Background(), session, newConfig(reports.CSV, true), reports.EmailReport)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		e.AssertCalled(t, "SendEmailNotification", []string{"test@example.com"}, "", "Report", "", "", mock.MatchedBy(func(content string) bool {
+			return strings.Contains(content, "<h1>Formats Report</h1>")
+		}), "", mock.MatchedBy(func(attachments map[string][]byte) bool {
+			for name := range attachments {
+				return len(attachments) == 1 && strings.HasSuffix(name, ".csv")
+			}
+			return false
+		}))
+	})
+}